@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"spend-api/internal/domain/auth"
+	"strings"
+)
+
+const apiKeysUsage = "usage: apikeys create SUBJECT NAME|revoke SUBJECT ID"
+
+// apiKeyManager is what the apikeys subcommands need of the API key service
+type apiKeyManager interface {
+	auth.ForCreatingAPIKeyForSubject
+	auth.ForRevokingAPIKey
+}
+
+// runAPIKeys runs one of the apikeys subcommands on behalf of the user with
+// the given subject. Creating a key from the command line is how the first
+// key of a tenant is issued, before any caller can authenticate to create
+// one through the API.
+func runAPIKeys(ctx context.Context, service apiKeyManager, users auth.ForLoadingUsers, args []string, out io.Writer) error {
+	if len(args) < 3 {
+		return errors.New(apiKeysUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		issued, err := service.CreateAPIKeyForSubject(ctx, args[1], strings.Join(args[2:], " "))
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "created API key %s (%s)\n%s\n", issued.ID, issued.Name, issued.Key)
+		return nil
+	case "revoke":
+		user, err := users.LoadUserBySubject(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: user.Subject, UserID: user.ID, TenantID: user.TenantID})
+		if err := service.RevokeAPIKey(ctx, args[2]); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "revoked API key %s\n", args[2])
+		return nil
+	default:
+		return errors.New(apiKeysUsage)
+	}
+}
@@ -1,14 +1,20 @@
 package main
 
 import (
+	"context"
 	"github.com/joho/godotenv"
 	"log"
 	"net/http"
-	dbAccounts "spend-api/internal/app/adapters/db/accounts"
-	restAccounts "spend-api/internal/app/adapters/rest/accounts"
+	"os"
+	dbAuth "spend-api/internal/app/adapters/db/auth"
+	dbIdempotency "spend-api/internal/app/adapters/db/idempotency"
+	fileAuth "spend-api/internal/app/adapters/file/auth"
+	restAuth "spend-api/internal/app/adapters/rest/auth"
 	"spend-api/internal/config"
-	domainAccounts "spend-api/internal/domain/accounts"
+	domainAuth "spend-api/internal/domain/auth"
+	domainIdempotency "spend-api/internal/domain/idempotency"
 	"spend-api/internal/infra/db"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql" // Import MySQL driver
 )
@@ -31,15 +37,71 @@ func main() {
 		_ = executor.Close()
 	}(executor)
 
-	accountDbAdapter := dbAccounts.NewForSavingAccountUsingDB(executor)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), executor, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("migrate failed: %v", err)
+		}
+		return
+	}
+
+	tenantDbAdapter := dbAuth.NewForSavingTenantUsingDB(executor)
+	userDbAdapter := dbAuth.NewForSavingUserUsingDB(executor)
+	userLoaderDbAdapter := dbAuth.NewForLoadingUsersUsingDB(executor)
+	verificationKeyAdapter := fileAuth.NewForLoadingVerificationKeysUsingFiles(cfg.JWTSecret, cfg.JWTPublicKeyPath, cfg.JWKSPath)
+	tokenService := domainAuth.NewTokenService(verificationKeyAdapter, userLoaderDbAdapter, cfg.JWTIssuer, cfg.JWTAudience, domainAuth.DefaultLeeway)
+	userService := domainAuth.NewUserService(tenantDbAdapter, userDbAdapter, userLoaderDbAdapter, tokenService, executor)
+
+	if len(os.Args) > 1 && os.Args[1] == "tenants" {
+		if err := runTenants(context.Background(), userService, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("tenants failed: %v", err)
+		}
+		return
+	}
 
-	accountService := domainAccounts.NewAccountService(accountDbAdapter)
+	apiKeyDbAdapter := dbAuth.NewForSavingAPIKeyUsingDB(executor)
+	apiKeyLoaderDbAdapter := dbAuth.NewForLoadingAPIKeysUsingDB(executor)
+	apiKeyModifierDbAdapter := dbAuth.NewForModifyingAPIKeyUsingDB(executor)
+	apiKeyService := domainAuth.NewAPIKeyService(apiKeyDbAdapter, apiKeyLoaderDbAdapter, apiKeyModifierDbAdapter, userLoaderDbAdapter, executor)
 
-	accountAPIHandler := restAccounts.NewForCreatingAccountUsingRestAPI(accountService)
+	if len(os.Args) > 1 && os.Args[1] == "apikeys" {
+		if err := runAPIKeys(context.Background(), apiKeyService, userLoaderDbAdapter, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("apikeys failed: %v", err)
+		}
+		return
+	}
+
+	// Verification keys are loaded once up front so a misconfigured key stops
+	// the server from starting rather than failing every request
+	if _, err := verificationKeyAdapter.LoadVerificationKeys(context.Background()); err != nil {
+		log.Fatalf("failed to load JWT verification keys: %v", err)
+	}
+
+	idempotencyDbAdapter := dbIdempotency.NewForSavingRecordUsingDB(executor)
+	idempotencyLoaderDbAdapter := dbIdempotency.NewForLoadingRecordUsingDB(executor)
+	idempotencyCompleterDbAdapter := dbIdempotency.NewForCompletingRecordUsingDB(executor)
+	idempotencyRemoverDbAdapter := dbIdempotency.NewForRemovingRecordsUsingDB(executor)
+	idempotencyService := domainIdempotency.NewIdempotencyService(idempotencyDbAdapter, idempotencyLoaderDbAdapter, idempotencyCompleterDbAdapter, idempotencyRemoverDbAdapter, domainIdempotency.DefaultTTL)
+	go purgeIdempotencyRecords(idempotencyService, time.Hour)
+
+	mux := newRouter(executor, apiKeyService, userService, idempotencyService)
+
+	// Every route requires authentication; the principal is passed on to the
+	// handlers, and from them to the services, in the request context
+	api := restAuth.NewForAuthenticatingRequestsUsingRestAPI(apiKeyService, tokenService, mux)
 
-	http.Handle("/accounts", accountAPIHandler)
 	log.Println("Server running on :8080")
-	if err := http.ListenAndServe(":8080", nil); err != nil {
+	if err := http.ListenAndServe(":8080", api); err != nil {
 		log.Fatalf("failed to start server: %v", err)
 	}
 }
+
+// purgeIdempotencyRecords removes expired idempotency records every interval
+func purgeIdempotencyRecords(service *domainIdempotency.IdempotencyService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if _, err := service.PurgeExpired(context.Background()); err != nil {
+			log.Printf("failed to purge idempotency records: %v", err)
+		}
+	}
+}
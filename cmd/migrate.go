@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"spend-api/internal/infra/db"
+	"time"
+)
+
+const migrateUsage = "usage: migrate up|down|status|unlock"
+
+// runMigrate runs one of the migrate subcommands against the database
+func runMigrate(ctx context.Context, executor db.Executor, args []string, out io.Writer) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := db.NewMigrator(executor, db.Migrations)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			_, _ = fmt.Fprintf(out, "applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			_, _ = fmt.Fprintln(out, "schema is up to date")
+		}
+		return err
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "reverted %04d_%s\n", reverted.Version, reverted.Name)
+		return nil
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = "applied " + status.AppliedAt.Format(time.RFC3339)
+			}
+			name := status.Name
+			if name == "" {
+				name = "(missing file)"
+			}
+			_, _ = fmt.Fprintf(out, "%04d\t%s\t%s\n", status.Version, name, applied)
+		}
+		return nil
+	case "unlock":
+		if err := migrator.Unlock(ctx); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, "migration lock released")
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	dbAccess "spend-api/internal/app/adapters/db/access"
+	dbAccounts "spend-api/internal/app/adapters/db/accounts"
+	dbBudgets "spend-api/internal/app/adapters/db/budgets"
+	dbCategories "spend-api/internal/app/adapters/db/categories"
+	dbExchangeRates "spend-api/internal/app/adapters/db/exchangerates"
+	dbImports "spend-api/internal/app/adapters/db/imports"
+	dbReports "spend-api/internal/app/adapters/db/reports"
+	dbTransactions "spend-api/internal/app/adapters/db/transactions"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	restAccounts "spend-api/internal/app/adapters/rest/accounts"
+	restAuth "spend-api/internal/app/adapters/rest/auth"
+	restBudgets "spend-api/internal/app/adapters/rest/budgets"
+	restCategories "spend-api/internal/app/adapters/rest/categories"
+	restExchangeRates "spend-api/internal/app/adapters/rest/exchangerates"
+	restIdempotency "spend-api/internal/app/adapters/rest/idempotency"
+	restImports "spend-api/internal/app/adapters/rest/imports"
+	restReports "spend-api/internal/app/adapters/rest/reports"
+	restTransactions "spend-api/internal/app/adapters/rest/transactions"
+	domainAccess "spend-api/internal/domain/access"
+	domainAccounts "spend-api/internal/domain/accounts"
+	domainAuth "spend-api/internal/domain/auth"
+	domainBudgets "spend-api/internal/domain/budgets"
+	domainCategories "spend-api/internal/domain/categories"
+	domainExchangeRates "spend-api/internal/domain/exchangerates"
+	domainIdempotency "spend-api/internal/domain/idempotency"
+	domainImports "spend-api/internal/domain/imports"
+	domainReports "spend-api/internal/domain/reports"
+	domainTransactions "spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+)
+
+// executor is what the DB adapters and the services' units of work need of the database
+type executor interface {
+	db.Executor
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// newRouter wires the services of the API to the database and routes every
+// endpoint to its handler. The handlers expect an authenticated principal in
+// the request context, which scopes everything they read and write to the
+// principal's tenant. Accounts, their transactions, imports into them, and the
+// duplicates, rules, rule runs, reports and budgets drawn from them go
+// through access policies enforcing the principal's role on each account.
+func newRouter(executor executor, apiKeyService *domainAuth.APIKeyService, userService *domainAuth.UserService, idempotencyService *domainIdempotency.IdempotencyService) *http.ServeMux {
+	accountDbAdapter := dbAccounts.NewForSavingAccountUsingDB(executor)
+	accountLoaderDbAdapter := dbAccounts.NewForLoadingAccountUsingDB(executor)
+	accountModifierDbAdapter := dbAccounts.NewForModifyingAccountUsingDB(executor)
+	accountRemoverDbAdapter := dbAccounts.NewForRemovingAccountUsingDB(executor)
+	transactionDbAdapter := dbTransactions.NewForSavingTransactionUsingDB(executor)
+	transactionLoaderDbAdapter := dbTransactions.NewForLoadingTransactionsUsingDB(executor)
+	transactionStatusDbAdapter := dbTransactions.NewForModifyingTransactionStatusUsingDB(executor)
+	transactionModifierDbAdapter := dbTransactions.NewForModifyingTransactionUsingDB(executor)
+	accountCurrencyDbAdapter := dbTransactions.NewForLoadingAccountCurrencyUsingDB(executor)
+	balanceDbAdapter := dbTransactions.NewForLoadingBalanceUsingDB(executor)
+	categoryCheckDbAdapter := dbTransactions.NewForCheckingCategoryUsingDB(executor)
+	transactionCategoryDbAdapter := dbTransactions.NewForModifyingTransactionCategoryUsingDB(executor)
+	transactionLabelsDbAdapter := dbTransactions.NewForModifyingTransactionLabelsUsingDB(executor)
+	ruleDbAdapter := dbTransactions.NewForSavingRuleUsingDB(executor)
+	ruleLoaderDbAdapter := dbTransactions.NewForLoadingRulesUsingDB(executor)
+	ruleModifierDbAdapter := dbTransactions.NewForModifyingRuleUsingDB(executor)
+	ruleRemoverDbAdapter := dbTransactions.NewForRemovingRuleUsingDB(executor)
+	transactionStreamDbAdapter := dbTransactions.NewForStreamingTransactionsUsingDB(executor)
+	duplicateCandidatesDbAdapter := dbTransactions.NewForLoadingDuplicateCandidatesUsingDB(executor)
+	duplicateResolutionDbAdapter := dbTransactions.NewForSavingDuplicateResolutionUsingDB(executor)
+	duplicateResolutionLoaderDbAdapter := dbTransactions.NewForLoadingDuplicateResolutionsUsingDB(executor)
+	transferDbAdapter := dbTransactions.NewForSavingTransferUsingDB(executor)
+	openingBalanceDbAdapter := dbTransactions.NewForRecordingOpeningBalanceUsingDB(executor)
+	rateDbAdapter := dbExchangeRates.NewForSavingRatesUsingDB(executor)
+	rateLoaderDbAdapter := dbExchangeRates.NewForLoadingRateUsingDB(executor)
+	categoryDbAdapter := dbCategories.NewForSavingCategoryUsingDB(executor)
+	categoryLoaderDbAdapter := dbCategories.NewForLoadingCategoriesUsingDB(executor)
+	categoryModifierDbAdapter := dbCategories.NewForModifyingCategoryUsingDB(executor)
+	categoryRemoverDbAdapter := dbCategories.NewForRemovingCategoryUsingDB(executor)
+	profileDbAdapter := dbImports.NewForSavingProfileUsingDB(executor)
+	profileLoaderDbAdapter := dbImports.NewForLoadingProfilesUsingDB(executor)
+	profileRemoverDbAdapter := dbImports.NewForRemovingProfileUsingDB(executor)
+	importCurrencyDbAdapter := dbImports.NewForLoadingAccountCurrencyUsingDB(executor)
+	spendingDbAdapter := dbReports.NewForLoadingSpendingUsingDB(executor)
+	budgetDbAdapter := dbBudgets.NewForSavingBudgetUsingDB(executor)
+	budgetLoaderDbAdapter := dbBudgets.NewForLoadingBudgetUsingDB(executor)
+	budgetModifierDbAdapter := dbBudgets.NewForModifyingBudgetUsingDB(executor)
+	budgetRemoverDbAdapter := dbBudgets.NewForRemovingBudgetUsingDB(executor)
+	budgetCurrencyDbAdapter := dbBudgets.NewForLoadingAccountCurrencyUsingDB(executor)
+	expenseDbAdapter := dbBudgets.NewForLoadingExpensesUsingDB(executor)
+	grantDbAdapter := dbAccess.NewForSavingGrantUsingDB(executor)
+	grantLoaderDbAdapter := dbAccess.NewForLoadingGrantsUsingDB(executor)
+	grantModifierDbAdapter := dbAccess.NewForModifyingGrantUsingDB(executor)
+	grantRemoverDbAdapter := dbAccess.NewForRemovingGrantsUsingDB(executor)
+	accountCheckDbAdapter := dbAccess.NewForCheckingAccountUsingDB(executor)
+	userCheckDbAdapter := dbAccess.NewForCheckingUserUsingDB(executor)
+
+	accountService := domainAccounts.NewAccountService(accountDbAdapter, accountLoaderDbAdapter, accountModifierDbAdapter, accountRemoverDbAdapter, openingBalanceDbAdapter, executor)
+
+	rateService := domainExchangeRates.NewRateService(rateDbAdapter, rateLoaderDbAdapter, executor)
+
+	transactionService := domainTransactions.NewTransactionService(transactionDbAdapter, transactionLoaderDbAdapter, accountCurrencyDbAdapter, transactionStatusDbAdapter, balanceDbAdapter, categoryCheckDbAdapter, transactionCategoryDbAdapter, ruleLoaderDbAdapter, executor, rateService)
+
+	ruleService := domainTransactions.NewRuleService(ruleDbAdapter, ruleLoaderDbAdapter, ruleModifierDbAdapter, ruleRemoverDbAdapter, categoryCheckDbAdapter, transactionLoaderDbAdapter, transactionLabelsDbAdapter, executor)
+	exportService := domainTransactions.NewExportService(transactionStreamDbAdapter)
+	duplicateService := domainTransactions.NewDuplicateService(duplicateCandidatesDbAdapter, transactionLoaderDbAdapter, transactionLabelsDbAdapter, transactionStatusDbAdapter, duplicateResolutionDbAdapter, duplicateResolutionLoaderDbAdapter, executor)
+	editService := domainTransactions.NewEditService(transactionLoaderDbAdapter, transactionModifierDbAdapter, executor)
+	transferService := domainTransactions.NewTransferService(transferDbAdapter, transactionDbAdapter, accountCurrencyDbAdapter, executor)
+
+	categoryService := domainCategories.NewCategoryService(categoryDbAdapter, categoryLoaderDbAdapter, categoryModifierDbAdapter, categoryRemoverDbAdapter)
+
+	importTransactionAdapter := dbImports.NewForRecordingTransactionUsingDB(transactionService)
+	importService := domainImports.NewImportService(profileDbAdapter, profileLoaderDbAdapter, profileRemoverDbAdapter, importCurrencyDbAdapter, balanceDbAdapter, importTransactionAdapter)
+
+	accessService := domainAccess.NewAccessService(grantDbAdapter, grantLoaderDbAdapter, grantModifierDbAdapter, grantRemoverDbAdapter, accountCheckDbAdapter, userCheckDbAdapter, executor)
+	accountPolicy := domainAccess.NewAccountPolicy(accountService, accessService, executor)
+	transactionPolicy := domainAccess.NewTransactionPolicy(transactionService, editService, transferService, exportService, accessService)
+	importPolicy := domainAccess.NewImportPolicy(importService, accessService)
+	duplicatePolicy := domainAccess.NewDuplicatePolicy(duplicateService, transactionService, accessService)
+	rulePolicy := domainAccess.NewRulePolicy(ruleService, accountService, accessService)
+
+	reportService := domainReports.NewReportService(spendingDbAdapter, rateService)
+	reportPolicy := domainAccess.NewReportPolicy(reportService, accessService)
+
+	budgetService := domainBudgets.NewBudgetService(budgetDbAdapter, budgetLoaderDbAdapter, budgetModifierDbAdapter, budgetRemoverDbAdapter, budgetCurrencyDbAdapter, expenseDbAdapter, rateService)
+	budgetPolicy := domainAccess.NewBudgetPolicy(budgetService, accessService)
+
+	// idempotent makes a create endpoint safe to retry with an Idempotency-Key
+	idempotent := func(handler http.Handler) http.Handler {
+		return restIdempotency.NewForHandlingIdempotentRequestsUsingRestAPI(idempotencyService, handler)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /accounts", idempotent(restAccounts.NewForCreatingAccountUsingRestAPI(accountPolicy)))
+	mux.Handle("GET /accounts", restAccounts.NewForListingAccountsUsingRestAPI(accountPolicy))
+	mux.Handle("GET /accounts/{id}", restAccounts.NewForGettingAccountUsingRestAPI(accountPolicy))
+	mux.Handle("PATCH /accounts/{id}", restAccounts.NewForUpdatingAccountUsingRestAPI(accountPolicy))
+	mux.Handle("DELETE /accounts/{id}", restAccounts.NewForDeletingAccountUsingRestAPI(accountPolicy))
+	mux.Handle("GET /accounts/{id}/access", restAccess.NewForListingAccessUsingRestAPI(accessService))
+	mux.Handle("POST /accounts/{id}/access", idempotent(restAccess.NewForGrantingAccessUsingRestAPI(accessService)))
+	mux.Handle("PUT /accounts/{id}/access/{userID}", restAccess.NewForChangingAccessUsingRestAPI(accessService))
+	mux.Handle("DELETE /accounts/{id}/access/{userID}", restAccess.NewForRevokingAccessUsingRestAPI(accessService))
+	mux.Handle("GET /accounts/{id}/balance", restTransactions.NewForGettingBalanceUsingRestAPI(transactionPolicy))
+	mux.Handle("POST /accounts/{id}/imports", idempotent(restImports.NewForImportingCSVUsingRestAPI(importPolicy)))
+	mux.Handle("POST /accounts/{id}/statements", idempotent(restImports.NewForImportingStatementUsingRestAPI(importPolicy)))
+	mux.Handle("POST /import-profiles", idempotent(restImports.NewForCreatingProfileUsingRestAPI(importService)))
+	mux.Handle("GET /import-profiles", restImports.NewForListingProfilesUsingRestAPI(importService))
+	mux.Handle("DELETE /import-profiles/{id}", restImports.NewForDeletingProfileUsingRestAPI(importService))
+	mux.Handle("POST /transactions", idempotent(restTransactions.NewForCreatingTransactionUsingRestAPI(transactionPolicy)))
+	mux.Handle("GET /transactions", restTransactions.NewForListingTransactionsUsingRestAPI(transactionPolicy))
+	mux.Handle("GET /transactions/export", restTransactions.NewForExportingTransactionsUsingRestAPI(transactionPolicy, transactionPolicy))
+	mux.Handle("GET /transactions/duplicates", restTransactions.NewForFindingDuplicatesUsingRestAPI(duplicatePolicy))
+	mux.Handle("POST /transactions/duplicates/resolutions", idempotent(restTransactions.NewForResolvingDuplicateUsingRestAPI(duplicatePolicy)))
+	mux.Handle("GET /transactions/duplicates/resolutions", restTransactions.NewForListingDuplicateResolutionsUsingRestAPI(duplicatePolicy))
+	mux.Handle("POST /transactions/categorize", restTransactions.NewForCategorizingTransactionsUsingRestAPI(transactionPolicy))
+	mux.Handle("GET /transactions/{id}", restTransactions.NewForGettingTransactionUsingRestAPI(transactionPolicy))
+	mux.Handle("PATCH /transactions/{id}", restTransactions.NewForUpdatingTransactionUsingRestAPI(transactionPolicy))
+	mux.Handle("POST /transactions/{id}/post", restTransactions.NewForPostingTransactionUsingRestAPI(transactionPolicy))
+	mux.Handle("POST /transactions/{id}/void", restTransactions.NewForVoidingTransactionUsingRestAPI(transactionPolicy))
+	mux.Handle("POST /transfers", idempotent(restTransactions.NewForCreatingTransferUsingRestAPI(transactionPolicy)))
+	mux.Handle("POST /rules", idempotent(restTransactions.NewForCreatingRuleUsingRestAPI(rulePolicy)))
+	mux.Handle("GET /rules", restTransactions.NewForListingRulesUsingRestAPI(rulePolicy))
+	mux.Handle("POST /rules/apply", restTransactions.NewForApplyingRulesUsingRestAPI(rulePolicy))
+	mux.Handle("PUT /rules/{id}", restTransactions.NewForUpdatingRuleUsingRestAPI(rulePolicy))
+	mux.Handle("DELETE /rules/{id}", restTransactions.NewForDeletingRuleUsingRestAPI(rulePolicy))
+	mux.Handle("POST /categories", idempotent(restCategories.NewForCreatingCategoryUsingRestAPI(categoryService)))
+	mux.Handle("GET /categories", restCategories.NewForListingCategoriesUsingRestAPI(categoryService))
+	mux.Handle("GET /categories/{id}", restCategories.NewForGettingCategoryUsingRestAPI(categoryService))
+	mux.Handle("PATCH /categories/{id}", restCategories.NewForUpdatingCategoryUsingRestAPI(categoryService))
+	mux.Handle("DELETE /categories/{id}", restCategories.NewForDeletingCategoryUsingRestAPI(categoryService))
+	mux.Handle("GET /reports/spending", restReports.NewForReportingSpendingUsingRestAPI(reportPolicy))
+	mux.Handle("POST /budgets", idempotent(restBudgets.NewForCreatingBudgetUsingRestAPI(budgetPolicy)))
+	mux.Handle("GET /budgets", restBudgets.NewForListingBudgetsUsingRestAPI(budgetPolicy))
+	mux.Handle("GET /budgets/{id}", restBudgets.NewForGettingBudgetUsingRestAPI(budgetPolicy))
+	mux.Handle("PUT /budgets/{id}", restBudgets.NewForUpdatingBudgetUsingRestAPI(budgetPolicy))
+	mux.Handle("DELETE /budgets/{id}", restBudgets.NewForDeletingBudgetUsingRestAPI(budgetPolicy))
+	mux.Handle("GET /budgets/{id}/status", restBudgets.NewForGettingBudgetStatusUsingRestAPI(budgetPolicy))
+	mux.Handle("POST /exchange-rates/imports", idempotent(restExchangeRates.NewForImportingRatesUsingRestAPI(rateService)))
+	mux.Handle("GET /exchange-rates/convert", restExchangeRates.NewForConvertingMoneyUsingRestAPI(rateService))
+	mux.Handle("POST /api-keys", restAuth.NewForCreatingAPIKeyUsingRestAPI(apiKeyService))
+	mux.Handle("GET /api-keys", restAuth.NewForListingAPIKeysUsingRestAPI(apiKeyService))
+	mux.Handle("POST /users", idempotent(restAuth.NewForCreatingUserUsingRestAPI(userService)))
+	mux.Handle("GET /users", restAuth.NewForListingUsersUsingRestAPI(userService))
+	mux.Handle("DELETE /api-keys/{id}", restAuth.NewForRevokingAPIKeyUsingRestAPI(apiKeyService))
+	mux.Handle("POST /api-keys/{id}/rotate", restAuth.NewForRotatingAPIKeyUsingRestAPI(apiKeyService))
+
+	return mux
+}
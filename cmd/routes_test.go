@@ -0,0 +1,1253 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"spend-api/internal/app/adapters/db/auth"
+	"spend-api/internal/app/adapters/db/idempotency"
+	domainAuth "spend-api/internal/domain/auth"
+	domainIdempotency "spend-api/internal/domain/idempotency"
+	"spend-api/internal/infra/db"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ownerTenant owns the data the isolation requests address, all with ID 1.
+// otherTenant is the tenant of the caller trying to reach it. Its ID is that
+// of another row of ownerTenant, so a statement binding the tenant and the
+// ID to each other's placeholders finds a row it must not.
+const (
+	ownerTenant = "1"
+	otherTenant = "2"
+)
+
+// secret appears in the text of every row of ownerTenant
+const secret = "Secret"
+
+// alice owns every account of ownerTenant, carol views its account 1 and dave
+// has no role on any of them; mallory is a user of otherTenant
+var (
+	alice   = &domainAuth.Principal{Subject: "alice", Method: domainAuth.MethodToken, UserID: "1", TenantID: ownerTenant}
+	carol   = &domainAuth.Principal{Subject: "carol", Method: domainAuth.MethodToken, UserID: "2", TenantID: ownerTenant}
+	dave    = &domainAuth.Principal{Subject: "dave", Method: domainAuth.MethodToken, UserID: "3", TenantID: ownerTenant}
+	mallory = &domainAuth.Principal{Subject: "mallory", Method: domainAuth.MethodToken, UserID: "9", TenantID: otherTenant}
+)
+
+// row is a row of a table of FakeDB, by column
+type row map[string]interface{}
+
+// FakeDB is an in-memory database seeded with the data of two tenants. It
+// runs the statements of the DB adapters against its tables, honouring their
+// WHERE and JOIN conditions as far as it understands them and taking any other
+// condition to hold, so a statement finds at least the rows MariaDB would.
+// Every row of a tenant other than Tenant that a statement finds, changes or
+// inserts is recorded in Leaks.
+type FakeDB struct {
+	Tenant     string
+	Tables     map[string][]row
+	Leaks      []string
+	Statements []string
+	Args       [][]interface{}
+	lastID     int64
+}
+
+// newFakeDB returns a FakeDB seeded with both tenants' data, used by a caller of the tenant
+func newFakeDB(tenant string) *FakeDB {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	transaction := func(id, tenantID, accountID int64, date time.Time, description string) row {
+		return row{"id": id, "tenant_id": strconv.FormatInt(tenantID, 10), "account_id": accountID, "amount": "-3.50", "currency": "EUR",
+			"transaction_type": "debit", "transaction_date": date, "status": "pending", "description": description, "version": int64(1)}
+	}
+	return &FakeDB{Tenant: tenant, lastID: 100, Tables: map[string][]row{
+		"tenants": {{"id": int64(1), "name": "Owner"}, {"id": int64(2), "name": "Other"}},
+		"users": {
+			{"id": int64(1), "tenant_id": ownerTenant, "subject": "alice", "name": secret + " Alice", "admin": true, "created_at": day(1)},
+			{"id": int64(2), "tenant_id": ownerTenant, "subject": "carol", "name": secret + " Carol", "created_at": day(1)},
+			{"id": int64(3), "tenant_id": ownerTenant, "subject": "dave", "name": secret + " Dave", "created_at": day(1)},
+			{"id": int64(8), "tenant_id": otherTenant, "subject": "trent", "name": "Trent", "created_at": day(1)},
+			{"id": int64(9), "tenant_id": otherTenant, "subject": "mallory", "name": "Mallory", "admin": true, "created_at": day(1)},
+		},
+		"accounts": {
+			{"id": int64(1), "tenant_id": ownerTenant, "name": secret + " current", "currency": "EUR", "version": int64(1)},
+			{"id": int64(2), "tenant_id": ownerTenant, "name": secret + " savings", "currency": "EUR", "version": int64(1)},
+			{"id": int64(11), "tenant_id": otherTenant, "name": "Current", "currency": "EUR", "version": int64(1)},
+			{"id": int64(12), "tenant_id": otherTenant, "name": "Trent's", "currency": "EUR", "version": int64(1)},
+		},
+		"account_grants": {
+			{"tenant_id": ownerTenant, "account_id": int64(1), "user_id": int64(1), "role": "owner", "created_at": day(1)},
+			{"tenant_id": ownerTenant, "account_id": int64(2), "user_id": int64(1), "role": "owner", "created_at": day(1)},
+			{"tenant_id": ownerTenant, "account_id": int64(1), "user_id": int64(2), "role": "viewer", "granted_by": "alice", "created_at": day(1)},
+			{"tenant_id": otherTenant, "account_id": int64(11), "user_id": int64(9), "role": "owner", "created_at": day(1)},
+			{"tenant_id": otherTenant, "account_id": int64(12), "user_id": int64(8), "role": "owner", "created_at": day(1)},
+		},
+		"transactions": {
+			transaction(1, 1, 1, day(2), secret+" coffee"),
+			transaction(2, 1, 1, day(3), secret+" coffee"),
+			transaction(11, 2, 11, day(2), "Coffee"),
+		},
+		"balance_snapshots": {
+			{"tenant_id": ownerTenant, "account_id": int64(1), "period_start": day(1), "net_change": "-7.00"},
+			{"tenant_id": otherTenant, "account_id": int64(11), "period_start": day(1), "net_change": "-3.50"},
+		},
+		"duplicate_resolutions": {
+			{"id": int64(1), "tenant_id": ownerTenant, "action": "dismissed", "kept_transaction_id": int64(1), "duplicate_transaction_id": int64(2), "resolved_at": day(4)},
+		},
+		"categories": {
+			{"id": int64(1), "tenant_id": ownerTenant, "name": secret + " food"},
+			{"id": int64(2), "tenant_id": ownerTenant, "name": secret + " drinks", "parent_id": int64(1)},
+			{"id": int64(11), "tenant_id": otherTenant, "name": "Food"},
+		},
+		"categorization_rules": {
+			{"id": int64(1), "tenant_id": ownerTenant, "name": secret + " coffee", "priority": int64(0), "description_contains": "coffee", "category_id": int64(1)},
+			{"id": int64(2), "tenant_id": ownerTenant, "name": secret + " cafe", "priority": int64(1), "description_contains": "coffee", "payee": secret + " Cafe"},
+			{"id": int64(11), "tenant_id": otherTenant, "name": "Coffee", "priority": int64(0), "description_contains": "coffee", "category_id": int64(11)},
+		},
+		"import_profiles": {
+			{"id": int64(1), "tenant_id": ownerTenant, "name": secret + " bank", "delimiter": ",", "skip_rows": int64(0), "date_column": int64(0), "date_format": "2006-01-02",
+				"description_column": int64(1), "amount_column": int64(2), "decimal_separator": "."},
+			{"id": int64(2), "tenant_id": ownerTenant, "name": secret + " card", "delimiter": ";", "skip_rows": int64(1), "date_column": int64(0), "date_format": "02.01.2006",
+				"description_column": int64(1), "amount_column": int64(2), "decimal_separator": ","},
+		},
+		"budgets": {
+			{"id": int64(1), "tenant_id": ownerTenant, "name": secret + " food", "amount": "100.00", "currency": "EUR", "period": "monthly", "start_date": day(1), "rollover": false},
+			{"id": int64(2), "tenant_id": ownerTenant, "name": secret + " fun", "amount": "50.00", "currency": "EUR", "period": "monthly", "start_date": day(1), "rollover": false},
+		},
+		"exchange_rates": {
+			{"tenant_id": ownerTenant, "base_currency": "EUR", "quote_currency": "USD", "rate_date": day(2), "rate": "1.09450000"},
+		},
+		"api_keys": {
+			{"id": int64(1), "tenant_id": ownerTenant, "user_id": int64(1), "name": secret + " CI", "prefix": "abcdefgh", "secret_hash": "00", "created_at": day(1)},
+			{"id": int64(2), "tenant_id": ownerTenant, "user_id": int64(2), "name": secret + " deploy", "prefix": "ijklmnop", "secret_hash": "00", "created_at": day(1)},
+		},
+	}}
+}
+
+func (f *FakeDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return f.ExecContext(context.Background(), query, args...)
+}
+
+func (f *FakeDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	f.record(query, args)
+	statement := bind(query)
+	switch {
+	case strings.HasPrefix(statement, "INSERT INTO "):
+		return f.insert(statement, args)
+	case strings.HasPrefix(statement, "UPDATE "):
+		return f.update(statement, args)
+	case strings.HasPrefix(statement, "DELETE FROM "):
+		return f.delete(statement, args)
+	}
+	return nil, fmt.Errorf("fake database cannot execute %q", query)
+}
+
+func (f *FakeDB) Query(query string, args ...interface{}) (db.Rows, error) {
+	return f.QueryContext(context.Background(), query, args...)
+}
+
+func (f *FakeDB) QueryContext(ctx context.Context, query string, args ...interface{}) (db.Rows, error) {
+	f.record(query, args)
+	values, err := f.selectRows(bind(query), args, scope{})
+	if err != nil {
+		return nil, err
+	}
+	return &FakeRows{values: values}, nil
+}
+
+func (f *FakeDB) QueryRow(query string, args ...interface{}) db.Row {
+	return f.QueryRowContext(context.Background(), query, args...)
+}
+
+func (f *FakeDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) db.Row {
+	rows, err := f.QueryContext(ctx, query, args...)
+	if err != nil {
+		return &FakeRow{err: err}
+	}
+	values := rows.(*FakeRows).values
+	if len(values) == 0 {
+		return &FakeRow{err: sql.ErrNoRows}
+	}
+	return &FakeRow{values: values[0]}
+}
+
+func (f *FakeDB) Close() error {
+	return nil
+}
+
+// WithinTransaction runs fn, restoring the tables if it fails
+func (f *FakeDB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	saved := map[string][]row{}
+	for table, rows := range f.Tables {
+		for _, r := range rows {
+			copied := row{}
+			for column, value := range r {
+				copied[column] = value
+			}
+			saved[table] = append(saved[table], copied)
+		}
+	}
+	if err := fn(ctx); err != nil {
+		f.Tables = saved
+		return err
+	}
+	return nil
+}
+
+func (f *FakeDB) record(query string, args []interface{}) {
+	f.Statements = append(f.Statements, query)
+	f.Args = append(f.Args, args)
+}
+
+// guard records a leak if the row belongs to another tenant than the caller's
+func (f *FakeDB) guard(table string, r row) {
+	if tenantID, ok := r["tenant_id"]; ok && fmt.Sprint(tenantID) != f.Tenant {
+		f.Leaks = append(f.Leaks, fmt.Sprintf("%s of tenant %v: %v", table, tenantID, r))
+	}
+}
+
+var insertStatement = regexp.MustCompile(`^INSERT INTO (\w+) \(([^)]*)\) VALUES (.*?)( ON DUPLICATE KEY UPDATE .*)?$`)
+
+func (f *FakeDB) insert(statement string, args []interface{}) (sql.Result, error) {
+	match := insertStatement.FindStringSubmatch(statement)
+	if match == nil {
+		return nil, fmt.Errorf("fake database cannot insert with %q", statement)
+	}
+	table, columns := match[1], strings.Split(match[2], ", ")
+	inserted := int64(0)
+	for _, tuple := range topSplit(match[3], ", ") {
+		values := strings.Split(strings.Trim(tuple, "()"), ", ")
+		r := row{}
+		for i, column := range columns {
+			r[column], _ = f.operand(values[i], scope{}, args)
+		}
+		if _, ok := r["id"]; !ok {
+			f.lastID++
+			r["id"] = f.lastID
+		}
+		f.guard(table, r)
+		f.Tables[table] = append(f.Tables[table], r)
+		inserted++
+	}
+	return fakeResult{lastID: f.lastID, affected: inserted}, nil
+}
+
+var updateStatement = regexp.MustCompile(`^UPDATE (\w+) SET (.*)$`)
+
+func (f *FakeDB) update(statement string, args []interface{}) (sql.Result, error) {
+	match := updateStatement.FindStringSubmatch(statement)
+	if match == nil || topIndex(match[2], " WHERE ") < 0 {
+		return nil, fmt.Errorf("fake database cannot update with %q", statement)
+	}
+	where := topIndex(match[2], " WHERE ")
+	table := match[1]
+	matched := f.filter(table, match[2][where+len(" WHERE "):], args)
+	for _, r := range matched {
+		f.guard(table, r)
+		for _, assignment := range topSplit(match[2][:where], ", ") {
+			column, expression, _ := strings.Cut(assignment, " = ")
+			if expression == column+" + 1" {
+				r[column] = r[column].(int64) + 1
+			} else if value, ok := f.operand(expression, scope{}, args); ok {
+				r[column] = value
+			}
+		}
+	}
+	return fakeResult{affected: int64(len(matched))}, nil
+}
+
+var deleteStatement = regexp.MustCompile(`^DELETE FROM (\w+) WHERE (.*)$`)
+
+func (f *FakeDB) delete(statement string, args []interface{}) (sql.Result, error) {
+	match := deleteStatement.FindStringSubmatch(statement)
+	if match == nil {
+		return nil, fmt.Errorf("fake database cannot delete with %q", statement)
+	}
+	table := match[1]
+	matched := f.filter(table, match[2], args)
+	var kept []row
+	for _, r := range f.Tables[table] {
+		if containsRow(matched, r) {
+			f.guard(table, r)
+			continue
+		}
+		kept = append(kept, r)
+	}
+	f.Tables[table] = kept
+	return fakeResult{affected: int64(len(matched))}, nil
+}
+
+// filter returns the rows of the table the condition holds for
+func (f *FakeDB) filter(table, condition string, args []interface{}) []row {
+	var matched []row
+	for _, r := range f.Tables[table] {
+		if f.holds(condition, scope{rows: map[string]row{table: r}, first: table}, args) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// selectRows runs a SELECT, within the scope of the row of an enclosing
+// statement when it is a subquery
+func (f *FakeDB) selectRows(statement string, args []interface{}, outer scope) ([][]interface{}, error) {
+	if strings.HasPrefix(statement, "WITH RECURSIVE ") {
+		return f.recursive(statement, args, outer)
+	}
+	body, ok := strings.CutPrefix(statement, "SELECT ")
+	from := topIndex(body, " FROM ")
+	if !ok || from < 0 {
+		return nil, fmt.Errorf("fake database cannot select with %q", statement)
+	}
+	items, rest := topSplit(body[:from], ", "), body[from+len(" FROM "):]
+
+	limit := -1
+	if i := topIndex(rest, " LIMIT "); i >= 0 {
+		value, _ := f.operand(rest[i+len(" LIMIT "):], scope{}, args)
+		limit, _ = strconv.Atoi(fmt.Sprint(value))
+		rest = rest[:i]
+	}
+	if i := topIndex(rest, " ORDER BY "); i >= 0 {
+		rest = rest[:i]
+	}
+	grouping := ""
+	if i := topIndex(rest, " GROUP BY "); i >= 0 {
+		grouping, rest = rest[i+len(" GROUP BY "):], rest[:i]
+	}
+	condition := ""
+	if i := topIndex(rest, " WHERE "); i >= 0 {
+		condition, rest = rest[i+len(" WHERE "):], rest[:i]
+	}
+
+	scopes, err := f.join(rest, args, outer)
+	if err != nil {
+		return nil, err
+	}
+	var matched []scope
+	for _, s := range scopes {
+		if !f.holds(condition, s, args) {
+			continue
+		}
+		matched = append(matched, s)
+		for alias, r := range s.rows {
+			if _, enclosing := outer.rows[alias]; !enclosing {
+				f.guard(alias, r)
+			}
+		}
+	}
+
+	var groups [][]scope
+	switch {
+	case grouping != "":
+		index := map[string]int{}
+		for _, s := range matched {
+			key := ""
+			for _, column := range strings.Split(grouping, ", ") {
+				value, _ := f.operand(column, s, args)
+				key += fmt.Sprint(value) + "|"
+			}
+			if i, ok := index[key]; ok {
+				groups[i] = append(groups[i], s)
+				continue
+			}
+			index[key] = len(groups)
+			groups = append(groups, []scope{s})
+		}
+	case aggregate(items):
+		groups = [][]scope{matched}
+	default:
+		for _, s := range matched {
+			groups = append(groups, []scope{s})
+		}
+	}
+	var values [][]interface{}
+	for _, group := range groups {
+		values = append(values, f.project(items, group, args))
+	}
+	if limit >= 0 && len(values) > limit {
+		values = values[:limit]
+	}
+	return values, nil
+}
+
+// recursive runs a WITH RECURSIVE query of a single column, holding the rows
+// found so far in a temporary table until a step finds no new one
+func (f *FakeDB) recursive(statement string, args []interface{}, outer scope) ([][]interface{}, error) {
+	open := strings.Index(statement, " AS (")
+	name := strings.TrimPrefix(statement[:open], "WITH RECURSIVE ")
+	body := statement[open+len(" AS "):]
+	end := closingParen(body)
+	anchor, step, ok := strings.Cut(body[1:end], " UNION ALL ")
+	if !ok {
+		return nil, fmt.Errorf("fake database cannot recurse with %q", statement)
+	}
+	column := strings.Fields(anchor)[1]
+
+	defer delete(f.Tables, name)
+	f.Tables[name] = nil
+	seen := map[string]bool{}
+	for rows, err := f.selectRows(anchor, args, outer); ; rows, err = f.selectRows(step, args, outer) {
+		if err != nil {
+			return nil, err
+		}
+		found := 0
+		for _, values := range rows {
+			if key := fmt.Sprint(values[0]); !seen[key] {
+				seen[key] = true
+				f.Tables[name] = append(f.Tables[name], row{column: values[0]})
+				found++
+			}
+		}
+		if found == 0 {
+			break
+		}
+	}
+	return f.selectRows(strings.TrimSpace(body[end+1:]), args, outer)
+}
+
+// join returns every combination of rows of the tables of a FROM clause its
+// JOIN conditions hold for, each within the scope of the enclosing row
+func (f *FakeDB) join(from string, args []interface{}, outer scope) ([]scope, error) {
+	tables := topSplit(from, " JOIN ")
+	table, alias := tableAlias(tables[0])
+	if _, ok := f.Tables[table]; !ok {
+		return nil, fmt.Errorf("fake database has no table %s", table)
+	}
+	var scopes []scope
+	for _, r := range f.Tables[table] {
+		s := scope{rows: map[string]row{}, first: alias}
+		for enclosing, other := range outer.rows {
+			s.rows[enclosing] = other
+		}
+		s.rows[alias] = r
+		scopes = append(scopes, s)
+	}
+	for _, joined := range tables[1:] {
+		reference, condition, _ := strings.Cut(joined, " ON ")
+		table, alias := tableAlias(reference)
+		var next []scope
+		for _, s := range scopes {
+			for _, r := range f.Tables[table] {
+				combined := scope{rows: map[string]row{alias: r}, first: s.first}
+				for other, r := range s.rows {
+					combined.rows[other] = r
+				}
+				if f.holds(condition, combined, args) {
+					next = append(next, combined)
+				}
+			}
+		}
+		scopes = next
+	}
+	return scopes, nil
+}
+
+// tableAlias splits a table reference into the table and the alias it goes by
+func tableAlias(reference string) (string, string) {
+	table, alias, ok := strings.Cut(strings.TrimSpace(reference), " ")
+	if !ok {
+		return table, table
+	}
+	return table, alias
+}
+
+// scope holds the rows an expression is evaluated against, by alias, and
+// the alias unqualified columns belong to
+type scope struct {
+	rows  map[string]row
+	first string
+}
+
+var identifier = regexp.MustCompile(`^[a-z_]+(\.[a-z_]+)?$`)
+var literal = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// operand evaluates a placeholder, literal, column, scalar subquery or sum
+// of those; other expressions are not understood
+func (f *FakeDB) operand(expression string, s scope, args []interface{}) (interface{}, bool) {
+	if terms := topSplit(expression, " + "); len(terms) > 1 {
+		sum := new(big.Rat)
+		for _, term := range terms {
+			value, ok := f.operand(term, s, args)
+			number, isNumber := new(big.Rat).SetString(fmt.Sprint(value))
+			if !ok || !isNumber {
+				return nil, false
+			}
+			sum.Add(sum, number)
+		}
+		return decimal(sum), true
+	}
+	switch {
+	case strings.HasPrefix(expression, "$"):
+		i, err := strconv.Atoi(expression[1:])
+		return args[i], err == nil
+	case literal.MatchString(expression):
+		return expression, true
+	case identifier.MatchString(expression) && s.rows != nil:
+		alias, column, qualified := strings.Cut(expression, ".")
+		if !qualified {
+			alias, column = s.first, expression
+		}
+		r, ok := s.rows[alias]
+		return r[column], ok
+	case strings.HasPrefix(expression, "(SELECT ") && closingParen(expression) == len(expression)-1:
+		rows, err := f.selectRows(expression[1:len(expression)-1], args, s)
+		if err != nil || len(rows) == 0 {
+			return nil, err == nil
+		}
+		_, isUnknown := rows[0][0].(unknown)
+		return rows[0][0], !isUnknown
+	}
+	return nil, false
+}
+
+var (
+	comparison = regexp.MustCompile(`^(\S+) (=|<>|<=|>=|<|>) (\S+)$`)
+	membership = regexp.MustCompile(`^(\S+) (IN|NOT IN) \((.*)\)$`)
+	between    = regexp.MustCompile(`^(\S+) BETWEEN (\S+) AND (\S+)$`)
+	like       = regexp.MustCompile(`^(\S+) LIKE (\S+)$`)
+	exists     = regexp.MustCompile(`^(NOT )?EXISTS \((.*)\)$`)
+)
+
+// holds reports whether every condition of a conjunction holds. Conditions
+// that are not understood are taken to hold.
+func (f *FakeDB) holds(condition string, s scope, args []interface{}) bool {
+	if condition == "" {
+		return true
+	}
+	for _, c := range conjuncts(condition) {
+		if !f.predicate(strings.TrimSpace(c), s, args) {
+			return false
+		}
+	}
+	return true
+}
+
+// conjuncts splits a condition around its top level ANDs, keeping those of BETWEEN
+func conjuncts(condition string) []string {
+	var result []string
+	for _, part := range topSplit(condition, " AND ") {
+		if n := len(result); n > 0 && strings.Contains(result[n-1], " BETWEEN ") && !strings.Contains(result[n-1], " AND ") {
+			result[n-1] += " AND " + part
+			continue
+		}
+		result = append(result, part)
+	}
+	return result
+}
+
+func (f *FakeDB) predicate(condition string, s scope, args []interface{}) bool {
+	if condition == "FALSE" {
+		return false
+	}
+	if match := exists.FindStringSubmatch(condition); match != nil {
+		rows, err := f.selectRows(match[2], args, s)
+		return err != nil || (len(rows) > 0) == (match[1] == "")
+	}
+	if match := comparison.FindStringSubmatch(condition); match != nil {
+		left, ok := f.operand(match[1], s, args)
+		right, ok2 := f.operand(match[3], s, args)
+		if !ok || !ok2 {
+			return true
+		}
+		order, known := compare(left, right)
+		switch match[2] {
+		case "=":
+			return known && order == 0
+		case "<>":
+			return known && order != 0
+		case "<":
+			return known && order < 0
+		case "<=":
+			return known && order <= 0
+		case ">":
+			return known && order > 0
+		default:
+			return known && order >= 0
+		}
+	}
+	if match := membership.FindStringSubmatch(condition); match != nil {
+		value, ok := f.operand(match[1], s, args)
+		if !ok {
+			return true
+		}
+		var candidates []interface{}
+		if strings.HasPrefix(match[3], "SELECT ") || strings.HasPrefix(match[3], "WITH ") {
+			rows, err := f.selectRows(match[3], args, s)
+			if err != nil {
+				return true
+			}
+			for _, values := range rows {
+				candidates = append(candidates, values[0])
+			}
+		} else {
+			for _, item := range strings.Split(match[3], ", ") {
+				candidate, ok := f.operand(item, s, args)
+				if !ok {
+					return true
+				}
+				candidates = append(candidates, candidate)
+			}
+		}
+		found := false
+		for _, candidate := range candidates {
+			if order, known := compare(value, candidate); known && order == 0 {
+				found = true
+			}
+		}
+		return found == (match[2] == "IN")
+	}
+	if match := between.FindStringSubmatch(condition); match != nil {
+		value, ok := f.operand(match[1], s, args)
+		low, ok2 := f.operand(match[2], s, args)
+		high, ok3 := f.operand(match[3], s, args)
+		if !ok || !ok2 || !ok3 {
+			return true
+		}
+		above, known := compare(value, low)
+		below, known2 := compare(value, high)
+		return known && known2 && above >= 0 && below <= 0
+	}
+	if match := like.FindStringSubmatch(condition); match != nil {
+		value, ok := f.operand(match[1], s, args)
+		pattern, ok2 := f.operand(match[2], s, args)
+		if !ok || !ok2 {
+			return true
+		}
+		return value != nil && likePattern(fmt.Sprint(pattern)).MatchString(fmt.Sprint(value))
+	}
+	return true
+}
+
+// compare orders two values as MariaDB would, numbers numerically; NULL is not ordered
+func compare(left, right interface{}) (int, bool) {
+	if left == nil || right == nil {
+		return 0, false
+	}
+	leftTime, isTime := left.(time.Time)
+	rightTime, isTime2 := right.(time.Time)
+	if isTime && isTime2 {
+		return leftTime.Compare(rightTime), true
+	}
+	leftNumber, isNumber := new(big.Rat).SetString(fmt.Sprint(left))
+	rightNumber, isNumber2 := new(big.Rat).SetString(fmt.Sprint(right))
+	if isNumber && isNumber2 {
+		return leftNumber.Cmp(rightNumber), true
+	}
+	return strings.Compare(fmt.Sprint(left), fmt.Sprint(right)), true
+}
+
+// likePattern turns a LIKE pattern into a case-insensitive regular expression
+func likePattern(pattern string) *regexp.Regexp {
+	expression := "(?i)^"
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+			expression += regexp.QuoteMeta(pattern[i : i+1])
+		case '%':
+			expression += ".*"
+		case '_':
+			expression += "."
+		default:
+			expression += regexp.QuoteMeta(pattern[i : i+1])
+		}
+	}
+	return regexp.MustCompile(expression + "$")
+}
+
+// aggregate reports whether the select list aggregates the rows it selects
+func aggregate(items []string) bool {
+	for _, item := range items {
+		if strings.HasPrefix(item, "COUNT(") || strings.HasPrefix(item, "SUM(") || strings.HasPrefix(item, "COALESCE(SUM(") {
+			return true
+		}
+	}
+	return false
+}
+
+// project evaluates the select list for a group of rows, a single one unless
+// the query aggregates; expressions it does not understand are unknown
+func (f *FakeDB) project(items []string, group []scope, args []interface{}) []interface{} {
+	first := scope{}
+	if len(group) > 0 {
+		first = group[0]
+	}
+	values := make([]interface{}, len(items))
+	for i, item := range items {
+		expression := item
+		if as := topIndex(item, " AS "); as >= 0 {
+			expression = item[:as]
+		}
+		if value, ok := f.total(expression, group, args); ok {
+			values[i] = value
+		} else if value, ok := f.operand(expression, first, args); ok {
+			values[i] = value
+		} else {
+			values[i] = unknown{}
+		}
+	}
+	return values
+}
+
+// total evaluates COUNT(*) and sums of a column over a group of rows
+func (f *FakeDB) total(expression string, group []scope, args []interface{}) (interface{}, bool) {
+	if expression == "COUNT(*)" {
+		return int64(len(group)), true
+	}
+	summed, coalesced := strings.CutPrefix(expression, "COALESCE(")
+	if coalesced {
+		summed = strings.TrimSuffix(summed, ", 0)")
+	}
+	column, ok := strings.CutPrefix(summed, "SUM(")
+	column, ok2 := strings.CutSuffix(column, ")")
+	if !ok || !ok2 {
+		return nil, false
+	}
+	var sum *big.Rat
+	for _, s := range group {
+		value, ok := f.operand(column, s, args)
+		number, isNumber := new(big.Rat).SetString(fmt.Sprint(value))
+		if !ok {
+			return unknown{}, true
+		}
+		if value == nil {
+			continue
+		}
+		if !isNumber {
+			return unknown{}, true
+		}
+		if sum == nil {
+			sum = new(big.Rat)
+		}
+		sum.Add(sum, number)
+	}
+	switch {
+	case sum != nil:
+		return decimal(sum), true
+	case coalesced:
+		return "0", true
+	}
+	return nil, true
+}
+
+// decimal formats a number the way MariaDB returns a DECIMAL, without trailing zeros
+func decimal(number *big.Rat) string {
+	text := number.FloatString(8)
+	return strings.TrimSuffix(strings.TrimRight(text, "0"), ".")
+}
+
+// closingParen returns the index of the parenthesis closing the one s starts with, or -1
+func closingParen(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// unknown is the value of an expression FakeDB does not evaluate. It scans
+// as zero, or as "0" into a string, the way a sum over no rows would.
+type unknown struct{}
+
+// bind numbers the placeholders of a query, so the i-th ? becomes $i
+func bind(query string) string {
+	var bound strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			fmt.Fprintf(&bound, "$%d", n)
+			n++
+			continue
+		}
+		bound.WriteRune(r)
+	}
+	return bound.String()
+}
+
+// topIndex returns the index of the first occurrence of sep outside parentheses, or -1
+func topIndex(s, sep string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 && strings.HasPrefix(s[i:], sep) {
+			return i
+		}
+	}
+	return -1
+}
+
+// topSplit splits s around every occurrence of sep outside parentheses
+func topSplit(s, sep string) []string {
+	var parts []string
+	for i := topIndex(s, sep); i >= 0; i = topIndex(s, sep) {
+		parts = append(parts, s[:i])
+		s = s[i+len(sep):]
+	}
+	return append(parts, s)
+}
+
+// containsRow reports whether the very row is among rows
+func containsRow(rows []row, r row) bool {
+	for _, candidate := range rows {
+		if fmt.Sprintf("%p", candidate) == fmt.Sprintf("%p", r) {
+			return true
+		}
+	}
+	return false
+}
+
+// FakeRows replays the rows a query selected
+type FakeRows struct {
+	values [][]interface{}
+	pos    int
+}
+
+func (r *FakeRows) Next() bool {
+	if r.pos < len(r.values) {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+func (r *FakeRows) Scan(dest ...interface{}) error { return scanValues(r.values[r.pos-1], dest) }
+func (r *FakeRows) Err() error                     { return nil }
+func (r *FakeRows) Close() error                   { return nil }
+
+// FakeRow replays the first row a query selected
+type FakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r *FakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+// scanValues converts the values into the destinations the way database/sql does
+func scanValues(values []interface{}, dest []interface{}) error {
+	if len(values) != len(dest) {
+		return fmt.Errorf("fake database selected %d columns into %d destinations", len(values), len(dest))
+	}
+	for i, value := range values {
+		if _, ok := value.(unknown); ok {
+			if text, ok := dest[i].(*string); ok {
+				*text = "0"
+				continue
+			}
+			value = nil
+		}
+		var err error
+		switch d := dest[i].(type) {
+		case sql.Scanner:
+			err = d.Scan(value)
+		case *string:
+			err = scanInto(d, value)
+		case *int:
+			err = scanInto(d, value)
+		case *int64:
+			err = scanInto(d, value)
+		case *bool:
+			err = scanInto(d, value)
+		case *[]byte:
+			err = scanInto(d, value)
+		case *time.Time:
+			err = scanInto(d, value)
+		default:
+			err = fmt.Errorf("fake database cannot scan into %T", d)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanInto[T any](dest *T, value interface{}) error {
+	var scanned sql.Null[T]
+	if err := scanned.Scan(value); err != nil {
+		return err
+	}
+	*dest = scanned.V
+	return nil
+}
+
+// fakeResult is the result of a statement changing rows
+type fakeResult struct {
+	lastID, affected int64
+}
+
+func (r fakeResult) LastInsertId() (int64, error) { return r.lastID, nil }
+func (r fakeResult) RowsAffected() (int64, error) { return r.affected, nil }
+
+// fakeTokens proves the subject each of its tokens is issued to
+type fakeTokens map[string]string
+
+func (f fakeTokens) VerifyToken(ctx context.Context, token string) (string, error) {
+	subject, ok := f[token]
+	if !ok {
+		return "", domainAuth.ErrUnauthenticated
+	}
+	return subject, nil
+}
+
+// tokens are the tokens the identity provider issued
+var tokens = fakeTokens{"bob-token": "bob"}
+
+// newTestRouter returns the router of the API over fakeDB
+func newTestRouter(fakeDB *FakeDB) *http.ServeMux {
+	users := auth.NewForLoadingUsersUsingDB(fakeDB)
+	apiKeyService := domainAuth.NewAPIKeyService(auth.NewForSavingAPIKeyUsingDB(fakeDB), auth.NewForLoadingAPIKeysUsingDB(fakeDB), auth.NewForModifyingAPIKeyUsingDB(fakeDB), users, fakeDB)
+	userService := domainAuth.NewUserService(auth.NewForSavingTenantUsingDB(fakeDB), auth.NewForSavingUserUsingDB(fakeDB), users, tokens, fakeDB)
+	idempotencyService := domainIdempotency.NewIdempotencyService(idempotency.NewForSavingRecordUsingDB(fakeDB), idempotency.NewForLoadingRecordUsingDB(fakeDB),
+		idempotency.NewForCompletingRecordUsingDB(fakeDB), idempotency.NewForRemovingRecordsUsingDB(fakeDB), domainIdempotency.DefaultTTL)
+	return newRouter(fakeDB, apiKeyService, userService, idempotencyService)
+}
+
+// serve sends a request on behalf of the principal to the router over fakeDB
+func serve(fakeDB *FakeDB, principal *domainAuth.Principal, method, path, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req = req.WithContext(domainAuth.WithPrincipal(req.Context(), principal))
+	respRecorder := httptest.NewRecorder()
+	newTestRouter(fakeDB).ServeHTTP(respRecorder, req)
+	return respRecorder
+}
+
+// isolationCase is a request to one endpoint, and the statuses it must get
+// when made by a user of another tenant than the one the data it addresses
+// belongs to, and when made by the owner of that data
+type isolationCase struct {
+	method, path, contentType, body string
+	status, ownerStatus             int
+}
+
+// csvImport is a multipart form carrying a CSV statement
+const csvImport = "--b\r\nContent-Disposition: form-data; name=\"file\"; filename=\"s.csv\"\r\n\r\n2024-01-02,Coffee,-3.50\r\n--b--\r\n"
+
+// ofxImport is an OFX statement with a single transaction
+const ofxImport = `<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>EUR<BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240102<TRNAMT>-3.50<FITID>1<NAME>Coffee</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`
+
+// isolationCases covers every endpoint of the API. Those addressing a
+// resource of another tenant must not find it, and references to one in the
+// body are rejected as unknown, as the endpoint rejects any unknown
+// reference. The others must only see the caller's tenant.
+var isolationCases = []isolationCase{
+	{"POST", "/accounts", "", `{"name":"Savings","currency":"EUR","openingBalance":"10.00"}`, http.StatusCreated, http.StatusCreated},
+	{"GET", "/accounts", "", "", http.StatusOK, http.StatusOK},
+	{"GET", "/accounts/1", "", "", http.StatusNotFound, http.StatusOK},
+	{"PATCH", "/accounts/1", "", `{"name":"Mine now"}`, http.StatusNotFound, http.StatusOK},
+	{"DELETE", "/accounts/1", "", "", http.StatusNotFound, http.StatusConflict},
+	{"GET", "/accounts/1/access", "", "", http.StatusNotFound, http.StatusOK},
+	{"POST", "/accounts/1/access", "", `{"userID":"3","role":"editor"}`, http.StatusNotFound, http.StatusCreated},
+	{"PUT", "/accounts/1/access/2", "", `{"role":"editor"}`, http.StatusNotFound, http.StatusOK},
+	{"DELETE", "/accounts/1/access/2", "", "", http.StatusNotFound, http.StatusNoContent},
+	{"GET", "/accounts/1/balance", "", "", http.StatusNotFound, http.StatusOK},
+	{"GET", "/accounts/1/balance?currency=USD", "", "", http.StatusNotFound, http.StatusOK},
+	{"POST", "/accounts/1/imports?profileID=1", "multipart/form-data; boundary=b", csvImport, http.StatusNotFound, http.StatusOK},
+	{"POST", "/accounts/1/statements?format=ofx", "", ofxImport, http.StatusNotFound, http.StatusOK},
+	{"POST", "/import-profiles", "", `{"name":"Bank","dateColumn":0,"dateFormat":"2006-01-02","descriptionColumn":1,"amountColumn":2}`, http.StatusCreated, http.StatusCreated},
+	{"GET", "/import-profiles", "", "", http.StatusOK, http.StatusOK},
+	{"DELETE", "/import-profiles/1", "", "", http.StatusNotFound, http.StatusNoContent},
+	{"POST", "/transactions", "", `{"accountID":"1","amount":"-3.50","currency":"EUR","type":"debit","date":"2024-01-02"}`, http.StatusNotFound, http.StatusCreated},
+	{"GET", "/transactions", "", "", http.StatusOK, http.StatusOK},
+	{"GET", "/transactions/export?format=csv", "", "", http.StatusOK, http.StatusOK},
+	{"GET", "/transactions/duplicates", "", "", http.StatusOK, http.StatusOK},
+	{"POST", "/transactions/duplicates/resolutions", "", `{"action":"dismissed","keptID":"1","duplicateID":"2"}`, http.StatusNotFound, http.StatusCreated},
+	{"GET", "/transactions/duplicates/resolutions", "", "", http.StatusOK, http.StatusOK},
+	{"POST", "/transactions/categorize", "", `{"transactionIDs":["1"],"categoryID":"1"}`, http.StatusUnprocessableEntity, http.StatusOK},
+	{"GET", "/transactions/1", "", "", http.StatusNotFound, http.StatusOK},
+	{"PATCH", "/transactions/1", "", `{"description":"Mine now"}`, http.StatusNotFound, http.StatusOK},
+	{"POST", "/transactions/1/post", "", "", http.StatusNotFound, http.StatusOK},
+	{"POST", "/transactions/1/void", "", "", http.StatusNotFound, http.StatusOK},
+	{"POST", "/transfers", "", `{"fromAccountID":"1","toAccountID":"2","amount":"5.00","currency":"EUR","date":"2024-01-02"}`, http.StatusNotFound, http.StatusCreated},
+	{"POST", "/rules", "", `{"name":"Coffee","accountID":"1","descriptionContains":"coffee","categoryID":"1"}`, http.StatusUnprocessableEntity, http.StatusCreated},
+	{"GET", "/rules", "", "", http.StatusOK, http.StatusOK},
+	{"POST", "/rules/apply", "", `{"from":"2024-01-01","to":"2024-12-31","dryRun":true}`, http.StatusOK, http.StatusOK},
+	{"PUT", "/rules/1", "", `{"name":"Coffee","descriptionContains":"coffee","payee":"Cafe"}`, http.StatusNotFound, http.StatusOK},
+	{"DELETE", "/rules/1", "", "", http.StatusNotFound, http.StatusNoContent},
+	{"POST", "/categories", "", `{"name":"Food","parentID":"1"}`, http.StatusUnprocessableEntity, http.StatusCreated},
+	{"GET", "/categories", "", "", http.StatusOK, http.StatusOK},
+	{"GET", "/categories/1", "", "", http.StatusNotFound, http.StatusOK},
+	{"PATCH", "/categories/1", "", `{"name":"Mine now"}`, http.StatusNotFound, http.StatusOK},
+	{"DELETE", "/categories/1", "", "", http.StatusNotFound, http.StatusConflict},
+	{"GET", "/reports/spending?from=2024-01-01&to=2024-12-31", "", "", http.StatusOK, http.StatusOK},
+	{"GET", "/reports/spending?from=2024-01-01&to=2024-12-31&currency=USD", "", "", http.StatusUnprocessableEntity, http.StatusOK},
+	{"POST", "/budgets", "", `{"name":"Food","amount":"100.00","currency":"EUR","period":"monthly","startDate":"2024-01-01","accountID":"1"}`, http.StatusUnprocessableEntity, http.StatusCreated},
+	{"GET", "/budgets", "", "", http.StatusOK, http.StatusOK},
+	{"GET", "/budgets/1", "", "", http.StatusNotFound, http.StatusOK},
+	{"PUT", "/budgets/1", "", `{"name":"Food","amount":"100.00","currency":"EUR","period":"monthly","startDate":"2024-01-01"}`, http.StatusNotFound, http.StatusOK},
+	{"DELETE", "/budgets/1", "", "", http.StatusNotFound, http.StatusNoContent},
+	{"GET", "/budgets/1/status", "", "", http.StatusNotFound, http.StatusOK},
+	{"GET", "/budgets/1/status?date=2024-01-03&currency=USD", "", "", http.StatusNotFound, http.StatusOK},
+	{"POST", "/exchange-rates/imports", "", "date,base,quote,rate\n2024-01-02,EUR,USD,1.0945\n", http.StatusOK, http.StatusOK},
+	{"GET", "/exchange-rates/convert?amount=1.00&from=EUR&to=USD&date=2024-01-02", "", "", http.StatusNotFound, http.StatusOK},
+	{"POST", "/api-keys", "", `{"name":"CI"}`, http.StatusCreated, http.StatusCreated},
+	{"GET", "/api-keys", "", "", http.StatusOK, http.StatusOK},
+	{"DELETE", "/api-keys/1", "", "", http.StatusNotFound, http.StatusNoContent},
+	{"POST", "/api-keys/1/rotate", "", "", http.StatusNotFound, http.StatusCreated},
+	{"POST", "/users", "", `{"token":"bob-token","name":"Bob"}`, http.StatusCreated, http.StatusCreated},
+	{"GET", "/users", "", "", http.StatusOK, http.StatusOK},
+}
+
+// Test no endpoint reads or changes the data of another tenant: whatever
+// belongs to another tenant is not found, no statement finds, changes or
+// inserts a row of another tenant, and nothing of it shows in a response.
+// The owner of the data makes the same request to show it is there to find.
+func TestRouter_TenantIsolation(t *testing.T) {
+	for _, tc := range isolationCases {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			fakeDB := newFakeDB(otherTenant)
+			respRecorder := serve(fakeDB, mallory, tc.method, tc.path, tc.contentType, tc.body)
+
+			assert.Equal(t, tc.status, respRecorder.Code, respRecorder.Body.String())
+			assert.NotContains(t, respRecorder.Body.String(), secret, "The response shows data of another tenant")
+			assert.Empty(t, fakeDB.Leaks, "A statement reached data of another tenant")
+
+			fakeDB = newFakeDB(ownerTenant)
+			respRecorder = serve(fakeDB, alice, tc.method, tc.path, tc.contentType, tc.body)
+
+			assert.Equal(t, tc.ownerStatus, respRecorder.Code, respRecorder.Body.String())
+			assert.Empty(t, fakeDB.Leaks, "A statement reached data of another tenant")
+		})
+	}
+}
+
+// Test the fake database catches a statement that is not restricted to the
+// caller's tenant, or binds the tenant to the wrong placeholder
+func TestFakeDB_CatchesUnrestrictedStatements(t *testing.T) {
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{"SELECT id, name FROM accounts WHERE tenant_id = ? OR 1=1", []interface{}{otherTenant}},
+		{"SELECT id, name FROM accounts WHERE id = ? AND tenant_id = ?", []interface{}{otherTenant, "1"}},
+		{"UPDATE accounts SET name = ? WHERE id = ?", []interface{}{"Mine now", "1"}},
+	}
+	for _, statement := range statements {
+		fakeDB := newFakeDB(otherTenant)
+		var err error
+		if strings.HasPrefix(statement.query, "SELECT") {
+			_, err = fakeDB.Query(statement.query, statement.args...)
+		} else {
+			_, err = fakeDB.Exec(statement.query, statement.args...)
+		}
+		assert.Nil(t, err)
+		assert.NotEmpty(t, fakeDB.Leaks, "Leak not caught: %s", statement.query)
+	}
+}
+
+// Test an account of the caller's tenant on which the caller has no role
+// is forbidden, with the missing permission explained
+func TestRouter_ForbiddenWithoutRole(t *testing.T) {
+	fakeDB := newFakeDB(otherTenant)
+
+	respRecorder := serve(fakeDB, mallory, http.MethodGet, "/accounts/12", "", "")
+
+	assert.Equal(t, http.StatusForbidden, respRecorder.Code)
+	assert.JSONEq(t, `{"Error":"forbidden: the view permission on account 12 is required, and you have no role on it","AccountID":"12","Permission":"view","Role":""}`, respRecorder.Body.String())
+	assert.Len(t, fakeDB.Statements, 2, "The account should not be loaded")
+}
+
+// Test only a tenant's administrators add users, and only with a subject a
+// token proves, so a subject of another tenant's user-to-be cannot be taken
+func TestRouter_CreateUser(t *testing.T) {
+	cases := []struct {
+		name      string
+		principal *domainAuth.Principal
+		body      string
+		status    int
+	}{
+		{"administrator adds a user", alice, `{"token":"bob-token","name":"Bob"}`, http.StatusCreated},
+		{"member may not add a user", carol, `{"token":"bob-token","name":"Bob"}`, http.StatusForbidden},
+		{"member may not squat a subject", dave, `{"token":"zoe","name":"Zoe"}`, http.StatusForbidden},
+		{"administrator may not squat a subject", alice, `{"token":"zoe","name":"Zoe"}`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeDB := newFakeDB(ownerTenant)
+			respRecorder := serve(fakeDB, tc.principal, http.MethodPost, "/users", "", tc.body)
+
+			assert.Equal(t, tc.status, respRecorder.Code, respRecorder.Body.String())
+			if tc.status != http.StatusCreated {
+				assert.Equal(t, newFakeDB(ownerTenant).Tables["users"], fakeDB.Tables["users"], "No user should be added")
+			}
+		})
+	}
+}
+
+// newGrantsFakeDB returns a FakeDB of ownerTenant where account 2 also holds
+// a likely duplicate pair, and budget 2 only covers account 2
+func newGrantsFakeDB() *FakeDB {
+	fakeDB := newFakeDB(ownerTenant)
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	for _, id := range []int64{3, 4} {
+		fakeDB.Tables["transactions"] = append(fakeDB.Tables["transactions"], row{"id": id, "tenant_id": ownerTenant, "account_id": int64(2), "amount": "-3.50", "currency": "EUR",
+			"transaction_type": "debit", "transaction_date": day(int(id) - 1), "status": "pending", "description": "Tea", "version": int64(1)})
+	}
+	fakeDB.Tables["budgets"][1]["account_id"] = int64(2)
+	fakeDB.Tables["categorization_rules"][1]["account_id"] = int64(2)
+	return fakeDB
+}
+
+// Test duplicates, rule runs, spending reports and budget statuses only draw
+// on the accounts the caller may view, and that merging, dismissing and
+// applying rules is forbidden without the edit permission on every account
+// they would change
+func TestRouter_GrantsOnDerivedData(t *testing.T) {
+	testGrants(t, []grantCase{
+		{"owner finds duplicates of every account", alice, "GET", "/transactions/duplicates", "", http.StatusOK, `"AccountID":"2"`, ""},
+		{"viewer finds none of an account without a role", carol, "GET", "/transactions/duplicates", "", http.StatusOK, "[]", ""},
+		{"viewer may not search an account without a role", carol, "GET", "/transactions/duplicates?accountID=2", "", http.StatusForbidden, `"Permission":"view"`, ""},
+		{"no grant finds no duplicates", dave, "GET", "/transactions/duplicates", "", http.StatusOK, "[]", ""},
+		{"viewer may not dismiss", carol, "POST", "/transactions/duplicates/resolutions", `{"action":"dismissed","keptID":"1","duplicateID":"2"}`, http.StatusForbidden, `"Role":"viewer"`, ""},
+		{"viewer may not merge", carol, "POST", "/transactions/duplicates/resolutions", `{"action":"merged","keptID":"3","duplicateID":"4"}`, http.StatusForbidden, `"AccountID":"2"`, ""},
+		{"no grant may not merge", dave, "POST", "/transactions/duplicates/resolutions", `{"action":"merged","keptID":"3","duplicateID":"4"}`, http.StatusForbidden, `"Permission":"edit"`, ""},
+		{"viewer lists resolutions of a viewable account", carol, "GET", "/transactions/duplicates/resolutions", "", http.StatusOK, `"KeptID":"1"`, ""},
+		{"no grant lists no resolutions", dave, "GET", "/transactions/duplicates/resolutions", "", http.StatusOK, "[]", ""},
+		{"viewer may not apply rules", carol, "POST", "/rules/apply", `{"from":"2024-01-01","to":"2024-12-31"}`, http.StatusForbidden, `"Permission":"edit"`, ""},
+		{"viewer may not apply rules to a viewable account", carol, "POST", "/rules/apply", `{"accountID":"1","from":"2024-01-01","to":"2024-12-31"}`, http.StatusForbidden, `"Role":"viewer"`, ""},
+		{"no grant may not apply rules", dave, "POST", "/rules/apply", `{"from":"2024-01-01","to":"2024-12-31"}`, http.StatusForbidden, `"Permission":"edit"`, ""},
+		{"viewer dry run examines viewable accounts", carol, "POST", "/rules/apply", `{"from":"2024-01-01","to":"2024-12-31","dryRun":true}`, http.StatusOK, `"Examined":2`, ""},
+		{"no grant dry run examines nothing", dave, "POST", "/rules/apply", `{"from":"2024-01-01","to":"2024-12-31","dryRun":true}`, http.StatusOK, `"Examined":0`, ""},
+		{"owner report counts every account", alice, "GET", "/reports/spending?from=2024-01-01&to=2024-12-31", "", http.StatusOK, `"Transactions":4`, ""},
+		{"viewer report counts viewable accounts", carol, "GET", "/reports/spending?from=2024-01-01&to=2024-12-31", "", http.StatusOK, `"Transactions":2`, `"Transactions":4`},
+		{"viewer may not report on an account without a role", carol, "GET", "/reports/spending?from=2024-01-01&to=2024-12-31&accountID=2", "", http.StatusForbidden, `"AccountID":"2"`, ""},
+		{"no grant report counts nothing", dave, "GET", "/reports/spending?from=2024-01-01&to=2024-12-31", "", http.StatusOK, `"Rows":[]`, ""},
+		{"owner status counts every account", alice, "GET", "/budgets/1/status?date=2024-01-03", "", http.StatusOK, `"Spent":{"amount":"14.00"`, ""},
+		{"viewer status counts viewable accounts", carol, "GET", "/budgets/1/status?date=2024-01-03", "", http.StatusOK, `"Spent":{"amount":"7.00"`, ""},
+		{"no grant status counts nothing", dave, "GET", "/budgets/1/status?date=2024-01-03", "", http.StatusOK, `"Spent":{"amount":"0.00"`, ""},
+		{"viewer may not see the status of an account without a role", carol, "GET", "/budgets/2/status?date=2024-01-03", "", http.StatusForbidden, `"AccountID":"2"`, ""},
+	})
+}
+
+// Test rules are only listed to those who may view their account, and only
+// created, changed and deleted by those who may edit every account they could
+// change
+func TestRouter_GrantsOnRules(t *testing.T) {
+	testGrants(t, []grantCase{
+		{"owner lists every rule", alice, "GET", "/rules", "", http.StatusOK, `"AccountID":"2"`, ""},
+		{"viewer lists rules of viewable accounts", carol, "GET", "/rules", "", http.StatusOK, `"ID":"1"`, `"AccountID":"2"`},
+		{"no grant lists rules without an account", dave, "GET", "/rules", "", http.StatusOK, `"ID":"1"`, `"AccountID":"2"`},
+		{"owner creates a rule", alice, "POST", "/rules", `{"name":"Tea","accountID":"2","descriptionContains":"tea","categoryID":"1"}`, http.StatusCreated, `"AccountID":"2"`, ""},
+		{"viewer may not create a rule without an account", carol, "POST", "/rules", `{"name":"Tea","descriptionContains":"tea","categoryID":"1"}`, http.StatusForbidden, `"Permission":"edit"`, ""},
+		{"viewer may not create a rule on a viewable account", carol, "POST", "/rules", `{"name":"Tea","accountID":"1","descriptionContains":"tea","categoryID":"1"}`, http.StatusForbidden, `"Role":"viewer"`, ""},
+		{"no grant may not create a rule", dave, "POST", "/rules", `{"name":"Tea","accountID":"1","descriptionContains":"tea","categoryID":"1"}`, http.StatusForbidden, `"Permission":"edit"`, ""},
+		{"viewer may not change a rule", carol, "PUT", "/rules/1", `{"name":"Tea","accountID":"1","descriptionContains":"tea","categoryID":"1"}`, http.StatusForbidden, `"Permission":"edit"`, ""},
+		{"no grant may not change a rule", dave, "PUT", "/rules/2", `{"name":"Tea","descriptionContains":"tea","categoryID":"1"}`, http.StatusForbidden, `"AccountID":"2"`, ""},
+		{"no grant does not find a missing rule", dave, "PUT", "/rules/99", `{"name":"Tea","descriptionContains":"tea","categoryID":"1"}`, http.StatusNotFound, "", ""},
+		{"viewer may not delete a rule", carol, "DELETE", "/rules/1", "", http.StatusForbidden, `"Permission":"edit"`, ""},
+		{"no grant may not delete a rule", dave, "DELETE", "/rules/2", "", http.StatusForbidden, `"AccountID":"2"`, ""},
+		{"viewer does not find a missing rule", carol, "DELETE", "/rules/99", "", http.StatusNotFound, "", ""},
+	})
+}
+
+// Test budgets of an account are only seen by those who may view it, and only
+// created, changed and deleted by those who may edit it
+func TestRouter_GrantsOnBudgets(t *testing.T) {
+	testGrants(t, []grantCase{
+		{"owner lists every budget", alice, "GET", "/budgets", "", http.StatusOK, `"AccountID":"2"`, ""},
+		{"viewer lists budgets of viewable accounts", carol, "GET", "/budgets", "", http.StatusOK, `"ID":"1"`, `"AccountID":"2"`},
+		{"no grant lists budgets without an account", dave, "GET", "/budgets", "", http.StatusOK, `"ID":"1"`, `"AccountID":"2"`},
+		{"owner gets a budget of an account", alice, "GET", "/budgets/2", "", http.StatusOK, `"AccountID":"2"`, ""},
+		{"viewer may not get a budget of an account without a role", carol, "GET", "/budgets/2", "", http.StatusForbidden, `"Permission":"view"`, ""},
+		{"no grant gets a budget without an account", dave, "GET", "/budgets/1", "", http.StatusOK, `"ID":"1"`, ""},
+		{"no grant does not find a missing budget", dave, "GET", "/budgets/99", "", http.StatusNotFound, "", ""},
+		{"owner creates a budget on an account", alice, "POST", "/budgets", `{"name":"Tea","amount":"10","currency":"EUR","accountID":"2"}`, http.StatusCreated, `"AccountID":"2"`, ""},
+		{"viewer may not create a budget on a viewable account", carol, "POST", "/budgets", `{"name":"Tea","amount":"10","currency":"EUR","accountID":"1"}`, http.StatusForbidden, `"Role":"viewer"`, ""},
+		{"no grant may not create a budget on an account", dave, "POST", "/budgets", `{"name":"Tea","amount":"10","currency":"EUR","accountID":"1"}`, http.StatusForbidden, `"Permission":"edit"`, ""},
+		{"no grant creates a budget without an account", dave, "POST", "/budgets", `{"name":"Tea","amount":"10","currency":"EUR"}`, http.StatusCreated, `"Name":"Tea"`, ""},
+		{"viewer may not change a budget of an account without a role", carol, "PUT", "/budgets/2", `{"name":"Tea","amount":"10","currency":"EUR","accountID":"2"}`, http.StatusForbidden, `"AccountID":"2"`, ""},
+		{"viewer may not move a budget to a viewable account", carol, "PUT", "/budgets/1", `{"name":"Tea","amount":"10","currency":"EUR","accountID":"1"}`, http.StatusForbidden, `"Role":"viewer"`, ""},
+		{"no grant does not find a missing budget to change", dave, "PUT", "/budgets/99", `{"name":"Tea","amount":"10","currency":"EUR"}`, http.StatusNotFound, "", ""},
+		{"viewer may not delete a budget of an account without a role", carol, "DELETE", "/budgets/2", "", http.StatusForbidden, `"AccountID":"2"`, ""},
+		{"no grant may not delete a budget of an account", dave, "DELETE", "/budgets/2", "", http.StatusForbidden, `"Permission":"edit"`, ""},
+		{"viewer does not find a missing budget to delete", carol, "DELETE", "/budgets/99", "", http.StatusNotFound, "", ""},
+	})
+}
+
+// grantCase is a call by a principal with the grants of newGrantsFakeDB, and
+// what it should answer
+type grantCase struct {
+	name           string
+	principal      *domainAuth.Principal
+	method, path   string
+	body           string
+	status         int
+	contains       string
+	doesNotContain string
+}
+
+// testGrants runs the cases, each against a fresh newGrantsFakeDB, checking a
+// forbidden call changes nothing
+func testGrants(t *testing.T, cases []grantCase) {
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeDB := newGrantsFakeDB()
+			respRecorder := serve(fakeDB, tc.principal, tc.method, tc.path, "", tc.body)
+
+			assert.Equal(t, tc.status, respRecorder.Code, respRecorder.Body.String())
+			assert.Contains(t, respRecorder.Body.String(), tc.contains)
+			if tc.doesNotContain != "" {
+				assert.NotContains(t, respRecorder.Body.String(), tc.doesNotContain)
+			}
+			if tc.status == http.StatusForbidden {
+				assert.Equal(t, newGrantsFakeDB().Tables, fakeDB.Tables, "Nothing should change")
+			}
+		})
+	}
+}
+
+// Test every route of the API is covered by the isolation test
+func TestRouter_IsolationCasesCoverEveryRoute(t *testing.T) {
+	router := newTestRouter(newFakeDB(otherTenant))
+	covered := map[string]bool{}
+	for _, tc := range isolationCases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		_, pattern := router.Handler(req)
+		covered[pattern] = true
+	}
+	for _, pattern := range routePatterns(t) {
+		assert.True(t, covered[pattern], "No isolation case for %s", pattern)
+	}
+}
+
+// routePatterns returns the pattern of every route newRouter registers
+func routePatterns(t *testing.T) []string {
+	source, err := os.ReadFile("routes.go")
+	assert.Nil(t, err)
+	var patterns []string
+	for _, match := range regexp.MustCompile(`mux\.Handle\("([^"]+)"`).FindAllStringSubmatch(string(source), -1) {
+		patterns = append(patterns, match[1])
+	}
+	assert.NotEmpty(t, patterns)
+	return patterns
+}
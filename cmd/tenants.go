@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"spend-api/internal/domain/auth"
+	"strings"
+)
+
+const tenantsUsage = "usage: tenants create NAME SUBJECT USER_NAME|add-user TENANT_ID SUBJECT USER_NAME"
+
+// tenantManager is what the tenants subcommands need of the user service
+type tenantManager interface {
+	auth.ForCreatingTenant
+	auth.ForAddingUser
+}
+
+// runTenants runs one of the tenants subcommands. Tenants are only created
+// from the command line: a new tenant has no users yet who could call the
+// API, so it is created together with its first one. Users are added from
+// the command line when they have no token to prove their subject with.
+func runTenants(ctx context.Context, service tenantManager, args []string, out io.Writer) error {
+	if len(args) < 4 {
+		return errors.New(tenantsUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		tenant, user, err := service.CreateTenant(ctx, args[1], args[2], strings.Join(args[3:], " "))
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "created tenant %s (%s) with user %s (%s)\n", tenant.ID, tenant.Name, user.ID, user.Subject)
+		return nil
+	case "add-user":
+		user, err := service.AddUser(ctx, args[1], args[2], strings.Join(args[3:], " "))
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "added user %s (%s) to tenant %s\n", user.ID, user.Subject, user.TenantID)
+		return nil
+	default:
+		return errors.New(tenantsUsage)
+	}
+}
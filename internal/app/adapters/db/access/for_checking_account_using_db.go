@@ -0,0 +1,27 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/infra/db"
+)
+
+// ForCheckingAccountUsingDB is the adapter for checking accounts exist using DB
+type ForCheckingAccountUsingDB struct {
+	db db.Executor
+}
+
+// NewForCheckingAccountUsingDB creates a new DB adapter for checking accounts exist
+func NewForCheckingAccountUsingDB(executor db.Executor) *ForCheckingAccountUsingDB {
+	return &ForCheckingAccountUsingDB{db: executor}
+}
+
+// AccountExists reports whether an account of the tenant with the given ID exists in DB
+func (a *ForCheckingAccountUsingDB) AccountExists(ctx context.Context, tenantID, id string) (bool, error) {
+	var count int
+	err := a.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM accounts WHERE id = ? AND tenant_id = ?", id, tenantID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check account: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,29 @@
+package access
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test checking whether an account of the tenant exists
+func TestForCheckingAccountUsingDB(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{1}}}
+	exists, err := NewForCheckingAccountUsingDB(fakeDB).AccountExists(context.Background(), "1", "3")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "SELECT COUNT(*) FROM accounts WHERE id = ? AND tenant_id = ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"3", "1"}, fakeDB.Args[0])
+
+	exists, err = NewForCheckingAccountUsingDB(&FakeDB{Rows: [][]interface{}{{0}}}).AccountExists(context.Background(), "1", "99")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+}
+
+// Test account check failure
+func TestForCheckingAccountUsingDB_Failure(t *testing.T) {
+	_, err := NewForCheckingAccountUsingDB(&FakeDB{ReturnQueryError: true}).AccountExists(context.Background(), "1", "3")
+
+	assert.Equal(t, "failed to check account: failed to execute query", err.Error())
+}
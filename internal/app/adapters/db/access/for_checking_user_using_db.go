@@ -0,0 +1,27 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/infra/db"
+)
+
+// ForCheckingUserUsingDB is the adapter for checking users exist using DB
+type ForCheckingUserUsingDB struct {
+	db db.Executor
+}
+
+// NewForCheckingUserUsingDB creates a new DB adapter for checking users exist
+func NewForCheckingUserUsingDB(executor db.Executor) *ForCheckingUserUsingDB {
+	return &ForCheckingUserUsingDB{db: executor}
+}
+
+// UserExists reports whether a user of the tenant with the given ID exists in DB
+func (a *ForCheckingUserUsingDB) UserExists(ctx context.Context, tenantID, id string) (bool, error) {
+	var count int
+	err := a.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users WHERE id = ? AND tenant_id = ?", id, tenantID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,29 @@
+package access
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test checking whether a user of the tenant exists
+func TestForCheckingUserUsingDB(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{1}}}
+	exists, err := NewForCheckingUserUsingDB(fakeDB).UserExists(context.Background(), "1", "5")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "SELECT COUNT(*) FROM users WHERE id = ? AND tenant_id = ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"5", "1"}, fakeDB.Args[0])
+
+	exists, err = NewForCheckingUserUsingDB(&FakeDB{Rows: [][]interface{}{{0}}}).UserExists(context.Background(), "1", "99")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+}
+
+// Test user check failure
+func TestForCheckingUserUsingDB_Failure(t *testing.T) {
+	_, err := NewForCheckingUserUsingDB(&FakeDB{ReturnQueryError: true}).UserExists(context.Background(), "1", "5")
+
+	assert.Equal(t, "failed to check user: failed to execute query", err.Error())
+}
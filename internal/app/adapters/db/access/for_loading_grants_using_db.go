@@ -0,0 +1,69 @@
+package access
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/infra/db"
+)
+
+// grantColumns are the account_grants columns scanGrant expects, in order
+const grantColumns = "account_id, user_id, role, granted_by, created_at"
+
+// ForLoadingGrantsUsingDB is the adapter for loading grants using DB
+type ForLoadingGrantsUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingGrantsUsingDB creates a new DB adapter for loading grants
+func NewForLoadingGrantsUsingDB(executor db.Executor) *ForLoadingGrantsUsingDB {
+	return &ForLoadingGrantsUsingDB{db: executor}
+}
+
+// LoadGrant loads the user's grant on the account of the tenant from DB
+func (a *ForLoadingGrantsUsingDB) LoadGrant(ctx context.Context, tenantID, accountID, userID string) (*access.Grant, error) {
+	query := "SELECT " + grantColumns + " FROM account_grants WHERE account_id = ? AND user_id = ? AND tenant_id = ?"
+	grant, err := db.QueryOne(ctx, a.db, scanGrant, query, accountID, userID, tenantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, access.ErrGrantNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load grant: %w", err)
+	}
+	return grant, nil
+}
+
+// LoadGrants loads every grant on the account of the tenant from DB, oldest first
+func (a *ForLoadingGrantsUsingDB) LoadGrants(ctx context.Context, tenantID, accountID string) ([]*access.Grant, error) {
+	query := "SELECT " + grantColumns + " FROM account_grants WHERE account_id = ? AND tenant_id = ? ORDER BY created_at, user_id"
+	grants, err := db.QueryAll(ctx, a.db, scanGrant, query, accountID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load grants: %w", err)
+	}
+	return grants, nil
+}
+
+// LoadUserGrants loads every grant of the user of the tenant from DB, by account
+func (a *ForLoadingGrantsUsingDB) LoadUserGrants(ctx context.Context, tenantID, userID string) ([]*access.Grant, error) {
+	query := "SELECT " + grantColumns + " FROM account_grants WHERE user_id = ? AND tenant_id = ? ORDER BY account_id"
+	grants, err := db.QueryAll(ctx, a.db, scanGrant, query, userID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load grants: %w", err)
+	}
+	return grants, nil
+}
+
+// scanGrant maps an account_grants row onto the domain model
+func scanGrant(row db.Row) (*access.Grant, error) {
+	grant := &access.Grant{}
+	var role string
+	var grantedBy sql.NullString
+	if err := row.Scan(&grant.AccountID, &grant.UserID, &role, &grantedBy, &grant.CreatedAt); err != nil {
+		return nil, err
+	}
+	grant.Role = access.Role(role)
+	grant.GrantedBy = grantedBy.String
+	return grant, nil
+}
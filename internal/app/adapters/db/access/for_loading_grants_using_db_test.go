@@ -0,0 +1,74 @@
+package access
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spend-api/internal/domain/access"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// grantRow returns an account_grants row giving the user a role on account 3
+func grantRow(userID, role string) []interface{} {
+	return []interface{}{"3", userID, role, sql.NullString{String: "alice", Valid: true}, grantCreatedAt}
+}
+
+// Test loading a user's grant on an account
+func TestForLoadingGrantsUsingDB_LoadGrant(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{grantRow("5", "viewer")}}
+
+	grant, err := NewForLoadingGrantsUsingDB(fakeDB).LoadGrant(context.Background(), "1", "3", "5")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &access.Grant{AccountID: "3", UserID: "5", Role: access.RoleViewer, GrantedBy: "alice", CreatedAt: grantCreatedAt}, grant)
+	assert.Equal(t, "SELECT account_id, user_id, role, granted_by, created_at FROM account_grants WHERE account_id = ? AND user_id = ? AND tenant_id = ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"3", "5", "1"}, fakeDB.Args[0])
+}
+
+// Test loading a grant that does not exist
+func TestForLoadingGrantsUsingDB_NotFound(t *testing.T) {
+	_, err := NewForLoadingGrantsUsingDB(&FakeDB{}).LoadGrant(context.Background(), "1", "3", "5")
+
+	assert.True(t, errors.Is(err, access.ErrGrantNotFound), "Expected ErrGrantNotFound")
+}
+
+// Test grant loading failure
+func TestForLoadingGrantsUsingDB_Failure(t *testing.T) {
+	_, err := NewForLoadingGrantsUsingDB(&FakeDB{ReturnQueryError: true}).LoadGrant(context.Background(), "1", "3", "5")
+
+	assert.Equal(t, "failed to load grant: failed to execute query", err.Error())
+}
+
+// Test listing every grant on an account
+func TestForLoadingGrantsUsingDB_LoadGrants(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{grantRow("5", "owner"), grantRow("6", "editor")}}
+
+	grants, err := NewForLoadingGrantsUsingDB(fakeDB).LoadGrants(context.Background(), "1", "3")
+
+	assert.Nil(t, err)
+	assert.Len(t, grants, 2)
+	assert.Equal(t, access.RoleEditor, grants[1].Role)
+	assert.Equal(t, "SELECT account_id, user_id, role, granted_by, created_at FROM account_grants WHERE account_id = ? AND tenant_id = ? ORDER BY created_at, user_id", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"3", "1"}, fakeDB.Args[0])
+}
+
+// Test listing every grant of a user
+func TestForLoadingGrantsUsingDB_LoadUserGrants(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{grantRow("5", "owner")}}
+
+	grants, err := NewForLoadingGrantsUsingDB(fakeDB).LoadUserGrants(context.Background(), "1", "5")
+
+	assert.Nil(t, err)
+	assert.Len(t, grants, 1)
+	assert.Equal(t, "SELECT account_id, user_id, role, granted_by, created_at FROM account_grants WHERE user_id = ? AND tenant_id = ? ORDER BY account_id", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"5", "1"}, fakeDB.Args[0])
+}
+
+// Test grant listing failure
+func TestForLoadingGrantsUsingDB_LoadGrantsFailure(t *testing.T) {
+	_, err := NewForLoadingGrantsUsingDB(&FakeDB{ReturnQueryError: true}).LoadUserGrants(context.Background(), "1", "5")
+
+	assert.Equal(t, "failed to load grants: failed to execute query", err.Error())
+}
@@ -0,0 +1,29 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/infra/db"
+)
+
+// ForModifyingGrantUsingDB is the adapter for modifying grants using DB
+type ForModifyingGrantUsingDB struct {
+	db db.Executor
+}
+
+// NewForModifyingGrantUsingDB creates a new DB adapter for modifying grants
+func NewForModifyingGrantUsingDB(executor db.Executor) *ForModifyingGrantUsingDB {
+	return &ForModifyingGrantUsingDB{db: executor}
+}
+
+// ModifyGrant stores the role of the given grant of the tenant in DB. The grant is loaded in
+// the same unit of work, and setting the role it already has changes no rows, so the rows
+// affected are not checked.
+func (a *ForModifyingGrantUsingDB) ModifyGrant(ctx context.Context, tenantID string, grant *access.Grant) error {
+	query := "UPDATE account_grants SET role = ? WHERE account_id = ? AND user_id = ? AND tenant_id = ?"
+	if _, err := a.db.ExecContext(ctx, query, string(grant.Role), grant.AccountID, grant.UserID, tenantID); err != nil {
+		return fmt.Errorf("failed to modify grant: %w", err)
+	}
+	return nil
+}
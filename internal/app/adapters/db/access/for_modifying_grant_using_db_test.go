@@ -0,0 +1,27 @@
+package access
+
+import (
+	"context"
+	"spend-api/internal/domain/access"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test grant modification success
+func TestForModifyingGrantUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+
+	err := NewForModifyingGrantUsingDB(fakeDB).ModifyGrant(context.Background(), "1", &access.Grant{AccountID: "3", UserID: "5", Role: access.RoleOwner})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "UPDATE account_grants SET role = ? WHERE account_id = ? AND user_id = ? AND tenant_id = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"owner", "3", "5", "1"}, fakeDB.ExecArgs[0])
+}
+
+// Test grant modification failure
+func TestForModifyingGrantUsingDB_Failure(t *testing.T) {
+	err := NewForModifyingGrantUsingDB(&FakeDB{ReturnError: true}).ModifyGrant(context.Background(), "1", &access.Grant{AccountID: "3", UserID: "5"})
+
+	assert.Equal(t, "failed to modify grant: failed to execute query", err.Error())
+}
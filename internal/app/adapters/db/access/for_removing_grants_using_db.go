@@ -0,0 +1,43 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/infra/db"
+)
+
+// ForRemovingGrantsUsingDB is the adapter for removing grants using DB
+type ForRemovingGrantsUsingDB struct {
+	db db.Executor
+}
+
+// NewForRemovingGrantsUsingDB creates a new DB adapter for removing grants
+func NewForRemovingGrantsUsingDB(executor db.Executor) *ForRemovingGrantsUsingDB {
+	return &ForRemovingGrantsUsingDB{db: executor}
+}
+
+// RemoveGrant removes the user's grant on the account of the tenant from DB
+func (a *ForRemovingGrantsUsingDB) RemoveGrant(ctx context.Context, tenantID, accountID, userID string) error {
+	result, err := a.db.ExecContext(ctx, "DELETE FROM account_grants WHERE account_id = ? AND user_id = ? AND tenant_id = ?", accountID, userID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to remove grant: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rows affected: %w", err)
+	}
+	if affected == 0 {
+		return access.ErrGrantNotFound
+	}
+	return nil
+}
+
+// RemoveGrants removes every grant on the account of the tenant from DB
+func (a *ForRemovingGrantsUsingDB) RemoveGrants(ctx context.Context, tenantID, accountID string) error {
+	if _, err := a.db.ExecContext(ctx, "DELETE FROM account_grants WHERE account_id = ? AND tenant_id = ?", accountID, tenantID); err != nil {
+		return fmt.Errorf("failed to remove grants: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/access"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test removing a user's grant on an account
+func TestForRemovingGrantsUsingDB_RemoveGrant(t *testing.T) {
+	fakeDB := &FakeDB{}
+
+	err := NewForRemovingGrantsUsingDB(fakeDB).RemoveGrant(context.Background(), "1", "3", "5")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "DELETE FROM account_grants WHERE account_id = ? AND user_id = ? AND tenant_id = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"3", "5", "1"}, fakeDB.ExecArgs[0])
+}
+
+// Test removing a grant that does not exist
+func TestForRemovingGrantsUsingDB_NotFound(t *testing.T) {
+	err := NewForRemovingGrantsUsingDB(&FakeDB{ReturnNoneAffected: true}).RemoveGrant(context.Background(), "1", "3", "5")
+
+	assert.True(t, errors.Is(err, access.ErrGrantNotFound), "Expected ErrGrantNotFound")
+}
+
+// Test removing every grant on an account
+func TestForRemovingGrantsUsingDB_RemoveGrants(t *testing.T) {
+	fakeDB := &FakeDB{ReturnNoneAffected: true}
+
+	err := NewForRemovingGrantsUsingDB(fakeDB).RemoveGrants(context.Background(), "1", "3")
+
+	assert.Nil(t, err, "An account without grants is no error")
+	assert.Equal(t, "DELETE FROM account_grants WHERE account_id = ? AND tenant_id = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"3", "1"}, fakeDB.ExecArgs[0])
+}
+
+// Test grant removal failure
+func TestForRemovingGrantsUsingDB_Failure(t *testing.T) {
+	err := NewForRemovingGrantsUsingDB(&FakeDB{ReturnError: true}).RemoveGrant(context.Background(), "1", "3", "5")
+	assert.Equal(t, "failed to remove grant: failed to execute query", err.Error())
+
+	err = NewForRemovingGrantsUsingDB(&FakeDB{ReturnError: true}).RemoveGrants(context.Background(), "1", "3")
+	assert.Equal(t, "failed to remove grants: failed to execute query", err.Error())
+}
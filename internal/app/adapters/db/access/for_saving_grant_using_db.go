@@ -0,0 +1,35 @@
+package access
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/infra/db"
+)
+
+// ForSavingGrantUsingDB is the adapter for saving grants using DB
+type ForSavingGrantUsingDB struct {
+	db db.Executor
+}
+
+// NewForSavingGrantUsingDB creates a new DB adapter for saving grants
+func NewForSavingGrantUsingDB(executor db.Executor) *ForSavingGrantUsingDB {
+	return &ForSavingGrantUsingDB{db: executor}
+}
+
+// SaveGrant saves the given grant on an account of the tenant to DB. A user holds at most one
+// grant per account, so a second fails with ErrDuplicateGrant.
+func (a *ForSavingGrantUsingDB) SaveGrant(ctx context.Context, tenantID string, grant *access.Grant) error {
+	grantedBy := sql.NullString{String: grant.GrantedBy, Valid: grant.GrantedBy != ""}
+
+	query := "INSERT INTO account_grants (tenant_id, account_id, user_id, role, granted_by, created_at) VALUES (?, ?, ?, ?, ?, ?)"
+	_, err := a.db.ExecContext(ctx, query, tenantID, grant.AccountID, grant.UserID, string(grant.Role), grantedBy, grant.CreatedAt)
+	if db.IsDuplicateKey(err) {
+		return fmt.Errorf("%w: user %s on account %s", access.ErrDuplicateGrant, grant.UserID, grant.AccountID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save grant: %w", err)
+	}
+	return nil
+}
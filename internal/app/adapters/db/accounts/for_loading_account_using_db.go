@@ -0,0 +1,57 @@
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/infra/db"
+)
+
+// ForLoadingAccountUsingDB is the adapter for loading accounts using DB
+type ForLoadingAccountUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingAccountUsingDB creates a new DB adapter for loading accounts
+func NewForLoadingAccountUsingDB(db db.Executor) *ForLoadingAccountUsingDB {
+	return &ForLoadingAccountUsingDB{db: db}
+}
+
+// LoadAccount loads the account of the tenant with the given ID from DB
+func (a *ForLoadingAccountUsingDB) LoadAccount(ctx context.Context, tenantID, id string) (*accounts.Account, error) {
+	query := "SELECT id, number, name, currency, version FROM accounts WHERE id = ? AND tenant_id = ?"
+	account, err := db.QueryOne(ctx, a.db, scanAccount, query, id, tenantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, accounts.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	return account, nil
+}
+
+// LoadAccounts loads all accounts of the tenant from DB
+func (a *ForLoadingAccountUsingDB) LoadAccounts(ctx context.Context, tenantID string) ([]*accounts.Account, error) {
+	query := "SELECT id, number, name, currency, version FROM accounts WHERE tenant_id = ? ORDER BY id"
+	result, err := db.QueryAll(ctx, a.db, scanAccount, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+	return result, nil
+}
+
+// scanAccount maps an accounts row onto the domain model
+func scanAccount(row db.Row) (*accounts.Account, error) {
+	account := &accounts.Account{}
+	var number sql.NullString
+	var currency string
+	if err := row.Scan(&account.ID, &number, &account.Name, &currency, &account.Version); err != nil {
+		return nil, err
+	}
+	account.Number = number.String
+	account.Currency = money.Currency(currency)
+	return account, nil
+}
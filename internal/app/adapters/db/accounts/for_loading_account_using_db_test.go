@@ -0,0 +1,82 @@
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test loading a single account
+func TestForLoadingAccountUsingDB_LoadAccount(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"1", sql.NullString{String: "GB29NWBK60161331926819", Valid: true}, "Savings", "EUR", 2}}}
+	adapter := NewForLoadingAccountUsingDB(fakeDB)
+
+	account, err := adapter.LoadAccount(context.Background(), "2", "1")
+	assert.Nil(t, err, "Expected no error when loading account")
+	assert.Equal(t, "SELECT id, number, name, currency, version FROM accounts WHERE id = ? AND tenant_id = ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1", "2"}, fakeDB.Args[0])
+	assert.Equal(t, "1", account.ID)
+	assert.Equal(t, "Savings", account.Name)
+	assert.Equal(t, money.Currency("EUR"), account.Currency)
+	assert.Equal(t, "GB29NWBK60161331926819", account.Number)
+	assert.Equal(t, 2, account.Version)
+}
+
+// Test loading an account that does not exist
+func TestForLoadingAccountUsingDB_LoadAccount_NotFound(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForLoadingAccountUsingDB(fakeDB)
+
+	account, err := adapter.LoadAccount(context.Background(), "1", "1")
+	assert.True(t, errors.Is(err, accounts.ErrAccountNotFound), "Expected ErrAccountNotFound")
+	assert.Nil(t, account)
+}
+
+// Test account loading failure
+func TestForLoadingAccountUsingDB_LoadAccount_Failure(t *testing.T) {
+	fakeDB := &FakeDB{ReturnQueryError: true}
+	adapter := NewForLoadingAccountUsingDB(fakeDB)
+
+	_, err := adapter.LoadAccount(context.Background(), "1", "1")
+	assert.NotNil(t, err, "Expected an error when loading account")
+	assert.Equal(t, "failed to load account: failed to execute query", err.Error(), "Expected error message to match")
+}
+
+// Test loading all accounts
+func TestForLoadingAccountUsingDB_LoadAccounts(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"1", sql.NullString{}, "Savings", "EUR", 2}, {"2", sql.NullString{}, "Current", "GBP", 1}}}
+	adapter := NewForLoadingAccountUsingDB(fakeDB)
+
+	result, err := adapter.LoadAccounts(context.Background(), "1")
+	assert.Nil(t, err, "Expected no error when loading accounts")
+	assert.Equal(t, []interface{}{"1"}, fakeDB.Args[0], "Only the tenant's accounts should be listed")
+	assert.Len(t, result, 2)
+	assert.Equal(t, "Current", result[1].Name)
+	assert.Empty(t, result[1].Number, "A NULL account number should load as blank")
+}
+
+// Test loading all accounts when there are none
+func TestForLoadingAccountUsingDB_LoadAccounts_Empty(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForLoadingAccountUsingDB(fakeDB)
+
+	result, err := adapter.LoadAccounts(context.Background(), "1")
+	assert.Nil(t, err, "Expected no error when loading accounts")
+	assert.NotNil(t, result, "Expected an empty, non-nil slice")
+	assert.Empty(t, result)
+}
+
+// Test failure loading all accounts
+func TestForLoadingAccountUsingDB_LoadAccounts_Failure(t *testing.T) {
+	fakeDB := &FakeDB{ReturnQueryError: true}
+	adapter := NewForLoadingAccountUsingDB(fakeDB)
+
+	_, err := adapter.LoadAccounts(context.Background(), "1")
+	assert.NotNil(t, err, "Expected an error when loading accounts")
+	assert.Equal(t, "failed to load accounts: failed to execute query", err.Error(), "Expected error message to match")
+}
@@ -0,0 +1,39 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/infra/db"
+)
+
+// ForModifyingAccountUsingDB is the adapter for persisting account changes using DB
+type ForModifyingAccountUsingDB struct {
+	db db.Executor
+}
+
+// NewForModifyingAccountUsingDB creates a new DB adapter for modifying accounts
+func NewForModifyingAccountUsingDB(db db.Executor) *ForModifyingAccountUsingDB {
+	return &ForModifyingAccountUsingDB{db: db}
+}
+
+// ModifyAccount writes the given account's fields to DB and advances its
+// version, provided the stored account is still at account.Version and
+// belongs to the tenant
+func (a *ForModifyingAccountUsingDB) ModifyAccount(ctx context.Context, tenantID string, account *accounts.Account) error {
+	query := "UPDATE accounts SET name = ?, version = version + 1 WHERE id = ? AND tenant_id = ? AND version = ?"
+	result, err := a.db.ExecContext(ctx, query, account.Name, account.ID, tenantID, account.Version)
+	if err != nil {
+		return fmt.Errorf("failed to modify account: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rows affected: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: account %s is no longer at version %d", accounts.ErrVersionMismatch, account.ID, account.Version)
+	}
+	account.Version++
+	return nil
+}
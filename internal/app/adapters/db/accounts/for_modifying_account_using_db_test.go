@@ -0,0 +1,43 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/accounts"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test successful account modification
+func TestForModifyingAccountUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForModifyingAccountUsingDB(fakeDB)
+
+	account := &accounts.Account{ID: "1", Name: "Holiday fund", Version: 2}
+	err := adapter.ModifyAccount(context.Background(), "1", account)
+	assert.Nil(t, err, "Expected no error when modifying account")
+	assert.Equal(t, []interface{}{"Holiday fund", "1", "1", 2}, fakeDB.ExecArgs[0], "The update should be guarded by the version")
+	assert.Equal(t, 3, account.Version, "The account should move on to the next version")
+}
+
+// Test modifying an account that changed since it was loaded
+func TestForModifyingAccountUsingDB_VersionMismatch(t *testing.T) {
+	fakeDB := &FakeDB{ReturnNoneAffected: true}
+	adapter := NewForModifyingAccountUsingDB(fakeDB)
+
+	account := &accounts.Account{ID: "1", Name: "Holiday fund", Version: 2}
+	err := adapter.ModifyAccount(context.Background(), "1", account)
+	assert.True(t, errors.Is(err, accounts.ErrVersionMismatch), "Expected ErrVersionMismatch")
+	assert.Equal(t, 2, account.Version, "The account should keep its version")
+}
+
+// Test account modification failure
+func TestForModifyingAccountUsingDB_Failure(t *testing.T) {
+	fakeDB := &FakeDB{ReturnError: true}
+	adapter := NewForModifyingAccountUsingDB(fakeDB)
+
+	err := adapter.ModifyAccount(context.Background(), "1", &accounts.Account{ID: "1", Name: "Holiday fund"})
+	assert.NotNil(t, err, "Expected an error when modifying account")
+	assert.Equal(t, "failed to modify account: failed to execute query", err.Error(), "Expected error message to match")
+}
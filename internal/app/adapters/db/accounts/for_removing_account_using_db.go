@@ -0,0 +1,72 @@
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/infra/db"
+)
+
+// ForRemovingAccountUsingDB is the adapter for removing accounts using DB
+type ForRemovingAccountUsingDB struct {
+	db db.Executor
+}
+
+// NewForRemovingAccountUsingDB creates a new DB adapter for removing accounts
+func NewForRemovingAccountUsingDB(db db.Executor) *ForRemovingAccountUsingDB {
+	return &ForRemovingAccountUsingDB{db: db}
+}
+
+// RemoveAccount deletes the account of the tenant with the given ID from DB.
+// The delete is guarded in the same statement so that an account which still
+// has transactions, budgets or rules, or has moved on from a non-zero version,
+// is never removed.
+func (a *ForRemovingAccountUsingDB) RemoveAccount(ctx context.Context, tenantID, id string, version int) error {
+	query := "DELETE FROM accounts WHERE id = ? AND tenant_id = ?" +
+		" AND NOT EXISTS (SELECT 1 FROM transactions WHERE account_id = ?)" +
+		" AND NOT EXISTS (SELECT 1 FROM budgets WHERE account_id = ?)" +
+		" AND NOT EXISTS (SELECT 1 FROM categorization_rules WHERE account_id = ?)"
+	args := []interface{}{id, tenantID, id, id, id}
+	if version != 0 {
+		query += " AND version = ?"
+		args = append(args, version)
+	}
+	result, err := a.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to remove account: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rows affected: %w", err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	// Nothing was deleted, work out whether the account is missing, changed or still in use
+	var stored, count, budgets, rules int
+	query = "SELECT version, (SELECT COUNT(*) FROM transactions WHERE account_id = ?)," +
+		" (SELECT COUNT(*) FROM budgets WHERE account_id = ?)," +
+		" (SELECT COUNT(*) FROM categorization_rules WHERE account_id = ?)" +
+		" FROM accounts WHERE id = ? AND tenant_id = ?"
+	err = a.db.QueryRowContext(ctx, query, id, id, id, id, tenantID).Scan(&stored, &count, &budgets, &rules)
+	if errors.Is(err, sql.ErrNoRows) {
+		return accounts.ErrAccountNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to count account transactions: %w", err)
+	}
+	if version != 0 && stored != version {
+		return fmt.Errorf("%w: account %s is at version %d, not %d", accounts.ErrVersionMismatch, id, stored, version)
+	}
+	if count > 0 {
+		return accounts.ErrAccountHasTransactions
+	}
+	if budgets > 0 || rules > 0 {
+		return accounts.ErrAccountInUse
+	}
+	return accounts.ErrAccountNotFound
+}
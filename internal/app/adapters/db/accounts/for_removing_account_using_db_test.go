@@ -0,0 +1,87 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/accounts"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test successful account removal
+func TestForRemovingAccountUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForRemovingAccountUsingDB(fakeDB)
+
+	err := adapter.RemoveAccount(context.Background(), "1", "1", 0)
+	assert.Nil(t, err, "Expected no error when removing account")
+	assert.Empty(t, fakeDB.Queries, "No follow-up query expected when the delete succeeds")
+}
+
+// Test removing an account that still has transactions
+func TestForRemovingAccountUsingDB_HasTransactions(t *testing.T) {
+	fakeDB := &FakeDB{ReturnNoneAffected: true, Rows: [][]interface{}{{1, 3, 0, 0}}}
+	adapter := NewForRemovingAccountUsingDB(fakeDB)
+
+	err := adapter.RemoveAccount(context.Background(), "1", "1", 0)
+	assert.True(t, errors.Is(err, accounts.ErrAccountHasTransactions), "Expected ErrAccountHasTransactions")
+}
+
+// Test removing an account that a budget still refers to
+func TestForRemovingAccountUsingDB_HasBudgets(t *testing.T) {
+	fakeDB := &FakeDB{ReturnNoneAffected: true, Rows: [][]interface{}{{1, 0, 2, 0}}}
+	adapter := NewForRemovingAccountUsingDB(fakeDB)
+
+	err := adapter.RemoveAccount(context.Background(), "1", "1", 0)
+	assert.True(t, errors.Is(err, accounts.ErrAccountInUse), "Expected ErrAccountInUse")
+	assert.Contains(t, fakeDB.Queries[0], "(SELECT COUNT(*) FROM budgets WHERE account_id = ?)")
+}
+
+// Test removing an account that a categorisation rule still refers to
+func TestForRemovingAccountUsingDB_HasRules(t *testing.T) {
+	fakeDB := &FakeDB{ReturnNoneAffected: true, Rows: [][]interface{}{{1, 0, 0, 1}}}
+	adapter := NewForRemovingAccountUsingDB(fakeDB)
+
+	err := adapter.RemoveAccount(context.Background(), "1", "1", 0)
+	assert.True(t, errors.Is(err, accounts.ErrAccountInUse), "Expected ErrAccountInUse")
+	assert.Contains(t, fakeDB.Queries[0], "(SELECT COUNT(*) FROM categorization_rules WHERE account_id = ?)")
+}
+
+// Test removing an account that does not exist
+func TestForRemovingAccountUsingDB_NotFound(t *testing.T) {
+	fakeDB := &FakeDB{ReturnNoneAffected: true}
+	adapter := NewForRemovingAccountUsingDB(fakeDB)
+
+	err := adapter.RemoveAccount(context.Background(), "1", "1", 0)
+	assert.True(t, errors.Is(err, accounts.ErrAccountNotFound), "Expected ErrAccountNotFound")
+}
+
+// Test removing an account guarded by a version checks it in the delete itself
+func TestForRemovingAccountUsingDB_Version(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForRemovingAccountUsingDB(fakeDB)
+
+	err := adapter.RemoveAccount(context.Background(), "1", "1", 4)
+	assert.Nil(t, err, "Expected no error when removing the current version")
+	assert.Equal(t, []interface{}{"1", "1", "1", "1", "1", 4}, fakeDB.ExecArgs[0])
+}
+
+// Test removing an account that has moved on from the given version
+func TestForRemovingAccountUsingDB_VersionMismatch(t *testing.T) {
+	fakeDB := &FakeDB{ReturnNoneAffected: true, Rows: [][]interface{}{{5, 0, 0, 0}}}
+	adapter := NewForRemovingAccountUsingDB(fakeDB)
+
+	err := adapter.RemoveAccount(context.Background(), "1", "1", 4)
+	assert.True(t, errors.Is(err, accounts.ErrVersionMismatch), "Expected ErrVersionMismatch")
+}
+
+// Test account removal failure
+func TestForRemovingAccountUsingDB_Failure(t *testing.T) {
+	fakeDB := &FakeDB{ReturnError: true}
+	adapter := NewForRemovingAccountUsingDB(fakeDB)
+
+	err := adapter.RemoveAccount(context.Background(), "1", "1", 0)
+	assert.NotNil(t, err, "Expected an error when removing account")
+	assert.Equal(t, "failed to remove account: failed to execute query", err.Error(), "Expected error message to match")
+}
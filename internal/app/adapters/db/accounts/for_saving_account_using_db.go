@@ -1,6 +1,8 @@
 package accounts
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"spend-api/internal/domain/accounts"
 	"spend-api/internal/infra/db"
@@ -16,10 +18,10 @@ func NewForSavingAccountUsingDB(db db.Executor) *ForSavingAccountUsingDB {
 	return &ForSavingAccountUsingDB{db: db}
 }
 
-// SaveAccount saves the given account to DB
-func (a *ForSavingAccountUsingDB) SaveAccount(account *accounts.Account) error {
-	query := "INSERT INTO accounts (name) VALUES (?)"
-	result, err := a.db.Exec(query, account.Name)
+// SaveAccount saves the given account of the tenant to DB
+func (a *ForSavingAccountUsingDB) SaveAccount(ctx context.Context, tenantID string, account *accounts.Account) error {
+	query := "INSERT INTO accounts (tenant_id, number, name, currency) VALUES (?, ?, ?, ?)"
+	result, err := a.db.ExecContext(ctx, query, tenantID, nullableString(account.Number), account.Name, string(account.Currency))
 	if err != nil {
 		return fmt.Errorf("failed to save account: %w", err)
 	}
@@ -31,3 +33,8 @@ func (a *ForSavingAccountUsingDB) SaveAccount(account *accounts.Account) error {
 	account.ID = fmt.Sprintf("%d", id)
 	return nil
 }
+
+// nullableString stores an empty string as NULL
+func nullableString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
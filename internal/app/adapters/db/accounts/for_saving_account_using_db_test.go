@@ -1,9 +1,12 @@
 package accounts
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"reflect"
 	"spend-api/internal/domain/accounts"
+	"spend-api/internal/infra/db"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,28 +14,109 @@ import (
 
 // FakeDB for simulating DB behavior
 type FakeDB struct {
-	ReturnError       bool
-	ReturnInsertError bool
+	ReturnError        bool
+	ReturnInsertError  bool
+	ReturnNoneAffected bool
+	ReturnQueryError   bool
+	Rows               [][]interface{}
+	Queries            []string
+	Args               [][]interface{}
+	ExecArgs           [][]interface{}
 }
 
 func (f *FakeDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	f.ExecArgs = append(f.ExecArgs, args)
 	if f.ReturnError {
 		return nil, errors.New("failed to execute query")
 	}
 	if f.ReturnInsertError {
 		return &MockFailedResult{}, nil
+	} else if f.ReturnNoneAffected {
+		return &MockEmptyResult{}, nil
 	} else {
 		return &MockResult{}, nil
 	}
 
 }
 
+func (f *FakeDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return f.Exec(query, args...)
+}
+
+func (f *FakeDB) QueryContext(ctx context.Context, query string, args ...interface{}) (db.Rows, error) {
+	return f.Query(query, args...)
+}
+
+func (f *FakeDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) db.Row {
+	return f.QueryRow(query, args...)
+}
+
+func (f *FakeDB) Query(query string, args ...interface{}) (db.Rows, error) {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return nil, errors.New("failed to execute query")
+	}
+	return &FakeRows{rows: f.Rows}, nil
+}
+
+func (f *FakeDB) QueryRow(query string, args ...interface{}) db.Row {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return &FakeRow{err: errors.New("failed to execute query")}
+	}
+	if len(f.Rows) == 0 {
+		return &FakeRow{err: sql.ErrNoRows}
+	}
+	return &FakeRow{values: f.Rows[0]}
+}
+
 func (f *FakeDB) Close() error {
 	return nil
 }
 
+// FakeRows replays canned rows for queries
+type FakeRows struct {
+	rows [][]interface{}
+	pos  int
+}
+
+func (r *FakeRows) Next() bool {
+	if r.pos < len(r.rows) {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+func (r *FakeRows) Scan(dest ...interface{}) error { return scanValues(r.rows[r.pos-1], dest) }
+func (r *FakeRows) Err() error                     { return nil }
+func (r *FakeRows) Close() error                   { return nil }
+
+// FakeRow replays a single canned row
+type FakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r *FakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+func scanValues(values []interface{}, dest []interface{}) error {
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(values[i]))
+	}
+	return nil
+}
+
 type MockResult struct{}
 type MockFailedResult struct{}
+type MockEmptyResult struct{}
 
 func (r *MockResult) LastInsertId() (int64, error) { return 0, nil }
 func (r *MockResult) RowsAffected() (int64, error) { return 1, nil }
@@ -42,6 +126,9 @@ func (r *MockFailedResult) LastInsertId() (int64, error) {
 }
 func (r *MockFailedResult) RowsAffected() (int64, error) { return 1, nil }
 
+func (r *MockEmptyResult) LastInsertId() (int64, error) { return 0, nil }
+func (r *MockEmptyResult) RowsAffected() (int64, error) { return 0, nil }
+
 // Test successful account saving
 func TestForSavingAccountUsingDB_Success(t *testing.T) {
 	fakeDB := &FakeDB{}
@@ -52,8 +139,25 @@ func TestForSavingAccountUsingDB_Success(t *testing.T) {
 		Name: "John Doe",
 	}
 
-	err := adapter.SaveAccount(account)
+	err := adapter.SaveAccount(context.Background(), "1", account)
+	assert.Nil(t, err, "Expected no error when saving account")
+	assert.Equal(t, []interface{}{"1", sql.NullString{}, "John Doe", ""}, fakeDB.ExecArgs[0], "A missing account number should be stored as NULL")
+}
+
+// Test saving an account with a number and currency
+func TestForSavingAccountUsingDB_WithNumber(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForSavingAccountUsingDB(fakeDB)
+
+	account := &accounts.Account{
+		Number:   "GB29NWBK60161331926819",
+		Name:     "John Doe",
+		Currency: "GBP",
+	}
+
+	err := adapter.SaveAccount(context.Background(), "1", account)
 	assert.Nil(t, err, "Expected no error when saving account")
+	assert.Equal(t, []interface{}{"1", sql.NullString{String: "GB29NWBK60161331926819", Valid: true}, "John Doe", "GBP"}, fakeDB.ExecArgs[0])
 }
 
 // Test account saving failure
@@ -66,7 +170,7 @@ func TestForSavingAccountUsingDB_Failure(t *testing.T) {
 		Name: "John Doe",
 	}
 
-	err := adapter.SaveAccount(account)
+	err := adapter.SaveAccount(context.Background(), "1", account)
 	assert.NotNil(t, err, "Expected an error when saving account")
 	assert.Equal(t, "failed to save account: failed to execute query", err.Error(), "Expected error message to match")
 }
@@ -81,7 +185,7 @@ func TestForSavingAccountUsingDB_InsertIdFailure(t *testing.T) {
 		Name: "John Doe",
 	}
 
-	err := adapter.SaveAccount(account)
+	err := adapter.SaveAccount(context.Background(), "1", account)
 	assert.NotNil(t, err, "Expected an error when saving account")
 	assert.Equal(t, "failed to retrieve last insert ID: failed to execute query", err.Error(), "Expected error message to match")
 }
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/infra/db"
+)
+
+// apiKeyColumns are the api_keys columns scanAPIKey expects, in order
+const apiKeyColumns = "id, tenant_id, user_id, name, prefix, secret_hash, created_by, created_at, revoked_at"
+
+// ForLoadingAPIKeysUsingDB is the adapter for loading API keys using DB
+type ForLoadingAPIKeysUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingAPIKeysUsingDB creates a new DB adapter for loading API keys
+func NewForLoadingAPIKeysUsingDB(executor db.Executor) *ForLoadingAPIKeysUsingDB {
+	return &ForLoadingAPIKeysUsingDB{db: executor}
+}
+
+// LoadAPIKey loads the API key of the tenant with the given ID from DB
+func (a *ForLoadingAPIKeysUsingDB) LoadAPIKey(ctx context.Context, tenantID, id string) (*auth.APIKey, error) {
+	return a.loadOne(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE id = ? AND tenant_id = ?", id, tenantID)
+}
+
+// LoadAPIKeyByPrefix loads the API key with the given prefix from DB, whichever tenant it
+// belongs to
+func (a *ForLoadingAPIKeysUsingDB) LoadAPIKeyByPrefix(ctx context.Context, prefix string) (*auth.APIKey, error) {
+	return a.loadOne(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE prefix = ?", prefix)
+}
+
+// LoadAPIKeys loads all API keys of the tenant from DB, oldest first
+func (a *ForLoadingAPIKeysUsingDB) LoadAPIKeys(ctx context.Context, tenantID string) ([]*auth.APIKey, error) {
+	keys, err := db.QueryAll(ctx, a.db, scanAPIKey, "SELECT "+apiKeyColumns+" FROM api_keys WHERE tenant_id = ? ORDER BY id", tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// loadOne loads the single API key the query selects, if there is one
+func (a *ForLoadingAPIKeysUsingDB) loadOne(ctx context.Context, query string, args ...interface{}) (*auth.APIKey, error) {
+	key, err := db.QueryOne(ctx, a.db, scanAPIKey, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, auth.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load API key: %w", err)
+	}
+	return key, nil
+}
+
+// scanAPIKey maps an api_keys row onto the domain model
+func scanAPIKey(row db.Row) (*auth.APIKey, error) {
+	key := &auth.APIKey{}
+	var createdBy sql.NullString
+	var revokedAt sql.NullTime
+	if err := row.Scan(&key.ID, &key.TenantID, &key.UserID, &key.Name, &key.Prefix, &key.SecretHash, &createdBy, &key.CreatedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	key.CreatedBy = createdBy.String
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return key, nil
+}
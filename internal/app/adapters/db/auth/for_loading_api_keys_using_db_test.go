@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spend-api/internal/domain/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	keyCreatedAt = time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+	keyRevokedAt = time.Date(2024, 2, 1, 8, 0, 0, 0, time.UTC)
+)
+
+func apiKeyRow(id string, revokedAt sql.NullTime) []interface{} {
+	return []interface{}{id, "1", "5", "CI", "0123456789ab", "f00d", sql.NullString{String: "alice", Valid: true}, keyCreatedAt, revokedAt}
+}
+
+// Test loading an API key by ID
+func TestForLoadingAPIKeysUsingDB_LoadAPIKey(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{apiKeyRow("3", sql.NullTime{Time: keyRevokedAt, Valid: true})}}
+
+	key, err := NewForLoadingAPIKeysUsingDB(fakeDB).LoadAPIKey(context.Background(), "1", "3")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &auth.APIKey{ID: "3", TenantID: "1", UserID: "5", Name: "CI", Prefix: "0123456789ab", SecretHash: "f00d", CreatedBy: "alice", CreatedAt: keyCreatedAt, RevokedAt: &keyRevokedAt}, key)
+	assert.Equal(t, "SELECT id, tenant_id, user_id, name, prefix, secret_hash, created_by, created_at, revoked_at FROM api_keys WHERE id = ? AND tenant_id = ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"3", "1"}, fakeDB.Args[0])
+}
+
+// Test loading an API key by its prefix
+func TestForLoadingAPIKeysUsingDB_LoadAPIKeyByPrefix(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{apiKeyRow("3", sql.NullTime{})}}
+
+	key, err := NewForLoadingAPIKeysUsingDB(fakeDB).LoadAPIKeyByPrefix(context.Background(), "0123456789ab")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "3", key.ID)
+	assert.Nil(t, key.RevokedAt)
+	assert.Equal(t, "SELECT id, tenant_id, user_id, name, prefix, secret_hash, created_by, created_at, revoked_at FROM api_keys WHERE prefix = ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"0123456789ab"}, fakeDB.Args[0])
+}
+
+// Test loading an API key that does not exist
+func TestForLoadingAPIKeysUsingDB_NotFound(t *testing.T) {
+	_, err := NewForLoadingAPIKeysUsingDB(&FakeDB{}).LoadAPIKey(context.Background(), "1", "3")
+
+	assert.True(t, errors.Is(err, auth.ErrAPIKeyNotFound), "Expected ErrAPIKeyNotFound")
+}
+
+// Test API key loading failure
+func TestForLoadingAPIKeysUsingDB_Failure(t *testing.T) {
+	_, err := NewForLoadingAPIKeysUsingDB(&FakeDB{ReturnQueryError: true}).LoadAPIKeyByPrefix(context.Background(), "0123456789ab")
+
+	assert.Equal(t, "failed to load API key: failed to execute query", err.Error())
+}
+
+// Test listing all API keys
+func TestForLoadingAPIKeysUsingDB_LoadAPIKeys(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{apiKeyRow("1", sql.NullTime{}), apiKeyRow("2", sql.NullTime{})}}
+
+	keys, err := NewForLoadingAPIKeysUsingDB(fakeDB).LoadAPIKeys(context.Background(), "1")
+
+	assert.Nil(t, err)
+	assert.Len(t, keys, 2)
+	assert.Equal(t, "2", keys[1].ID)
+	assert.Equal(t, "SELECT id, tenant_id, user_id, name, prefix, secret_hash, created_by, created_at, revoked_at FROM api_keys WHERE tenant_id = ? ORDER BY id", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1"}, fakeDB.Args[0])
+}
+
+// Test API key listing failure
+func TestForLoadingAPIKeysUsingDB_LoadAPIKeysFailure(t *testing.T) {
+	_, err := NewForLoadingAPIKeysUsingDB(&FakeDB{ReturnQueryError: true}).LoadAPIKeys(context.Background(), "1")
+
+	assert.Equal(t, "failed to load API keys: failed to execute query", err.Error())
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/infra/db"
+)
+
+// userColumns are the users columns scanUser expects, in order
+const userColumns = "id, tenant_id, subject, name, admin, created_at"
+
+// ForLoadingUsersUsingDB is the adapter for loading users using DB
+type ForLoadingUsersUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingUsersUsingDB creates a new DB adapter for loading users
+func NewForLoadingUsersUsingDB(executor db.Executor) *ForLoadingUsersUsingDB {
+	return &ForLoadingUsersUsingDB{db: executor}
+}
+
+// LoadUser loads the user of the tenant with the given ID from DB
+func (a *ForLoadingUsersUsingDB) LoadUser(ctx context.Context, tenantID, id string) (*auth.User, error) {
+	return a.loadOne(ctx, "SELECT "+userColumns+" FROM users WHERE id = ? AND tenant_id = ?", id, tenantID)
+}
+
+// LoadUserBySubject loads the user with the given subject from DB, whichever tenant they
+// belong to
+func (a *ForLoadingUsersUsingDB) LoadUserBySubject(ctx context.Context, subject string) (*auth.User, error) {
+	return a.loadOne(ctx, "SELECT "+userColumns+" FROM users WHERE subject = ?", subject)
+}
+
+// LoadUsers loads all users of the tenant from DB, oldest first
+func (a *ForLoadingUsersUsingDB) LoadUsers(ctx context.Context, tenantID string) ([]*auth.User, error) {
+	users, err := db.QueryAll(ctx, a.db, scanUser, "SELECT "+userColumns+" FROM users WHERE tenant_id = ? ORDER BY id", tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load users: %w", err)
+	}
+	return users, nil
+}
+
+// loadOne loads the single user the query selects, if there is one
+func (a *ForLoadingUsersUsingDB) loadOne(ctx context.Context, query string, args ...interface{}) (*auth.User, error) {
+	user, err := db.QueryOne(ctx, a.db, scanUser, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, auth.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	return user, nil
+}
+
+// scanUser maps a users row onto the domain model
+func scanUser(row db.Row) (*auth.User, error) {
+	user := &auth.User{}
+	if err := row.Scan(&user.ID, &user.TenantID, &user.Subject, &user.Name, &user.Admin, &user.CreatedAt); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func userRow(id string) []interface{} {
+	return []interface{}{id, "1", "alice", "Alice", true, keyCreatedAt}
+}
+
+// Test loading a user of a tenant by ID
+func TestForLoadingUsersUsingDB_LoadUser(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{userRow("5")}}
+
+	user, err := NewForLoadingUsersUsingDB(fakeDB).LoadUser(context.Background(), "1", "5")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &auth.User{ID: "5", TenantID: "1", Subject: "alice", Name: "Alice", Admin: true, CreatedAt: keyCreatedAt}, user)
+	assert.Equal(t, "SELECT id, tenant_id, subject, name, admin, created_at FROM users WHERE id = ? AND tenant_id = ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"5", "1"}, fakeDB.Args[0])
+}
+
+// Test loading a user by subject, whichever tenant they belong to
+func TestForLoadingUsersUsingDB_LoadUserBySubject(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{userRow("5")}}
+
+	user, err := NewForLoadingUsersUsingDB(fakeDB).LoadUserBySubject(context.Background(), "alice")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "5", user.ID)
+	assert.Equal(t, "SELECT id, tenant_id, subject, name, admin, created_at FROM users WHERE subject = ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"alice"}, fakeDB.Args[0])
+}
+
+// Test loading a user that does not exist
+func TestForLoadingUsersUsingDB_NotFound(t *testing.T) {
+	_, err := NewForLoadingUsersUsingDB(&FakeDB{}).LoadUserBySubject(context.Background(), "alice")
+
+	assert.True(t, errors.Is(err, auth.ErrUserNotFound), "Expected ErrUserNotFound")
+}
+
+// Test user loading failure
+func TestForLoadingUsersUsingDB_Failure(t *testing.T) {
+	_, err := NewForLoadingUsersUsingDB(&FakeDB{ReturnQueryError: true}).LoadUser(context.Background(), "1", "5")
+
+	assert.Equal(t, "failed to load user: failed to execute query", err.Error())
+}
+
+// Test listing the users of a tenant
+func TestForLoadingUsersUsingDB_LoadUsers(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{userRow("5"), userRow("6")}}
+
+	users, err := NewForLoadingUsersUsingDB(fakeDB).LoadUsers(context.Background(), "1")
+
+	assert.Nil(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, "SELECT id, tenant_id, subject, name, admin, created_at FROM users WHERE tenant_id = ? ORDER BY id", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1"}, fakeDB.Args[0])
+}
+
+// Test user listing failure
+func TestForLoadingUsersUsingDB_LoadUsersFailure(t *testing.T) {
+	_, err := NewForLoadingUsersUsingDB(&FakeDB{ReturnQueryError: true}).LoadUsers(context.Background(), "1")
+
+	assert.Equal(t, "failed to load users: failed to execute query", err.Error())
+}
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/infra/db"
+)
+
+// ForModifyingAPIKeyUsingDB is the adapter for modifying API keys using DB
+type ForModifyingAPIKeyUsingDB struct {
+	db db.Executor
+}
+
+// NewForModifyingAPIKeyUsingDB creates a new DB adapter for modifying API keys
+func NewForModifyingAPIKeyUsingDB(executor db.Executor) *ForModifyingAPIKeyUsingDB {
+	return &ForModifyingAPIKeyUsingDB{db: executor}
+}
+
+// ModifyAPIKey stores the name and revocation of the given API key of the tenant in DB. The
+// key itself never changes.
+func (a *ForModifyingAPIKeyUsingDB) ModifyAPIKey(ctx context.Context, tenantID string, key *auth.APIKey) error {
+	revokedAt := sql.NullTime{}
+	if key.RevokedAt != nil {
+		revokedAt = sql.NullTime{Time: *key.RevokedAt, Valid: true}
+	}
+
+	result, err := a.db.ExecContext(ctx, "UPDATE api_keys SET name = ?, revoked_at = ? WHERE id = ? AND tenant_id = ?", key.Name, revokedAt, key.ID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to modify API key: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rows affected: %w", err)
+	}
+	if affected == 0 {
+		return auth.ErrAPIKeyNotFound
+	}
+	return nil
+}
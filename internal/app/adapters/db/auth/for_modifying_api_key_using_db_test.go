@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spend-api/internal/domain/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test revoking an API key stores when it was revoked
+func TestForModifyingAPIKeyUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	revokedAt := time.Date(2024, 2, 1, 8, 0, 0, 0, time.UTC)
+
+	err := NewForModifyingAPIKeyUsingDB(fakeDB).ModifyAPIKey(context.Background(), "1", &auth.APIKey{ID: "3", Name: "CI", RevokedAt: &revokedAt})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "UPDATE api_keys SET name = ?, revoked_at = ? WHERE id = ? AND tenant_id = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"CI", sql.NullTime{Time: revokedAt, Valid: true}, "3", "1"}, fakeDB.ExecArgs[0])
+}
+
+// Test modifying an API key that does not exist
+func TestForModifyingAPIKeyUsingDB_NotFound(t *testing.T) {
+	err := NewForModifyingAPIKeyUsingDB(&FakeDB{ReturnNoneAffected: true}).ModifyAPIKey(context.Background(), "1", &auth.APIKey{ID: "3"})
+
+	assert.True(t, errors.Is(err, auth.ErrAPIKeyNotFound), "Expected ErrAPIKeyNotFound")
+}
+
+// Test API key modifying failure
+func TestForModifyingAPIKeyUsingDB_Failure(t *testing.T) {
+	err := NewForModifyingAPIKeyUsingDB(&FakeDB{ReturnError: true}).ModifyAPIKey(context.Background(), "1", &auth.APIKey{ID: "3"})
+
+	assert.Equal(t, "failed to modify API key: failed to execute query", err.Error())
+}
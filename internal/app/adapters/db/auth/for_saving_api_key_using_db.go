@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/infra/db"
+)
+
+// ForSavingAPIKeyUsingDB is the adapter for saving API keys using DB
+type ForSavingAPIKeyUsingDB struct {
+	db db.Executor
+}
+
+// NewForSavingAPIKeyUsingDB creates a new DB adapter for saving API keys
+func NewForSavingAPIKeyUsingDB(executor db.Executor) *ForSavingAPIKeyUsingDB {
+	return &ForSavingAPIKeyUsingDB{db: executor}
+}
+
+// SaveAPIKey saves the given API key of the tenant to DB. The prefix is unique, so a key whose
+// prefix is already taken fails with ErrDuplicateAPIKey.
+func (a *ForSavingAPIKeyUsingDB) SaveAPIKey(ctx context.Context, tenantID string, key *auth.APIKey) error {
+	query := "INSERT INTO api_keys (tenant_id, user_id, name, prefix, secret_hash, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+	result, err := a.db.ExecContext(ctx, query, tenantID, key.UserID, key.Name, key.Prefix, key.SecretHash, nullableString(key.CreatedBy), key.CreatedAt)
+	if db.IsDuplicateKey(err) {
+		return fmt.Errorf("%w: %s", auth.ErrDuplicateAPIKey, key.Prefix)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save API key: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve last insert ID: %w", err)
+	}
+	key.ID = fmt.Sprintf("%d", id)
+	return nil
+}
+
+// nullableString stores an empty string as NULL
+func nullableString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/infra/db"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeDB for simulating DB behavior
+type FakeDB struct {
+	ReturnError        bool
+	ReturnInsertError  bool
+	ReturnNoneAffected bool
+	ReturnQueryError   bool
+	ExecErr            error
+	Rows               [][]interface{}
+	Queries            []string
+	Args               [][]interface{}
+	ExecQueries        []string
+	ExecArgs           [][]interface{}
+}
+
+func (f *FakeDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	f.ExecQueries = append(f.ExecQueries, query)
+	f.ExecArgs = append(f.ExecArgs, args)
+	if f.ReturnError {
+		return nil, errors.New("failed to execute query")
+	}
+	if f.ExecErr != nil {
+		return nil, f.ExecErr
+	}
+	if f.ReturnInsertError {
+		return &MockFailedResult{}, nil
+	}
+	if f.ReturnNoneAffected {
+		return &MockEmptyResult{}, nil
+	}
+	return &MockResult{}, nil
+}
+
+func (f *FakeDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return f.Exec(query, args...)
+}
+
+func (f *FakeDB) QueryContext(ctx context.Context, query string, args ...interface{}) (db.Rows, error) {
+	return f.Query(query, args...)
+}
+
+func (f *FakeDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) db.Row {
+	return f.QueryRow(query, args...)
+}
+
+func (f *FakeDB) Query(query string, args ...interface{}) (db.Rows, error) {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return nil, errors.New("failed to execute query")
+	}
+	return &FakeRows{rows: f.Rows}, nil
+}
+
+func (f *FakeDB) QueryRow(query string, args ...interface{}) db.Row {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return &FakeRow{err: errors.New("failed to execute query")}
+	}
+	if len(f.Rows) == 0 {
+		return &FakeRow{err: sql.ErrNoRows}
+	}
+	return &FakeRow{values: f.Rows[0]}
+}
+
+func (f *FakeDB) Close() error {
+	return nil
+}
+
+// FakeRows replays canned rows for queries
+type FakeRows struct {
+	rows [][]interface{}
+	pos  int
+}
+
+func (r *FakeRows) Next() bool {
+	if r.pos < len(r.rows) {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+func (r *FakeRows) Scan(dest ...interface{}) error { return scanValues(r.rows[r.pos-1], dest) }
+func (r *FakeRows) Err() error                     { return nil }
+func (r *FakeRows) Close() error                   { return nil }
+
+// FakeRow replays a single canned row
+type FakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r *FakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+func scanValues(values []interface{}, dest []interface{}) error {
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(values[i]))
+	}
+	return nil
+}
+
+type MockResult struct{}
+type MockFailedResult struct{}
+type MockEmptyResult struct{}
+
+func (r *MockResult) LastInsertId() (int64, error) { return 7, nil }
+func (r *MockResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (r *MockFailedResult) LastInsertId() (int64, error) {
+	return 0, errors.New("failed to execute query")
+}
+func (r *MockFailedResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (r *MockEmptyResult) LastInsertId() (int64, error) { return 0, nil }
+func (r *MockEmptyResult) RowsAffected() (int64, error) { return 0, nil }
+
+// Test API key saving success
+func TestForSavingAPIKeyUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	createdAt := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+	key := &auth.APIKey{UserID: "5", Name: "CI", Prefix: "0123456789ab", SecretHash: "f00d", CreatedBy: "alice", CreatedAt: createdAt}
+
+	err := NewForSavingAPIKeyUsingDB(fakeDB).SaveAPIKey(context.Background(), "1", key)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "7", key.ID)
+	assert.Equal(t, "INSERT INTO api_keys (tenant_id, user_id, name, prefix, secret_hash, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"1", "5", "CI", "0123456789ab", "f00d", sql.NullString{String: "alice", Valid: true}, createdAt}, fakeDB.ExecArgs[0])
+}
+
+// Test a key created without a principal stores NULL as its creator
+func TestForSavingAPIKeyUsingDB_NoCreator(t *testing.T) {
+	fakeDB := &FakeDB{}
+
+	err := NewForSavingAPIKeyUsingDB(fakeDB).SaveAPIKey(context.Background(), "1", &auth.APIKey{Name: "CI"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, sql.NullString{}, fakeDB.ExecArgs[0][5])
+}
+
+// Test saving a key whose prefix is taken
+func TestForSavingAPIKeyUsingDB_DuplicatePrefix(t *testing.T) {
+	fakeDB := &FakeDB{ExecErr: &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}}
+
+	err := NewForSavingAPIKeyUsingDB(fakeDB).SaveAPIKey(context.Background(), "1", &auth.APIKey{Name: "CI"})
+
+	assert.True(t, errors.Is(err, auth.ErrDuplicateAPIKey), "Expected ErrDuplicateAPIKey")
+}
+
+// Test API key saving failure
+func TestForSavingAPIKeyUsingDB_Failure(t *testing.T) {
+	err := NewForSavingAPIKeyUsingDB(&FakeDB{ReturnError: true}).SaveAPIKey(context.Background(), "1", &auth.APIKey{Name: "CI"})
+
+	assert.Equal(t, "failed to save API key: failed to execute query", err.Error())
+}
+
+// Test a failure to retrieve the new key's ID
+func TestForSavingAPIKeyUsingDB_InsertIDFailure(t *testing.T) {
+	err := NewForSavingAPIKeyUsingDB(&FakeDB{ReturnInsertError: true}).SaveAPIKey(context.Background(), "1", &auth.APIKey{Name: "CI"})
+
+	assert.Equal(t, "failed to retrieve last insert ID: failed to execute query", err.Error())
+}
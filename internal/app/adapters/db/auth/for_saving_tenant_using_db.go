@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/infra/db"
+)
+
+// ForSavingTenantUsingDB is the adapter for saving tenants using DB
+type ForSavingTenantUsingDB struct {
+	db db.Executor
+}
+
+// NewForSavingTenantUsingDB creates a new DB adapter for saving tenants
+func NewForSavingTenantUsingDB(executor db.Executor) *ForSavingTenantUsingDB {
+	return &ForSavingTenantUsingDB{db: executor}
+}
+
+// SaveTenant saves the given tenant to DB
+func (a *ForSavingTenantUsingDB) SaveTenant(ctx context.Context, tenant *auth.Tenant) error {
+	result, err := a.db.ExecContext(ctx, "INSERT INTO tenants (name, created_at) VALUES (?, ?)", tenant.Name, tenant.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save tenant: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve last insert ID: %w", err)
+	}
+	tenant.ID = fmt.Sprintf("%d", id)
+	return nil
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+	"spend-api/internal/domain/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test tenant saving success
+func TestForSavingTenantUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	createdAt := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+	tenant := &auth.Tenant{Name: "Smiths", CreatedAt: createdAt}
+
+	err := NewForSavingTenantUsingDB(fakeDB).SaveTenant(context.Background(), tenant)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "7", tenant.ID)
+	assert.Equal(t, "INSERT INTO tenants (name, created_at) VALUES (?, ?)", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"Smiths", createdAt}, fakeDB.ExecArgs[0])
+}
+
+// Test tenant saving failure
+func TestForSavingTenantUsingDB_Failure(t *testing.T) {
+	err := NewForSavingTenantUsingDB(&FakeDB{ReturnError: true}).SaveTenant(context.Background(), &auth.Tenant{Name: "Smiths"})
+
+	assert.Equal(t, "failed to save tenant: failed to execute query", err.Error())
+}
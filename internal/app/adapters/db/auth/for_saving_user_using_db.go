@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/infra/db"
+)
+
+// ForSavingUserUsingDB is the adapter for saving users using DB
+type ForSavingUserUsingDB struct {
+	db db.Executor
+}
+
+// NewForSavingUserUsingDB creates a new DB adapter for saving users
+func NewForSavingUserUsingDB(executor db.Executor) *ForSavingUserUsingDB {
+	return &ForSavingUserUsingDB{db: executor}
+}
+
+// SaveUser saves the given user of the tenant to DB. Subjects are unique, so a user whose
+// subject is already taken fails with ErrDuplicateUser.
+func (a *ForSavingUserUsingDB) SaveUser(ctx context.Context, tenantID string, user *auth.User) error {
+	query := "INSERT INTO users (tenant_id, subject, name, admin, created_at) VALUES (?, ?, ?, ?, ?)"
+	result, err := a.db.ExecContext(ctx, query, tenantID, user.Subject, user.Name, user.Admin, user.CreatedAt)
+	if db.IsDuplicateKey(err) {
+		return fmt.Errorf("%w: %s", auth.ErrDuplicateUser, user.Subject)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve last insert ID: %w", err)
+	}
+	user.ID = fmt.Sprintf("%d", id)
+	return nil
+}
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/auth"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test user saving success
+func TestForSavingUserUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	createdAt := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+	user := &auth.User{Subject: "alice", Name: "Alice", Admin: true, CreatedAt: createdAt}
+
+	err := NewForSavingUserUsingDB(fakeDB).SaveUser(context.Background(), "1", user)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "7", user.ID)
+	assert.Equal(t, "INSERT INTO users (tenant_id, subject, name, admin, created_at) VALUES (?, ?, ?, ?, ?)", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"1", "alice", "Alice", true, createdAt}, fakeDB.ExecArgs[0])
+}
+
+// Test saving a user whose subject is taken
+func TestForSavingUserUsingDB_DuplicateSubject(t *testing.T) {
+	fakeDB := &FakeDB{ExecErr: &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}}
+
+	err := NewForSavingUserUsingDB(fakeDB).SaveUser(context.Background(), "1", &auth.User{Subject: "alice"})
+
+	assert.True(t, errors.Is(err, auth.ErrDuplicateUser), "Expected ErrDuplicateUser")
+}
+
+// Test user saving failure
+func TestForSavingUserUsingDB_Failure(t *testing.T) {
+	err := NewForSavingUserUsingDB(&FakeDB{ReturnError: true}).SaveUser(context.Background(), "1", &auth.User{Subject: "alice"})
+
+	assert.Equal(t, "failed to save user: failed to execute query", err.Error())
+}
@@ -0,0 +1,32 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test looking up an account's currency
+func TestForLoadingAccountCurrencyUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"GBP"}}}
+
+	currency, err := NewForLoadingAccountCurrencyUsingDB(fakeDB).LoadAccountCurrency(context.Background(), "1", "12345")
+	assert.Nil(t, err)
+	assert.Equal(t, money.Currency("GBP"), currency)
+}
+
+// Test looking up the currency of an account that does not exist
+func TestForLoadingAccountCurrencyUsingDB_NotFound(t *testing.T) {
+	_, err := NewForLoadingAccountCurrencyUsingDB(&FakeDB{}).LoadAccountCurrency(context.Background(), "1", "12345")
+	assert.True(t, errors.Is(err, budgets.ErrAccountNotFound), "Expected ErrAccountNotFound")
+}
+
+// Test account currency lookup failure
+func TestForLoadingAccountCurrencyUsingDB_Failure(t *testing.T) {
+	_, err := NewForLoadingAccountCurrencyUsingDB(&FakeDB{ReturnQueryError: true}).LoadAccountCurrency(context.Background(), "1", "12345")
+	assert.Equal(t, "failed to load account currency: failed to execute query", err.Error(), "Expected error message to match")
+}
@@ -0,0 +1,71 @@
+package budgets
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/infra/db"
+)
+
+// budgetColumns lists the columns scanBudget expects, in order
+const budgetColumns = "id, " + budgetWriteColumns
+
+// ForLoadingBudgetUsingDB is the adapter for loading budgets using DB
+type ForLoadingBudgetUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingBudgetUsingDB creates a new DB adapter for loading budgets
+func NewForLoadingBudgetUsingDB(executor db.Executor) *ForLoadingBudgetUsingDB {
+	return &ForLoadingBudgetUsingDB{db: executor}
+}
+
+// LoadBudget loads the tenant's budget with the given ID from DB
+func (a *ForLoadingBudgetUsingDB) LoadBudget(ctx context.Context, tenantID, id string) (*budgets.Budget, error) {
+	query := "SELECT " + budgetColumns + " FROM budgets WHERE id = ? AND tenant_id = ?"
+	budget, err := db.QueryOne(ctx, a.db, scanBudget, query, id, tenantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, budgets.ErrBudgetNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load budget: %w", err)
+	}
+	return budget, nil
+}
+
+// LoadBudgets loads all budgets of the tenant from DB
+func (a *ForLoadingBudgetUsingDB) LoadBudgets(ctx context.Context, tenantID string) ([]*budgets.Budget, error) {
+	query := "SELECT " + budgetColumns + " FROM budgets WHERE tenant_id = ? ORDER BY id"
+	result, err := db.QueryAll(ctx, a.db, scanBudget, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load budgets: %w", err)
+	}
+	return result, nil
+}
+
+// scanBudget maps a budgets row onto the domain model
+func scanBudget(row db.Row) (*budgets.Budget, error) {
+	budget := &budgets.Budget{}
+	var amount, currency, period string
+	var endDate sql.NullTime
+	var accountID, kind, pattern sql.NullString
+	err := row.Scan(&budget.ID, &budget.Name, &amount, &currency, &period, &budget.StartDate, &endDate, &accountID, &kind, &pattern, &budget.Rollover)
+	if err != nil {
+		return nil, err
+	}
+	budget.Period = budgets.Period(period)
+	if endDate.Valid {
+		end := endDate.Time
+		budget.EndDate = &end
+	}
+	budget.AccountID = accountID.String
+	budget.Type = kind.String
+	budget.DescriptionPattern = pattern.String
+	if budget.Amount, err = money.Parse(amount, money.Currency(currency)); err != nil {
+		return nil, fmt.Errorf("invalid amount stored for budget %s: %w", budget.ID, err)
+	}
+	return budget, nil
+}
@@ -0,0 +1,67 @@
+package budgets
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func budgetRow(id string, endDate sql.NullTime) []interface{} {
+	start := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	return []interface{}{id, "Holiday", "1000.0000", "EUR", "custom", start, endDate, sql.NullString{}, sql.NullString{String: "debit", Valid: true},
+		sql.NullString{String: "(?i)hotel", Valid: true}, false}
+}
+
+// Test loading a single budget
+func TestForLoadingBudgetUsingDB_LoadBudget(t *testing.T) {
+	end := time.Date(2024, 8, 14, 0, 0, 0, 0, time.UTC)
+	fakeDB := &FakeDB{Rows: [][]interface{}{budgetRow("1", sql.NullTime{Time: end, Valid: true})}}
+	adapter := NewForLoadingBudgetUsingDB(fakeDB)
+
+	budget, err := adapter.LoadBudget(context.Background(), "1", "1")
+	assert.Nil(t, err, "Expected no error when loading budget")
+	assert.Equal(t, "SELECT id, name, amount, currency, period, start_date, end_date, account_id, transaction_type, description_pattern, rollover"+
+		" FROM budgets WHERE id = ? AND tenant_id = ?", fakeDB.Queries[0])
+	assert.Equal(t, money.MustParse("1000.00", "EUR"), budget.Amount)
+	assert.Equal(t, budgets.PeriodCustom, budget.Period)
+	assert.Equal(t, &end, budget.EndDate)
+	assert.Empty(t, budget.AccountID, "A NULL account should load as blank")
+	assert.Equal(t, "debit", budget.Type)
+	assert.Equal(t, "(?i)hotel", budget.DescriptionPattern)
+}
+
+// Test loading a budget that does not exist
+func TestForLoadingBudgetUsingDB_LoadBudget_NotFound(t *testing.T) {
+	budget, err := NewForLoadingBudgetUsingDB(&FakeDB{}).LoadBudget(context.Background(), "1", "1")
+	assert.True(t, errors.Is(err, budgets.ErrBudgetNotFound), "Expected ErrBudgetNotFound")
+	assert.Nil(t, budget)
+}
+
+// Test budget loading failure
+func TestForLoadingBudgetUsingDB_LoadBudget_Failure(t *testing.T) {
+	_, err := NewForLoadingBudgetUsingDB(&FakeDB{ReturnQueryError: true}).LoadBudget(context.Background(), "1", "1")
+	assert.Equal(t, "failed to load budget: failed to execute query", err.Error(), "Expected error message to match")
+}
+
+// Test loading all budgets
+func TestForLoadingBudgetUsingDB_LoadBudgets(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{budgetRow("1", sql.NullTime{}), budgetRow("2", sql.NullTime{})}}
+
+	result, err := NewForLoadingBudgetUsingDB(fakeDB).LoadBudgets(context.Background(), "1")
+	assert.Nil(t, err, "Expected no error when loading budgets")
+	assert.Len(t, result, 2)
+	assert.Equal(t, "2", result[1].ID)
+	assert.Nil(t, result[1].EndDate, "A NULL end date should load as nil")
+}
+
+// Test loading budgets failure
+func TestForLoadingBudgetUsingDB_LoadBudgets_Failure(t *testing.T) {
+	_, err := NewForLoadingBudgetUsingDB(&FakeDB{ReturnQueryError: true}).LoadBudgets(context.Background(), "1")
+	assert.Equal(t, "failed to load budgets: failed to execute query", err.Error(), "Expected error message to match")
+}
@@ -0,0 +1,77 @@
+package budgets
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+	"strings"
+)
+
+// ForLoadingExpensesUsingDB is the adapter for loading the expenses counted against budgets using DB
+type ForLoadingExpensesUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingExpensesUsingDB creates a new DB adapter for loading the expenses counted against budgets
+func NewForLoadingExpensesUsingDB(executor db.Executor) *ForLoadingExpensesUsingDB {
+	return &ForLoadingExpensesUsingDB{db: executor}
+}
+
+// LoadExpenses reads the tenant's transactions selected by the query from DB
+// in date order, handing each to each as it arrives. Voided transactions are
+// left out. Unless the query names a type, only outgoing payments and refunds
+// are read, and transfers between accounts never are.
+func (a *ForLoadingExpensesUsingDB) LoadExpenses(ctx context.Context, tenantID string, query budgets.ExpenseQuery, each func(*budgets.Expense) error) error {
+	conditions := []string{"tenant_id = ?", "currency = ?", "transaction_date BETWEEN ? AND ?", "status <> ?"}
+	args := []interface{}{tenantID, string(query.Currency), query.From, query.To, string(transactions.StatusVoided)}
+	if query.AccountID != "" {
+		conditions = append(conditions, "account_id = ?")
+		args = append(args, query.AccountID)
+	}
+	if query.AccountIDs != nil {
+		if len(query.AccountIDs) == 0 {
+			// No account's spending may be counted
+			conditions = append(conditions, "FALSE")
+		} else {
+			conditions = append(conditions, "account_id IN (?"+strings.Repeat(", ?", len(query.AccountIDs)-1)+")")
+			for _, id := range query.AccountIDs {
+				args = append(args, id)
+			}
+		}
+	}
+	if query.Type != "" {
+		conditions = append(conditions, "transaction_type = ?")
+		args = append(args, query.Type)
+	} else {
+		conditions = append(conditions, "transaction_type NOT IN (?, ?)", "(amount < 0 OR transaction_type = ?)")
+		args = append(args, string(transactions.KindTransferIn), string(transactions.KindTransferOut), string(transactions.KindRefund))
+	}
+
+	statement := "SELECT transaction_date, description, amount FROM transactions WHERE " + strings.Join(conditions, " AND ") +
+		" ORDER BY transaction_date, id"
+	scan := func(row db.Row) (*budgets.Expense, error) {
+		return scanExpense(row, query.Currency)
+	}
+	err := db.QueryEach(ctx, a.db, scan, each, statement, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load expenses: %w", err)
+	}
+	return nil
+}
+
+// scanExpense maps a transactions row onto an expense in the given currency
+func scanExpense(row db.Row, currency money.Currency) (*budgets.Expense, error) {
+	expense := &budgets.Expense{}
+	var amount string
+	if err := row.Scan(&expense.Date, &expense.Description, &amount); err != nil {
+		return nil, err
+	}
+	var err error
+	if expense.Amount, err = money.Parse(amount, currency); err != nil {
+		return nil, fmt.Errorf("invalid expense amount: %w", err)
+	}
+	return expense, nil
+}
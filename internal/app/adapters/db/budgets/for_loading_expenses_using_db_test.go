@@ -0,0 +1,68 @@
+package budgets
+
+import (
+	"context"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test loading expenses without a type selects outgoing payments and refunds, but no transfers
+func TestForLoadingExpensesUsingDB_AnyType(t *testing.T) {
+	from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)
+	fakeDB := &FakeDB{Rows: [][]interface{}{{from, "TESCO STORES", "-60.0000"}, {to, "Refund", "10.0000"}}}
+
+	var loaded []*budgets.Expense
+	query := budgets.ExpenseQuery{AccountID: "12345", Currency: "EUR", From: from, To: to}
+	err := NewForLoadingExpensesUsingDB(fakeDB).LoadExpenses(context.Background(), "1", query, func(expense *budgets.Expense) error {
+		loaded = append(loaded, expense)
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT transaction_date, description, amount FROM transactions WHERE tenant_id = ? AND currency = ? AND transaction_date BETWEEN ? AND ?"+
+		" AND status <> ? AND account_id = ? AND transaction_type NOT IN (?, ?) AND (amount < 0 OR transaction_type = ?)"+
+		" ORDER BY transaction_date, id", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1", "EUR", from, to, "voided", "12345", "transfer_in", "transfer_out", "refund"}, fakeDB.Args[0])
+	assert.Equal(t, []*budgets.Expense{
+		{Date: from, Description: "TESCO STORES", Amount: money.MustParse("-60.00", "EUR")},
+		{Date: to, Description: "Refund", Amount: money.MustParse("10.00", "EUR")},
+	}, loaded)
+}
+
+// Test loading expenses of a single type
+func TestForLoadingExpensesUsingDB_Type(t *testing.T) {
+	fakeDB := &FakeDB{}
+	query := budgets.ExpenseQuery{Type: "fee", Currency: "GBP"}
+
+	err := NewForLoadingExpensesUsingDB(fakeDB).LoadExpenses(context.Background(), "1", query, func(*budgets.Expense) error { return nil })
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT transaction_date, description, amount FROM transactions WHERE tenant_id = ? AND currency = ? AND transaction_date BETWEEN ? AND ?"+
+		" AND status <> ? AND transaction_type = ? ORDER BY transaction_date, id", fakeDB.Queries[0])
+}
+
+// Test loading expenses of some accounts only, or of none at all
+func TestForLoadingExpensesUsingDB_RestrictedAccounts(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForLoadingExpensesUsingDB(fakeDB)
+
+	err := adapter.LoadExpenses(context.Background(), "1", budgets.ExpenseQuery{Type: "fee", Currency: "GBP", AccountIDs: []string{"1", "2"}}, func(*budgets.Expense) error { return nil })
+	assert.Nil(t, err)
+	assert.Contains(t, fakeDB.Queries[0], " AND status <> ? AND account_id IN (?, ?) AND transaction_type = ?")
+	assert.Equal(t, []interface{}{"1", "GBP", time.Time{}, time.Time{}, "voided", "1", "2", "fee"}, fakeDB.Args[0])
+
+	err = adapter.LoadExpenses(context.Background(), "1", budgets.ExpenseQuery{Type: "fee", Currency: "GBP", AccountIDs: []string{}}, func(*budgets.Expense) error { return nil })
+	assert.Nil(t, err)
+	assert.Contains(t, fakeDB.Queries[1], " AND status <> ? AND FALSE AND transaction_type = ?")
+}
+
+// Test expense loading failure
+func TestForLoadingExpensesUsingDB_Failure(t *testing.T) {
+	err := NewForLoadingExpensesUsingDB(&FakeDB{ReturnQueryError: true}).LoadExpenses(context.Background(), "1", budgets.ExpenseQuery{}, func(*budgets.Expense) error { return nil })
+	assert.Equal(t, "failed to load expenses: failed to execute query", err.Error(), "Expected error message to match")
+}
@@ -0,0 +1,30 @@
+package budgets
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/infra/db"
+	"strings"
+)
+
+// ForModifyingBudgetUsingDB is the adapter for persisting budget changes using DB
+type ForModifyingBudgetUsingDB struct {
+	db db.Executor
+}
+
+// NewForModifyingBudgetUsingDB creates a new DB adapter for modifying budgets
+func NewForModifyingBudgetUsingDB(executor db.Executor) *ForModifyingBudgetUsingDB {
+	return &ForModifyingBudgetUsingDB{db: executor}
+}
+
+// ModifyBudget writes all of the given budget's fields to DB, provided it belongs to the tenant
+func (a *ForModifyingBudgetUsingDB) ModifyBudget(ctx context.Context, tenantID string, budget *budgets.Budget) error {
+	assignments := strings.ReplaceAll(budgetWriteColumns, ",", " = ?,") + " = ?"
+	query := "UPDATE budgets SET " + assignments + " WHERE id = ? AND tenant_id = ?"
+	_, err := a.db.ExecContext(ctx, query, append(budgetArgs(budget), budget.ID, tenantID)...)
+	if err != nil {
+		return fmt.Errorf("failed to modify budget: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package budgets
+
+import (
+	"context"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test modifying a budget writes every field
+func TestForModifyingBudgetUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	budget := &budgets.Budget{ID: "3", Name: "Groceries", Amount: money.MustParse("450.00", "EUR"), Period: budgets.PeriodMonthly}
+
+	err := NewForModifyingBudgetUsingDB(fakeDB).ModifyBudget(context.Background(), "1", budget)
+	assert.Nil(t, err, "Expected no error when modifying budget")
+	assert.Equal(t, "UPDATE budgets SET name = ?, amount = ?, currency = ?, period = ?, start_date = ?, end_date = ?, account_id = ?,"+
+		" transaction_type = ?, description_pattern = ?, rollover = ? WHERE id = ? AND tenant_id = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"3", "1"}, fakeDB.ExecArgs[0][10:])
+}
+
+// Test budget modifying failure
+func TestForModifyingBudgetUsingDB_Failure(t *testing.T) {
+	budget := &budgets.Budget{ID: "3", Name: "Groceries", Amount: money.MustParse("450.00", "EUR")}
+
+	err := NewForModifyingBudgetUsingDB(&FakeDB{ReturnError: true}).ModifyBudget(context.Background(), "1", budget)
+	assert.Equal(t, "failed to modify budget: failed to execute query", err.Error(), "Expected error message to match")
+}
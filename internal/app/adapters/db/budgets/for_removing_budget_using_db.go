@@ -0,0 +1,35 @@
+package budgets
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/infra/db"
+)
+
+// ForRemovingBudgetUsingDB is the adapter for removing budgets using DB
+type ForRemovingBudgetUsingDB struct {
+	db db.Executor
+}
+
+// NewForRemovingBudgetUsingDB creates a new DB adapter for removing budgets
+func NewForRemovingBudgetUsingDB(executor db.Executor) *ForRemovingBudgetUsingDB {
+	return &ForRemovingBudgetUsingDB{db: executor}
+}
+
+// RemoveBudget deletes the tenant's budget with the given ID from DB
+func (a *ForRemovingBudgetUsingDB) RemoveBudget(ctx context.Context, tenantID, id string) error {
+	result, err := a.db.ExecContext(ctx, "DELETE FROM budgets WHERE id = ? AND tenant_id = ?", id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to remove budget: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rows affected: %w", err)
+	}
+	if affected == 0 {
+		return budgets.ErrBudgetNotFound
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/budgets"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test removing a budget
+func TestForRemovingBudgetUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+
+	err := NewForRemovingBudgetUsingDB(fakeDB).RemoveBudget(context.Background(), "1", "3")
+	assert.Nil(t, err, "Expected no error when removing budget")
+	assert.Equal(t, []interface{}{"3", "1"}, fakeDB.ExecArgs[0])
+}
+
+// Test removing a budget that does not exist
+func TestForRemovingBudgetUsingDB_NotFound(t *testing.T) {
+	err := NewForRemovingBudgetUsingDB(&FakeDB{ReturnNoneAffected: true}).RemoveBudget(context.Background(), "1", "3")
+	assert.True(t, errors.Is(err, budgets.ErrBudgetNotFound), "Expected ErrBudgetNotFound")
+}
+
+// Test budget removing failure
+func TestForRemovingBudgetUsingDB_Failure(t *testing.T) {
+	err := NewForRemovingBudgetUsingDB(&FakeDB{ReturnError: true}).RemoveBudget(context.Background(), "1", "3")
+	assert.Equal(t, "failed to remove budget: failed to execute query", err.Error(), "Expected error message to match")
+}
@@ -0,0 +1,60 @@
+package budgets
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/infra/db"
+	"time"
+)
+
+// ForSavingBudgetUsingDB is the adapter for saving budgets using DB
+type ForSavingBudgetUsingDB struct {
+	db db.Executor
+}
+
+// NewForSavingBudgetUsingDB creates a new DB adapter for saving budgets
+func NewForSavingBudgetUsingDB(executor db.Executor) *ForSavingBudgetUsingDB {
+	return &ForSavingBudgetUsingDB{db: executor}
+}
+
+// SaveBudget saves the given budget of the tenant to DB
+func (a *ForSavingBudgetUsingDB) SaveBudget(ctx context.Context, tenantID string, budget *budgets.Budget) error {
+	query := "INSERT INTO budgets (tenant_id, " + budgetWriteColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := a.db.ExecContext(ctx, query, append([]interface{}{tenantID}, budgetArgs(budget)...)...)
+	if err != nil {
+		return fmt.Errorf("failed to save budget: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve last insert ID: %w", err)
+	}
+	budget.ID = fmt.Sprintf("%d", id)
+	return nil
+}
+
+// budgetWriteColumns lists the columns budgetArgs fills, in order
+const budgetWriteColumns = "name, amount, currency, period, start_date, end_date, account_id, transaction_type, description_pattern, rollover"
+
+// budgetArgs flattens a budget into the values of budgetWriteColumns, storing
+// unset filters as NULL
+func budgetArgs(budget *budgets.Budget) []interface{} {
+	return []interface{}{budget.Name, budget.Amount.String(), string(budget.Amount.Currency()), string(budget.Period), budget.StartDate,
+		nullableDate(budget.EndDate), nullableString(budget.AccountID), nullableString(budget.Type), nullableString(budget.DescriptionPattern),
+		budget.Rollover}
+}
+
+// nullableDate stores a missing date as NULL
+func nullableDate(date *time.Time) sql.NullTime {
+	if date == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *date, Valid: true}
+}
+
+// nullableString stores an empty string as NULL
+func nullableString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
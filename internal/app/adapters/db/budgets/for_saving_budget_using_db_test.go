@@ -0,0 +1,167 @@
+package budgets
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/infra/db"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeDB for simulating DB behavior
+type FakeDB struct {
+	ReturnError        bool
+	ReturnInsertError  bool
+	ReturnNoneAffected bool
+	ReturnQueryError   bool
+	Rows               [][]interface{}
+	Queries            []string
+	Args               [][]interface{}
+	ExecQueries        []string
+	ExecArgs           [][]interface{}
+}
+
+func (f *FakeDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	f.ExecQueries = append(f.ExecQueries, query)
+	f.ExecArgs = append(f.ExecArgs, args)
+	if f.ReturnError {
+		return nil, errors.New("failed to execute query")
+	}
+	if f.ReturnInsertError {
+		return &MockFailedResult{}, nil
+	}
+	if f.ReturnNoneAffected {
+		return &MockEmptyResult{}, nil
+	}
+	return &MockResult{}, nil
+}
+
+func (f *FakeDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return f.Exec(query, args...)
+}
+
+func (f *FakeDB) QueryContext(ctx context.Context, query string, args ...interface{}) (db.Rows, error) {
+	return f.Query(query, args...)
+}
+
+func (f *FakeDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) db.Row {
+	return f.QueryRow(query, args...)
+}
+
+func (f *FakeDB) Query(query string, args ...interface{}) (db.Rows, error) {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return nil, errors.New("failed to execute query")
+	}
+	return &FakeRows{rows: f.Rows}, nil
+}
+
+func (f *FakeDB) QueryRow(query string, args ...interface{}) db.Row {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return &FakeRow{err: errors.New("failed to execute query")}
+	}
+	if len(f.Rows) == 0 {
+		return &FakeRow{err: sql.ErrNoRows}
+	}
+	return &FakeRow{values: f.Rows[0]}
+}
+
+func (f *FakeDB) Close() error {
+	return nil
+}
+
+// FakeRows replays canned rows for queries
+type FakeRows struct {
+	rows [][]interface{}
+	pos  int
+}
+
+func (r *FakeRows) Next() bool {
+	if r.pos < len(r.rows) {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+func (r *FakeRows) Scan(dest ...interface{}) error { return scanValues(r.rows[r.pos-1], dest) }
+func (r *FakeRows) Err() error                     { return nil }
+func (r *FakeRows) Close() error                   { return nil }
+
+// FakeRow replays a single canned row
+type FakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r *FakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+func scanValues(values []interface{}, dest []interface{}) error {
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(values[i]))
+	}
+	return nil
+}
+
+type MockResult struct{}
+type MockFailedResult struct{}
+type MockEmptyResult struct{}
+
+func (r *MockResult) LastInsertId() (int64, error) { return 7, nil }
+func (r *MockResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (r *MockFailedResult) LastInsertId() (int64, error) {
+	return 0, errors.New("failed to execute query")
+}
+func (r *MockFailedResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (r *MockEmptyResult) LastInsertId() (int64, error) { return 0, nil }
+func (r *MockEmptyResult) RowsAffected() (int64, error) { return 0, nil }
+
+// Test saving a monthly budget stores unset filters as NULL
+func TestForSavingBudgetUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForSavingBudgetUsingDB(fakeDB)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	budget := &budgets.Budget{Name: "Groceries", Amount: money.MustParse("400.00", "EUR"), Period: budgets.PeriodMonthly,
+		StartDate: start, AccountID: "12345", Rollover: true}
+
+	err := adapter.SaveBudget(context.Background(), "1", budget)
+	assert.Nil(t, err, "Expected no error when saving budget")
+	assert.Equal(t, "7", budget.ID)
+	assert.Equal(t, "INSERT INTO budgets (tenant_id, name, amount, currency, period, start_date, end_date, account_id, transaction_type, description_pattern, rollover)"+
+		" VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"1", "Groceries", "400.00", "EUR", "monthly", start, sql.NullTime{},
+		sql.NullString{String: "12345", Valid: true}, sql.NullString{}, sql.NullString{}, true}, fakeDB.ExecArgs[0])
+}
+
+// Test budget saving failure
+func TestForSavingBudgetUsingDB_Failure(t *testing.T) {
+	adapter := NewForSavingBudgetUsingDB(&FakeDB{ReturnError: true})
+
+	err := adapter.SaveBudget(context.Background(), "1", &budgets.Budget{Name: "Groceries", Amount: money.MustParse("400.00", "EUR")})
+	assert.Equal(t, "failed to save budget: failed to execute query", err.Error(), "Expected error message to match")
+}
+
+// Test last insert ID failure
+func TestForSavingBudgetUsingDB_InsertIdFailure(t *testing.T) {
+	adapter := NewForSavingBudgetUsingDB(&FakeDB{ReturnInsertError: true})
+
+	err := adapter.SaveBudget(context.Background(), "1", &budgets.Budget{Name: "Groceries", Amount: money.MustParse("400.00", "EUR")})
+	assert.Equal(t, "failed to retrieve last insert ID: failed to execute query", err.Error(), "Expected error message to match")
+}
@@ -0,0 +1,54 @@
+package categories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/categories"
+	"spend-api/internal/infra/db"
+)
+
+// ForLoadingCategoriesUsingDB is the adapter for loading categories using DB
+type ForLoadingCategoriesUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingCategoriesUsingDB creates a new DB adapter for loading categories
+func NewForLoadingCategoriesUsingDB(db db.Executor) *ForLoadingCategoriesUsingDB {
+	return &ForLoadingCategoriesUsingDB{db: db}
+}
+
+// LoadCategory loads the tenant's category with the given ID from DB
+func (a *ForLoadingCategoriesUsingDB) LoadCategory(ctx context.Context, tenantID, id string) (*categories.Category, error) {
+	query := "SELECT id, name, parent_id FROM categories WHERE id = ? AND tenant_id = ?"
+	category, err := db.QueryOne(ctx, a.db, scanCategory, query, id, tenantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, categories.ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load category: %w", err)
+	}
+	return category, nil
+}
+
+// LoadCategories loads all categories of the tenant from DB
+func (a *ForLoadingCategoriesUsingDB) LoadCategories(ctx context.Context, tenantID string) ([]*categories.Category, error) {
+	query := "SELECT id, name, parent_id FROM categories WHERE tenant_id = ? ORDER BY name, id"
+	result, err := db.QueryAll(ctx, a.db, scanCategory, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load categories: %w", err)
+	}
+	return result, nil
+}
+
+// scanCategory maps a categories row onto the domain model
+func scanCategory(row db.Row) (*categories.Category, error) {
+	category := &categories.Category{}
+	var parentID sql.NullString
+	if err := row.Scan(&category.ID, &category.Name, &parentID); err != nil {
+		return nil, err
+	}
+	category.ParentID = parentID.String
+	return category, nil
+}
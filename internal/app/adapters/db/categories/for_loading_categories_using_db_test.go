@@ -0,0 +1,57 @@
+package categories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spend-api/internal/domain/categories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test loading a single category
+func TestForLoadingCategoriesUsingDB_LoadCategory(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"2", "Groceries", sql.NullString{String: "1", Valid: true}}}}
+	adapter := NewForLoadingCategoriesUsingDB(fakeDB)
+
+	category, err := adapter.LoadCategory(context.Background(), "1", "2")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT id, name, parent_id FROM categories WHERE id = ? AND tenant_id = ?", fakeDB.Queries[0])
+	assert.Equal(t, categories.NewCategory("2", "Groceries", "1"), category)
+}
+
+// Test loading a category that does not exist
+func TestForLoadingCategoriesUsingDB_NotFound(t *testing.T) {
+	adapter := NewForLoadingCategoriesUsingDB(&FakeDB{})
+
+	_, err := adapter.LoadCategory(context.Background(), "1", "99")
+
+	assert.True(t, errors.Is(err, categories.ErrCategoryNotFound), "Expected ErrCategoryNotFound")
+}
+
+// Test loading all categories
+func TestForLoadingCategoriesUsingDB_LoadCategories(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{
+		{"1", "Food", sql.NullString{}},
+		{"2", "Groceries", sql.NullString{String: "1", Valid: true}},
+	}}
+	adapter := NewForLoadingCategoriesUsingDB(fakeDB)
+
+	result, err := adapter.LoadCategories(context.Background(), "1")
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+	assert.Empty(t, result[0].ParentID, "A NULL parent should load as a top-level category")
+	assert.Equal(t, "1", result[1].ParentID)
+}
+
+// Test category loading failure
+func TestForLoadingCategoriesUsingDB_Failure(t *testing.T) {
+	adapter := NewForLoadingCategoriesUsingDB(&FakeDB{ReturnQueryError: true})
+
+	_, err := adapter.LoadCategories(context.Background(), "1")
+
+	assert.Equal(t, "failed to load categories: failed to execute query", err.Error())
+}
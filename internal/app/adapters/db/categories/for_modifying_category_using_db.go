@@ -0,0 +1,28 @@
+package categories
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/categories"
+	"spend-api/internal/infra/db"
+)
+
+// ForModifyingCategoryUsingDB is the adapter for persisting category changes using DB
+type ForModifyingCategoryUsingDB struct {
+	db db.Executor
+}
+
+// NewForModifyingCategoryUsingDB creates a new DB adapter for modifying categories
+func NewForModifyingCategoryUsingDB(db db.Executor) *ForModifyingCategoryUsingDB {
+	return &ForModifyingCategoryUsingDB{db: db}
+}
+
+// ModifyCategory writes the given category's name and parent to DB, provided it belongs to the tenant
+func (a *ForModifyingCategoryUsingDB) ModifyCategory(ctx context.Context, tenantID string, category *categories.Category) error {
+	query := "UPDATE categories SET name = ?, parent_id = ? WHERE id = ? AND tenant_id = ?"
+	_, err := a.db.ExecContext(ctx, query, category.Name, nullableString(category.ParentID), category.ID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to modify category: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package categories
+
+import (
+	"context"
+	"database/sql"
+	"spend-api/internal/domain/categories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test moving a category to the top level
+func TestForModifyingCategoryUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForModifyingCategoryUsingDB(fakeDB)
+
+	err := adapter.ModifyCategory(context.Background(), "1", categories.NewCategory("2", "Groceries", ""))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "UPDATE categories SET name = ?, parent_id = ? WHERE id = ? AND tenant_id = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"Groceries", sql.NullString{}, "2", "1"}, fakeDB.ExecArgs[0])
+}
+
+// Test category modification failure
+func TestForModifyingCategoryUsingDB_Failure(t *testing.T) {
+	adapter := NewForModifyingCategoryUsingDB(&FakeDB{ReturnError: true})
+
+	err := adapter.ModifyCategory(context.Background(), "1", categories.NewCategory("2", "Groceries", ""))
+
+	assert.Equal(t, "failed to modify category: failed to execute query", err.Error())
+}
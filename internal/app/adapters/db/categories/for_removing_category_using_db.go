@@ -0,0 +1,57 @@
+package categories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/categories"
+	"spend-api/internal/infra/db"
+)
+
+// ForRemovingCategoryUsingDB is the adapter for removing categories using DB
+type ForRemovingCategoryUsingDB struct {
+	db db.Executor
+}
+
+// NewForRemovingCategoryUsingDB creates a new DB adapter for removing categories
+func NewForRemovingCategoryUsingDB(db db.Executor) *ForRemovingCategoryUsingDB {
+	return &ForRemovingCategoryUsingDB{db: db}
+}
+
+// RemoveCategory deletes the tenant's category with the given ID from DB. The delete is
+// guarded in the same statement so that a category which still has
+// transactions or categorisation rules is never removed; the parent_id foreign
+// key does the same for subcategories.
+func (a *ForRemovingCategoryUsingDB) RemoveCategory(ctx context.Context, tenantID, id string) error {
+	query := "DELETE FROM categories WHERE id = ? AND tenant_id = ? AND NOT EXISTS (SELECT 1 FROM transactions WHERE category_id = ?)" +
+		" AND NOT EXISTS (SELECT 1 FROM categorization_rules WHERE category_id = ?)"
+	result, err := a.db.ExecContext(ctx, query, id, tenantID, id, id)
+	if err != nil {
+		return fmt.Errorf("failed to remove category: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rows affected: %w", err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	// Nothing was deleted, work out whether the category is missing or still in use
+	var count int
+	query = "SELECT (SELECT COUNT(*) FROM transactions WHERE category_id = ?) + (SELECT COUNT(*) FROM categorization_rules WHERE category_id = ?)" +
+		" FROM categories WHERE id = ? AND tenant_id = ?"
+	err = a.db.QueryRowContext(ctx, query, id, id, id, tenantID).Scan(&count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return categories.ErrCategoryNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to count category transactions and rules: %w", err)
+	}
+	if count > 0 {
+		return categories.ErrCategoryInUse
+	}
+	return categories.ErrCategoryNotFound
+}
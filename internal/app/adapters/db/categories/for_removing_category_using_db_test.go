@@ -0,0 +1,55 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/categories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test successful category removal
+func TestForRemovingCategoryUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForRemovingCategoryUsingDB(fakeDB)
+
+	err := adapter.RemoveCategory(context.Background(), "1", "3")
+	assert.Nil(t, err)
+	assert.Empty(t, fakeDB.Queries, "No follow-up query expected when the delete succeeds")
+}
+
+// Test removing a category that still has transactions
+func TestForRemovingCategoryUsingDB_InUse(t *testing.T) {
+	adapter := NewForRemovingCategoryUsingDB(&FakeDB{ReturnNoneAffected: true, Rows: [][]interface{}{{2}}})
+
+	err := adapter.RemoveCategory(context.Background(), "1", "3")
+	assert.True(t, errors.Is(err, categories.ErrCategoryInUse), "Expected ErrCategoryInUse")
+}
+
+// Test removing a category that does not exist
+func TestForRemovingCategoryUsingDB_NotFound(t *testing.T) {
+	adapter := NewForRemovingCategoryUsingDB(&FakeDB{ReturnNoneAffected: true, Rows: [][]interface{}{{0}}})
+
+	err := adapter.RemoveCategory(context.Background(), "1", "3")
+	assert.True(t, errors.Is(err, categories.ErrCategoryNotFound), "Expected ErrCategoryNotFound")
+}
+
+// Test removing a category of another tenant reports it as missing
+func TestForRemovingCategoryUsingDB_OtherTenant(t *testing.T) {
+	fakeDB := &FakeDB{ReturnNoneAffected: true}
+	adapter := NewForRemovingCategoryUsingDB(fakeDB)
+
+	err := adapter.RemoveCategory(context.Background(), "2", "3")
+	assert.True(t, errors.Is(err, categories.ErrCategoryNotFound), "Expected ErrCategoryNotFound")
+	assert.Equal(t, []interface{}{"3", "2", "3", "3"}, fakeDB.ExecArgs[0])
+	assert.Contains(t, fakeDB.Queries[0], "FROM categories WHERE id = ? AND tenant_id = ?")
+}
+
+// Test category removal failure
+func TestForRemovingCategoryUsingDB_Failure(t *testing.T) {
+	adapter := NewForRemovingCategoryUsingDB(&FakeDB{ReturnError: true})
+
+	err := adapter.RemoveCategory(context.Background(), "1", "3")
+	assert.Equal(t, "failed to remove category: failed to execute query", err.Error())
+}
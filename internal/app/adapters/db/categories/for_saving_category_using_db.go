@@ -0,0 +1,40 @@
+package categories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spend-api/internal/domain/categories"
+	"spend-api/internal/infra/db"
+)
+
+// ForSavingCategoryUsingDB is the adapter for saving categories using DB
+type ForSavingCategoryUsingDB struct {
+	db db.Executor
+}
+
+// NewForSavingCategoryUsingDB creates a new DB adapter for saving categories
+func NewForSavingCategoryUsingDB(db db.Executor) *ForSavingCategoryUsingDB {
+	return &ForSavingCategoryUsingDB{db: db}
+}
+
+// SaveCategory saves the given category of the tenant to DB
+func (a *ForSavingCategoryUsingDB) SaveCategory(ctx context.Context, tenantID string, category *categories.Category) error {
+	query := "INSERT INTO categories (tenant_id, name, parent_id) VALUES (?, ?, ?)"
+	result, err := a.db.ExecContext(ctx, query, tenantID, category.Name, nullableString(category.ParentID))
+	if err != nil {
+		return fmt.Errorf("failed to save category: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve last insert ID: %w", err)
+	}
+	category.ID = fmt.Sprintf("%d", id)
+	return nil
+}
+
+// nullableString stores an empty string as NULL
+func nullableString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,165 @@
+package categories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"spend-api/internal/domain/categories"
+	"spend-api/internal/infra/db"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeDB for simulating DB behavior
+type FakeDB struct {
+	ReturnError        bool
+	ReturnInsertError  bool
+	ReturnNoneAffected bool
+	ReturnQueryError   bool
+	Rows               [][]interface{}
+	Queries            []string
+	ExecQueries        []string
+	ExecArgs           [][]interface{}
+}
+
+func (f *FakeDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	f.ExecQueries = append(f.ExecQueries, query)
+	f.ExecArgs = append(f.ExecArgs, args)
+	if f.ReturnError {
+		return nil, errors.New("failed to execute query")
+	}
+	if f.ReturnInsertError {
+		return &MockFailedResult{}, nil
+	} else if f.ReturnNoneAffected {
+		return &MockEmptyResult{}, nil
+	} else {
+		return &MockResult{}, nil
+	}
+}
+
+func (f *FakeDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return f.Exec(query, args...)
+}
+
+func (f *FakeDB) QueryContext(ctx context.Context, query string, args ...interface{}) (db.Rows, error) {
+	return f.Query(query, args...)
+}
+
+func (f *FakeDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) db.Row {
+	return f.QueryRow(query, args...)
+}
+
+func (f *FakeDB) Query(query string, args ...interface{}) (db.Rows, error) {
+	f.Queries = append(f.Queries, query)
+	if f.ReturnQueryError {
+		return nil, errors.New("failed to execute query")
+	}
+	return &FakeRows{rows: f.Rows}, nil
+}
+
+func (f *FakeDB) QueryRow(query string, args ...interface{}) db.Row {
+	f.Queries = append(f.Queries, query)
+	if f.ReturnQueryError {
+		return &FakeRow{err: errors.New("failed to execute query")}
+	}
+	if len(f.Rows) == 0 {
+		return &FakeRow{err: sql.ErrNoRows}
+	}
+	return &FakeRow{values: f.Rows[0]}
+}
+
+func (f *FakeDB) Close() error {
+	return nil
+}
+
+// FakeRows replays canned rows for queries
+type FakeRows struct {
+	rows [][]interface{}
+	pos  int
+}
+
+func (r *FakeRows) Next() bool {
+	if r.pos < len(r.rows) {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+func (r *FakeRows) Scan(dest ...interface{}) error { return scanValues(r.rows[r.pos-1], dest) }
+func (r *FakeRows) Err() error                     { return nil }
+func (r *FakeRows) Close() error                   { return nil }
+
+// FakeRow replays a single canned row
+type FakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r *FakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+func scanValues(values []interface{}, dest []interface{}) error {
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(values[i]))
+	}
+	return nil
+}
+
+type MockResult struct{}
+type MockFailedResult struct{}
+type MockEmptyResult struct{}
+
+func (r *MockResult) LastInsertId() (int64, error) { return 0, nil }
+func (r *MockResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (r *MockFailedResult) LastInsertId() (int64, error) {
+	return 1, errors.New("failed to execute query")
+}
+func (r *MockFailedResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (r *MockEmptyResult) LastInsertId() (int64, error) { return 0, nil }
+func (r *MockEmptyResult) RowsAffected() (int64, error) { return 0, nil }
+
+// Test successful category saving
+func TestForSavingCategoryUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForSavingCategoryUsingDB(fakeDB)
+
+	err := adapter.SaveCategory(context.Background(), "1", categories.NewCategory("", "Food", ""))
+	assert.Nil(t, err, "Expected no error when saving category")
+	assert.Equal(t, "INSERT INTO categories (tenant_id, name, parent_id) VALUES (?, ?, ?)", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"1", "Food", sql.NullString{}}, fakeDB.ExecArgs[0], "A top-level category should have a NULL parent")
+}
+
+// Test saving a subcategory
+func TestForSavingCategoryUsingDB_Child(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForSavingCategoryUsingDB(fakeDB)
+
+	err := adapter.SaveCategory(context.Background(), "1", categories.NewCategory("", "Groceries", "1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"1", "Groceries", sql.NullString{String: "1", Valid: true}}, fakeDB.ExecArgs[0])
+}
+
+// Test category saving failure
+func TestForSavingCategoryUsingDB_Failure(t *testing.T) {
+	adapter := NewForSavingCategoryUsingDB(&FakeDB{ReturnError: true})
+
+	err := adapter.SaveCategory(context.Background(), "1", categories.NewCategory("", "Food", ""))
+	assert.Equal(t, "failed to save category: failed to execute query", err.Error())
+}
+
+// Test last insert ID failure
+func TestForSavingCategoryUsingDB_InsertIdFailure(t *testing.T) {
+	adapter := NewForSavingCategoryUsingDB(&FakeDB{ReturnInsertError: true})
+
+	err := adapter.SaveCategory(context.Background(), "1", categories.NewCategory("", "Food", ""))
+	assert.Equal(t, "failed to retrieve last insert ID: failed to execute query", err.Error())
+}
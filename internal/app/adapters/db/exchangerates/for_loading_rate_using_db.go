@@ -0,0 +1,51 @@
+package exchangerates
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/infra/db"
+	"time"
+)
+
+// ForLoadingRateUsingDB is the adapter for loading exchange rates using DB
+type ForLoadingRateUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingRateUsingDB creates a new DB adapter for loading exchange rates
+func NewForLoadingRateUsingDB(executor db.Executor) *ForLoadingRateUsingDB {
+	return &ForLoadingRateUsingDB{db: executor}
+}
+
+// LoadRate loads the tenant's most recent rate for the pair published on or before the given day
+func (a *ForLoadingRateUsingDB) LoadRate(ctx context.Context, tenantID string, base, quote money.Currency, on time.Time) (*exchangerates.Rate, error) {
+	query := "SELECT base_currency, quote_currency, rate_date, rate FROM exchange_rates" +
+		" WHERE tenant_id = ? AND base_currency = ? AND quote_currency = ? AND rate_date <= ?" +
+		" ORDER BY rate_date DESC LIMIT 1"
+	rate, err := db.QueryOne(ctx, a.db, scanRate, query, tenantID, string(base), string(quote), on)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, exchangerates.ErrRateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load exchange rate: %w", err)
+	}
+	return rate, nil
+}
+
+// scanRate maps an exchange_rates row onto the domain model
+func scanRate(row db.Row) (*exchangerates.Rate, error) {
+	var base, quote, value string
+	var date time.Time
+	if err := row.Scan(&base, &quote, &date, &value); err != nil {
+		return nil, err
+	}
+	decimal, err := money.ParseDecimal(value)
+	if err != nil {
+		return nil, err
+	}
+	return exchangerates.NewRate(money.Currency(base), money.Currency(quote), date, decimal), nil
+}
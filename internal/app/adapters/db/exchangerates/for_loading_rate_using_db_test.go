@@ -0,0 +1,48 @@
+package exchangerates
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test loading the latest rate on or before a day
+func TestForLoadingRateUsingDB(t *testing.T) {
+	published := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"EUR", "USD", published, "1.09450000"}}}
+	adapter := NewForLoadingRateUsingDB(fakeDB)
+
+	on := time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)
+	rate, err := adapter.LoadRate(context.Background(), "1", "EUR", "USD", on)
+
+	assert.Nil(t, err, "Expected no error when loading a rate")
+	assert.Equal(t, "SELECT base_currency, quote_currency, rate_date, rate FROM exchange_rates"+
+		" WHERE tenant_id = ? AND base_currency = ? AND quote_currency = ? AND rate_date <= ? ORDER BY rate_date DESC LIMIT 1", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1", "EUR", "USD", on}, fakeDB.Args[0])
+	assert.Equal(t, money.Currency("USD"), rate.Quote)
+	assert.Equal(t, published, rate.Date)
+	assert.Equal(t, "1.09450000", rate.Value.String())
+}
+
+// Test loading a rate that is not known
+func TestForLoadingRateUsingDB_NotFound(t *testing.T) {
+	adapter := NewForLoadingRateUsingDB(&FakeDB{})
+
+	_, err := adapter.LoadRate(context.Background(), "1", "EUR", "USD", time.Now())
+
+	assert.True(t, errors.Is(err, exchangerates.ErrRateNotFound), "Expected ErrRateNotFound")
+}
+
+// Test rate loading failure
+func TestForLoadingRateUsingDB_Failure(t *testing.T) {
+	adapter := NewForLoadingRateUsingDB(&FakeDB{ReturnQueryError: true})
+
+	_, err := adapter.LoadRate(context.Background(), "1", "EUR", "USD", time.Now())
+
+	assert.Equal(t, "failed to load exchange rate: failed to execute query", err.Error())
+}
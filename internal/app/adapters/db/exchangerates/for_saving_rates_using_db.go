@@ -0,0 +1,50 @@
+package exchangerates
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/infra/db"
+	"strings"
+)
+
+// saveBatchSize caps the number of rows written by a single INSERT statement
+const saveBatchSize = 500
+
+// ForSavingRatesUsingDB is the adapter for saving exchange rates using DB
+type ForSavingRatesUsingDB struct {
+	db db.Executor
+}
+
+// NewForSavingRatesUsingDB creates a new DB adapter for saving exchange rates
+func NewForSavingRatesUsingDB(executor db.Executor) *ForSavingRatesUsingDB {
+	return &ForSavingRatesUsingDB{db: executor}
+}
+
+// SaveRates upserts the given rates of the tenant into DB, replacing any rate
+// the tenant already stored for the same currency pair and day
+func (a *ForSavingRatesUsingDB) SaveRates(ctx context.Context, tenantID string, rates []*exchangerates.Rate) error {
+	for start := 0; start < len(rates); start += saveBatchSize {
+		end := min(start+saveBatchSize, len(rates))
+		query, args := buildUpsertQuery(tenantID, rates[start:end])
+		if _, err := a.db.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to save exchange rates: %w", err)
+		}
+	}
+	return nil
+}
+
+// buildUpsertQuery builds a single multi-row INSERT for the batch
+func buildUpsertQuery(tenantID string, rates []*exchangerates.Rate) (string, []interface{}) {
+	placeholders := make([]string, 0, len(rates))
+	args := make([]interface{}, 0, len(rates)*5)
+	for _, rate := range rates {
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?)")
+		args = append(args, tenantID, string(rate.Base), string(rate.Quote), rate.Date, rate.Value.String())
+	}
+
+	query := "INSERT INTO exchange_rates (tenant_id, base_currency, quote_currency, rate_date, rate) VALUES " +
+		strings.Join(placeholders, ", ") +
+		" ON DUPLICATE KEY UPDATE rate = VALUES(rate)"
+	return query, args
+}
@@ -0,0 +1,173 @@
+package exchangerates
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/infra/db"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Fake DB for simulating DB behavior
+type FakeDB struct {
+	ReturnError      bool
+	ReturnQueryError bool
+	Rows             [][]interface{}
+	Queries          []string
+	Args             [][]interface{}
+	ExecQueries      []string
+	ExecArgs         [][]interface{}
+}
+
+func (f *FakeDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	f.ExecQueries = append(f.ExecQueries, query)
+	f.ExecArgs = append(f.ExecArgs, args)
+	if f.ReturnError {
+		return nil, errors.New("failed to execute query")
+	}
+	return &MockResult{}, nil
+}
+
+func (f *FakeDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return f.Exec(query, args...)
+}
+
+func (f *FakeDB) QueryContext(ctx context.Context, query string, args ...interface{}) (db.Rows, error) {
+	return f.Query(query, args...)
+}
+
+func (f *FakeDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) db.Row {
+	return f.QueryRow(query, args...)
+}
+
+func (f *FakeDB) Query(query string, args ...interface{}) (db.Rows, error) {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return nil, errors.New("failed to execute query")
+	}
+	return &FakeRows{rows: f.Rows}, nil
+}
+
+func (f *FakeDB) QueryRow(query string, args ...interface{}) db.Row {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return &FakeRow{err: errors.New("failed to execute query")}
+	}
+	if len(f.Rows) == 0 {
+		return &FakeRow{err: sql.ErrNoRows}
+	}
+	return &FakeRow{values: f.Rows[0]}
+}
+
+func (f *FakeDB) Close() error {
+	return nil
+}
+
+// FakeRows iterates over canned rows
+type FakeRows struct {
+	rows [][]interface{}
+	pos  int
+}
+
+func (r *FakeRows) Next() bool {
+	if r.pos >= len(r.rows) {
+		return false
+	}
+	r.pos++
+	return true
+}
+
+func (r *FakeRows) Scan(dest ...interface{}) error { return scanValues(r.rows[r.pos-1], dest) }
+func (r *FakeRows) Err() error                     { return nil }
+func (r *FakeRows) Close() error                   { return nil }
+
+// FakeRow returns a single canned row or an error
+type FakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r *FakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+func scanValues(values []interface{}, dest []interface{}) error {
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(values[i]))
+	}
+	return nil
+}
+
+type MockResult struct{}
+
+func (r *MockResult) LastInsertId() (int64, error) { return 0, nil }
+func (r *MockResult) RowsAffected() (int64, error) { return 1, nil }
+
+func makeRates(count int) []*exchangerates.Rate {
+	var result []*exchangerates.Rate
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	value, _ := money.ParseDecimal("1.0945")
+	for i := 0; i < count; i++ {
+		result = append(result, exchangerates.NewRate("EUR", "USD", start.AddDate(0, 0, i), value))
+	}
+	return result
+}
+
+// Test saving rates as a single upsert
+func TestForSavingRatesUsingDB(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForSavingRatesUsingDB(fakeDB)
+
+	rates := makeRates(2)
+	err := adapter.SaveRates(context.Background(), "1", rates)
+
+	assert.Nil(t, err, "Expected no error when saving rates")
+	assert.Equal(t, "INSERT INTO exchange_rates (tenant_id, base_currency, quote_currency, rate_date, rate) VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)"+
+		" ON DUPLICATE KEY UPDATE rate = VALUES(rate)", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"1", "EUR", "USD", rates[0].Date, "1.0945", "1", "EUR", "USD", rates[1].Date, "1.0945"}, fakeDB.ExecArgs[0])
+}
+
+// Test large imports are split into batches
+func TestForSavingRatesUsingDB_Batches(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForSavingRatesUsingDB(fakeDB)
+
+	err := adapter.SaveRates(context.Background(), "1", makeRates(saveBatchSize+1))
+
+	assert.Nil(t, err)
+	assert.Len(t, fakeDB.ExecQueries, 2, "Expected one statement per batch")
+	assert.Equal(t, saveBatchSize, strings.Count(fakeDB.ExecQueries[0], "(?, ?, ?, ?, ?)"))
+	assert.Len(t, fakeDB.ExecArgs[1], 5)
+}
+
+// Test saving no rates touches nothing
+func TestForSavingRatesUsingDB_Empty(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForSavingRatesUsingDB(fakeDB)
+
+	err := adapter.SaveRates(context.Background(), "1", nil)
+
+	assert.Nil(t, err)
+	assert.Empty(t, fakeDB.ExecQueries)
+}
+
+// Test rate saving failure
+func TestForSavingRatesUsingDB_Failure(t *testing.T) {
+	adapter := NewForSavingRatesUsingDB(&FakeDB{ReturnError: true})
+
+	err := adapter.SaveRates(context.Background(), "1", makeRates(1))
+
+	assert.Equal(t, "failed to save exchange rates: failed to execute query", err.Error())
+}
@@ -0,0 +1,36 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/idempotency"
+	"spend-api/internal/infra/db"
+)
+
+// ForCompletingRecordUsingDB is the adapter for storing the responses of idempotent requests using DB
+type ForCompletingRecordUsingDB struct {
+	db db.Executor
+}
+
+// NewForCompletingRecordUsingDB creates a new DB adapter for storing the responses of idempotent requests
+func NewForCompletingRecordUsingDB(executor db.Executor) *ForCompletingRecordUsingDB {
+	return &ForCompletingRecordUsingDB{db: executor}
+}
+
+// CompleteRecord stores the response under the tenant's given key in DB
+func (a *ForCompletingRecordUsingDB) CompleteRecord(ctx context.Context, tenantID, key string, response *idempotency.Response) error {
+	query := "UPDATE idempotency_keys SET response_status = ?, response_content_type = ?, response_body = ? WHERE tenant_id = ? AND idempotency_key = ?"
+	result, err := a.db.ExecContext(ctx, query, response.StatusCode, response.ContentType, response.Body, tenantID, key)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency record: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rows affected: %w", err)
+	}
+	if affected == 0 {
+		return idempotency.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/idempotency"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test storing the response of a claimed key
+func TestForCompletingRecordUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	response := &idempotency.Response{StatusCode: 201, ContentType: "application/json", Body: []byte(`{"ID":"1"}`)}
+
+	err := NewForCompletingRecordUsingDB(fakeDB).CompleteRecord(context.Background(), "1", "abc", response)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "UPDATE idempotency_keys SET response_status = ?, response_content_type = ?, response_body = ? WHERE tenant_id = ? AND idempotency_key = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{201, "application/json", []byte(`{"ID":"1"}`), "1", "abc"}, fakeDB.ExecArgs[0])
+}
+
+// Test storing the response of a key that is no longer recorded
+func TestForCompletingRecordUsingDB_NotFound(t *testing.T) {
+	err := NewForCompletingRecordUsingDB(&FakeDB{ReturnNoneAffected: true}).CompleteRecord(context.Background(), "1", "abc", &idempotency.Response{})
+
+	assert.True(t, errors.Is(err, idempotency.ErrRecordNotFound), "Expected ErrRecordNotFound")
+}
+
+// Test record completing failure
+func TestForCompletingRecordUsingDB_Failure(t *testing.T) {
+	err := NewForCompletingRecordUsingDB(&FakeDB{ReturnError: true}).CompleteRecord(context.Background(), "1", "abc", &idempotency.Response{})
+
+	assert.Equal(t, "failed to complete idempotency record: failed to execute query", err.Error())
+}
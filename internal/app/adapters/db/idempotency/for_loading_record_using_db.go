@@ -0,0 +1,50 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/idempotency"
+	"spend-api/internal/infra/db"
+)
+
+// ForLoadingRecordUsingDB is the adapter for loading idempotency records using DB
+type ForLoadingRecordUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingRecordUsingDB creates a new DB adapter for loading idempotency records
+func NewForLoadingRecordUsingDB(executor db.Executor) *ForLoadingRecordUsingDB {
+	return &ForLoadingRecordUsingDB{db: executor}
+}
+
+// LoadRecord loads the record of the tenant's given key from DB
+func (a *ForLoadingRecordUsingDB) LoadRecord(ctx context.Context, tenantID, key string) (*idempotency.Record, error) {
+	query := "SELECT idempotency_key, fingerprint, response_status, response_content_type, response_body, created_at, expires_at" +
+		" FROM idempotency_keys WHERE tenant_id = ? AND idempotency_key = ?"
+	record, err := db.QueryOne(ctx, a.db, scanRecord, query, tenantID, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, idempotency.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load idempotency record: %w", err)
+	}
+	return record, nil
+}
+
+// scanRecord maps an idempotency_keys row onto the domain model. A NULL status means the
+// response has not been stored yet.
+func scanRecord(row db.Row) (*idempotency.Record, error) {
+	record := &idempotency.Record{}
+	var status sql.NullInt64
+	var contentType sql.NullString
+	var body []byte
+	if err := row.Scan(&record.Key, &record.Fingerprint, &status, &contentType, &body, &record.CreatedAt, &record.ExpiresAt); err != nil {
+		return nil, err
+	}
+	if status.Valid {
+		record.Response = &idempotency.Response{StatusCode: int(status.Int64), ContentType: contentType.String, Body: body}
+	}
+	return record, nil
+}
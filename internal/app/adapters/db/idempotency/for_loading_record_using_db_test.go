@@ -0,0 +1,57 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spend-api/internal/domain/idempotency"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test loading a completed record
+func TestForLoadingRecordUsingDB_Completed(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"abc", "f00d", sql.NullInt64{Int64: 201, Valid: true}, sql.NullString{String: "application/json", Valid: true},
+		[]byte(`{"ID":"1"}`), createdAt, createdAt.Add(24 * time.Hour)}}}
+
+	record, err := NewForLoadingRecordUsingDB(fakeDB).LoadRecord(context.Background(), "1", "abc")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT idempotency_key, fingerprint, response_status, response_content_type, response_body, created_at, expires_at"+
+		" FROM idempotency_keys WHERE tenant_id = ? AND idempotency_key = ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1", "abc"}, fakeDB.Args[0])
+	assert.Equal(t, &idempotency.Record{
+		Key:         "abc",
+		Fingerprint: "f00d",
+		Response:    &idempotency.Response{StatusCode: 201, ContentType: "application/json", Body: []byte(`{"ID":"1"}`)},
+		CreatedAt:   createdAt,
+		ExpiresAt:   createdAt.Add(24 * time.Hour),
+	}, record)
+}
+
+// Test a record whose request is still being handled has no response
+func TestForLoadingRecordUsingDB_InProgress(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"abc", "f00d", sql.NullInt64{}, sql.NullString{}, []byte(nil), time.Now(), time.Now()}}}
+
+	record, err := NewForLoadingRecordUsingDB(fakeDB).LoadRecord(context.Background(), "1", "abc")
+
+	assert.Nil(t, err)
+	assert.Nil(t, record.Response)
+}
+
+// Test loading the record of an unknown key
+func TestForLoadingRecordUsingDB_NotFound(t *testing.T) {
+	_, err := NewForLoadingRecordUsingDB(&FakeDB{}).LoadRecord(context.Background(), "1", "abc")
+
+	assert.True(t, errors.Is(err, idempotency.ErrRecordNotFound), "Expected ErrRecordNotFound")
+}
+
+// Test record loading failure
+func TestForLoadingRecordUsingDB_Failure(t *testing.T) {
+	_, err := NewForLoadingRecordUsingDB(&FakeDB{ReturnQueryError: true}).LoadRecord(context.Background(), "1", "abc")
+
+	assert.Equal(t, "failed to load idempotency record: failed to execute query", err.Error())
+}
@@ -0,0 +1,40 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/infra/db"
+	"time"
+)
+
+// ForRemovingRecordsUsingDB is the adapter for removing idempotency records using DB
+type ForRemovingRecordsUsingDB struct {
+	db db.Executor
+}
+
+// NewForRemovingRecordsUsingDB creates a new DB adapter for removing idempotency records
+func NewForRemovingRecordsUsingDB(executor db.Executor) *ForRemovingRecordsUsingDB {
+	return &ForRemovingRecordsUsingDB{db: executor}
+}
+
+// RemoveRecord deletes the record of the tenant's given key from DB, if there is one
+func (a *ForRemovingRecordsUsingDB) RemoveRecord(ctx context.Context, tenantID, key string) error {
+	if _, err := a.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE tenant_id = ? AND idempotency_key = ?", tenantID, key); err != nil {
+		return fmt.Errorf("failed to remove idempotency record: %w", err)
+	}
+	return nil
+}
+
+// RemoveExpired deletes the records of every tenant expiring at or before now from DB
+func (a *ForRemovingRecordsUsingDB) RemoveExpired(ctx context.Context, now time.Time) (int, error) {
+	result, err := a.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= ?", now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove expired idempotency records: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve rows affected: %w", err)
+	}
+	return int(affected), nil
+}
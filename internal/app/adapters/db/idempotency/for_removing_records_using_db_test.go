@@ -0,0 +1,44 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test removing the record of a key
+func TestForRemovingRecordsUsingDB_RemoveRecord(t *testing.T) {
+	fakeDB := &FakeDB{}
+
+	err := NewForRemovingRecordsUsingDB(fakeDB).RemoveRecord(context.Background(), "1", "abc")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "DELETE FROM idempotency_keys WHERE tenant_id = ? AND idempotency_key = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"1", "abc"}, fakeDB.ExecArgs[0])
+}
+
+// Test removing the expired records
+func TestForRemovingRecordsUsingDB_RemoveExpired(t *testing.T) {
+	fakeDB := &FakeDB{}
+	now := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+
+	removed, err := NewForRemovingRecordsUsingDB(fakeDB).RemoveExpired(context.Background(), now)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, "DELETE FROM idempotency_keys WHERE expires_at <= ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{now}, fakeDB.ExecArgs[0])
+}
+
+// Test record removing failures
+func TestForRemovingRecordsUsingDB_Failure(t *testing.T) {
+	adapter := NewForRemovingRecordsUsingDB(&FakeDB{ReturnError: true})
+
+	err := adapter.RemoveRecord(context.Background(), "1", "abc")
+	assert.Equal(t, "failed to remove idempotency record: failed to execute query", err.Error())
+
+	_, err = adapter.RemoveExpired(context.Background(), time.Now())
+	assert.Equal(t, "failed to remove expired idempotency records: failed to execute query", err.Error())
+}
@@ -0,0 +1,32 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/idempotency"
+	"spend-api/internal/infra/db"
+)
+
+// ForSavingRecordUsingDB is the adapter for claiming idempotency keys using DB
+type ForSavingRecordUsingDB struct {
+	db db.Executor
+}
+
+// NewForSavingRecordUsingDB creates a new DB adapter for claiming idempotency keys
+func NewForSavingRecordUsingDB(executor db.Executor) *ForSavingRecordUsingDB {
+	return &ForSavingRecordUsingDB{db: executor}
+}
+
+// SaveRecord saves the given record of the tenant, without a response, to DB. The tenant and
+// key make up the primary key, so a key the tenant already recorded fails with ErrKeyExists.
+func (a *ForSavingRecordUsingDB) SaveRecord(ctx context.Context, tenantID string, record *idempotency.Record) error {
+	query := "INSERT INTO idempotency_keys (tenant_id, idempotency_key, fingerprint, created_at, expires_at) VALUES (?, ?, ?, ?, ?)"
+	_, err := a.db.ExecContext(ctx, query, tenantID, record.Key, record.Fingerprint, record.CreatedAt, record.ExpiresAt)
+	if db.IsDuplicateKey(err) {
+		return idempotency.ErrKeyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save idempotency record: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"spend-api/internal/domain/idempotency"
+	"spend-api/internal/infra/db"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeDB for simulating DB behavior
+type FakeDB struct {
+	ReturnError        bool
+	ReturnInsertError  bool
+	ReturnNoneAffected bool
+	ReturnQueryError   bool
+	ExecErr            error
+	Rows               [][]interface{}
+	Queries            []string
+	Args               [][]interface{}
+	ExecQueries        []string
+	ExecArgs           [][]interface{}
+}
+
+func (f *FakeDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	f.ExecQueries = append(f.ExecQueries, query)
+	f.ExecArgs = append(f.ExecArgs, args)
+	if f.ReturnError {
+		return nil, errors.New("failed to execute query")
+	}
+	if f.ExecErr != nil {
+		return nil, f.ExecErr
+	}
+	if f.ReturnInsertError {
+		return &MockFailedResult{}, nil
+	}
+	if f.ReturnNoneAffected {
+		return &MockEmptyResult{}, nil
+	}
+	return &MockResult{}, nil
+}
+
+func (f *FakeDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return f.Exec(query, args...)
+}
+
+func (f *FakeDB) QueryContext(ctx context.Context, query string, args ...interface{}) (db.Rows, error) {
+	return f.Query(query, args...)
+}
+
+func (f *FakeDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) db.Row {
+	return f.QueryRow(query, args...)
+}
+
+func (f *FakeDB) Query(query string, args ...interface{}) (db.Rows, error) {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return nil, errors.New("failed to execute query")
+	}
+	return &FakeRows{rows: f.Rows}, nil
+}
+
+func (f *FakeDB) QueryRow(query string, args ...interface{}) db.Row {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return &FakeRow{err: errors.New("failed to execute query")}
+	}
+	if len(f.Rows) == 0 {
+		return &FakeRow{err: sql.ErrNoRows}
+	}
+	return &FakeRow{values: f.Rows[0]}
+}
+
+func (f *FakeDB) Close() error {
+	return nil
+}
+
+// FakeRows replays canned rows for queries
+type FakeRows struct {
+	rows [][]interface{}
+	pos  int
+}
+
+func (r *FakeRows) Next() bool {
+	if r.pos < len(r.rows) {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+func (r *FakeRows) Scan(dest ...interface{}) error { return scanValues(r.rows[r.pos-1], dest) }
+func (r *FakeRows) Err() error                     { return nil }
+func (r *FakeRows) Close() error                   { return nil }
+
+// FakeRow replays a single canned row
+type FakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r *FakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+func scanValues(values []interface{}, dest []interface{}) error {
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(values[i]))
+	}
+	return nil
+}
+
+type MockResult struct{}
+type MockFailedResult struct{}
+type MockEmptyResult struct{}
+
+func (r *MockResult) LastInsertId() (int64, error) { return 7, nil }
+func (r *MockResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (r *MockFailedResult) LastInsertId() (int64, error) {
+	return 0, errors.New("failed to execute query")
+}
+func (r *MockFailedResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (r *MockEmptyResult) LastInsertId() (int64, error) { return 0, nil }
+func (r *MockEmptyResult) RowsAffected() (int64, error) { return 0, nil }
+
+// Test claiming a key saves a record without a response
+func TestForSavingRecordUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	createdAt := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+	record := &idempotency.Record{Key: "abc", Fingerprint: "f00d", CreatedAt: createdAt, ExpiresAt: createdAt.Add(24 * time.Hour)}
+
+	err := NewForSavingRecordUsingDB(fakeDB).SaveRecord(context.Background(), "1", record)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "INSERT INTO idempotency_keys (tenant_id, idempotency_key, fingerprint, created_at, expires_at) VALUES (?, ?, ?, ?, ?)", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"1", "abc", "f00d", createdAt, createdAt.Add(24 * time.Hour)}, fakeDB.ExecArgs[0])
+}
+
+// Test claiming a key that is already recorded
+func TestForSavingRecordUsingDB_KeyExists(t *testing.T) {
+	fakeDB := &FakeDB{ExecErr: &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}}
+
+	err := NewForSavingRecordUsingDB(fakeDB).SaveRecord(context.Background(), "1", &idempotency.Record{Key: "abc"})
+
+	assert.True(t, errors.Is(err, idempotency.ErrKeyExists), "Expected ErrKeyExists")
+}
+
+// Test record saving failure
+func TestForSavingRecordUsingDB_Failure(t *testing.T) {
+	err := NewForSavingRecordUsingDB(&FakeDB{ReturnError: true}).SaveRecord(context.Background(), "1", &idempotency.Record{Key: "abc"})
+
+	assert.Equal(t, "failed to save idempotency record: failed to execute query", err.Error())
+}
@@ -0,0 +1,34 @@
+package imports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/imports"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/infra/db"
+)
+
+// ForLoadingAccountCurrencyUsingDB is the adapter for looking up account currencies using DB
+type ForLoadingAccountCurrencyUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingAccountCurrencyUsingDB creates a new DB adapter for looking up account currencies
+func NewForLoadingAccountCurrencyUsingDB(executor db.Executor) *ForLoadingAccountCurrencyUsingDB {
+	return &ForLoadingAccountCurrencyUsingDB{db: executor}
+}
+
+// LoadAccountCurrency loads the currency of the tenant's account with the given ID from DB
+func (a *ForLoadingAccountCurrencyUsingDB) LoadAccountCurrency(ctx context.Context, tenantID, accountID string) (money.Currency, error) {
+	var currency string
+	err := a.db.QueryRowContext(ctx, "SELECT currency FROM accounts WHERE id = ? AND tenant_id = ?", accountID, tenantID).Scan(&currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", imports.ErrAccountNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load account currency: %w", err)
+	}
+	return money.Currency(currency), nil
+}
@@ -0,0 +1,28 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/imports"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test looking up an account's currency
+func TestForLoadingAccountCurrencyUsingDB(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"GBP"}}}
+
+	currency, err := NewForLoadingAccountCurrencyUsingDB(fakeDB).LoadAccountCurrency(context.Background(), "1", "12345")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "GBP", string(currency))
+	assert.Equal(t, "SELECT currency FROM accounts WHERE id = ? AND tenant_id = ?", fakeDB.Queries[0])
+}
+
+// Test looking up the currency of an unknown account
+func TestForLoadingAccountCurrencyUsingDB_NotFound(t *testing.T) {
+	_, err := NewForLoadingAccountCurrencyUsingDB(&FakeDB{}).LoadAccountCurrency(context.Background(), "1", "99")
+
+	assert.True(t, errors.Is(err, imports.ErrAccountNotFound), "Expected ErrAccountNotFound")
+}
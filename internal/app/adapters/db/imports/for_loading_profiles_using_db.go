@@ -0,0 +1,68 @@
+package imports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/imports"
+	"spend-api/internal/infra/db"
+)
+
+// ForLoadingProfilesUsingDB is the adapter for loading import profiles using DB
+type ForLoadingProfilesUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingProfilesUsingDB creates a new DB adapter for loading import profiles
+func NewForLoadingProfilesUsingDB(executor db.Executor) *ForLoadingProfilesUsingDB {
+	return &ForLoadingProfilesUsingDB{db: executor}
+}
+
+// LoadProfile loads the tenant's import profile with the given ID from DB
+func (a *ForLoadingProfilesUsingDB) LoadProfile(ctx context.Context, tenantID, id string) (*imports.Profile, error) {
+	query := "SELECT id, " + profileWriteColumns + " FROM import_profiles WHERE id = ? AND tenant_id = ?"
+	profile, err := db.QueryOne(ctx, a.db, scanProfile, query, id, tenantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, imports.ErrProfileNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load import profile: %w", err)
+	}
+	return profile, nil
+}
+
+// LoadProfiles loads all import profiles of the tenant from DB
+func (a *ForLoadingProfilesUsingDB) LoadProfiles(ctx context.Context, tenantID string) ([]*imports.Profile, error) {
+	query := "SELECT id, " + profileWriteColumns + " FROM import_profiles WHERE tenant_id = ? ORDER BY name, id"
+	result, err := db.QueryAll(ctx, a.db, scanProfile, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load import profiles: %w", err)
+	}
+	return result, nil
+}
+
+// scanProfile maps an import_profiles row onto the domain model
+func scanProfile(row db.Row) (*imports.Profile, error) {
+	profile := &imports.Profile{}
+	var description, amount, debit, credit sql.NullInt64
+	err := row.Scan(&profile.ID, &profile.Name, &profile.Delimiter, &profile.SkipRows, &profile.DateColumn, &profile.DateFormat,
+		&description, &amount, &debit, &credit, &profile.DecimalSeparator)
+	if err != nil {
+		return nil, err
+	}
+	profile.DescriptionColumn = scanColumn(description)
+	profile.AmountColumn = scanColumn(amount)
+	profile.DebitColumn = scanColumn(debit)
+	profile.CreditColumn = scanColumn(credit)
+	return profile, nil
+}
+
+// scanColumn reads a nullable column index
+func scanColumn(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	column := int(value.Int64)
+	return &column
+}
@@ -0,0 +1,60 @@
+package imports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spend-api/internal/domain/imports"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func profileRow(id string) []interface{} {
+	return []interface{}{id, "Sparkasse", ";", 1, 0, "DD.MM.YYYY", sql.NullInt64{Int64: 1, Valid: true}, sql.NullInt64{Int64: 2, Valid: true},
+		sql.NullInt64{}, sql.NullInt64{}, ","}
+}
+
+// Test loading all import profiles
+func TestForLoadingProfilesUsingDB_LoadProfiles(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{profileRow("1")}}
+
+	result, err := NewForLoadingProfilesUsingDB(fakeDB).LoadProfiles(context.Background(), "1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT id, name, delimiter, skip_rows, date_column, date_format, description_column, amount_column, debit_column, credit_column, decimal_separator"+
+		" FROM import_profiles WHERE tenant_id = ? ORDER BY name, id", fakeDB.Queries[0])
+	assert.Len(t, result, 1)
+	profile := result[0]
+	assert.Equal(t, "Sparkasse", profile.Name)
+	assert.Equal(t, 1, profile.SkipRows)
+	assert.Equal(t, 1, *profile.DescriptionColumn)
+	assert.Equal(t, 2, *profile.AmountColumn)
+	assert.Nil(t, profile.DebitColumn)
+	assert.Equal(t, ",", profile.DecimalSeparator)
+}
+
+// Test loading a single import profile
+func TestForLoadingProfilesUsingDB_LoadProfile(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{profileRow("4")}}
+
+	profile, err := NewForLoadingProfilesUsingDB(fakeDB).LoadProfile(context.Background(), "1", "4")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "4", profile.ID)
+	assert.Equal(t, []interface{}{"4", "1"}, fakeDB.Args[0])
+}
+
+// Test loading an import profile that does not exist
+func TestForLoadingProfilesUsingDB_NotFound(t *testing.T) {
+	_, err := NewForLoadingProfilesUsingDB(&FakeDB{}).LoadProfile(context.Background(), "1", "4")
+
+	assert.True(t, errors.Is(err, imports.ErrProfileNotFound), "Expected ErrProfileNotFound")
+}
+
+// Test import profile loading failure
+func TestForLoadingProfilesUsingDB_Failure(t *testing.T) {
+	_, err := NewForLoadingProfilesUsingDB(&FakeDB{ReturnQueryError: true}).LoadProfiles(context.Background(), "1")
+
+	assert.Equal(t, "failed to load import profiles: failed to execute query", err.Error())
+}
@@ -0,0 +1,72 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/imports"
+	"spend-api/internal/domain/transactions"
+)
+
+// bankKind gives the kinds a bank's transaction type is recorded as for money going out and
+// coming in; an empty kind falls back to a plain debit or credit
+type bankKind struct {
+	out, in transactions.Kind
+}
+
+// bankKinds maps the OFX transaction types that have a matching kind
+var bankKinds = map[string]bankKind{
+	"INT":    {in: transactions.KindInterest},
+	"DIV":    {in: transactions.KindInterest},
+	"FEE":    {out: transactions.KindFee},
+	"SRVCHG": {out: transactions.KindFee},
+	"XFER":   {out: transactions.KindTransferOut, in: transactions.KindTransferIn},
+}
+
+// ForRecordingTransactionUsingDB is the adapter for recording imported statement entries as
+// transactions. It goes through the transaction service so that imported transactions are
+// validated, categorised and stored exactly like those created one at a time.
+type ForRecordingTransactionUsingDB struct {
+	transactionService transactions.ForImportingTransaction
+}
+
+// NewForRecordingTransactionUsingDB creates a new adapter for recording imported transactions
+func NewForRecordingTransactionUsingDB(service transactions.ForImportingTransaction) *ForRecordingTransactionUsingDB {
+	return &ForRecordingTransactionUsingDB{transactionService: service}
+}
+
+// RecordTransaction creates a posted transaction for the entry, booked on the entry's posted
+// date. Money going out is recorded as a debit and money coming in as a credit, unless the
+// bank's type says it is interest, a fee or a transfer. Reversals bringing money back, such
+// as a returned direct debit, are recorded as refunds.
+func (a *ForRecordingTransactionUsingDB) RecordTransaction(ctx context.Context, accountID string, entry *imports.Entry) (string, error) {
+	kind, mapped := transactions.KindCredit, bankKinds[entry.BankType].in
+	if entry.Amount.IsNegative() {
+		kind, mapped = transactions.KindDebit, bankKinds[entry.BankType].out
+	}
+	if mapped != "" {
+		kind = mapped
+	}
+	if entry.Reversal && entry.Amount.IsPositive() {
+		kind = transactions.KindRefund
+	}
+	postedDate := entry.PostedDate
+	if postedDate.IsZero() {
+		postedDate = entry.Date
+	}
+
+	transaction, err := a.transactionService.ImportTransaction(ctx, accountID, entry.Amount, kind, entry.Description, entry.ExternalID, entry.Date, postedDate)
+	if errors.Is(err, transactions.ErrInvalidTransaction) {
+		return "", fmt.Errorf("%w: %s", imports.ErrInvalidEntry, err.Error())
+	}
+	if errors.Is(err, transactions.ErrDuplicateTransaction) {
+		return "", imports.ErrDuplicateEntry
+	}
+	if errors.Is(err, transactions.ErrAccountNotFound) {
+		return "", imports.ErrAccountNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to record transaction: %w", err)
+	}
+	return transaction.ID, nil
+}
@@ -0,0 +1,114 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/imports"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForImportingTransaction simulates the transaction service for testing.
+type FakeForImportingTransaction struct {
+	ReturnErr  error
+	Kind       transactions.Kind
+	ExternalID string
+	PostedDate time.Time
+}
+
+func (f *FakeForImportingTransaction) ImportTransaction(ctx context.Context, accountID string, amount money.Money, kind transactions.Kind, description, externalID string, date, postedDate time.Time) (*transactions.Transaction, error) {
+	f.Kind, f.ExternalID, f.PostedDate = kind, externalID, postedDate
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	transaction := transactions.NewTransaction("42", accountID, amount, kind, date, description)
+	return transaction, nil
+}
+
+// Test entries are recorded as posted debits or credits
+func TestForRecordingTransactionUsingDB(t *testing.T) {
+	service := &FakeForImportingTransaction{}
+	adapter := NewForRecordingTransactionUsingDB(service)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	id, err := adapter.RecordTransaction(context.Background(), "12345", &imports.Entry{Date: date, Amount: money.MustParse("-3.50", "EUR"), Description: "Coffee", ExternalID: "FIT-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "42", id)
+	assert.Equal(t, transactions.KindDebit, service.Kind)
+	assert.Equal(t, "FIT-1", service.ExternalID)
+	assert.Equal(t, date, service.PostedDate)
+
+	_, err = adapter.RecordTransaction(context.Background(), "12345", &imports.Entry{Date: date, PostedDate: date.AddDate(0, 0, 2), Amount: money.MustParse("10.00", "EUR")})
+	assert.Nil(t, err)
+	assert.Equal(t, transactions.KindCredit, service.Kind)
+	assert.Equal(t, date.AddDate(0, 0, 2), service.PostedDate)
+}
+
+// Test the bank's transaction types pick the matching kind when the sign allows it
+func TestForRecordingTransactionUsingDB_BankTypes(t *testing.T) {
+	cases := []struct {
+		bankType string
+		amount   string
+		expected transactions.Kind
+	}{
+		{"INT", "1.25", transactions.KindInterest},
+		{"INT", "-1.25", transactions.KindDebit},
+		{"SRVCHG", "-2.00", transactions.KindFee},
+		{"FEE", "2.00", transactions.KindCredit},
+		{"XFER", "-50.00", transactions.KindTransferOut},
+		{"XFER", "50.00", transactions.KindTransferIn},
+		{"POS", "-9.99", transactions.KindDebit},
+		{"", "-9.99", transactions.KindDebit},
+	}
+
+	for _, c := range cases {
+		service := &FakeForImportingTransaction{}
+
+		_, err := NewForRecordingTransactionUsingDB(service).
+			RecordTransaction(context.Background(), "12345", &imports.Entry{Amount: money.MustParse(c.amount, "EUR"), BankType: c.bankType})
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, service.Kind, c.bankType+" "+c.amount)
+	}
+}
+
+// Test reversals bringing money back are refunds and those taking it out are debits
+func TestForRecordingTransactionUsingDB_Reversals(t *testing.T) {
+	service := &FakeForImportingTransaction{}
+	adapter := NewForRecordingTransactionUsingDB(service)
+
+	_, err := adapter.RecordTransaction(context.Background(), "12345", &imports.Entry{Amount: money.MustParse("25.00", "EUR"), Reversal: true})
+	assert.Nil(t, err)
+	assert.Equal(t, transactions.KindRefund, service.Kind)
+
+	_, err = adapter.RecordTransaction(context.Background(), "12345", &imports.Entry{Amount: money.MustParse("-25.00", "EUR"), Reversal: true})
+	assert.Nil(t, err)
+	assert.Equal(t, transactions.KindDebit, service.Kind)
+}
+
+// Test rejected transactions are reported as invalid entries
+func TestForRecordingTransactionUsingDB_Errors(t *testing.T) {
+	invalid := &transactions.ValidationError{}
+	invalid.Add("categoryID", "unknown")
+	cases := map[error]error{
+		invalid:                              imports.ErrInvalidEntry,
+		transactions.ErrAccountNotFound:      imports.ErrAccountNotFound,
+		transactions.ErrDuplicateTransaction: imports.ErrDuplicateEntry,
+	}
+
+	for serviceErr, expected := range cases {
+		adapter := NewForRecordingTransactionUsingDB(&FakeForImportingTransaction{ReturnErr: serviceErr})
+
+		_, err := adapter.RecordTransaction(context.Background(), "12345", &imports.Entry{Amount: money.MustParse("1.00", "EUR")})
+
+		assert.True(t, errors.Is(err, expected), serviceErr.Error())
+	}
+
+	_, err := NewForRecordingTransactionUsingDB(&FakeForImportingTransaction{ReturnErr: errors.New("database down")}).
+		RecordTransaction(context.Background(), "12345", &imports.Entry{Amount: money.MustParse("1.00", "EUR")})
+	assert.Equal(t, "failed to record transaction: database down", err.Error())
+}
@@ -0,0 +1,35 @@
+package imports
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/imports"
+	"spend-api/internal/infra/db"
+)
+
+// ForRemovingProfileUsingDB is the adapter for removing import profiles using DB
+type ForRemovingProfileUsingDB struct {
+	db db.Executor
+}
+
+// NewForRemovingProfileUsingDB creates a new DB adapter for removing import profiles
+func NewForRemovingProfileUsingDB(executor db.Executor) *ForRemovingProfileUsingDB {
+	return &ForRemovingProfileUsingDB{db: executor}
+}
+
+// RemoveProfile deletes the tenant's import profile with the given ID from DB
+func (a *ForRemovingProfileUsingDB) RemoveProfile(ctx context.Context, tenantID, id string) error {
+	result, err := a.db.ExecContext(ctx, "DELETE FROM import_profiles WHERE id = ? AND tenant_id = ?", id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to remove import profile: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rows affected: %w", err)
+	}
+	if affected == 0 {
+		return imports.ErrProfileNotFound
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/imports"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test successful import profile removal
+func TestForRemovingProfileUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+
+	err := NewForRemovingProfileUsingDB(fakeDB).RemoveProfile(context.Background(), "1", "4")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "DELETE FROM import_profiles WHERE id = ? AND tenant_id = ?", fakeDB.ExecQueries[0])
+}
+
+// Test removing an import profile that does not exist
+func TestForRemovingProfileUsingDB_NotFound(t *testing.T) {
+	err := NewForRemovingProfileUsingDB(&FakeDB{ReturnNoneAffected: true}).RemoveProfile(context.Background(), "1", "4")
+
+	assert.True(t, errors.Is(err, imports.ErrProfileNotFound), "Expected ErrProfileNotFound")
+}
+
+// Test import profile removal failure
+func TestForRemovingProfileUsingDB_Failure(t *testing.T) {
+	err := NewForRemovingProfileUsingDB(&FakeDB{ReturnError: true}).RemoveProfile(context.Background(), "1", "4")
+
+	assert.Equal(t, "failed to remove import profile: failed to execute query", err.Error())
+}
@@ -0,0 +1,48 @@
+package imports
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spend-api/internal/domain/imports"
+	"spend-api/internal/infra/db"
+)
+
+// profileWriteColumns lists the columns profileArgs fills, in order
+const profileWriteColumns = "name, delimiter, skip_rows, date_column, date_format, description_column, amount_column, debit_column, credit_column, decimal_separator"
+
+// ForSavingProfileUsingDB is the adapter for saving import profiles using DB
+type ForSavingProfileUsingDB struct {
+	db db.Executor
+}
+
+// NewForSavingProfileUsingDB creates a new DB adapter for saving import profiles
+func NewForSavingProfileUsingDB(executor db.Executor) *ForSavingProfileUsingDB {
+	return &ForSavingProfileUsingDB{db: executor}
+}
+
+// SaveProfile saves the given import profile of the tenant to DB
+func (a *ForSavingProfileUsingDB) SaveProfile(ctx context.Context, tenantID string, profile *imports.Profile) error {
+	query := "INSERT INTO import_profiles (tenant_id, " + profileWriteColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := a.db.ExecContext(ctx, query, tenantID, profile.Name, profile.Delimiter, profile.SkipRows, profile.DateColumn, profile.DateFormat,
+		nullableColumn(profile.DescriptionColumn), nullableColumn(profile.AmountColumn), nullableColumn(profile.DebitColumn),
+		nullableColumn(profile.CreditColumn), profile.DecimalSeparator)
+	if err != nil {
+		return fmt.Errorf("failed to save import profile: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve last insert ID: %w", err)
+	}
+	profile.ID = fmt.Sprintf("%d", id)
+	return nil
+}
+
+// nullableColumn stores an unmapped column as NULL
+func nullableColumn(column *int) sql.NullInt64 {
+	if column == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*column), Valid: true}
+}
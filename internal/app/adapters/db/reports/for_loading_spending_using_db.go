@@ -0,0 +1,133 @@
+package reports
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/reports"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+	"strings"
+)
+
+// periodStarts maps each report period onto the SQL expression for the first day of the
+// period a transaction falls in. Weeks start on Monday.
+var periodStarts = map[reports.Period]string{
+	reports.PeriodDay:   "transaction_date",
+	reports.PeriodWeek:  "DATE_SUB(transaction_date, INTERVAL WEEKDAY(transaction_date) DAY)",
+	reports.PeriodMonth: "DATE_SUB(transaction_date, INTERVAL DAYOFMONTH(transaction_date) - 1 DAY)",
+	reports.PeriodYear:  "MAKEDATE(YEAR(transaction_date), 1)",
+}
+
+// categoryTreeQuery selects the IDs of a tenant's category and all of its descendants
+const categoryTreeQuery = "WITH RECURSIVE tree AS (SELECT id FROM categories WHERE id = ? AND tenant_id = ? " +
+	"UNION ALL SELECT c.id FROM categories c JOIN tree ON c.parent_id = tree.id) SELECT id FROM tree"
+
+// ForLoadingSpendingUsingDB is the adapter for adding up transactions using DB
+type ForLoadingSpendingUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingSpendingUsingDB creates a new DB adapter for adding up transactions
+func NewForLoadingSpendingUsingDB(executor db.Executor) *ForLoadingSpendingUsingDB {
+	return &ForLoadingSpendingUsingDB{db: executor}
+}
+
+// LoadSpending adds up the tenant's transactions in the query's date range in
+// DB, grouped by period, currency and, when asked, account and category.
+// Voided transactions are left out, and transfers count towards neither
+// income nor expenses unless the query includes them.
+func (a *ForLoadingSpendingUsingDB) LoadSpending(ctx context.Context, tenantID string, query reports.SpendingQuery) ([]*reports.SpendingRow, error) {
+	statement, args := buildSpendingQuery(tenantID, query)
+	scan := func(row db.Row) (*reports.SpendingRow, error) {
+		return scanSpendingRow(row, query)
+	}
+	result, err := db.QueryAll(ctx, a.db, scan, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load spending: %w", err)
+	}
+	return result, nil
+}
+
+// buildSpendingQuery assembles the grouped totals query
+func buildSpendingQuery(tenantID string, query reports.SpendingQuery) (string, []interface{}) {
+	columns := []string{periodStarts[query.Period] + " AS period_start"}
+	groups := []string{"period_start"}
+	if query.ByAccount {
+		columns = append(columns, "account_id")
+		groups = append(groups, "account_id")
+	}
+	if query.ByCategory {
+		columns = append(columns, "category_id")
+		groups = append(groups, "category_id")
+	}
+	columns = append(columns, "currency",
+		"COALESCE(SUM(CASE WHEN amount > 0 THEN amount END), 0)",
+		"COALESCE(SUM(CASE WHEN amount < 0 THEN -amount END), 0)",
+		"COUNT(*)")
+	groups = append(groups, "currency")
+
+	conditions := []string{"tenant_id = ?", "transaction_date BETWEEN ? AND ?", "status <> ?"}
+	args := []interface{}{tenantID, query.From, query.To, string(transactions.StatusVoided)}
+	if !query.IncludeTransfers {
+		conditions = append(conditions, "transaction_type NOT IN (?, ?)")
+		args = append(args, string(transactions.KindTransferIn), string(transactions.KindTransferOut))
+	}
+	if query.AccountID != "" {
+		conditions = append(conditions, "account_id = ?")
+		args = append(args, query.AccountID)
+	}
+	if query.AccountIDs != nil {
+		if len(query.AccountIDs) == 0 {
+			// No account at all may be reported on
+			conditions = append(conditions, "FALSE")
+		} else {
+			conditions = append(conditions, "account_id IN (?"+strings.Repeat(", ?", len(query.AccountIDs)-1)+")")
+			for _, id := range query.AccountIDs {
+				args = append(args, id)
+			}
+		}
+	}
+	if query.CategoryID != "" {
+		conditions = append(conditions, "category_id IN ("+categoryTreeQuery+")")
+		args = append(args, query.CategoryID, tenantID)
+	}
+
+	grouping := strings.Join(groups, ", ")
+	statement := "SELECT " + strings.Join(columns, ", ") + " FROM transactions WHERE " + strings.Join(conditions, " AND ") +
+		" GROUP BY " + grouping + " ORDER BY " + grouping
+	return statement, args
+}
+
+// scanSpendingRow maps a row of grouped totals onto the domain model
+func scanSpendingRow(row db.Row, query reports.SpendingQuery) (*reports.SpendingRow, error) {
+	spending := &reports.SpendingRow{}
+	var accountID, categoryID sql.NullString
+	var currency, income, expenses string
+	targets := []interface{}{&spending.PeriodStart}
+	if query.ByAccount {
+		targets = append(targets, &accountID)
+	}
+	if query.ByCategory {
+		targets = append(targets, &categoryID)
+	}
+	targets = append(targets, &currency, &income, &expenses, &spending.Transactions)
+	if err := row.Scan(targets...); err != nil {
+		return nil, err
+	}
+
+	spending.AccountID = accountID.String
+	spending.CategoryID = categoryID.String
+	var err error
+	if spending.Income, err = money.Parse(income, money.Currency(currency)); err != nil {
+		return nil, fmt.Errorf("invalid income total: %w", err)
+	}
+	if spending.Expenses, err = money.Parse(expenses, money.Currency(currency)); err != nil {
+		return nil, fmt.Errorf("invalid expenses total: %w", err)
+	}
+	if spending.NetCashFlow, err = spending.Income.Sub(spending.Expenses); err != nil {
+		return nil, err
+	}
+	return spending, nil
+}
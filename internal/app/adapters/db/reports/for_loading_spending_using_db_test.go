@@ -0,0 +1,206 @@
+package reports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/reports"
+	"spend-api/internal/infra/db"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeDB for simulating DB behavior
+type FakeDB struct {
+	ReturnQueryError bool
+	Rows             [][]interface{}
+	Queries          []string
+	Args             [][]interface{}
+}
+
+func (f *FakeDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return nil, errors.New("unexpected write")
+}
+
+func (f *FakeDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return f.Exec(query, args...)
+}
+
+func (f *FakeDB) QueryContext(ctx context.Context, query string, args ...interface{}) (db.Rows, error) {
+	return f.Query(query, args...)
+}
+
+func (f *FakeDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) db.Row {
+	return f.QueryRow(query, args...)
+}
+
+func (f *FakeDB) Query(query string, args ...interface{}) (db.Rows, error) {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return nil, errors.New("failed to execute query")
+	}
+	return &FakeRows{rows: f.Rows}, nil
+}
+
+func (f *FakeDB) QueryRow(query string, args ...interface{}) db.Row {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return &FakeRow{err: errors.New("failed to execute query")}
+	}
+	if len(f.Rows) == 0 {
+		return &FakeRow{err: sql.ErrNoRows}
+	}
+	return &FakeRow{values: f.Rows[0]}
+}
+
+func (f *FakeDB) Close() error {
+	return nil
+}
+
+// FakeRows replays canned rows for queries
+type FakeRows struct {
+	rows [][]interface{}
+	pos  int
+}
+
+func (r *FakeRows) Next() bool {
+	if r.pos < len(r.rows) {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+func (r *FakeRows) Scan(dest ...interface{}) error { return scanValues(r.rows[r.pos-1], dest) }
+func (r *FakeRows) Err() error                     { return nil }
+func (r *FakeRows) Close() error                   { return nil }
+
+// FakeRow replays a single canned row
+type FakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r *FakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+func scanValues(values []interface{}, dest []interface{}) error {
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(values[i]))
+	}
+	return nil
+}
+
+// Test monthly totals of every account
+func TestForLoadingSpendingUsingDB_ByMonth(t *testing.T) {
+	january := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeDB := &FakeDB{Rows: [][]interface{}{{january, "EUR", "2500.0000", "1200.5000", 30}}}
+	adapter := NewForLoadingSpendingUsingDB(fakeDB)
+
+	to := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	result, err := adapter.LoadSpending(context.Background(), "1", reports.SpendingQuery{From: january, To: to, Period: reports.PeriodMonth})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT DATE_SUB(transaction_date, INTERVAL DAYOFMONTH(transaction_date) - 1 DAY) AS period_start, currency,"+
+		" COALESCE(SUM(CASE WHEN amount > 0 THEN amount END), 0), COALESCE(SUM(CASE WHEN amount < 0 THEN -amount END), 0), COUNT(*)"+
+		" FROM transactions WHERE tenant_id = ? AND transaction_date BETWEEN ? AND ? AND status <> ? AND transaction_type NOT IN (?, ?)"+
+		" GROUP BY period_start, currency ORDER BY period_start, currency", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1", january, to, "voided", "transfer_in", "transfer_out"}, fakeDB.Args[0])
+	assert.Equal(t, []*reports.SpendingRow{{
+		PeriodStart:  january,
+		Income:       money.MustParse("2500.00", "EUR"),
+		Expenses:     money.MustParse("1200.50", "EUR"),
+		NetCashFlow:  money.MustParse("1299.50", "EUR"),
+		Transactions: 30,
+	}}, result)
+}
+
+// Test weekly totals of one account, split by account and category
+func TestForLoadingSpendingUsingDB_ByAccountAndCategory(t *testing.T) {
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	fakeDB := &FakeDB{Rows: [][]interface{}{
+		{monday, sql.NullString{String: "12345", Valid: true}, sql.NullString{String: "7", Valid: true}, "EUR", "0.0000", "45.9000", 3},
+		{monday, sql.NullString{String: "12345", Valid: true}, sql.NullString{}, "EUR", "10.0000", "0.0000", 1},
+	}}
+	adapter := NewForLoadingSpendingUsingDB(fakeDB)
+
+	query := reports.SpendingQuery{From: monday, To: monday.AddDate(0, 0, 6), Period: reports.PeriodWeek, AccountID: "12345", CategoryID: "3", ByAccount: true, ByCategory: true}
+	result, err := adapter.LoadSpending(context.Background(), "1", query)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT DATE_SUB(transaction_date, INTERVAL WEEKDAY(transaction_date) DAY) AS period_start, account_id, category_id, currency,"+
+		" COALESCE(SUM(CASE WHEN amount > 0 THEN amount END), 0), COALESCE(SUM(CASE WHEN amount < 0 THEN -amount END), 0), COUNT(*)"+
+		" FROM transactions WHERE tenant_id = ? AND transaction_date BETWEEN ? AND ? AND status <> ? AND transaction_type NOT IN (?, ?) AND account_id = ?"+
+		" AND category_id IN (WITH RECURSIVE tree AS (SELECT id FROM categories WHERE id = ? AND tenant_id = ? UNION ALL SELECT c.id FROM categories c JOIN tree ON c.parent_id = tree.id) SELECT id FROM tree)"+
+		" GROUP BY period_start, account_id, category_id, currency ORDER BY period_start, account_id, category_id, currency", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1", monday, monday.AddDate(0, 0, 6), "voided", "transfer_in", "transfer_out", "12345", "3", "1"}, fakeDB.Args[0])
+	assert.Len(t, result, 2)
+	assert.Equal(t, "12345", result[0].AccountID)
+	assert.Equal(t, "7", result[0].CategoryID)
+	assert.Equal(t, money.MustParse("-45.90", "EUR"), result[0].NetCashFlow)
+	assert.Equal(t, "", result[1].CategoryID)
+	assert.Equal(t, money.MustParse("10.00", "EUR"), result[1].Income)
+}
+
+// Test transfers between accounts are only counted when the report asks for them
+func TestForLoadingSpendingUsingDB_IncludeTransfers(t *testing.T) {
+	fakeDB := &FakeDB{}
+	january := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	_, err := NewForLoadingSpendingUsingDB(fakeDB).LoadSpending(context.Background(), "1", reports.SpendingQuery{From: january, To: to, Period: reports.PeriodMonth, IncludeTransfers: true})
+
+	assert.Nil(t, err)
+	assert.Contains(t, fakeDB.Queries[0], " FROM transactions WHERE tenant_id = ? AND transaction_date BETWEEN ? AND ? AND status <> ? GROUP BY")
+	assert.Equal(t, []interface{}{"1", january, to, "voided"}, fakeDB.Args[0])
+}
+
+// Test a report restricted to some accounts, or to none at all
+func TestForLoadingSpendingUsingDB_RestrictedAccounts(t *testing.T) {
+	fakeDB := &FakeDB{}
+	january := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	adapter := NewForLoadingSpendingUsingDB(fakeDB)
+
+	_, err := adapter.LoadSpending(context.Background(), "1", reports.SpendingQuery{From: january, To: to, Period: reports.PeriodMonth, IncludeTransfers: true, AccountIDs: []string{"1", "2"}})
+	assert.Nil(t, err)
+	assert.Contains(t, fakeDB.Queries[0], " AND status <> ? AND account_id IN (?, ?) GROUP BY")
+	assert.Equal(t, []interface{}{"1", january, to, "voided", "1", "2"}, fakeDB.Args[0])
+
+	_, err = adapter.LoadSpending(context.Background(), "1", reports.SpendingQuery{From: january, To: to, Period: reports.PeriodMonth, IncludeTransfers: true, AccountIDs: []string{}})
+	assert.Nil(t, err)
+	assert.Contains(t, fakeDB.Queries[1], " AND status <> ? AND FALSE GROUP BY")
+}
+
+// Test each period starts on the right day
+func TestForLoadingSpendingUsingDB_Periods(t *testing.T) {
+	cases := map[reports.Period]string{
+		reports.PeriodDay:  "SELECT transaction_date AS period_start",
+		reports.PeriodYear: "SELECT MAKEDATE(YEAR(transaction_date), 1) AS period_start",
+	}
+
+	for period, prefix := range cases {
+		fakeDB := &FakeDB{}
+		_, err := NewForLoadingSpendingUsingDB(fakeDB).LoadSpending(context.Background(), "1", reports.SpendingQuery{Period: period})
+
+		assert.Nil(t, err)
+		assert.Contains(t, fakeDB.Queries[0], prefix)
+	}
+}
+
+// Test spending loading failure
+func TestForLoadingSpendingUsingDB_Failure(t *testing.T) {
+	_, err := NewForLoadingSpendingUsingDB(&FakeDB{ReturnQueryError: true}).LoadSpending(context.Background(), "1", reports.SpendingQuery{Period: reports.PeriodMonth})
+
+	assert.Equal(t, "failed to load spending: failed to execute query", err.Error())
+}
@@ -0,0 +1,27 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/infra/db"
+)
+
+// ForCheckingCategoryUsingDB is the adapter for checking categories exist using DB
+type ForCheckingCategoryUsingDB struct {
+	db db.Executor
+}
+
+// NewForCheckingCategoryUsingDB creates a new DB adapter for checking categories exist
+func NewForCheckingCategoryUsingDB(executor db.Executor) *ForCheckingCategoryUsingDB {
+	return &ForCheckingCategoryUsingDB{db: executor}
+}
+
+// CategoryExists reports whether a category of the tenant with the given ID exists in DB
+func (a *ForCheckingCategoryUsingDB) CategoryExists(ctx context.Context, tenantID, id string) (bool, error) {
+	var count int
+	err := a.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM categories WHERE id = ? AND tenant_id = ?", id, tenantID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check category: %w", err)
+	}
+	return count > 0, nil
+}
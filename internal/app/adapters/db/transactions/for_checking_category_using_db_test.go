@@ -0,0 +1,28 @@
+package transactions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test checking a category that exists and one that does not
+func TestForCheckingCategoryUsingDB(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{1}}}
+	exists, err := NewForCheckingCategoryUsingDB(fakeDB).CategoryExists(context.Background(), "1", "7")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, []interface{}{"7", "1"}, fakeDB.Args[0])
+
+	exists, err = NewForCheckingCategoryUsingDB(&FakeDB{Rows: [][]interface{}{{0}}}).CategoryExists(context.Background(), "1", "99")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+}
+
+// Test category check failure
+func TestForCheckingCategoryUsingDB_Failure(t *testing.T) {
+	_, err := NewForCheckingCategoryUsingDB(&FakeDB{ReturnQueryError: true}).CategoryExists(context.Background(), "1", "7")
+
+	assert.Equal(t, "failed to check category: failed to execute query", err.Error())
+}
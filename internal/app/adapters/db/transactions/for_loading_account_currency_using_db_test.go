@@ -0,0 +1,41 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test loading the currency of an account
+func TestForLoadingAccountCurrencyUsingDB(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"GBP"}}}
+	adapter := NewForLoadingAccountCurrencyUsingDB(fakeDB)
+
+	currency, err := adapter.LoadAccountCurrency(context.Background(), "1", "12345")
+
+	assert.Nil(t, err, "Expected no error when loading the account currency")
+	assert.Equal(t, money.Currency("GBP"), currency)
+	assert.Equal(t, []interface{}{"12345", "1"}, fakeDB.Args[0])
+}
+
+// Test loading the currency of an account that does not exist
+func TestForLoadingAccountCurrencyUsingDB_NotFound(t *testing.T) {
+	adapter := NewForLoadingAccountCurrencyUsingDB(&FakeDB{})
+
+	_, err := adapter.LoadAccountCurrency(context.Background(), "1", "12345")
+
+	assert.True(t, errors.Is(err, transactions.ErrAccountNotFound), "Expected ErrAccountNotFound")
+}
+
+// Test failure loading the currency of an account
+func TestForLoadingAccountCurrencyUsingDB_Failure(t *testing.T) {
+	adapter := NewForLoadingAccountCurrencyUsingDB(&FakeDB{ReturnQueryError: true})
+
+	_, err := adapter.LoadAccountCurrency(context.Background(), "1", "12345")
+
+	assert.Equal(t, "failed to load account currency: failed to execute query", err.Error())
+}
@@ -0,0 +1,108 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+	"time"
+)
+
+// ForLoadingBalanceUsingDB is the adapter for loading account balances using DB. Balances
+// start from the monthly balance_snapshots rows before the month in question, so only that
+// month's transactions need summing however long the account's history is.
+type ForLoadingBalanceUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingBalanceUsingDB creates a new DB adapter for loading account balances
+func NewForLoadingBalanceUsingDB(executor db.Executor) *ForLoadingBalanceUsingDB {
+	return &ForLoadingBalanceUsingDB{db: executor}
+}
+
+// LoadBalance loads the balance of the tenant's account at the end of the asOf day from DB
+func (a *ForLoadingBalanceUsingDB) LoadBalance(ctx context.Context, tenantID, accountID string, asOf time.Time) (money.Money, error) {
+	return a.loadBalance(ctx, tenantID, accountID, asOf, "t.transaction_date <= ?", asOf)
+}
+
+// LoadBalanceThrough loads the balance of the transaction's account just after the transaction from DB
+func (a *ForLoadingBalanceUsingDB) LoadBalanceThrough(ctx context.Context, tenantID string, transaction *transactions.Transaction) (money.Money, error) {
+	date := transaction.Timestamp
+	return a.loadBalance(ctx, tenantID, transaction.AccountID, date,
+		"(t.transaction_date < ? OR (t.transaction_date = ? AND t.id <= ?))", date, date, transaction.ID)
+}
+
+// LoadDailyChanges loads what the tenant's account's transactions of each day up to and
+// including asOf add to its balance from DB, in date order
+func (a *ForLoadingBalanceUsingDB) LoadDailyChanges(ctx context.Context, tenantID, accountID string, asOf time.Time) ([]*transactions.DailyChange, error) {
+	query := "SELECT transaction_date, currency, SUM(amount) FROM transactions " +
+		"WHERE account_id = ? AND tenant_id = ? AND status <> ? AND transaction_date <= ? " +
+		"GROUP BY transaction_date, currency ORDER BY transaction_date"
+	result, err := db.QueryAll(ctx, a.db, scanDailyChange, query, accountID, tenantID, string(transactions.StatusVoided), asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load daily balance changes: %w", err)
+	}
+	return result, nil
+}
+
+// scanDailyChange maps a row of daily totals onto the domain model
+func scanDailyChange(row db.Row) (*transactions.DailyChange, error) {
+	change := &transactions.DailyChange{}
+	var currency, amount string
+	if err := row.Scan(&change.Date, &currency, &amount); err != nil {
+		return nil, err
+	}
+	var err error
+	if change.Amount, err = money.Parse(amount, money.Currency(currency)); err != nil {
+		return nil, fmt.Errorf("invalid balance change on %s: %w", change.Date.Format(time.DateOnly), err)
+	}
+	return change, nil
+}
+
+// loadBalance adds the snapshots of the months before date to the transactions of date's
+// month that match the given condition. Snapshots and transactions are those of the tenant's
+// account, which must exist.
+func (a *ForLoadingBalanceUsingDB) loadBalance(ctx context.Context, tenantID, accountID string, date time.Time, condition string, conditionArgs ...interface{}) (money.Money, error) {
+	query := "SELECT a.currency, " +
+		"(SELECT COALESCE(SUM(s.net_change), 0) FROM balance_snapshots s WHERE s.account_id = a.id AND s.tenant_id = a.tenant_id AND s.period_start < ?) + " +
+		"(SELECT COALESCE(SUM(t.amount), 0) FROM transactions t WHERE t.account_id = a.id AND t.tenant_id = a.tenant_id AND t.status <> ? AND t.transaction_date >= ? AND " + condition + ") " +
+		"FROM accounts a WHERE a.id = ? AND a.tenant_id = ?"
+	month := periodStart(date)
+	args := append([]interface{}{month, string(transactions.StatusVoided), month}, conditionArgs...)
+	args = append(args, accountID, tenantID)
+
+	var currency, amount string
+	err := a.db.QueryRowContext(ctx, query, args...).Scan(&currency, &amount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return money.Money{}, transactions.ErrAccountNotFound
+	}
+	if err != nil {
+		return money.Money{}, fmt.Errorf("failed to load balance: %w", err)
+	}
+
+	balance, err := money.Parse(amount, money.Currency(currency))
+	if err != nil {
+		return money.Money{}, fmt.Errorf("invalid balance for account %s: %w", accountID, err)
+	}
+	return balance, nil
+}
+
+// recordBalanceChange adds change to the snapshot of the tenant's account for the month
+// containing date. It must run in the same unit of work as the write to transactions that
+// caused it.
+func recordBalanceChange(ctx context.Context, executor db.Executor, tenantID, accountID string, date time.Time, change money.Money) error {
+	query := "INSERT INTO balance_snapshots (tenant_id, account_id, period_start, net_change) VALUES (?, ?, ?, ?) " +
+		"ON DUPLICATE KEY UPDATE net_change = net_change + VALUES(net_change)"
+	if _, err := executor.ExecContext(ctx, query, tenantID, accountID, periodStart(date), change.String()); err != nil {
+		return fmt.Errorf("failed to update balance snapshot: %w", err)
+	}
+	return nil
+}
+
+// periodStart returns the first day of the month containing date
+func periodStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
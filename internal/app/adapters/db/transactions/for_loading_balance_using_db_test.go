@@ -0,0 +1,78 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test loading a balance at the end of a day starts from the snapshots before its month
+func TestForLoadingBalanceUsingDB_LoadBalance(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"EUR", "1234.5600"}}}
+	adapter := NewForLoadingBalanceUsingDB(fakeDB)
+
+	balance, err := adapter.LoadBalance(context.Background(), "1", "12345", time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC))
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.MustParse("1234.56", "EUR"), balance)
+	assert.Contains(t, fakeDB.Queries[0], "FROM balance_snapshots s WHERE s.account_id = a.id AND s.tenant_id = a.tenant_id AND s.period_start < ?")
+	assert.Contains(t, fakeDB.Queries[0], "FROM accounts a WHERE a.id = ? AND a.tenant_id = ?")
+	assert.Contains(t, fakeDB.Queries[0], "t.transaction_date <= ?")
+	month := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []interface{}{month, "voided", month, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), "12345", "1"}, fakeDB.Args[0])
+}
+
+// Test loading the balance just after a transaction includes earlier transactions on the same day
+func TestForLoadingBalanceUsingDB_LoadBalanceThrough(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"EUR", "-20.0000"}}}
+	adapter := NewForLoadingBalanceUsingDB(fakeDB)
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	transaction := transactions.NewTransaction("42", "12345", money.MustParse("-4.50", "EUR"), transactions.KindDebit, date, "Coffee")
+
+	balance, err := adapter.LoadBalanceThrough(context.Background(), "1", transaction)
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.MustParse("-20.00", "EUR"), balance)
+	assert.Contains(t, fakeDB.Queries[0], "(t.transaction_date < ? OR (t.transaction_date = ? AND t.id <= ?))")
+	assert.Equal(t, []interface{}{date, date, "42", "12345", "1"}, fakeDB.Args[0][3:])
+}
+
+// Test loading the daily changes of an account's balance leaves voided and later transactions out
+func TestForLoadingBalanceUsingDB_LoadDailyChanges(t *testing.T) {
+	first, second := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	fakeDB := &FakeDB{Rows: [][]interface{}{{first, "EUR", "-12.5000"}, {second, "EUR", "100.0000"}}}
+	adapter := NewForLoadingBalanceUsingDB(fakeDB)
+
+	changes, err := adapter.LoadDailyChanges(context.Background(), "1", "12345", second)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*transactions.DailyChange{
+		{Date: first, Amount: money.MustParse("-12.50", "EUR")},
+		{Date: second, Amount: money.MustParse("100.00", "EUR")},
+	}, changes)
+	assert.Contains(t, fakeDB.Queries[0], "GROUP BY transaction_date, currency ORDER BY transaction_date")
+	assert.Equal(t, []interface{}{"12345", "1", "voided", second}, fakeDB.Args[0])
+}
+
+// Test loading the balance of an account that does not exist
+func TestForLoadingBalanceUsingDB_NotFound(t *testing.T) {
+	adapter := NewForLoadingBalanceUsingDB(&FakeDB{})
+
+	_, err := adapter.LoadBalance(context.Background(), "1", "999", time.Now())
+
+	assert.True(t, errors.Is(err, transactions.ErrAccountNotFound), "Expected ErrAccountNotFound")
+}
+
+// Test balance loading failure
+func TestForLoadingBalanceUsingDB_Failure(t *testing.T) {
+	adapter := NewForLoadingBalanceUsingDB(&FakeDB{ReturnQueryError: true})
+
+	_, err := adapter.LoadBalance(context.Background(), "1", "12345", time.Now())
+
+	assert.Equal(t, "failed to load balance: failed to execute query", err.Error())
+}
@@ -0,0 +1,76 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+	"strings"
+)
+
+// ForLoadingDuplicateCandidatesUsingDB is the adapter for loading likely duplicate transactions using DB
+type ForLoadingDuplicateCandidatesUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingDuplicateCandidatesUsingDB creates a new DB adapter for loading likely duplicate transactions
+func NewForLoadingDuplicateCandidatesUsingDB(executor db.Executor) *ForLoadingDuplicateCandidatesUsingDB {
+	return &ForLoadingDuplicateCandidatesUsingDB{db: executor}
+}
+
+// LoadDuplicateCandidates loads the pairs of transactions of the same account
+// for the same amount dated within windowDays of each other, most recent
+// first. Each pair is loaded once, with the earlier recorded transaction
+// first, leaving out voided transactions and pairs that were dismissed. Only
+// the tenant's transactions of the accounts the filter selects are paired.
+func (a *ForLoadingDuplicateCandidatesUsingDB) LoadDuplicateCandidates(ctx context.Context, tenantID string, filter transactions.DuplicateFilter, limit int) ([]*transactions.DuplicatePair, error) {
+	query := "SELECT " + qualifiedColumns("a") + ", " + qualifiedColumns("b") + " FROM transactions a" +
+		" JOIN transactions b ON b.tenant_id = a.tenant_id AND b.account_id = a.account_id AND b.amount = a.amount AND b.currency = a.currency AND b.id > a.id" +
+		" AND b.transaction_date BETWEEN DATE_SUB(a.transaction_date, INTERVAL ? DAY) AND DATE_ADD(a.transaction_date, INTERVAL ? DAY)" +
+		" WHERE a.tenant_id = ? AND a.status <> ? AND b.status <> ?" +
+		" AND NOT EXISTS (SELECT 1 FROM duplicate_resolutions r WHERE r.tenant_id = a.tenant_id AND r.kept_transaction_id IN (a.id, b.id) AND r.duplicate_transaction_id IN (a.id, b.id))"
+	args := []interface{}{filter.WindowDays, filter.WindowDays, tenantID, string(transactions.StatusVoided), string(transactions.StatusVoided)}
+	if filter.AccountID != "" {
+		query += " AND a.account_id = ?"
+		args = append(args, filter.AccountID)
+	}
+	if filter.AccountIDs != nil {
+		condition, ids := accountsCondition("a.account_id", filter.AccountIDs)
+		query += " AND " + condition
+		args = append(args, ids...)
+	}
+	query += " ORDER BY b.transaction_date DESC, b.id DESC LIMIT ?"
+	args = append(args, limit)
+
+	result, err := db.QueryAll(ctx, a.db, scanDuplicatePair, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load duplicate candidates: %w", err)
+	}
+	return result, nil
+}
+
+// qualifiedColumns prefixes transactionColumns with a table alias
+func qualifiedColumns(alias string) string {
+	columns := strings.Split(transactionColumns, ", ")
+	for i, column := range columns {
+		columns[i] = alias + "." + column
+	}
+	return strings.Join(columns, ", ")
+}
+
+// scanDuplicatePair maps a row holding two transactions onto a pair
+func scanDuplicatePair(row db.Row) (*transactions.DuplicatePair, error) {
+	first, second := &transactionRow{}, &transactionRow{}
+	if err := row.Scan(append(first.targets(), second.targets()...)...); err != nil {
+		return nil, err
+	}
+	transaction, err := first.transaction()
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := second.transaction()
+	if err != nil {
+		return nil, err
+	}
+	return &transactions.DuplicatePair{Transaction: transaction, Duplicate: duplicate}, nil
+}
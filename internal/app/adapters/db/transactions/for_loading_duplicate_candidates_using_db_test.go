@@ -0,0 +1,74 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pairRow(firstID, secondID string) []interface{} {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	row := []interface{}{firstID, "12345", "-4.5000", "EUR", "debit", date, sql.NullTime{}, "posted", "COFFEE SHOP", sql.NullString{}, sql.NullString{}, sql.NullString{String: "FIT-1", Valid: true}, sql.NullString{}, 1}
+	return append(row, secondID, "12345", "-4.5000", "EUR", "debit", date.AddDate(0, 0, 1), sql.NullTime{}, "posted", "Coffee Shop", sql.NullString{String: "7", Valid: true}, sql.NullString{}, sql.NullString{}, sql.NullString{}, 2)
+}
+
+// Test loading duplicate candidates of one account
+func TestForLoadingDuplicateCandidatesUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{pairRow("1", "2")}}
+	adapter := NewForLoadingDuplicateCandidatesUsingDB(fakeDB)
+
+	result, err := adapter.LoadDuplicateCandidates(context.Background(), "1", transactions.DuplicateFilter{AccountID: "12345", WindowDays: 3}, 5000)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT a.id, a.account_id, a.amount, a.currency, a.transaction_type, a.transaction_date, a.posted_date, a.status, a.description, a.category_id, a.payee, a.external_id, a.transfer_id, a.version,"+
+		" b.id, b.account_id, b.amount, b.currency, b.transaction_type, b.transaction_date, b.posted_date, b.status, b.description, b.category_id, b.payee, b.external_id, b.transfer_id, b.version"+
+		" FROM transactions a JOIN transactions b ON b.tenant_id = a.tenant_id AND b.account_id = a.account_id AND b.amount = a.amount AND b.currency = a.currency AND b.id > a.id"+
+		" AND b.transaction_date BETWEEN DATE_SUB(a.transaction_date, INTERVAL ? DAY) AND DATE_ADD(a.transaction_date, INTERVAL ? DAY)"+
+		" WHERE a.tenant_id = ? AND a.status <> ? AND b.status <> ?"+
+		" AND NOT EXISTS (SELECT 1 FROM duplicate_resolutions r WHERE r.tenant_id = a.tenant_id AND r.kept_transaction_id IN (a.id, b.id) AND r.duplicate_transaction_id IN (a.id, b.id))"+
+		" AND a.account_id = ? ORDER BY b.transaction_date DESC, b.id DESC LIMIT ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{3, 3, "1", "voided", "voided", "12345", 5000}, fakeDB.Args[0])
+	assert.Len(t, result, 1)
+	assert.Equal(t, "1", result[0].Transaction.ID)
+	assert.Equal(t, "FIT-1", result[0].Transaction.ExternalID)
+	assert.Equal(t, "2", result[0].Duplicate.ID)
+	assert.Equal(t, "Coffee Shop", result[0].Duplicate.Description)
+	assert.Equal(t, "7", result[0].Duplicate.CategoryID)
+	assert.Equal(t, "", result[0].Duplicate.ExternalID)
+}
+
+// Test searching every account leaves out the account condition
+func TestForLoadingDuplicateCandidatesUsingDB_AllAccounts(t *testing.T) {
+	fakeDB := &FakeDB{}
+	_, err := NewForLoadingDuplicateCandidatesUsingDB(fakeDB).LoadDuplicateCandidates(context.Background(), "1", transactions.DuplicateFilter{WindowDays: 7}, 10)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, fakeDB.Queries[0], "a.account_id = ?")
+	assert.Equal(t, []interface{}{7, 7, "1", "voided", "voided", 10}, fakeDB.Args[0])
+}
+
+// Test searching a restricted set of accounts, or none at all
+func TestForLoadingDuplicateCandidatesUsingDB_RestrictedAccounts(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForLoadingDuplicateCandidatesUsingDB(fakeDB)
+
+	_, err := adapter.LoadDuplicateCandidates(context.Background(), "1", transactions.DuplicateFilter{AccountIDs: []string{"1", "2"}, WindowDays: 3}, 10)
+	assert.Nil(t, err)
+	assert.Contains(t, fakeDB.Queries[0], " AND a.account_id IN (?, ?) ORDER BY")
+	assert.Equal(t, []interface{}{3, 3, "1", "voided", "voided", "1", "2", 10}, fakeDB.Args[0])
+
+	_, err = adapter.LoadDuplicateCandidates(context.Background(), "1", transactions.DuplicateFilter{AccountIDs: []string{}, WindowDays: 3}, 10)
+	assert.Nil(t, err)
+	assert.Contains(t, fakeDB.Queries[1], " AND FALSE ORDER BY")
+}
+
+// Test duplicate candidate loading failure
+func TestForLoadingDuplicateCandidatesUsingDB_Failure(t *testing.T) {
+	_, err := NewForLoadingDuplicateCandidatesUsingDB(&FakeDB{ReturnQueryError: true}).LoadDuplicateCandidates(context.Background(), "1", transactions.DuplicateFilter{WindowDays: 3}, 10)
+
+	assert.Equal(t, "failed to load duplicate candidates: failed to execute query", err.Error())
+}
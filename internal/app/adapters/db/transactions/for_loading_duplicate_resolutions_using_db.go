@@ -0,0 +1,51 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+)
+
+// ForLoadingDuplicateResolutionsUsingDB is the adapter for loading duplicate resolutions using DB
+type ForLoadingDuplicateResolutionsUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingDuplicateResolutionsUsingDB creates a new DB adapter for loading duplicate resolutions
+func NewForLoadingDuplicateResolutionsUsingDB(executor db.Executor) *ForLoadingDuplicateResolutionsUsingDB {
+	return &ForLoadingDuplicateResolutionsUsingDB{db: executor}
+}
+
+// LoadDuplicateResolutions loads the resolutions of the tenant from DB, most
+// recent first. When the filter restricts the accounts, only resolutions of
+// pairs whose transactions both belong to them are loaded.
+func (a *ForLoadingDuplicateResolutionsUsingDB) LoadDuplicateResolutions(ctx context.Context, tenantID string, filter transactions.ResolutionFilter) ([]*transactions.DuplicateResolution, error) {
+	query := "SELECT id, action, kept_transaction_id, duplicate_transaction_id, resolved_at FROM duplicate_resolutions WHERE tenant_id = ?"
+	args := []interface{}{tenantID}
+	if filter.AccountIDs != nil {
+		condition, ids := accountsCondition("account_id", filter.AccountIDs)
+		for _, column := range []string{"kept_transaction_id", "duplicate_transaction_id"} {
+			query += " AND " + column + " IN (SELECT id FROM transactions WHERE tenant_id = ? AND " + condition + ")"
+			args = append(append(args, tenantID), ids...)
+		}
+	}
+	query += " ORDER BY resolved_at DESC, id DESC"
+	result, err := db.QueryAll(ctx, a.db, scanDuplicateResolution, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load duplicate resolutions: %w", err)
+	}
+	return result, nil
+}
+
+// scanDuplicateResolution maps a duplicate_resolutions row onto the domain model
+func scanDuplicateResolution(row db.Row) (*transactions.DuplicateResolution, error) {
+	resolution := &transactions.DuplicateResolution{}
+	var action string
+	err := row.Scan(&resolution.ID, &action, &resolution.KeptID, &resolution.DuplicateID, &resolution.ResolvedAt)
+	if err != nil {
+		return nil, err
+	}
+	resolution.Action = transactions.ResolutionAction(action)
+	return resolution, nil
+}
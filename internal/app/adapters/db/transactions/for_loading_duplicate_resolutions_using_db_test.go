@@ -0,0 +1,47 @@
+package transactions
+
+import (
+	"context"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test loading the duplicate resolution audit trail
+func TestForLoadingDuplicateResolutionsUsingDB_Success(t *testing.T) {
+	resolvedAt := time.Date(2024, 1, 20, 9, 30, 0, 0, time.UTC)
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"4", "dismissed", "1", "2", resolvedAt}}}
+
+	result, err := NewForLoadingDuplicateResolutionsUsingDB(fakeDB).LoadDuplicateResolutions(context.Background(), "1", transactions.ResolutionFilter{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT id, action, kept_transaction_id, duplicate_transaction_id, resolved_at FROM duplicate_resolutions WHERE tenant_id = ? ORDER BY resolved_at DESC, id DESC", fakeDB.Queries[0])
+	assert.Equal(t, []*transactions.DuplicateResolution{{ID: "4", Action: transactions.ResolutionDismissed, KeptID: "1", DuplicateID: "2", ResolvedAt: resolvedAt}}, result)
+}
+
+// Test only the resolutions of pairs within the accounts are loaded, or none at all
+func TestForLoadingDuplicateResolutionsUsingDB_RestrictedAccounts(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForLoadingDuplicateResolutionsUsingDB(fakeDB)
+
+	_, err := adapter.LoadDuplicateResolutions(context.Background(), "1", transactions.ResolutionFilter{AccountIDs: []string{"3"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT id, action, kept_transaction_id, duplicate_transaction_id, resolved_at FROM duplicate_resolutions WHERE tenant_id = ?"+
+		" AND kept_transaction_id IN (SELECT id FROM transactions WHERE tenant_id = ? AND account_id IN (?))"+
+		" AND duplicate_transaction_id IN (SELECT id FROM transactions WHERE tenant_id = ? AND account_id IN (?))"+
+		" ORDER BY resolved_at DESC, id DESC", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1", "1", "3", "1", "3"}, fakeDB.Args[0])
+
+	_, err = adapter.LoadDuplicateResolutions(context.Background(), "1", transactions.ResolutionFilter{AccountIDs: []string{}})
+	assert.Nil(t, err)
+	assert.Contains(t, fakeDB.Queries[1], "AND FALSE)")
+}
+
+// Test duplicate resolution loading failure
+func TestForLoadingDuplicateResolutionsUsingDB_Failure(t *testing.T) {
+	_, err := NewForLoadingDuplicateResolutionsUsingDB(&FakeDB{ReturnQueryError: true}).LoadDuplicateResolutions(context.Background(), "1", transactions.ResolutionFilter{})
+
+	assert.Equal(t, "failed to load duplicate resolutions: failed to execute query", err.Error())
+}
@@ -0,0 +1,82 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+)
+
+// ruleColumns lists the columns scanRule expects, in order
+const ruleColumns = "id, " + ruleWriteColumns
+
+// ForLoadingRulesUsingDB is the adapter for loading categorisation rules using DB
+type ForLoadingRulesUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingRulesUsingDB creates a new DB adapter for loading categorisation rules
+func NewForLoadingRulesUsingDB(executor db.Executor) *ForLoadingRulesUsingDB {
+	return &ForLoadingRulesUsingDB{db: executor}
+}
+
+// LoadRule loads the tenant's rule with the given ID from DB
+func (a *ForLoadingRulesUsingDB) LoadRule(ctx context.Context, tenantID, id string) (*transactions.Rule, error) {
+	query := "SELECT " + ruleColumns + " FROM categorization_rules WHERE id = ? AND tenant_id = ?"
+	rule, err := db.QueryOne(ctx, a.db, scanRule, query, id, tenantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, transactions.ErrRuleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rule: %w", err)
+	}
+	return rule, nil
+}
+
+// LoadRules loads all rules of the tenant from DB in the order they are tried
+func (a *ForLoadingRulesUsingDB) LoadRules(ctx context.Context, tenantID string) ([]*transactions.Rule, error) {
+	query := "SELECT " + ruleColumns + " FROM categorization_rules WHERE tenant_id = ? ORDER BY priority, id"
+	result, err := db.QueryAll(ctx, a.db, scanRule, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rules: %w", err)
+	}
+	return result, nil
+}
+
+// scanRule maps a categorization_rules row onto the domain model
+func scanRule(row db.Row) (*transactions.Rule, error) {
+	rule := &transactions.Rule{}
+	var accountID, kind, contains, pattern, minAmount, maxAmount, categoryID, payee sql.NullString
+	err := row.Scan(&rule.ID, &rule.Name, &rule.Priority, &accountID, &kind, &contains, &pattern, &minAmount, &maxAmount, &categoryID, &payee)
+	if err != nil {
+		return nil, err
+	}
+	rule.AccountID = accountID.String
+	rule.Type = transactions.Kind(kind.String)
+	rule.DescriptionContains = contains.String
+	rule.DescriptionPattern = pattern.String
+	rule.CategoryID = categoryID.String
+	rule.Payee = payee.String
+	if rule.MinAmount, err = scanDecimal(minAmount); err != nil {
+		return nil, fmt.Errorf("invalid minimum amount stored for rule %s: %w", rule.ID, err)
+	}
+	if rule.MaxAmount, err = scanDecimal(maxAmount); err != nil {
+		return nil, fmt.Errorf("invalid maximum amount stored for rule %s: %w", rule.ID, err)
+	}
+	return rule, nil
+}
+
+// scanDecimal reads a nullable DECIMAL column
+func scanDecimal(value sql.NullString) (*money.Decimal, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	decimal, err := money.ParseDecimal(value.String)
+	if err != nil {
+		return nil, err
+	}
+	return &decimal, nil
+}
@@ -0,0 +1,65 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ruleRow(id string) []interface{} {
+	return []interface{}{id, "Supermarkets", 20, sql.NullString{String: "12345", Valid: true}, sql.NullString{String: "debit", Valid: true},
+		sql.NullString{}, sql.NullString{String: "(?i)^lidl", Valid: true}, sql.NullString{String: "-50.0000", Valid: true}, sql.NullString{},
+		sql.NullString{String: "7", Valid: true}, sql.NullString{}}
+}
+
+// Test loading all rules in priority order
+func TestForLoadingRulesUsingDB_LoadRules(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{ruleRow("1")}}
+	adapter := NewForLoadingRulesUsingDB(fakeDB)
+
+	result, err := adapter.LoadRules(context.Background(), "1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT id, name, priority, account_id, transaction_type, description_contains, description_pattern, min_amount, max_amount, category_id, payee"+
+		" FROM categorization_rules WHERE tenant_id = ? ORDER BY priority, id", fakeDB.Queries[0])
+	assert.Len(t, result, 1)
+	rule := result[0]
+	assert.Equal(t, "Supermarkets", rule.Name)
+	assert.Equal(t, 20, rule.Priority)
+	assert.Equal(t, "12345", rule.AccountID)
+	assert.Equal(t, transactions.KindDebit, rule.Type)
+	assert.Equal(t, "", rule.DescriptionContains)
+	assert.Equal(t, "(?i)^lidl", rule.DescriptionPattern)
+	assert.Equal(t, "-50.0000", rule.MinAmount.String())
+	assert.Nil(t, rule.MaxAmount)
+	assert.Equal(t, "7", rule.CategoryID)
+	assert.Equal(t, "", rule.Payee)
+}
+
+// Test loading a single rule
+func TestForLoadingRulesUsingDB_LoadRule(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{ruleRow("3")}}
+	rule, err := NewForLoadingRulesUsingDB(fakeDB).LoadRule(context.Background(), "1", "3")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "3", rule.ID)
+	assert.Equal(t, []interface{}{"3", "1"}, fakeDB.Args[0])
+}
+
+// Test loading a rule that does not exist
+func TestForLoadingRulesUsingDB_NotFound(t *testing.T) {
+	_, err := NewForLoadingRulesUsingDB(&FakeDB{}).LoadRule(context.Background(), "1", "3")
+
+	assert.True(t, errors.Is(err, transactions.ErrRuleNotFound), "Expected ErrRuleNotFound")
+}
+
+// Test rule loading failure
+func TestForLoadingRulesUsingDB_Failure(t *testing.T) {
+	_, err := NewForLoadingRulesUsingDB(&FakeDB{ReturnQueryError: true}).LoadRules(context.Background(), "1")
+
+	assert.Equal(t, "failed to load rules: failed to execute query", err.Error())
+}
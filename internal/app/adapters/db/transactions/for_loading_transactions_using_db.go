@@ -0,0 +1,204 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+	"strings"
+)
+
+// transactionColumns lists the columns scanTransaction expects, in order
+const transactionColumns = "id, account_id, amount, currency, transaction_type, transaction_date, posted_date, status, description, category_id, payee, external_id, transfer_id, version"
+
+// categoryTreeQuery selects the IDs of a tenant's category and all of its descendants
+const categoryTreeQuery = "WITH RECURSIVE tree AS (SELECT id FROM categories WHERE id = ? AND tenant_id = ? " +
+	"UNION ALL SELECT c.id FROM categories c JOIN tree ON c.parent_id = tree.id) SELECT id FROM tree"
+
+// sortColumns maps the domain sort fields onto the columns they order by
+var sortColumns = map[transactions.SortField]string{
+	transactions.SortByDate:   "transaction_date",
+	transactions.SortByAmount: "amount",
+}
+
+// ForLoadingTransactionsUsingDB is the adapter for loading transactions using DB
+type ForLoadingTransactionsUsingDB struct {
+	db db.Executor
+}
+
+// NewForLoadingTransactionsUsingDB creates a new DB adapter for loading transactions
+func NewForLoadingTransactionsUsingDB(executor db.Executor) *ForLoadingTransactionsUsingDB {
+	return &ForLoadingTransactionsUsingDB{db: executor}
+}
+
+// LoadTransaction loads the tenant's transaction with the given ID from DB
+func (a *ForLoadingTransactionsUsingDB) LoadTransaction(ctx context.Context, tenantID, id string) (*transactions.Transaction, error) {
+	query := "SELECT " + transactionColumns + " FROM transactions WHERE id = ? AND tenant_id = ?"
+	transaction, err := db.QueryOne(ctx, a.db, scanTransaction, query, id, tenantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, transactions.ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transaction: %w", err)
+	}
+	return transaction, nil
+}
+
+// LoadTransactions loads the tenant's transactions matching the filter from DB,
+// using keyset pagination on (sort column, id) so deep pages stay cheap
+func (a *ForLoadingTransactionsUsingDB) LoadTransactions(ctx context.Context, tenantID string, filter transactions.TransactionFilter, after *transactions.PageCursor, limit int) ([]*transactions.Transaction, error) {
+	query, args := buildListQuery(tenantID, filter, after, limit)
+	result, err := db.QueryAll(ctx, a.db, scanTransaction, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transactions: %w", err)
+	}
+	return result, nil
+}
+
+// buildListQuery assembles the filtered, ordered and paginated listing query
+func buildListQuery(tenantID string, filter transactions.TransactionFilter, after *transactions.PageCursor, limit int) (string, []interface{}) {
+	conditions, args := filterConditions(tenantID, filter)
+
+	column := sortColumns[filter.SortBy]
+	direction, comparison := "DESC", "<"
+	if filter.SortOrder == transactions.SortAscending {
+		direction, comparison = "ASC", ">"
+	}
+
+	if after != nil {
+		var value interface{} = after.Date
+		if filter.SortBy == transactions.SortByAmount {
+			value = after.Amount
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", column, comparison, column, comparison))
+		args = append(args, value, value, after.ID)
+	}
+
+	query := "SELECT " + transactionColumns + " FROM transactions WHERE " + strings.Join(conditions, " AND ")
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT ?", column, direction, direction)
+	args = append(args, limit)
+
+	return query, args
+}
+
+// filterConditions turns the tenant and the filter's restrictions into WHERE conditions and
+// their arguments
+func filterConditions(tenantID string, filter transactions.TransactionFilter) ([]string, []interface{}) {
+	conditions := []string{"tenant_id = ?"}
+	args := []interface{}{tenantID}
+
+	if filter.AccountID != "" {
+		conditions = append(conditions, "account_id = ?")
+		args = append(args, filter.AccountID)
+	}
+	if filter.AccountIDs != nil {
+		condition, ids := accountsCondition("account_id", filter.AccountIDs)
+		conditions = append(conditions, condition)
+		args = append(args, ids...)
+	}
+	if filter.CategoryID != "" {
+		conditions = append(conditions, "category_id IN ("+categoryTreeQuery+")")
+		args = append(args, filter.CategoryID, tenantID)
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "transaction_date >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "transaction_date <= ?")
+		args = append(args, *filter.To)
+	}
+	if filter.Type != "" {
+		conditions = append(conditions, "transaction_type = ?")
+		args = append(args, string(filter.Type))
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, string(filter.Status))
+	}
+	if filter.MinAmount != nil {
+		conditions = append(conditions, "amount >= ?")
+		args = append(args, filter.MinAmount.String())
+	}
+	if filter.MaxAmount != nil {
+		conditions = append(conditions, "amount <= ?")
+		args = append(args, filter.MaxAmount.String())
+	}
+	if filter.TransferID != "" {
+		conditions = append(conditions, "transfer_id = ?")
+		args = append(args, filter.TransferID)
+	}
+	if filter.Description != "" {
+		conditions = append(conditions, "description LIKE ?")
+		args = append(args, "%"+escapeLike(filter.Description)+"%")
+	}
+	return conditions, args
+}
+
+// accountsCondition restricts the account column to the given accounts, and
+// to none at all if there are none, with the condition's arguments
+func accountsCondition(column string, accountIDs []string) (string, []interface{}) {
+	if len(accountIDs) == 0 {
+		return "FALSE", nil
+	}
+	args := make([]interface{}, len(accountIDs))
+	for i, id := range accountIDs {
+		args[i] = id
+	}
+	return column + " IN (?" + strings.Repeat(", ?", len(accountIDs)-1) + ")", args
+}
+
+// escapeLike escapes the LIKE wildcards in a user supplied search term
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
+
+// scanTransaction maps a transactions row onto the domain model
+func scanTransaction(row db.Row) (*transactions.Transaction, error) {
+	scanned := &transactionRow{}
+	if err := row.Scan(scanned.targets()...); err != nil {
+		return nil, err
+	}
+	return scanned.transaction()
+}
+
+// transactionRow holds the values of transactionColumns while they are
+// scanned, so queries selecting more than one transaction per row can share
+// the mapping
+type transactionRow struct {
+	result                         transactions.Transaction
+	amount, currency, kind, status string
+	postedDate                     sql.NullTime
+	categoryID, payee, externalID  sql.NullString
+	transferID                     sql.NullString
+}
+
+// targets returns the scan destinations of transactionColumns, in order
+func (r *transactionRow) targets() []interface{} {
+	return []interface{}{&r.result.ID, &r.result.AccountID, &r.amount, &r.currency, &r.kind, &r.result.Timestamp, &r.postedDate,
+		&r.status, &r.result.Description, &r.categoryID, &r.payee, &r.externalID, &r.transferID, &r.result.Version}
+}
+
+// transaction builds the domain model from the scanned values
+func (r *transactionRow) transaction() (*transactions.Transaction, error) {
+	transaction := r.result
+	transaction.Type = transactions.Kind(r.kind)
+	if r.postedDate.Valid {
+		postedDate := r.postedDate.Time
+		transaction.PostedDate = &postedDate
+	}
+	transaction.Status = transactions.Status(r.status)
+	transaction.CategoryID = r.categoryID.String
+	transaction.Payee = r.payee.String
+	transaction.ExternalID = r.externalID.String
+	transaction.TransferID = r.transferID.String
+	var err error
+	transaction.Amount, err = money.Parse(r.amount, money.Currency(r.currency))
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount stored for transaction %s: %w", transaction.ID, err)
+	}
+	return &transaction, nil
+}
@@ -0,0 +1,166 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test loading transactions without any restriction
+func TestForLoadingTransactionsUsingDB_NoFilter(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"1", "12345", "100.0000", "EUR", "credit", date, sql.NullTime{Time: date, Valid: true}, "posted", "Salary", sql.NullString{String: "7", Valid: true}, sql.NullString{String: "Employer", Valid: true}, sql.NullString{String: "FIT-1", Valid: true}, sql.NullString{String: "4", Valid: true}, 2}}}
+	adapter := NewForLoadingTransactionsUsingDB(fakeDB)
+
+	filter := transactions.TransactionFilter{SortBy: transactions.SortByDate, SortOrder: transactions.SortDescending}
+	result, err := adapter.LoadTransactions(context.Background(), "1", filter, nil, 51)
+
+	assert.Nil(t, err, "Expected no error when loading transactions")
+	assert.Equal(t, "SELECT id, account_id, amount, currency, transaction_type, transaction_date, posted_date, status, description, category_id, payee, external_id, transfer_id, version FROM transactions WHERE tenant_id = ? ORDER BY transaction_date DESC, id DESC LIMIT ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1", 51}, fakeDB.Args[0])
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Salary", result[0].Description)
+	assert.Equal(t, 2, result[0].Version)
+	assert.Equal(t, date, result[0].Timestamp)
+	assert.Equal(t, money.MustParse("100.00", "EUR"), result[0].Amount)
+	assert.Equal(t, date, *result[0].PostedDate)
+	assert.Equal(t, transactions.StatusPosted, result[0].Status)
+	assert.Equal(t, "7", result[0].CategoryID)
+	assert.Equal(t, "Employer", result[0].Payee)
+	assert.Equal(t, "FIT-1", result[0].ExternalID)
+	assert.Equal(t, "4", result[0].TransferID)
+}
+
+// Test every filter turns into a condition with its argument
+func TestForLoadingTransactionsUsingDB_AllFilters(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForLoadingTransactionsUsingDB(fakeDB)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	minAmount, _ := money.ParseDecimal("10")
+	maxAmount, _ := money.ParseDecimal("99.50")
+	filter := transactions.TransactionFilter{
+		AccountID:   "12345",
+		CategoryID:  "3",
+		From:        &from,
+		To:          &to,
+		Type:        "debit",
+		Status:      transactions.StatusPending,
+		MinAmount:   &minAmount,
+		MaxAmount:   &maxAmount,
+		Description: "50%_off",
+		TransferID:  "8",
+		SortBy:      transactions.SortByAmount,
+		SortOrder:   transactions.SortAscending,
+	}
+	_, err := adapter.LoadTransactions(context.Background(), "1", filter, nil, 11)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT id, account_id, amount, currency, transaction_type, transaction_date, posted_date, status, description, category_id, payee, external_id, transfer_id, version FROM transactions"+
+		" WHERE tenant_id = ? AND account_id = ? AND category_id IN (WITH RECURSIVE tree AS (SELECT id FROM categories WHERE id = ? AND tenant_id = ?"+
+		" UNION ALL SELECT c.id FROM categories c JOIN tree ON c.parent_id = tree.id) SELECT id FROM tree)"+
+		" AND transaction_date >= ? AND transaction_date <= ? AND transaction_type = ? AND status = ?"+
+		" AND amount >= ? AND amount <= ? AND transfer_id = ? AND description LIKE ?"+
+		" ORDER BY amount ASC, id ASC LIMIT ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1", "12345", "3", "1", from, to, "debit", "pending", "10", "99.50", "8", `%50\%\_off%`, 11}, fakeDB.Args[0])
+}
+
+// Test a cursor resumes the listing after the previous page using the sort column and ID
+func TestForLoadingTransactionsUsingDB_Cursor(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForLoadingTransactionsUsingDB(fakeDB)
+
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	filter := transactions.TransactionFilter{SortBy: transactions.SortByDate, SortOrder: transactions.SortDescending}
+	after := &transactions.PageCursor{SortBy: transactions.SortByDate, Date: date, ID: "42"}
+	_, err := adapter.LoadTransactions(context.Background(), "1", filter, after, 11)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT id, account_id, amount, currency, transaction_type, transaction_date, posted_date, status, description, category_id, payee, external_id, transfer_id, version FROM transactions"+
+		" WHERE tenant_id = ? AND (transaction_date < ? OR (transaction_date = ? AND id < ?))"+
+		" ORDER BY transaction_date DESC, id DESC LIMIT ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1", date, date, "42", 11}, fakeDB.Args[0])
+}
+
+// Test a list of accounts restricts the listing to those accounts, and an empty one to nothing
+func TestForLoadingTransactionsUsingDB_AccountIDs(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForLoadingTransactionsUsingDB(fakeDB)
+
+	filter := transactions.TransactionFilter{AccountIDs: []string{"3", "5"}, SortBy: transactions.SortByDate, SortOrder: transactions.SortDescending}
+	_, err := adapter.LoadTransactions(context.Background(), "1", filter, nil, 11)
+	assert.Nil(t, err)
+
+	filter.AccountIDs = []string{}
+	_, err = adapter.LoadTransactions(context.Background(), "1", filter, nil, 11)
+	assert.Nil(t, err)
+
+	columns := "SELECT id, account_id, amount, currency, transaction_type, transaction_date, posted_date, status, description, category_id, payee, external_id, transfer_id, version FROM transactions"
+	assert.Equal(t, columns+" WHERE tenant_id = ? AND account_id IN (?, ?) ORDER BY transaction_date DESC, id DESC LIMIT ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1", "3", "5", 11}, fakeDB.Args[0])
+	assert.Equal(t, columns+" WHERE tenant_id = ? AND FALSE ORDER BY transaction_date DESC, id DESC LIMIT ?", fakeDB.Queries[1])
+	assert.Equal(t, []interface{}{"1", 11}, fakeDB.Args[1])
+}
+
+// Test transaction loading failure
+func TestForLoadingTransactionsUsingDB_Failure(t *testing.T) {
+	fakeDB := &FakeDB{ReturnQueryError: true}
+	adapter := NewForLoadingTransactionsUsingDB(fakeDB)
+
+	filter := transactions.TransactionFilter{SortBy: transactions.SortByDate, SortOrder: transactions.SortDescending}
+	_, err := adapter.LoadTransactions(context.Background(), "1", filter, nil, 11)
+
+	assert.NotNil(t, err, "Expected an error when loading transactions")
+	assert.Equal(t, "failed to load transactions: failed to execute query", err.Error(), "Expected error message to match")
+}
+
+// Test that a corrupt stored amount is reported rather than silently rounded
+func TestForLoadingTransactionsUsingDB_InvalidStoredAmount(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"1", "12345", "1.005", "EUR", "credit", time.Now(), sql.NullTime{}, "pending", "Salary", sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, 1}}}
+	adapter := NewForLoadingTransactionsUsingDB(fakeDB)
+
+	filter := transactions.TransactionFilter{SortBy: transactions.SortByDate, SortOrder: transactions.SortDescending}
+	_, err := adapter.LoadTransactions(context.Background(), "1", filter, nil, 11)
+
+	assert.True(t, errors.Is(err, money.ErrTooPrecise), "Expected ErrTooPrecise")
+}
+
+// Test loading a single transaction
+func TestForLoadingTransactionsUsingDB_LoadTransaction(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	fakeDB := &FakeDB{Rows: [][]interface{}{{"7", "12345", "-4.5000", "EUR", "debit", date, sql.NullTime{}, "pending", "Coffee", sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, 1}}}
+	adapter := NewForLoadingTransactionsUsingDB(fakeDB)
+
+	transaction, err := adapter.LoadTransaction(context.Background(), "1", "7")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT id, account_id, amount, currency, transaction_type, transaction_date, posted_date, status, description, category_id, payee, external_id, transfer_id, version FROM transactions WHERE id = ? AND tenant_id = ?", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"7", "1"}, fakeDB.Args[0])
+	assert.Equal(t, transactions.StatusPending, transaction.Status)
+	assert.Nil(t, transaction.PostedDate, "A NULL posted date should load as nil")
+}
+
+// Test loading a transaction that does not exist
+func TestForLoadingTransactionsUsingDB_LoadTransaction_NotFound(t *testing.T) {
+	adapter := NewForLoadingTransactionsUsingDB(&FakeDB{})
+
+	_, err := adapter.LoadTransaction(context.Background(), "1", "7")
+
+	assert.True(t, errors.Is(err, transactions.ErrTransactionNotFound), "Expected ErrTransactionNotFound")
+}
+
+// Test failure loading a single transaction
+func TestForLoadingTransactionsUsingDB_LoadTransaction_Failure(t *testing.T) {
+	adapter := NewForLoadingTransactionsUsingDB(&FakeDB{ReturnQueryError: true})
+
+	_, err := adapter.LoadTransaction(context.Background(), "1", "7")
+
+	assert.Equal(t, "failed to load transaction: failed to execute query", err.Error())
+}
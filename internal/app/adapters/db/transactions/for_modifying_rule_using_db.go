@@ -0,0 +1,30 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+	"strings"
+)
+
+// ForModifyingRuleUsingDB is the adapter for persisting categorisation rule changes using DB
+type ForModifyingRuleUsingDB struct {
+	db db.Executor
+}
+
+// NewForModifyingRuleUsingDB creates a new DB adapter for modifying categorisation rules
+func NewForModifyingRuleUsingDB(executor db.Executor) *ForModifyingRuleUsingDB {
+	return &ForModifyingRuleUsingDB{db: executor}
+}
+
+// ModifyRule writes all of the given rule's conditions and actions to DB, provided it belongs to the tenant
+func (a *ForModifyingRuleUsingDB) ModifyRule(ctx context.Context, tenantID string, rule *transactions.Rule) error {
+	assignments := strings.ReplaceAll(ruleWriteColumns, ",", " = ?,") + " = ?"
+	query := "UPDATE categorization_rules SET " + assignments + " WHERE id = ? AND tenant_id = ?"
+	_, err := a.db.ExecContext(ctx, query, append(ruleArgs(rule), rule.ID, tenantID)...)
+	if err != nil {
+		return fmt.Errorf("failed to modify rule: %w", err)
+	}
+	return nil
+}
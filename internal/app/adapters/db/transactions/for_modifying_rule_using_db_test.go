@@ -0,0 +1,31 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test modifying a rule rewrites every column
+func TestForModifyingRuleUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForModifyingRuleUsingDB(fakeDB)
+
+	err := adapter.ModifyRule(context.Background(), "1", &transactions.Rule{ID: "4", Name: "Coffee", DescriptionContains: "starbucks", CategoryID: "7"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "UPDATE categorization_rules SET name = ?, priority = ?, account_id = ?, transaction_type = ?, description_contains = ?, description_pattern = ?,"+
+		" min_amount = ?, max_amount = ?, category_id = ?, payee = ? WHERE id = ? AND tenant_id = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"Coffee", 0, sql.NullString{}, sql.NullString{}, sql.NullString{String: "starbucks", Valid: true}, sql.NullString{},
+		sql.NullString{}, sql.NullString{}, sql.NullString{String: "7", Valid: true}, sql.NullString{}, "4", "1"}, fakeDB.ExecArgs[0])
+}
+
+// Test rule modification failure
+func TestForModifyingRuleUsingDB_Failure(t *testing.T) {
+	err := NewForModifyingRuleUsingDB(&FakeDB{ReturnError: true}).ModifyRule(context.Background(), "1", &transactions.Rule{ID: "4"})
+
+	assert.Equal(t, "failed to modify rule: failed to execute query", err.Error())
+}
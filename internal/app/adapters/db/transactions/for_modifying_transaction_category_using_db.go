@@ -0,0 +1,42 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/infra/db"
+	"strings"
+)
+
+// ForModifyingTransactionCategoryUsingDB is the adapter for persisting transaction categories using DB
+type ForModifyingTransactionCategoryUsingDB struct {
+	db db.Executor
+}
+
+// NewForModifyingTransactionCategoryUsingDB creates a new DB adapter for persisting transaction categories
+func NewForModifyingTransactionCategoryUsingDB(executor db.Executor) *ForModifyingTransactionCategoryUsingDB {
+	return &ForModifyingTransactionCategoryUsingDB{db: executor}
+}
+
+// ModifyTransactionCategory sets the category of all the given transactions of the tenant in a
+// single statement and returns how many of them changed. Only the versions of those that changed
+// go up; the version is assigned first so it compares against the category the transaction had
+// before.
+func (a *ForModifyingTransactionCategoryUsingDB) ModifyTransactionCategory(ctx context.Context, tenantID string, ids []string, categoryID string) (int, error) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	query := "UPDATE transactions SET version = IF(category_id <=> ?, version, version + 1), category_id = ? WHERE id IN (" + placeholders + ") AND tenant_id = ?"
+	args := []interface{}{nullableString(categoryID), nullableString(categoryID)}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	args = append(args, tenantID)
+
+	result, err := a.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to modify transaction category: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve affected rows: %w", err)
+	}
+	return int(affected), nil
+}
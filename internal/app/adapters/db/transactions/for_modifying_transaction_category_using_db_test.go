@@ -0,0 +1,38 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test setting the category of several transactions at once
+func TestForModifyingTransactionCategoryUsingDB(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForModifyingTransactionCategoryUsingDB(fakeDB)
+
+	changed, err := adapter.ModifyTransactionCategory(context.Background(), "1", []string{"1", "2", "3"}, "7")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, changed)
+	assert.Equal(t, "UPDATE transactions SET version = IF(category_id <=> ?, version, version + 1), category_id = ? WHERE id IN (?, ?, ?) AND tenant_id = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{sql.NullString{String: "7", Valid: true}, sql.NullString{String: "7", Valid: true}, "1", "2", "3", "1"}, fakeDB.ExecArgs[0])
+}
+
+// Test clearing the category stores NULL
+func TestForModifyingTransactionCategoryUsingDB_Clear(t *testing.T) {
+	fakeDB := &FakeDB{}
+	_, err := NewForModifyingTransactionCategoryUsingDB(fakeDB).ModifyTransactionCategory(context.Background(), "1", []string{"1"}, "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, sql.NullString{}, fakeDB.ExecArgs[0][0])
+}
+
+// Test modification failure
+func TestForModifyingTransactionCategoryUsingDB_Failure(t *testing.T) {
+	_, err := NewForModifyingTransactionCategoryUsingDB(&FakeDB{ReturnError: true}).ModifyTransactionCategory(context.Background(), "1", []string{"1"}, "7")
+
+	assert.Equal(t, "failed to modify transaction category: failed to execute query", err.Error())
+}
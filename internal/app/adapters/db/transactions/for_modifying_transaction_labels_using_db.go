@@ -0,0 +1,30 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+)
+
+// ForModifyingTransactionLabelsUsingDB is the adapter for persisting transaction categories and payees using DB
+type ForModifyingTransactionLabelsUsingDB struct {
+	db db.Executor
+}
+
+// NewForModifyingTransactionLabelsUsingDB creates a new DB adapter for persisting transaction categories and payees
+func NewForModifyingTransactionLabelsUsingDB(executor db.Executor) *ForModifyingTransactionLabelsUsingDB {
+	return &ForModifyingTransactionLabelsUsingDB{db: executor}
+}
+
+// ModifyTransactionLabels writes the given transaction's category and payee to DB and advances its
+// version, provided it belongs to the tenant
+func (a *ForModifyingTransactionLabelsUsingDB) ModifyTransactionLabels(ctx context.Context, tenantID string, transaction *transactions.Transaction) error {
+	query := "UPDATE transactions SET category_id = ?, payee = ?, version = version + 1 WHERE id = ? AND tenant_id = ?"
+	_, err := a.db.ExecContext(ctx, query, nullableString(transaction.CategoryID), nullableString(transaction.Payee), transaction.ID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to modify transaction labels: %w", err)
+	}
+	transaction.Version++
+	return nil
+}
@@ -0,0 +1,30 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test writing a transaction's category and payee
+func TestForModifyingTransactionLabelsUsingDB(t *testing.T) {
+	fakeDB := &FakeDB{}
+	transaction := &transactions.Transaction{ID: "9", Payee: "Lidl"}
+
+	err := NewForModifyingTransactionLabelsUsingDB(fakeDB).ModifyTransactionLabels(context.Background(), "1", transaction)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "UPDATE transactions SET category_id = ?, payee = ?, version = version + 1 WHERE id = ? AND tenant_id = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{sql.NullString{}, sql.NullString{String: "Lidl", Valid: true}, "9", "1"}, fakeDB.ExecArgs[0])
+	assert.Equal(t, 1, transaction.Version, "The transaction should move on to the next version")
+}
+
+// Test label modification failure
+func TestForModifyingTransactionLabelsUsingDB_Failure(t *testing.T) {
+	err := NewForModifyingTransactionLabelsUsingDB(&FakeDB{ReturnError: true}).ModifyTransactionLabels(context.Background(), "1", &transactions.Transaction{ID: "9"})
+
+	assert.Equal(t, "failed to modify transaction labels: failed to execute query", err.Error())
+}
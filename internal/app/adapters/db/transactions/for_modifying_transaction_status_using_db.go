@@ -0,0 +1,44 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+)
+
+// ForModifyingTransactionStatusUsingDB is the adapter for persisting transaction status changes using DB
+type ForModifyingTransactionStatusUsingDB struct {
+	db db.Executor
+}
+
+// NewForModifyingTransactionStatusUsingDB creates a new DB adapter for persisting transaction status changes
+func NewForModifyingTransactionStatusUsingDB(executor db.Executor) *ForModifyingTransactionStatusUsingDB {
+	return &ForModifyingTransactionStatusUsingDB{db: executor}
+}
+
+// ModifyTransactionStatus writes the transaction's status and posted date, provided the stored
+// transaction belongs to the tenant and is still in the from status, and advances its version.
+// Voiding takes the amount back out of the balance snapshot of the transaction's month, so callers
+// run it in a unit of work.
+func (a *ForModifyingTransactionStatusUsingDB) ModifyTransactionStatus(ctx context.Context, tenantID string, transaction *transactions.Transaction, from transactions.Status) error {
+	query := "UPDATE transactions SET status = ?, posted_date = ?, version = version + 1 WHERE id = ? AND tenant_id = ? AND status = ?"
+	result, err := a.db.ExecContext(ctx, query, string(transaction.Status), nullableDate(transaction.PostedDate), transaction.ID, tenantID, string(from))
+	if err != nil {
+		return fmt.Errorf("failed to modify transaction status: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: transaction %s is no longer %s", transactions.ErrInvalidStatusTransition, transaction.ID, from)
+	}
+	transaction.Version++
+
+	if transaction.Status != transactions.StatusVoided || from == transactions.StatusVoided {
+		return nil
+	}
+	return recordBalanceChange(ctx, a.db, tenantID, transaction.AccountID, transaction.Timestamp, transaction.Amount.Neg())
+}
@@ -0,0 +1,67 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPostedTransaction() *transactions.Transaction {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	transaction := transactions.NewTransaction("7", "12345", money.MustParse("-4.50", "EUR"), "debit", date, "Coffee")
+	_ = transaction.Post(date)
+	return transaction
+}
+
+// Test persisting a status change
+func TestForModifyingTransactionStatusUsingDB(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForModifyingTransactionStatusUsingDB(fakeDB)
+
+	transaction := newPostedTransaction()
+	err := adapter.ModifyTransactionStatus(context.Background(), "1", transaction, transactions.StatusPending)
+
+	assert.Nil(t, err, "Expected no error when modifying the status")
+	assert.Equal(t, "UPDATE transactions SET status = ?, posted_date = ?, version = version + 1 WHERE id = ? AND tenant_id = ? AND status = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"posted", sql.NullTime{Time: *transaction.PostedDate, Valid: true}, "7", "1", "pending"}, fakeDB.ExecArgs[0])
+	assert.Len(t, fakeDB.ExecQueries, 1, "Posting does not change the balance")
+	assert.Equal(t, 2, transaction.Version, "The transaction should move on to the next version")
+}
+
+// Test voiding takes the amount back out of its month's balance snapshot
+func TestForModifyingTransactionStatusUsingDB_Void(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForModifyingTransactionStatusUsingDB(fakeDB)
+
+	transaction := newPostedTransaction()
+	_ = transaction.Void()
+	err := adapter.ModifyTransactionStatus(context.Background(), "1", transaction, transactions.StatusPosted)
+
+	assert.Nil(t, err)
+	assert.Contains(t, fakeDB.ExecQueries[1], "INSERT INTO balance_snapshots")
+	assert.Equal(t, []interface{}{"1", "12345", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "4.50"}, fakeDB.ExecArgs[1])
+}
+
+// Test a status change is refused when the stored status has moved on
+func TestForModifyingTransactionStatusUsingDB_Changed(t *testing.T) {
+	adapter := NewForModifyingTransactionStatusUsingDB(&FakeDB{ReturnNoneAffected: true})
+
+	err := adapter.ModifyTransactionStatus(context.Background(), "1", newPostedTransaction(), transactions.StatusPending)
+
+	assert.True(t, errors.Is(err, transactions.ErrInvalidStatusTransition), "Expected ErrInvalidStatusTransition")
+}
+
+// Test status change failure
+func TestForModifyingTransactionStatusUsingDB_Failure(t *testing.T) {
+	adapter := NewForModifyingTransactionStatusUsingDB(&FakeDB{ReturnError: true})
+
+	err := adapter.ModifyTransactionStatus(context.Background(), "1", newPostedTransaction(), transactions.StatusPending)
+
+	assert.Equal(t, "failed to modify transaction status: failed to execute query", err.Error())
+}
@@ -0,0 +1,52 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+)
+
+// ForModifyingTransactionUsingDB is the adapter for persisting transaction edits using DB
+type ForModifyingTransactionUsingDB struct {
+	db db.Executor
+}
+
+// NewForModifyingTransactionUsingDB creates a new DB adapter for persisting transaction edits
+func NewForModifyingTransactionUsingDB(executor db.Executor) *ForModifyingTransactionUsingDB {
+	return &ForModifyingTransactionUsingDB{db: executor}
+}
+
+// ModifyTransaction writes the transaction's description and amount and advances its version,
+// provided the stored transaction belongs to the tenant and is still at transaction.Version. A
+// changed amount moves the balance snapshot of the transaction's month by the difference, so
+// callers run it in a unit of work.
+func (a *ForModifyingTransactionUsingDB) ModifyTransaction(ctx context.Context, tenantID string, transaction *transactions.Transaction, previous money.Money) error {
+	query := "UPDATE transactions SET description = ?, amount = ?, version = version + 1 WHERE id = ? AND tenant_id = ? AND version = ?"
+	result, err := a.db.ExecContext(ctx, query, transaction.Description, transaction.Amount.String(), transaction.ID, tenantID, transaction.Version)
+	if err != nil {
+		return fmt.Errorf("failed to modify transaction: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: transaction %s is no longer at version %d", transactions.ErrVersionMismatch, transaction.ID, transaction.Version)
+	}
+	transaction.Version++
+
+	if transaction.Status == transactions.StatusVoided {
+		return nil
+	}
+	change, err := transaction.Amount.Sub(previous)
+	if err != nil {
+		return err
+	}
+	if change.IsZero() {
+		return nil
+	}
+	return recordBalanceChange(ctx, a.db, tenantID, transaction.AccountID, transaction.Timestamp, change)
+}
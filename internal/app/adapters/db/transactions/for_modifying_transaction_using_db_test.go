@@ -0,0 +1,72 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newEditedTransaction() *transactions.Transaction {
+	transaction := transactions.NewTransaction("7", "12345", money.MustParse("-5.40", "EUR"), "debit", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), "Coffee")
+	transaction.Version = 3
+	return transaction
+}
+
+// Test persisting an edit moves its month's balance snapshot by the change in amount
+func TestForModifyingTransactionUsingDB(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForModifyingTransactionUsingDB(fakeDB)
+
+	transaction := newEditedTransaction()
+	err := adapter.ModifyTransaction(context.Background(), "1", transaction, money.MustParse("-4.50", "EUR"))
+
+	assert.Nil(t, err, "Expected no error when modifying the transaction")
+	assert.Equal(t, "UPDATE transactions SET description = ?, amount = ?, version = version + 1 WHERE id = ? AND tenant_id = ? AND version = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"Coffee", "-5.40", "7", "1", 3}, fakeDB.ExecArgs[0])
+	assert.Equal(t, 4, transaction.Version, "The transaction should move on to the next version")
+	assert.Contains(t, fakeDB.ExecQueries[1], "INSERT INTO balance_snapshots")
+	assert.Equal(t, []interface{}{"1", "12345", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "-0.90"}, fakeDB.ExecArgs[1])
+}
+
+// Test an edit that leaves the amount alone, or is to a voided transaction, does not touch the balance
+func TestForModifyingTransactionUsingDB_BalanceUnchanged(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForModifyingTransactionUsingDB(fakeDB)
+
+	err := adapter.ModifyTransaction(context.Background(), "1", newEditedTransaction(), money.MustParse("-5.40", "EUR"))
+	assert.Nil(t, err)
+
+	voided := newEditedTransaction()
+	voided.Status = transactions.StatusVoided
+	err = adapter.ModifyTransaction(context.Background(), "1", voided, money.MustParse("-4.50", "EUR"))
+	assert.Nil(t, err)
+
+	assert.Len(t, fakeDB.ExecQueries, 2, "Only the transactions should be updated")
+}
+
+// Test an edit is refused when the stored transaction has moved on
+func TestForModifyingTransactionUsingDB_VersionMismatch(t *testing.T) {
+	fakeDB := &FakeDB{ReturnNoneAffected: true}
+	adapter := NewForModifyingTransactionUsingDB(fakeDB)
+
+	transaction := newEditedTransaction()
+	err := adapter.ModifyTransaction(context.Background(), "1", transaction, money.MustParse("-4.50", "EUR"))
+
+	assert.True(t, errors.Is(err, transactions.ErrVersionMismatch), "Expected ErrVersionMismatch")
+	assert.Equal(t, 3, transaction.Version, "The transaction should keep its version")
+	assert.Len(t, fakeDB.ExecQueries, 1, "The balance should not change")
+}
+
+// Test edit failure
+func TestForModifyingTransactionUsingDB_Failure(t *testing.T) {
+	adapter := NewForModifyingTransactionUsingDB(&FakeDB{ReturnError: true})
+
+	err := adapter.ModifyTransaction(context.Background(), "1", newEditedTransaction(), money.MustParse("-4.50", "EUR"))
+
+	assert.Equal(t, "failed to modify transaction: failed to execute query", err.Error())
+}
@@ -0,0 +1,34 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+	"time"
+)
+
+// ForRecordingOpeningBalanceUsingDB is the adapter for recording an account's
+// opening balance as its first transaction
+type ForRecordingOpeningBalanceUsingDB struct {
+	transactionPersistence *ForSavingTransactionUsingDB
+}
+
+// NewForRecordingOpeningBalanceUsingDB creates a new DB adapter for recording opening balances
+func NewForRecordingOpeningBalanceUsingDB(executor db.Executor) *ForRecordingOpeningBalanceUsingDB {
+	return &ForRecordingOpeningBalanceUsingDB{transactionPersistence: NewForSavingTransactionUsingDB(executor)}
+}
+
+// RecordOpeningBalance saves a posted adjustment transaction carrying the opening balance
+func (a *ForRecordingOpeningBalanceUsingDB) RecordOpeningBalance(ctx context.Context, tenantID, accountID string, amount money.Money) error {
+	today := transactions.DateOf(time.Now())
+	transaction := transactions.NewTransaction("", accountID, amount, transactions.KindAdjustment, today, "Opening balance")
+	if err := transaction.Post(today); err != nil {
+		return fmt.Errorf("failed to record opening balance: %w", err)
+	}
+	if err := a.transactionPersistence.SaveTransaction(ctx, tenantID, transaction); err != nil {
+		return fmt.Errorf("failed to record opening balance: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test recording an opening balance
+func TestForRecordingOpeningBalanceUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForRecordingOpeningBalanceUsingDB(fakeDB)
+
+	err := adapter.RecordOpeningBalance(context.Background(), "2", "1", money.MustParse("250.50", "EUR"))
+	assert.Nil(t, err, "Expected no error when recording opening balance")
+	assert.Len(t, fakeDB.ExecQueries, 2, "The transaction and its balance snapshot should be written")
+
+	today := transactions.DateOf(time.Now())
+	args := fakeDB.ExecArgs[0]
+	assert.Equal(t, "2", args[0], "Opening balance should belong to the account's tenant")
+	assert.Equal(t, "1", args[1])
+	assert.Equal(t, "250.50", args[2])
+	assert.Equal(t, "EUR", args[3])
+	assert.Equal(t, string(transactions.KindAdjustment), args[4])
+	assert.Equal(t, today, args[5], "Opening balance should be dated today")
+	assert.Equal(t, sql.NullTime{Time: today, Valid: true}, args[6])
+	assert.Equal(t, string(transactions.StatusPosted), args[7], "Opening balance should be posted")
+}
+
+// Test opening balance recording failure
+func TestForRecordingOpeningBalanceUsingDB_Failure(t *testing.T) {
+	adapter := NewForRecordingOpeningBalanceUsingDB(&FakeDB{ReturnError: true})
+
+	err := adapter.RecordOpeningBalance(context.Background(), "1", "1", money.MustParse("250.50", "EUR"))
+	assert.NotNil(t, err, "Expected an error when recording opening balance")
+	assert.Equal(t, "failed to record opening balance: failed to save transaction: failed to execute query", err.Error(), "Expected error message to match")
+}
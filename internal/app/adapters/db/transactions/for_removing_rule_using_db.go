@@ -0,0 +1,35 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+)
+
+// ForRemovingRuleUsingDB is the adapter for removing categorisation rules using DB
+type ForRemovingRuleUsingDB struct {
+	db db.Executor
+}
+
+// NewForRemovingRuleUsingDB creates a new DB adapter for removing categorisation rules
+func NewForRemovingRuleUsingDB(executor db.Executor) *ForRemovingRuleUsingDB {
+	return &ForRemovingRuleUsingDB{db: executor}
+}
+
+// RemoveRule deletes the tenant's rule with the given ID from DB
+func (a *ForRemovingRuleUsingDB) RemoveRule(ctx context.Context, tenantID, id string) error {
+	result, err := a.db.ExecContext(ctx, "DELETE FROM categorization_rules WHERE id = ? AND tenant_id = ?", id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to remove rule: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rows affected: %w", err)
+	}
+	if affected == 0 {
+		return transactions.ErrRuleNotFound
+	}
+	return nil
+}
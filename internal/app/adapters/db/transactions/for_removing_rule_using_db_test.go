@@ -0,0 +1,34 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test successful rule removal
+func TestForRemovingRuleUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	err := NewForRemovingRuleUsingDB(fakeDB).RemoveRule(context.Background(), "1", "4")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "DELETE FROM categorization_rules WHERE id = ? AND tenant_id = ?", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"4", "1"}, fakeDB.ExecArgs[0])
+}
+
+// Test removing a rule that does not exist
+func TestForRemovingRuleUsingDB_NotFound(t *testing.T) {
+	err := NewForRemovingRuleUsingDB(&FakeDB{ReturnNoneAffected: true}).RemoveRule(context.Background(), "1", "4")
+
+	assert.True(t, errors.Is(err, transactions.ErrRuleNotFound), "Expected ErrRuleNotFound")
+}
+
+// Test rule removal failure
+func TestForRemovingRuleUsingDB_Failure(t *testing.T) {
+	err := NewForRemovingRuleUsingDB(&FakeDB{ReturnError: true}).RemoveRule(context.Background(), "1", "4")
+
+	assert.Equal(t, "failed to remove rule: failed to execute query", err.Error())
+}
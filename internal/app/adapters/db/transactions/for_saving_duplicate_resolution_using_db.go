@@ -0,0 +1,34 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+)
+
+// ForSavingDuplicateResolutionUsingDB is the adapter for saving duplicate resolutions using DB
+type ForSavingDuplicateResolutionUsingDB struct {
+	db db.Executor
+}
+
+// NewForSavingDuplicateResolutionUsingDB creates a new DB adapter for saving duplicate resolutions
+func NewForSavingDuplicateResolutionUsingDB(executor db.Executor) *ForSavingDuplicateResolutionUsingDB {
+	return &ForSavingDuplicateResolutionUsingDB{db: executor}
+}
+
+// SaveDuplicateResolution saves the given resolution of the tenant to DB
+func (a *ForSavingDuplicateResolutionUsingDB) SaveDuplicateResolution(ctx context.Context, tenantID string, resolution *transactions.DuplicateResolution) error {
+	query := "INSERT INTO duplicate_resolutions (tenant_id, action, kept_transaction_id, duplicate_transaction_id, resolved_at) VALUES (?, ?, ?, ?, ?)"
+	result, err := a.db.ExecContext(ctx, query, tenantID, string(resolution.Action), resolution.KeptID, resolution.DuplicateID, resolution.ResolvedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save duplicate resolution: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve last insert ID: %w", err)
+	}
+	resolution.ID = fmt.Sprintf("%d", id)
+	return nil
+}
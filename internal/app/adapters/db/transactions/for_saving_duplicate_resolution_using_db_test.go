@@ -0,0 +1,38 @@
+package transactions
+
+import (
+	"context"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test saving a duplicate resolution
+func TestForSavingDuplicateResolutionUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	resolvedAt := time.Date(2024, 1, 20, 9, 30, 0, 0, time.UTC)
+	resolution := &transactions.DuplicateResolution{Action: transactions.ResolutionMerged, KeptID: "1", DuplicateID: "2", ResolvedAt: resolvedAt}
+
+	err := NewForSavingDuplicateResolutionUsingDB(fakeDB).SaveDuplicateResolution(context.Background(), "1", resolution)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "0", resolution.ID)
+	assert.Equal(t, "INSERT INTO duplicate_resolutions (tenant_id, action, kept_transaction_id, duplicate_transaction_id, resolved_at) VALUES (?, ?, ?, ?, ?)", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"1", "merged", "1", "2", resolvedAt}, fakeDB.ExecArgs[0])
+}
+
+// Test duplicate resolution saving failure
+func TestForSavingDuplicateResolutionUsingDB_Failure(t *testing.T) {
+	err := NewForSavingDuplicateResolutionUsingDB(&FakeDB{ReturnError: true}).SaveDuplicateResolution(context.Background(), "1", &transactions.DuplicateResolution{})
+
+	assert.Equal(t, "failed to save duplicate resolution: failed to execute query", err.Error())
+}
+
+// Test failure to retrieve the new resolution's ID
+func TestForSavingDuplicateResolutionUsingDB_InsertIDFailure(t *testing.T) {
+	err := NewForSavingDuplicateResolutionUsingDB(&FakeDB{ReturnInsertError: true}).SaveDuplicateResolution(context.Background(), "1", &transactions.DuplicateResolution{})
+
+	assert.Equal(t, "failed to retrieve last insert ID: failed to execute query", err.Error())
+}
@@ -0,0 +1,55 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+)
+
+// ForSavingRuleUsingDB is the adapter for saving categorisation rules using DB
+type ForSavingRuleUsingDB struct {
+	db db.Executor
+}
+
+// NewForSavingRuleUsingDB creates a new DB adapter for saving categorisation rules
+func NewForSavingRuleUsingDB(executor db.Executor) *ForSavingRuleUsingDB {
+	return &ForSavingRuleUsingDB{db: executor}
+}
+
+// SaveRule saves the given rule of the tenant to DB
+func (a *ForSavingRuleUsingDB) SaveRule(ctx context.Context, tenantID string, rule *transactions.Rule) error {
+	query := "INSERT INTO categorization_rules (tenant_id, " + ruleWriteColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := a.db.ExecContext(ctx, query, append([]interface{}{tenantID}, ruleArgs(rule)...)...)
+	if err != nil {
+		return fmt.Errorf("failed to save rule: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve last insert ID: %w", err)
+	}
+	rule.ID = fmt.Sprintf("%d", id)
+	return nil
+}
+
+// ruleWriteColumns lists the columns ruleArgs fills, in order
+const ruleWriteColumns = "name, priority, account_id, transaction_type, description_contains, description_pattern, min_amount, max_amount, category_id, payee"
+
+// ruleArgs flattens a rule into the values of ruleWriteColumns, storing unset
+// conditions and actions as NULL
+func ruleArgs(rule *transactions.Rule) []interface{} {
+	return []interface{}{rule.Name, rule.Priority, nullableString(rule.AccountID), nullableString(string(rule.Type)),
+		nullableString(rule.DescriptionContains), nullableString(rule.DescriptionPattern), nullableDecimal(rule.MinAmount),
+		nullableDecimal(rule.MaxAmount), nullableString(rule.CategoryID), nullableString(rule.Payee)}
+}
+
+// nullableDecimal stores a missing amount as NULL
+func nullableDecimal(value *money.Decimal) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: value.String(), Valid: true}
+}
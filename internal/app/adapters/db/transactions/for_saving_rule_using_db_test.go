@@ -0,0 +1,35 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test saving a rule stores unset conditions and actions as NULL
+func TestForSavingRuleUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForSavingRuleUsingDB(fakeDB)
+
+	maxAmount, _ := money.ParseDecimal("-100")
+	rule := &transactions.Rule{Name: "Big shops", Priority: 10, DescriptionContains: "tesco", MaxAmount: &maxAmount, Payee: "Tesco"}
+	err := adapter.SaveRule(context.Background(), "1", rule)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "0", rule.ID)
+	assert.Equal(t, "INSERT INTO categorization_rules (tenant_id, name, priority, account_id, transaction_type, description_contains, description_pattern, min_amount, max_amount, category_id, payee)"+
+		" VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"1", "Big shops", 10, sql.NullString{}, sql.NullString{}, sql.NullString{String: "tesco", Valid: true}, sql.NullString{},
+		sql.NullString{}, sql.NullString{String: "-100", Valid: true}, sql.NullString{}, sql.NullString{String: "Tesco", Valid: true}}, fakeDB.ExecArgs[0])
+}
+
+// Test rule saving failure
+func TestForSavingRuleUsingDB_Failure(t *testing.T) {
+	err := NewForSavingRuleUsingDB(&FakeDB{ReturnError: true}).SaveRule(context.Background(), "1", &transactions.Rule{Name: "Rule"})
+
+	assert.Equal(t, "failed to save rule: failed to execute query", err.Error())
+}
@@ -1,9 +1,12 @@
 package transactions
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"spend-api/internal/domain/transactions"
 	"spend-api/internal/infra/db"
+	"time"
 )
 
 // ForSavingTransactionUsingDB is the adapter for saving transactions using DB
@@ -16,10 +19,19 @@ func NewForSavingTransactionUsingDB(executor db.Executor) *ForSavingTransactionU
 	return &ForSavingTransactionUsingDB{db: executor}
 }
 
-// SaveTransaction saves the given transaction to DB
-func (a *ForSavingTransactionUsingDB) SaveTransaction(transaction *transactions.Transaction) error {
-	query := "INSERT INTO transactions (account_id, amount, type, description) VALUES (?, ?, ?, ?, ?)"
-	result, err := a.db.Exec(query, transaction.AccountID, transaction.Amount, transaction.Type, transaction.Description)
+// SaveTransaction saves the given transaction to DB and adds its amount to the balance snapshot
+// of its month. Callers run it in a unit of work so the two writes stay in step. External IDs
+// are unique per account, so saving an already imported one fails with ErrDuplicateTransaction.
+// The legs of a transfer carry the ID of a transfer saved beforehand. The transaction belongs to
+// the tenant, as must its account.
+func (a *ForSavingTransactionUsingDB) SaveTransaction(ctx context.Context, tenantID string, transaction *transactions.Transaction) error {
+	query := "INSERT INTO transactions (tenant_id, account_id, amount, currency, transaction_type, transaction_date, posted_date, status, description, category_id, payee, external_id, transfer_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := a.db.ExecContext(ctx, query, tenantID, transaction.AccountID, transaction.Amount.String(), string(transaction.Amount.Currency()), string(transaction.Type),
+		transaction.Timestamp, nullableDate(transaction.PostedDate), string(transaction.Status), transaction.Description, nullableString(transaction.CategoryID), nullableString(transaction.Payee),
+		nullableString(transaction.ExternalID), nullableString(transaction.TransferID))
+	if db.IsDuplicateKey(err) {
+		return transactions.ErrDuplicateTransaction
+	}
 	if err != nil {
 		return fmt.Errorf("failed to save transaction: %w", err)
 	}
@@ -29,5 +41,22 @@ func (a *ForSavingTransactionUsingDB) SaveTransaction(transaction *transactions.
 		return fmt.Errorf("failed to retrieve last insert ID: %w", err)
 	}
 	transaction.ID = fmt.Sprintf("%d", id)
-	return nil
+
+	if transaction.Status == transactions.StatusVoided {
+		return nil
+	}
+	return recordBalanceChange(ctx, a.db, tenantID, transaction.AccountID, transaction.Timestamp, transaction.Amount)
+}
+
+// nullableDate stores a missing date as NULL
+func nullableDate(date *time.Time) sql.NullTime {
+	if date == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *date, Valid: true}
+}
+
+// nullableString stores an empty string as NULL
+func nullableString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
 }
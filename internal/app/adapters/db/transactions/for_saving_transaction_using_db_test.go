@@ -1,37 +1,130 @@
 package transactions
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"reflect"
+	"spend-api/internal/domain/money"
 	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
 	"testing"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 
 // Fake DB for simulating DB behavior
 type FakeDB struct {
-	ReturnError       bool
-	ReturnInsertError bool
+	ReturnError        bool
+	ReturnInsertError  bool
+	ReturnQueryError   bool
+	ReturnNoneAffected bool
+	ExecErr            error
+	Rows               [][]interface{}
+	Queries            []string
+	Args               [][]interface{}
+	ExecQueries        []string
+	ExecArgs           [][]interface{}
 }
 
 func (f *FakeDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	f.ExecQueries = append(f.ExecQueries, query)
+	f.ExecArgs = append(f.ExecArgs, args)
 	if f.ReturnError {
 		return nil, errors.New("failed to execute query")
 	}
+	if f.ExecErr != nil {
+		return nil, f.ExecErr
+	}
 	if f.ReturnInsertError {
 		return &MockFailedResult{}, nil
+	} else if f.ReturnNoneAffected {
+		return &MockEmptyResult{}, nil
 	} else {
 		return &MockResult{}, nil
 	}
 }
 
+func (f *FakeDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return f.Exec(query, args...)
+}
+
+func (f *FakeDB) QueryContext(ctx context.Context, query string, args ...interface{}) (db.Rows, error) {
+	return f.Query(query, args...)
+}
+
+func (f *FakeDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) db.Row {
+	return f.QueryRow(query, args...)
+}
+
+func (f *FakeDB) Query(query string, args ...interface{}) (db.Rows, error) {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return nil, errors.New("failed to execute query")
+	}
+	return &FakeRows{rows: f.Rows}, nil
+}
+
+func (f *FakeDB) QueryRow(query string, args ...interface{}) db.Row {
+	f.Queries = append(f.Queries, query)
+	f.Args = append(f.Args, args)
+	if f.ReturnQueryError {
+		return &FakeRow{err: errors.New("failed to execute query")}
+	}
+	if len(f.Rows) == 0 {
+		return &FakeRow{err: sql.ErrNoRows}
+	}
+	return &FakeRow{values: f.Rows[0]}
+}
+
 func (f *FakeDB) Close() error {
 	return nil
 }
 
+// FakeRows replays canned rows for queries
+type FakeRows struct {
+	rows [][]interface{}
+	pos  int
+}
+
+func (r *FakeRows) Next() bool {
+	if r.pos < len(r.rows) {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+func (r *FakeRows) Scan(dest ...interface{}) error { return scanValues(r.rows[r.pos-1], dest) }
+func (r *FakeRows) Err() error                     { return nil }
+func (r *FakeRows) Close() error                   { return nil }
+
+// FakeRow replays a single canned row
+type FakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r *FakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+func scanValues(values []interface{}, dest []interface{}) error {
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(values[i]))
+	}
+	return nil
+}
+
 type MockResult struct{}
 type MockFailedResult struct{}
+type MockEmptyResult struct{}
 
 func (r *MockResult) LastInsertId() (int64, error) { return 0, nil }
 func (r *MockResult) RowsAffected() (int64, error) { return 1, nil }
@@ -41,21 +134,52 @@ func (r *MockFailedResult) LastInsertId() (int64, error) {
 }
 func (r *MockFailedResult) RowsAffected() (int64, error) { return 1, nil }
 
+func (r *MockEmptyResult) LastInsertId() (int64, error) { return 0, nil }
+func (r *MockEmptyResult) RowsAffected() (int64, error) { return 0, nil }
+
 // Test successful transaction saving
 func TestForSavingTransactionUsingDB_Success(t *testing.T) {
 	fakeDB := &FakeDB{}
 	adapter := NewForSavingTransactionUsingDB(fakeDB)
 
-	transaction := &transactions.Transaction{
-		ID:          "txn123",
-		AccountID:   "12345",
-		Amount:      100.0,
-		Type:        "credit",
-		Description: "Payment",
-	}
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	transaction := transactions.NewTransaction("", "12345", money.MustParse("100.00", "EUR"), "credit", date, "Payment")
 
-	err := adapter.SaveTransaction(transaction)
+	err := adapter.SaveTransaction(context.Background(), "1", transaction)
 	assert.Nil(t, err, "Expected no error when saving transaction")
+	assert.Equal(t, "INSERT INTO transactions (tenant_id, account_id, amount, currency, transaction_type, transaction_date, posted_date, status, description, category_id, payee, external_id, transfer_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", fakeDB.ExecQueries[0], "Columns should match the schema")
+	assert.Equal(t, []interface{}{"1", "12345", "100.00", "EUR", "credit", date, sql.NullTime{}, "pending", "Payment", sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}}, fakeDB.ExecArgs[0], "Amount should be written as an exact decimal string")
+	assert.Equal(t, "INSERT INTO balance_snapshots (tenant_id, account_id, period_start, net_change) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE net_change = net_change + VALUES(net_change)", fakeDB.ExecQueries[1])
+	assert.Equal(t, []interface{}{"1", "12345", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "100.00"}, fakeDB.ExecArgs[1], "The amount should be added to its month's snapshot")
+}
+
+// Test saving a posted transaction writes its posted date
+func TestForSavingTransactionUsingDB_Posted(t *testing.T) {
+	fakeDB := &FakeDB{}
+	adapter := NewForSavingTransactionUsingDB(fakeDB)
+
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	transaction := transactions.NewTransaction("", "12345", money.MustParse("100.00", "EUR"), "credit", date, "Payment")
+	_ = transaction.Post(date.AddDate(0, 0, 2))
+
+	err := adapter.SaveTransaction(context.Background(), "1", transaction)
+	assert.Nil(t, err)
+	assert.Equal(t, sql.NullTime{Time: date.AddDate(0, 0, 2), Valid: true}, fakeDB.ExecArgs[0][6])
+	assert.Equal(t, "posted", fakeDB.ExecArgs[0][7])
+}
+
+// Test saving an already imported external ID reports a duplicate and leaves the balance alone
+func TestForSavingTransactionUsingDB_Duplicate(t *testing.T) {
+	fakeDB := &FakeDB{ExecErr: &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}}
+	adapter := NewForSavingTransactionUsingDB(fakeDB)
+
+	transaction := transactions.NewTransaction("", "12345", money.MustParse("-4.50", "EUR"), "debit", time.Now(), "Coffee")
+	transaction.ExternalID = "20240115-001"
+
+	err := adapter.SaveTransaction(context.Background(), "1", transaction)
+	assert.Equal(t, transactions.ErrDuplicateTransaction, err)
+	assert.Equal(t, sql.NullString{String: "20240115-001", Valid: true}, fakeDB.ExecArgs[0][11])
+	assert.Len(t, fakeDB.ExecQueries, 1, "No snapshot should be written")
 }
 
 // Test transaction saving failure
@@ -66,12 +190,12 @@ func TestForSavingTransactionUsingDB_Failure(t *testing.T) {
 	transaction := &transactions.Transaction{
 		ID:          "txn123",
 		AccountID:   "12345",
-		Amount:      100.0,
+		Amount:      money.MustParse("100.00", "EUR"),
 		Type:        "credit",
 		Description: "Payment",
 	}
 
-	err := adapter.SaveTransaction(transaction)
+	err := adapter.SaveTransaction(context.Background(), "1", transaction)
 	assert.NotNil(t, err, "Expected an error when saving transaction")
 	assert.Equal(t, "failed to save transaction: failed to execute query", err.Error(), "Expected error message to match")
 }
@@ -83,12 +207,12 @@ func TestForSavingTransactionUsingDB_InsertIdFailure(t *testing.T) {
 	transaction := &transactions.Transaction{
 		ID:          "txn123",
 		AccountID:   "12345",
-		Amount:      100.0,
+		Amount:      money.MustParse("100.00", "EUR"),
 		Type:        "credit",
 		Description: "Payment",
 	}
 
-	err := adapter.SaveTransaction(transaction)
+	err := adapter.SaveTransaction(context.Background(), "1", transaction)
 	assert.NotNil(t, err, "Expected an error when saving account")
 	assert.Equal(t, "failed to retrieve last insert ID: failed to execute query", err.Error(), "Expected error message to match")
 }
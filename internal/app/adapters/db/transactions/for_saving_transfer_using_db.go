@@ -0,0 +1,35 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+)
+
+// ForSavingTransferUsingDB is the adapter for saving transfers using DB
+type ForSavingTransferUsingDB struct {
+	db db.Executor
+}
+
+// NewForSavingTransferUsingDB creates a new DB adapter for saving transfers
+func NewForSavingTransferUsingDB(executor db.Executor) *ForSavingTransferUsingDB {
+	return &ForSavingTransferUsingDB{db: executor}
+}
+
+// SaveTransfer saves the given transfer of the tenant to DB. Its legs are saved as transactions
+// referring to it.
+func (a *ForSavingTransferUsingDB) SaveTransfer(ctx context.Context, tenantID string, transfer *transactions.Transfer) error {
+	query := "INSERT INTO transfers (tenant_id, from_account_id, to_account_id) VALUES (?, ?, ?)"
+	result, err := a.db.ExecContext(ctx, query, tenantID, transfer.FromAccountID, transfer.ToAccountID)
+	if err != nil {
+		return fmt.Errorf("failed to save transfer: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve last insert ID: %w", err)
+	}
+	transfer.ID = fmt.Sprintf("%d", id)
+	return nil
+}
@@ -0,0 +1,36 @@
+package transactions
+
+import (
+	"context"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test saving a transfer
+func TestForSavingTransferUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{}
+	transfer := &transactions.Transfer{FromAccountID: "12345", ToAccountID: "67890"}
+
+	err := NewForSavingTransferUsingDB(fakeDB).SaveTransfer(context.Background(), "1", transfer)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "0", transfer.ID)
+	assert.Equal(t, "INSERT INTO transfers (tenant_id, from_account_id, to_account_id) VALUES (?, ?, ?)", fakeDB.ExecQueries[0])
+	assert.Equal(t, []interface{}{"1", "12345", "67890"}, fakeDB.ExecArgs[0])
+}
+
+// Test transfer saving failure
+func TestForSavingTransferUsingDB_Failure(t *testing.T) {
+	err := NewForSavingTransferUsingDB(&FakeDB{ReturnError: true}).SaveTransfer(context.Background(), "1", &transactions.Transfer{})
+
+	assert.Equal(t, "failed to save transfer: failed to execute query", err.Error())
+}
+
+// Test failure to retrieve the new transfer's ID
+func TestForSavingTransferUsingDB_InsertIDFailure(t *testing.T) {
+	err := NewForSavingTransferUsingDB(&FakeDB{ReturnInsertError: true}).SaveTransfer(context.Background(), "1", &transactions.Transfer{})
+
+	assert.Equal(t, "failed to retrieve last insert ID: failed to execute query", err.Error())
+}
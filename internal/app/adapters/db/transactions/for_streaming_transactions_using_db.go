@@ -0,0 +1,39 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/transactions"
+	"spend-api/internal/infra/db"
+	"strings"
+)
+
+// ForStreamingTransactionsUsingDB is the adapter for streaming transactions using DB
+type ForStreamingTransactionsUsingDB struct {
+	db db.Executor
+}
+
+// NewForStreamingTransactionsUsingDB creates a new DB adapter for streaming transactions
+func NewForStreamingTransactionsUsingDB(executor db.Executor) *ForStreamingTransactionsUsingDB {
+	return &ForStreamingTransactionsUsingDB{db: executor}
+}
+
+// StreamTransactions reads the tenant's transactions matching the filter from
+// DB in a single query, handing each row to each as it arrives rather than
+// loading the result first
+func (a *ForStreamingTransactionsUsingDB) StreamTransactions(ctx context.Context, tenantID string, filter transactions.TransactionFilter, each func(*transactions.Transaction) error) error {
+	conditions, args := filterConditions(tenantID, filter)
+	direction := "DESC"
+	if filter.SortOrder == transactions.SortAscending {
+		direction = "ASC"
+	}
+
+	query := "SELECT " + transactionColumns + " FROM transactions WHERE " + strings.Join(conditions, " AND ")
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s", sortColumns[filter.SortBy], direction, direction)
+
+	err := db.QueryEach(ctx, a.db, scanTransaction, each, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to stream transactions: %w", err)
+	}
+	return nil
+}
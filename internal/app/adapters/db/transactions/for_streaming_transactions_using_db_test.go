@@ -0,0 +1,73 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func streamedRow(id string) []interface{} {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	return []interface{}{id, "12345", "-4.5000", "EUR", "debit", date, sql.NullTime{Time: date, Valid: true}, "posted", "Coffee", sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, 1}
+}
+
+// Test streaming hands over every matching transaction in order without a limit
+func TestForStreamingTransactionsUsingDB_Success(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{streamedRow("1"), streamedRow("2")}}
+	adapter := NewForStreamingTransactionsUsingDB(fakeDB)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := transactions.TransactionFilter{AccountID: "12345", From: &from, SortBy: transactions.SortByDate, SortOrder: transactions.SortAscending}
+	var streamed []string
+	err := adapter.StreamTransactions(context.Background(), "1", filter, func(transaction *transactions.Transaction) error {
+		streamed = append(streamed, transaction.ID)
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, streamed)
+	assert.Equal(t, "SELECT id, account_id, amount, currency, transaction_type, transaction_date, posted_date, status, description, category_id, payee, external_id, transfer_id, version"+
+		" FROM transactions WHERE tenant_id = ? AND account_id = ? AND transaction_date >= ? ORDER BY transaction_date ASC, id ASC", fakeDB.Queries[0])
+	assert.Equal(t, []interface{}{"1", "12345", from}, fakeDB.Args[0])
+}
+
+// Test streaming without any restriction in descending amount order
+func TestForStreamingTransactionsUsingDB_NoFilter(t *testing.T) {
+	fakeDB := &FakeDB{}
+	filter := transactions.TransactionFilter{SortBy: transactions.SortByAmount, SortOrder: transactions.SortDescending}
+
+	err := NewForStreamingTransactionsUsingDB(fakeDB).StreamTransactions(context.Background(), "1", filter, func(*transactions.Transaction) error { return nil })
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT id, account_id, amount, currency, transaction_type, transaction_date, posted_date, status, description, category_id, payee, external_id, transfer_id, version"+
+		" FROM transactions WHERE tenant_id = ? ORDER BY amount DESC, id DESC", fakeDB.Queries[0])
+}
+
+// Test an error from the consumer stops the stream
+func TestForStreamingTransactionsUsingDB_ConsumerError(t *testing.T) {
+	fakeDB := &FakeDB{Rows: [][]interface{}{streamedRow("1"), streamedRow("2")}}
+	stop := errors.New("client went away")
+	calls := 0
+
+	filter := transactions.TransactionFilter{SortBy: transactions.SortByDate}
+	err := NewForStreamingTransactionsUsingDB(fakeDB).StreamTransactions(context.Background(), "1", filter, func(*transactions.Transaction) error {
+		calls++
+		return stop
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+// Test streaming failure
+func TestForStreamingTransactionsUsingDB_Failure(t *testing.T) {
+	filter := transactions.TransactionFilter{SortBy: transactions.SortByDate}
+	err := NewForStreamingTransactionsUsingDB(&FakeDB{ReturnQueryError: true}).StreamTransactions(context.Background(), "1", filter, func(*transactions.Transaction) error { return nil })
+
+	assert.Equal(t, "failed to stream transactions: failed to execute query", err.Error())
+}
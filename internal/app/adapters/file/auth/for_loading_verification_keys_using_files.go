@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"spend-api/internal/domain/auth"
+	"sync"
+	"time"
+)
+
+// ForLoadingVerificationKeysUsingFiles is the adapter for loading the keys bearer tokens are
+// verified against from local configuration: an HS256 secret, a PEM file of RSA or Ed25519
+// public keys, and a JWKS file. Any of them may be left empty.
+type ForLoadingVerificationKeysUsingFiles struct {
+	secret        string
+	publicKeyPath string
+	jwksPath      string
+
+	mu       sync.Mutex
+	keys     []auth.VerificationKey
+	modTimes map[string]time.Time
+}
+
+// NewForLoadingVerificationKeysUsingFiles creates a new adapter for loading verification
+// keys from the given secret and files
+func NewForLoadingVerificationKeysUsingFiles(secret, publicKeyPath, jwksPath string) *ForLoadingVerificationKeysUsingFiles {
+	return &ForLoadingVerificationKeysUsingFiles{
+		secret:        secret,
+		publicKeyPath: publicKeyPath,
+		jwksPath:      jwksPath,
+	}
+}
+
+// LoadVerificationKeys returns the configured keys. The files are read again whenever one of
+// them has changed since it was last read, so keys can be rotated without a restart.
+func (a *ForLoadingVerificationKeysUsingFiles) LoadVerificationKeys(ctx context.Context) ([]auth.VerificationKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	modTimes := map[string]time.Time{}
+	for _, path := range []string{a.publicKeyPath, a.jwksPath} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read verification keys: %w", err)
+		}
+		modTimes[path] = info.ModTime()
+	}
+	if a.modTimes != nil && sameModTimes(a.modTimes, modTimes) {
+		return a.keys, nil
+	}
+
+	keys, err := a.load()
+	if err != nil {
+		return nil, err
+	}
+	a.keys, a.modTimes = keys, modTimes
+	return keys, nil
+}
+
+// load reads every configured key
+func (a *ForLoadingVerificationKeysUsingFiles) load() ([]auth.VerificationKey, error) {
+	var keys []auth.VerificationKey
+	if a.secret != "" {
+		key, err := auth.NewVerificationKey("", auth.AlgorithmHS256, []byte(a.secret))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if a.publicKeyPath != "" {
+		data, err := os.ReadFile(a.publicKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read verification keys: %w", err)
+		}
+		pemKeys, err := parsePEMKeys(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", a.publicKeyPath, err)
+		}
+		keys = append(keys, pemKeys...)
+	}
+	if a.jwksPath != "" {
+		data, err := os.ReadFile(a.jwksPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read verification keys: %w", err)
+		}
+		jwksKeys, err := parseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", a.jwksPath, err)
+		}
+		keys = append(keys, jwksKeys...)
+	}
+	return keys, nil
+}
+
+// parsePEMKeys parses every PUBLIC KEY or RSA PUBLIC KEY block in data
+func parsePEMKeys(data []byte) ([]auth.VerificationKey, error) {
+	var keys []auth.VerificationKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		var publicKey any
+		var err error
+		switch block.Type {
+		case "PUBLIC KEY":
+			publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		algorithm := auth.AlgorithmRS256
+		if _, ok := publicKey.(ed25519.PublicKey); ok {
+			algorithm = auth.AlgorithmEdDSA
+		}
+		key, err := auth.NewVerificationKey("", algorithm, publicKey)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no public keys found", auth.ErrInvalidVerificationKey)
+	}
+	return keys, nil
+}
+
+// jwk is a JSON Web Key as found in a JWKS document
+type jwk struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Curve     string `json:"crv"`
+	K         string `json:"k"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	X         string `json:"x"`
+}
+
+// parseJWKS parses the signing keys of a JWKS document. Keys of a type or
+// algorithm that is not supported, or meant for encryption, are skipped.
+func parseJWKS(data []byte) ([]auth.VerificationKey, error) {
+	var document struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	var keys []auth.VerificationKey
+	for _, key := range document.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		var algorithm auth.Algorithm
+		var publicKey any
+		var err error
+		switch {
+		case key.KeyType == "oct":
+			algorithm = auth.AlgorithmHS256
+			publicKey, err = base64.RawURLEncoding.DecodeString(key.K)
+		case key.KeyType == "RSA":
+			algorithm = auth.AlgorithmRS256
+			publicKey, err = rsaPublicKey(key.N, key.E)
+		case key.KeyType == "OKP" && key.Curve == "Ed25519":
+			algorithm = auth.AlgorithmEdDSA
+			var x []byte
+			x, err = base64.RawURLEncoding.DecodeString(key.X)
+			publicKey = ed25519.PublicKey(x)
+		default:
+			continue
+		}
+		if key.Algorithm != "" && auth.Algorithm(key.Algorithm) != algorithm {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.KeyID, err)
+		}
+
+		verificationKey, err := auth.NewVerificationKey(key.KeyID, algorithm, publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.KeyID, err)
+		}
+		keys = append(keys, verificationKey)
+	}
+	return keys, nil
+}
+
+// rsaPublicKey builds an RSA public key from its base64url encoded modulus and exponent
+func rsaPublicKey(n, e string) (*rsa.PublicKey, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, err
+	}
+	exponent, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, err
+	}
+	if len(exponent) == 0 || len(exponent) > 4 {
+		return nil, fmt.Errorf("%w: unsupported RSA exponent", auth.ErrInvalidVerificationKey)
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: int(new(big.Int).SetBytes(exponent).Int64())}, nil
+}
+
+// sameModTimes reports whether the files were last modified at the same times
+func sameModTimes(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, modTime := range a {
+		if !b[path].Equal(modTime) {
+			return false
+		}
+	}
+	return true
+}
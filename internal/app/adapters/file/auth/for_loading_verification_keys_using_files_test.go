@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"spend-api/internal/domain/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const hmacSecret = "0123456789abcdef0123456789abcdef"
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func publicKeyPEM(t *testing.T, key any) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	assert.Nil(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// Test only the HS256 secret is loaded when no files are configured
+func TestForLoadingVerificationKeysUsingFiles_Secret(t *testing.T) {
+	keys, err := NewForLoadingVerificationKeysUsingFiles(hmacSecret, "", "").LoadVerificationKeys(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []auth.VerificationKey{{Algorithm: auth.AlgorithmHS256, Key: []byte(hmacSecret)}}, keys)
+}
+
+// Test a secret too short to be safe is refused
+func TestForLoadingVerificationKeysUsingFiles_ShortSecret(t *testing.T) {
+	_, err := NewForLoadingVerificationKeysUsingFiles("secret", "", "").LoadVerificationKeys(context.Background())
+
+	assert.True(t, errors.Is(err, auth.ErrInvalidVerificationKey), "Expected ErrInvalidVerificationKey")
+}
+
+// Test RSA and Ed25519 public keys are loaded from a PEM file
+func TestForLoadingVerificationKeysUsingFiles_PEM(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	edPublic, _, _ := ed25519.GenerateKey(rand.Reader)
+	rsaPKCS1 := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)}))
+	path := writeFile(t, "keys.pem", publicKeyPEM(t, &rsaKey.PublicKey)+publicKeyPEM(t, edPublic)+rsaPKCS1)
+
+	keys, err := NewForLoadingVerificationKeysUsingFiles("", path, "").LoadVerificationKeys(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []auth.VerificationKey{
+		{Algorithm: auth.AlgorithmRS256, Key: &rsaKey.PublicKey},
+		{Algorithm: auth.AlgorithmEdDSA, Key: edPublic},
+		{Algorithm: auth.AlgorithmRS256, Key: &rsaKey.PublicKey},
+	}, keys)
+}
+
+// Test a PEM file without public keys is refused
+func TestForLoadingVerificationKeysUsingFiles_EmptyPEM(t *testing.T) {
+	path := writeFile(t, "keys.pem", "not a key")
+
+	_, err := NewForLoadingVerificationKeysUsingFiles("", path, "").LoadVerificationKeys(context.Background())
+
+	assert.True(t, errors.Is(err, auth.ErrInvalidVerificationKey), "Expected ErrInvalidVerificationKey")
+}
+
+// Test the signing keys of a JWKS file are loaded, and the others skipped
+func TestForLoadingVerificationKeysUsingFiles_JWKS(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	edPublic, _, _ := ed25519.GenerateKey(rand.Reader)
+	encode := base64.RawURLEncoding.EncodeToString
+	jwks := fmt.Sprintf(`{"keys": [
+		{"kty": "oct", "kid": "hs", "k": %q},
+		{"kty": "RSA", "kid": "rs", "alg": "RS256", "use": "sig", "n": %q, "e": %q},
+		{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": %q},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": %q, "e": "AQAB"},
+		{"kty": "RSA", "kid": "rs512", "alg": "RS512", "n": %q, "e": "AQAB"},
+		{"kty": "EC", "kid": "es", "crv": "P-256", "x": "AA", "y": "AA"}
+	]}`, encode([]byte(hmacSecret)), encode(rsaKey.N.Bytes()), encode(big.NewInt(int64(rsaKey.E)).Bytes()), encode(edPublic), encode(rsaKey.N.Bytes()), encode(rsaKey.N.Bytes()))
+	path := writeFile(t, "jwks.json", jwks)
+
+	keys, err := NewForLoadingVerificationKeysUsingFiles("", "", path).LoadVerificationKeys(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []auth.VerificationKey{
+		{ID: "hs", Algorithm: auth.AlgorithmHS256, Key: []byte(hmacSecret)},
+		{ID: "rs", Algorithm: auth.AlgorithmRS256, Key: &rsaKey.PublicKey},
+		{ID: "ed", Algorithm: auth.AlgorithmEdDSA, Key: edPublic},
+	}, keys)
+}
+
+// Test a malformed JWKS file is refused
+func TestForLoadingVerificationKeysUsingFiles_InvalidJWKS(t *testing.T) {
+	for name, jwks := range map[string]string{
+		"not JSON":         "{",
+		"bad modulus":      `{"keys": [{"kty": "RSA", "kid": "rs", "n": "!", "e": "AQAB"}]}`,
+		"short Ed25519":    `{"keys": [{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": "AAAA"}]}`,
+		"huge exponent":    `{"keys": [{"kty": "RSA", "kid": "rs", "n": "AQAB", "e": "AQABAQAB"}]}`,
+		"short oct secret": `{"keys": [{"kty": "oct", "kid": "hs", "k": "c2VjcmV0"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := writeFile(t, "jwks.json", jwks)
+
+			_, err := NewForLoadingVerificationKeysUsingFiles("", "", path).LoadVerificationKeys(context.Background())
+
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+// Test a missing file is reported
+func TestForLoadingVerificationKeysUsingFiles_MissingFile(t *testing.T) {
+	_, err := NewForLoadingVerificationKeysUsingFiles("", "", filepath.Join(t.TempDir(), "jwks.json")).LoadVerificationKeys(context.Background())
+
+	assert.True(t, errors.Is(err, os.ErrNotExist), "Expected os.ErrNotExist")
+}
+
+// Test the files are read again once they change
+func TestForLoadingVerificationKeysUsingFiles_Reload(t *testing.T) {
+	encode := base64.RawURLEncoding.EncodeToString
+	path := writeFile(t, "jwks.json", fmt.Sprintf(`{"keys": [{"kty": "oct", "kid": "old", "k": %q}]}`, encode([]byte(hmacSecret))))
+	adapter := NewForLoadingVerificationKeysUsingFiles("", "", path)
+
+	keys, err := adapter.LoadVerificationKeys(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "old", keys[0].ID)
+
+	assert.Nil(t, os.WriteFile(path, []byte(fmt.Sprintf(`{"keys": [{"kty": "oct", "kid": "new", "k": %q}]}`, encode([]byte(hmacSecret)))), 0o600))
+	later := time.Now().Add(time.Minute)
+	assert.Nil(t, os.Chtimes(path, later, later))
+
+	keys, err = adapter.LoadVerificationKeys(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "new", keys[0].ID)
+}
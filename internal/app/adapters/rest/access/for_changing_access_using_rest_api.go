@@ -0,0 +1,73 @@
+package access
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/accounts"
+)
+
+// ForChangingAccessUsingRestAPI is the REST API adapter for changing a user's role on an account.
+type ForChangingAccessUsingRestAPI struct {
+	accessService access.ForChangingAccess
+}
+
+// NewForChangingAccessUsingRestAPI creates a new REST handler for changing access to accounts.
+func NewForChangingAccessUsingRestAPI(service access.ForChangingAccess) *ForChangingAccessUsingRestAPI {
+	return &ForChangingAccessUsingRestAPI{
+		accessService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for changing the role of the user identified
+// by the {userID} path value on the account identified by {id}. Only its
+// owners may, and demoting the account's last owner is rejected with 409
+// Conflict.
+func (h *ForChangingAccessUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		Role string `json:"role"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	grant, err := h.accessService.ChangeAccess(r.Context(), r.PathValue("id"), r.PathValue("userID"), access.Role(requestBody.Role))
+	if WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, accounts.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, access.ErrGrantNotFound) {
+		http.Error(w, "User has no access to the account", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, access.ErrInvalidGrant) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, access.ErrLastOwner) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to change access", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(grant); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
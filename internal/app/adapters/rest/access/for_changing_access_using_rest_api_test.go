@@ -0,0 +1,69 @@
+package access
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/accounts"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test successfully changing a user's role on an account
+func TestForChangingAccessUsingRestAPI_Success(t *testing.T) {
+	fakeService := &FakeAccessService{}
+	apiHandler := NewForChangingAccessUsingRestAPI(fakeService)
+
+	req := httptest.NewRequest(http.MethodPut, "/accounts/3/access/6", bytes.NewBufferString(`{"role":"viewer"}`))
+	req.SetPathValue("id", "3")
+	req.SetPathValue("userID", "6")
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "3", fakeService.AccountID)
+	assert.Equal(t, "6", fakeService.UserID)
+	assert.Equal(t, access.RoleViewer, fakeService.Role)
+}
+
+// Test changing errors
+func TestForChangingAccessUsingRestAPI_Errors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code int
+	}{
+		"forbidden":       {&access.ForbiddenError{AccountID: "3", Permission: access.PermissionAdminister}, http.StatusForbidden},
+		"unknown account": {accounts.ErrAccountNotFound, http.StatusNotFound},
+		"no access":       {access.ErrGrantNotFound, http.StatusNotFound},
+		"unknown role":    {access.ErrInvalidGrant, http.StatusBadRequest},
+		"last owner":      {access.ErrLastOwner, http.StatusConflict},
+		"failure":         {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := NewForChangingAccessUsingRestAPI(&FakeAccessService{ReturnErr: tt.err})
+
+			req := httptest.NewRequest(http.MethodPut, "/accounts/3/access/6", bytes.NewBufferString(`{"role":"viewer"}`))
+			respRecorder := httptest.NewRecorder()
+			apiHandler.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.code, respRecorder.Code)
+		})
+	}
+}
+
+// Test changing with an invalid body or method
+func TestForChangingAccessUsingRestAPI_InvalidRequest(t *testing.T) {
+	apiHandler := NewForChangingAccessUsingRestAPI(&FakeAccessService{})
+
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodPut, "/accounts/3/access/6", bytes.NewBufferString(`{`)))
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+
+	respRecorder = httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodPost, "/accounts/3/access/6", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
@@ -0,0 +1,74 @@
+package access
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/accounts"
+)
+
+// ForGrantingAccessUsingRestAPI is the REST API adapter for inviting a user to an account.
+type ForGrantingAccessUsingRestAPI struct {
+	accessService access.ForGrantingAccess
+}
+
+// NewForGrantingAccessUsingRestAPI creates a new REST handler for granting access to accounts.
+func NewForGrantingAccessUsingRestAPI(service access.ForGrantingAccess) *ForGrantingAccessUsingRestAPI {
+	return &ForGrantingAccessUsingRestAPI{
+		accessService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for giving a user of the caller's tenant a
+// role on the account identified by the {id} path value. Only its owners may,
+// and a user who already has a role on the account is rejected with 409
+// Conflict; changing it is a PUT on the grant instead.
+func (h *ForGrantingAccessUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		UserID string `json:"userID"`
+		Role   string `json:"role"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	grant, err := h.accessService.GrantAccess(r.Context(), r.PathValue("id"), requestBody.UserID, access.Role(requestBody.Role))
+	if WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, accounts.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, access.ErrUserNotFound) {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, access.ErrInvalidGrant) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, access.ErrDuplicateGrant) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to grant access", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(grant); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
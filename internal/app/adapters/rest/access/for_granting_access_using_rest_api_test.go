@@ -0,0 +1,107 @@
+package access
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/accounts"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// grantCreatedAt is when the grants in these tests were made
+var grantCreatedAt = time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+
+// FakeAccessService simulates the access service for testing, recording what it was asked.
+type FakeAccessService struct {
+	ReturnErr error
+	AccountID string
+	UserID    string
+	Role      access.Role
+}
+
+func (f *FakeAccessService) GrantAccess(ctx context.Context, accountID, userID string, role access.Role) (*access.Grant, error) {
+	f.AccountID, f.UserID, f.Role = accountID, userID, role
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return &access.Grant{AccountID: accountID, UserID: userID, Role: role, GrantedBy: "alice", CreatedAt: grantCreatedAt}, nil
+}
+
+func (f *FakeAccessService) ListAccess(ctx context.Context, accountID string) ([]*access.Grant, error) {
+	f.AccountID = accountID
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return []*access.Grant{{AccountID: accountID, UserID: "5", Role: access.RoleOwner, CreatedAt: grantCreatedAt}}, nil
+}
+
+func (f *FakeAccessService) ChangeAccess(ctx context.Context, accountID, userID string, role access.Role) (*access.Grant, error) {
+	return f.GrantAccess(ctx, accountID, userID, role)
+}
+
+func (f *FakeAccessService) RevokeAccess(ctx context.Context, accountID, userID string) error {
+	f.AccountID, f.UserID = accountID, userID
+	return f.ReturnErr
+}
+
+// Test successfully inviting a user to an account
+func TestForGrantingAccessUsingRestAPI_Success(t *testing.T) {
+	fakeService := &FakeAccessService{}
+	apiHandler := NewForGrantingAccessUsingRestAPI(fakeService)
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts/3/access", bytes.NewBufferString(`{"userID":"6","role":"editor"}`))
+	req.SetPathValue("id", "3")
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code)
+	assert.Equal(t, "3", fakeService.AccountID)
+	assert.Equal(t, "6", fakeService.UserID)
+	assert.Equal(t, access.RoleEditor, fakeService.Role)
+	assert.JSONEq(t, `{"AccountID":"3","UserID":"6","Role":"editor","GrantedBy":"alice","CreatedAt":"2024-01-15T09:30:00Z"}`, respRecorder.Body.String())
+}
+
+// Test granting errors
+func TestForGrantingAccessUsingRestAPI_Errors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code int
+	}{
+		"forbidden":       {&access.ForbiddenError{AccountID: "3", Permission: access.PermissionAdminister, Role: access.RoleEditor}, http.StatusForbidden},
+		"unknown account": {accounts.ErrAccountNotFound, http.StatusNotFound},
+		"unknown user":    {access.ErrUserNotFound, http.StatusNotFound},
+		"unknown role":    {access.ErrInvalidGrant, http.StatusBadRequest},
+		"already granted": {access.ErrDuplicateGrant, http.StatusConflict},
+		"failure":         {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := NewForGrantingAccessUsingRestAPI(&FakeAccessService{ReturnErr: tt.err})
+
+			req := httptest.NewRequest(http.MethodPost, "/accounts/3/access", bytes.NewBufferString(`{"userID":"6","role":"editor"}`))
+			respRecorder := httptest.NewRecorder()
+			apiHandler.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.code, respRecorder.Code)
+		})
+	}
+}
+
+// Test granting with an invalid body or method
+func TestForGrantingAccessUsingRestAPI_InvalidRequest(t *testing.T) {
+	apiHandler := NewForGrantingAccessUsingRestAPI(&FakeAccessService{})
+
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodPost, "/accounts/3/access", bytes.NewBufferString(`{`)))
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+
+	respRecorder = httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/accounts/3/access", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
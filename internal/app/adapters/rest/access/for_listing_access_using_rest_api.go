@@ -0,0 +1,51 @@
+package access
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/accounts"
+)
+
+// ForListingAccessUsingRestAPI is the REST API adapter for listing who has access to an account.
+type ForListingAccessUsingRestAPI struct {
+	accessService access.ForListingAccess
+}
+
+// NewForListingAccessUsingRestAPI creates a new REST handler for listing access to accounts.
+func NewForListingAccessUsingRestAPI(service access.ForListingAccess) *ForListingAccessUsingRestAPI {
+	return &ForListingAccessUsingRestAPI{
+		accessService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for listing the grants on the account
+// identified by the {id} path value. Anyone who may view the account may see
+// them.
+func (h *ForListingAccessUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	grants, err := h.accessService.ListAccess(r.Context(), r.PathValue("id"))
+	if WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, accounts.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to list access", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(grants); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,57 @@
+package access
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/accounts"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test successfully listing who has access to an account
+func TestForListingAccessUsingRestAPI_Success(t *testing.T) {
+	fakeService := &FakeAccessService{}
+	apiHandler := NewForListingAccessUsingRestAPI(fakeService)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/3/access", nil)
+	req.SetPathValue("id", "3")
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "3", fakeService.AccountID)
+	assert.JSONEq(t, `[{"AccountID":"3","UserID":"5","Role":"owner","GrantedBy":"","CreatedAt":"2024-01-15T09:30:00Z"}]`, respRecorder.Body.String())
+}
+
+// Test listing errors
+func TestForListingAccessUsingRestAPI_Errors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code int
+	}{
+		"forbidden":       {&access.ForbiddenError{AccountID: "3", Permission: access.PermissionView}, http.StatusForbidden},
+		"unknown account": {accounts.ErrAccountNotFound, http.StatusNotFound},
+		"failure":         {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := NewForListingAccessUsingRestAPI(&FakeAccessService{ReturnErr: tt.err})
+
+			respRecorder := httptest.NewRecorder()
+			apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/accounts/3/access", nil))
+
+			assert.Equal(t, tt.code, respRecorder.Code)
+		})
+	}
+}
+
+// Test listing with an invalid method
+func TestForListingAccessUsingRestAPI_InvalidMethod(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+	NewForListingAccessUsingRestAPI(&FakeAccessService{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodPost, "/accounts/3/access", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
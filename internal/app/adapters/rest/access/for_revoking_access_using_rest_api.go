@@ -0,0 +1,54 @@
+package access
+
+import (
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/accounts"
+)
+
+// ForRevokingAccessUsingRestAPI is the REST API adapter for taking a user's access to an account away.
+type ForRevokingAccessUsingRestAPI struct {
+	accessService access.ForRevokingAccess
+}
+
+// NewForRevokingAccessUsingRestAPI creates a new REST handler for revoking access to accounts.
+func NewForRevokingAccessUsingRestAPI(service access.ForRevokingAccess) *ForRevokingAccessUsingRestAPI {
+	return &ForRevokingAccessUsingRestAPI{
+		accessService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for revoking the access of the user
+// identified by the {userID} path value to the account identified by {id}.
+// Owners may revoke anyone's access and everyone may give up their own, but
+// the account's last owner cannot, which is rejected with 409 Conflict.
+func (h *ForRevokingAccessUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := h.accessService.RevokeAccess(r.Context(), r.PathValue("id"), r.PathValue("userID"))
+	if WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, accounts.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, access.ErrGrantNotFound) {
+		http.Error(w, "User has no access to the account", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, access.ErrLastOwner) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to revoke access", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,60 @@
+package access
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/accounts"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test successfully revoking a user's access to an account
+func TestForRevokingAccessUsingRestAPI_Success(t *testing.T) {
+	fakeService := &FakeAccessService{}
+	apiHandler := NewForRevokingAccessUsingRestAPI(fakeService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/accounts/3/access/6", nil)
+	req.SetPathValue("id", "3")
+	req.SetPathValue("userID", "6")
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusNoContent, respRecorder.Code)
+	assert.Equal(t, "3", fakeService.AccountID)
+	assert.Equal(t, "6", fakeService.UserID)
+}
+
+// Test revoking errors
+func TestForRevokingAccessUsingRestAPI_Errors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code int
+	}{
+		"forbidden":       {&access.ForbiddenError{AccountID: "3", Permission: access.PermissionAdminister}, http.StatusForbidden},
+		"unknown account": {accounts.ErrAccountNotFound, http.StatusNotFound},
+		"no access":       {access.ErrGrantNotFound, http.StatusNotFound},
+		"last owner":      {access.ErrLastOwner, http.StatusConflict},
+		"failure":         {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := NewForRevokingAccessUsingRestAPI(&FakeAccessService{ReturnErr: tt.err})
+
+			respRecorder := httptest.NewRecorder()
+			apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodDelete, "/accounts/3/access/6", nil))
+
+			assert.Equal(t, tt.code, respRecorder.Code)
+		})
+	}
+}
+
+// Test revoking with an invalid method
+func TestForRevokingAccessUsingRestAPI_InvalidMethod(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+	NewForRevokingAccessUsingRestAPI(&FakeAccessService{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/accounts/3/access/6", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
@@ -0,0 +1,28 @@
+package access
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/access"
+)
+
+// WriteForbidden responds with 403 Forbidden if err is an *access.ForbiddenError,
+// explaining which permission on which account the caller lacks, and
+// reports whether it did. The other REST adapters use it for every call
+// that goes through an access policy.
+func WriteForbidden(w http.ResponseWriter, err error) bool {
+	var forbidden *access.ForbiddenError
+	if !errors.As(err, &forbidden) {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"Error":      forbidden.Error(),
+		"AccountID":  forbidden.AccountID,
+		"Permission": forbidden.Permission,
+		"Role":       forbidden.Role,
+	})
+	return true
+}
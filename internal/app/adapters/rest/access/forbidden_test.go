@@ -0,0 +1,32 @@
+package access
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test a forbidden error is explained in a 403 response, even when wrapped
+func TestWriteForbidden(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+	err := fmt.Errorf("failed: %w", &access.ForbiddenError{AccountID: "3", Permission: access.PermissionEdit, Role: access.RoleViewer})
+
+	assert.True(t, WriteForbidden(respRecorder, err))
+	assert.Equal(t, http.StatusForbidden, respRecorder.Code)
+	assert.Equal(t, "application/json", respRecorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"Error":"forbidden: the edit permission on account 3 is required, which the viewer role does not grant","AccountID":"3","Permission":"edit","Role":"viewer"}`, respRecorder.Body.String())
+}
+
+// Test other errors are left for the caller to handle
+func TestWriteForbidden_OtherError(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	assert.False(t, WriteForbidden(respRecorder, nil))
+	assert.False(t, WriteForbidden(respRecorder, errors.New("boom")))
+	assert.Equal(t, 0, respRecorder.Body.Len(), "Nothing should be written")
+}
@@ -2,8 +2,11 @@ package accounts
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/money"
+	"strings"
 )
 
 type ForCreatingAccountUsingRestAPI struct {
@@ -25,7 +28,10 @@ func (h *ForCreatingAccountUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *htt
 	}
 
 	var requestBody struct {
-		Name string `json:"name"`
+		Name           string      `json:"name"`
+		Number         string      `json:"number"`
+		OpeningBalance json.Number `json:"openingBalance"`
+		Currency       string      `json:"currency"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
@@ -33,13 +39,30 @@ func (h *ForCreatingAccountUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *htt
 		return
 	}
 
-	account, err := h.accountService.CreateAccount(requestBody.Name)
+	currency, err := money.ParseCurrency(requestBody.Currency)
+	if err != nil {
+		http.Error(w, "Invalid currency: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	openingBalance, err := parseOpeningBalance(requestBody.OpeningBalance, currency)
+	if err != nil {
+		http.Error(w, "Invalid opening balance: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	account, err := h.accountService.CreateAccount(r.Context(), requestBody.Name, strings.TrimSpace(requestBody.Number), currency, openingBalance)
+	if errors.Is(err, accounts.ErrNameRequired) || errors.Is(err, money.ErrUnknownCurrency) || errors.Is(err, money.ErrCurrencyMismatch) {
+		http.Error(w, "Invalid account: "+err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, "Failed to create account", http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(account.Version))
 	w.WriteHeader(http.StatusCreated)
 	err = json.NewEncoder(w).Encode(map[string]string{"id": account.ID})
 	if err != nil {
@@ -47,3 +70,11 @@ func (h *ForCreatingAccountUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *htt
 		return
 	}
 }
+
+// parseOpeningBalance parses the optional opening balance in the account's currency
+func parseOpeningBalance(amount json.Number, currency money.Currency) (money.Money, error) {
+	if amount == "" {
+		return money.Zero(currency), nil
+	}
+	return money.Parse(amount.String(), currency)
+}
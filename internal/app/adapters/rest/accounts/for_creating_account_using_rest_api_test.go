@@ -2,12 +2,14 @@ package accounts
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/money"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,7 +17,10 @@ import (
 
 // FakeForCreatingAccount simulates the account service for testing.
 type FakeForCreatingAccount struct {
-	ReturnError bool
+	ReturnError    bool
+	Number         string
+	Currency       money.Currency
+	OpeningBalance money.Money
 }
 
 // Fake ResponseWriter that simulates an encoding failure
@@ -35,13 +40,20 @@ func (e *errorResponseWriter) Write(b []byte) (int, error) {
 	return 0, io.ErrClosedPipe
 }
 
-func (f *FakeForCreatingAccount) CreateAccount(name string) (*accounts.Account, error) {
+func (f *FakeForCreatingAccount) CreateAccount(ctx context.Context, name, number string, currency money.Currency, openingBalance money.Money) (*accounts.Account, error) {
+	f.Number = number
+	f.Currency = currency
+	f.OpeningBalance = openingBalance
+	if name == "" {
+		return nil, accounts.ErrNameRequired
+	}
 	if f.ReturnError {
 		return nil, errors.New("failed to create account")
 	}
 	return &accounts.Account{
-		ID:   "12345",
-		Name: name,
+		ID:       "12345",
+		Name:     name,
+		Currency: currency,
 	}, nil
 }
 
@@ -53,7 +65,8 @@ func TestForCreatingAccountUsingRestAPI(t *testing.T) {
 	apiHandler := NewForCreatingAccountUsingRestAPI(fakeAccountService)
 
 	requestBody := map[string]string{
-		"name": "John Doe",
+		"name":     "John Doe",
+		"currency": "EUR",
 	}
 	jsonBody, _ := json.Marshal(requestBody)
 
@@ -65,6 +78,7 @@ func TestForCreatingAccountUsingRestAPI(t *testing.T) {
 
 	assert.Equal(t, http.StatusCreated, respRecorder.Code, "Expected HTTP 201 Created")
 	assert.Contains(t, respRecorder.Body.String(), `"id":"12345"`, "Response should contain account ID")
+	assert.Equal(t, money.Currency("EUR"), fakeAccountService.Currency, "Account currency should reach the service")
 }
 
 // Test for invalid HTTP method
@@ -102,7 +116,8 @@ func TestForCreatingAccountUsingRestAPI_ServiceError(t *testing.T) {
 	apiHandler := NewForCreatingAccountUsingRestAPI(fakeAccountService)
 
 	requestBody := map[string]string{
-		"name": "John Doe",
+		"name":     "John Doe",
+		"currency": "EUR",
 	}
 	jsonBody, _ := json.Marshal(requestBody)
 
@@ -121,7 +136,8 @@ func TestForCreatingAccountUsingRestAPI_EncodingError(t *testing.T) {
 	apiHandler := NewForCreatingAccountUsingRestAPI(fakeAccountService)
 
 	requestBody := map[string]string{
-		"name": "John Doe",
+		"name":     "John Doe",
+		"currency": "EUR",
 	}
 	jsonBody, _ := json.Marshal(requestBody)
 
@@ -135,3 +151,71 @@ func TestForCreatingAccountUsingRestAPI_EncodingError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, respRecorder.statusCode,
 		"Expected Internal Server Error if JSON encoding fails")
 }
+
+// Test that the opening balance is passed through to the account service
+func TestForCreatingAccountUsingRestAPI_OpeningBalance(t *testing.T) {
+	fakeAccountService := &FakeForCreatingAccount{}
+	apiHandler := NewForCreatingAccountUsingRestAPI(fakeAccountService)
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewReader([]byte(`{"name":"Savings","number":" GB29NWBK60161331926819 ","openingBalance":"250.50","currency":"GBP"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code, "Expected HTTP 201 Created")
+	assert.Equal(t, money.MustParse("250.50", "GBP"), fakeAccountService.OpeningBalance, "Opening balance should reach the service")
+	assert.Equal(t, "GB29NWBK60161331926819", fakeAccountService.Number, "Account number should reach the service")
+}
+
+// Test that an opening balance without a valid currency is rejected
+func TestForCreatingAccountUsingRestAPI_InvalidOpeningBalance(t *testing.T) {
+	for _, body := range []string{
+		`{"name":"Savings","openingBalance":"250.50"}`,
+		`{"name":"Savings","openingBalance":"250.505","currency":"GBP"}`,
+		`{"name":"Savings","openingBalance":"abc","currency":"GBP"}`,
+	} {
+		apiHandler := NewForCreatingAccountUsingRestAPI(&FakeForCreatingAccount{})
+
+		req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, respRecorder.Code, body)
+	}
+}
+
+// Test that an account without a name is rejected
+func TestForCreatingAccountUsingRestAPI_BlankName(t *testing.T) {
+	apiHandler := NewForCreatingAccountUsingRestAPI(&FakeForCreatingAccount{})
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewReader([]byte(`{"name":"","currency":"EUR"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code, "Expected HTTP 400 Bad Request")
+}
+
+// Test that the account currency is required and must be a known ISO 4217 code
+func TestForCreatingAccountUsingRestAPI_InvalidCurrency(t *testing.T) {
+	for _, body := range []string{
+		`{"name":"Savings"}`,
+		`{"name":"Savings","currency":"XYZ"}`,
+	} {
+		fakeAccountService := &FakeForCreatingAccount{}
+		apiHandler := NewForCreatingAccountUsingRestAPI(fakeAccountService)
+
+		req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, respRecorder.Code, body)
+		assert.Empty(t, fakeAccountService.Currency, "The service should not be called")
+	}
+}
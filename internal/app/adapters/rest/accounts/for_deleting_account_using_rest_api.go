@@ -0,0 +1,63 @@
+package accounts
+
+import (
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/accounts"
+)
+
+// ForDeletingAccountUsingRestAPI is the REST API adapter for deleting accounts.
+type ForDeletingAccountUsingRestAPI struct {
+	accountService accounts.ForDeletingAccount
+}
+
+// NewForDeletingAccountUsingRestAPI creates a new REST handler for deleting accounts.
+func NewForDeletingAccountUsingRestAPI(service accounts.ForDeletingAccount) *ForDeletingAccountUsingRestAPI {
+	return &ForDeletingAccountUsingRestAPI{
+		accountService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for deleting the account identified by the {id} path value.
+// With an If-Match header the account is only deleted if it is still at that ETag, and the
+// request fails with 412 Precondition Failed otherwise.
+func (h *ForDeletingAccountUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		http.Error(w, "Account does not match If-Match", http.StatusPreconditionFailed)
+		return
+	}
+
+	err := h.accountService.DeleteAccount(r.Context(), r.PathValue("id"), version)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, accounts.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, accounts.ErrVersionMismatch) {
+		http.Error(w, "Account does not match If-Match", http.StatusPreconditionFailed)
+		return
+	}
+	if errors.Is(err, accounts.ErrAccountHasTransactions) {
+		http.Error(w, "Account still has transactions and cannot be deleted", http.StatusConflict)
+		return
+	}
+	if errors.Is(err, accounts.ErrAccountInUse) {
+		http.Error(w, "Account still has budgets or rules and cannot be deleted", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to delete account", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
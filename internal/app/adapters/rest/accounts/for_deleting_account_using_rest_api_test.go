@@ -0,0 +1,119 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/accounts"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForDeletingAccount simulates the account service for testing.
+type FakeForDeletingAccount struct {
+	ReturnErr error
+	Version   int
+}
+
+func (f *FakeForDeletingAccount) DeleteAccount(ctx context.Context, id string, version int) error {
+	f.Version = version
+	return f.ReturnErr
+}
+
+func newDeleteAccountRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodDelete, "/accounts/12345", nil)
+	req.SetPathValue("id", "12345")
+	return req
+}
+
+// Test for deleting an account via the REST API
+func TestForDeletingAccountUsingRestAPI(t *testing.T) {
+	apiHandler := NewForDeletingAccountUsingRestAPI(&FakeForDeletingAccount{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newDeleteAccountRequest())
+
+	assert.Equal(t, http.StatusNoContent, respRecorder.Code, "Expected HTTP 204 No Content")
+}
+
+// Test that If-Match passes the expected version on
+func TestForDeletingAccountUsingRestAPI_IfMatch(t *testing.T) {
+	fakeService := &FakeForDeletingAccount{}
+	apiHandler := NewForDeletingAccountUsingRestAPI(fakeService)
+	respRecorder := httptest.NewRecorder()
+
+	req := newDeleteAccountRequest()
+	req.Header.Set("If-Match", `"4"`)
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusNoContent, respRecorder.Code, "Expected HTTP 204 No Content")
+	assert.Equal(t, 4, fakeService.Version, "The If-Match version should be passed on")
+}
+
+// Test deleting an account that changed since it was read
+func TestForDeletingAccountUsingRestAPI_PreconditionFailed(t *testing.T) {
+	apiHandler := NewForDeletingAccountUsingRestAPI(&FakeForDeletingAccount{ReturnErr: accounts.ErrVersionMismatch})
+	respRecorder := httptest.NewRecorder()
+
+	req := newDeleteAccountRequest()
+	req.Header.Set("If-Match", `"4"`)
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, respRecorder.Code, "Expected HTTP 412 Precondition Failed")
+}
+
+// Test for invalid HTTP method
+func TestForDeletingAccountUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForDeletingAccountUsingRestAPI(&FakeForDeletingAccount{})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/12345", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
+
+// Test for an account that does not exist
+func TestForDeletingAccountUsingRestAPI_NotFound(t *testing.T) {
+	apiHandler := NewForDeletingAccountUsingRestAPI(&FakeForDeletingAccount{ReturnErr: accounts.ErrAccountNotFound})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newDeleteAccountRequest())
+
+	assert.Equal(t, http.StatusNotFound, respRecorder.Code)
+}
+
+// Test for deleting an account that still has transactions
+func TestForDeletingAccountUsingRestAPI_HasTransactions(t *testing.T) {
+	apiHandler := NewForDeletingAccountUsingRestAPI(&FakeForDeletingAccount{ReturnErr: accounts.ErrAccountHasTransactions})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newDeleteAccountRequest())
+
+	assert.Equal(t, http.StatusConflict, respRecorder.Code, "Expected HTTP 409 Conflict")
+	assert.Contains(t, respRecorder.Body.String(), "still has transactions")
+}
+
+// Test for deleting an account that budgets or rules still refer to
+func TestForDeletingAccountUsingRestAPI_InUse(t *testing.T) {
+	apiHandler := NewForDeletingAccountUsingRestAPI(&FakeForDeletingAccount{ReturnErr: accounts.ErrAccountInUse})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newDeleteAccountRequest())
+
+	assert.Equal(t, http.StatusConflict, respRecorder.Code, "Expected HTTP 409 Conflict")
+	assert.Contains(t, respRecorder.Body.String(), "still has budgets or rules")
+}
+
+// Test for internal server error from the account service
+func TestForDeletingAccountUsingRestAPI_ServiceError(t *testing.T) {
+	apiHandler := NewForDeletingAccountUsingRestAPI(&FakeForDeletingAccount{ReturnErr: errors.New("boom")})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newDeleteAccountRequest())
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
@@ -0,0 +1,77 @@
+package accounts
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/accounts"
+	"strconv"
+	"strings"
+)
+
+// ForGettingAccountUsingRestAPI is the REST API adapter for retrieving a single account.
+type ForGettingAccountUsingRestAPI struct {
+	accountService accounts.ForGettingAccount
+}
+
+// NewForGettingAccountUsingRestAPI creates a new REST handler for retrieving accounts.
+func NewForGettingAccountUsingRestAPI(service accounts.ForGettingAccount) *ForGettingAccountUsingRestAPI {
+	return &ForGettingAccountUsingRestAPI{
+		accountService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for retrieving an account by the {id} path value. The
+// account's version is sent as its ETag, for use in If-Match when changing it.
+func (h *ForGettingAccountUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	account, err := h.accountService.GetAccount(r.Context(), r.PathValue("id"))
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, accounts.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to get account", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(account.Version))
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(account)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// etag formats an account version as a strong entity tag
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatchVersion returns the account version the request's If-Match header
+// asks for, or zero if it has none or is "*". ok is false if the header
+// cannot match any version, such as a weak or malformed entity tag.
+func ifMatchVersion(r *http.Request) (version int, ok bool) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, true
+	}
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return 0, false
+	}
+	version, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,147 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/accounts"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForGettingAccount simulates the account service for testing.
+type FakeForGettingAccount struct {
+	ReturnError     bool
+	ReturnNotFound  bool
+	ReturnForbidden bool
+}
+
+func (f *FakeForGettingAccount) GetAccount(ctx context.Context, id string) (*accounts.Account, error) {
+	if f.ReturnNotFound {
+		return nil, accounts.ErrAccountNotFound
+	}
+	if f.ReturnForbidden {
+		return nil, &access.ForbiddenError{AccountID: id, Permission: access.PermissionView}
+	}
+	if f.ReturnError {
+		return nil, errors.New("failed to get account")
+	}
+	return &accounts.Account{
+		ID:      id,
+		Name:    "John Doe",
+		Version: 3,
+	}, nil
+}
+
+// Test for getting an account via the REST API
+func TestForGettingAccountUsingRestAPI(t *testing.T) {
+	apiHandler := NewForGettingAccountUsingRestAPI(&FakeForGettingAccount{})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/12345", nil)
+	req.SetPathValue("id", "12345")
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, respRecorder.Body.String(), `"ID":"12345"`, "Response should contain account ID")
+	assert.Contains(t, respRecorder.Body.String(), `"Name":"John Doe"`, "Response should contain account name")
+	assert.Equal(t, `"3"`, respRecorder.Header().Get("ETag"), "The account's version should be its ETag")
+}
+
+// Test for invalid HTTP method
+func TestForGettingAccountUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForGettingAccountUsingRestAPI(&FakeForGettingAccount{})
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts/12345", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
+
+// Test for an account that does not exist
+func TestForGettingAccountUsingRestAPI_NotFound(t *testing.T) {
+	apiHandler := NewForGettingAccountUsingRestAPI(&FakeForGettingAccount{ReturnNotFound: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/12345", nil)
+	req.SetPathValue("id", "12345")
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusNotFound, respRecorder.Code)
+}
+
+// Test for an account the caller may not view
+func TestForGettingAccountUsingRestAPI_Forbidden(t *testing.T) {
+	apiHandler := NewForGettingAccountUsingRestAPI(&FakeForGettingAccount{ReturnForbidden: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/12345", nil)
+	req.SetPathValue("id", "12345")
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusForbidden, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), "the view permission on account 12345 is required", "The response should explain the missing permission")
+}
+
+// Test for internal server error from the account service
+func TestForGettingAccountUsingRestAPI_ServiceError(t *testing.T) {
+	apiHandler := NewForGettingAccountUsingRestAPI(&FakeForGettingAccount{ReturnError: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/12345", nil)
+	req.SetPathValue("id", "12345")
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
+
+// Test for JSON encoding failure when getting an account
+func TestForGettingAccountUsingRestAPI_EncodingError(t *testing.T) {
+	apiHandler := NewForGettingAccountUsingRestAPI(&FakeForGettingAccount{})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/12345", nil)
+	req.SetPathValue("id", "12345")
+	respRecorder := &errorResponseWriter{}
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.statusCode,
+		"Expected Internal Server Error if JSON encoding fails")
+}
+
+// Test reading the version an If-Match header asks for
+func TestIfMatchVersion(t *testing.T) {
+	tests := map[string]struct {
+		header  string
+		version int
+		ok      bool
+	}{
+		"missing":   {"", 0, true},
+		"any":       {"*", 0, true},
+		"version":   {`"3"`, 3, true},
+		"weak":      {`W/"3"`, 0, false},
+		"unquoted":  {"3", 0, false},
+		"malformed": {`"abc"`, 0, false},
+		"zero":      {`"0"`, 0, false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/accounts/12345", nil)
+			req.Header.Set("If-Match", tt.header)
+
+			version, ok := ifMatchVersion(req)
+
+			assert.Equal(t, tt.version, version)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package accounts
+
+import (
+	"encoding/json"
+	"net/http"
+	"spend-api/internal/domain/accounts"
+)
+
+// ForListingAccountsUsingRestAPI is the REST API adapter for listing accounts.
+type ForListingAccountsUsingRestAPI struct {
+	accountService accounts.ForListingAccounts
+}
+
+// NewForListingAccountsUsingRestAPI creates a new REST handler for listing accounts.
+func NewForListingAccountsUsingRestAPI(service accounts.ForListingAccounts) *ForListingAccountsUsingRestAPI {
+	return &ForListingAccountsUsingRestAPI{
+		accountService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for listing accounts.
+func (h *ForListingAccountsUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := h.accountService.ListAccounts(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to list accounts", http.StatusInternalServerError)
+		return
+	}
+	if result == nil {
+		result = []*accounts.Account{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
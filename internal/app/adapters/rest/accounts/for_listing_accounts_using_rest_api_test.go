@@ -0,0 +1,78 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/accounts"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForListingAccounts simulates the account service for testing.
+type FakeForListingAccounts struct {
+	Accounts    []*accounts.Account
+	ReturnError bool
+}
+
+func (f *FakeForListingAccounts) ListAccounts(ctx context.Context) ([]*accounts.Account, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to list accounts")
+	}
+	return f.Accounts, nil
+}
+
+// Test for listing accounts via the REST API
+func TestForListingAccountsUsingRestAPI(t *testing.T) {
+	apiHandler := NewForListingAccountsUsingRestAPI(&FakeForListingAccounts{
+		Accounts: []*accounts.Account{{ID: "1", Name: "Savings"}, {ID: "2", Name: "Current"}},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, respRecorder.Body.String(), `"Name":"Savings"`)
+	assert.Contains(t, respRecorder.Body.String(), `"Name":"Current"`)
+}
+
+// Test that an empty account list is encoded as an empty JSON array
+func TestForListingAccountsUsingRestAPI_Empty(t *testing.T) {
+	apiHandler := NewForListingAccountsUsingRestAPI(&FakeForListingAccounts{})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.JSONEq(t, `[]`, respRecorder.Body.String())
+}
+
+// Test for invalid HTTP method
+func TestForListingAccountsUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForListingAccountsUsingRestAPI(&FakeForListingAccounts{})
+
+	req := httptest.NewRequest(http.MethodPut, "/accounts", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
+
+// Test for internal server error from the account service
+func TestForListingAccountsUsingRestAPI_ServiceError(t *testing.T) {
+	apiHandler := NewForListingAccountsUsingRestAPI(&FakeForListingAccounts{ReturnError: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
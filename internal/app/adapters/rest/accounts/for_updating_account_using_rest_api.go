@@ -0,0 +1,76 @@
+package accounts
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/accounts"
+)
+
+// ForUpdatingAccountUsingRestAPI is the REST API adapter for updating accounts.
+type ForUpdatingAccountUsingRestAPI struct {
+	accountService accounts.ForUpdatingAccount
+}
+
+// NewForUpdatingAccountUsingRestAPI creates a new REST handler for updating accounts.
+func NewForUpdatingAccountUsingRestAPI(service accounts.ForUpdatingAccount) *ForUpdatingAccountUsingRestAPI {
+	return &ForUpdatingAccountUsingRestAPI{
+		accountService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for updating the account identified by the {id} path value.
+// With an If-Match header the update only goes ahead if the account is still at that ETag,
+// and fails with 412 Precondition Failed otherwise.
+func (h *ForUpdatingAccountUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		http.Error(w, "Account does not match If-Match", http.StatusPreconditionFailed)
+		return
+	}
+
+	account, err := h.accountService.UpdateAccount(r.Context(), r.PathValue("id"), requestBody.Name, version)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, accounts.ErrNameRequired) {
+		http.Error(w, "Invalid account: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, accounts.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, accounts.ErrVersionMismatch) {
+		http.Error(w, "Account does not match If-Match", http.StatusPreconditionFailed)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to update account", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(account.Version))
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(account)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
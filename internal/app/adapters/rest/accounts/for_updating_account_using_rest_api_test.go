@@ -0,0 +1,142 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/accounts"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForUpdatingAccount simulates the account service for testing.
+type FakeForUpdatingAccount struct {
+	ReturnError    bool
+	ReturnNotFound bool
+	Version        int
+}
+
+func (f *FakeForUpdatingAccount) UpdateAccount(ctx context.Context, id, name string, version int) (*accounts.Account, error) {
+	f.Version = version
+	if name == "" {
+		return nil, accounts.ErrNameRequired
+	}
+	if f.ReturnNotFound {
+		return nil, accounts.ErrAccountNotFound
+	}
+	if version != 0 && version != 2 {
+		return nil, accounts.ErrVersionMismatch
+	}
+	if f.ReturnError {
+		return nil, errors.New("failed to update account")
+	}
+	return &accounts.Account{
+		ID:      id,
+		Name:    name,
+		Version: 3,
+	}, nil
+}
+
+func newUpdateAccountRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPatch, "/accounts/12345", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "12345")
+	return req
+}
+
+// Test for updating an account via the REST API
+func TestForUpdatingAccountUsingRestAPI(t *testing.T) {
+	apiHandler := NewForUpdatingAccountUsingRestAPI(&FakeForUpdatingAccount{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newUpdateAccountRequest(`{"name":"Holiday fund"}`))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, respRecorder.Body.String(), `"Name":"Holiday fund"`, "Response should contain new name")
+}
+
+// Test that If-Match passes the expected version on and the response carries the new one
+func TestForUpdatingAccountUsingRestAPI_IfMatch(t *testing.T) {
+	fakeService := &FakeForUpdatingAccount{}
+	apiHandler := NewForUpdatingAccountUsingRestAPI(fakeService)
+	respRecorder := httptest.NewRecorder()
+
+	req := newUpdateAccountRequest(`{"name":"Holiday fund"}`)
+	req.Header.Set("If-Match", `"2"`)
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code, "Expected HTTP 200 OK")
+	assert.Equal(t, 2, fakeService.Version, "The If-Match version should be passed on")
+	assert.Equal(t, `"3"`, respRecorder.Header().Get("ETag"), "The new version should be the ETag")
+}
+
+// Test that a stale or unusable If-Match fails the precondition
+func TestForUpdatingAccountUsingRestAPI_PreconditionFailed(t *testing.T) {
+	for _, ifMatch := range []string{`"1"`, `W/"2"`} {
+		fakeService := &FakeForUpdatingAccount{}
+		apiHandler := NewForUpdatingAccountUsingRestAPI(fakeService)
+		respRecorder := httptest.NewRecorder()
+
+		req := newUpdateAccountRequest(`{"name":"Holiday fund"}`)
+		req.Header.Set("If-Match", ifMatch)
+		apiHandler.ServeHTTP(respRecorder, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, respRecorder.Code, "Expected HTTP 412 for If-Match %s", ifMatch)
+	}
+}
+
+// Test for invalid HTTP method
+func TestForUpdatingAccountUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForUpdatingAccountUsingRestAPI(&FakeForUpdatingAccount{})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/12345", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
+
+// Test for invalid JSON in the request body
+func TestForUpdatingAccountUsingRestAPI_InvalidJSON(t *testing.T) {
+	apiHandler := NewForUpdatingAccountUsingRestAPI(&FakeForUpdatingAccount{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newUpdateAccountRequest(`invalid json`))
+
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+}
+
+// Test that renaming an account to a blank name is rejected
+func TestForUpdatingAccountUsingRestAPI_BlankName(t *testing.T) {
+	apiHandler := NewForUpdatingAccountUsingRestAPI(&FakeForUpdatingAccount{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newUpdateAccountRequest(`{"name":""}`))
+
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, respRecorder.Body.String(), "account name is required")
+}
+
+// Test for an account that does not exist
+func TestForUpdatingAccountUsingRestAPI_NotFound(t *testing.T) {
+	apiHandler := NewForUpdatingAccountUsingRestAPI(&FakeForUpdatingAccount{ReturnNotFound: true})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newUpdateAccountRequest(`{"name":"Holiday fund"}`))
+
+	assert.Equal(t, http.StatusNotFound, respRecorder.Code)
+}
+
+// Test for internal server error from the account service
+func TestForUpdatingAccountUsingRestAPI_ServiceError(t *testing.T) {
+	apiHandler := NewForUpdatingAccountUsingRestAPI(&FakeForUpdatingAccount{ReturnError: true})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newUpdateAccountRequest(`{"name":"Holiday fund"}`))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
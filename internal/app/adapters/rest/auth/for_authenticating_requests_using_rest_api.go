@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/auth"
+	"strings"
+)
+
+// apiKeyHeader carries an API key as an alternative to the Authorization header
+const apiKeyHeader = "X-API-Key"
+
+// ForAuthenticatingRequestsUsingRestAPI is the REST API adapter that lets only authenticated
+// requests through. It wraps the handler of the whole API.
+type ForAuthenticatingRequestsUsingRestAPI struct {
+	apiKeyService auth.ForAuthenticatingAPIKey
+	tokenService  auth.ForAuthenticatingToken
+	next          http.Handler
+}
+
+// NewForAuthenticatingRequestsUsingRestAPI creates a new REST handler that passes
+// authenticated requests on to next.
+func NewForAuthenticatingRequestsUsingRestAPI(apiKeys auth.ForAuthenticatingAPIKey, tokens auth.ForAuthenticatingToken, next http.Handler) *ForAuthenticatingRequestsUsingRestAPI {
+	return &ForAuthenticatingRequestsUsingRestAPI{
+		apiKeyService: apiKeys,
+		tokenService:  tokens,
+		next:          next,
+	}
+}
+
+// ServeHTTP authenticates the request with the API key in the X-API-Key
+// header, or the credentials in an "Authorization: Bearer" header, which may
+// be an API key or a JWT. Requests without valid credentials are rejected
+// with 401 Unauthorized. Otherwise the request is passed on with the
+// authenticated principal in its context.
+func (h *ForAuthenticatingRequestsUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	credentials := r.Header.Get(apiKeyHeader)
+	if credentials == "" {
+		scheme, value, found := strings.Cut(r.Header.Get("Authorization"), " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			credentials = strings.TrimSpace(value)
+		}
+	}
+	if credentials == "" {
+		unauthorized(w, "Authentication required")
+		return
+	}
+
+	var principal *auth.Principal
+	var err error
+	if strings.HasPrefix(credentials, auth.APIKeyPrefix) {
+		principal, err = h.apiKeyService.AuthenticateAPIKey(r.Context(), credentials)
+	} else {
+		principal, err = h.tokenService.AuthenticateToken(r.Context(), credentials)
+	}
+	if errors.Is(err, auth.ErrUnauthenticated) {
+		unauthorized(w, "Invalid credentials")
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to authenticate request", http.StatusInternalServerError)
+		return
+	}
+
+	h.next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+}
+
+// unauthorized rejects the request, telling the client how to authenticate
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="spend-api"`)
+	http.Error(w, message, http.StatusUnauthorized)
+}
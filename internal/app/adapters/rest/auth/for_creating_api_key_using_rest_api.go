@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/auth"
+)
+
+// ForCreatingAPIKeyUsingRestAPI is the REST API adapter for creating API keys.
+type ForCreatingAPIKeyUsingRestAPI struct {
+	apiKeyService auth.ForCreatingAPIKey
+}
+
+// NewForCreatingAPIKeyUsingRestAPI creates a new REST handler for creating API keys.
+func NewForCreatingAPIKeyUsingRestAPI(service auth.ForCreatingAPIKey) *ForCreatingAPIKeyUsingRestAPI {
+	return &ForCreatingAPIKeyUsingRestAPI{
+		apiKeyService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for creating an API key. The response is
+// the only place the key itself is ever shown.
+func (h *ForCreatingAPIKeyUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	issued, err := h.apiKeyService.CreateAPIKey(r.Context(), requestBody.Name)
+	if errors.Is(err, auth.ErrInvalidAPIKey) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to create API key", http.StatusInternalServerError)
+		return
+	}
+
+	writeIssuedAPIKey(w, issued)
+}
+
+// writeIssuedAPIKey sends a newly issued key back with 201 Created
+func writeIssuedAPIKey(w http.ResponseWriter, issued *auth.IssuedAPIKey) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(issued); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var keyCreatedAt = time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+
+// FakeAPIKeyService simulates the API key service for testing.
+type FakeAPIKeyService struct {
+	ReturnErr error
+	Name      string
+	ID        string
+	Principal *auth.Principal
+	Presented string
+}
+
+func (f *FakeAPIKeyService) issued(id, name string) *auth.IssuedAPIKey {
+	return &auth.IssuedAPIKey{
+		APIKey: &auth.APIKey{ID: id, TenantID: "1", UserID: "5", Name: name, Prefix: "0123456789ab", SecretHash: "f00d", CreatedAt: keyCreatedAt},
+		Key:    "sk_0123456789ab_secret",
+	}
+}
+
+func (f *FakeAPIKeyService) CreateAPIKey(ctx context.Context, name string) (*auth.IssuedAPIKey, error) {
+	f.Name = name
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return f.issued("3", name), nil
+}
+
+func (f *FakeAPIKeyService) ListAPIKeys(ctx context.Context) ([]*auth.APIKey, error) {
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return []*auth.APIKey{f.issued("3", "CI").APIKey}, nil
+}
+
+func (f *FakeAPIKeyService) RevokeAPIKey(ctx context.Context, id string) error {
+	f.ID = id
+	return f.ReturnErr
+}
+
+func (f *FakeAPIKeyService) RotateAPIKey(ctx context.Context, id string) (*auth.IssuedAPIKey, error) {
+	f.ID = id
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return f.issued("4", "CI"), nil
+}
+
+func (f *FakeAPIKeyService) AuthenticateAPIKey(ctx context.Context, key string) (*auth.Principal, error) {
+	f.Presented = key
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return f.Principal, nil
+}
+
+// Test successful API key creation shows the key once, without its hash
+func TestForCreatingAPIKeyUsingRestAPI_Success(t *testing.T) {
+	fakeService := &FakeAPIKeyService{}
+	apiHandler := NewForCreatingAPIKeyUsingRestAPI(fakeService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(`{"name":"CI"}`))
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code)
+	assert.Equal(t, "no-store", respRecorder.Header().Get("Cache-Control"))
+	assert.Equal(t, "CI", fakeService.Name)
+	assert.JSONEq(t, `{"ID":"3","TenantID":"1","UserID":"5","Name":"CI","Prefix":"0123456789ab","CreatedBy":"","CreatedAt":"2024-01-15T09:30:00Z","RevokedAt":null,"Key":"sk_0123456789ab_secret"}`, respRecorder.Body.String())
+}
+
+// Test API key creation errors
+func TestForCreatingAPIKeyUsingRestAPI_Errors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code int
+	}{
+		"invalid name": {auth.ErrInvalidAPIKey, http.StatusBadRequest},
+		"failure":      {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := NewForCreatingAPIKeyUsingRestAPI(&FakeAPIKeyService{ReturnErr: tt.err})
+
+			req := httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(`{"name":""}`))
+			respRecorder := httptest.NewRecorder()
+			apiHandler.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.code, respRecorder.Code)
+		})
+	}
+}
+
+// Test API key creation with an invalid body or method
+func TestForCreatingAPIKeyUsingRestAPI_InvalidRequest(t *testing.T) {
+	apiHandler := NewForCreatingAPIKeyUsingRestAPI(&FakeAPIKeyService{})
+
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(`{`)))
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+
+	respRecorder = httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/api-keys", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
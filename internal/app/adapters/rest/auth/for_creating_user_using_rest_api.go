@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/auth"
+)
+
+// ForCreatingUserUsingRestAPI is the REST API adapter for adding users to the caller's tenant.
+type ForCreatingUserUsingRestAPI struct {
+	userService auth.ForCreatingUser
+}
+
+// NewForCreatingUserUsingRestAPI creates a new REST handler for creating users.
+func NewForCreatingUserUsingRestAPI(service auth.ForCreatingUser) *ForCreatingUserUsingRestAPI {
+	return &ForCreatingUserUsingRestAPI{
+		userService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for creating a user. The user joins the
+// tenant of the caller, who must administer it or gets 403 Forbidden, with the
+// subject their token proves. A subject already taken gets 409 Conflict.
+func (h *ForCreatingUserUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		Token string `json:"token"`
+		Name  string `json:"name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.userService.CreateUser(r.Context(), requestBody.Token, requestBody.Name)
+	if errors.Is(err, auth.ErrNotAdministrator) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if errors.Is(err, auth.ErrInvalidUser) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, auth.ErrDuplicateUser) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to create user", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
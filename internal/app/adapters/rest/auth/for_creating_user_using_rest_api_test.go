@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeUserService simulates the user service for testing.
+type FakeUserService struct {
+	ReturnErr error
+	Token     string
+	Name      string
+}
+
+func (f *FakeUserService) CreateUser(ctx context.Context, token, name string) (*auth.User, error) {
+	f.Token, f.Name = token, name
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return &auth.User{ID: "8", TenantID: "1", Subject: "bob", Name: name, CreatedAt: keyCreatedAt}, nil
+}
+
+func (f *FakeUserService) ListUsers(ctx context.Context) ([]*auth.User, error) {
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return []*auth.User{{ID: "5", TenantID: "1", Subject: "alice", Name: "Alice", Admin: true, CreatedAt: keyCreatedAt}}, nil
+}
+
+// Test successful user creation
+func TestForCreatingUserUsingRestAPI_Success(t *testing.T) {
+	fakeService := &FakeUserService{}
+	apiHandler := NewForCreatingUserUsingRestAPI(fakeService)
+
+	req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewBufferString(`{"token":"bobs.id.token","name":"Bob"}`))
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code)
+	assert.Equal(t, "bobs.id.token", fakeService.Token)
+	assert.Equal(t, "Bob", fakeService.Name)
+	assert.JSONEq(t, `{"ID":"8","TenantID":"1","Subject":"bob","Name":"Bob","Admin":false,"CreatedAt":"2024-01-15T09:30:00Z"}`, respRecorder.Body.String())
+}
+
+// Test user creation errors
+func TestForCreatingUserUsingRestAPI_Errors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code int
+	}{
+		"not admin":     {auth.ErrNotAdministrator, http.StatusForbidden},
+		"invalid":       {auth.ErrInvalidUser, http.StatusBadRequest},
+		"subject taken": {auth.ErrDuplicateUser, http.StatusConflict},
+		"failure":       {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := NewForCreatingUserUsingRestAPI(&FakeUserService{ReturnErr: tt.err})
+
+			req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewBufferString(`{"token":"bobs.id.token","name":"Bob"}`))
+			respRecorder := httptest.NewRecorder()
+			apiHandler.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.code, respRecorder.Code)
+		})
+	}
+}
+
+// Test user creation with an invalid body or method
+func TestForCreatingUserUsingRestAPI_InvalidRequest(t *testing.T) {
+	apiHandler := NewForCreatingUserUsingRestAPI(&FakeUserService{})
+
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodPost, "/users", bytes.NewBufferString(`{`)))
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+
+	respRecorder = httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/users", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"spend-api/internal/domain/auth"
+)
+
+// ForListingAPIKeysUsingRestAPI is the REST API adapter for listing API keys.
+type ForListingAPIKeysUsingRestAPI struct {
+	apiKeyService auth.ForListingAPIKeys
+}
+
+// NewForListingAPIKeysUsingRestAPI creates a new REST handler for listing API keys.
+func NewForListingAPIKeysUsingRestAPI(service auth.ForListingAPIKeys) *ForListingAPIKeysUsingRestAPI {
+	return &ForListingAPIKeysUsingRestAPI{
+		apiKeyService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for listing API keys. Keys are listed
+// without the key itself, which is never stored.
+func (h *ForListingAPIKeysUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	keys, err := h.apiKeyService.ListAPIKeys(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to list API keys", http.StatusInternalServerError)
+		return
+	}
+	if keys == nil {
+		keys = []*auth.APIKey{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(keys); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
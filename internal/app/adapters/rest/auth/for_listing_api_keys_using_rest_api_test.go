@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test listing API keys leaves out their hashes
+func TestForListingAPIKeysUsingRestAPI_Success(t *testing.T) {
+	apiHandler := NewForListingAPIKeysUsingRestAPI(&FakeAPIKeyService{})
+
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/api-keys", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.JSONEq(t, `[{"ID":"3","TenantID":"1","UserID":"5","Name":"CI","Prefix":"0123456789ab","CreatedBy":"","CreatedAt":"2024-01-15T09:30:00Z","RevokedAt":null}]`, respRecorder.Body.String())
+}
+
+// Test API key listing failure
+func TestForListingAPIKeysUsingRestAPI_Failure(t *testing.T) {
+	apiHandler := NewForListingAPIKeysUsingRestAPI(&FakeAPIKeyService{ReturnErr: errors.New("boom")})
+
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/api-keys", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
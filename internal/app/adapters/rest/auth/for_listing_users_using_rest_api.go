@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"spend-api/internal/domain/auth"
+)
+
+// ForListingUsersUsingRestAPI is the REST API adapter for listing the users of the caller's tenant.
+type ForListingUsersUsingRestAPI struct {
+	userService auth.ForListingUsers
+}
+
+// NewForListingUsersUsingRestAPI creates a new REST handler for listing users.
+func NewForListingUsersUsingRestAPI(service auth.ForListingUsers) *ForListingUsersUsingRestAPI {
+	return &ForListingUsersUsingRestAPI{
+		userService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for listing the users of the caller's tenant.
+func (h *ForListingUsersUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	users, err := h.userService.ListUsers(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to list users", http.StatusInternalServerError)
+		return
+	}
+	if users == nil {
+		users = []*auth.User{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(users); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
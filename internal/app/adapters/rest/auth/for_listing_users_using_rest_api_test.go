@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test listing the users of the tenant
+func TestForListingUsersUsingRestAPI_Success(t *testing.T) {
+	apiHandler := NewForListingUsersUsingRestAPI(&FakeUserService{})
+
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.JSONEq(t, `[{"ID":"5","TenantID":"1","Subject":"alice","Name":"Alice","Admin":true,"CreatedAt":"2024-01-15T09:30:00Z"}]`, respRecorder.Body.String())
+}
+
+// Test user listing failure
+func TestForListingUsersUsingRestAPI_Failure(t *testing.T) {
+	apiHandler := NewForListingUsersUsingRestAPI(&FakeUserService{ReturnErr: errors.New("boom")})
+
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/auth"
+)
+
+// ForRevokingAPIKeyUsingRestAPI is the REST API adapter for revoking API keys.
+type ForRevokingAPIKeyUsingRestAPI struct {
+	apiKeyService auth.ForRevokingAPIKey
+}
+
+// NewForRevokingAPIKeyUsingRestAPI creates a new REST handler for revoking API keys.
+func NewForRevokingAPIKeyUsingRestAPI(service auth.ForRevokingAPIKey) *ForRevokingAPIKeyUsingRestAPI {
+	return &ForRevokingAPIKeyUsingRestAPI{
+		apiKeyService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for revoking the API key identified by the {id} path value.
+// The key is kept, so it still shows up when listing keys, but can no longer be used.
+func (h *ForRevokingAPIKeyUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := h.apiKeyService.RevokeAPIKey(r.Context(), r.PathValue("id"))
+	if errors.Is(err, auth.ErrAPIKeyNotFound) {
+		http.Error(w, "API key not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to revoke API key", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test revoking an API key
+func TestForRevokingAPIKeyUsingRestAPI_Success(t *testing.T) {
+	fakeService := &FakeAPIKeyService{}
+	apiHandler := NewForRevokingAPIKeyUsingRestAPI(fakeService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api-keys/3", nil)
+	req.SetPathValue("id", "3")
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusNoContent, respRecorder.Code)
+	assert.Equal(t, "3", fakeService.ID)
+}
+
+// Test API key revoking errors
+func TestForRevokingAPIKeyUsingRestAPI_Errors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code int
+	}{
+		"not found": {auth.ErrAPIKeyNotFound, http.StatusNotFound},
+		"failure":   {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := NewForRevokingAPIKeyUsingRestAPI(&FakeAPIKeyService{ReturnErr: tt.err})
+
+			req := httptest.NewRequest(http.MethodDelete, "/api-keys/3", nil)
+			req.SetPathValue("id", "3")
+			respRecorder := httptest.NewRecorder()
+			apiHandler.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.code, respRecorder.Code)
+		})
+	}
+}
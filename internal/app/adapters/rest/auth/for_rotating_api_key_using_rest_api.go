@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/auth"
+)
+
+// ForRotatingAPIKeyUsingRestAPI is the REST API adapter for rotating API keys.
+type ForRotatingAPIKeyUsingRestAPI struct {
+	apiKeyService auth.ForRotatingAPIKey
+}
+
+// NewForRotatingAPIKeyUsingRestAPI creates a new REST handler for rotating API keys.
+func NewForRotatingAPIKeyUsingRestAPI(service auth.ForRotatingAPIKey) *ForRotatingAPIKeyUsingRestAPI {
+	return &ForRotatingAPIKeyUsingRestAPI{
+		apiKeyService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for rotating the API key identified by the {id} path value.
+// The key is revoked and a new one with the same name is issued in its place.
+func (h *ForRotatingAPIKeyUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	issued, err := h.apiKeyService.RotateAPIKey(r.Context(), r.PathValue("id"))
+	if errors.Is(err, auth.ErrAPIKeyNotFound) {
+		http.Error(w, "API key not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, auth.ErrAPIKeyRevoked) {
+		http.Error(w, "API key was already revoked and cannot be rotated", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to rotate API key", http.StatusInternalServerError)
+		return
+	}
+
+	writeIssuedAPIKey(w, issued)
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test rotating an API key returns its replacement
+func TestForRotatingAPIKeyUsingRestAPI_Success(t *testing.T) {
+	fakeService := &FakeAPIKeyService{}
+	apiHandler := NewForRotatingAPIKeyUsingRestAPI(fakeService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api-keys/3/rotate", nil)
+	req.SetPathValue("id", "3")
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code)
+	assert.Equal(t, "3", fakeService.ID)
+	assert.Contains(t, respRecorder.Body.String(), `"ID":"4"`)
+	assert.Contains(t, respRecorder.Body.String(), `"Key":"sk_0123456789ab_secret"`)
+}
+
+// Test API key rotating errors
+func TestForRotatingAPIKeyUsingRestAPI_Errors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code int
+	}{
+		"not found": {auth.ErrAPIKeyNotFound, http.StatusNotFound},
+		"revoked":   {auth.ErrAPIKeyRevoked, http.StatusConflict},
+		"failure":   {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := NewForRotatingAPIKeyUsingRestAPI(&FakeAPIKeyService{ReturnErr: tt.err})
+
+			req := httptest.NewRequest(http.MethodPost, "/api-keys/3/rotate", nil)
+			req.SetPathValue("id", "3")
+			respRecorder := httptest.NewRecorder()
+			apiHandler.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.code, respRecorder.Code)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package budgets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"time"
+)
+
+// dateLayout is the format accepted for dates in request bodies and query parameters
+const dateLayout = "2006-01-02"
+
+// ForCreatingBudgetUsingRestAPI is the REST API adapter for creating budgets.
+type ForCreatingBudgetUsingRestAPI struct {
+	budgetService budgets.ForCreatingBudget
+}
+
+// NewForCreatingBudgetUsingRestAPI creates a new REST handler for creating budgets.
+func NewForCreatingBudgetUsingRestAPI(service budgets.ForCreatingBudget) *ForCreatingBudgetUsingRestAPI {
+	return &ForCreatingBudgetUsingRestAPI{
+		budgetService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for creating a budget. A body that cannot be
+// read is a 400 Bad Request; a budget the service rejects, such as one limited
+// to an unknown account, is reported with 422 Unprocessable Entity.
+func (h *ForCreatingBudgetUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	budget, err := decodeBudget(r)
+	if errors.Is(err, budgets.ErrInvalidBudget) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	budget, err = h.budgetService.CreateBudget(r.Context(), budget)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, budgets.ErrInvalidBudget) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to create budget", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(budget)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// decodeBudget reads a budget from the request body. The amount is in the
+// given currency, dates are YYYY-MM-DD and type, when given, must be a
+// transaction kind.
+func decodeBudget(r *http.Request) (*budgets.Budget, error) {
+	var requestBody struct {
+		Name               string      `json:"name"`
+		Amount             json.Number `json:"amount"`
+		Currency           string      `json:"currency"`
+		Period             string      `json:"period"`
+		StartDate          string      `json:"startDate"`
+		EndDate            string      `json:"endDate"`
+		AccountID          string      `json:"accountID"`
+		Type               string      `json:"type"`
+		DescriptionPattern string      `json:"descriptionPattern"`
+		Rollover           bool        `json:"rollover"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		return nil, err
+	}
+
+	currency, err := money.ParseCurrency(requestBody.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", budgets.ErrInvalidBudget, err)
+	}
+	amount, err := money.Parse(requestBody.Amount.String(), currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid amount: %v", budgets.ErrInvalidBudget, err)
+	}
+	if requestBody.Type != "" && !transactions.Kind(requestBody.Type).IsValid() {
+		return nil, fmt.Errorf("%w: unknown type %q", budgets.ErrInvalidBudget, requestBody.Type)
+	}
+
+	budget := &budgets.Budget{
+		Name:               requestBody.Name,
+		Amount:             amount,
+		Period:             budgets.Period(requestBody.Period),
+		AccountID:          requestBody.AccountID,
+		Type:               requestBody.Type,
+		DescriptionPattern: requestBody.DescriptionPattern,
+		Rollover:           requestBody.Rollover,
+	}
+	if budget.StartDate, err = parseDate("startDate", requestBody.StartDate); err != nil {
+		return nil, err
+	}
+	if requestBody.EndDate != "" {
+		end, err := parseDate("endDate", requestBody.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		budget.EndDate = &end
+	}
+	return budget, nil
+}
+
+// parseDate parses an optional YYYY-MM-DD date, returning the zero time when it is empty
+func parseDate(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid %s %q, expected YYYY-MM-DD", budgets.ErrInvalidBudget, name, value)
+	}
+	return date, nil
+}
@@ -0,0 +1,177 @@
+package budgets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/money"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeBudgetService simulates the budget service for testing.
+type FakeBudgetService struct {
+	ReturnErr error
+	Budget    *budgets.Budget
+	ID        string
+	On        time.Time
+	Currency  money.Currency
+}
+
+func (f *FakeBudgetService) CreateBudget(ctx context.Context, budget *budgets.Budget) (*budgets.Budget, error) {
+	f.Budget = budget
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	budget.ID = "1"
+	return budget, nil
+}
+
+func (f *FakeBudgetService) GetBudget(ctx context.Context, id string) (*budgets.Budget, error) {
+	f.ID = id
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return &budgets.Budget{ID: id, Name: "Groceries", Amount: money.MustParse("400.00", "EUR"), Period: budgets.PeriodMonthly}, nil
+}
+
+func (f *FakeBudgetService) ListBudgets(ctx context.Context) ([]*budgets.Budget, error) {
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	if f.Budget == nil {
+		return nil, nil
+	}
+	return []*budgets.Budget{f.Budget}, nil
+}
+
+func (f *FakeBudgetService) UpdateBudget(ctx context.Context, id string, budget *budgets.Budget) (*budgets.Budget, error) {
+	f.ID = id
+	f.Budget = budget
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	budget.ID = id
+	return budget, nil
+}
+
+func (f *FakeBudgetService) DeleteBudget(ctx context.Context, id string) error {
+	f.ID = id
+	return f.ReturnErr
+}
+
+func (f *FakeBudgetService) GetBudgetStatus(ctx context.Context, id string, query budgets.StatusQuery) (*budgets.Status, error) {
+	f.ID = id
+	f.On = query.On
+	f.Currency = query.Currency
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return &budgets.Status{
+		BudgetID:  id,
+		Budgeted:  money.MustParse("300.00", "EUR"),
+		Available: money.MustParse("300.00", "EUR"),
+		Spent:     money.MustParse("90.00", "EUR"),
+		Remaining: money.MustParse("210.00", "EUR"),
+		Projected: money.MustParse("270.00", "EUR"),
+		State:     budgets.StateOnTrack,
+	}, nil
+}
+
+func newBudgetRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+// Test for creating a budget via the REST API
+func TestForCreatingBudgetUsingRestAPI(t *testing.T) {
+	fakeBudgetService := &FakeBudgetService{}
+	apiHandler := NewForCreatingBudgetUsingRestAPI(fakeBudgetService)
+	respRecorder := httptest.NewRecorder()
+
+	body := `{"name": "Holiday", "amount": "1000", "currency": "EUR", "period": "custom", "startDate": "2024-08-01", "endDate": "2024-08-14",` +
+		` "accountID": "12345", "type": "debit", "descriptionPattern": "(?i)hotel"}`
+	apiHandler.ServeHTTP(respRecorder, newBudgetRequest(http.MethodPost, "/budgets", body))
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code, "Expected HTTP 201 Created")
+	assert.Contains(t, respRecorder.Body.String(), `"ID":"1"`)
+	assert.Contains(t, respRecorder.Body.String(), `"Amount":{"amount":"1000.00","currency":"EUR"}`)
+	end := time.Date(2024, 8, 14, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, &budgets.Budget{
+		ID:                 "1",
+		Name:               "Holiday",
+		Amount:             money.MustParse("1000.00", "EUR"),
+		Period:             budgets.PeriodCustom,
+		StartDate:          time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:            &end,
+		AccountID:          "12345",
+		Type:               "debit",
+		DescriptionPattern: "(?i)hotel",
+	}, fakeBudgetService.Budget)
+}
+
+// Test for invalid HTTP method
+func TestForCreatingBudgetUsingRestAPI_InvalidMethod(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForCreatingBudgetUsingRestAPI(&FakeBudgetService{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/budgets", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
+
+// Test malformed and invalid budgets are bad requests
+func TestForCreatingBudgetUsingRestAPI_BadRequests(t *testing.T) {
+	tests := map[string]string{
+		"not json":         `{`,
+		"unknown currency": `{"name": "Food", "amount": "10", "currency": "XXX"}`,
+		"bad amount":       `{"name": "Food", "amount": "ten", "currency": "EUR"}`,
+		"unknown type":     `{"name": "Food", "amount": "10", "currency": "EUR", "type": "gift"}`,
+		"bad date":         `{"name": "Food", "amount": "10", "currency": "EUR", "startDate": "01/08/2024"}`,
+	}
+	for name, body := range tests {
+		fakeBudgetService := &FakeBudgetService{}
+		respRecorder := httptest.NewRecorder()
+
+		NewForCreatingBudgetUsingRestAPI(fakeBudgetService).ServeHTTP(respRecorder, newBudgetRequest(http.MethodPost, "/budgets", body))
+
+		assert.Equal(t, http.StatusBadRequest, respRecorder.Code, name)
+		assert.Nil(t, fakeBudgetService.Budget, name)
+	}
+}
+
+// Test budgets the service rejects are unprocessable
+func TestForCreatingBudgetUsingRestAPI_Invalid(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+	serviceErr := &FakeBudgetService{ReturnErr: budgets.ErrInvalidBudget}
+
+	NewForCreatingBudgetUsingRestAPI(serviceErr).ServeHTTP(respRecorder, newBudgetRequest(http.MethodPost, "/budgets", `{"amount": "10", "currency": "EUR"}`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), "invalid budget")
+}
+
+// Test creating a budget on an account the caller may not edit
+func TestForCreatingBudgetUsingRestAPI_Forbidden(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+	forbidden := &access.ForbiddenError{AccountID: "12345", Permission: access.PermissionEdit}
+
+	NewForCreatingBudgetUsingRestAPI(&FakeBudgetService{ReturnErr: forbidden}).ServeHTTP(respRecorder, newBudgetRequest(http.MethodPost, "/budgets", `{"name": "Food", "amount": "10", "currency": "EUR", "accountID": "12345"}`))
+
+	assert.Equal(t, http.StatusForbidden, respRecorder.Code)
+}
+
+// Test budget creation failure
+func TestForCreatingBudgetUsingRestAPI_Failure(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+	fakeBudgetService := &FakeBudgetService{ReturnErr: context.DeadlineExceeded}
+
+	NewForCreatingBudgetUsingRestAPI(fakeBudgetService).ServeHTTP(respRecorder, newBudgetRequest(http.MethodPost, "/budgets", `{"name": "Food", "amount": "10", "currency": "EUR"}`))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
@@ -0,0 +1,43 @@
+package budgets
+
+import (
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/budgets"
+)
+
+// ForDeletingBudgetUsingRestAPI is the REST API adapter for deleting budgets.
+type ForDeletingBudgetUsingRestAPI struct {
+	budgetService budgets.ForDeletingBudget
+}
+
+// NewForDeletingBudgetUsingRestAPI creates a new REST handler for deleting budgets.
+func NewForDeletingBudgetUsingRestAPI(service budgets.ForDeletingBudget) *ForDeletingBudgetUsingRestAPI {
+	return &ForDeletingBudgetUsingRestAPI{
+		budgetService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for deleting the budget identified by the {id} path value.
+func (h *ForDeletingBudgetUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := h.budgetService.DeleteBudget(r.Context(), r.PathValue("id"))
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, budgets.ErrBudgetNotFound) {
+		http.Error(w, "Budget not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to delete budget", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
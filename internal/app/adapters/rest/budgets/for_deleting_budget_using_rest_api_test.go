@@ -0,0 +1,57 @@
+package budgets
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/budgets"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDeleteBudgetRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodDelete, "/budgets/1", nil)
+	req.SetPathValue("id", "1")
+	return req
+}
+
+// Test for deleting a budget via the REST API
+func TestForDeletingBudgetUsingRestAPI(t *testing.T) {
+	fakeBudgetService := &FakeBudgetService{}
+	respRecorder := httptest.NewRecorder()
+
+	NewForDeletingBudgetUsingRestAPI(fakeBudgetService).ServeHTTP(respRecorder, newDeleteBudgetRequest())
+
+	assert.Equal(t, http.StatusNoContent, respRecorder.Code, "Expected HTTP 204 No Content")
+	assert.Equal(t, "1", fakeBudgetService.ID)
+}
+
+// Test deleting a budget that does not exist
+func TestForDeletingBudgetUsingRestAPI_NotFound(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForDeletingBudgetUsingRestAPI(&FakeBudgetService{ReturnErr: budgets.ErrBudgetNotFound}).ServeHTTP(respRecorder, newDeleteBudgetRequest())
+
+	assert.Equal(t, http.StatusNotFound, respRecorder.Code)
+}
+
+// Test deleting a budget of an account the caller may not edit
+func TestForDeletingBudgetUsingRestAPI_Forbidden(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+	forbidden := &access.ForbiddenError{AccountID: "12345", Permission: access.PermissionEdit}
+
+	NewForDeletingBudgetUsingRestAPI(&FakeBudgetService{ReturnErr: forbidden}).ServeHTTP(respRecorder, newDeleteBudgetRequest())
+
+	assert.Equal(t, http.StatusForbidden, respRecorder.Code)
+}
+
+// Test budget deletion failure
+func TestForDeletingBudgetUsingRestAPI_Failure(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForDeletingBudgetUsingRestAPI(&FakeBudgetService{ReturnErr: errors.New("failed to remove budget")}).ServeHTTP(respRecorder, newDeleteBudgetRequest())
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
@@ -0,0 +1,83 @@
+package budgets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// ForGettingBudgetStatusUsingRestAPI is the REST API adapter for a budget's spending status.
+type ForGettingBudgetStatusUsingRestAPI struct {
+	budgetService budgets.ForGettingBudgetStatus
+}
+
+// NewForGettingBudgetStatusUsingRestAPI creates a new REST handler for budget statuses.
+func NewForGettingBudgetStatusUsingRestAPI(service budgets.ForGettingBudgetStatus) *ForGettingBudgetStatusUsingRestAPI {
+	return &ForGettingBudgetStatusUsingRestAPI{
+		budgetService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for the status of the budget identified by the {id} path
+// value. The optional date query parameter picks the day to report on, today by default, and
+// currency converts the figures into that currency. A missing rate is reported as 422
+// Unprocessable Entity.
+func (h *ForGettingBudgetStatusUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var on time.Time
+	if value := r.URL.Query().Get("date"); value != "" {
+		var err error
+		if on, err = time.Parse(dateLayout, value); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid date %q, expected YYYY-MM-DD", value), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var currency money.Currency
+	if value := r.URL.Query().Get("currency"); value != "" {
+		var err error
+		if currency, err = money.ParseCurrency(value); err != nil {
+			http.Error(w, "Invalid currency: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	status, err := h.budgetService.GetBudgetStatus(r.Context(), r.PathValue("id"), budgets.StatusQuery{On: on, Currency: currency})
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, budgets.ErrBudgetNotFound) {
+		http.Error(w, "Budget not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, budgets.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, exchangerates.ErrRateNotFound) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to get budget status", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(status)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
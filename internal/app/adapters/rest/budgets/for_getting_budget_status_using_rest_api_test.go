@@ -0,0 +1,96 @@
+package budgets
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newBudgetStatusRequest(query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/budgets/1/status"+query, nil)
+	req.SetPathValue("id", "1")
+	return req
+}
+
+// Test for a budget's status via the REST API
+func TestForGettingBudgetStatusUsingRestAPI(t *testing.T) {
+	fakeBudgetService := &FakeBudgetService{}
+	respRecorder := httptest.NewRecorder()
+
+	NewForGettingBudgetStatusUsingRestAPI(fakeBudgetService).ServeHTTP(respRecorder, newBudgetStatusRequest("?date=2024-04-10"))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"Remaining":{"amount":"210.00","currency":"EUR"}`)
+	assert.Contains(t, respRecorder.Body.String(), `"State":"on_track"`)
+	assert.Equal(t, time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), fakeBudgetService.On)
+}
+
+// Test a status without a date is left to default to today
+func TestForGettingBudgetStatusUsingRestAPI_Today(t *testing.T) {
+	fakeBudgetService := &FakeBudgetService{}
+	respRecorder := httptest.NewRecorder()
+
+	NewForGettingBudgetStatusUsingRestAPI(fakeBudgetService).ServeHTTP(respRecorder, newBudgetStatusRequest(""))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.True(t, fakeBudgetService.On.IsZero())
+}
+
+// Test an invalid date is a bad request
+func TestForGettingBudgetStatusUsingRestAPI_BadDate(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForGettingBudgetStatusUsingRestAPI(&FakeBudgetService{}).ServeHTTP(respRecorder, newBudgetStatusRequest("?date=tomorrow"))
+
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+}
+
+// Test a status in another currency passes the currency on, and a missing rate is unprocessable
+func TestForGettingBudgetStatusUsingRestAPI_Currency(t *testing.T) {
+	fakeBudgetService := &FakeBudgetService{}
+	respRecorder := httptest.NewRecorder()
+
+	NewForGettingBudgetStatusUsingRestAPI(fakeBudgetService).ServeHTTP(respRecorder, newBudgetStatusRequest("?currency=USD"))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, money.Currency("USD"), fakeBudgetService.Currency)
+
+	respRecorder = httptest.NewRecorder()
+	NewForGettingBudgetStatusUsingRestAPI(&FakeBudgetService{}).ServeHTTP(respRecorder, newBudgetStatusRequest("?currency=dollars"))
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+
+	respRecorder = httptest.NewRecorder()
+	serviceErr := fmt.Errorf("%w: EUR/USD on 2024-04-02", exchangerates.ErrRateNotFound)
+	NewForGettingBudgetStatusUsingRestAPI(&FakeBudgetService{ReturnErr: serviceErr}).ServeHTTP(respRecorder, newBudgetStatusRequest("?currency=USD"))
+	assert.Equal(t, http.StatusUnprocessableEntity, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), "EUR/USD on 2024-04-02")
+}
+
+// Test the status of a budget that does not exist, and failures
+func TestForGettingBudgetStatusUsingRestAPI_Errors(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+	NewForGettingBudgetStatusUsingRestAPI(&FakeBudgetService{ReturnErr: budgets.ErrBudgetNotFound}).ServeHTTP(respRecorder, newBudgetStatusRequest(""))
+	assert.Equal(t, http.StatusNotFound, respRecorder.Code)
+
+	respRecorder = httptest.NewRecorder()
+	NewForGettingBudgetStatusUsingRestAPI(&FakeBudgetService{ReturnErr: budgets.ErrAccountNotFound}).ServeHTTP(respRecorder, newBudgetStatusRequest(""))
+	assert.Equal(t, http.StatusNotFound, respRecorder.Code)
+
+	respRecorder = httptest.NewRecorder()
+	forbidden := &access.ForbiddenError{AccountID: "12345", Permission: access.PermissionView}
+	NewForGettingBudgetStatusUsingRestAPI(&FakeBudgetService{ReturnErr: forbidden}).ServeHTTP(respRecorder, newBudgetStatusRequest(""))
+	assert.Equal(t, http.StatusForbidden, respRecorder.Code)
+
+	respRecorder = httptest.NewRecorder()
+	NewForGettingBudgetStatusUsingRestAPI(&FakeBudgetService{ReturnErr: errors.New("failed to load expenses")}).ServeHTTP(respRecorder, newBudgetStatusRequest(""))
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
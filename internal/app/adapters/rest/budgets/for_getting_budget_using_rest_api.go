@@ -0,0 +1,50 @@
+package budgets
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/budgets"
+)
+
+// ForGettingBudgetUsingRestAPI is the REST API adapter for retrieving a single budget.
+type ForGettingBudgetUsingRestAPI struct {
+	budgetService budgets.ForGettingBudget
+}
+
+// NewForGettingBudgetUsingRestAPI creates a new REST handler for retrieving budgets.
+func NewForGettingBudgetUsingRestAPI(service budgets.ForGettingBudget) *ForGettingBudgetUsingRestAPI {
+	return &ForGettingBudgetUsingRestAPI{
+		budgetService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for retrieving a budget by the {id} path value.
+func (h *ForGettingBudgetUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	budget, err := h.budgetService.GetBudget(r.Context(), r.PathValue("id"))
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, budgets.ErrBudgetNotFound) {
+		http.Error(w, "Budget not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to get budget", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(budget)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
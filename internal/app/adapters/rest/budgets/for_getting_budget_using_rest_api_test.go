@@ -0,0 +1,57 @@
+package budgets
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/budgets"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newGetBudgetRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/budgets/1", nil)
+	req.SetPathValue("id", "1")
+	return req
+}
+
+// Test for retrieving a budget via the REST API
+func TestForGettingBudgetUsingRestAPI(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForGettingBudgetUsingRestAPI(&FakeBudgetService{}).ServeHTTP(respRecorder, newGetBudgetRequest())
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"Name":"Groceries"`)
+	assert.Contains(t, respRecorder.Body.String(), `"Period":"monthly"`)
+}
+
+// Test retrieving a budget that does not exist
+func TestForGettingBudgetUsingRestAPI_NotFound(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForGettingBudgetUsingRestAPI(&FakeBudgetService{ReturnErr: budgets.ErrBudgetNotFound}).ServeHTTP(respRecorder, newGetBudgetRequest())
+
+	assert.Equal(t, http.StatusNotFound, respRecorder.Code)
+}
+
+// Test getting a budget of an account the caller may not view
+func TestForGettingBudgetUsingRestAPI_Forbidden(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+	forbidden := &access.ForbiddenError{AccountID: "12345", Permission: access.PermissionView}
+
+	NewForGettingBudgetUsingRestAPI(&FakeBudgetService{ReturnErr: forbidden}).ServeHTTP(respRecorder, newGetBudgetRequest())
+
+	assert.Equal(t, http.StatusForbidden, respRecorder.Code)
+}
+
+// Test budget retrieval failure
+func TestForGettingBudgetUsingRestAPI_Failure(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForGettingBudgetUsingRestAPI(&FakeBudgetService{ReturnErr: errors.New("failed to load budget")}).ServeHTTP(respRecorder, newGetBudgetRequest())
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
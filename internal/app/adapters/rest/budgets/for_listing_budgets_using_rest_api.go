@@ -0,0 +1,44 @@
+package budgets
+
+import (
+	"encoding/json"
+	"net/http"
+	"spend-api/internal/domain/budgets"
+)
+
+// ForListingBudgetsUsingRestAPI is the REST API adapter for listing budgets.
+type ForListingBudgetsUsingRestAPI struct {
+	budgetService budgets.ForListingBudgets
+}
+
+// NewForListingBudgetsUsingRestAPI creates a new REST handler for listing budgets.
+func NewForListingBudgetsUsingRestAPI(service budgets.ForListingBudgets) *ForListingBudgetsUsingRestAPI {
+	return &ForListingBudgetsUsingRestAPI{
+		budgetService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for listing budgets.
+func (h *ForListingBudgetsUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := h.budgetService.ListBudgets(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to list budgets", http.StatusInternalServerError)
+		return
+	}
+	if result == nil {
+		result = []*budgets.Budget{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,42 @@
+package budgets
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test for listing budgets via the REST API
+func TestForListingBudgetsUsingRestAPI(t *testing.T) {
+	fakeBudgetService := &FakeBudgetService{Budget: &budgets.Budget{ID: "1", Name: "Groceries", Amount: money.MustParse("400.00", "EUR")}}
+	respRecorder := httptest.NewRecorder()
+
+	NewForListingBudgetsUsingRestAPI(fakeBudgetService).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/budgets", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"Name":"Groceries"`)
+}
+
+// Test listing when there are no budgets returns an empty array
+func TestForListingBudgetsUsingRestAPI_Empty(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForListingBudgetsUsingRestAPI(&FakeBudgetService{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/budgets", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.JSONEq(t, `[]`, respRecorder.Body.String())
+}
+
+// Test budget listing failure
+func TestForListingBudgetsUsingRestAPI_Failure(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForListingBudgetsUsingRestAPI(&FakeBudgetService{ReturnErr: errors.New("failed to load budgets")}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/budgets", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
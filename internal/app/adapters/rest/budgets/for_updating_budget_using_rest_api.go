@@ -0,0 +1,66 @@
+package budgets
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/budgets"
+)
+
+// ForUpdatingBudgetUsingRestAPI is the REST API adapter for updating budgets.
+type ForUpdatingBudgetUsingRestAPI struct {
+	budgetService budgets.ForUpdatingBudget
+}
+
+// NewForUpdatingBudgetUsingRestAPI creates a new REST handler for updating budgets.
+func NewForUpdatingBudgetUsingRestAPI(service budgets.ForUpdatingBudget) *ForUpdatingBudgetUsingRestAPI {
+	return &ForUpdatingBudgetUsingRestAPI{
+		budgetService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for replacing the budget identified by the {id} path value.
+// The body takes the same fields as when creating a budget; fields left out are cleared.
+// As when creating, a budget the service rejects is reported with 422 Unprocessable Entity.
+func (h *ForUpdatingBudgetUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	budget, err := decodeBudget(r)
+	if errors.Is(err, budgets.ErrInvalidBudget) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	budget, err = h.budgetService.UpdateBudget(r.Context(), r.PathValue("id"), budget)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, budgets.ErrBudgetNotFound) {
+		http.Error(w, "Budget not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, budgets.ErrInvalidBudget) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to update budget", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(budget)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
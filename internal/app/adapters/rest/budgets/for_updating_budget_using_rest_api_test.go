@@ -0,0 +1,69 @@
+package budgets
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newUpdateBudgetRequest(body string) *http.Request {
+	req := newBudgetRequest(http.MethodPut, "/budgets/1", body)
+	req.SetPathValue("id", "1")
+	return req
+}
+
+// Test for replacing a budget via the REST API
+func TestForUpdatingBudgetUsingRestAPI(t *testing.T) {
+	fakeBudgetService := &FakeBudgetService{}
+	respRecorder := httptest.NewRecorder()
+
+	NewForUpdatingBudgetUsingRestAPI(fakeBudgetService).ServeHTTP(respRecorder, newUpdateBudgetRequest(`{"name": "Groceries", "amount": 450, "currency": "EUR", "rollover": true}`))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "1", fakeBudgetService.ID)
+	assert.Equal(t, money.MustParse("450.00", "EUR"), fakeBudgetService.Budget.Amount)
+	assert.True(t, fakeBudgetService.Budget.Rollover)
+}
+
+// Test replacing a budget that does not exist
+func TestForUpdatingBudgetUsingRestAPI_NotFound(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForUpdatingBudgetUsingRestAPI(&FakeBudgetService{ReturnErr: budgets.ErrBudgetNotFound}).ServeHTTP(respRecorder, newUpdateBudgetRequest(`{"name": "Food", "amount": "10", "currency": "EUR"}`))
+
+	assert.Equal(t, http.StatusNotFound, respRecorder.Code)
+}
+
+// Test updating a budget of an account the caller may not edit
+func TestForUpdatingBudgetUsingRestAPI_Forbidden(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+	forbidden := &access.ForbiddenError{AccountID: "12345", Permission: access.PermissionEdit}
+
+	NewForUpdatingBudgetUsingRestAPI(&FakeBudgetService{ReturnErr: forbidden}).ServeHTTP(respRecorder, newUpdateBudgetRequest(`{"name": "Food", "amount": "10", "currency": "EUR"}`))
+
+	assert.Equal(t, http.StatusForbidden, respRecorder.Code)
+}
+
+// Test replacing a budget with invalid details
+func TestForUpdatingBudgetUsingRestAPI_Invalid(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForUpdatingBudgetUsingRestAPI(&FakeBudgetService{ReturnErr: budgets.ErrInvalidBudget}).ServeHTTP(respRecorder, newUpdateBudgetRequest(`{"amount": "10", "currency": "EUR"}`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, respRecorder.Code)
+}
+
+// Test budget update failure
+func TestForUpdatingBudgetUsingRestAPI_Failure(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForUpdatingBudgetUsingRestAPI(&FakeBudgetService{ReturnErr: errors.New("failed to modify budget")}).ServeHTTP(respRecorder, newUpdateBudgetRequest(`{"name": "Food", "amount": "10", "currency": "EUR"}`))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
@@ -0,0 +1,62 @@
+package categories
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/categories"
+)
+
+// ForCreatingCategoryUsingRestAPI is the REST API adapter for creating categories.
+type ForCreatingCategoryUsingRestAPI struct {
+	categoryService categories.ForCreatingCategory
+}
+
+// NewForCreatingCategoryUsingRestAPI creates a new REST handler for creating categories.
+func NewForCreatingCategoryUsingRestAPI(service categories.ForCreatingCategory) *ForCreatingCategoryUsingRestAPI {
+	return &ForCreatingCategoryUsingRestAPI{
+		categoryService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for creating a category. An empty or missing
+// parentID creates a top-level category. A category the service rejects, such as
+// one without a name or under an unknown parent, is reported with 422 Unprocessable Entity.
+func (h *ForCreatingCategoryUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		Name     string `json:"name"`
+		ParentID string `json:"parentID"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(r.Context(), requestBody.Name, requestBody.ParentID)
+	if errors.Is(err, categories.ErrInvalidCategory) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if errors.Is(err, categories.ErrDuplicateCategory) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to create category", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(map[string]string{"id": category.ID})
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
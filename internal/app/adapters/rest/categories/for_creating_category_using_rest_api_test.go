@@ -0,0 +1,112 @@
+package categories
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/categories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeCategoryService simulates the category service for testing.
+type FakeCategoryService struct {
+	ReturnErr error
+	Name      *string
+	ParentID  *string
+}
+
+func (f *FakeCategoryService) CreateCategory(ctx context.Context, name, parentID string) (*categories.Category, error) {
+	f.Name, f.ParentID = &name, &parentID
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return categories.NewCategory("5", name, parentID), nil
+}
+
+func (f *FakeCategoryService) GetCategory(ctx context.Context, id string) (*categories.Category, error) {
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return categories.NewCategory(id, "Groceries", "1"), nil
+}
+
+func (f *FakeCategoryService) ListCategories(ctx context.Context) ([]*categories.Category, error) {
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return nil, nil
+}
+
+func (f *FakeCategoryService) UpdateCategory(ctx context.Context, id string, name, parentID *string) (*categories.Category, error) {
+	f.Name, f.ParentID = name, parentID
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	category := categories.NewCategory(id, "Groceries", "1")
+	if name != nil {
+		category.Name = *name
+	}
+	if parentID != nil {
+		category.ParentID = *parentID
+	}
+	return category, nil
+}
+
+func (f *FakeCategoryService) DeleteCategory(ctx context.Context, id string) error {
+	return f.ReturnErr
+}
+
+func newCreateCategoryRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/categories", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+// Test for creating a subcategory via the REST API
+func TestForCreatingCategoryUsingRestAPI(t *testing.T) {
+	fakeCategoryService := &FakeCategoryService{}
+	apiHandler := NewForCreatingCategoryUsingRestAPI(fakeCategoryService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newCreateCategoryRequest(`{"name":"Groceries","parentID":"1"}`))
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code)
+	assert.JSONEq(t, `{"id":"5"}`, respRecorder.Body.String())
+	assert.Equal(t, "Groceries", *fakeCategoryService.Name)
+	assert.Equal(t, "1", *fakeCategoryService.ParentID)
+}
+
+// Test domain errors map onto client errors
+func TestForCreatingCategoryUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		categories.ErrInvalidCategory:   http.StatusUnprocessableEntity,
+		categories.ErrDuplicateCategory: http.StatusConflict,
+		errors.New("database down"):     http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		apiHandler := NewForCreatingCategoryUsingRestAPI(&FakeCategoryService{ReturnErr: serviceErr})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newCreateCategoryRequest(`{"name":"Groceries"}`))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
+
+// Test for invalid JSON and HTTP method
+func TestForCreatingCategoryUsingRestAPI_BadRequests(t *testing.T) {
+	apiHandler := NewForCreatingCategoryUsingRestAPI(&FakeCategoryService{})
+
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, newCreateCategoryRequest(`{"name":`))
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+
+	respRecorder = httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/categories", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
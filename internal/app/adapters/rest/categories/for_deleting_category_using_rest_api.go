@@ -0,0 +1,43 @@
+package categories
+
+import (
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/categories"
+)
+
+// ForDeletingCategoryUsingRestAPI is the REST API adapter for deleting categories.
+type ForDeletingCategoryUsingRestAPI struct {
+	categoryService categories.ForDeletingCategory
+}
+
+// NewForDeletingCategoryUsingRestAPI creates a new REST handler for deleting categories.
+func NewForDeletingCategoryUsingRestAPI(service categories.ForDeletingCategory) *ForDeletingCategoryUsingRestAPI {
+	return &ForDeletingCategoryUsingRestAPI{
+		categoryService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for deleting the category identified by the {id} path value.
+func (h *ForDeletingCategoryUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := h.categoryService.DeleteCategory(r.Context(), r.PathValue("id"))
+	if errors.Is(err, categories.ErrCategoryNotFound) {
+		http.Error(w, "Category not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, categories.ErrCategoryInUse) {
+		http.Error(w, "Category still has subcategories, transactions or rules and cannot be deleted", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to delete category", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,45 @@
+package categories
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/categories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDeleteCategoryRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodDelete, "/categories/2", nil)
+	req.SetPathValue("id", "2")
+	return req
+}
+
+// Test for deleting a category via the REST API
+func TestForDeletingCategoryUsingRestAPI(t *testing.T) {
+	apiHandler := NewForDeletingCategoryUsingRestAPI(&FakeCategoryService{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newDeleteCategoryRequest())
+
+	assert.Equal(t, http.StatusNoContent, respRecorder.Code)
+}
+
+// Test domain errors map onto client errors
+func TestForDeletingCategoryUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		categories.ErrCategoryNotFound: http.StatusNotFound,
+		categories.ErrCategoryInUse:    http.StatusConflict,
+		errors.New("database down"):    http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		apiHandler := NewForDeletingCategoryUsingRestAPI(&FakeCategoryService{ReturnErr: serviceErr})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newDeleteCategoryRequest())
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
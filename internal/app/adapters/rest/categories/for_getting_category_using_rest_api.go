@@ -0,0 +1,46 @@
+package categories
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/categories"
+)
+
+// ForGettingCategoryUsingRestAPI is the REST API adapter for retrieving a single category.
+type ForGettingCategoryUsingRestAPI struct {
+	categoryService categories.ForGettingCategory
+}
+
+// NewForGettingCategoryUsingRestAPI creates a new REST handler for retrieving categories.
+func NewForGettingCategoryUsingRestAPI(service categories.ForGettingCategory) *ForGettingCategoryUsingRestAPI {
+	return &ForGettingCategoryUsingRestAPI{
+		categoryService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for retrieving a category by the {id} path value.
+func (h *ForGettingCategoryUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	category, err := h.categoryService.GetCategory(r.Context(), r.PathValue("id"))
+	if errors.Is(err, categories.ErrCategoryNotFound) {
+		http.Error(w, "Category not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to get category", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(category)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
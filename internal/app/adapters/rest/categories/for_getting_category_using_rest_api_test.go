@@ -0,0 +1,45 @@
+package categories
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/categories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newGetCategoryRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/categories/2", nil)
+	req.SetPathValue("id", "2")
+	return req
+}
+
+// Test for retrieving a category via the REST API
+func TestForGettingCategoryUsingRestAPI(t *testing.T) {
+	apiHandler := NewForGettingCategoryUsingRestAPI(&FakeCategoryService{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newGetCategoryRequest())
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.JSONEq(t, `{"ID":"2","Name":"Groceries","ParentID":"1"}`, respRecorder.Body.String())
+}
+
+// Test for retrieving a category that does not exist, and service failure
+func TestForGettingCategoryUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		categories.ErrCategoryNotFound: http.StatusNotFound,
+		errors.New("database down"):    http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		apiHandler := NewForGettingCategoryUsingRestAPI(&FakeCategoryService{ReturnErr: serviceErr})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newGetCategoryRequest())
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
@@ -0,0 +1,45 @@
+package categories
+
+import (
+	"encoding/json"
+	"net/http"
+	"spend-api/internal/domain/categories"
+)
+
+// ForListingCategoriesUsingRestAPI is the REST API adapter for listing categories.
+type ForListingCategoriesUsingRestAPI struct {
+	categoryService categories.ForListingCategories
+}
+
+// NewForListingCategoriesUsingRestAPI creates a new REST handler for listing categories.
+func NewForListingCategoriesUsingRestAPI(service categories.ForListingCategories) *ForListingCategoriesUsingRestAPI {
+	return &ForListingCategoriesUsingRestAPI{
+		categoryService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for listing categories. The whole taxonomy is
+// returned as a flat list; each category names its parent.
+func (h *ForListingCategoriesUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := h.categoryService.ListCategories(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to list categories", http.StatusInternalServerError)
+		return
+	}
+	if result == nil {
+		result = []*categories.Category{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,31 @@
+package categories
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test an empty taxonomy is listed as an empty array
+func TestForListingCategoriesUsingRestAPI_Empty(t *testing.T) {
+	apiHandler := NewForListingCategoriesUsingRestAPI(&FakeCategoryService{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/categories", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.JSONEq(t, `[]`, respRecorder.Body.String())
+}
+
+// Test listing failure
+func TestForListingCategoriesUsingRestAPI_Error(t *testing.T) {
+	apiHandler := NewForListingCategoriesUsingRestAPI(&FakeCategoryService{ReturnErr: errors.New("database down")})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/categories", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
@@ -0,0 +1,66 @@
+package categories
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/categories"
+)
+
+// ForUpdatingCategoryUsingRestAPI is the REST API adapter for updating categories.
+type ForUpdatingCategoryUsingRestAPI struct {
+	categoryService categories.ForUpdatingCategory
+}
+
+// NewForUpdatingCategoryUsingRestAPI creates a new REST handler for updating categories.
+func NewForUpdatingCategoryUsingRestAPI(service categories.ForUpdatingCategory) *ForUpdatingCategoryUsingRestAPI {
+	return &ForUpdatingCategoryUsingRestAPI{
+		categoryService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for updating the category identified by the {id} path value.
+// Only the fields present in the body change; a parentID of "" moves the category to the top level.
+// Changes the service rejects, such as an unknown parent, are reported with 422 Unprocessable Entity.
+func (h *ForUpdatingCategoryUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		Name     *string `json:"name"`
+		ParentID *string `json:"parentID"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	category, err := h.categoryService.UpdateCategory(r.Context(), r.PathValue("id"), requestBody.Name, requestBody.ParentID)
+	if errors.Is(err, categories.ErrCategoryNotFound) {
+		http.Error(w, "Category not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, categories.ErrInvalidCategory) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if errors.Is(err, categories.ErrDuplicateCategory) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to update category", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(category)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
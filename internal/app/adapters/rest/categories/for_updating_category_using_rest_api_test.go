@@ -0,0 +1,65 @@
+package categories
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/categories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newUpdateCategoryRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPatch, "/categories/2", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "2")
+	return req
+}
+
+// Test moving a category to the top level leaves its name alone
+func TestForUpdatingCategoryUsingRestAPI(t *testing.T) {
+	fakeCategoryService := &FakeCategoryService{}
+	apiHandler := NewForUpdatingCategoryUsingRestAPI(fakeCategoryService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newUpdateCategoryRequest(`{"parentID":""}`))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Nil(t, fakeCategoryService.Name, "A missing name should be left unchanged")
+	assert.Equal(t, "", *fakeCategoryService.ParentID)
+	assert.JSONEq(t, `{"ID":"2","Name":"Groceries","ParentID":""}`, respRecorder.Body.String())
+}
+
+// Test domain errors map onto client errors
+func TestForUpdatingCategoryUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		categories.ErrCategoryNotFound:  http.StatusNotFound,
+		categories.ErrInvalidCategory:   http.StatusUnprocessableEntity,
+		categories.ErrDuplicateCategory: http.StatusConflict,
+		errors.New("database down"):     http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		apiHandler := NewForUpdatingCategoryUsingRestAPI(&FakeCategoryService{ReturnErr: serviceErr})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newUpdateCategoryRequest(`{"name":"Food"}`))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
+
+// Test for invalid JSON and HTTP method
+func TestForUpdatingCategoryUsingRestAPI_BadRequests(t *testing.T) {
+	apiHandler := NewForUpdatingCategoryUsingRestAPI(&FakeCategoryService{})
+
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, newUpdateCategoryRequest(`not json`))
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+
+	respRecorder = httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodPut, "/categories/2", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
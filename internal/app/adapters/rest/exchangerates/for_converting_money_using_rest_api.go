@@ -0,0 +1,73 @@
+package exchangerates
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// ForConvertingMoneyUsingRestAPI is the REST API adapter for converting amounts between currencies.
+type ForConvertingMoneyUsingRestAPI struct {
+	rateService exchangerates.ForConvertingMoney
+}
+
+// NewForConvertingMoneyUsingRestAPI creates a new REST handler for converting amounts between currencies.
+func NewForConvertingMoneyUsingRestAPI(service exchangerates.ForConvertingMoney) *ForConvertingMoneyUsingRestAPI {
+	return &ForConvertingMoneyUsingRestAPI{
+		rateService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for converting an amount. The query
+// parameters amount, from and to are required; date defaults to today.
+func (h *ForConvertingMoneyUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	from, err := money.ParseCurrency(query.Get("from"))
+	if err != nil {
+		http.Error(w, "Invalid from: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err := money.ParseCurrency(query.Get("to"))
+	if err != nil {
+		http.Error(w, "Invalid to: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	amount, err := money.Parse(query.Get("amount"), from)
+	if err != nil {
+		http.Error(w, "Invalid amount: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	on := time.Now().UTC().Truncate(24 * time.Hour)
+	if value := query.Get("date"); value != "" {
+		on, err = time.Parse(dateLayout, value)
+		if err != nil {
+			http.Error(w, "Invalid date: expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+
+	converted, err := h.rateService.Convert(r.Context(), amount, to, on)
+	if errors.Is(err, exchangerates.ErrRateNotFound) {
+		http.Error(w, "Exchange rate not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to convert amount", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(converted)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,90 @@
+package exchangerates
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForConvertingMoney simulates the exchange rate service for testing.
+type FakeForConvertingMoney struct {
+	ReturnErr error
+	Amount    money.Money
+	On        time.Time
+}
+
+func (f *FakeForConvertingMoney) Convert(ctx context.Context, amount money.Money, to money.Currency, on time.Time) (money.Money, error) {
+	f.Amount, f.On = amount, on
+	if f.ReturnErr != nil {
+		return money.Money{}, f.ReturnErr
+	}
+	return money.MustParse("109.45", to), nil
+}
+
+// Test converting an amount on a given day
+func TestForConvertingMoneyUsingRestAPI(t *testing.T) {
+	fakeRateService := &FakeForConvertingMoney{}
+	apiHandler := NewForConvertingMoneyUsingRestAPI(fakeRateService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/exchange-rates/convert?amount=100&from=EUR&to=USD&date=2024-01-02", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.JSONEq(t, `{"amount":"109.45","currency":"USD"}`, respRecorder.Body.String())
+	assert.Equal(t, money.MustParse("100.00", "EUR"), fakeRateService.Amount)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), fakeRateService.On)
+}
+
+// Test for invalid HTTP method
+func TestForConvertingMoneyUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForConvertingMoneyUsingRestAPI(&FakeForConvertingMoney{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodPost, "/exchange-rates/convert", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
+
+// Test invalid query parameters are bad requests
+func TestForConvertingMoneyUsingRestAPI_InvalidQuery(t *testing.T) {
+	for _, query := range []string{
+		"amount=100&to=USD",
+		"amount=100&from=EUR",
+		"amount=abc&from=EUR&to=USD",
+		"amount=100&from=EUR&to=USD&date=02/01/2024",
+	} {
+		apiHandler := NewForConvertingMoneyUsingRestAPI(&FakeForConvertingMoney{})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/exchange-rates/convert?"+query, nil))
+
+		assert.Equal(t, http.StatusBadRequest, respRecorder.Code, query)
+	}
+}
+
+// Test converting without a known rate
+func TestForConvertingMoneyUsingRestAPI_RateNotFound(t *testing.T) {
+	apiHandler := NewForConvertingMoneyUsingRestAPI(&FakeForConvertingMoney{ReturnErr: exchangerates.ErrRateNotFound})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/exchange-rates/convert?amount=100&from=EUR&to=USD", nil))
+
+	assert.Equal(t, http.StatusNotFound, respRecorder.Code)
+}
+
+// Test for internal server error from the exchange rate service
+func TestForConvertingMoneyUsingRestAPI_ServiceError(t *testing.T) {
+	apiHandler := NewForConvertingMoneyUsingRestAPI(&FakeForConvertingMoney{ReturnErr: errors.New("failed to load rate")})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/exchange-rates/convert?amount=100&from=EUR&to=USD", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
@@ -0,0 +1,124 @@
+package exchangerates
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/domain/money"
+	"strings"
+	"time"
+)
+
+// dateLayout is the format of the dates in the imported CSV
+const dateLayout = "2006-01-02"
+
+// importHeader is the header row an imported CSV must start with
+var importHeader = []string{"date", "base", "quote", "rate"}
+
+// ForImportingRatesUsingRestAPI is the REST API adapter for importing exchange rates.
+type ForImportingRatesUsingRestAPI struct {
+	rateService exchangerates.ForImportingRates
+}
+
+// NewForImportingRatesUsingRestAPI creates a new REST handler for importing exchange rates.
+func NewForImportingRatesUsingRestAPI(service exchangerates.ForImportingRates) *ForImportingRatesUsingRestAPI {
+	return &ForImportingRatesUsingRestAPI{
+		rateService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for importing exchange rates. The body is a
+// CSV document with the header "date,base,quote,rate", one rate per line.
+func (h *ForImportingRatesUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rates, err := parseRates(r.Body)
+	if err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.rateService.ImportRates(r.Context(), rates)
+	if errors.Is(err, exchangerates.ErrInvalidRate) {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to import exchange rates", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(map[string]int{"imported": len(rates)})
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// parseRates reads the CSV body, reporting the line of the first bad record
+func parseRates(body io.Reader) ([]*exchangerates.Rate, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = len(importHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing header: %w", err)
+	}
+	for i, column := range importHeader {
+		if !strings.EqualFold(strings.TrimSpace(header[i]), column) {
+			return nil, fmt.Errorf("header must be %q", strings.Join(importHeader, ","))
+		}
+	}
+
+	var rates []*exchangerates.Rate
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		rate, err := parseRate(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if len(rates) == 0 {
+		return nil, errors.New("no rates to import")
+	}
+	return rates, nil
+}
+
+func parseRate(record []string) (*exchangerates.Rate, error) {
+	date, err := time.Parse(dateLayout, record[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", record[0])
+	}
+	base, err := money.ParseCurrency(record[1])
+	if err != nil {
+		return nil, err
+	}
+	quote, err := money.ParseCurrency(record[2])
+	if err != nil {
+		return nil, err
+	}
+	value, err := money.ParseDecimal(record[3])
+	if err != nil {
+		return nil, err
+	}
+	return exchangerates.NewRate(base, quote, date, value), nil
+}
@@ -0,0 +1,102 @@
+package exchangerates
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/exchangerates"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForImportingRates simulates the exchange rate service for testing.
+type FakeForImportingRates struct {
+	ReturnErr error
+	Rates     []*exchangerates.Rate
+}
+
+func (f *FakeForImportingRates) ImportRates(ctx context.Context, rates []*exchangerates.Rate) error {
+	f.Rates = rates
+	return f.ReturnErr
+}
+
+func newImportRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/exchange-rates/imports", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	return req
+}
+
+// Test importing rates from a CSV body
+func TestForImportingRatesUsingRestAPI(t *testing.T) {
+	fakeRateService := &FakeForImportingRates{}
+	apiHandler := NewForImportingRatesUsingRestAPI(fakeRateService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newImportRequest("date,base,quote,rate\n2024-01-02,EUR,USD,1.0945\n2024-01-02, gbp, eur, 1.1512\n"))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.JSONEq(t, `{"imported":2}`, respRecorder.Body.String())
+	assert.Len(t, fakeRateService.Rates, 2)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), fakeRateService.Rates[1].Date)
+	assert.Equal(t, "GBP", string(fakeRateService.Rates[1].Base))
+	assert.Equal(t, "1.1512", fakeRateService.Rates[1].Value.String())
+}
+
+// Test for invalid HTTP method
+func TestForImportingRatesUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForImportingRatesUsingRestAPI(&FakeForImportingRates{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/exchange-rates/imports", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
+
+// Test malformed CSV bodies are rejected with the offending line
+func TestForImportingRatesUsingRestAPI_InvalidBody(t *testing.T) {
+	cases := map[string]string{
+		"": "missing header",
+		"day,from,to,value\n2024-01-02,EUR,USD,1.09\n":                          "header must be",
+		"date,base,quote,rate\n":                                                "no rates to import",
+		"date,base,quote,rate\n02/01/2024,EUR,USD,1.09":                         "line 2: invalid date",
+		"date,base,quote,rate\n2024-01-02,EUR,XYZ,1.09":                         "line 2: unknown currency",
+		"date,base,quote,rate\n2024-01-02,EUR,USD,1.09\n2024-01-03,EUR,USD,abc": "line 3:",
+		"date,base,quote,rate\n2024-01-02,EUR,USD":                              "wrong number of fields",
+	}
+
+	for body, message := range cases {
+		fakeRateService := &FakeForImportingRates{}
+		apiHandler := NewForImportingRatesUsingRestAPI(fakeRateService)
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newImportRequest(body))
+
+		assert.Equal(t, http.StatusBadRequest, respRecorder.Code, body)
+		assert.Contains(t, respRecorder.Body.String(), message, body)
+		assert.Nil(t, fakeRateService.Rates, "The service should not be called")
+	}
+}
+
+// Test rates rejected by the service are bad requests
+func TestForImportingRatesUsingRestAPI_InvalidRate(t *testing.T) {
+	apiHandler := NewForImportingRatesUsingRestAPI(&FakeForImportingRates{ReturnErr: exchangerates.ErrInvalidRate})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newImportRequest("date,base,quote,rate\n2024-01-02,EUR,EUR,1\n"))
+
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+}
+
+// Test for internal server error from the exchange rate service
+func TestForImportingRatesUsingRestAPI_ServiceError(t *testing.T) {
+	apiHandler := NewForImportingRatesUsingRestAPI(&FakeForImportingRates{ReturnErr: errors.New("failed to save rates")})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newImportRequest("date,base,quote,rate\n2024-01-02,EUR,USD,1.09\n"))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
@@ -0,0 +1,146 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/idempotency"
+)
+
+const (
+	// keyHeader carries the client's idempotency key
+	keyHeader = "Idempotency-Key"
+	// replayedHeader marks a response replayed from an earlier request
+	replayedHeader = "Idempotent-Replayed"
+	// maxBodySize bounds the body read into memory to fingerprint a request,
+	// large enough for the biggest create request, a statement upload
+	maxBodySize = 10 << 20
+)
+
+// ForHandlingIdempotentRequestsUsingRestAPI is the REST API adapter that makes
+// create requests safe to retry. It wraps the handler of a create endpoint.
+type ForHandlingIdempotentRequestsUsingRestAPI struct {
+	idempotencyService idempotency.ForHandlingIdempotentRequests
+	next               http.Handler
+}
+
+// NewForHandlingIdempotentRequestsUsingRestAPI creates a new REST handler that
+// passes requests on to next, replaying its responses to retried requests.
+func NewForHandlingIdempotentRequestsUsingRestAPI(service idempotency.ForHandlingIdempotentRequests, next http.Handler) *ForHandlingIdempotentRequestsUsingRestAPI {
+	return &ForHandlingIdempotentRequestsUsingRestAPI{
+		idempotencyService: service,
+		next:               next,
+	}
+}
+
+// ServeHTTP handles POST requests carrying an Idempotency-Key header, replaying the stored
+// response to retries of the same request from the same principal. Reusing a key for another
+// request fails with 422, retrying too early with 409, and bodies over 10 MiB with 413.
+func (h *ForHandlingIdempotentRequestsUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get(keyHeader)
+	if r.Method != http.MethodPost || key == "" {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	stored, err := h.idempotencyService.Begin(r.Context(), key, fingerprint(r, body))
+	if errors.Is(err, idempotency.ErrInvalidKey) {
+		http.Error(w, "Invalid Idempotency-Key, expected 1 to 255 characters", http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, idempotency.ErrKeyReused) {
+		http.Error(w, "Idempotency-Key was already used for a different request", http.StatusUnprocessableEntity)
+		return
+	}
+	if errors.Is(err, idempotency.ErrRequestInProgress) {
+		http.Error(w, "A request with this Idempotency-Key is still in progress", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to check Idempotency-Key", http.StatusInternalServerError)
+		return
+	}
+	if stored != nil {
+		if stored.ContentType != "" {
+			w.Header().Set("Content-Type", stored.ContentType)
+		}
+		w.Header().Set(replayedHeader, "true")
+		w.WriteHeader(stored.StatusCode)
+		_, _ = w.Write(stored.Body)
+		return
+	}
+
+	// The response is stored even if the client has gone away, so its retry
+	// finds it. Should the handler panic, the key is released instead.
+	ctx := context.WithoutCancel(r.Context())
+	recorder := &responseRecorder{ResponseWriter: w}
+	completed := false
+	defer func() {
+		if !completed {
+			_ = h.idempotencyService.Complete(ctx, key, &idempotency.Response{StatusCode: http.StatusInternalServerError})
+		}
+	}()
+	h.next.ServeHTTP(recorder, r)
+	completed = true
+	_ = h.idempotencyService.Complete(ctx, key, recorder.response())
+}
+
+// fingerprint identifies a request by its method, path and body, and by who
+// made it, so one caller's key never replays another caller's response
+func fingerprint(r *http.Request, body []byte) string {
+	digest := sha256.New()
+	if principal, ok := auth.PrincipalFrom(r.Context()); ok {
+		_, _ = io.WriteString(digest, principal.Subject+"\n")
+	}
+	_, _ = io.WriteString(digest, r.Method+" "+r.URL.RequestURI()+"\n")
+	_, _ = digest.Write(body)
+	return hex.EncodeToString(digest.Sum(nil))
+}
+
+// responseRecorder passes a response on to the client while keeping a copy of it
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+// response returns the copy of what was sent
+func (r *responseRecorder) response() *idempotency.Response {
+	statusCode := r.statusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	return &idempotency.Response{StatusCode: statusCode, ContentType: r.Header().Get("Content-Type"), Body: r.body.Bytes()}
+}
@@ -0,0 +1,185 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/idempotency"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeIdempotencyService simulates the idempotency service for testing.
+type FakeIdempotencyService struct {
+	Stored       *idempotency.Response
+	ReturnErr    error
+	Key          string
+	Fingerprints []string
+	Completed    *idempotency.Response
+}
+
+func (f *FakeIdempotencyService) Begin(ctx context.Context, key, fingerprint string) (*idempotency.Response, error) {
+	f.Key = key
+	f.Fingerprints = append(f.Fingerprints, fingerprint)
+	return f.Stored, f.ReturnErr
+}
+
+func (f *FakeIdempotencyService) Complete(ctx context.Context, key string, response *idempotency.Response) error {
+	f.Completed = response
+	return nil
+}
+
+// createHandler stands in for a create endpoint, counting how often it runs
+type createHandler struct {
+	Calls int
+	Body  string
+}
+
+func (h *createHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.Calls++
+	body, _ := io.ReadAll(r.Body)
+	h.Body = string(body)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write([]byte(`{"ID":"1"}`))
+}
+
+func postWithKey(handler http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	respRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(respRecorder, req)
+	return respRecorder
+}
+
+// Test the first request with a key is handled and its response stored
+func TestForHandlingIdempotentRequestsUsingRestAPI_FirstRequest(t *testing.T) {
+	fakeService := &FakeIdempotencyService{}
+	next := &createHandler{}
+	apiHandler := NewForHandlingIdempotentRequestsUsingRestAPI(fakeService, next)
+
+	respRecorder := postWithKey(apiHandler, "abc", `{"amount":"1.00"}`)
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code)
+	assert.Equal(t, `{"ID":"1"}`, respRecorder.Body.String())
+	assert.Equal(t, 1, next.Calls)
+	assert.Equal(t, `{"amount":"1.00"}`, next.Body, "The handler should still see the whole body")
+	assert.Equal(t, "abc", fakeService.Key)
+	assert.Equal(t, &idempotency.Response{StatusCode: http.StatusCreated, ContentType: "application/json", Body: []byte(`{"ID":"1"}`)}, fakeService.Completed)
+}
+
+// Test a retry gets the stored response without being handled again
+func TestForHandlingIdempotentRequestsUsingRestAPI_Replay(t *testing.T) {
+	fakeService := &FakeIdempotencyService{Stored: &idempotency.Response{StatusCode: http.StatusCreated, ContentType: "application/json", Body: []byte(`{"ID":"1"}`)}}
+	next := &createHandler{}
+	apiHandler := NewForHandlingIdempotentRequestsUsingRestAPI(fakeService, next)
+
+	respRecorder := postWithKey(apiHandler, "abc", `{"amount":"1.00"}`)
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code)
+	assert.Equal(t, `{"ID":"1"}`, respRecorder.Body.String())
+	assert.Equal(t, "application/json", respRecorder.Header().Get("Content-Type"))
+	assert.Equal(t, "true", respRecorder.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 0, next.Calls)
+	assert.Nil(t, fakeService.Completed)
+}
+
+// Test requests are fingerprinted by their body, so a changed body is told apart
+func TestForHandlingIdempotentRequestsUsingRestAPI_Fingerprint(t *testing.T) {
+	fakeService := &FakeIdempotencyService{}
+	apiHandler := NewForHandlingIdempotentRequestsUsingRestAPI(fakeService, &createHandler{})
+
+	postWithKey(apiHandler, "abc", `{"amount":"1.00"}`)
+	postWithKey(apiHandler, "abc", `{"amount":"1.00"}`)
+	postWithKey(apiHandler, "abc", `{"amount":"2.00"}`)
+
+	assert.Len(t, fakeService.Fingerprints[0], 64)
+	assert.Equal(t, fakeService.Fingerprints[0], fakeService.Fingerprints[1])
+	assert.NotEqual(t, fakeService.Fingerprints[0], fakeService.Fingerprints[2])
+}
+
+// Test the same request from another principal is told apart
+func TestForHandlingIdempotentRequestsUsingRestAPI_FingerprintPrincipal(t *testing.T) {
+	fakeService := &FakeIdempotencyService{}
+	apiHandler := NewForHandlingIdempotentRequestsUsingRestAPI(fakeService, &createHandler{})
+
+	for _, subject := range []string{"alice", "alice", "bob"} {
+		req := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(`{}`))
+		req.Header.Set("Idempotency-Key", "abc")
+		req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Subject: subject}))
+		apiHandler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, fakeService.Fingerprints[0], fakeService.Fingerprints[1])
+	assert.NotEqual(t, fakeService.Fingerprints[0], fakeService.Fingerprints[2])
+}
+
+// Test key conflicts and failures map onto their status codes without running the handler
+func TestForHandlingIdempotentRequestsUsingRestAPI_Errors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code int
+	}{
+		"invalid key": {idempotency.ErrInvalidKey, http.StatusBadRequest},
+		"key reused":  {idempotency.ErrKeyReused, http.StatusUnprocessableEntity},
+		"in progress": {idempotency.ErrRequestInProgress, http.StatusConflict},
+		"failure":     {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			next := &createHandler{}
+			apiHandler := NewForHandlingIdempotentRequestsUsingRestAPI(&FakeIdempotencyService{ReturnErr: tt.err}, next)
+
+			respRecorder := postWithKey(apiHandler, "abc", `{}`)
+
+			assert.Equal(t, tt.code, respRecorder.Code)
+			assert.Equal(t, 0, next.Calls)
+		})
+	}
+}
+
+// Test an oversized body is rejected before it is buffered in full or handled
+func TestForHandlingIdempotentRequestsUsingRestAPI_TooLarge(t *testing.T) {
+	fakeService := &FakeIdempotencyService{}
+	next := &createHandler{}
+	apiHandler := NewForHandlingIdempotentRequestsUsingRestAPI(fakeService, next)
+
+	respRecorder := postWithKey(apiHandler, "abc", strings.Repeat("x", maxBodySize+1))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, respRecorder.Code)
+	assert.Equal(t, 0, next.Calls)
+	assert.Empty(t, fakeService.Fingerprints, "The key should not be claimed")
+}
+
+// Test requests without a key, and requests other than POST, are passed straight on
+func TestForHandlingIdempotentRequestsUsingRestAPI_PassThrough(t *testing.T) {
+	fakeService := &FakeIdempotencyService{}
+	next := &createHandler{}
+	apiHandler := NewForHandlingIdempotentRequestsUsingRestAPI(fakeService, next)
+
+	postWithKey(apiHandler, "", `{}`)
+	req := httptest.NewRequest(http.MethodGet, "/transactions", nil)
+	req.Header.Set("Idempotency-Key", "abc")
+	apiHandler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, 2, next.Calls)
+	assert.Empty(t, fakeService.Fingerprints)
+}
+
+// Test a handler that panics releases the key so the request can be retried
+func TestForHandlingIdempotentRequestsUsingRestAPI_Panic(t *testing.T) {
+	fakeService := &FakeIdempotencyService{}
+	apiHandler := NewForHandlingIdempotentRequestsUsingRestAPI(fakeService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	assert.Panics(t, func() { postWithKey(apiHandler, "abc", `{}`) })
+	assert.Equal(t, http.StatusInternalServerError, fakeService.Completed.StatusCode)
+}
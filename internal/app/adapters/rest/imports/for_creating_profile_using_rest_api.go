@@ -0,0 +1,76 @@
+package imports
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/imports"
+)
+
+// ForCreatingProfileUsingRestAPI is the REST API adapter for creating import profiles.
+type ForCreatingProfileUsingRestAPI struct {
+	importService imports.ForCreatingProfile
+}
+
+// NewForCreatingProfileUsingRestAPI creates a new REST handler for creating import profiles.
+func NewForCreatingProfileUsingRestAPI(service imports.ForCreatingProfile) *ForCreatingProfileUsingRestAPI {
+	return &ForCreatingProfileUsingRestAPI{
+		importService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for creating an import profile. Column indices count from zero;
+// give either amountColumn or both debitColumn and creditColumn.
+func (h *ForCreatingProfileUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		Name              string `json:"name"`
+		Delimiter         string `json:"delimiter"`
+		SkipRows          int    `json:"skipRows"`
+		DateColumn        int    `json:"dateColumn"`
+		DateFormat        string `json:"dateFormat"`
+		DescriptionColumn *int   `json:"descriptionColumn"`
+		AmountColumn      *int   `json:"amountColumn"`
+		DebitColumn       *int   `json:"debitColumn"`
+		CreditColumn      *int   `json:"creditColumn"`
+		DecimalSeparator  string `json:"decimalSeparator"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.importService.CreateProfile(r.Context(), &imports.Profile{
+		Name:              requestBody.Name,
+		Delimiter:         requestBody.Delimiter,
+		SkipRows:          requestBody.SkipRows,
+		DateColumn:        requestBody.DateColumn,
+		DateFormat:        requestBody.DateFormat,
+		DescriptionColumn: requestBody.DescriptionColumn,
+		AmountColumn:      requestBody.AmountColumn,
+		DebitColumn:       requestBody.DebitColumn,
+		CreditColumn:      requestBody.CreditColumn,
+		DecimalSeparator:  requestBody.DecimalSeparator,
+	})
+	if errors.Is(err, imports.ErrInvalidProfile) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to create import profile", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(profile)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
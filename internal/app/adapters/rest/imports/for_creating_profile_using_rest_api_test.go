@@ -0,0 +1,132 @@
+package imports
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/imports"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeImportService simulates the import service for testing.
+type FakeImportService struct {
+	ReturnErr error
+	Profile   *imports.Profile
+	Profiles  []*imports.Profile
+	ID        string
+	AccountID string
+	Format    imports.Format
+	Data      string
+}
+
+func (f *FakeImportService) CreateProfile(ctx context.Context, profile *imports.Profile) (*imports.Profile, error) {
+	f.Profile = profile
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	profile.ID = "1"
+	return profile, nil
+}
+
+func (f *FakeImportService) ListProfiles(ctx context.Context) ([]*imports.Profile, error) {
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return f.Profiles, nil
+}
+
+func (f *FakeImportService) DeleteProfile(ctx context.Context, id string) error {
+	f.ID = id
+	return f.ReturnErr
+}
+
+func (f *FakeImportService) ImportCSV(ctx context.Context, accountID, profileID string, data io.Reader) (*imports.Report, error) {
+	f.AccountID, f.ID = accountID, profileID
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+	f.Data = string(content)
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return &imports.Report{AccountID: accountID, Format: imports.FormatCSV, ProfileID: profileID, Imported: 1, Skipped: 1, Rows: []imports.RowResult{
+		{Line: 1, Status: imports.RowImported, TransactionID: "7"},
+		{Line: 2, Status: imports.RowSkipped, Message: "blank line"},
+	}}, nil
+}
+
+func (f *FakeImportService) ImportStatement(ctx context.Context, accountID string, format imports.Format, data io.Reader) (*imports.Report, error) {
+	f.AccountID, f.Format = accountID, format
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+	f.Data = string(content)
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	balance := money.MustParse("996.75", "EUR")
+	date := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	return &imports.Report{AccountID: accountID, Format: imports.FormatOFX, Imported: 1, Rows: []imports.RowResult{
+		{Line: 12, Status: imports.RowImported, TransactionID: "7"},
+	}, LedgerBalance: &balance, LedgerBalanceDate: &date, ComputedBalance: &balance}, nil
+}
+
+func newCreateProfileRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/import-profiles", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+// Test for creating an import profile via the REST API
+func TestForCreatingProfileUsingRestAPI(t *testing.T) {
+	fakeImportService := &FakeImportService{}
+	apiHandler := NewForCreatingProfileUsingRestAPI(fakeImportService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newCreateProfileRequest(`{"name":"Sparkasse","delimiter":";","skipRows":1,"dateColumn":0,"dateFormat":"DD.MM.YYYY","descriptionColumn":1,"amountColumn":2,"decimalSeparator":","}`))
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code)
+	profile := fakeImportService.Profile
+	assert.Equal(t, "Sparkasse", profile.Name)
+	assert.Equal(t, ";", profile.Delimiter)
+	assert.Equal(t, 1, profile.SkipRows)
+	assert.Equal(t, 2, *profile.AmountColumn)
+	assert.Nil(t, profile.DebitColumn)
+	assert.Contains(t, respRecorder.Body.String(), `"ID":"1"`)
+}
+
+// Test domain errors map onto client errors
+func TestForCreatingProfileUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		imports.ErrInvalidProfile:   http.StatusBadRequest,
+		errors.New("database down"): http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		apiHandler := NewForCreatingProfileUsingRestAPI(&FakeImportService{ReturnErr: serviceErr})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newCreateProfileRequest(`{"name":"Bank"}`))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
+
+// Test an unreadable body is a bad request
+func TestForCreatingProfileUsingRestAPI_InvalidBody(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForCreatingProfileUsingRestAPI(&FakeImportService{}).ServeHTTP(respRecorder, newCreateProfileRequest(`{"amountColumn":"two"}`))
+
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+}
@@ -0,0 +1,39 @@
+package imports
+
+import (
+	"errors"
+	"net/http"
+	"spend-api/internal/domain/imports"
+)
+
+// ForDeletingProfileUsingRestAPI is the REST API adapter for deleting import profiles.
+type ForDeletingProfileUsingRestAPI struct {
+	importService imports.ForDeletingProfile
+}
+
+// NewForDeletingProfileUsingRestAPI creates a new REST handler for deleting import profiles.
+func NewForDeletingProfileUsingRestAPI(service imports.ForDeletingProfile) *ForDeletingProfileUsingRestAPI {
+	return &ForDeletingProfileUsingRestAPI{
+		importService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for deleting the import profile identified by the {id} path value.
+func (h *ForDeletingProfileUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := h.importService.DeleteProfile(r.Context(), r.PathValue("id"))
+	if errors.Is(err, imports.ErrProfileNotFound) {
+		http.Error(w, "Import profile not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to delete import profile", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,44 @@
+package imports
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/imports"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDeleteProfileRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodDelete, "/import-profiles/3", nil)
+	req.SetPathValue("id", "3")
+	return req
+}
+
+// Test for deleting an import profile via the REST API
+func TestForDeletingProfileUsingRestAPI(t *testing.T) {
+	fakeImportService := &FakeImportService{}
+	respRecorder := httptest.NewRecorder()
+
+	NewForDeletingProfileUsingRestAPI(fakeImportService).ServeHTTP(respRecorder, newDeleteProfileRequest())
+
+	assert.Equal(t, http.StatusNoContent, respRecorder.Code)
+	assert.Equal(t, "3", fakeImportService.ID)
+}
+
+// Test domain errors map onto client errors
+func TestForDeletingProfileUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		imports.ErrProfileNotFound:  http.StatusNotFound,
+		errors.New("database down"): http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		respRecorder := httptest.NewRecorder()
+
+		NewForDeletingProfileUsingRestAPI(&FakeImportService{ReturnErr: serviceErr}).ServeHTTP(respRecorder, newDeleteProfileRequest())
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
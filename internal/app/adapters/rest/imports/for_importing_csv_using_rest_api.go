@@ -0,0 +1,93 @@
+package imports
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/imports"
+	"strings"
+)
+
+// maxUploadSize caps the size of an uploaded statement
+const maxUploadSize = 10 << 20
+
+// ForImportingCSVUsingRestAPI is the REST API adapter for importing CSV bank statements.
+type ForImportingCSVUsingRestAPI struct {
+	importService imports.ForImportingCSV
+}
+
+// NewForImportingCSVUsingRestAPI creates a new REST handler for importing CSV bank statements.
+func NewForImportingCSVUsingRestAPI(service imports.ForImportingCSV) *ForImportingCSVUsingRestAPI {
+	return &ForImportingCSVUsingRestAPI{
+		importService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for importing a CSV statement into the account identified by
+// the {id} path value, read with the profile named by the profileID query parameter. The CSV is
+// either the raw request body or the "file" field of a multipart form. The response reports
+// every line as imported, skipped or failed.
+func (h *ForImportingCSVUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	profileID := r.URL.Query().Get("profileID")
+	if profileID == "" {
+		http.Error(w, "Missing profileID", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	data, err := uploadedFile(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer data.Close()
+
+	report, err := h.importService.ImportCSV(r.Context(), r.PathValue("id"), profileID, data)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, imports.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, imports.ErrProfileNotFound) {
+		http.Error(w, "Import profile not found", http.StatusNotFound)
+		return
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, "Statement too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to import statement", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(report)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// uploadedFile returns the statement sent with the request
+func uploadedFile(r *http.Request) (io.ReadCloser, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.Body, nil
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
@@ -0,0 +1,104 @@
+package imports
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/imports"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newImportRequest(target, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	req.SetPathValue("id", "12345")
+	return req
+}
+
+// Test importing a CSV statement sent as the request body
+func TestForImportingCSVUsingRestAPI(t *testing.T) {
+	fakeImportService := &FakeImportService{}
+	respRecorder := httptest.NewRecorder()
+
+	NewForImportingCSVUsingRestAPI(fakeImportService).ServeHTTP(respRecorder, newImportRequest("/accounts/12345/imports?profileID=2", "2024-01-15,Coffee,-3.50\n"))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "12345", fakeImportService.AccountID)
+	assert.Equal(t, "2", fakeImportService.ID)
+	assert.Equal(t, "2024-01-15,Coffee,-3.50\n", fakeImportService.Data)
+	assert.JSONEq(t, `{"AccountID":"12345","Format":"csv","ProfileID":"2","Imported":1,"Skipped":1,"Failed":0,"Rows":[`+
+		`{"Line":1,"Status":"imported","TransactionID":"7","Message":""},{"Line":2,"Status":"skipped","TransactionID":"","Message":"blank line"}],`+
+		`"LedgerBalance":null,"LedgerBalanceDate":null,"ComputedBalance":null}`, respRecorder.Body.String())
+}
+
+// Test importing a CSV statement uploaded as a multipart form
+func TestForImportingCSVUsingRestAPI_Multipart(t *testing.T) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", "statement.csv")
+	_, _ = part.Write([]byte("2024-01-15,Coffee,-3.50\n"))
+	_ = writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts/12345/imports?profileID=2", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.SetPathValue("id", "12345")
+	fakeImportService := &FakeImportService{}
+	respRecorder := httptest.NewRecorder()
+
+	NewForImportingCSVUsingRestAPI(fakeImportService).ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "2024-01-15,Coffee,-3.50\n", fakeImportService.Data)
+}
+
+// Test a profile is required
+func TestForImportingCSVUsingRestAPI_MissingProfile(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForImportingCSVUsingRestAPI(&FakeImportService{}).ServeHTTP(respRecorder, newImportRequest("/accounts/12345/imports", "x"))
+
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+}
+
+// Test statements over the size limit are rejected
+func TestForImportingCSVUsingRestAPI_TooLarge(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForImportingCSVUsingRestAPI(&FakeImportService{}).ServeHTTP(respRecorder, newImportRequest("/accounts/12345/imports?profileID=2", strings.Repeat("x", maxUploadSize+1)))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, respRecorder.Code)
+}
+
+// Test domain errors map onto client errors
+func TestForImportingCSVUsingRestAPI_Errors(t *testing.T) {
+	forbidden := &access.ForbiddenError{AccountID: "12345", Permission: access.PermissionEdit, Role: access.RoleViewer}
+	cases := map[error]int{
+		imports.ErrAccountNotFound:  http.StatusNotFound,
+		imports.ErrProfileNotFound:  http.StatusNotFound,
+		forbidden:                   http.StatusForbidden,
+		errors.New("database down"): http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		respRecorder := httptest.NewRecorder()
+
+		NewForImportingCSVUsingRestAPI(&FakeImportService{ReturnErr: serviceErr}).ServeHTTP(respRecorder, newImportRequest("/accounts/12345/imports?profileID=2", ""))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
+
+// Test for invalid HTTP method
+func TestForImportingCSVUsingRestAPI_InvalidMethod(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForImportingCSVUsingRestAPI(&FakeImportService{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/accounts/12345/imports", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
@@ -0,0 +1,93 @@
+package imports
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/imports"
+	"strings"
+)
+
+// statementFormats maps the format query parameter onto statement formats. QFX is Quicken's
+// name for OFX and is read the same way, and STA is the usual extension of MT940 files.
+var statementFormats = map[string]imports.Format{
+	"":         "",
+	"ofx":      imports.FormatOFX,
+	"qfx":      imports.FormatOFX,
+	"qif":      imports.FormatQIF,
+	"camt053":  imports.FormatCAMT053,
+	"camt.053": imports.FormatCAMT053,
+	"mt940":    imports.FormatMT940,
+	"sta":      imports.FormatMT940,
+}
+
+// ForImportingStatementUsingRestAPI is the REST API adapter for importing OFX, QFX, QIF, camt.053
+// and MT940 bank statements.
+type ForImportingStatementUsingRestAPI struct {
+	importService imports.ForImportingStatement
+}
+
+// NewForImportingStatementUsingRestAPI creates a new REST handler for importing OFX, QFX, QIF,
+// camt.053 and MT940 bank statements.
+func NewForImportingStatementUsingRestAPI(service imports.ForImportingStatement) *ForImportingStatementUsingRestAPI {
+	return &ForImportingStatementUsingRestAPI{
+		importService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for importing a bank statement into the account
+// identified by the {id} path value. The optional format query parameter names the format,
+// which is otherwise detected from the file. The statement is sent like a CSV one, and the
+// response reports every line along with the statement's ledger balance and the account's
+// balance on the same date.
+func (h *ForImportingStatementUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format, ok := statementFormats[strings.ToLower(r.URL.Query().Get("format"))]
+	if !ok {
+		http.Error(w, "Invalid format, expected ofx, qfx, qif, camt053 or mt940", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	data, err := uploadedFile(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer data.Close()
+
+	report, err := h.importService.ImportStatement(r.Context(), r.PathValue("id"), format, data)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, imports.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, imports.ErrInvalidStatement) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, "Statement too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to import statement", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(report)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
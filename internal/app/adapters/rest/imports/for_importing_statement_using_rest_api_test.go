@@ -0,0 +1,81 @@
+package imports
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/imports"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test importing an OFX statement reports its lines and balances
+func TestForImportingStatementUsingRestAPI(t *testing.T) {
+	fakeImportService := &FakeImportService{}
+	respRecorder := httptest.NewRecorder()
+
+	NewForImportingStatementUsingRestAPI(fakeImportService).ServeHTTP(respRecorder, newImportRequest("/accounts/12345/statements", "OFXHEADER:100\n<OFX>"))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "12345", fakeImportService.AccountID)
+	assert.Equal(t, imports.Format(""), fakeImportService.Format, "The format should be left to detection")
+	assert.Equal(t, "OFXHEADER:100\n<OFX>", fakeImportService.Data)
+	assert.JSONEq(t, `{"AccountID":"12345","Format":"ofx","ProfileID":"","Imported":1,"Skipped":0,"Failed":0,`+
+		`"Rows":[{"Line":12,"Status":"imported","TransactionID":"7","Message":""}],`+
+		`"LedgerBalance":{"amount":"996.75","currency":"EUR"},"LedgerBalanceDate":"2024-01-31T00:00:00Z",`+
+		`"ComputedBalance":{"amount":"996.75","currency":"EUR"}}`, respRecorder.Body.String())
+}
+
+// Test the format parameter picks the format, with QFX read as OFX
+func TestForImportingStatementUsingRestAPI_Format(t *testing.T) {
+	cases := map[string]imports.Format{
+		"ofx":      imports.FormatOFX,
+		"QFX":      imports.FormatOFX,
+		"qif":      imports.FormatQIF,
+		"camt.053": imports.FormatCAMT053,
+		"mt940":    imports.FormatMT940,
+		"sta":      imports.FormatMT940,
+	}
+
+	for param, format := range cases {
+		fakeImportService := &FakeImportService{}
+		respRecorder := httptest.NewRecorder()
+
+		NewForImportingStatementUsingRestAPI(fakeImportService).ServeHTTP(respRecorder, newImportRequest("/accounts/12345/statements?format="+param, "x"))
+
+		assert.Equal(t, http.StatusOK, respRecorder.Code, param)
+		assert.Equal(t, format, fakeImportService.Format, param)
+	}
+
+	respRecorder := httptest.NewRecorder()
+	NewForImportingStatementUsingRestAPI(&FakeImportService{}).ServeHTTP(respRecorder, newImportRequest("/accounts/12345/statements?format=csv", "x"))
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+}
+
+// Test domain errors map onto client errors
+func TestForImportingStatementUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		imports.ErrAccountNotFound: http.StatusNotFound,
+		fmt.Errorf("%w: no <OFX> element found", imports.ErrInvalidStatement): http.StatusBadRequest,
+		errors.New("database down"): http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		respRecorder := httptest.NewRecorder()
+
+		NewForImportingStatementUsingRestAPI(&FakeImportService{ReturnErr: serviceErr}).ServeHTTP(respRecorder, newImportRequest("/accounts/12345/statements", ""))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
+
+// Test for invalid HTTP method
+func TestForImportingStatementUsingRestAPI_InvalidMethod(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForImportingStatementUsingRestAPI(&FakeImportService{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/accounts/12345/statements", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
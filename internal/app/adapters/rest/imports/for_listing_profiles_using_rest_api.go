@@ -0,0 +1,44 @@
+package imports
+
+import (
+	"encoding/json"
+	"net/http"
+	"spend-api/internal/domain/imports"
+)
+
+// ForListingProfilesUsingRestAPI is the REST API adapter for listing import profiles.
+type ForListingProfilesUsingRestAPI struct {
+	importService imports.ForListingProfiles
+}
+
+// NewForListingProfilesUsingRestAPI creates a new REST handler for listing import profiles.
+func NewForListingProfilesUsingRestAPI(service imports.ForListingProfiles) *ForListingProfilesUsingRestAPI {
+	return &ForListingProfilesUsingRestAPI{
+		importService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for listing import profiles.
+func (h *ForListingProfilesUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := h.importService.ListProfiles(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to list import profiles", http.StatusInternalServerError)
+		return
+	}
+	if result == nil {
+		result = []*imports.Profile{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,40 @@
+package imports
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/imports"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test for listing import profiles via the REST API
+func TestForListingProfilesUsingRestAPI(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+	service := &FakeImportService{Profiles: []*imports.Profile{{ID: "1", Name: "Sparkasse"}}}
+
+	NewForListingProfilesUsingRestAPI(service).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/import-profiles", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"Name":"Sparkasse"`)
+}
+
+// Test listing no import profiles returns an empty array
+func TestForListingProfilesUsingRestAPI_Empty(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForListingProfilesUsingRestAPI(&FakeImportService{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/import-profiles", nil))
+
+	assert.JSONEq(t, `[]`, respRecorder.Body.String())
+}
+
+// Test listing failure
+func TestForListingProfilesUsingRestAPI_Failure(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForListingProfilesUsingRestAPI(&FakeImportService{ReturnErr: errors.New("database down")}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/import-profiles", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
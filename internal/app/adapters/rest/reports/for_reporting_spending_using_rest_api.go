@@ -0,0 +1,130 @@
+package reports
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/reports"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayout is the format accepted for date query parameters
+const dateLayout = "2006-01-02"
+
+// ForReportingSpendingUsingRestAPI is the REST API adapter for spending reports.
+type ForReportingSpendingUsingRestAPI struct {
+	reportService reports.ForReportingSpending
+}
+
+// NewForReportingSpendingUsingRestAPI creates a new REST handler for spending reports.
+func NewForReportingSpendingUsingRestAPI(service reports.ForReportingSpending) *ForReportingSpendingUsingRestAPI {
+	return &ForReportingSpendingUsingRestAPI{
+		reportService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for a spending report. Supported query parameters are from,
+// to, period (day, week, month or year), accountID, categoryID, groupBy, a comma separated
+// list of account and category, includeTransfers, which counts transfers between accounts
+// as income and expenses, and currency, which converts every amount into that currency at the
+// rate of its transaction's day. A missing rate is reported as 422 Unprocessable Entity.
+func (h *ForReportingSpendingUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query, err := parseSpendingQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.reportService.ReportSpending(r.Context(), query)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, reports.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, reports.ErrInvalidReport) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, exchangerates.ErrRateNotFound) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to report spending", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(report)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// parseSpendingQuery reads the report's parameters from the query string
+func parseSpendingQuery(values url.Values) (reports.SpendingQuery, error) {
+	query := reports.SpendingQuery{
+		Period:     reports.Period(values.Get("period")),
+		AccountID:  values.Get("accountID"),
+		CategoryID: values.Get("categoryID"),
+	}
+
+	var err error
+	if query.From, err = parseDateParam(values, "from"); err != nil {
+		return query, err
+	}
+	if query.To, err = parseDateParam(values, "to"); err != nil {
+		return query, err
+	}
+	if includeTransfers := values.Get("includeTransfers"); includeTransfers != "" {
+		if query.IncludeTransfers, err = strconv.ParseBool(includeTransfers); err != nil {
+			return query, fmt.Errorf("Invalid includeTransfers %q, expected true or false", includeTransfers)
+		}
+	}
+	if currency := values.Get("currency"); currency != "" {
+		if query.Currency, err = money.ParseCurrency(currency); err != nil {
+			return query, fmt.Errorf("Invalid currency: %v", err)
+		}
+	}
+	if groupBy := values.Get("groupBy"); groupBy != "" {
+		for _, group := range strings.Split(groupBy, ",") {
+			switch strings.TrimSpace(group) {
+			case "account":
+				query.ByAccount = true
+			case "category":
+				query.ByCategory = true
+			default:
+				return query, fmt.Errorf("Invalid groupBy %q, expected account and/or category", group)
+			}
+		}
+	}
+	return query, nil
+}
+
+func parseDateParam(values url.Values, name string) (time.Time, error) {
+	value := values.Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid %s date %q, expected YYYY-MM-DD", name, value)
+	}
+	return date, nil
+}
@@ -0,0 +1,122 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/reports"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForReportingSpending simulates the report service for testing.
+type FakeForReportingSpending struct {
+	ReturnErr error
+	Query     reports.SpendingQuery
+}
+
+func (f *FakeForReportingSpending) ReportSpending(ctx context.Context, query reports.SpendingQuery) (*reports.SpendingReport, error) {
+	f.Query = query
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	row := &reports.SpendingRow{
+		PeriodStart:  query.From,
+		CategoryID:   "7",
+		Income:       money.MustParse("0.00", "EUR"),
+		Expenses:     money.MustParse("45.90", "EUR"),
+		NetCashFlow:  money.MustParse("-45.90", "EUR"),
+		Transactions: 3,
+	}
+	total := &reports.SpendingTotal{Income: row.Income, Expenses: row.Expenses, NetCashFlow: row.NetCashFlow, Transactions: 3}
+	return &reports.SpendingReport{From: query.From, To: query.To, Period: query.Period, Rows: []*reports.SpendingRow{row}, Totals: []*reports.SpendingTotal{total}}, nil
+}
+
+// Test for a spending report via the REST API
+func TestForReportingSpendingUsingRestAPI(t *testing.T) {
+	fakeReportService := &FakeForReportingSpending{}
+	apiHandler := NewForReportingSpendingUsingRestAPI(fakeReportService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/reports/spending?from=2024-01-01&to=2024-03-31&period=week&accountID=12345&groupBy=account,category&includeTransfers=true", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"Expenses":{"amount":"45.90","currency":"EUR"}`)
+	assert.Contains(t, respRecorder.Body.String(), `"Period":"week"`)
+	assert.Equal(t, reports.SpendingQuery{
+		From:             time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:               time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		Period:           reports.PeriodWeek,
+		AccountID:        "12345",
+		ByAccount:        true,
+		ByCategory:       true,
+		IncludeTransfers: true,
+	}, fakeReportService.Query)
+}
+
+// Test malformed and rejected reports are bad requests
+func TestForReportingSpendingUsingRestAPI_BadRequests(t *testing.T) {
+	for _, query := range []string{"from=yesterday", "to=2024-13-01", "groupBy=payee", "includeTransfers=maybe", "currency=euro"} {
+		respRecorder := httptest.NewRecorder()
+
+		NewForReportingSpendingUsingRestAPI(&FakeForReportingSpending{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/reports/spending?"+query, nil))
+
+		assert.Equal(t, http.StatusBadRequest, respRecorder.Code, query)
+	}
+
+	respRecorder := httptest.NewRecorder()
+	serviceErr := fmt.Errorf("%w: unknown period %q", reports.ErrInvalidReport, "fortnight")
+	NewForReportingSpendingUsingRestAPI(&FakeForReportingSpending{ReturnErr: serviceErr}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/reports/spending?period=fortnight", nil))
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `unknown period "fortnight"`)
+}
+
+// Test a report in a reporting currency passes the currency on, and a missing rate is unprocessable
+func TestForReportingSpendingUsingRestAPI_Currency(t *testing.T) {
+	fakeReportService := &FakeForReportingSpending{}
+	respRecorder := httptest.NewRecorder()
+
+	NewForReportingSpendingUsingRestAPI(fakeReportService).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/reports/spending?currency=USD", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, money.Currency("USD"), fakeReportService.Query.Currency)
+
+	respRecorder = httptest.NewRecorder()
+	serviceErr := fmt.Errorf("%w: EUR/USD on 2024-01-02", exchangerates.ErrRateNotFound)
+	NewForReportingSpendingUsingRestAPI(&FakeForReportingSpending{ReturnErr: serviceErr}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/reports/spending?currency=USD", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), "EUR/USD on 2024-01-02")
+}
+
+// Test report failures
+func TestForReportingSpendingUsingRestAPI_Failure(t *testing.T) {
+	cases := map[error]int{
+		reports.ErrAccountNotFound: http.StatusNotFound,
+		&access.ForbiddenError{AccountID: "12345", Permission: access.PermissionView}: http.StatusForbidden,
+		errors.New("database down"): http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		respRecorder := httptest.NewRecorder()
+
+		NewForReportingSpendingUsingRestAPI(&FakeForReportingSpending{ReturnErr: serviceErr}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/reports/spending", nil))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
+
+// Test an invalid method is rejected
+func TestForReportingSpendingUsingRestAPI_InvalidMethod(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForReportingSpendingUsingRestAPI(&FakeForReportingSpending{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodPost, "/reports/spending", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
@@ -0,0 +1,95 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/transactions"
+	"time"
+)
+
+// ForApplyingRulesUsingRestAPI is the REST API adapter for applying categorisation rules to existing transactions.
+type ForApplyingRulesUsingRestAPI struct {
+	ruleService transactions.ForApplyingRules
+}
+
+// NewForApplyingRulesUsingRestAPI creates a new REST handler for applying categorisation rules.
+func NewForApplyingRulesUsingRestAPI(service transactions.ForApplyingRules) *ForApplyingRulesUsingRestAPI {
+	return &ForApplyingRulesUsingRestAPI{
+		ruleService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for applying the rules to the transactions dated from..to,
+// optionally in a single account. With dryRun the changes are listed but not saved, and
+// existing categories and payees are only replaced when overwrite is set.
+func (h *ForApplyingRulesUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		AccountID string `json:"accountID"`
+		From      string `json:"from"`
+		To        string `json:"to"`
+		Overwrite bool   `json:"overwrite"`
+		DryRun    bool   `json:"dryRun"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	invalid := &transactions.ValidationError{Err: transactions.ErrInvalidRule}
+	application := transactions.RuleApplication{
+		AccountID: requestBody.AccountID,
+		From:      parseDateField("from", requestBody.From, invalid),
+		To:        parseDateField("to", requestBody.To, invalid),
+		Overwrite: requestBody.Overwrite,
+		DryRun:    requestBody.DryRun,
+	}
+	if len(invalid.Fields) > 0 {
+		writeValidationError(w, invalid)
+		return
+	}
+
+	run, err := h.ruleService.ApplyRules(r.Context(), application)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, transactions.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.As(err, &invalid) {
+		writeValidationError(w, invalid)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to apply rules", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(run)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// parseDateField reads an optional YYYY-MM-DD date, recording a problem in invalid
+func parseDateField(field, value string, invalid *transactions.ValidationError) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		invalid.Add(field, "expected YYYY-MM-DD")
+	}
+	return date
+}
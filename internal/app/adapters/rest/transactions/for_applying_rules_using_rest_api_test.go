@@ -0,0 +1,61 @@
+package transactions
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test a dry run previews the changes the rules would make
+func TestForApplyingRulesUsingRestAPI_DryRun(t *testing.T) {
+	fakeRuleService := &FakeRuleService{}
+	apiHandler := NewForApplyingRulesUsingRestAPI(fakeRuleService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newRuleRequest(http.MethodPost, "/rules/apply", `{"accountID":"12345","from":"2024-01-01","to":"2024-03-31","dryRun":true}`))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, transactions.RuleApplication{
+		AccountID: "12345",
+		From:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		DryRun:    true,
+	}, fakeRuleService.Application)
+	assert.JSONEq(t, `{"DryRun":true,"Examined":2,"Changes":[{"TransactionID":"5","RuleIDs":["1"],"FromCategoryID":"","ToCategoryID":"7","FromPayee":"","ToPayee":"Lidl"}]}`, respRecorder.Body.String())
+}
+
+// Test malformed dates are reported before the rules run
+func TestForApplyingRulesUsingRestAPI_InvalidDates(t *testing.T) {
+	fakeRuleService := &FakeRuleService{}
+	respRecorder := httptest.NewRecorder()
+
+	NewForApplyingRulesUsingRestAPI(fakeRuleService).ServeHTTP(respRecorder, newRuleRequest(http.MethodPost, "/rules/apply", `{"from":"01/01/2024","to":"2024-13-01"}`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, respRecorder.Code)
+	assert.JSONEq(t, `{"Error":"Invalid rule","Fields":[{"Field":"from","Message":"expected YYYY-MM-DD"},{"Field":"to","Message":"expected YYYY-MM-DD"}]}`, respRecorder.Body.String())
+	assert.True(t, fakeRuleService.Application.From.IsZero(), "The service should not be called")
+}
+
+// Test service failures
+func TestForApplyingRulesUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		&transactions.ValidationError{Err: transactions.ErrInvalidRule}:               http.StatusUnprocessableEntity,
+		transactions.ErrAccountNotFound:                                               http.StatusNotFound,
+		&access.ForbiddenError{AccountID: "12345", Permission: access.PermissionEdit}: http.StatusForbidden,
+		errors.New("database down"):                                                   http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		respRecorder := httptest.NewRecorder()
+
+		NewForApplyingRulesUsingRestAPI(&FakeRuleService{ReturnErr: serviceErr}).ServeHTTP(respRecorder, newRuleRequest(http.MethodPost, "/rules/apply", `{"from":"2024-01-01","to":"2024-01-31"}`))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
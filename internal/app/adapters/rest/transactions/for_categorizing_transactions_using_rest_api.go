@@ -0,0 +1,62 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/transactions"
+)
+
+// ForCategorizingTransactionsUsingRestAPI is the REST API adapter for re-categorising transactions in bulk.
+type ForCategorizingTransactionsUsingRestAPI struct {
+	transactionService transactions.ForCategorizingTransactions
+}
+
+// NewForCategorizingTransactionsUsingRestAPI creates a new REST handler for re-categorising transactions.
+func NewForCategorizingTransactionsUsingRestAPI(service transactions.ForCategorizingTransactions) *ForCategorizingTransactionsUsingRestAPI {
+	return &ForCategorizingTransactionsUsingRestAPI{
+		transactionService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for moving the listed transactions into a category. An empty
+// categoryID makes them uncategorised. The response counts the transactions that changed.
+func (h *ForCategorizingTransactionsUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		TransactionIDs []string `json:"transactionIDs"`
+		CategoryID     string   `json:"categoryID"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	changed, err := h.transactionService.CategorizeTransactions(r.Context(), requestBody.TransactionIDs, requestBody.CategoryID)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	var invalid *transactions.ValidationError
+	if errors.As(err, &invalid) {
+		writeValidationError(w, invalid)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to categorise transactions", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(map[string]int{"updated": changed})
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,80 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForCategorizingTransactions simulates the transaction service for testing.
+type FakeForCategorizingTransactions struct {
+	ReturnErr  error
+	IDs        []string
+	CategoryID string
+}
+
+func (f *FakeForCategorizingTransactions) CategorizeTransactions(ctx context.Context, ids []string, categoryID string) (int, error) {
+	f.IDs, f.CategoryID = ids, categoryID
+	if f.ReturnErr != nil {
+		return 0, f.ReturnErr
+	}
+	return len(ids), nil
+}
+
+func newCategorizeRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/transactions/categorize", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+// Test re-categorising transactions in bulk
+func TestForCategorizingTransactionsUsingRestAPI(t *testing.T) {
+	fakeTransactionService := &FakeForCategorizingTransactions{}
+	apiHandler := NewForCategorizingTransactionsUsingRestAPI(fakeTransactionService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newCategorizeRequest(`{"transactionIDs":["1","2"],"categoryID":"7"}`))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.JSONEq(t, `{"updated":2}`, respRecorder.Body.String())
+	assert.Equal(t, []string{"1", "2"}, fakeTransactionService.IDs)
+	assert.Equal(t, "7", fakeTransactionService.CategoryID)
+}
+
+// Test validation failures are unprocessable and other failures are server errors
+func TestForCategorizingTransactionsUsingRestAPI_Errors(t *testing.T) {
+	invalid := &transactions.ValidationError{}
+	invalid.Add("categoryID", `unknown category "99"`)
+	cases := map[error]int{
+		invalid:                     http.StatusUnprocessableEntity,
+		errors.New("database down"): http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		apiHandler := NewForCategorizingTransactionsUsingRestAPI(&FakeForCategorizingTransactions{ReturnErr: serviceErr})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newCategorizeRequest(`{"transactionIDs":["1"],"categoryID":"99"}`))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
+
+// Test for invalid JSON and HTTP method
+func TestForCategorizingTransactionsUsingRestAPI_BadRequests(t *testing.T) {
+	apiHandler := NewForCategorizingTransactionsUsingRestAPI(&FakeForCategorizingTransactions{})
+
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, newCategorizeRequest(`{"transactionIDs":"1"}`))
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+
+	respRecorder = httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/categorize", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
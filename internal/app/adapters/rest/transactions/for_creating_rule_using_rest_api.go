@@ -0,0 +1,92 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+)
+
+// ForCreatingRuleUsingRestAPI is the REST API adapter for creating categorisation rules.
+type ForCreatingRuleUsingRestAPI struct {
+	ruleService transactions.ForCreatingRule
+}
+
+// NewForCreatingRuleUsingRestAPI creates a new REST handler for creating categorisation rules.
+func NewForCreatingRuleUsingRestAPI(service transactions.ForCreatingRule) *ForCreatingRuleUsingRestAPI {
+	return &ForCreatingRuleUsingRestAPI{
+		ruleService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for creating a categorisation rule. Invalid
+// fields are reported with 422 Unprocessable Entity.
+func (h *ForCreatingRuleUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rule, err := decodeRule(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	rule, err = h.ruleService.CreateRule(r.Context(), rule)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	var invalid *transactions.ValidationError
+	if errors.As(err, &invalid) {
+		writeValidationError(w, invalid)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to create rule", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(rule)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// decodeRule reads a rule's conditions and actions from the request body.
+// Amount bounds are signed and may be sent as JSON strings or numbers.
+func decodeRule(r *http.Request) (*transactions.Rule, error) {
+	var requestBody struct {
+		Name                string         `json:"name"`
+		Priority            int            `json:"priority"`
+		AccountID           string         `json:"accountID"`
+		Type                string         `json:"type"`
+		DescriptionContains string         `json:"descriptionContains"`
+		DescriptionPattern  string         `json:"descriptionPattern"`
+		MinAmount           *money.Decimal `json:"minAmount"`
+		MaxAmount           *money.Decimal `json:"maxAmount"`
+		CategoryID          string         `json:"categoryID"`
+		Payee               string         `json:"payee"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		return nil, err
+	}
+	return &transactions.Rule{
+		Name:                requestBody.Name,
+		Priority:            requestBody.Priority,
+		AccountID:           requestBody.AccountID,
+		Type:                transactions.Kind(requestBody.Type),
+		DescriptionContains: requestBody.DescriptionContains,
+		DescriptionPattern:  requestBody.DescriptionPattern,
+		MinAmount:           requestBody.MinAmount,
+		MaxAmount:           requestBody.MaxAmount,
+		CategoryID:          requestBody.CategoryID,
+		Payee:               requestBody.Payee,
+	}, nil
+}
@@ -0,0 +1,136 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeRuleService simulates the rule service for testing.
+type FakeRuleService struct {
+	ReturnErr   error
+	Rules       []*transactions.Rule
+	ID          string
+	Rule        *transactions.Rule
+	Application transactions.RuleApplication
+}
+
+func (f *FakeRuleService) CreateRule(ctx context.Context, rule *transactions.Rule) (*transactions.Rule, error) {
+	f.Rule = rule
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	rule.ID = "1"
+	return rule, nil
+}
+
+func (f *FakeRuleService) ListRules(ctx context.Context) ([]*transactions.Rule, error) {
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return f.Rules, nil
+}
+
+func (f *FakeRuleService) UpdateRule(ctx context.Context, id string, rule *transactions.Rule) (*transactions.Rule, error) {
+	f.ID, f.Rule = id, rule
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	rule.ID = id
+	return rule, nil
+}
+
+func (f *FakeRuleService) DeleteRule(ctx context.Context, id string) error {
+	f.ID = id
+	return f.ReturnErr
+}
+
+func (f *FakeRuleService) ApplyRules(ctx context.Context, application transactions.RuleApplication) (*transactions.RuleRun, error) {
+	f.Application = application
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return &transactions.RuleRun{
+		DryRun:   application.DryRun,
+		Examined: 2,
+		Changes:  []transactions.RuleChange{{TransactionID: "5", RuleIDs: []string{"1"}, ToCategoryID: "7", ToPayee: "Lidl"}},
+	}, nil
+}
+
+func newRuleRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+// Test for creating a rule via the REST API
+func TestForCreatingRuleUsingRestAPI(t *testing.T) {
+	fakeRuleService := &FakeRuleService{}
+	apiHandler := NewForCreatingRuleUsingRestAPI(fakeRuleService)
+	respRecorder := httptest.NewRecorder()
+
+	body := `{"name":"Big shops","priority":10,"type":"debit","descriptionContains":"tesco","minAmount":"-500","maxAmount":-100,"payee":"Tesco"}`
+	apiHandler.ServeHTTP(respRecorder, newRuleRequest(http.MethodPost, "/rules", body))
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"MinAmount":"-500"`)
+	rule := fakeRuleService.Rule
+	assert.Equal(t, "Big shops", rule.Name)
+	assert.Equal(t, 10, rule.Priority)
+	assert.Equal(t, transactions.KindDebit, rule.Type)
+	assert.Equal(t, "tesco", rule.DescriptionContains)
+	assert.Equal(t, "-500", rule.MinAmount.String())
+	assert.Equal(t, "-100", rule.MaxAmount.String())
+	assert.Equal(t, "Tesco", rule.Payee)
+}
+
+// Test an unreadable body is a bad request
+func TestForCreatingRuleUsingRestAPI_InvalidBody(t *testing.T) {
+	apiHandler := NewForCreatingRuleUsingRestAPI(&FakeRuleService{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newRuleRequest(http.MethodPost, "/rules", `{"minAmount":"lots"}`))
+
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+}
+
+// Test validation failures list the invalid fields
+func TestForCreatingRuleUsingRestAPI_Errors(t *testing.T) {
+	invalid := &transactions.ValidationError{Err: transactions.ErrInvalidRule}
+	invalid.Add("descriptionPattern", "missing closing )")
+	cases := map[error]int{
+		invalid: http.StatusUnprocessableEntity,
+		&access.ForbiddenError{AccountID: "12345", Permission: access.PermissionEdit}: http.StatusForbidden,
+		errors.New("database down"): http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		apiHandler := NewForCreatingRuleUsingRestAPI(&FakeRuleService{ReturnErr: serviceErr})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newRuleRequest(http.MethodPost, "/rules", `{"name":"Broken","descriptionPattern":"("}`))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+
+	respRecorder := httptest.NewRecorder()
+	NewForCreatingRuleUsingRestAPI(&FakeRuleService{ReturnErr: invalid}).ServeHTTP(respRecorder, newRuleRequest(http.MethodPost, "/rules", `{}`))
+	assert.JSONEq(t, `{"Error":"Invalid rule","Fields":[{"Field":"descriptionPattern","Message":"missing closing )"}]}`, respRecorder.Body.String())
+}
+
+// Test an invalid method is rejected
+func TestForCreatingRuleUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForCreatingRuleUsingRestAPI(&FakeRuleService{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newRuleRequest(http.MethodGet, "/rules", ""))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
@@ -2,8 +2,13 @@ package transactions
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/money"
 	"spend-api/internal/domain/transactions"
+	"time"
 )
 
 // ForCreatingTransactionUsingRestAPI is the REST API adapter for creating transactions.
@@ -18,18 +23,26 @@ func NewForCreatingTransactionUsingRestAPI(service transactions.ForCreatingTrans
 	}
 }
 
-// ServeHTTP handles HTTP requests for creating a transaction.
+// ServeHTTP handles HTTP requests for creating a transaction. Invalid fields
+// are reported with 422 Unprocessable Entity, listing each field and problem.
 func (h *ForCreatingTransactionUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
 		return
 	}
 
+	// The amount may be sent as a JSON string or number; either way it is
+	// parsed from its text so no precision is lost to float64
 	var requestBody struct {
-		AccountID   string  `json:"accountID"`
-		Amount      float64 `json:"amount"`
-		Type        string  `json:"type"`
-		Description string  `json:"description"`
+		AccountID   string      `json:"accountID"`
+		Amount      json.Number `json:"amount"`
+		Currency    string      `json:"currency"`
+		Type        string      `json:"type"`
+		Description string      `json:"description"`
+		CategoryID  string      `json:"categoryID"`
+		Date        string      `json:"date"`
+		PostedDate  string      `json:"postedDate"`
+		Status      string      `json:"status"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
@@ -37,7 +50,46 @@ func (h *ForCreatingTransactionUsingRestAPI) ServeHTTP(w http.ResponseWriter, r
 		return
 	}
 
-	transaction, err := h.transactionService.CreateTransaction(requestBody.AccountID, requestBody.Amount, requestBody.Type, requestBody.Description)
+	// Problems with individual fields are collected so the client can fix
+	// them all in one go
+	invalid := &transactions.ValidationError{}
+	currency, err := money.ParseCurrency(requestBody.Currency)
+	if err != nil {
+		invalid.Add("currency", err.Error())
+	}
+	var amount money.Money
+	if currency.IsValid() {
+		amount, err = money.Parse(requestBody.Amount.String(), currency)
+		if err != nil {
+			invalid.Add("amount", err.Error())
+		}
+	}
+	kind := transactions.Kind(requestBody.Type)
+	if !kind.IsValid() {
+		invalid.Add("type", fmt.Sprintf("unknown type %q", requestBody.Type))
+	}
+	date, postedDate := parseTransactionDates(requestBody.Date, requestBody.PostedDate, requestBody.Status, invalid)
+	if len(invalid.Fields) > 0 {
+		writeValidationError(w, invalid)
+		return
+	}
+
+	transaction, err := h.transactionService.CreateTransaction(r.Context(), requestBody.AccountID, amount, kind, requestBody.Description, requestBody.CategoryID, date, postedDate)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, transactions.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.As(err, &invalid) {
+		writeValidationError(w, invalid)
+		return
+	}
+	if errors.Is(err, transactions.ErrInvalidTransaction) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
 	if err != nil {
 		http.Error(w, "Failed to create transaction", http.StatusInternalServerError)
 		return
@@ -51,3 +103,58 @@ func (h *ForCreatingTransactionUsingRestAPI) ServeHTTP(w http.ResponseWriter, r
 		return
 	}
 }
+
+// parseTransactionDates reads the optional transaction date, posted date and
+// status of a new transaction, recording any problem in invalid. Transactions
+// are posted unless the status says otherwise, and a posted transaction
+// without a posted date is posted on its transaction date.
+func parseTransactionDates(dateValue, postedDateValue, status string, invalid *transactions.ValidationError) (time.Time, *time.Time) {
+	date := transactions.DateOf(time.Now())
+	if dateValue != "" {
+		parsed, err := time.Parse(dateLayout, dateValue)
+		if err != nil {
+			invalid.Add("date", "expected YYYY-MM-DD")
+		}
+		date = parsed
+	}
+
+	switch transactions.Status(status) {
+	case "", transactions.StatusPosted:
+		if postedDateValue == "" {
+			return date, &date
+		}
+		postedDate, err := time.Parse(dateLayout, postedDateValue)
+		if err != nil {
+			invalid.Add("postedDate", "expected YYYY-MM-DD")
+		}
+		return date, &postedDate
+	case transactions.StatusPending:
+		if postedDateValue != "" {
+			invalid.Add("postedDate", "must be empty for a pending transaction")
+		}
+		return date, nil
+	default:
+		invalid.Add("status", "expected pending or posted")
+		return date, nil
+	}
+}
+
+// writeValidationError responds with 422 and the list of invalid fields
+func writeValidationError(w http.ResponseWriter, invalid *transactions.ValidationError) {
+	title := "Invalid transaction"
+	if errors.Is(invalid, transactions.ErrInvalidRule) {
+		title = "Invalid rule"
+	}
+	if errors.Is(invalid, transactions.ErrInvalidResolution) {
+		title = "Invalid resolution"
+	}
+	if errors.Is(invalid, transactions.ErrInvalidTransfer) {
+		title = "Invalid transfer"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"Error":  title,
+		"Fields": invalid.Fields,
+	})
+}
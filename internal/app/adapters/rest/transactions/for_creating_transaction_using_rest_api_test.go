@@ -2,12 +2,14 @@ package transactions
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"spend-api/internal/domain/money"
 	"spend-api/internal/domain/transactions"
 	"testing"
 	"time"
@@ -22,6 +24,7 @@ func TestForCreatingTransactionUsingRestAPI_Success(t *testing.T) {
 	requestBody := map[string]interface{}{
 		"accountID":   "12345",
 		"amount":      100.0,
+		"currency":    "EUR",
 		"type":        "credit",
 		"description": "Payment",
 	}
@@ -38,6 +41,60 @@ func TestForCreatingTransactionUsingRestAPI_Success(t *testing.T) {
 	assert.Contains(t, respRecorder.Body.String(), `"AccountID":"12345"`)
 }
 
+// Test that a string amount is parsed exactly and returned as a string
+func TestForCreatingTransactionUsingRestAPI_StringAmount(t *testing.T) {
+	fakeTransactionService := &FakeForCreatingTransaction{}
+	apiHandler := NewForCreatingTransactionUsingRestAPI(fakeTransactionService)
+
+	body := `{"accountID":"12345","amount":"0.30","currency":"eur","type":"debit","description":"Coffee"}`
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code)
+	assert.Equal(t, money.MustParse("0.30", "EUR"), fakeTransactionService.Amount)
+	assert.Contains(t, respRecorder.Body.String(), `"Amount":{"amount":"0.30","currency":"EUR"}`)
+}
+
+// Test that amounts and currencies the money type rejects are unprocessable
+func TestForCreatingTransactionUsingRestAPI_InvalidAmount(t *testing.T) {
+	bodies := map[string]string{
+		"too precise":      `{"accountID":"12345","amount":"1.005","currency":"EUR","type":"debit"}`,
+		"fractional yen":   `{"accountID":"12345","amount":10.5,"currency":"JPY","type":"debit"}`,
+		"missing currency": `{"accountID":"12345","amount":"10.00","type":"debit"}`,
+		"unknown currency": `{"accountID":"12345","amount":"10.00","currency":"ABC","type":"debit"}`,
+	}
+
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := NewForCreatingTransactionUsingRestAPI(&FakeForCreatingTransaction{})
+
+			req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader([]byte(body)))
+			req.Header.Set("Content-Type", "application/json")
+			respRecorder := httptest.NewRecorder()
+
+			apiHandler.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, respRecorder.Code)
+		})
+	}
+}
+
+// Test an amount that is not a number is rejected with the rest of a malformed body
+func TestForCreatingTransactionUsingRestAPI_AmountNotANumber(t *testing.T) {
+	apiHandler := NewForCreatingTransactionUsingRestAPI(&FakeForCreatingTransaction{})
+
+	body := `{"accountID":"12345","amount":"ten","currency":"EUR","type":"debit"}`
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader([]byte(body)))
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+}
+
 // Test for invalid HTTP method
 func TestForCreatingTransactionUsingRestAPI_InvalidMethod(t *testing.T) {
 	fakeTransactionService := &FakeForCreatingTransaction{}
@@ -75,6 +132,7 @@ func TestForCreatingTransactionUsingRestAPI_ServiceError(t *testing.T) {
 	requestBody := map[string]interface{}{
 		"accountID":   "12345",
 		"amount":      100.0,
+		"currency":    "EUR",
 		"type":        "credit",
 		"description": "Payment",
 	}
@@ -89,6 +147,28 @@ func TestForCreatingTransactionUsingRestAPI_ServiceError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
 }
 
+// Test that domain errors from the transaction service map onto client errors
+func TestForCreatingTransactionUsingRestAPI_ClientErrors(t *testing.T) {
+	cases := map[error]int{
+		transactions.ErrAccountNotFound:    http.StatusNotFound,
+		transactions.ErrInvalidTransaction: http.StatusUnprocessableEntity,
+		&transactions.ValidationError{Fields: []transactions.FieldError{{Field: "currency", Message: "does not match the account"}}}: http.StatusUnprocessableEntity,
+	}
+
+	for serviceErr, status := range cases {
+		apiHandler := NewForCreatingTransactionUsingRestAPI(&FakeForCreatingTransaction{ReturnErr: serviceErr})
+
+		body := `{"accountID":"12345","amount":"10.00","currency":"USD","type":"debit","description":"Coffee"}`
+		req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, req)
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
+
 // Test for JSON encoding failure when creating a transaction
 func TestForCreatingTransactionUsingRestAPI_EncodingError(t *testing.T) {
 	fakeTransactionService := &FakeForCreatingTransaction{}
@@ -97,6 +177,7 @@ func TestForCreatingTransactionUsingRestAPI_EncodingError(t *testing.T) {
 	requestBody := map[string]interface{}{
 		"accountID":   "12345",
 		"amount":      100.0,
+		"currency":    "EUR",
 		"type":        "credit",
 		"description": "Payment",
 	}
@@ -114,6 +195,90 @@ func TestForCreatingTransactionUsingRestAPI_EncodingError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, respRecorder.statusCode)
 }
 
+// Test the transaction and posted dates are read from the body
+func TestForCreatingTransactionUsingRestAPI_Dates(t *testing.T) {
+	cases := []struct {
+		body       string
+		date       time.Time
+		postedDate *time.Time
+	}{
+		{`"date":"2024-01-02","postedDate":"2024-01-04"`, day(2024, 1, 2), ptr(day(2024, 1, 4))},
+		{`"date":"2024-01-02"`, day(2024, 1, 2), ptr(day(2024, 1, 2))},
+		{`"date":"2024-01-02","status":"pending"`, day(2024, 1, 2), nil},
+		{`"status":"posted"`, transactions.DateOf(time.Now()), ptr(transactions.DateOf(time.Now()))},
+	}
+
+	for _, c := range cases {
+		fakeTransactionService := &FakeForCreatingTransaction{}
+		apiHandler := NewForCreatingTransactionUsingRestAPI(fakeTransactionService)
+
+		body := `{"accountID":"12345","amount":"-4.50","currency":"EUR","type":"debit",` + c.body + `}`
+		req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader([]byte(body)))
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, req)
+
+		assert.Equal(t, http.StatusCreated, respRecorder.Code, c.body)
+		assert.Equal(t, c.date, fakeTransactionService.Date, c.body)
+		assert.Equal(t, c.postedDate, fakeTransactionService.PostedDate, c.body)
+	}
+}
+
+// Test malformed dates and statuses are unprocessable
+func TestForCreatingTransactionUsingRestAPI_InvalidDates(t *testing.T) {
+	for _, fields := range []string{
+		`"date":"02/01/2024"`,
+		`"postedDate":"tomorrow"`,
+		`"status":"cleared"`,
+		`"status":"pending","postedDate":"2024-01-04"`,
+	} {
+		apiHandler := NewForCreatingTransactionUsingRestAPI(&FakeForCreatingTransaction{})
+
+		body := `{"accountID":"12345","amount":"-4.50","currency":"EUR","type":"debit",` + fields + `}`
+		req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader([]byte(body)))
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, respRecorder.Code, fields)
+	}
+}
+
+// Test every invalid field is reported at once with its own message
+func TestForCreatingTransactionUsingRestAPI_ValidationDetails(t *testing.T) {
+	fakeTransactionService := &FakeForCreatingTransaction{}
+	apiHandler := NewForCreatingTransactionUsingRestAPI(fakeTransactionService)
+
+	body := `{"accountID":"12345","amount":"1.005","currency":"EUR","type":"payment","date":"yesterday"}`
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader([]byte(body)))
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, respRecorder.Code)
+	var response struct {
+		Error  string
+		Fields []transactions.FieldError
+	}
+	assert.NoError(t, json.NewDecoder(respRecorder.Body).Decode(&response))
+	assert.Equal(t, "Invalid transaction", response.Error)
+	var fields []string
+	for _, field := range response.Fields {
+		fields = append(fields, field.Field)
+		assert.NotEmpty(t, field.Message)
+	}
+	assert.Equal(t, []string{"amount", "type", "date"}, fields)
+	assert.True(t, fakeTransactionService.Date.IsZero(), "The service should not be called")
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
 // Fake ResponseWriter that simulates an encoding failure
 type errorResponseWriter struct {
 	statusCode int
@@ -134,9 +299,18 @@ func (e *errorResponseWriter) Write(b []byte) (int, error) {
 // FakeForCreatingTransaction simulates the transaction service for testing.
 type FakeForCreatingTransaction struct {
 	ReturnError bool
+	ReturnErr   error
+	Amount      money.Money
+	Date        time.Time
+	PostedDate  *time.Time
 }
 
-func (f *FakeForCreatingTransaction) CreateTransaction(accountID string, amount float64, txnType, description string) (*transactions.Transaction, error) {
+func (f *FakeForCreatingTransaction) CreateTransaction(ctx context.Context, accountID string, amount money.Money, kind transactions.Kind, description, categoryID string, date time.Time, postedDate *time.Time) (*transactions.Transaction, error) {
+	f.Amount = amount
+	f.Date, f.PostedDate = date, postedDate
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
 	if f.ReturnError {
 		return nil, errors.New("failed to create transaction")
 	}
@@ -144,7 +318,8 @@ func (f *FakeForCreatingTransaction) CreateTransaction(accountID string, amount
 		ID:          "txn123",
 		AccountID:   accountID,
 		Amount:      amount,
-		Type:        txnType,
+		Type:        kind,
+		CategoryID:  categoryID,
 		Timestamp:   time.Now(),
 		Description: description,
 	}, nil
@@ -0,0 +1,89 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+)
+
+// ForCreatingTransferUsingRestAPI is the REST API adapter for moving money between accounts.
+type ForCreatingTransferUsingRestAPI struct {
+	transferService transactions.ForCreatingTransfer
+}
+
+// NewForCreatingTransferUsingRestAPI creates a new REST handler for creating transfers.
+func NewForCreatingTransferUsingRestAPI(service transactions.ForCreatingTransfer) *ForCreatingTransferUsingRestAPI {
+	return &ForCreatingTransferUsingRestAPI{
+		transferService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for creating a transfer. The response holds
+// the transfer with both of its transactions. Invalid fields are reported with
+// 422 Unprocessable Entity, listing each field and problem.
+func (h *ForCreatingTransferUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		FromAccountID string      `json:"fromAccountID"`
+		ToAccountID   string      `json:"toAccountID"`
+		Amount        json.Number `json:"amount"`
+		Currency      string      `json:"currency"`
+		Description   string      `json:"description"`
+		Date          string      `json:"date"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	invalid := &transactions.ValidationError{Err: transactions.ErrInvalidTransfer}
+	currency, err := money.ParseCurrency(requestBody.Currency)
+	if err != nil {
+		invalid.Add("currency", err.Error())
+	}
+	var amount money.Money
+	if currency.IsValid() {
+		amount, err = money.Parse(requestBody.Amount.String(), currency)
+		if err != nil {
+			invalid.Add("amount", err.Error())
+		}
+	}
+	date := parseDateField("date", requestBody.Date, invalid)
+	if len(invalid.Fields) > 0 {
+		writeValidationError(w, invalid)
+		return
+	}
+
+	transfer, err := h.transferService.CreateTransfer(r.Context(), requestBody.FromAccountID, requestBody.ToAccountID, amount, requestBody.Description, date)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, transactions.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.As(err, &invalid) {
+		writeValidationError(w, invalid)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to create transfer", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(transfer)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
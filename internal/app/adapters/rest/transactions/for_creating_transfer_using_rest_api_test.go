@@ -0,0 +1,116 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+)
+
+// FakeForCreatingTransfer simulates the transfer service for testing.
+type FakeForCreatingTransfer struct {
+	ReturnErr     error
+	FromAccountID string
+	ToAccountID   string
+	Amount        money.Money
+	Date          time.Time
+}
+
+func (f *FakeForCreatingTransfer) CreateTransfer(ctx context.Context, fromAccountID, toAccountID string, amount money.Money, description string, date time.Time) (*transactions.Transfer, error) {
+	f.FromAccountID, f.ToAccountID, f.Amount, f.Date = fromAccountID, toAccountID, amount, date
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	outgoing := transactions.NewTransaction("1", fromAccountID, amount.Neg(), transactions.KindTransferOut, date, description)
+	incoming := transactions.NewTransaction("2", toAccountID, amount, transactions.KindTransferIn, date, description)
+	outgoing.TransferID, incoming.TransferID = "4", "4"
+	return &transactions.Transfer{ID: "4", FromAccountID: fromAccountID, ToAccountID: toAccountID, Amount: amount, Date: date,
+		Description: description, Outgoing: outgoing, Incoming: incoming}, nil
+}
+
+// Test creating a transfer returns it with both of its transactions
+func TestForCreatingTransferUsingRestAPI_Success(t *testing.T) {
+	fakeService := &FakeForCreatingTransfer{}
+	apiHandler := NewForCreatingTransferUsingRestAPI(fakeService)
+
+	body := `{"fromAccountID":"12345","toAccountID":"67890","amount":"250.00","currency":"EUR","description":"Savings","date":"2024-01-15"}`
+	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewReader([]byte(body)))
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code)
+	assert.Equal(t, "12345", fakeService.FromAccountID)
+	assert.Equal(t, "67890", fakeService.ToAccountID)
+	assert.Equal(t, money.MustParse("250.00", "EUR"), fakeService.Amount)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), fakeService.Date)
+	assert.Contains(t, respRecorder.Body.String(), `"ID":"4"`)
+	assert.Contains(t, respRecorder.Body.String(), `"Outgoing":{"ID":"1"`)
+	assert.Contains(t, respRecorder.Body.String(), `"Amount":{"amount":"-250.00","currency":"EUR"}`)
+	assert.Contains(t, respRecorder.Body.String(), `"TransferID":"4"`)
+}
+
+// Test malformed fields are reported together before the service is called
+func TestForCreatingTransferUsingRestAPI_InvalidFields(t *testing.T) {
+	fakeService := &FakeForCreatingTransfer{}
+	apiHandler := NewForCreatingTransferUsingRestAPI(fakeService)
+
+	body := `{"fromAccountID":"12345","toAccountID":"67890","amount":"1.005","currency":"EUR","date":"15/01/2024"}`
+	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewReader([]byte(body)))
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"Error":"Invalid transfer"`)
+	assert.Contains(t, respRecorder.Body.String(), `"Field":"amount"`)
+	assert.Contains(t, respRecorder.Body.String(), `"Field":"date"`)
+	assert.Empty(t, fakeService.FromAccountID)
+}
+
+// Test service errors map onto their status codes
+func TestForCreatingTransferUsingRestAPI_Errors(t *testing.T) {
+	invalid := &transactions.ValidationError{Err: transactions.ErrInvalidTransfer}
+	invalid.Add("toAccountID", "must differ from fromAccountID")
+	tests := map[string]struct {
+		err  error
+		code int
+	}{
+		"invalid":           {invalid, http.StatusUnprocessableEntity},
+		"account not found": {transactions.ErrAccountNotFound, http.StatusNotFound},
+		"forbidden":         {&access.ForbiddenError{AccountID: "12345", Permission: access.PermissionEdit, Role: access.RoleViewer}, http.StatusForbidden},
+		"failure":           {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := NewForCreatingTransferUsingRestAPI(&FakeForCreatingTransfer{ReturnErr: tt.err})
+
+			body := `{"fromAccountID":"12345","toAccountID":"12345","amount":"10.00","currency":"EUR"}`
+			req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewReader([]byte(body)))
+			respRecorder := httptest.NewRecorder()
+
+			apiHandler.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.code, respRecorder.Code)
+		})
+	}
+}
+
+// Test that only POST is accepted
+func TestForCreatingTransferUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForCreatingTransferUsingRestAPI(&FakeForCreatingTransfer{})
+
+	req := httptest.NewRequest(http.MethodGet, "/transfers", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
@@ -0,0 +1,43 @@
+package transactions
+
+import (
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/transactions"
+)
+
+// ForDeletingRuleUsingRestAPI is the REST API adapter for deleting categorisation rules.
+type ForDeletingRuleUsingRestAPI struct {
+	ruleService transactions.ForDeletingRule
+}
+
+// NewForDeletingRuleUsingRestAPI creates a new REST handler for deleting categorisation rules.
+func NewForDeletingRuleUsingRestAPI(service transactions.ForDeletingRule) *ForDeletingRuleUsingRestAPI {
+	return &ForDeletingRuleUsingRestAPI{
+		ruleService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for deleting the rule identified by the {id} path value.
+func (h *ForDeletingRuleUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := h.ruleService.DeleteRule(r.Context(), r.PathValue("id"))
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, transactions.ErrRuleNotFound) {
+		http.Error(w, "Rule not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to delete rule", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
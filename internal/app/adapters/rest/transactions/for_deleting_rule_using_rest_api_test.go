@@ -0,0 +1,46 @@
+package transactions
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDeleteRuleRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodDelete, "/rules/4", nil)
+	req.SetPathValue("id", "4")
+	return req
+}
+
+// Test for deleting a rule via the REST API
+func TestForDeletingRuleUsingRestAPI(t *testing.T) {
+	fakeRuleService := &FakeRuleService{}
+	respRecorder := httptest.NewRecorder()
+
+	NewForDeletingRuleUsingRestAPI(fakeRuleService).ServeHTTP(respRecorder, newDeleteRuleRequest())
+
+	assert.Equal(t, http.StatusNoContent, respRecorder.Code)
+	assert.Equal(t, "4", fakeRuleService.ID)
+}
+
+// Test domain errors map onto client errors
+func TestForDeletingRuleUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		transactions.ErrRuleNotFound: http.StatusNotFound,
+		&access.ForbiddenError{AccountID: "12345", Permission: access.PermissionEdit}: http.StatusForbidden,
+		errors.New("database down"): http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		respRecorder := httptest.NewRecorder()
+
+		NewForDeletingRuleUsingRestAPI(&FakeRuleService{ReturnErr: serviceErr}).ServeHTTP(respRecorder, newDeleteRuleRequest())
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
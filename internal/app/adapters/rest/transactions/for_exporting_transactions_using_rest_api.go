@@ -0,0 +1,355 @@
+package transactions
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/transactions"
+	"strings"
+	"time"
+)
+
+// Export formats
+const (
+	exportCSV    = "csv"
+	exportNDJSON = "ndjson"
+	exportOFX    = "ofx"
+)
+
+// exportFormats maps the format query parameter onto an export format
+var exportFormats = map[string]string{
+	"csv":    exportCSV,
+	"ndjson": exportNDJSON,
+	"jsonl":  exportNDJSON,
+	"ofx":    exportOFX,
+}
+
+// exportMediaTypes maps the media types of the Accept header onto an export format
+var exportMediaTypes = map[string]string{
+	"*/*":                  exportCSV,
+	"text/*":               exportCSV,
+	"text/csv":             exportCSV,
+	"application/x-ndjson": exportNDJSON,
+	"application/jsonl":    exportNDJSON,
+	"application/x-ofx":    exportOFX,
+	"application/ofx":      exportOFX,
+}
+
+// exportContentTypes gives the Content-Type each export format is sent with
+var exportContentTypes = map[string]string{
+	exportCSV:    "text/csv; charset=utf-8",
+	exportNDJSON: "application/x-ndjson",
+	exportOFX:    "application/x-ofx",
+}
+
+// exportCSVHeader names the columns of CSV exports
+var exportCSVHeader = []string{"id", "account_id", "date", "posted_date", "status", "type", "amount", "currency", "description", "category_id", "payee", "external_id"}
+
+// ofxTransactionTypes translates transaction kinds into OFX transaction types
+var ofxTransactionTypes = map[transactions.Kind]string{
+	transactions.KindDebit:       "DEBIT",
+	transactions.KindCredit:      "CREDIT",
+	transactions.KindFee:         "FEE",
+	transactions.KindInterest:    "INT",
+	transactions.KindRefund:      "CREDIT",
+	transactions.KindTransferIn:  "XFER",
+	transactions.KindTransferOut: "XFER",
+	transactions.KindAdjustment:  "OTHER",
+}
+
+// ForExportingTransactionsUsingRestAPI is the REST API adapter for exporting transactions.
+type ForExportingTransactionsUsingRestAPI struct {
+	exportService  transactions.ForExportingTransactions
+	balanceService transactions.ForGettingBalance
+}
+
+// NewForExportingTransactionsUsingRestAPI creates a new REST handler for exporting transactions.
+func NewForExportingTransactionsUsingRestAPI(exportService transactions.ForExportingTransactions, balanceService transactions.ForGettingBalance) *ForExportingTransactionsUsingRestAPI {
+	return &ForExportingTransactionsUsingRestAPI{
+		exportService:  exportService,
+		balanceService: balanceService,
+	}
+}
+
+// ServeHTTP handles HTTP requests for exporting every transaction matching the listing
+// filters, except limit and cursor, as CSV, NDJSON or OFX. The format query parameter
+// (csv, ndjson, jsonl or ofx) takes precedence over the Accept header, and CSV is the
+// default. Transactions are written as they are read, so an export that fails part way
+// through is cut off rather than reported. OFX exports are statements of a single
+// account: they need an accountID, are ordered by date and leave out voided transactions.
+func (h *ForExportingTransactionsUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseTransactionFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	format, status, err := negotiateExportFormat(r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	var encoder transactionEncoder
+	switch format {
+	case exportCSV:
+		encoder = &csvTransactionEncoder{writer: csv.NewWriter(w)}
+	case exportNDJSON:
+		encoder = &ndjsonTransactionEncoder{encoder: json.NewEncoder(w)}
+	case exportOFX:
+		if filter.AccountID == "" {
+			http.Error(w, "OFX exports need an accountID", http.StatusBadRequest)
+			return
+		}
+		if (filter.SortBy != "" && filter.SortBy != transactions.SortByDate) || (filter.SortOrder != "" && filter.SortOrder != transactions.SortAscending) {
+			http.Error(w, "OFX exports are sorted by date, oldest first", http.StatusBadRequest)
+			return
+		}
+		balance, err := h.balanceService.GetBalance(r.Context(), filter.AccountID, filter.To, "")
+		if restAccess.WriteForbidden(w, err) {
+			return
+		}
+		if errors.Is(err, transactions.ErrAccountNotFound) {
+			http.Error(w, "Account not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to export transactions", http.StatusInternalServerError)
+			return
+		}
+		encoder = newOFXTransactionEncoder(w, balance, filter.From)
+	}
+
+	// The status line goes out with the first transaction, so that problems
+	// found before anything is read can still be reported properly
+	started := false
+	start := func() {
+		w.Header().Set("Content-Type", exportContentTypes[format])
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="transactions.%s"`, format))
+		w.WriteHeader(http.StatusOK)
+		started = true
+	}
+
+	err = h.exportService.ExportTransactions(r.Context(), filter, func(transaction *transactions.Transaction) error {
+		if !started {
+			start()
+		}
+		return encoder.encode(transaction)
+	})
+	if err != nil && !started {
+		if restAccess.WriteForbidden(w, err) {
+			return
+		}
+		if errors.Is(err, transactions.ErrAccountNotFound) {
+			http.Error(w, "Account not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, transactions.ErrInvalidFilter) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to export transactions", http.StatusInternalServerError)
+		return
+	}
+	if err != nil {
+		// Abort the connection so the client cannot mistake a partial export for a complete one
+		panic(http.ErrAbortHandler)
+	}
+
+	if !started {
+		start()
+	}
+	if err := encoder.end(); err != nil {
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// negotiateExportFormat picks the export format from the format query parameter or, failing
+// that, the first supported media type in the Accept header
+func negotiateExportFormat(r *http.Request) (string, int, error) {
+	if value := r.URL.Query().Get("format"); value != "" {
+		format, ok := exportFormats[strings.ToLower(value)]
+		if !ok {
+			return "", http.StatusBadRequest, fmt.Errorf("Unknown format %q, expected csv, ndjson or ofx", value)
+		}
+		return format, 0, nil
+	}
+
+	accept := r.Header.Get("Accept")
+	if strings.TrimSpace(accept) == "" {
+		return exportCSV, 0, nil
+	}
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, _ := strings.Cut(mediaRange, ";")
+		if format, ok := exportMediaTypes[strings.ToLower(strings.TrimSpace(mediaType))]; ok {
+			return format, 0, nil
+		}
+	}
+	return "", http.StatusNotAcceptable, errors.New("Exports are available as text/csv, application/x-ndjson or application/x-ofx")
+}
+
+// transactionEncoder writes exported transactions in one of the export formats
+type transactionEncoder interface {
+	encode(transaction *transactions.Transaction) error
+	end() error
+}
+
+// csvTransactionEncoder writes a header row followed by a row per transaction
+type csvTransactionEncoder struct {
+	writer  *csv.Writer
+	started bool
+}
+
+func (e *csvTransactionEncoder) encode(transaction *transactions.Transaction) error {
+	if err := e.begin(); err != nil {
+		return err
+	}
+	postedDate := ""
+	if transaction.PostedDate != nil {
+		postedDate = transaction.PostedDate.Format(dateLayout)
+	}
+	return e.writer.Write([]string{
+		transaction.ID,
+		transaction.AccountID,
+		transaction.Timestamp.Format(dateLayout),
+		postedDate,
+		string(transaction.Status),
+		string(transaction.Type),
+		transaction.Amount.String(),
+		string(transaction.Amount.Currency()),
+		transaction.Description,
+		transaction.CategoryID,
+		transaction.Payee,
+		transaction.ExternalID,
+	})
+}
+
+func (e *csvTransactionEncoder) end() error {
+	if err := e.begin(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvTransactionEncoder) begin() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	return e.writer.Write(exportCSVHeader)
+}
+
+// ndjsonTransactionEncoder writes each transaction as a JSON object on a line of its own
+type ndjsonTransactionEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonTransactionEncoder) encode(transaction *transactions.Transaction) error {
+	return e.encoder.Encode(transaction)
+}
+
+func (e *ndjsonTransactionEncoder) end() error {
+	return nil
+}
+
+// ofxTransactionEncoder writes an OFX 2.2 bank statement of a single account. The statement
+// starts on the from date, or on the first transaction's date when there is none, and ends
+// with the account's ledger balance.
+type ofxTransactionEncoder struct {
+	writer  *bufio.Writer
+	balance *transactions.AccountBalance
+	from    *time.Time
+	started bool
+}
+
+func newOFXTransactionEncoder(w io.Writer, balance *transactions.AccountBalance, from *time.Time) *ofxTransactionEncoder {
+	return &ofxTransactionEncoder{writer: bufio.NewWriter(w), balance: balance, from: from}
+}
+
+func (e *ofxTransactionEncoder) encode(transaction *transactions.Transaction) error {
+	if transaction.Status == transactions.StatusVoided {
+		return nil
+	}
+	e.begin(transaction.Timestamp)
+
+	posted := transaction.Timestamp
+	if transaction.PostedDate != nil {
+		posted = *transaction.PostedDate
+	}
+	fmt.Fprintf(e.writer, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><DTUSER>%s</DTUSER><TRNAMT>%s</TRNAMT><FITID>%s</FITID>",
+		ofxTransactionTypes[transaction.Type], formatOFXDate(posted), formatOFXDate(transaction.Timestamp), transaction.Amount.String(), escapeOFX(transaction.ID))
+	if transaction.Payee != "" {
+		fmt.Fprintf(e.writer, "<NAME>%s</NAME>", escapeOFX(truncate(transaction.Payee, 32)))
+	}
+	if transaction.Description != "" {
+		fmt.Fprintf(e.writer, "<MEMO>%s</MEMO>", escapeOFX(truncate(transaction.Description, 255)))
+	}
+	_, err := e.writer.WriteString("</STMTTRN>\n")
+	return err
+}
+
+func (e *ofxTransactionEncoder) end() error {
+	asOf, ledger := time.Now().UTC(), e.balance.Current
+	if e.balance.AsOf != nil {
+		asOf, ledger = *e.balance.AsOf, *e.balance.AsOfBalance
+	}
+	e.begin(asOf)
+
+	fmt.Fprintf(e.writer, "<DTEND>%s</DTEND>\n</BANKTRANLIST>\n", formatOFXDate(asOf))
+	fmt.Fprintf(e.writer, "<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n", ledger.String(), formatOFXDate(asOf))
+	e.writer.WriteString("</STMTRS>\n</STMTTRNRS>\n</BANKMSGSRSV1>\n</OFX>\n")
+	return e.writer.Flush()
+}
+
+// begin writes everything up to the statement's first transaction, once
+func (e *ofxTransactionEncoder) begin(firstDate time.Time) {
+	if e.started {
+		return
+	}
+	e.started = true
+	start := firstDate
+	if e.from != nil {
+		start = *e.from
+	}
+
+	e.writer.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"no\"?>\n")
+	e.writer.WriteString("<?OFX OFXHEADER=\"200\" VERSION=\"220\" SECURITY=\"NONE\" OLDFILEUID=\"NONE\" NEWFILEUID=\"NONE\"?>\n")
+	e.writer.WriteString("<OFX>\n<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>")
+	fmt.Fprintf(e.writer, "<DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE></SONRS></SIGNONMSGSRSV1>\n", time.Now().UTC().Format("20060102150405"))
+	e.writer.WriteString("<BANKMSGSRSV1>\n<STMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n")
+	fmt.Fprintf(e.writer, "<STMTRS><CURDEF>%s</CURDEF><BANKACCTFROM><ACCTID>%s</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>\n",
+		e.balance.Current.Currency(), escapeOFX(e.balance.AccountID))
+	fmt.Fprintf(e.writer, "<BANKTRANLIST><DTSTART>%s</DTSTART>\n", formatOFXDate(start))
+}
+
+// formatOFXDate formats a calendar date as OFX writes it
+func formatOFXDate(date time.Time) string {
+	return date.Format("20060102")
+}
+
+// escapeOFX escapes text for an OFX element
+func escapeOFX(text string) string {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(text))
+	return escaped.String()
+}
+
+// truncate shortens text to at most limit characters, the most some OFX elements allow
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit])
+}
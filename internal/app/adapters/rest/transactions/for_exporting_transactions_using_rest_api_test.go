@@ -0,0 +1,204 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForExportingTransactions simulates the export service for testing.
+type FakeForExportingTransactions struct {
+	ReturnErr    error
+	FailAfter    int
+	Transactions []*transactions.Transaction
+	Filter       transactions.TransactionFilter
+}
+
+func (f *FakeForExportingTransactions) ExportTransactions(ctx context.Context, filter transactions.TransactionFilter, each func(*transactions.Transaction) error) error {
+	f.Filter = filter
+	if f.ReturnErr != nil && f.FailAfter == 0 {
+		return f.ReturnErr
+	}
+	for i, transaction := range f.Transactions {
+		if f.ReturnErr != nil && i == f.FailAfter {
+			return f.ReturnErr
+		}
+		if err := each(transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportedTransactions() []*transactions.Transaction {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	posted := date.AddDate(0, 0, 1)
+	coffee := transactions.NewTransaction("1", "12345", money.MustParse("-4.50", "EUR"), transactions.KindDebit, date, `Coffee, "large"`)
+	coffee.Status, coffee.PostedDate, coffee.Payee, coffee.CategoryID = transactions.StatusPosted, &posted, "Bean & Co", "7"
+	refund := transactions.NewTransaction("2", "12345", money.MustParse("4.50", "EUR"), transactions.KindRefund, date, "Refund")
+	refund.Status = transactions.StatusVoided
+	return []*transactions.Transaction{coffee, refund}
+}
+
+// Test exporting as CSV by default
+func TestForExportingTransactionsUsingRestAPI_CSV(t *testing.T) {
+	fakeExportService := &FakeForExportingTransactions{Transactions: exportedTransactions()}
+	apiHandler := NewForExportingTransactionsUsingRestAPI(fakeExportService, &FakeForGettingBalance{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/export?accountID=12345&from=2024-01-01", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", respRecorder.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="transactions.csv"`, respRecorder.Header().Get("Content-Disposition"))
+	assert.Equal(t, "id,account_id,date,posted_date,status,type,amount,currency,description,category_id,payee,external_id\n"+
+		"1,12345,2024-01-15,2024-01-16,posted,debit,-4.50,EUR,\"Coffee, \"\"large\"\"\",7,Bean & Co,\n"+
+		"2,12345,2024-01-15,,voided,refund,4.50,EUR,Refund,,,\n", respRecorder.Body.String())
+	assert.Equal(t, "12345", fakeExportService.Filter.AccountID)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *fakeExportService.Filter.From)
+}
+
+// Test an empty CSV export still has its header row
+func TestForExportingTransactionsUsingRestAPI_EmptyCSV(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForExportingTransactionsUsingRestAPI(&FakeForExportingTransactions{}, &FakeForGettingBalance{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/export", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "id,account_id,date,posted_date,status,type,amount,currency,description,category_id,payee,external_id\n", respRecorder.Body.String())
+}
+
+// Test exporting as NDJSON chosen by the Accept header
+func TestForExportingTransactionsUsingRestAPI_NDJSON(t *testing.T) {
+	apiHandler := NewForExportingTransactionsUsingRestAPI(&FakeForExportingTransactions{Transactions: exportedTransactions()}, &FakeForGettingBalance{})
+	respRecorder := httptest.NewRecorder()
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/export", nil)
+	req.Header.Set("Accept", "application/json;q=0.9, application/x-ndjson")
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "application/x-ndjson", respRecorder.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(respRecorder.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"ID":"1"`)
+	assert.Contains(t, lines[0], `"Amount":{"amount":"-4.50","currency":"EUR"}`)
+	assert.Contains(t, lines[1], `"Status":"voided"`)
+}
+
+// Test exporting an account's statement as OFX, leaving out voided transactions
+func TestForExportingTransactionsUsingRestAPI_OFX(t *testing.T) {
+	fakeBalanceService := &FakeForGettingBalance{}
+	apiHandler := NewForExportingTransactionsUsingRestAPI(&FakeForExportingTransactions{Transactions: exportedTransactions()}, fakeBalanceService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/export?format=ofx&accountID=12345&to=2024-01-31", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "application/x-ofx", respRecorder.Header().Get("Content-Type"))
+	body := respRecorder.Body.String()
+	assert.True(t, strings.HasPrefix(body, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>`+"\n"+`<?OFX OFXHEADER="200" VERSION="220"`))
+	assert.Contains(t, body, "<CURDEF>EUR</CURDEF><BANKACCTFROM><ACCTID>12345</ACCTID>")
+	assert.Contains(t, body, "<BANKTRANLIST><DTSTART>20240115</DTSTART>\n")
+	assert.Contains(t, body, "<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240116</DTPOSTED><DTUSER>20240115</DTUSER><TRNAMT>-4.50</TRNAMT>"+
+		"<FITID>1</FITID><NAME>Bean &amp; Co</NAME><MEMO>Coffee, &#34;large&#34;</MEMO></STMTTRN>\n")
+	assert.NotContains(t, body, "<FITID>2</FITID>")
+	assert.Contains(t, body, "<DTEND>20240131</DTEND>\n</BANKTRANLIST>\n<LEDGERBAL><BALAMT>100.00</BALAMT><DTASOF>20240131</DTASOF></LEDGERBAL>\n")
+	assert.True(t, strings.HasSuffix(body, "</OFX>\n"))
+	assert.Equal(t, "12345", fakeBalanceService.AccountID)
+}
+
+// Test an empty OFX export starts on the from date
+func TestForExportingTransactionsUsingRestAPI_EmptyOFX(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/export?accountID=12345&from=2024-01-01", nil)
+	req.Header.Set("Accept", "application/x-ofx")
+	NewForExportingTransactionsUsingRestAPI(&FakeForExportingTransactions{}, &FakeForGettingBalance{}).ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), "<DTSTART>20240101</DTSTART>")
+	assert.Contains(t, respRecorder.Body.String(), "<BALAMT>150.25</BALAMT>")
+	assert.NotContains(t, respRecorder.Body.String(), "<STMTTRN>")
+}
+
+// Test requests that cannot be exported are rejected before anything is written
+func TestForExportingTransactionsUsingRestAPI_BadRequests(t *testing.T) {
+	cases := map[string]struct {
+		target string
+		accept string
+		status int
+	}{
+		"unknown format":       {"/transactions/export?format=xlsx", "", http.StatusBadRequest},
+		"unsupported media":    {"/transactions/export", "application/pdf", http.StatusNotAcceptable},
+		"malformed filter":     {"/transactions/export?from=yesterday", "", http.StatusBadRequest},
+		"OFX without account":  {"/transactions/export?format=ofx", "", http.StatusBadRequest},
+		"OFX sorted by amount": {"/transactions/export?format=ofx&accountID=12345&sortBy=amount", "", http.StatusBadRequest},
+		"OFX newest first":     {"/transactions/export?format=ofx&accountID=12345&sortOrder=desc", "", http.StatusBadRequest},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			fakeExportService := &FakeForExportingTransactions{Transactions: exportedTransactions()}
+			respRecorder := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet, c.target, nil)
+			req.Header.Set("Accept", c.accept)
+			NewForExportingTransactionsUsingRestAPI(fakeExportService, &FakeForGettingBalance{}).ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, c.status, respRecorder.Code)
+		})
+	}
+}
+
+// Test failures before the export starts map onto status codes
+func TestForExportingTransactionsUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		fmt.Errorf("%w: exports are not paginated", transactions.ErrInvalidFilter): http.StatusBadRequest,
+		transactions.ErrAccountNotFound:                                            http.StatusNotFound,
+		errors.New("database down"):                                                http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		respRecorder := httptest.NewRecorder()
+
+		NewForExportingTransactionsUsingRestAPI(&FakeForExportingTransactions{ReturnErr: serviceErr}, &FakeForGettingBalance{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/export", nil))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+
+	respRecorder := httptest.NewRecorder()
+	balanceService := &FakeForGettingBalance{ReturnErr: transactions.ErrAccountNotFound}
+	NewForExportingTransactionsUsingRestAPI(&FakeForExportingTransactions{}, balanceService).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/export?format=ofx&accountID=99", nil))
+	assert.Equal(t, http.StatusNotFound, respRecorder.Code)
+}
+
+// Test a failure part way through aborts the response instead of ending it cleanly
+func TestForExportingTransactionsUsingRestAPI_FailureMidStream(t *testing.T) {
+	fakeExportService := &FakeForExportingTransactions{Transactions: exportedTransactions(), ReturnErr: errors.New("connection lost"), FailAfter: 1}
+	apiHandler := NewForExportingTransactionsUsingRestAPI(fakeExportService, &FakeForGettingBalance{})
+	respRecorder := httptest.NewRecorder()
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/export", nil))
+	})
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+}
+
+// Test an invalid method is rejected
+func TestForExportingTransactionsUsingRestAPI_InvalidMethod(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForExportingTransactionsUsingRestAPI(&FakeForExportingTransactions{}, &FakeForGettingBalance{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodPost, "/transactions/export", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
@@ -0,0 +1,95 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/transactions"
+	"strconv"
+)
+
+// ForFindingDuplicatesUsingRestAPI is the REST API adapter for finding likely duplicate transactions.
+type ForFindingDuplicatesUsingRestAPI struct {
+	duplicateService transactions.ForFindingDuplicates
+}
+
+// NewForFindingDuplicatesUsingRestAPI creates a new REST handler for finding likely duplicate transactions.
+func NewForFindingDuplicatesUsingRestAPI(service transactions.ForFindingDuplicates) *ForFindingDuplicatesUsingRestAPI {
+	return &ForFindingDuplicatesUsingRestAPI{
+		duplicateService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for finding likely duplicate transactions. Supported query
+// parameters are accountID, windowDays, minSimilarity and limit.
+func (h *ForFindingDuplicatesUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseDuplicateFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pairs, err := h.duplicateService.FindDuplicates(r.Context(), filter)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, transactions.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, transactions.ErrInvalidFilter) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to find duplicates", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(pairs)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// parseDuplicateFilter reads the duplicate search from the query string
+func parseDuplicateFilter(query url.Values) (transactions.DuplicateFilter, error) {
+	filter := transactions.DuplicateFilter{AccountID: query.Get("accountID")}
+
+	var err error
+	if filter.WindowDays, err = parseIntParam(query, "windowDays"); err != nil {
+		return filter, err
+	}
+	if filter.Limit, err = parseIntParam(query, "limit"); err != nil {
+		return filter, err
+	}
+	if value := query.Get("minSimilarity"); value != "" {
+		if filter.MinSimilarity, err = strconv.ParseFloat(value, 64); err != nil {
+			return filter, fmt.Errorf("Invalid minSimilarity %q", value)
+		}
+	}
+	return filter, nil
+}
+
+func parseIntParam(query url.Values, name string) (int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid %s %q", name, value)
+	}
+	return number, nil
+}
@@ -0,0 +1,85 @@
+package transactions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test for finding duplicates via the REST API
+func TestForFindingDuplicatesUsingRestAPI(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	fakeDuplicateService := &FakeDuplicateService{Pairs: []*transactions.DuplicatePair{{
+		Transaction: transactions.NewTransaction("1", "12345", money.MustParse("-4.50", "EUR"), transactions.KindDebit, date, "COFFEE SHOP"),
+		Duplicate:   transactions.NewTransaction("2", "12345", money.MustParse("-4.50", "EUR"), transactions.KindDebit, date, "Coffee Shop"),
+		Similarity:  1,
+	}}}
+	apiHandler := NewForFindingDuplicatesUsingRestAPI(fakeDuplicateService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/duplicates?accountID=12345&windowDays=5&minSimilarity=0.8&limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"Similarity":1`)
+	assert.Contains(t, respRecorder.Body.String(), `"Description":"Coffee Shop"`)
+	assert.Equal(t, transactions.DuplicateFilter{AccountID: "12345", WindowDays: 5, MinSimilarity: 0.8, Limit: 10}, fakeDuplicateService.Filter)
+}
+
+// Test malformed and rejected searches are bad requests
+func TestForFindingDuplicatesUsingRestAPI_InvalidFilter(t *testing.T) {
+	for _, query := range []string{"windowDays=soon", "minSimilarity=high", "limit=all"} {
+		respRecorder := httptest.NewRecorder()
+
+		NewForFindingDuplicatesUsingRestAPI(&FakeDuplicateService{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/duplicates?"+query, nil))
+
+		assert.Equal(t, http.StatusBadRequest, respRecorder.Code, query)
+	}
+
+	respRecorder := httptest.NewRecorder()
+	serviceErr := fmt.Errorf("%w: windowDays must be between 1 and 31", transactions.ErrInvalidFilter)
+	NewForFindingDuplicatesUsingRestAPI(&FakeDuplicateService{ReturnErr: serviceErr}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/duplicates?windowDays=90", nil))
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), "windowDays must be between 1 and 31")
+}
+
+// Test search failure
+func TestForFindingDuplicatesUsingRestAPI_Failure(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForFindingDuplicatesUsingRestAPI(&FakeDuplicateService{ReturnErr: errors.New("database down")}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/duplicates", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
+
+// Test an account that cannot be searched is not found, or forbidden
+func TestForFindingDuplicatesUsingRestAPI_Access(t *testing.T) {
+	cases := map[error]int{
+		transactions.ErrAccountNotFound:                                               http.StatusNotFound,
+		&access.ForbiddenError{AccountID: "12345", Permission: access.PermissionView}: http.StatusForbidden,
+	}
+
+	for serviceErr, status := range cases {
+		respRecorder := httptest.NewRecorder()
+
+		NewForFindingDuplicatesUsingRestAPI(&FakeDuplicateService{ReturnErr: serviceErr}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/duplicates?accountID=12345", nil))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
+
+// Test an invalid method is rejected
+func TestForFindingDuplicatesUsingRestAPI_InvalidMethod(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForFindingDuplicatesUsingRestAPI(&FakeDuplicateService{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodPost, "/transactions/duplicates", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
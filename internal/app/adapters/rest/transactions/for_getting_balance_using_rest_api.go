@@ -0,0 +1,78 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"time"
+)
+
+// ForGettingBalanceUsingRestAPI is the REST API adapter for getting account balances.
+type ForGettingBalanceUsingRestAPI struct {
+	transactionService transactions.ForGettingBalance
+}
+
+// NewForGettingBalanceUsingRestAPI creates a new REST handler for getting account balances.
+func NewForGettingBalanceUsingRestAPI(service transactions.ForGettingBalance) *ForGettingBalanceUsingRestAPI {
+	return &ForGettingBalanceUsingRestAPI{
+		transactionService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for the balance of the account identified by the {id} path
+// value. The optional asOf query parameter also asks for the balance at the end of that day,
+// and currency converts the balances into that currency, each day's change at that day's rate.
+// A missing rate is reported as 422 Unprocessable Entity.
+func (h *ForGettingBalanceUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var asOf *time.Time
+	if value := r.URL.Query().Get("asOf"); value != "" {
+		date, err := time.Parse(dateLayout, value)
+		if err != nil {
+			http.Error(w, "Invalid asOf date, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		asOf = &date
+	}
+	var currency money.Currency
+	if value := r.URL.Query().Get("currency"); value != "" {
+		var err error
+		if currency, err = money.ParseCurrency(value); err != nil {
+			http.Error(w, "Invalid currency: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	balance, err := h.transactionService.GetBalance(r.Context(), r.PathValue("id"), asOf, currency)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, transactions.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, exchangerates.ErrRateNotFound) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to get balance", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(balance)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
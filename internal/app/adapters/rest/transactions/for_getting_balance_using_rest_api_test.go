@@ -0,0 +1,110 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/exchangerates"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForGettingBalance simulates the transaction service for testing.
+type FakeForGettingBalance struct {
+	ReturnErr error
+	AccountID string
+	AsOf      *time.Time
+	Currency  money.Currency
+}
+
+func (f *FakeForGettingBalance) GetBalance(ctx context.Context, accountID string, asOf *time.Time, currency money.Currency) (*transactions.AccountBalance, error) {
+	f.AccountID, f.AsOf, f.Currency = accountID, asOf, currency
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	balance := &transactions.AccountBalance{AccountID: accountID, Current: money.MustParse("150.25", "EUR")}
+	if asOf != nil {
+		asOfBalance := money.MustParse("100.00", "EUR")
+		balance.AsOf, balance.AsOfBalance = asOf, &asOfBalance
+	}
+	return balance, nil
+}
+
+func newBalanceRequest(query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/accounts/12345/balance"+query, nil)
+	req.SetPathValue("id", "12345")
+	return req
+}
+
+// Test getting the current and as-of-date balances of an account
+func TestForGettingBalanceUsingRestAPI(t *testing.T) {
+	fakeTransactionService := &FakeForGettingBalance{}
+	apiHandler := NewForGettingBalanceUsingRestAPI(fakeTransactionService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newBalanceRequest("?asOf=2024-01-31&currency=USD"))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "12345", fakeTransactionService.AccountID)
+	assert.Equal(t, money.Currency("USD"), fakeTransactionService.Currency)
+	assert.Equal(t, day(2024, time.January, 31), *fakeTransactionService.AsOf)
+	var response map[string]interface{}
+	assert.NoError(t, json.NewDecoder(respRecorder.Body).Decode(&response))
+	assert.Equal(t, map[string]interface{}{"amount": "150.25", "currency": "EUR"}, response["Current"])
+	assert.Equal(t, map[string]interface{}{"amount": "100.00", "currency": "EUR"}, response["AsOfBalance"])
+}
+
+// Test the as-of balance is only computed when asked for
+func TestForGettingBalanceUsingRestAPI_CurrentOnly(t *testing.T) {
+	fakeTransactionService := &FakeForGettingBalance{}
+	apiHandler := NewForGettingBalanceUsingRestAPI(fakeTransactionService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newBalanceRequest(""))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Nil(t, fakeTransactionService.AsOf)
+}
+
+// Test client and service errors
+func TestForGettingBalanceUsingRestAPI_Errors(t *testing.T) {
+	cases := []struct {
+		query      string
+		serviceErr error
+		status     int
+	}{
+		{"?asOf=31/01/2024", nil, http.StatusBadRequest},
+		{"?currency=dollars", nil, http.StatusBadRequest},
+		{"?currency=USD", fmt.Errorf("%w: EUR/USD on 2024-01-31", exchangerates.ErrRateNotFound), http.StatusUnprocessableEntity},
+		{"", transactions.ErrAccountNotFound, http.StatusNotFound},
+		{"", &access.ForbiddenError{AccountID: "12345", Permission: access.PermissionView}, http.StatusForbidden},
+		{"", errors.New("database down"), http.StatusInternalServerError},
+	}
+
+	for _, c := range cases {
+		apiHandler := NewForGettingBalanceUsingRestAPI(&FakeForGettingBalance{ReturnErr: c.serviceErr})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newBalanceRequest(c.query))
+
+		assert.Equal(t, c.status, respRecorder.Code, c.query)
+	}
+}
+
+// Test for invalid HTTP method
+func TestForGettingBalanceUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForGettingBalanceUsingRestAPI(&FakeForGettingBalance{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodPost, "/accounts/12345/balance", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
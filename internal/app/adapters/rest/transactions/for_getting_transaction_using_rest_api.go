@@ -0,0 +1,77 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/transactions"
+	"strconv"
+	"strings"
+)
+
+// ForGettingTransactionUsingRestAPI is the REST API adapter for retrieving a single transaction.
+type ForGettingTransactionUsingRestAPI struct {
+	transactionService transactions.ForGettingTransaction
+}
+
+// NewForGettingTransactionUsingRestAPI creates a new REST handler for retrieving transactions.
+func NewForGettingTransactionUsingRestAPI(service transactions.ForGettingTransaction) *ForGettingTransactionUsingRestAPI {
+	return &ForGettingTransactionUsingRestAPI{
+		transactionService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for retrieving a transaction by the {id} path value. The
+// transaction's version is sent as its ETag, for use in If-Match when editing it.
+func (h *ForGettingTransactionUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	transaction, err := h.transactionService.GetTransaction(r.Context(), r.PathValue("id"))
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, transactions.ErrTransactionNotFound) {
+		http.Error(w, "Transaction not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to get transaction", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(transaction.Version))
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(transaction)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// etag formats a transaction version as a strong entity tag
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatchVersion returns the transaction version the request's If-Match
+// header asks for, or zero if there is none or it is "*". ok is false when
+// the header cannot match any version; If-Match only compares strong tags.
+func ifMatchVersion(r *http.Request) (version int, ok bool) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, true
+	}
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return 0, false
+	}
+	version, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,80 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+)
+
+// FakeForGettingTransaction simulates the transaction service for testing.
+type FakeForGettingTransaction struct {
+	ReturnErr error
+}
+
+func (f *FakeForGettingTransaction) GetTransaction(ctx context.Context, id string) (*transactions.Transaction, error) {
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	transaction := transactions.NewTransaction(id, "12345", money.MustParse("-4.50", "EUR"), transactions.KindDebit, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), "Coffee")
+	transaction.Version = 3
+	return transaction, nil
+}
+
+// Test getting a transaction sends its version as the ETag
+func TestForGettingTransactionUsingRestAPI_Success(t *testing.T) {
+	apiHandler := NewForGettingTransactionUsingRestAPI(&FakeForGettingTransaction{})
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/7", nil)
+	req.SetPathValue("id", "7")
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"ID":"7"`)
+	assert.Contains(t, respRecorder.Body.String(), `"Version":3`)
+	assert.Equal(t, `"3"`, respRecorder.Header().Get("ETag"))
+}
+
+// Test service errors map onto their status codes
+func TestForGettingTransactionUsingRestAPI_Errors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code int
+	}{
+		"not found": {transactions.ErrTransactionNotFound, http.StatusNotFound},
+		"forbidden": {&access.ForbiddenError{AccountID: "12345", Permission: access.PermissionView}, http.StatusForbidden},
+		"failure":   {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := NewForGettingTransactionUsingRestAPI(&FakeForGettingTransaction{ReturnErr: tt.err})
+
+			req := httptest.NewRequest(http.MethodGet, "/transactions/7", nil)
+			respRecorder := httptest.NewRecorder()
+
+			apiHandler.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.code, respRecorder.Code)
+		})
+	}
+}
+
+// Test that only GET is accepted
+func TestForGettingTransactionUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForGettingTransactionUsingRestAPI(&FakeForGettingTransaction{})
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions/7", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
@@ -0,0 +1,45 @@
+package transactions
+
+import (
+	"encoding/json"
+	"net/http"
+	"spend-api/internal/domain/transactions"
+)
+
+// ForListingDuplicateResolutionsUsingRestAPI is the REST API adapter for listing resolved duplicates.
+type ForListingDuplicateResolutionsUsingRestAPI struct {
+	duplicateService transactions.ForListingDuplicateResolutions
+}
+
+// NewForListingDuplicateResolutionsUsingRestAPI creates a new REST handler for listing resolved duplicates.
+func NewForListingDuplicateResolutionsUsingRestAPI(service transactions.ForListingDuplicateResolutions) *ForListingDuplicateResolutionsUsingRestAPI {
+	return &ForListingDuplicateResolutionsUsingRestAPI{
+		duplicateService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for listing the audit trail of merged and dismissed duplicates,
+// most recent first.
+func (h *ForListingDuplicateResolutionsUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := h.duplicateService.ListDuplicateResolutions(r.Context(), transactions.ResolutionFilter{})
+	if err != nil {
+		http.Error(w, "Failed to list duplicate resolutions", http.StatusInternalServerError)
+		return
+	}
+	if result == nil {
+		result = []*transactions.DuplicateResolution{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
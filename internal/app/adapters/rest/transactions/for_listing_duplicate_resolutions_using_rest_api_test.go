@@ -0,0 +1,41 @@
+package transactions
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test for listing duplicate resolutions via the REST API
+func TestForListingDuplicateResolutionsUsingRestAPI(t *testing.T) {
+	fakeDuplicateService := &FakeDuplicateService{Resolutions: []*transactions.DuplicateResolution{{ID: "1", Action: transactions.ResolutionDismissed, KeptID: "1", DuplicateID: "2"}}}
+	respRecorder := httptest.NewRecorder()
+
+	NewForListingDuplicateResolutionsUsingRestAPI(fakeDuplicateService).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/duplicates/resolutions", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"Action":"dismissed"`)
+}
+
+// Test listing no resolutions returns an empty array
+func TestForListingDuplicateResolutionsUsingRestAPI_Empty(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForListingDuplicateResolutionsUsingRestAPI(&FakeDuplicateService{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/duplicates/resolutions", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.JSONEq(t, `[]`, respRecorder.Body.String())
+}
+
+// Test listing failure
+func TestForListingDuplicateResolutionsUsingRestAPI_Failure(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForListingDuplicateResolutionsUsingRestAPI(&FakeDuplicateService{ReturnErr: errors.New("database down")}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/duplicates/resolutions", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
@@ -0,0 +1,44 @@
+package transactions
+
+import (
+	"encoding/json"
+	"net/http"
+	"spend-api/internal/domain/transactions"
+)
+
+// ForListingRulesUsingRestAPI is the REST API adapter for listing categorisation rules.
+type ForListingRulesUsingRestAPI struct {
+	ruleService transactions.ForListingRules
+}
+
+// NewForListingRulesUsingRestAPI creates a new REST handler for listing categorisation rules.
+func NewForListingRulesUsingRestAPI(service transactions.ForListingRules) *ForListingRulesUsingRestAPI {
+	return &ForListingRulesUsingRestAPI{
+		ruleService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for listing categorisation rules in the order they are tried.
+func (h *ForListingRulesUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := h.ruleService.ListRules(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to list rules", http.StatusInternalServerError)
+		return
+	}
+	if result == nil {
+		result = []*transactions.Rule{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,42 @@
+package transactions
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test for listing rules via the REST API
+func TestForListingRulesUsingRestAPI(t *testing.T) {
+	fakeRuleService := &FakeRuleService{Rules: []*transactions.Rule{{ID: "1", Name: "Coffee", DescriptionContains: "starbucks", CategoryID: "7"}}}
+	apiHandler := NewForListingRulesUsingRestAPI(fakeRuleService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/rules", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"DescriptionContains":"starbucks"`)
+}
+
+// Test listing no rules returns an empty array
+func TestForListingRulesUsingRestAPI_Empty(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForListingRulesUsingRestAPI(&FakeRuleService{}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/rules", nil))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.JSONEq(t, `[]`, respRecorder.Body.String())
+}
+
+// Test listing failure
+func TestForListingRulesUsingRestAPI_Failure(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForListingRulesUsingRestAPI(&FakeRuleService{ReturnErr: errors.New("database down")}).ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/rules", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
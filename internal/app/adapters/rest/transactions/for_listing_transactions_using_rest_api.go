@@ -0,0 +1,135 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"strconv"
+	"time"
+)
+
+// dateLayout is the format accepted for date query parameters
+const dateLayout = "2006-01-02"
+
+// ForListingTransactionsUsingRestAPI is the REST API adapter for listing transactions.
+type ForListingTransactionsUsingRestAPI struct {
+	transactionService transactions.ForListingTransactions
+}
+
+// NewForListingTransactionsUsingRestAPI creates a new REST handler for listing transactions.
+func NewForListingTransactionsUsingRestAPI(service transactions.ForListingTransactions) *ForListingTransactionsUsingRestAPI {
+	return &ForListingTransactionsUsingRestAPI{
+		transactionService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for listing transactions. Supported query
+// parameters are accountID, categoryID, from, to, type, status, minAmount, maxAmount,
+// description, transferID, sortBy, sortOrder, limit and cursor. Listings of a single account sorted by date,
+// and not narrowed by any other filter, include each transaction's running balance.
+func (h *ForListingTransactionsUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseTransactionFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.transactionService.ListTransactions(r.Context(), filter)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, transactions.ErrAccountNotFound) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, transactions.ErrInvalidFilter) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to list transactions", http.StatusInternalServerError)
+		return
+	}
+	if page.Transactions == nil {
+		page.Transactions = []*transactions.Transaction{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(page)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// parseTransactionFilter reads the listing filter from the query string
+func parseTransactionFilter(query url.Values) (transactions.TransactionFilter, error) {
+	filter := transactions.TransactionFilter{
+		AccountID:   query.Get("accountID"),
+		CategoryID:  query.Get("categoryID"),
+		Type:        transactions.Kind(query.Get("type")),
+		Status:      transactions.Status(query.Get("status")),
+		Description: query.Get("description"),
+		TransferID:  query.Get("transferID"),
+		SortBy:      transactions.SortField(query.Get("sortBy")),
+		SortOrder:   transactions.SortOrder(query.Get("sortOrder")),
+		Cursor:      query.Get("cursor"),
+	}
+
+	var err error
+	if filter.From, err = parseDateParam(query, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseDateParam(query, "to"); err != nil {
+		return filter, err
+	}
+	if filter.MinAmount, err = parseAmountParam(query, "minAmount"); err != nil {
+		return filter, err
+	}
+	if filter.MaxAmount, err = parseAmountParam(query, "maxAmount"); err != nil {
+		return filter, err
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid limit %q", value)
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
+
+func parseDateParam(query url.Values, name string) (*time.Time, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s date %q, expected YYYY-MM-DD", name, value)
+	}
+	return &date, nil
+}
+
+func parseAmountParam(query url.Values, name string) (*money.Decimal, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	amount, err := money.ParseDecimal(value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s %q", name, value)
+	}
+	return &amount, nil
+}
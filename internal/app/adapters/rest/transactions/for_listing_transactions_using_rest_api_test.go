@@ -0,0 +1,161 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForListingTransactions simulates the transaction service for testing.
+type FakeForListingTransactions struct {
+	ReturnErr error
+	Filter    transactions.TransactionFilter
+	Page      *transactions.TransactionPage
+}
+
+func (f *FakeForListingTransactions) ListTransactions(ctx context.Context, filter transactions.TransactionFilter) (*transactions.TransactionPage, error) {
+	f.Filter = filter
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	if f.Page != nil {
+		return f.Page, nil
+	}
+	return &transactions.TransactionPage{}, nil
+}
+
+// Test for listing transactions via the REST API
+func TestForListingTransactionsUsingRestAPI_Success(t *testing.T) {
+	fakeTransactionService := &FakeForListingTransactions{Page: &transactions.TransactionPage{
+		Transactions: []*transactions.Transaction{{ID: "txn123", AccountID: "12345", Amount: money.MustParse("100.00", "EUR"), Type: "credit"}},
+		NextCursor:   "abc",
+	}}
+	apiHandler := NewForListingTransactionsUsingRestAPI(fakeTransactionService)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions?accountID=12345", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"ID":"txn123"`)
+	assert.Contains(t, respRecorder.Body.String(), `"NextCursor":"abc"`)
+	assert.Equal(t, "12345", fakeTransactionService.Filter.AccountID)
+}
+
+// Test that every query parameter is mapped onto the filter
+func TestForListingTransactionsUsingRestAPI_QueryParameters(t *testing.T) {
+	fakeTransactionService := &FakeForListingTransactions{}
+	apiHandler := NewForListingTransactionsUsingRestAPI(fakeTransactionService)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions?accountID=1&categoryID=4&from=2024-01-01&to=2024-01-31&type=debit&status=pending"+
+		"&minAmount=10&maxAmount=99.5&description=coffee&transferID=8&sortBy=amount&sortOrder=asc&limit=20&cursor=xyz", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	filter := fakeTransactionService.Filter
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "1", filter.AccountID)
+	assert.Equal(t, "4", filter.CategoryID)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *filter.From)
+	assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), *filter.To)
+	assert.Equal(t, transactions.KindDebit, filter.Type)
+	assert.Equal(t, transactions.StatusPending, filter.Status)
+	assert.Equal(t, "10", filter.MinAmount.String())
+	assert.Equal(t, "99.5", filter.MaxAmount.String())
+	assert.Equal(t, "coffee", filter.Description)
+	assert.Equal(t, "8", filter.TransferID)
+	assert.Equal(t, transactions.SortByAmount, filter.SortBy)
+	assert.Equal(t, transactions.SortAscending, filter.SortOrder)
+	assert.Equal(t, 20, filter.Limit)
+	assert.Equal(t, "xyz", filter.Cursor)
+}
+
+// Test that an empty page is encoded with an empty JSON array
+func TestForListingTransactionsUsingRestAPI_Empty(t *testing.T) {
+	apiHandler := NewForListingTransactionsUsingRestAPI(&FakeForListingTransactions{})
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.JSONEq(t, `{"Transactions":[],"NextCursor":""}`, respRecorder.Body.String())
+}
+
+// Test for malformed query parameters
+func TestForListingTransactionsUsingRestAPI_InvalidParameters(t *testing.T) {
+	for _, query := range []string{"from=yesterday", "to=2024-13-01", "minAmount=ten", "maxAmount=lots", "limit=many"} {
+		t.Run(query, func(t *testing.T) {
+			apiHandler := NewForListingTransactionsUsingRestAPI(&FakeForListingTransactions{})
+
+			req := httptest.NewRequest(http.MethodGet, "/transactions?"+query, nil)
+			respRecorder := httptest.NewRecorder()
+
+			apiHandler.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+		})
+	}
+}
+
+// Test that filter validation errors from the service are reported as bad requests
+func TestForListingTransactionsUsingRestAPI_InvalidFilter(t *testing.T) {
+	apiHandler := NewForListingTransactionsUsingRestAPI(&FakeForListingTransactions{
+		ReturnErr: fmt.Errorf("%w: malformed cursor", transactions.ErrInvalidFilter),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions?cursor=bad", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), "malformed cursor")
+}
+
+// Test for invalid HTTP method
+func TestForListingTransactionsUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForListingTransactionsUsingRestAPI(&FakeForListingTransactions{})
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
+
+// Test for internal server error from the transaction service
+func TestForListingTransactionsUsingRestAPI_ServiceError(t *testing.T) {
+	apiHandler := NewForListingTransactionsUsingRestAPI(&FakeForListingTransactions{ReturnErr: errors.New("boom")})
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
+}
+
+// Test listing the transactions of an unknown account is not found
+func TestForListingTransactionsUsingRestAPI_AccountNotFound(t *testing.T) {
+	apiHandler := NewForListingTransactionsUsingRestAPI(&FakeForListingTransactions{ReturnErr: transactions.ErrAccountNotFound})
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions?accountID=99", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusNotFound, respRecorder.Code)
+}
@@ -0,0 +1,79 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/transactions"
+	"time"
+)
+
+// ForPostingTransactionUsingRestAPI is the REST API adapter for posting pending transactions.
+type ForPostingTransactionUsingRestAPI struct {
+	transactionService transactions.ForPostingTransaction
+}
+
+// NewForPostingTransactionUsingRestAPI creates a new REST handler for posting pending transactions.
+func NewForPostingTransactionUsingRestAPI(service transactions.ForPostingTransaction) *ForPostingTransactionUsingRestAPI {
+	return &ForPostingTransactionUsingRestAPI{
+		transactionService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for posting the transaction identified by the
+// {id} path value. The body may give a postedDate; it defaults to today.
+func (h *ForPostingTransactionUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		PostedDate string `json:"postedDate"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	postedDate := transactions.DateOf(time.Now())
+	if requestBody.PostedDate != "" {
+		parsed, err := time.Parse(dateLayout, requestBody.PostedDate)
+		if err != nil {
+			http.Error(w, "Invalid postedDate, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		postedDate = parsed
+	}
+
+	transaction, err := h.transactionService.PostTransaction(r.Context(), r.PathValue("id"), postedDate)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, transactions.ErrTransactionNotFound) {
+		http.Error(w, "Transaction not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, transactions.ErrInvalidStatusTransition) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if errors.Is(err, transactions.ErrInvalidTransaction) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to post transaction", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(transaction)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,102 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForPostingTransaction simulates the transaction service for testing.
+type FakeForPostingTransaction struct {
+	ReturnErr  error
+	ID         string
+	PostedDate time.Time
+}
+
+func (f *FakeForPostingTransaction) PostTransaction(ctx context.Context, id string, postedDate time.Time) (*transactions.Transaction, error) {
+	f.ID, f.PostedDate = id, postedDate
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return &transactions.Transaction{ID: id, Status: transactions.StatusPosted, PostedDate: &postedDate}, nil
+}
+
+func newPostTransactionRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/transactions/7/post", bytes.NewReader([]byte(body)))
+	req.SetPathValue("id", "7")
+	return req
+}
+
+// Test posting a transaction on a given date
+func TestForPostingTransactionUsingRestAPI(t *testing.T) {
+	fakeTransactionService := &FakeForPostingTransaction{}
+	apiHandler := NewForPostingTransactionUsingRestAPI(fakeTransactionService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newPostTransactionRequest(`{"postedDate":"2024-01-04"}`))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "7", fakeTransactionService.ID)
+	assert.Equal(t, day(2024, 1, 4), fakeTransactionService.PostedDate)
+	assert.Contains(t, respRecorder.Body.String(), `"Status":"posted"`)
+}
+
+// Test posting without a body posts the transaction today
+func TestForPostingTransactionUsingRestAPI_DefaultsToToday(t *testing.T) {
+	fakeTransactionService := &FakeForPostingTransaction{}
+	apiHandler := NewForPostingTransactionUsingRestAPI(fakeTransactionService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newPostTransactionRequest(""))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, transactions.DateOf(time.Now()), fakeTransactionService.PostedDate)
+}
+
+// Test for invalid HTTP method
+func TestForPostingTransactionUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForPostingTransactionUsingRestAPI(&FakeForPostingTransaction{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodGet, "/transactions/7/post", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
+
+// Test malformed bodies are bad requests
+func TestForPostingTransactionUsingRestAPI_InvalidBody(t *testing.T) {
+	for _, body := range []string{`invalid json`, `{"postedDate":"04/01/2024"}`} {
+		apiHandler := NewForPostingTransactionUsingRestAPI(&FakeForPostingTransaction{})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newPostTransactionRequest(body))
+
+		assert.Equal(t, http.StatusBadRequest, respRecorder.Code, body)
+	}
+}
+
+// Test domain errors map onto client errors
+func TestForPostingTransactionUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		transactions.ErrTransactionNotFound:     http.StatusNotFound,
+		transactions.ErrInvalidStatusTransition: http.StatusConflict,
+		transactions.ErrInvalidTransaction:      http.StatusBadRequest,
+		errors.New("database is down"):          http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		apiHandler := NewForPostingTransactionUsingRestAPI(&FakeForPostingTransaction{ReturnErr: serviceErr})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newPostTransactionRequest(`{}`))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
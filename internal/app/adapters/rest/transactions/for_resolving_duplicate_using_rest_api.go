@@ -0,0 +1,68 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/transactions"
+)
+
+// ForResolvingDuplicateUsingRestAPI is the REST API adapter for merging or dismissing likely duplicates.
+type ForResolvingDuplicateUsingRestAPI struct {
+	duplicateService transactions.ForResolvingDuplicate
+}
+
+// NewForResolvingDuplicateUsingRestAPI creates a new REST handler for resolving likely duplicates.
+func NewForResolvingDuplicateUsingRestAPI(service transactions.ForResolvingDuplicate) *ForResolvingDuplicateUsingRestAPI {
+	return &ForResolvingDuplicateUsingRestAPI{
+		duplicateService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for resolving a pair of likely duplicates. The action is
+// "merged", which keeps keptID and voids duplicateID, or "dismissed", which keeps both.
+// Resolutions that cannot be applied are reported with 422 Unprocessable Entity.
+func (h *ForResolvingDuplicateUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		Action      string `json:"action"`
+		KeptID      string `json:"keptID"`
+		DuplicateID string `json:"duplicateID"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resolution, err := h.duplicateService.ResolveDuplicate(r.Context(), transactions.ResolutionAction(requestBody.Action), requestBody.KeptID, requestBody.DuplicateID)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	var invalid *transactions.ValidationError
+	if errors.As(err, &invalid) {
+		writeValidationError(w, invalid)
+		return
+	}
+	if errors.Is(err, transactions.ErrTransactionNotFound) {
+		http.Error(w, "Transaction not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to resolve duplicate", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(resolution)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,108 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeDuplicateService simulates the duplicate service for testing.
+type FakeDuplicateService struct {
+	ReturnErr   error
+	Pairs       []*transactions.DuplicatePair
+	Resolutions []*transactions.DuplicateResolution
+	Filter      transactions.DuplicateFilter
+	Action      transactions.ResolutionAction
+	KeptID      string
+	DuplicateID string
+}
+
+func (f *FakeDuplicateService) FindDuplicates(ctx context.Context, filter transactions.DuplicateFilter) ([]*transactions.DuplicatePair, error) {
+	f.Filter = filter
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return f.Pairs, nil
+}
+
+func (f *FakeDuplicateService) ResolveDuplicate(ctx context.Context, action transactions.ResolutionAction, keptID, duplicateID string) (*transactions.DuplicateResolution, error) {
+	f.Action, f.KeptID, f.DuplicateID = action, keptID, duplicateID
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	resolvedAt := time.Date(2024, 1, 20, 9, 30, 0, 0, time.UTC)
+	return &transactions.DuplicateResolution{ID: "1", Action: action, KeptID: keptID, DuplicateID: duplicateID, ResolvedAt: resolvedAt}, nil
+}
+
+func (f *FakeDuplicateService) ListDuplicateResolutions(ctx context.Context, filter transactions.ResolutionFilter) ([]*transactions.DuplicateResolution, error) {
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return f.Resolutions, nil
+}
+
+// Test for merging a pair of duplicates via the REST API
+func TestForResolvingDuplicateUsingRestAPI(t *testing.T) {
+	fakeDuplicateService := &FakeDuplicateService{}
+	apiHandler := NewForResolvingDuplicateUsingRestAPI(fakeDuplicateService)
+	respRecorder := httptest.NewRecorder()
+
+	body := `{"action":"merged","keptID":"1","duplicateID":"2"}`
+	apiHandler.ServeHTTP(respRecorder, newRuleRequest(http.MethodPost, "/transactions/duplicates/resolutions", body))
+
+	assert.Equal(t, http.StatusCreated, respRecorder.Code)
+	assert.JSONEq(t, `{"ID":"1","Action":"merged","KeptID":"1","DuplicateID":"2","ResolvedAt":"2024-01-20T09:30:00Z"}`, respRecorder.Body.String())
+	assert.Equal(t, transactions.ResolutionMerged, fakeDuplicateService.Action)
+	assert.Equal(t, "1", fakeDuplicateService.KeptID)
+	assert.Equal(t, "2", fakeDuplicateService.DuplicateID)
+}
+
+// Test an unreadable body is a bad request
+func TestForResolvingDuplicateUsingRestAPI_InvalidBody(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForResolvingDuplicateUsingRestAPI(&FakeDuplicateService{}).ServeHTTP(respRecorder, newRuleRequest(http.MethodPost, "/transactions/duplicates/resolutions", `{"keptID":1}`))
+
+	assert.Equal(t, http.StatusBadRequest, respRecorder.Code)
+}
+
+// Test resolution failures map onto status codes
+func TestForResolvingDuplicateUsingRestAPI_Errors(t *testing.T) {
+	invalid := &transactions.ValidationError{Err: transactions.ErrInvalidResolution}
+	invalid.Add("duplicateID", "has a different amount")
+	cases := map[error]int{
+		invalid:                             http.StatusUnprocessableEntity,
+		transactions.ErrTransactionNotFound: http.StatusNotFound,
+		&access.ForbiddenError{AccountID: "12345", Permission: access.PermissionEdit}: http.StatusForbidden,
+		errors.New("database down"): http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		apiHandler := NewForResolvingDuplicateUsingRestAPI(&FakeDuplicateService{ReturnErr: serviceErr})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newRuleRequest(http.MethodPost, "/transactions/duplicates/resolutions", `{"action":"merged","keptID":"1","duplicateID":"2"}`))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+
+	respRecorder := httptest.NewRecorder()
+	NewForResolvingDuplicateUsingRestAPI(&FakeDuplicateService{ReturnErr: invalid}).ServeHTTP(respRecorder, newRuleRequest(http.MethodPost, "/transactions/duplicates/resolutions", `{}`))
+	assert.JSONEq(t, `{"Error":"Invalid resolution","Fields":[{"Field":"duplicateID","Message":"has a different amount"}]}`, respRecorder.Body.String())
+}
+
+// Test an invalid method is rejected
+func TestForResolvingDuplicateUsingRestAPI_InvalidMethod(t *testing.T) {
+	respRecorder := httptest.NewRecorder()
+
+	NewForResolvingDuplicateUsingRestAPI(&FakeDuplicateService{}).ServeHTTP(respRecorder, newRuleRequest(http.MethodGet, "/transactions/duplicates/resolutions", ""))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
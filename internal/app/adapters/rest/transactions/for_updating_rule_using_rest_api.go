@@ -0,0 +1,62 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/transactions"
+)
+
+// ForUpdatingRuleUsingRestAPI is the REST API adapter for updating categorisation rules.
+type ForUpdatingRuleUsingRestAPI struct {
+	ruleService transactions.ForUpdatingRule
+}
+
+// NewForUpdatingRuleUsingRestAPI creates a new REST handler for updating categorisation rules.
+func NewForUpdatingRuleUsingRestAPI(service transactions.ForUpdatingRule) *ForUpdatingRuleUsingRestAPI {
+	return &ForUpdatingRuleUsingRestAPI{
+		ruleService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for replacing the rule identified by the {id} path value.
+// The body takes the same fields as when creating a rule; fields left out are cleared.
+func (h *ForUpdatingRuleUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rule, err := decodeRule(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	rule, err = h.ruleService.UpdateRule(r.Context(), r.PathValue("id"), rule)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, transactions.ErrRuleNotFound) {
+		http.Error(w, "Rule not found", http.StatusNotFound)
+		return
+	}
+	var invalid *transactions.ValidationError
+	if errors.As(err, &invalid) {
+		writeValidationError(w, invalid)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to update rule", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(rule)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,51 @@
+package transactions
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newUpdateRuleRequest(body string) *http.Request {
+	req := newRuleRequest(http.MethodPut, "/rules/4", body)
+	req.SetPathValue("id", "4")
+	return req
+}
+
+// Test for replacing a rule via the REST API
+func TestForUpdatingRuleUsingRestAPI(t *testing.T) {
+	fakeRuleService := &FakeRuleService{}
+	apiHandler := NewForUpdatingRuleUsingRestAPI(fakeRuleService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newUpdateRuleRequest(`{"name":"Coffee","descriptionPattern":"(?i)starbucks|costa","categoryID":"7"}`))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "4", fakeRuleService.ID)
+	assert.Equal(t, "(?i)starbucks|costa", fakeRuleService.Rule.DescriptionPattern)
+	assert.Nil(t, fakeRuleService.Rule.MinAmount, "Bounds left out should be cleared")
+}
+
+// Test domain errors map onto client errors
+func TestForUpdatingRuleUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		transactions.ErrRuleNotFound:                                                  http.StatusNotFound,
+		&transactions.ValidationError{Err: transactions.ErrInvalidRule}:               http.StatusUnprocessableEntity,
+		&access.ForbiddenError{AccountID: "12345", Permission: access.PermissionEdit}: http.StatusForbidden,
+		errors.New("database down"):                                                   http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		apiHandler := NewForUpdatingRuleUsingRestAPI(&FakeRuleService{ReturnErr: serviceErr})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newUpdateRuleRequest(`{"name":"Coffee"}`))
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
@@ -0,0 +1,101 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+)
+
+// ForUpdatingTransactionUsingRestAPI is the REST API adapter for editing transactions.
+type ForUpdatingTransactionUsingRestAPI struct {
+	transactionService transactions.ForUpdatingTransaction
+}
+
+// NewForUpdatingTransactionUsingRestAPI creates a new REST handler for editing transactions.
+func NewForUpdatingTransactionUsingRestAPI(service transactions.ForUpdatingTransaction) *ForUpdatingTransactionUsingRestAPI {
+	return &ForUpdatingTransactionUsingRestAPI{
+		transactionService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for editing the description and amount of the transaction
+// identified by the {id} path value; fields left out of the body keep their values. Invalid
+// fields are reported with 422 Unprocessable Entity. With an If-Match header the edit only goes
+// ahead if the transaction is still at that ETag, and fails with 412 Precondition Failed
+// otherwise.
+func (h *ForUpdatingTransactionUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		Description *string     `json:"description"`
+		Amount      json.Number `json:"amount"`
+		Currency    string      `json:"currency"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	invalid := &transactions.ValidationError{}
+	changes := transactions.TransactionChanges{Description: requestBody.Description}
+	if requestBody.Amount != "" {
+		currency, err := money.ParseCurrency(requestBody.Currency)
+		if err != nil {
+			invalid.Add("currency", err.Error())
+		}
+		if currency.IsValid() {
+			amount, err := money.Parse(requestBody.Amount.String(), currency)
+			if err != nil {
+				invalid.Add("amount", err.Error())
+			}
+			changes.Amount = &amount
+		}
+	}
+	if len(invalid.Fields) > 0 {
+		writeValidationError(w, invalid)
+		return
+	}
+
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		http.Error(w, "Transaction does not match If-Match", http.StatusPreconditionFailed)
+		return
+	}
+
+	transaction, err := h.transactionService.UpdateTransaction(r.Context(), r.PathValue("id"), changes, version)
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, transactions.ErrTransactionNotFound) {
+		http.Error(w, "Transaction not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, transactions.ErrVersionMismatch) {
+		http.Error(w, "Transaction does not match If-Match", http.StatusPreconditionFailed)
+		return
+	}
+	if errors.As(err, &invalid) {
+		writeValidationError(w, invalid)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to update transaction", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(transaction.Version))
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(transaction)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
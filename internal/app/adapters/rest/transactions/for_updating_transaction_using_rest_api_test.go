@@ -0,0 +1,138 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/access"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"testing"
+	"time"
+)
+
+// FakeForUpdatingTransaction simulates the edit service for testing.
+type FakeForUpdatingTransaction struct {
+	ReturnErr error
+	Changes   transactions.TransactionChanges
+	Version   int
+}
+
+func (f *FakeForUpdatingTransaction) UpdateTransaction(ctx context.Context, id string, changes transactions.TransactionChanges, version int) (*transactions.Transaction, error) {
+	f.Changes, f.Version = changes, version
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	transaction := transactions.NewTransaction(id, "12345", money.MustParse("-4.50", "EUR"), transactions.KindDebit, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), "Coffee")
+	if changes.Amount != nil {
+		transaction.Amount = *changes.Amount
+	}
+	transaction.Version = 4
+	return transaction, nil
+}
+
+func newUpdateTransactionRequest(body, ifMatch string) *http.Request {
+	req := httptest.NewRequest(http.MethodPatch, "/transactions/7", bytes.NewReader([]byte(body)))
+	req.SetPathValue("id", "7")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	return req
+}
+
+// Test editing a transaction passes the changes and If-Match version on and returns the new ETag
+func TestForUpdatingTransactionUsingRestAPI_Success(t *testing.T) {
+	fakeService := &FakeForUpdatingTransaction{}
+	apiHandler := NewForUpdatingTransactionUsingRestAPI(fakeService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newUpdateTransactionRequest(`{"description":"Coffee","amount":"-5.40","currency":"EUR"}`, `"3"`))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "Coffee", *fakeService.Changes.Description)
+	assert.Equal(t, money.MustParse("-5.40", "EUR"), *fakeService.Changes.Amount)
+	assert.Equal(t, 3, fakeService.Version)
+	assert.Equal(t, `"4"`, respRecorder.Header().Get("ETag"))
+	assert.Contains(t, respRecorder.Body.String(), `"Amount":{"amount":"-5.40","currency":"EUR"}`)
+}
+
+// Test fields left out of the body are not changed, and no If-Match means no version check
+func TestForUpdatingTransactionUsingRestAPI_Partial(t *testing.T) {
+	fakeService := &FakeForUpdatingTransaction{}
+	apiHandler := NewForUpdatingTransactionUsingRestAPI(fakeService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newUpdateTransactionRequest(`{"description":"Coffee"}`, ""))
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Nil(t, fakeService.Changes.Amount)
+	assert.Equal(t, 0, fakeService.Version)
+}
+
+// Test malformed fields are reported before the service is called
+func TestForUpdatingTransactionUsingRestAPI_InvalidFields(t *testing.T) {
+	fakeService := &FakeForUpdatingTransaction{}
+	apiHandler := NewForUpdatingTransactionUsingRestAPI(fakeService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newUpdateTransactionRequest(`{"amount":"-5.40","currency":"XYZ"}`, ""))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, respRecorder.Code)
+	assert.Contains(t, respRecorder.Body.String(), `"Field":"currency"`)
+	assert.Nil(t, fakeService.Changes.Description)
+}
+
+// Test a stale or unusable If-Match fails the precondition
+func TestForUpdatingTransactionUsingRestAPI_PreconditionFailed(t *testing.T) {
+	apiHandler := NewForUpdatingTransactionUsingRestAPI(&FakeForUpdatingTransaction{ReturnErr: transactions.ErrVersionMismatch})
+	respRecorder := httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, newUpdateTransactionRequest(`{"description":"Coffee"}`, `"2"`))
+	assert.Equal(t, http.StatusPreconditionFailed, respRecorder.Code)
+
+	fakeService := &FakeForUpdatingTransaction{}
+	apiHandler = NewForUpdatingTransactionUsingRestAPI(fakeService)
+	respRecorder = httptest.NewRecorder()
+	apiHandler.ServeHTTP(respRecorder, newUpdateTransactionRequest(`{"description":"Coffee"}`, `W/"3"`))
+	assert.Equal(t, http.StatusPreconditionFailed, respRecorder.Code)
+	assert.Nil(t, fakeService.Changes.Description, "The service should not be called")
+}
+
+// Test service errors map onto their status codes
+func TestForUpdatingTransactionUsingRestAPI_Errors(t *testing.T) {
+	invalid := &transactions.ValidationError{}
+	invalid.Add("status", "a voided transaction cannot be edited")
+	tests := map[string]struct {
+		err  error
+		code int
+	}{
+		"invalid":   {invalid, http.StatusUnprocessableEntity},
+		"not found": {transactions.ErrTransactionNotFound, http.StatusNotFound},
+		"forbidden": {&access.ForbiddenError{AccountID: "12345", Permission: access.PermissionEdit, Role: access.RoleViewer}, http.StatusForbidden},
+		"failure":   {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiHandler := NewForUpdatingTransactionUsingRestAPI(&FakeForUpdatingTransaction{ReturnErr: tt.err})
+			respRecorder := httptest.NewRecorder()
+
+			apiHandler.ServeHTTP(respRecorder, newUpdateTransactionRequest(`{"description":"Coffee"}`, ""))
+
+			assert.Equal(t, tt.code, respRecorder.Code)
+		})
+	}
+}
+
+// Test that only PATCH is accepted
+func TestForUpdatingTransactionUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForUpdatingTransactionUsingRestAPI(&FakeForUpdatingTransaction{})
+
+	req := httptest.NewRequest(http.MethodPut, "/transactions/7", nil)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
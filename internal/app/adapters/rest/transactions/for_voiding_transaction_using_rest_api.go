@@ -0,0 +1,54 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	restAccess "spend-api/internal/app/adapters/rest/access"
+	"spend-api/internal/domain/transactions"
+)
+
+// ForVoidingTransactionUsingRestAPI is the REST API adapter for voiding transactions.
+type ForVoidingTransactionUsingRestAPI struct {
+	transactionService transactions.ForVoidingTransaction
+}
+
+// NewForVoidingTransactionUsingRestAPI creates a new REST handler for voiding transactions.
+func NewForVoidingTransactionUsingRestAPI(service transactions.ForVoidingTransaction) *ForVoidingTransactionUsingRestAPI {
+	return &ForVoidingTransactionUsingRestAPI{
+		transactionService: service,
+	}
+}
+
+// ServeHTTP handles HTTP requests for voiding the transaction identified by the {id} path value.
+func (h *ForVoidingTransactionUsingRestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	transaction, err := h.transactionService.VoidTransaction(r.Context(), r.PathValue("id"))
+	if restAccess.WriteForbidden(w, err) {
+		return
+	}
+	if errors.Is(err, transactions.ErrTransactionNotFound) {
+		http.Error(w, "Transaction not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, transactions.ErrInvalidStatusTransition) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to void transaction", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(transaction)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,73 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"spend-api/internal/domain/transactions"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForVoidingTransaction simulates the transaction service for testing.
+type FakeForVoidingTransaction struct {
+	ReturnErr error
+	ID        string
+}
+
+func (f *FakeForVoidingTransaction) VoidTransaction(ctx context.Context, id string) (*transactions.Transaction, error) {
+	f.ID = id
+	if f.ReturnErr != nil {
+		return nil, f.ReturnErr
+	}
+	return &transactions.Transaction{ID: id, Status: transactions.StatusVoided}, nil
+}
+
+func newVoidTransactionRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/transactions/7/void", nil)
+	req.SetPathValue("id", "7")
+	return req
+}
+
+// Test voiding a transaction
+func TestForVoidingTransactionUsingRestAPI(t *testing.T) {
+	fakeTransactionService := &FakeForVoidingTransaction{}
+	apiHandler := NewForVoidingTransactionUsingRestAPI(fakeTransactionService)
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, newVoidTransactionRequest())
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, "7", fakeTransactionService.ID)
+	assert.Contains(t, respRecorder.Body.String(), `"Status":"voided"`)
+}
+
+// Test for invalid HTTP method
+func TestForVoidingTransactionUsingRestAPI_InvalidMethod(t *testing.T) {
+	apiHandler := NewForVoidingTransactionUsingRestAPI(&FakeForVoidingTransaction{})
+	respRecorder := httptest.NewRecorder()
+
+	apiHandler.ServeHTTP(respRecorder, httptest.NewRequest(http.MethodDelete, "/transactions/7/void", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, respRecorder.Code)
+}
+
+// Test domain errors map onto client errors
+func TestForVoidingTransactionUsingRestAPI_Errors(t *testing.T) {
+	cases := map[error]int{
+		transactions.ErrTransactionNotFound:     http.StatusNotFound,
+		transactions.ErrInvalidStatusTransition: http.StatusConflict,
+		errors.New("database is down"):          http.StatusInternalServerError,
+	}
+
+	for serviceErr, status := range cases {
+		apiHandler := NewForVoidingTransactionUsingRestAPI(&FakeForVoidingTransaction{ReturnErr: serviceErr})
+		respRecorder := httptest.NewRecorder()
+
+		apiHandler.ServeHTTP(respRecorder, newVoidTransactionRequest())
+
+		assert.Equal(t, status, respRecorder.Code, serviceErr.Error())
+	}
+}
@@ -7,27 +7,37 @@ import (
 
 // Config holds the application configuration
 type Config struct {
-	DbUser         string
-	DbPassword     string
-	DbHost         string
-	DbPort         string
-	DbName         string
-	CACertPath     string
-	ClientCertPath string
-	ClientKeyPath  string
+	DbUser           string
+	DbPassword       string
+	DbHost           string
+	DbPort           string
+	DbName           string
+	CACertPath       string
+	ClientCertPath   string
+	ClientKeyPath    string
+	JWTSecret        string
+	JWTPublicKeyPath string
+	JWKSPath         string
+	JWTIssuer        string
+	JWTAudience      string
 }
 
 // LoadConfig loads the configuration from environment variables
 func LoadConfig() *Config {
 	return &Config{
-		DbUser:         getEnvOrPanic("DB_USER"),
-		DbPassword:     getEnvOrPanic("DB_PASSWORD"),
-		DbHost:         getEnvOrPanic("DB_HOST"),
-		DbPort:         getEnvOrPanic("DB_PORT"),
-		DbName:         getEnvOrPanic("DB_NAME"),
-		CACertPath:     getEnv("CACERT_PATH", ""),
-		ClientCertPath: getEnv("CLIENT_CERT_PATH", ""),
-		ClientKeyPath:  getEnv("CLIENT_KEY_PATH", ""),
+		DbUser:           getEnvOrPanic("DB_USER"),
+		DbPassword:       getEnvOrPanic("DB_PASSWORD"),
+		DbHost:           getEnvOrPanic("DB_HOST"),
+		DbPort:           getEnvOrPanic("DB_PORT"),
+		DbName:           getEnvOrPanic("DB_NAME"),
+		CACertPath:       getEnv("CACERT_PATH", ""),
+		ClientCertPath:   getEnv("CLIENT_CERT_PATH", ""),
+		ClientKeyPath:    getEnv("CLIENT_KEY_PATH", ""),
+		JWTSecret:        getEnv("JWT_HS256_SECRET", ""),
+		JWTPublicKeyPath: getEnv("JWT_PUBLIC_KEY_PATH", ""),
+		JWKSPath:         getEnv("JWKS_PATH", ""),
+		JWTIssuer:        getEnv("JWT_ISSUER", ""),
+		JWTAudience:      getEnv("JWT_AUDIENCE", ""),
 	}
 }
 
@@ -15,6 +15,8 @@ func TestLoadConfig_WithEnvVariables(t *testing.T) {
 	_ = os.Setenv("DB_NAME", "testdb")
 	_ = os.Setenv("CACERT_PATH", "testcert")
 	_ = os.Setenv("CLIENT_CERT_PATH", "testcert")
+	_ = os.Setenv("JWT_HS256_SECRET", "testsecret")
+	_ = os.Setenv("JWKS_PATH", "testjwks")
 
 	defer func() {
 		_ = os.Unsetenv("DB_USER")
@@ -24,6 +26,8 @@ func TestLoadConfig_WithEnvVariables(t *testing.T) {
 		_ = os.Unsetenv("DB_NAME")
 		_ = os.Unsetenv("CACERT_PATH")
 		_ = os.Unsetenv("CLIENT_CERT_PATH")
+		_ = os.Unsetenv("JWT_HS256_SECRET")
+		_ = os.Unsetenv("JWKS_PATH")
 	}()
 
 	cfg := LoadConfig()
@@ -33,6 +37,9 @@ func TestLoadConfig_WithEnvVariables(t *testing.T) {
 	assert.Equal(t, "testhost", cfg.DbHost)
 	assert.Equal(t, "1234", cfg.DbPort)
 	assert.Equal(t, "testdb", cfg.DbName)
+	assert.Equal(t, "testsecret", cfg.JWTSecret)
+	assert.Equal(t, "testjwks", cfg.JWKSPath)
+	assert.Equal(t, "", cfg.JWTPublicKeyPath)
 
 }
 
@@ -0,0 +1,307 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"slices"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/auth"
+	"testing"
+)
+
+// FakeGrantStore simulates saving, loading, modifying and removing grants for testing. Its
+// grants belong to tenant 1.
+type FakeGrantStore struct {
+	Grants      []*Grant
+	ReturnError bool
+}
+
+func (f *FakeGrantStore) SaveGrant(ctx context.Context, tenantID string, grant *Grant) error {
+	if f.ReturnError {
+		return errors.New("failed to save grant")
+	}
+	if _, err := f.LoadGrant(ctx, tenantID, grant.AccountID, grant.UserID); err == nil {
+		return ErrDuplicateGrant
+	}
+	f.Grants = append(f.Grants, grant)
+	return nil
+}
+
+func (f *FakeGrantStore) LoadGrant(ctx context.Context, tenantID, accountID, userID string) (*Grant, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to load grant")
+	}
+	for _, grant := range f.Grants {
+		if tenantID == "1" && grant.AccountID == accountID && grant.UserID == userID {
+			copied := *grant
+			return &copied, nil
+		}
+	}
+	return nil, ErrGrantNotFound
+}
+
+func (f *FakeGrantStore) LoadGrants(ctx context.Context, tenantID, accountID string) ([]*Grant, error) {
+	result := []*Grant{}
+	for _, grant := range f.Grants {
+		if tenantID == "1" && grant.AccountID == accountID {
+			result = append(result, grant)
+		}
+	}
+	return result, nil
+}
+
+func (f *FakeGrantStore) LoadUserGrants(ctx context.Context, tenantID, userID string) ([]*Grant, error) {
+	result := []*Grant{}
+	for _, grant := range f.Grants {
+		if tenantID == "1" && grant.UserID == userID {
+			result = append(result, grant)
+		}
+	}
+	return result, nil
+}
+
+func (f *FakeGrantStore) ModifyGrant(ctx context.Context, tenantID string, grant *Grant) error {
+	for _, stored := range f.Grants {
+		if stored.AccountID == grant.AccountID && stored.UserID == grant.UserID {
+			stored.Role = grant.Role
+			return nil
+		}
+	}
+	return ErrGrantNotFound
+}
+
+func (f *FakeGrantStore) RemoveGrant(ctx context.Context, tenantID, accountID, userID string) error {
+	f.Grants = slices.DeleteFunc(f.Grants, func(grant *Grant) bool {
+		return grant.AccountID == accountID && grant.UserID == userID
+	})
+	return nil
+}
+
+func (f *FakeGrantStore) RemoveGrants(ctx context.Context, tenantID, accountID string) error {
+	f.Grants = slices.DeleteFunc(f.Grants, func(grant *Grant) bool {
+		return grant.AccountID == accountID
+	})
+	return nil
+}
+
+// FakeChecker simulates checking that accounts or users of tenant 1 exist for testing.
+type FakeChecker struct {
+	IDs []string
+}
+
+func (f *FakeChecker) AccountExists(ctx context.Context, tenantID, id string) (bool, error) {
+	return tenantID == "1" && slices.Contains(f.IDs, id), nil
+}
+
+func (f *FakeChecker) UserExists(ctx context.Context, tenantID, id string) (bool, error) {
+	return tenantID == "1" && slices.Contains(f.IDs, id), nil
+}
+
+// FakeTransactor simulates a unit of work, recording whether it rolled back.
+type FakeTransactor struct {
+	RolledBack bool
+}
+
+func (f *FakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		f.RolledBack = true
+		return err
+	}
+	return nil
+}
+
+// userContext returns a context acting as the user with the given ID of tenant 1
+func userContext(userID string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "user-" + userID, UserID: userID, TenantID: "1"})
+}
+
+// newTestAccessService returns an AccessService over the grants, with accounts 1 to 3 and users
+// 5 to 7 in tenant 1
+func newTestAccessService(grants *FakeGrantStore) *AccessService {
+	return NewAccessService(grants, grants, grants, grants, &FakeChecker{IDs: []string{"1", "2", "3"}}, &FakeChecker{IDs: []string{"5", "6", "7"}}, &FakeTransactor{})
+}
+
+// Test each role grants its permissions and no others
+func TestRoleAllows(t *testing.T) {
+	assert.True(t, RoleOwner.Allows(PermissionAdminister))
+	assert.True(t, RoleEditor.Allows(PermissionEdit))
+	assert.False(t, RoleEditor.Allows(PermissionAdminister))
+	assert.True(t, RoleViewer.Allows(PermissionView))
+	assert.False(t, RoleViewer.Allows(PermissionEdit))
+	assert.False(t, Role("admin").IsValid())
+	assert.False(t, Role("admin").Allows(PermissionView))
+}
+
+// Test the forbidden error explains which permission is missing
+func TestForbiddenError(t *testing.T) {
+	err := error(&ForbiddenError{AccountID: "1", Permission: PermissionEdit, Role: RoleViewer})
+
+	assert.True(t, errors.Is(err, ErrForbidden))
+	assert.Equal(t, "forbidden: the edit permission on account 1 is required, which the viewer role does not grant", err.Error())
+	assert.Equal(t, "forbidden: the view permission on account 2 is required, and you have no role on it", (&ForbiddenError{AccountID: "2", Permission: PermissionView}).Error())
+}
+
+// Test authorizing by the caller's role on the account
+func TestAccessServiceAuthorize(t *testing.T) {
+	grants := &FakeGrantStore{Grants: []*Grant{{AccountID: "1", UserID: "5", Role: RoleViewer}}}
+	service := newTestAccessService(grants)
+
+	assert.Nil(t, service.Authorize(userContext("5"), "1", PermissionView))
+
+	var forbidden *ForbiddenError
+	err := service.Authorize(userContext("5"), "1", PermissionEdit)
+	assert.True(t, errors.As(err, &forbidden), "Expected a ForbiddenError")
+	assert.Equal(t, ForbiddenError{AccountID: "1", Permission: PermissionEdit, Role: RoleViewer}, *forbidden)
+
+	err = service.Authorize(userContext("6"), "1", PermissionView)
+	assert.True(t, errors.As(err, &forbidden), "Expected a ForbiddenError")
+	assert.Equal(t, Role(""), forbidden.Role, "A user without a grant has no role")
+}
+
+// Test an unknown account is denied as not found
+func TestAccessServiceAuthorize_UnknownAccount(t *testing.T) {
+	service := newTestAccessService(&FakeGrantStore{})
+
+	err := service.Authorize(userContext("5"), "99", PermissionEdit)
+
+	assert.True(t, errors.Is(err, accounts.ErrAccountNotFound), "Expected ErrAccountNotFound")
+}
+
+// Test authorizing without a user
+func TestAccessServiceAuthorize_Unauthenticated(t *testing.T) {
+	service := newTestAccessService(&FakeGrantStore{})
+
+	err := service.Authorize(context.Background(), "1", PermissionView)
+
+	assert.True(t, errors.Is(err, auth.ErrUnauthenticated), "Expected ErrUnauthenticated")
+}
+
+// Test an owner granting another user a role
+func TestAccessServiceGrantAccess(t *testing.T) {
+	grants := &FakeGrantStore{Grants: []*Grant{{AccountID: "1", UserID: "5", Role: RoleOwner}}}
+	service := newTestAccessService(grants)
+
+	grant, err := service.GrantAccess(userContext("5"), "1", "6", RoleEditor)
+
+	assert.Nil(t, err)
+	assert.Equal(t, RoleEditor, grant.Role)
+	assert.Equal(t, "user-5", grant.GrantedBy, "The grant should record who made it")
+	assert.Len(t, grants.Grants, 2)
+}
+
+// Test granting takes the administer permission
+func TestAccessServiceGrantAccess_Forbidden(t *testing.T) {
+	grants := &FakeGrantStore{Grants: []*Grant{{AccountID: "1", UserID: "5", Role: RoleEditor}}}
+	service := newTestAccessService(grants)
+
+	_, err := service.GrantAccess(userContext("5"), "1", "6", RoleViewer)
+
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	assert.Len(t, grants.Grants, 1, "Nothing should be granted")
+}
+
+// Test granting an unknown role, to an unknown user, on an unknown account and twice
+func TestAccessServiceGrantAccess_Invalid(t *testing.T) {
+	grants := &FakeGrantStore{Grants: []*Grant{{AccountID: "1", UserID: "5", Role: RoleOwner}}}
+	service := newTestAccessService(grants)
+
+	_, err := service.GrantAccess(userContext("5"), "1", "6", "admin")
+	assert.True(t, errors.Is(err, ErrInvalidGrant), "Expected ErrInvalidGrant")
+
+	_, err = service.GrantAccess(userContext("5"), "1", "99", RoleViewer)
+	assert.True(t, errors.Is(err, ErrUserNotFound), "Expected ErrUserNotFound")
+
+	_, err = service.GrantAccess(userContext("5"), "99", "6", RoleViewer)
+	assert.True(t, errors.Is(err, accounts.ErrAccountNotFound), "Expected ErrAccountNotFound")
+
+	_, err = service.GrantAccess(userContext("5"), "1", "5", RoleViewer)
+	assert.True(t, errors.Is(err, ErrDuplicateGrant), "Expected ErrDuplicateGrant")
+}
+
+// Test anyone who may view an account may list who has access to it
+func TestAccessServiceListAccess(t *testing.T) {
+	grants := &FakeGrantStore{Grants: []*Grant{{AccountID: "1", UserID: "5", Role: RoleOwner}, {AccountID: "1", UserID: "6", Role: RoleViewer}, {AccountID: "2", UserID: "5", Role: RoleOwner}}}
+	service := newTestAccessService(grants)
+
+	result, err := service.ListAccess(userContext("6"), "1")
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+
+	_, err = service.ListAccess(userContext("6"), "2")
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+}
+
+// Test changing a user's role
+func TestAccessServiceChangeAccess(t *testing.T) {
+	grants := &FakeGrantStore{Grants: []*Grant{{AccountID: "1", UserID: "5", Role: RoleOwner}, {AccountID: "1", UserID: "6", Role: RoleViewer}}}
+	service := newTestAccessService(grants)
+
+	grant, err := service.ChangeAccess(userContext("5"), "1", "6", RoleOwner)
+
+	assert.Nil(t, err)
+	assert.Equal(t, RoleOwner, grant.Role)
+	assert.Equal(t, RoleOwner, grants.Grants[1].Role)
+
+	_, err = service.ChangeAccess(userContext("5"), "1", "7", RoleViewer)
+	assert.True(t, errors.Is(err, ErrGrantNotFound), "Expected ErrGrantNotFound")
+}
+
+// Test the last owner cannot be demoted or revoked, while one of several can
+func TestAccessService_LastOwner(t *testing.T) {
+	grants := &FakeGrantStore{Grants: []*Grant{{AccountID: "1", UserID: "5", Role: RoleOwner}}}
+	service := newTestAccessService(grants)
+
+	_, err := service.ChangeAccess(userContext("5"), "1", "5", RoleEditor)
+	assert.True(t, errors.Is(err, ErrLastOwner), "Expected ErrLastOwner")
+	err = service.RevokeAccess(userContext("5"), "1", "5")
+	assert.True(t, errors.Is(err, ErrLastOwner), "Expected ErrLastOwner")
+
+	grants.Grants = append(grants.Grants, &Grant{AccountID: "1", UserID: "6", Role: RoleOwner})
+	_, err = service.ChangeAccess(userContext("5"), "1", "5", RoleEditor)
+	assert.Nil(t, err)
+	assert.Equal(t, RoleEditor, grants.Grants[0].Role)
+}
+
+// Test revoking takes the administer permission, except for giving up one's own access
+func TestAccessServiceRevokeAccess(t *testing.T) {
+	grants := &FakeGrantStore{Grants: []*Grant{{AccountID: "1", UserID: "5", Role: RoleOwner}, {AccountID: "1", UserID: "6", Role: RoleViewer}, {AccountID: "1", UserID: "7", Role: RoleEditor}}}
+	service := newTestAccessService(grants)
+
+	err := service.RevokeAccess(userContext("6"), "1", "7")
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+
+	err = service.RevokeAccess(userContext("6"), "1", "6")
+	assert.Nil(t, err)
+	err = service.RevokeAccess(userContext("5"), "1", "7")
+	assert.Nil(t, err)
+	assert.Len(t, grants.Grants, 1)
+}
+
+// Test listing the accounts on which the caller holds a permission
+func TestAccessServiceAccessibleAccounts(t *testing.T) {
+	grants := &FakeGrantStore{Grants: []*Grant{{AccountID: "1", UserID: "5", Role: RoleOwner}, {AccountID: "2", UserID: "5", Role: RoleViewer}, {AccountID: "3", UserID: "6", Role: RoleOwner}}}
+	service := newTestAccessService(grants)
+
+	viewable, err := service.AccessibleAccounts(userContext("5"), PermissionView)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, viewable)
+
+	editable, err := service.AccessibleAccounts(userContext("5"), PermissionEdit)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1"}, editable)
+}
+
+// Test grants of other tenants are not seen
+func TestAccessService_OtherTenant(t *testing.T) {
+	grants := &FakeGrantStore{Grants: []*Grant{{AccountID: "1", UserID: "5", Role: RoleOwner}}}
+	service := newTestAccessService(grants)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "mallory", UserID: "5", TenantID: "2"})
+
+	err := service.Authorize(ctx, "1", PermissionAdminister)
+	assert.True(t, errors.Is(err, accounts.ErrAccountNotFound), "Another tenant's account is unknown")
+	_, err = service.ListAccess(ctx, "1")
+	assert.True(t, errors.Is(err, accounts.ErrAccountNotFound), "Expected ErrAccountNotFound")
+	viewable, _ := service.AccessibleAccounts(ctx, PermissionView)
+	assert.Empty(t, viewable)
+}
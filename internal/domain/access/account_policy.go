@@ -0,0 +1,101 @@
+package access
+
+import (
+	"context"
+	"slices"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/money"
+)
+
+// accountService is the account service AccountPolicy stands in front of
+type accountService interface {
+	accounts.ForCreatingAccount
+	accounts.ForGettingAccount
+	accounts.ForListingAccounts
+	accounts.ForUpdatingAccount
+	accounts.ForDeletingAccount
+}
+
+// AccountPolicy enforces the caller's role on each account before handing
+// the call on to the account service. Whoever creates an account owns it.
+type AccountPolicy struct {
+	accounts   accountService
+	access     *AccessService
+	transactor ForRunningInTransaction
+}
+
+// NewAccountPolicy creates a new AccountPolicy in front of the account service.
+func NewAccountPolicy(accounts accountService, access *AccessService, transactor ForRunningInTransaction) *AccountPolicy {
+	return &AccountPolicy{
+		accounts:   accounts,
+		access:     access,
+		transactor: transactor,
+	}
+}
+
+// CreateAccount creates the account and makes the caller its owner, in the
+// same unit of work.
+func (p *AccountPolicy) CreateAccount(ctx context.Context, name, number string, currency money.Currency, openingBalance money.Money) (*accounts.Account, error) {
+	var account *accounts.Account
+	err := p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		account, err = p.accounts.CreateAccount(ctx, name, number, currency, openingBalance)
+		if err != nil {
+			return err
+		}
+		return p.access.GrantOwnership(ctx, account.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// GetAccount retrieves the account if the caller may view it.
+func (p *AccountPolicy) GetAccount(ctx context.Context, id string) (*accounts.Account, error) {
+	if err := p.access.Authorize(ctx, id, PermissionView); err != nil {
+		return nil, err
+	}
+	return p.accounts.GetAccount(ctx, id)
+}
+
+// ListAccounts retrieves the accounts the caller may view.
+func (p *AccountPolicy) ListAccounts(ctx context.Context) ([]*accounts.Account, error) {
+	ids, err := p.access.AccessibleAccounts(ctx, PermissionView)
+	if err != nil {
+		return nil, err
+	}
+	all, err := p.accounts.ListAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	visible := []*accounts.Account{}
+	for _, account := range all {
+		if slices.Contains(ids, account.ID) {
+			visible = append(visible, account)
+		}
+	}
+	return visible, nil
+}
+
+// UpdateAccount renames the account if the caller may administer it.
+func (p *AccountPolicy) UpdateAccount(ctx context.Context, id, name string, version int) (*accounts.Account, error) {
+	if err := p.access.Authorize(ctx, id, PermissionAdminister); err != nil {
+		return nil, err
+	}
+	return p.accounts.UpdateAccount(ctx, id, name, version)
+}
+
+// DeleteAccount deletes the account, and everyone's access to it, if the
+// caller may administer it.
+func (p *AccountPolicy) DeleteAccount(ctx context.Context, id string, version int) error {
+	if err := p.access.Authorize(ctx, id, PermissionAdminister); err != nil {
+		return err
+	}
+	return p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := p.access.RemoveAccess(ctx, id); err != nil {
+			return err
+		}
+		return p.accounts.DeleteAccount(ctx, id, version)
+	})
+}
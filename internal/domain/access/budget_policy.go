@@ -0,0 +1,157 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/budgets"
+)
+
+// budgetService is the budget service BudgetPolicy stands in front of
+type budgetService interface {
+	budgets.ForCreatingBudget
+	budgets.ForGettingBudget
+	budgets.ForListingBudgets
+	budgets.ForUpdatingBudget
+	budgets.ForDeletingBudget
+	budgets.ForGettingBudgetStatus
+}
+
+// BudgetPolicy enforces the caller's role on the account a budget is limited
+// to. Seeing the budget and its status takes the view permission on it, and
+// creating, changing and deleting the budget the edit permission. Budgets
+// without an account are shared by the whole tenant, but their status only
+// adds up the spending of the accounts the caller may view.
+type BudgetPolicy struct {
+	budgets budgetService
+	access  *AccessService
+}
+
+// NewBudgetPolicy creates a new BudgetPolicy in front of the budget service.
+func NewBudgetPolicy(budgets budgetService, access *AccessService) *BudgetPolicy {
+	return &BudgetPolicy{
+		budgets: budgets,
+		access:  access,
+	}
+}
+
+// CreateBudget creates the budget if the caller may edit its account. A budget
+// on an account the tenant does not have is left to the budget service to
+// reject.
+func (p *BudgetPolicy) CreateBudget(ctx context.Context, budget *budgets.Budget) (*budgets.Budget, error) {
+	if err := p.authorizeBudget(ctx, budget.AccountID); err != nil {
+		return nil, err
+	}
+	return p.budgets.CreateBudget(ctx, budget)
+}
+
+// GetBudget retrieves the budget if the caller may view its account.
+func (p *BudgetPolicy) GetBudget(ctx context.Context, id string) (*budgets.Budget, error) {
+	budget, err := p.budgets.GetBudget(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if budget.AccountID != "" {
+		if err := p.authorize(ctx, budget.AccountID, PermissionView); err != nil {
+			return nil, err
+		}
+	}
+	return budget, nil
+}
+
+// ListBudgets lists the budgets of the accounts the caller may view, and those
+// of none.
+func (p *BudgetPolicy) ListBudgets(ctx context.Context) ([]*budgets.Budget, error) {
+	ids, err := p.access.AccessibleAccounts(ctx, PermissionView)
+	if err != nil {
+		return nil, err
+	}
+	all, err := p.budgets.ListBudgets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	visible := []*budgets.Budget{}
+	for _, budget := range all {
+		if budget.AccountID == "" || slices.Contains(ids, budget.AccountID) {
+			visible = append(visible, budget)
+		}
+	}
+	return visible, nil
+}
+
+// UpdateBudget replaces the budget if the caller may edit its account, both
+// before and after.
+func (p *BudgetPolicy) UpdateBudget(ctx context.Context, id string, budget *budgets.Budget) (*budgets.Budget, error) {
+	existing, err := p.budgets.GetBudget(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.authorizeBudget(ctx, existing.AccountID); err != nil {
+		return nil, err
+	}
+	if budget.AccountID != existing.AccountID {
+		if err := p.authorizeBudget(ctx, budget.AccountID); err != nil {
+			return nil, err
+		}
+	}
+	return p.budgets.UpdateBudget(ctx, id, budget)
+}
+
+// DeleteBudget deletes the budget if the caller may edit its account.
+func (p *BudgetPolicy) DeleteBudget(ctx context.Context, id string) error {
+	existing, err := p.budgets.GetBudget(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := p.authorizeBudget(ctx, existing.AccountID); err != nil {
+		return err
+	}
+	return p.budgets.DeleteBudget(ctx, id)
+}
+
+// GetBudgetStatus reports the status of a budget of a single account if the
+// caller may view that account. The status of a budget of every account only
+// counts the spending of the accounts the caller may view.
+func (p *BudgetPolicy) GetBudgetStatus(ctx context.Context, id string, query budgets.StatusQuery) (*budgets.Status, error) {
+	budget, err := p.budgets.GetBudget(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if budget.AccountID != "" {
+		if err := p.authorize(ctx, budget.AccountID, PermissionView); err != nil {
+			return nil, err
+		}
+		return p.budgets.GetBudgetStatus(ctx, id, query)
+	}
+	ids, err := p.access.AccessibleAccounts(ctx, PermissionView)
+	if err != nil {
+		return nil, err
+	}
+	query.AccountIDs = ids
+	return p.budgets.GetBudgetStatus(ctx, id, query)
+}
+
+// authorizeBudget checks the caller may edit the account of a budget, if it
+// has one. An account the tenant does not have passes, for the budget service
+// to report as an invalid budget.
+func (p *BudgetPolicy) authorizeBudget(ctx context.Context, accountID string) error {
+	if accountID == "" {
+		return nil
+	}
+	err := p.authorize(ctx, accountID, PermissionEdit)
+	if errors.Is(err, budgets.ErrAccountNotFound) {
+		return nil
+	}
+	return err
+}
+
+// authorize checks the caller holds the permission on the account, reporting
+// an account the tenant does not have as budgets.ErrAccountNotFound
+func (p *BudgetPolicy) authorize(ctx context.Context, accountID string, permission Permission) error {
+	err := p.access.Authorize(ctx, accountID, permission)
+	if errors.Is(err, accounts.ErrAccountNotFound) {
+		return budgets.ErrAccountNotFound
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/transactions"
+)
+
+// duplicateService is the duplicate service DuplicatePolicy stands in front of
+type duplicateService interface {
+	transactions.ForFindingDuplicates
+	transactions.ForResolvingDuplicate
+	transactions.ForListingDuplicateResolutions
+}
+
+// DuplicatePolicy enforces the caller's role on the accounts of likely
+// duplicate transactions. Finding duplicates and listing resolutions takes
+// the view permission, and merging or dismissing a pair takes the edit
+// permission on the accounts of both of its transactions.
+type DuplicatePolicy struct {
+	duplicates   duplicateService
+	transactions transactions.ForGettingTransaction
+	access       *AccessService
+}
+
+// NewDuplicatePolicy creates a new DuplicatePolicy in front of the duplicate
+// service, looking up the transactions of a pair with the transaction service.
+func NewDuplicatePolicy(duplicates duplicateService, transactions transactions.ForGettingTransaction, access *AccessService) *DuplicatePolicy {
+	return &DuplicatePolicy{
+		duplicates:   duplicates,
+		transactions: transactions,
+		access:       access,
+	}
+}
+
+// FindDuplicates searches the filter's account if the caller may view it, or
+// else the accounts they may view.
+func (p *DuplicatePolicy) FindDuplicates(ctx context.Context, filter transactions.DuplicateFilter) ([]*transactions.DuplicatePair, error) {
+	if filter.AccountID != "" {
+		if err := p.authorize(ctx, filter.AccountID, PermissionView); err != nil {
+			return nil, err
+		}
+		return p.duplicates.FindDuplicates(ctx, filter)
+	}
+	ids, err := p.access.AccessibleAccounts(ctx, PermissionView)
+	if err != nil {
+		return nil, err
+	}
+	filter.AccountIDs = ids
+	return p.duplicates.FindDuplicates(ctx, filter)
+}
+
+// ResolveDuplicate merges or dismisses the pair if the caller may edit the
+// accounts of both transactions. Unknown transactions are left for the
+// duplicate service to report.
+func (p *DuplicatePolicy) ResolveDuplicate(ctx context.Context, action transactions.ResolutionAction, keptID, duplicateID string) (*transactions.DuplicateResolution, error) {
+	for _, id := range []string{keptID, duplicateID} {
+		if id == "" {
+			continue
+		}
+		transaction, err := p.transactions.GetTransaction(ctx, id)
+		if errors.Is(err, transactions.ErrTransactionNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := p.authorize(ctx, transaction.AccountID, PermissionEdit); err != nil {
+			return nil, err
+		}
+	}
+	return p.duplicates.ResolveDuplicate(ctx, action, keptID, duplicateID)
+}
+
+// ListDuplicateResolutions lists the resolved pairs within the accounts the
+// caller may view.
+func (p *DuplicatePolicy) ListDuplicateResolutions(ctx context.Context, filter transactions.ResolutionFilter) ([]*transactions.DuplicateResolution, error) {
+	ids, err := p.access.AccessibleAccounts(ctx, PermissionView)
+	if err != nil {
+		return nil, err
+	}
+	filter.AccountIDs = ids
+	return p.duplicates.ListDuplicateResolutions(ctx, filter)
+}
+
+// authorize checks the caller holds the permission on the account, reporting
+// an account the tenant does not have as transactions.ErrAccountNotFound
+func (p *DuplicatePolicy) authorize(ctx context.Context, accountID string, permission Permission) error {
+	err := p.access.Authorize(ctx, accountID, permission)
+	if errors.Is(err, accounts.ErrAccountNotFound) {
+		return transactions.ErrAccountNotFound
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package access
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrForbidden is returned when the caller lacks the permission an operation needs.
+var ErrForbidden = errors.New("forbidden")
+
+// ErrInvalidGrant is returned when access is granted with an unknown role.
+var ErrInvalidGrant = errors.New("invalid grant")
+
+// ErrGrantNotFound is returned when a user has no role on the requested account.
+var ErrGrantNotFound = errors.New("grant not found")
+
+// ErrDuplicateGrant is returned when a user who already has a role on an account is granted another.
+var ErrDuplicateGrant = errors.New("user already has access to the account")
+
+// ErrLastOwner is returned when a change would leave an account without an owner.
+var ErrLastOwner = errors.New("account must keep an owner")
+
+// ErrUserNotFound is returned when access is granted to a user the tenant does not have.
+var ErrUserNotFound = errors.New("user not found")
+
+// ForbiddenError is returned when the caller's role on the account
+// AccountID, if they have one, does not grant Permission. It matches
+// ErrForbidden with errors.Is.
+type ForbiddenError struct {
+	AccountID  string
+	Permission Permission
+	Role       Role
+}
+
+func (e *ForbiddenError) Error() string {
+	if e.Role == "" {
+		return fmt.Sprintf("%s: the %s permission on account %s is required, and you have no role on it", ErrForbidden, e.Permission, e.AccountID)
+	}
+	return fmt.Sprintf("%s: the %s permission on account %s is required, which the %s role does not grant", ErrForbidden, e.Permission, e.AccountID, e.Role)
+}
+
+func (e *ForbiddenError) Unwrap() error {
+	return ErrForbidden
+}
@@ -0,0 +1,56 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"io"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/imports"
+)
+
+// importService is the import service ImportPolicy stands in front of
+type importService interface {
+	imports.ForImportingCSV
+	imports.ForImportingStatement
+}
+
+// ImportPolicy enforces the caller's role on an account before a statement
+// is imported into it, which takes the edit permission.
+type ImportPolicy struct {
+	imports importService
+	access  *AccessService
+}
+
+// NewImportPolicy creates a new ImportPolicy in front of the import service.
+func NewImportPolicy(imports importService, access *AccessService) *ImportPolicy {
+	return &ImportPolicy{
+		imports: imports,
+		access:  access,
+	}
+}
+
+// ImportCSV imports the CSV statement if the caller may edit the account.
+func (p *ImportPolicy) ImportCSV(ctx context.Context, accountID, profileID string, data io.Reader) (*imports.Report, error) {
+	if err := p.authorize(ctx, accountID, PermissionEdit); err != nil {
+		return nil, err
+	}
+	return p.imports.ImportCSV(ctx, accountID, profileID, data)
+}
+
+// ImportStatement imports the statement if the caller may edit the account.
+func (p *ImportPolicy) ImportStatement(ctx context.Context, accountID string, format imports.Format, data io.Reader) (*imports.Report, error) {
+	if err := p.authorize(ctx, accountID, PermissionEdit); err != nil {
+		return nil, err
+	}
+	return p.imports.ImportStatement(ctx, accountID, format, data)
+}
+
+// authorize checks the caller holds the permission on the account, reporting
+// an account the tenant does not have as imports.ErrAccountNotFound
+func (p *ImportPolicy) authorize(ctx context.Context, accountID string, permission Permission) error {
+	err := p.access.Authorize(ctx, accountID, permission)
+	if errors.Is(err, accounts.ErrAccountNotFound) {
+		return imports.ErrAccountNotFound
+	}
+	return err
+}
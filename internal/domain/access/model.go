@@ -0,0 +1,58 @@
+package access
+
+import (
+	"slices"
+	"time"
+)
+
+// Permission is something a user may be allowed to do with an account.
+type Permission string
+
+const (
+	// PermissionView allows reading an account, its balance and its transactions.
+	PermissionView Permission = "view"
+	// PermissionEdit allows adding, importing, editing, posting and voiding the
+	// account's transactions, and transferring money out of or into it.
+	PermissionEdit Permission = "edit"
+	// PermissionAdminister allows renaming and deleting the account, and
+	// deciding who else has access to it.
+	PermissionAdminister Permission = "administer"
+)
+
+// Role is a set of permissions a user is granted on an account.
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+// rolePermissions gives the permissions each role grants
+var rolePermissions = map[Role][]Permission{
+	RoleOwner:  {PermissionView, PermissionEdit, PermissionAdminister},
+	RoleEditor: {PermissionView, PermissionEdit},
+	RoleViewer: {PermissionView},
+}
+
+// IsValid reports whether the role is one of the known roles.
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Allows reports whether the role grants the permission.
+func (r Role) Allows(permission Permission) bool {
+	return slices.Contains(rolePermissions[r], permission)
+}
+
+// Grant gives the user UserID the role Role on the account AccountID.
+// GrantedBy is the subject of whoever granted it, empty for the owner who
+// created the account and for grants made by migrations.
+type Grant struct {
+	AccountID string
+	UserID    string
+	Role      Role
+	GrantedBy string
+	CreatedAt time.Time
+}
@@ -0,0 +1,685 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/budgets"
+	"spend-api/internal/domain/imports"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/reports"
+	"spend-api/internal/domain/transactions"
+	"strings"
+	"testing"
+	"time"
+)
+
+// FakeAccountService simulates the account service behind the policy, recording what reached it.
+type FakeAccountService struct {
+	Accounts    []*accounts.Account
+	Calls       []string
+	ReturnError bool
+}
+
+func (f *FakeAccountService) CreateAccount(ctx context.Context, name, number string, currency money.Currency, openingBalance money.Money) (*accounts.Account, error) {
+	f.Calls = append(f.Calls, "create")
+	if f.ReturnError {
+		return nil, errors.New("failed to create account")
+	}
+	return &accounts.Account{ID: "3", Name: name}, nil
+}
+
+func (f *FakeAccountService) GetAccount(ctx context.Context, id string) (*accounts.Account, error) {
+	f.Calls = append(f.Calls, "get "+id)
+	return &accounts.Account{ID: id}, nil
+}
+
+func (f *FakeAccountService) ListAccounts(ctx context.Context) ([]*accounts.Account, error) {
+	f.Calls = append(f.Calls, "list")
+	return f.Accounts, nil
+}
+
+func (f *FakeAccountService) UpdateAccount(ctx context.Context, id, name string, version int) (*accounts.Account, error) {
+	f.Calls = append(f.Calls, "update "+id)
+	return &accounts.Account{ID: id, Name: name}, nil
+}
+
+func (f *FakeAccountService) DeleteAccount(ctx context.Context, id string, version int) error {
+	f.Calls = append(f.Calls, "delete "+id)
+	if f.ReturnError {
+		return accounts.ErrAccountHasTransactions
+	}
+	return nil
+}
+
+// FakeTransactionService simulates the transaction, edit, transfer and export services behind the
+// policy, recording what reached them. Transaction 10 is in account 1, 20 in account 2, and 11
+// and 21 are the legs of transfer 4 between them.
+type FakeTransactionService struct {
+	Calls  []string
+	Filter transactions.TransactionFilter
+}
+
+var fakeTransactions = map[string]*transactions.Transaction{
+	"10": {ID: "10", AccountID: "1"},
+	"20": {ID: "20", AccountID: "2"},
+	"11": {ID: "11", AccountID: "1", TransferID: "4"},
+	"21": {ID: "21", AccountID: "2", TransferID: "4"},
+}
+
+func (f *FakeTransactionService) CreateTransaction(ctx context.Context, accountID string, amount money.Money, kind transactions.Kind, description, categoryID string, date time.Time, postedDate *time.Time) (*transactions.Transaction, error) {
+	f.Calls = append(f.Calls, "create "+accountID)
+	return &transactions.Transaction{AccountID: accountID}, nil
+}
+
+func (f *FakeTransactionService) ImportTransaction(ctx context.Context, accountID string, amount money.Money, kind transactions.Kind, description, externalID string, date, postedDate time.Time) (*transactions.Transaction, error) {
+	f.Calls = append(f.Calls, "import "+accountID)
+	return &transactions.Transaction{AccountID: accountID}, nil
+}
+
+func (f *FakeTransactionService) GetTransaction(ctx context.Context, id string) (*transactions.Transaction, error) {
+	transaction, ok := fakeTransactions[id]
+	if !ok {
+		return nil, transactions.ErrTransactionNotFound
+	}
+	return transaction, nil
+}
+
+func (f *FakeTransactionService) UpdateTransaction(ctx context.Context, id string, changes transactions.TransactionChanges, version int) (*transactions.Transaction, error) {
+	f.Calls = append(f.Calls, "update "+id)
+	return fakeTransactions[id], nil
+}
+
+func (f *FakeTransactionService) PostTransaction(ctx context.Context, id string, postedDate time.Time) (*transactions.Transaction, error) {
+	f.Calls = append(f.Calls, "post "+id)
+	return fakeTransactions[id], nil
+}
+
+func (f *FakeTransactionService) VoidTransaction(ctx context.Context, id string) (*transactions.Transaction, error) {
+	f.Calls = append(f.Calls, "void "+id)
+	return fakeTransactions[id], nil
+}
+
+func (f *FakeTransactionService) CategorizeTransactions(ctx context.Context, ids []string, categoryID string) (int, error) {
+	f.Calls = append(f.Calls, "categorize "+strings.Join(ids, ","))
+	return len(ids), nil
+}
+
+func (f *FakeTransactionService) ListTransactions(ctx context.Context, filter transactions.TransactionFilter) (*transactions.TransactionPage, error) {
+	f.Filter = filter
+	page := &transactions.TransactionPage{Transactions: []*transactions.Transaction{}}
+	for _, transaction := range fakeTransactions {
+		if filter.TransferID == "" || transaction.TransferID == filter.TransferID {
+			page.Transactions = append(page.Transactions, transaction)
+		}
+	}
+	return page, nil
+}
+
+func (f *FakeTransactionService) ExportTransactions(ctx context.Context, filter transactions.TransactionFilter, each func(*transactions.Transaction) error) error {
+	f.Filter = filter
+	return nil
+}
+
+func (f *FakeTransactionService) GetBalance(ctx context.Context, accountID string, asOf *time.Time, currency money.Currency) (*transactions.AccountBalance, error) {
+	f.Calls = append(f.Calls, "balance "+accountID)
+	return &transactions.AccountBalance{AccountID: accountID}, nil
+}
+
+func (f *FakeTransactionService) CreateTransfer(ctx context.Context, fromAccountID, toAccountID string, amount money.Money, description string, date time.Time) (*transactions.Transfer, error) {
+	f.Calls = append(f.Calls, "transfer "+fromAccountID+" "+toAccountID)
+	return &transactions.Transfer{FromAccountID: fromAccountID, ToAccountID: toAccountID}, nil
+}
+
+// FakeImportService simulates the import service behind the policy, recording what reached it.
+type FakeImportService struct {
+	Calls []string
+}
+
+func (f *FakeImportService) ImportCSV(ctx context.Context, accountID, profileID string, data io.Reader) (*imports.Report, error) {
+	f.Calls = append(f.Calls, "csv "+accountID)
+	return &imports.Report{AccountID: accountID}, nil
+}
+
+func (f *FakeImportService) ImportStatement(ctx context.Context, accountID string, format imports.Format, data io.Reader) (*imports.Report, error) {
+	f.Calls = append(f.Calls, "statement "+accountID)
+	return &imports.Report{AccountID: accountID}, nil
+}
+
+// FakeInsightService simulates the duplicate, rule, report and budget services behind the
+// policies, recording what reached them and the accounts they were restricted to. Rule and
+// budget 1 cover every account, and rule and budget 2 only account 2.
+type FakeInsightService struct {
+	Calls      []string
+	AccountIDs []string
+}
+
+func (f *FakeInsightService) FindDuplicates(ctx context.Context, filter transactions.DuplicateFilter) ([]*transactions.DuplicatePair, error) {
+	f.Calls, f.AccountIDs = append(f.Calls, "find "+filter.AccountID), filter.AccountIDs
+	return nil, nil
+}
+
+func (f *FakeInsightService) ResolveDuplicate(ctx context.Context, action transactions.ResolutionAction, keptID, duplicateID string) (*transactions.DuplicateResolution, error) {
+	f.Calls = append(f.Calls, "resolve "+keptID+" "+duplicateID)
+	return &transactions.DuplicateResolution{Action: action, KeptID: keptID, DuplicateID: duplicateID}, nil
+}
+
+func (f *FakeInsightService) ListDuplicateResolutions(ctx context.Context, filter transactions.ResolutionFilter) ([]*transactions.DuplicateResolution, error) {
+	f.Calls, f.AccountIDs = append(f.Calls, "resolutions"), filter.AccountIDs
+	return nil, nil
+}
+
+func (f *FakeInsightService) CreateRule(ctx context.Context, rule *transactions.Rule) (*transactions.Rule, error) {
+	f.Calls = append(f.Calls, "create rule "+rule.AccountID)
+	return rule, nil
+}
+
+func (f *FakeInsightService) GetRule(ctx context.Context, id string) (*transactions.Rule, error) {
+	switch id {
+	case "1":
+		return &transactions.Rule{ID: id}, nil
+	case "2":
+		return &transactions.Rule{ID: id, AccountID: "2"}, nil
+	}
+	return nil, transactions.ErrRuleNotFound
+}
+
+func (f *FakeInsightService) ListRules(ctx context.Context) ([]*transactions.Rule, error) {
+	return []*transactions.Rule{{ID: "1"}, {ID: "2", AccountID: "2"}, {ID: "3", AccountID: "1"}}, nil
+}
+
+func (f *FakeInsightService) UpdateRule(ctx context.Context, id string, rule *transactions.Rule) (*transactions.Rule, error) {
+	f.Calls = append(f.Calls, "update rule "+id)
+	return rule, nil
+}
+
+func (f *FakeInsightService) DeleteRule(ctx context.Context, id string) error {
+	f.Calls = append(f.Calls, "delete rule "+id)
+	return nil
+}
+
+func (f *FakeInsightService) ApplyRules(ctx context.Context, application transactions.RuleApplication) (*transactions.RuleRun, error) {
+	f.Calls, f.AccountIDs = append(f.Calls, "apply "+application.AccountID), application.AccountIDs
+	return &transactions.RuleRun{DryRun: application.DryRun}, nil
+}
+
+func (f *FakeInsightService) ReportSpending(ctx context.Context, query reports.SpendingQuery) (*reports.SpendingReport, error) {
+	f.Calls, f.AccountIDs = append(f.Calls, "report "+query.AccountID), query.AccountIDs
+	return &reports.SpendingReport{}, nil
+}
+
+func (f *FakeInsightService) CreateBudget(ctx context.Context, budget *budgets.Budget) (*budgets.Budget, error) {
+	f.Calls = append(f.Calls, "create budget "+budget.AccountID)
+	return budget, nil
+}
+
+func (f *FakeInsightService) ListBudgets(ctx context.Context) ([]*budgets.Budget, error) {
+	return []*budgets.Budget{{ID: "1"}, {ID: "2", AccountID: "2"}, {ID: "3", AccountID: "1"}}, nil
+}
+
+func (f *FakeInsightService) UpdateBudget(ctx context.Context, id string, budget *budgets.Budget) (*budgets.Budget, error) {
+	f.Calls = append(f.Calls, "update budget "+id)
+	return budget, nil
+}
+
+func (f *FakeInsightService) DeleteBudget(ctx context.Context, id string) error {
+	f.Calls = append(f.Calls, "delete budget "+id)
+	return nil
+}
+
+func (f *FakeInsightService) GetBudget(ctx context.Context, id string) (*budgets.Budget, error) {
+	switch id {
+	case "1":
+		return &budgets.Budget{ID: id}, nil
+	case "2":
+		return &budgets.Budget{ID: id, AccountID: "2"}, nil
+	}
+	return nil, budgets.ErrBudgetNotFound
+}
+
+func (f *FakeInsightService) GetBudgetStatus(ctx context.Context, id string, query budgets.StatusQuery) (*budgets.Status, error) {
+	f.Calls, f.AccountIDs = append(f.Calls, "status "+id), query.AccountIDs
+	return &budgets.Status{BudgetID: id}, nil
+}
+
+// sharedGrants returns grants where user 5 owns account 1 and views account 2, and user 6 owns
+// account 2
+func sharedGrants() *FakeGrantStore {
+	return &FakeGrantStore{Grants: []*Grant{
+		{AccountID: "1", UserID: "5", Role: RoleOwner},
+		{AccountID: "2", UserID: "5", Role: RoleViewer},
+		{AccountID: "2", UserID: "6", Role: RoleOwner},
+	}}
+}
+
+// Test the creator of an account becomes its owner
+func TestAccountPolicyCreateAccount(t *testing.T) {
+	grants := &FakeGrantStore{}
+	policy := NewAccountPolicy(&FakeAccountService{}, newTestAccessService(grants), &FakeTransactor{})
+
+	account, err := policy.CreateAccount(userContext("5"), "Joint", "", "EUR", money.Zero("EUR"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "3", account.ID)
+	assert.Equal(t, []*Grant{{AccountID: "3", UserID: "5", Role: RoleOwner, CreatedAt: grants.Grants[0].CreatedAt}}, grants.Grants)
+}
+
+// Test no owner is granted for an account that could not be created
+func TestAccountPolicyCreateAccount_Error(t *testing.T) {
+	grants := &FakeGrantStore{}
+	transactor := &FakeTransactor{}
+	policy := NewAccountPolicy(&FakeAccountService{ReturnError: true}, newTestAccessService(grants), transactor)
+
+	_, err := policy.CreateAccount(userContext("5"), "Joint", "", "EUR", money.Zero("EUR"))
+
+	assert.NotNil(t, err)
+	assert.Empty(t, grants.Grants)
+	assert.True(t, transactor.RolledBack)
+}
+
+// Test reading an account takes the view permission and changing it the administer permission
+func TestAccountPolicy_Permissions(t *testing.T) {
+	inner := &FakeAccountService{}
+	policy := NewAccountPolicy(inner, newTestAccessService(sharedGrants()), &FakeTransactor{})
+	ctx := userContext("5")
+
+	_, err := policy.GetAccount(ctx, "2")
+	assert.Nil(t, err)
+	_, err = policy.UpdateAccount(ctx, "2", "Renamed", 0)
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	err = policy.DeleteAccount(ctx, "2", 0)
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.UpdateAccount(ctx, "1", "Renamed", 0)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"get 2", "update 1"}, inner.Calls, "Forbidden calls should not reach the account service")
+}
+
+// Test listing only shows the accounts the caller may view
+func TestAccountPolicyListAccounts(t *testing.T) {
+	inner := &FakeAccountService{Accounts: []*accounts.Account{{ID: "1"}, {ID: "2"}, {ID: "3"}}}
+	policy := NewAccountPolicy(inner, newTestAccessService(sharedGrants()), &FakeTransactor{})
+
+	result, err := policy.ListAccounts(userContext("6"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*accounts.Account{{ID: "2"}}, result)
+}
+
+// Test deleting an account removes everyone's access to it, unless the deletion fails
+func TestAccountPolicyDeleteAccount(t *testing.T) {
+	grants := sharedGrants()
+	policy := NewAccountPolicy(&FakeAccountService{}, newTestAccessService(grants), &FakeTransactor{})
+
+	err := policy.DeleteAccount(userContext("6"), "2", 0)
+
+	assert.Nil(t, err)
+	assert.Len(t, grants.Grants, 1, "Only the grant on account 1 should be left")
+
+	grants = sharedGrants()
+	transactor := &FakeTransactor{}
+	policy = NewAccountPolicy(&FakeAccountService{ReturnError: true}, newTestAccessService(grants), transactor)
+	err = policy.DeleteAccount(userContext("6"), "2", 0)
+	assert.True(t, errors.Is(err, accounts.ErrAccountHasTransactions))
+	assert.True(t, transactor.RolledBack, "The grants should be restored")
+}
+
+// newTestTransactionPolicy returns a TransactionPolicy over the fake services and shared grants
+func newTestTransactionPolicy(inner *FakeTransactionService) *TransactionPolicy {
+	return NewTransactionPolicy(inner, inner, inner, inner, newTestAccessService(sharedGrants()))
+}
+
+// Test changing transactions takes the edit permission on their account
+func TestTransactionPolicy_Edit(t *testing.T) {
+	inner := &FakeTransactionService{}
+	policy := newTestTransactionPolicy(inner)
+	ctx := userContext("5")
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := policy.CreateTransaction(ctx, "2", money.MustParse("1", "EUR"), transactions.KindDebit, "", "", date, nil)
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.UpdateTransaction(ctx, "20", transactions.TransactionChanges{}, 0)
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.PostTransaction(ctx, "20", date)
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.ImportTransaction(ctx, "2", money.MustParse("1", "EUR"), transactions.KindDebit, "", "X", date, date)
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+
+	_, err = policy.CreateTransaction(ctx, "1", money.MustParse("1", "EUR"), transactions.KindDebit, "", "", date, nil)
+	assert.Nil(t, err)
+	_, err = policy.UpdateTransaction(ctx, "10", transactions.TransactionChanges{}, 0)
+	assert.Nil(t, err)
+	_, err = policy.PostTransaction(ctx, "10", date)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"create 1", "update 10", "post 10"}, inner.Calls)
+}
+
+// Test reading a transaction takes the view permission on its account
+func TestTransactionPolicyGetTransaction(t *testing.T) {
+	policy := newTestTransactionPolicy(&FakeTransactionService{})
+
+	_, err := policy.GetTransaction(userContext("6"), "10")
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	transaction, err := policy.GetTransaction(userContext("6"), "20")
+	assert.Nil(t, err)
+	assert.Equal(t, "2", transaction.AccountID)
+	_, err = policy.GetTransaction(userContext("6"), "99")
+	assert.True(t, errors.Is(err, transactions.ErrTransactionNotFound), "Expected ErrTransactionNotFound")
+}
+
+// Test voiding a leg of a transfer takes the edit permission on both accounts
+func TestTransactionPolicyVoidTransaction(t *testing.T) {
+	inner := &FakeTransactionService{}
+	policy := newTestTransactionPolicy(inner)
+
+	_, err := policy.VoidTransaction(userContext("5"), "11")
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden for the viewer of account 2")
+	_, err = policy.VoidTransaction(userContext("5"), "10")
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"void 10"}, inner.Calls)
+}
+
+// Test transfers take the edit permission on both accounts
+func TestTransactionPolicyCreateTransfer(t *testing.T) {
+	inner := &FakeTransactionService{}
+	policy := newTestTransactionPolicy(inner)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := policy.CreateTransfer(userContext("5"), "1", "2", money.MustParse("1", "EUR"), "", date)
+
+	var forbidden *ForbiddenError
+	assert.True(t, errors.As(err, &forbidden), "Expected a ForbiddenError")
+	assert.Equal(t, "2", forbidden.AccountID)
+	assert.Empty(t, inner.Calls)
+}
+
+// Test categorising takes the edit permission on every transaction's account, skipping unknown ones
+func TestTransactionPolicyCategorizeTransactions(t *testing.T) {
+	inner := &FakeTransactionService{}
+	policy := newTestTransactionPolicy(inner)
+
+	_, err := policy.CategorizeTransactions(userContext("5"), []string{"10", "20"}, "3")
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	count, err := policy.CategorizeTransactions(userContext("5"), []string{"10", "99"}, "3")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	assert.Equal(t, []string{"categorize 10,99"}, inner.Calls)
+}
+
+// Test an oversized batch is rejected before any transaction is looked up or written
+func TestTransactionPolicyCategorizeTransactions_TooMany(t *testing.T) {
+	inner := &FakeTransactionService{}
+	policy := newTestTransactionPolicy(inner)
+	ids := make([]string, transactions.MaxPageSize+1)
+	for i := range ids {
+		ids[i] = "20"
+	}
+
+	_, err := policy.CategorizeTransactions(userContext("5"), ids, "3")
+
+	var invalid *transactions.ValidationError
+	assert.True(t, errors.As(err, &invalid), "Expected a ValidationError")
+	assert.True(t, errors.Is(err, transactions.ErrInvalidTransaction), "Expected ErrInvalidTransaction")
+	assert.Empty(t, inner.Calls)
+}
+
+// Test listings are restricted to the accounts the caller may view
+func TestTransactionPolicyListTransactions(t *testing.T) {
+	inner := &FakeTransactionService{}
+	policy := newTestTransactionPolicy(inner)
+
+	_, err := policy.ListTransactions(userContext("6"), transactions.TransactionFilter{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2"}, inner.Filter.AccountIDs)
+
+	_, err = policy.ListTransactions(userContext("6"), transactions.TransactionFilter{AccountID: "1"})
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+
+	err = policy.ExportTransactions(userContext("7"), transactions.TransactionFilter{}, func(*transactions.Transaction) error { return nil })
+	assert.Nil(t, err)
+	assert.Equal(t, []string{}, inner.Filter.AccountIDs, "A user without grants should see nothing")
+}
+
+// Test balances take the view permission on the account
+func TestTransactionPolicyGetBalance(t *testing.T) {
+	inner := &FakeTransactionService{}
+	policy := newTestTransactionPolicy(inner)
+
+	_, err := policy.GetBalance(userContext("6"), "1", nil, "")
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.GetBalance(userContext("5"), "2", nil, "")
+	assert.Nil(t, err)
+}
+
+// Test an account the tenant does not have is denied as not found before the services are called
+func TestTransactionPolicy_UnknownAccount(t *testing.T) {
+	inner := &FakeTransactionService{}
+	policy := newTestTransactionPolicy(inner)
+	ctx := userContext("5")
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := policy.GetBalance(ctx, "99", nil, "")
+	assert.True(t, errors.Is(err, transactions.ErrAccountNotFound), "Expected ErrAccountNotFound")
+	_, err = policy.CreateTransaction(ctx, "99", money.MustParse("1", "EUR"), transactions.KindDebit, "", "", date, nil)
+	assert.True(t, errors.Is(err, transactions.ErrAccountNotFound), "Expected ErrAccountNotFound")
+	_, err = policy.CreateTransfer(ctx, "1", "99", money.MustParse("1", "EUR"), "", date)
+	assert.True(t, errors.Is(err, transactions.ErrAccountNotFound), "Expected ErrAccountNotFound")
+	_, err = policy.ListTransactions(ctx, transactions.TransactionFilter{AccountID: "99"})
+	assert.True(t, errors.Is(err, transactions.ErrAccountNotFound), "Expected ErrAccountNotFound")
+
+	assert.Empty(t, inner.Calls)
+}
+
+// Test imports take the edit permission on the account
+func TestImportPolicy(t *testing.T) {
+	inner := &FakeImportService{}
+	policy := NewImportPolicy(inner, newTestAccessService(sharedGrants()))
+
+	_, err := policy.ImportCSV(userContext("5"), "2", "1", strings.NewReader(""))
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.ImportStatement(userContext("5"), "1", imports.FormatOFX, strings.NewReader(""))
+	assert.Nil(t, err)
+
+	_, err = policy.ImportCSV(userContext("5"), "99", "1", strings.NewReader(""))
+	assert.True(t, errors.Is(err, imports.ErrAccountNotFound), "Expected ErrAccountNotFound")
+
+	assert.Equal(t, []string{"statement 1"}, inner.Calls)
+}
+
+// Test finding duplicates takes the view permission, and merging or dismissing a pair the edit
+// permission on the accounts of both transactions
+func TestDuplicatePolicy(t *testing.T) {
+	inner := &FakeInsightService{}
+	policy := NewDuplicatePolicy(inner, &FakeTransactionService{}, newTestAccessService(sharedGrants()))
+	ctx := userContext("5")
+
+	_, err := policy.FindDuplicates(ctx, transactions.DuplicateFilter{AccountID: "2"})
+	assert.Nil(t, err)
+	_, err = policy.FindDuplicates(ctx, transactions.DuplicateFilter{AccountID: "99"})
+	assert.True(t, errors.Is(err, transactions.ErrAccountNotFound), "Expected ErrAccountNotFound")
+	_, err = policy.FindDuplicates(userContext("6"), transactions.DuplicateFilter{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2"}, inner.AccountIDs)
+
+	_, err = policy.ResolveDuplicate(ctx, transactions.ResolutionMerged, "10", "20")
+	var forbidden *ForbiddenError
+	assert.True(t, errors.As(err, &forbidden), "Expected a ForbiddenError")
+	assert.Equal(t, "2", forbidden.AccountID)
+	assert.Equal(t, PermissionEdit, forbidden.Permission)
+	_, err = policy.ResolveDuplicate(ctx, transactions.ResolutionDismissed, "10", "11")
+	assert.Nil(t, err)
+	_, err = policy.ResolveDuplicate(ctx, transactions.ResolutionDismissed, "10", "99")
+	assert.Nil(t, err, "An unknown transaction should be left for the duplicate service")
+
+	_, err = policy.ListDuplicateResolutions(userContext("7"), transactions.ResolutionFilter{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{}, inner.AccountIDs, "A user without grants should see nothing")
+
+	assert.Equal(t, []string{"find 2", "find ", "resolve 10 11", "resolve 10 99", "resolutions"}, inner.Calls)
+}
+
+// Test applying rules takes the edit permission on every account it could change, and a dry run
+// the view permission
+func TestRulePolicy(t *testing.T) {
+	inner := &FakeInsightService{}
+	all := &FakeAccountService{Accounts: []*accounts.Account{{ID: "1"}, {ID: "2"}}}
+	policy := NewRulePolicy(inner, all, newTestAccessService(sharedGrants()))
+	ctx := userContext("5")
+
+	_, err := policy.ApplyRules(ctx, transactions.RuleApplication{AccountID: "2"})
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.ApplyRules(ctx, transactions.RuleApplication{AccountID: "2", DryRun: true})
+	assert.Nil(t, err)
+	_, err = policy.ApplyRules(ctx, transactions.RuleApplication{AccountID: "99", DryRun: true})
+	assert.True(t, errors.Is(err, transactions.ErrAccountNotFound), "Expected ErrAccountNotFound")
+
+	_, err = policy.ApplyRules(ctx, transactions.RuleApplication{})
+	var forbidden *ForbiddenError
+	assert.True(t, errors.As(err, &forbidden), "Expected a ForbiddenError")
+	assert.Equal(t, "2", forbidden.AccountID)
+	_, err = policy.ApplyRules(userContext("6"), transactions.RuleApplication{DryRun: true})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2"}, inner.AccountIDs)
+
+	grants := sharedGrants()
+	grants.Grants[1].Role = RoleEditor
+	_, err = NewRulePolicy(inner, all, newTestAccessService(grants)).ApplyRules(ctx, transactions.RuleApplication{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, inner.AccountIDs)
+
+	assert.Equal(t, []string{"apply 2", "apply ", "apply "}, inner.Calls)
+}
+
+// Test creating, changing and deleting a rule takes the edit permission on its account, or on
+// every account for a rule without one, and that only the rules of viewable accounts are listed
+func TestRulePolicy_Rules(t *testing.T) {
+	inner := &FakeInsightService{}
+	all := &FakeAccountService{Accounts: []*accounts.Account{{ID: "1"}, {ID: "2"}}}
+	policy := NewRulePolicy(inner, all, newTestAccessService(sharedGrants()))
+	viewer, owner := userContext("5"), userContext("6")
+
+	_, err := policy.CreateRule(viewer, &transactions.Rule{AccountID: "2"})
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.CreateRule(viewer, &transactions.Rule{})
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.CreateRule(viewer, &transactions.Rule{AccountID: "99"})
+	assert.True(t, errors.Is(err, transactions.ErrInvalidRule), "Expected ErrInvalidRule")
+	_, err = policy.CreateRule(owner, &transactions.Rule{AccountID: "2"})
+	assert.Nil(t, err)
+
+	_, err = policy.UpdateRule(viewer, "2", &transactions.Rule{AccountID: "1"})
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.UpdateRule(owner, "2", &transactions.Rule{AccountID: "1"})
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden moving the rule to an account the caller may not edit")
+	_, err = policy.UpdateRule(owner, "2", &transactions.Rule{AccountID: "2"})
+	assert.Nil(t, err)
+	_, err = policy.UpdateRule(owner, "99", &transactions.Rule{})
+	assert.True(t, errors.Is(err, transactions.ErrRuleNotFound), "Expected ErrRuleNotFound")
+
+	err = policy.DeleteRule(owner, "1")
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	err = policy.DeleteRule(owner, "2")
+	assert.Nil(t, err)
+
+	rules, err := policy.ListRules(owner)
+	assert.Nil(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "2", rules[1].ID)
+
+	assert.Equal(t, []string{"create rule 2", "update rule 2", "delete rule 2"}, inner.Calls)
+}
+
+// Test spending reports take the view permission on the account, or only add up the accounts
+// the caller may view
+func TestReportPolicy(t *testing.T) {
+	inner := &FakeInsightService{}
+	policy := NewReportPolicy(inner, newTestAccessService(sharedGrants()))
+
+	_, err := policy.ReportSpending(userContext("6"), reports.SpendingQuery{AccountID: "1"})
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.ReportSpending(userContext("6"), reports.SpendingQuery{AccountID: "99"})
+	assert.True(t, errors.Is(err, reports.ErrAccountNotFound), "Expected ErrAccountNotFound")
+	_, err = policy.ReportSpending(userContext("5"), reports.SpendingQuery{AccountID: "2"})
+	assert.Nil(t, err)
+	assert.Nil(t, inner.AccountIDs)
+
+	_, err = policy.ReportSpending(userContext("6"), reports.SpendingQuery{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2"}, inner.AccountIDs)
+
+	assert.Equal(t, []string{"report 2", "report "}, inner.Calls)
+}
+
+// Test a budget's status takes the view permission on its account, or only counts the
+// accounts the caller may view
+func TestBudgetPolicy(t *testing.T) {
+	inner := &FakeInsightService{}
+	policy := NewBudgetPolicy(inner, newTestAccessService(sharedGrants()))
+
+	_, err := policy.GetBudgetStatus(userContext("7"), "2", budgets.StatusQuery{})
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.GetBudgetStatus(userContext("5"), "3", budgets.StatusQuery{})
+	assert.True(t, errors.Is(err, budgets.ErrBudgetNotFound), "Expected ErrBudgetNotFound")
+	_, err = policy.GetBudgetStatus(userContext("5"), "2", budgets.StatusQuery{})
+	assert.Nil(t, err)
+
+	_, err = policy.GetBudgetStatus(userContext("6"), "1", budgets.StatusQuery{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2"}, inner.AccountIDs)
+
+	assert.Equal(t, []string{"status 2", "status 1"}, inner.Calls)
+}
+
+// Test a budget takes the view permission on its account to be seen, and the edit permission
+// to be created, changed or deleted, while budgets without an account are shared
+func TestBudgetPolicy_Budgets(t *testing.T) {
+	inner := &FakeInsightService{}
+	policy := NewBudgetPolicy(inner, newTestAccessService(sharedGrants()))
+	viewer, owner, stranger := userContext("5"), userContext("6"), userContext("7")
+
+	_, err := policy.CreateBudget(viewer, &budgets.Budget{AccountID: "2"})
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.CreateBudget(viewer, &budgets.Budget{AccountID: "99"})
+	assert.Nil(t, err, "An unknown account is left to the budget service")
+	_, err = policy.CreateBudget(stranger, &budgets.Budget{})
+	assert.Nil(t, err)
+	_, err = policy.CreateBudget(owner, &budgets.Budget{AccountID: "2"})
+	assert.Nil(t, err)
+
+	_, err = policy.GetBudget(stranger, "2")
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.GetBudget(stranger, "3")
+	assert.True(t, errors.Is(err, budgets.ErrBudgetNotFound), "Expected ErrBudgetNotFound")
+	budget, err := policy.GetBudget(viewer, "2")
+	assert.Nil(t, err)
+	assert.Equal(t, "2", budget.ID)
+
+	_, err = policy.UpdateBudget(viewer, "2", &budgets.Budget{AccountID: "2"})
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	_, err = policy.UpdateBudget(owner, "2", &budgets.Budget{AccountID: "1"})
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden moving the budget to an account the caller may not edit")
+	_, err = policy.UpdateBudget(owner, "2", &budgets.Budget{AccountID: "2"})
+	assert.Nil(t, err)
+	_, err = policy.UpdateBudget(owner, "99", &budgets.Budget{})
+	assert.True(t, errors.Is(err, budgets.ErrBudgetNotFound), "Expected ErrBudgetNotFound")
+
+	err = policy.DeleteBudget(viewer, "2")
+	assert.True(t, errors.Is(err, ErrForbidden), "Expected ErrForbidden")
+	err = policy.DeleteBudget(stranger, "1")
+	assert.Nil(t, err)
+
+	visible, err := policy.ListBudgets(owner)
+	assert.Nil(t, err)
+	assert.Len(t, visible, 2)
+	assert.Equal(t, "2", visible[1].ID)
+	visible, err = policy.ListBudgets(stranger)
+	assert.Nil(t, err)
+	assert.Len(t, visible, 1)
+
+	assert.Equal(t, []string{"create budget 99", "create budget ", "create budget 2", "update budget 2", "delete budget 1"}, inner.Calls)
+}
@@ -0,0 +1,67 @@
+package access
+
+import "context"
+
+// ForGrantingAccess defines the port for giving a user of the tenant a role on an account
+type ForGrantingAccess interface {
+	GrantAccess(ctx context.Context, accountID, userID string, role Role) (*Grant, error)
+}
+
+// ForListingAccess defines the port for listing who has access to an account
+type ForListingAccess interface {
+	ListAccess(ctx context.Context, accountID string) ([]*Grant, error)
+}
+
+// ForChangingAccess defines the port for changing a user's role on an account
+type ForChangingAccess interface {
+	ChangeAccess(ctx context.Context, accountID, userID string, role Role) (*Grant, error)
+}
+
+// ForRevokingAccess defines the port for taking a user's access to an account away
+type ForRevokingAccess interface {
+	RevokeAccess(ctx context.Context, accountID, userID string) error
+}
+
+// ForSavingGrant defines the port for saving a grant on an account of a tenant to persistence.
+// It fails with ErrDuplicateGrant if the user already has a role on the account.
+type ForSavingGrant interface {
+	SaveGrant(ctx context.Context, tenantID string, grant *Grant) error
+}
+
+// ForLoadingGrants defines the port for loading grants on accounts of a tenant from
+// persistence: a user's grant on an account, failing with ErrGrantNotFound if there is none,
+// every grant on an account, and every grant of a user.
+type ForLoadingGrants interface {
+	LoadGrant(ctx context.Context, tenantID, accountID, userID string) (*Grant, error)
+	LoadGrants(ctx context.Context, tenantID, accountID string) ([]*Grant, error)
+	LoadUserGrants(ctx context.Context, tenantID, userID string) ([]*Grant, error)
+}
+
+// ForModifyingGrant defines the port for storing a changed role of a grant of a tenant
+type ForModifyingGrant interface {
+	ModifyGrant(ctx context.Context, tenantID string, grant *Grant) error
+}
+
+// ForRemovingGrants defines the port for removing a user's grant on an account of a tenant,
+// or every grant on it, from persistence
+type ForRemovingGrants interface {
+	RemoveGrant(ctx context.Context, tenantID, accountID, userID string) error
+	RemoveGrants(ctx context.Context, tenantID, accountID string) error
+}
+
+// ForCheckingAccount defines the port for checking that an account of a tenant exists
+type ForCheckingAccount interface {
+	AccountExists(ctx context.Context, tenantID, id string) (bool, error)
+}
+
+// ForCheckingUser defines the port for checking that a user of a tenant exists
+type ForCheckingUser interface {
+	UserExists(ctx context.Context, tenantID, id string) (bool, error)
+}
+
+// ForRunningInTransaction defines the port for running several persistence
+// operations atomically. Persistence calls made with the context passed to fn
+// either all take effect or none do.
+type ForRunningInTransaction interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,44 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/reports"
+)
+
+// ReportPolicy enforces the caller's role on the accounts a spending report
+// adds up, which takes the view permission.
+type ReportPolicy struct {
+	reports reports.ForReportingSpending
+	access  *AccessService
+}
+
+// NewReportPolicy creates a new ReportPolicy in front of the report service.
+func NewReportPolicy(reports reports.ForReportingSpending, access *AccessService) *ReportPolicy {
+	return &ReportPolicy{
+		reports: reports,
+		access:  access,
+	}
+}
+
+// ReportSpending reports on the query's account if the caller may view it, or
+// else on the accounts they may view.
+func (p *ReportPolicy) ReportSpending(ctx context.Context, query reports.SpendingQuery) (*reports.SpendingReport, error) {
+	if query.AccountID != "" {
+		err := p.access.Authorize(ctx, query.AccountID, PermissionView)
+		if errors.Is(err, accounts.ErrAccountNotFound) {
+			return nil, reports.ErrAccountNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		return p.reports.ReportSpending(ctx, query)
+	}
+	ids, err := p.access.AccessibleAccounts(ctx, PermissionView)
+	if err != nil {
+		return nil, err
+	}
+	query.AccountIDs = ids
+	return p.reports.ReportSpending(ctx, query)
+}
@@ -0,0 +1,179 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/transactions"
+)
+
+// ruleService is the rule service RulePolicy stands in front of
+type ruleService interface {
+	transactions.ForCreatingRule
+	transactions.ForGettingRule
+	transactions.ForListingRules
+	transactions.ForUpdatingRule
+	transactions.ForDeletingRule
+	transactions.ForApplyingRules
+}
+
+// RulePolicy enforces the caller's role on the accounts whose transactions
+// categorisation rules change. Creating, changing, deleting and applying
+// rules takes the edit permission on every account they could change: the
+// rule's own, or all of the tenant's for a rule without one. Listing rules and
+// a dry run take the view permission.
+type RulePolicy struct {
+	rules    ruleService
+	accounts accounts.ForListingAccounts
+	access   *AccessService
+}
+
+// NewRulePolicy creates a new RulePolicy in front of the rule service,
+// finding the tenant's accounts with the account service.
+func NewRulePolicy(rules ruleService, accounts accounts.ForListingAccounts, access *AccessService) *RulePolicy {
+	return &RulePolicy{
+		rules:    rules,
+		accounts: accounts,
+		access:   access,
+	}
+}
+
+// CreateRule creates the rule if the caller may edit every account it could
+// change. A rule on an account the tenant does not have is invalid.
+func (p *RulePolicy) CreateRule(ctx context.Context, rule *transactions.Rule) (*transactions.Rule, error) {
+	if err := p.authorizeRule(ctx, rule.AccountID); err != nil {
+		return nil, err
+	}
+	return p.rules.CreateRule(ctx, rule)
+}
+
+// ListRules lists the rules on the accounts the caller may view, and those on
+// none.
+func (p *RulePolicy) ListRules(ctx context.Context) ([]*transactions.Rule, error) {
+	ids, err := p.access.AccessibleAccounts(ctx, PermissionView)
+	if err != nil {
+		return nil, err
+	}
+	all, err := p.rules.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	visible := []*transactions.Rule{}
+	for _, rule := range all {
+		if rule.AccountID == "" || slices.Contains(ids, rule.AccountID) {
+			visible = append(visible, rule)
+		}
+	}
+	return visible, nil
+}
+
+// UpdateRule replaces the rule if the caller may edit every account it could
+// change, both before and after.
+func (p *RulePolicy) UpdateRule(ctx context.Context, id string, rule *transactions.Rule) (*transactions.Rule, error) {
+	existing, err := p.rules.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.authorizeRule(ctx, existing.AccountID); err != nil {
+		return nil, err
+	}
+	if rule.AccountID != existing.AccountID {
+		if err := p.authorizeRule(ctx, rule.AccountID); err != nil {
+			return nil, err
+		}
+	}
+	return p.rules.UpdateRule(ctx, id, rule)
+}
+
+// DeleteRule deletes the rule if the caller may edit every account it could
+// change.
+func (p *RulePolicy) DeleteRule(ctx context.Context, id string) error {
+	existing, err := p.rules.GetRule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := p.authorizeRule(ctx, existing.AccountID); err != nil {
+		return err
+	}
+	return p.rules.DeleteRule(ctx, id)
+}
+
+// ApplyRules applies the rules to the transactions of the application's
+// account if the caller may edit it, or else to those of every account of
+// the tenant if the caller may edit them all. A dry run only needs the view
+// permission, and without an account covers the accounts the caller may view.
+func (p *RulePolicy) ApplyRules(ctx context.Context, application transactions.RuleApplication) (*transactions.RuleRun, error) {
+	permission := PermissionEdit
+	if application.DryRun {
+		permission = PermissionView
+	}
+	if application.AccountID != "" {
+		if err := p.authorize(ctx, application.AccountID, permission); err != nil {
+			return nil, err
+		}
+		return p.rules.ApplyRules(ctx, application)
+	}
+
+	if application.DryRun {
+		ids, err := p.access.AccessibleAccounts(ctx, PermissionView)
+		if err != nil {
+			return nil, err
+		}
+		application.AccountIDs = ids
+		return p.rules.ApplyRules(ctx, application)
+	}
+	ids, err := p.authorizeAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Only the accounts checked here are changed, even if another one is
+	// created meanwhile
+	application.AccountIDs = ids
+	return p.rules.ApplyRules(ctx, application)
+}
+
+// authorizeRule checks the caller may edit the account of a rule, or every
+// account of the tenant for a rule without one. An account the tenant does
+// not have is reported as an invalid rule.
+func (p *RulePolicy) authorizeRule(ctx context.Context, accountID string) error {
+	if accountID == "" {
+		_, err := p.authorizeAll(ctx)
+		return err
+	}
+	err := p.authorize(ctx, accountID, PermissionEdit)
+	if errors.Is(err, transactions.ErrAccountNotFound) {
+		invalid := &transactions.ValidationError{Err: transactions.ErrInvalidRule}
+		invalid.Add("accountID", fmt.Sprintf("unknown account %q", accountID))
+		return invalid
+	}
+	return err
+}
+
+// authorizeAll checks the caller may edit every account of the tenant, and
+// returns their IDs
+func (p *RulePolicy) authorizeAll(ctx context.Context) ([]string, error) {
+	all, err := p.accounts.ListAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(all))
+	for _, account := range all {
+		if err := p.authorize(ctx, account.ID, PermissionEdit); err != nil {
+			return nil, err
+		}
+		ids = append(ids, account.ID)
+	}
+	return ids, nil
+}
+
+// authorize checks the caller holds the permission on the account, reporting
+// an account the tenant does not have as transactions.ErrAccountNotFound
+func (p *RulePolicy) authorize(ctx context.Context, accountID string, permission Permission) error {
+	err := p.access.Authorize(ctx, accountID, permission)
+	if errors.Is(err, accounts.ErrAccountNotFound) {
+		return transactions.ErrAccountNotFound
+	}
+	return err
+}
@@ -0,0 +1,250 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/auth"
+	"time"
+)
+
+// AccessService provides the core logic for deciding who may do what with
+// an account, and for granting, changing and revoking that access.
+type AccessService struct {
+	grantPersistence ForSavingGrant
+	grantLoader      ForLoadingGrants
+	grantModifier    ForModifyingGrant
+	grantRemover     ForRemovingGrants
+	accounts         ForCheckingAccount
+	users            ForCheckingUser
+	transactor       ForRunningInTransaction
+}
+
+// NewAccessService creates a new AccessService.
+func NewAccessService(persistence ForSavingGrant, loader ForLoadingGrants, modifier ForModifyingGrant, remover ForRemovingGrants, accounts ForCheckingAccount, users ForCheckingUser, transactor ForRunningInTransaction) *AccessService {
+	return &AccessService{
+		grantPersistence: persistence,
+		grantLoader:      loader,
+		grantModifier:    modifier,
+		grantRemover:     remover,
+		accounts:         accounts,
+		users:            users,
+		transactor:       transactor,
+	}
+}
+
+// GrantAccess gives the user with the given ID, a user of the same tenant,
+// the role on the account. It takes the administer permission, and a user
+// can only hold one role on an account; ChangeAccess changes it.
+func (s *AccessService) GrantAccess(ctx context.Context, accountID, userID string, role Role) (*Grant, error) {
+	principal, err := principalFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidGrant, role)
+	}
+	if err := s.authorize(ctx, principal, accountID, PermissionAdminister); err != nil {
+		return nil, err
+	}
+	exists, err := s.users.UserExists(ctx, principal.TenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+
+	grant := &Grant{AccountID: accountID, UserID: userID, Role: role, GrantedBy: principal.Subject, CreatedAt: now()}
+	if err := s.grantPersistence.SaveGrant(ctx, principal.TenantID, grant); err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+// ListAccess retrieves every grant on the account. Anyone who may view the
+// account may see who else has access to it.
+func (s *AccessService) ListAccess(ctx context.Context, accountID string) ([]*Grant, error) {
+	principal, err := principalFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, principal, accountID, PermissionView); err != nil {
+		return nil, err
+	}
+	return s.grantLoader.LoadGrants(ctx, principal.TenantID, accountID)
+}
+
+// ChangeAccess gives the user who already has access to the account the
+// given role instead. It takes the administer permission, and fails with
+// ErrLastOwner rather than leave the account without an owner.
+func (s *AccessService) ChangeAccess(ctx context.Context, accountID, userID string, role Role) (*Grant, error) {
+	principal, err := principalFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidGrant, role)
+	}
+	if err := s.authorize(ctx, principal, accountID, PermissionAdminister); err != nil {
+		return nil, err
+	}
+
+	var grant *Grant
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		grant, err = s.grantLoader.LoadGrant(ctx, principal.TenantID, accountID, userID)
+		if err != nil {
+			return err
+		}
+		if grant.Role == RoleOwner && role != RoleOwner {
+			if err := s.keepOwner(ctx, principal.TenantID, accountID); err != nil {
+				return err
+			}
+		}
+		grant.Role = role
+		return s.grantModifier.ModifyGrant(ctx, principal.TenantID, grant)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+// RevokeAccess takes the user's access to the account away. It takes the
+// administer permission, except that anyone may give up their own access,
+// and fails with ErrLastOwner rather than leave the account without an owner.
+func (s *AccessService) RevokeAccess(ctx context.Context, accountID, userID string) error {
+	principal, err := principalFrom(ctx)
+	if err != nil {
+		return err
+	}
+	permission := PermissionAdminister
+	if userID == principal.UserID {
+		permission = PermissionView
+	}
+	if err := s.authorize(ctx, principal, accountID, permission); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		grant, err := s.grantLoader.LoadGrant(ctx, principal.TenantID, accountID, userID)
+		if err != nil {
+			return err
+		}
+		if grant.Role == RoleOwner {
+			if err := s.keepOwner(ctx, principal.TenantID, accountID); err != nil {
+				return err
+			}
+		}
+		return s.grantRemover.RemoveGrant(ctx, principal.TenantID, accountID, userID)
+	})
+}
+
+// Authorize returns nil if the user of the principal in ctx holds a role on
+// the account that grants the permission, a *ForbiddenError if not, and
+// accounts.ErrAccountNotFound if the tenant has no such account.
+func (s *AccessService) Authorize(ctx context.Context, accountID string, permission Permission) error {
+	principal, err := principalFrom(ctx)
+	if err != nil {
+		return err
+	}
+	return s.authorize(ctx, principal, accountID, permission)
+}
+
+// AccessibleAccounts returns the IDs of the accounts on which the user of
+// the principal in ctx holds a role that grants the permission.
+func (s *AccessService) AccessibleAccounts(ctx context.Context, permission Permission) ([]string, error) {
+	principal, err := principalFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.grantLoader.LoadUserGrants(ctx, principal.TenantID, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, grant := range grants {
+		if grant.Role.Allows(permission) {
+			ids = append(ids, grant.AccountID)
+		}
+	}
+	return ids, nil
+}
+
+// GrantOwnership makes the user of the principal in ctx the owner of the
+// account, which they have just created.
+func (s *AccessService) GrantOwnership(ctx context.Context, accountID string) error {
+	principal, err := principalFrom(ctx)
+	if err != nil {
+		return err
+	}
+	grant := &Grant{AccountID: accountID, UserID: principal.UserID, Role: RoleOwner, CreatedAt: now()}
+	return s.grantPersistence.SaveGrant(ctx, principal.TenantID, grant)
+}
+
+// RemoveAccess takes everyone's access to the account away, as it is deleted.
+func (s *AccessService) RemoveAccess(ctx context.Context, accountID string) error {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+	return s.grantRemover.RemoveGrants(ctx, tenantID, accountID)
+}
+
+// authorize returns nil if the principal's user holds a role on the account
+// that grants the permission, a *ForbiddenError if not, and
+// accounts.ErrAccountNotFound if the tenant has no such account
+func (s *AccessService) authorize(ctx context.Context, principal *auth.Principal, accountID string, permission Permission) error {
+	grant, err := s.grantLoader.LoadGrant(ctx, principal.TenantID, accountID, principal.UserID)
+	if errors.Is(err, ErrGrantNotFound) {
+		exists, err := s.accounts.AccountExists(ctx, principal.TenantID, accountID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return accounts.ErrAccountNotFound
+		}
+		return &ForbiddenError{AccountID: accountID, Permission: permission}
+	}
+	if err != nil {
+		return err
+	}
+	if !grant.Role.Allows(permission) {
+		return &ForbiddenError{AccountID: accountID, Permission: permission, Role: grant.Role}
+	}
+	return nil
+}
+
+// keepOwner fails with ErrLastOwner unless the account has more than one owner
+func (s *AccessService) keepOwner(ctx context.Context, tenantID, accountID string) error {
+	grants, err := s.grantLoader.LoadGrants(ctx, tenantID, accountID)
+	if err != nil {
+		return err
+	}
+	owners := 0
+	for _, grant := range grants {
+		if grant.Role == RoleOwner {
+			owners++
+		}
+	}
+	if owners < 2 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+// principalFrom returns the principal in ctx, or ErrUnauthenticated if there
+// is none or it acts as no user of a tenant
+func principalFrom(ctx context.Context) (*auth.Principal, error) {
+	principal, ok := auth.PrincipalFrom(ctx)
+	if !ok || principal.TenantID == "" || principal.UserID == "" {
+		return nil, fmt.Errorf("%w: no user in context", auth.ErrUnauthenticated)
+	}
+	return principal, nil
+}
+
+// now returns the current time as it is stored
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
@@ -0,0 +1,206 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/accounts"
+	"spend-api/internal/domain/money"
+	"spend-api/internal/domain/transactions"
+	"time"
+)
+
+// transactionService is the transaction service TransactionPolicy stands in front of
+type transactionService interface {
+	transactions.ForCreatingTransaction
+	transactions.ForImportingTransaction
+	transactions.ForGettingTransaction
+	transactions.ForPostingTransaction
+	transactions.ForVoidingTransaction
+	transactions.ForCategorizingTransactions
+	transactions.ForListingTransactions
+	transactions.ForGettingBalance
+}
+
+// TransactionPolicy enforces the caller's role on the accounts of
+// transactions before handing the call on to the transaction, edit, transfer
+// and export services. Reading takes the view permission on the account, and
+// anything that changes its transactions takes the edit permission.
+type TransactionPolicy struct {
+	transactions transactionService
+	edits        transactions.ForUpdatingTransaction
+	transfers    transactions.ForCreatingTransfer
+	exports      transactions.ForExportingTransactions
+	access       *AccessService
+}
+
+// NewTransactionPolicy creates a new TransactionPolicy in front of the services.
+func NewTransactionPolicy(transactions transactionService, edits transactions.ForUpdatingTransaction, transfers transactions.ForCreatingTransfer, exports transactions.ForExportingTransactions, access *AccessService) *TransactionPolicy {
+	return &TransactionPolicy{
+		transactions: transactions,
+		edits:        edits,
+		transfers:    transfers,
+		exports:      exports,
+		access:       access,
+	}
+}
+
+// CreateTransaction records the transaction if the caller may edit its account.
+func (p *TransactionPolicy) CreateTransaction(ctx context.Context, accountID string, amount money.Money, kind transactions.Kind, description, categoryID string, date time.Time, postedDate *time.Time) (*transactions.Transaction, error) {
+	if err := p.authorize(ctx, accountID, PermissionEdit); err != nil {
+		return nil, err
+	}
+	return p.transactions.CreateTransaction(ctx, accountID, amount, kind, description, categoryID, date, postedDate)
+}
+
+// ImportTransaction records the imported transaction if the caller may edit its account.
+func (p *TransactionPolicy) ImportTransaction(ctx context.Context, accountID string, amount money.Money, kind transactions.Kind, description, externalID string, date, postedDate time.Time) (*transactions.Transaction, error) {
+	if err := p.authorize(ctx, accountID, PermissionEdit); err != nil {
+		return nil, err
+	}
+	return p.transactions.ImportTransaction(ctx, accountID, amount, kind, description, externalID, date, postedDate)
+}
+
+// GetTransaction retrieves the transaction if the caller may view its account.
+func (p *TransactionPolicy) GetTransaction(ctx context.Context, id string) (*transactions.Transaction, error) {
+	transaction, err := p.transactions.GetTransaction(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.authorize(ctx, transaction.AccountID, PermissionView); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// UpdateTransaction edits the transaction if the caller may edit its account.
+func (p *TransactionPolicy) UpdateTransaction(ctx context.Context, id string, changes transactions.TransactionChanges, version int) (*transactions.Transaction, error) {
+	if err := p.authorizeTransaction(ctx, id, PermissionEdit); err != nil {
+		return nil, err
+	}
+	return p.edits.UpdateTransaction(ctx, id, changes, version)
+}
+
+// PostTransaction posts the transaction if the caller may edit its account.
+func (p *TransactionPolicy) PostTransaction(ctx context.Context, id string, postedDate time.Time) (*transactions.Transaction, error) {
+	if err := p.authorizeTransaction(ctx, id, PermissionEdit); err != nil {
+		return nil, err
+	}
+	return p.transactions.PostTransaction(ctx, id, postedDate)
+}
+
+// VoidTransaction voids the transaction if the caller may edit its account.
+// A leg of a transfer is voided with the other, so the caller must be able
+// to edit both accounts.
+func (p *TransactionPolicy) VoidTransaction(ctx context.Context, id string) (*transactions.Transaction, error) {
+	transaction, err := p.transactions.GetTransaction(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	accountIDs := []string{transaction.AccountID}
+	if transaction.TransferID != "" {
+		page, err := p.transactions.ListTransactions(ctx, transactions.TransactionFilter{TransferID: transaction.TransferID})
+		if err != nil {
+			return nil, err
+		}
+		for _, leg := range page.Transactions {
+			accountIDs = append(accountIDs, leg.AccountID)
+		}
+	}
+	for _, accountID := range accountIDs {
+		if err := p.authorize(ctx, accountID, PermissionEdit); err != nil {
+			return nil, err
+		}
+	}
+	return p.transactions.VoidTransaction(ctx, id)
+}
+
+// CategorizeTransactions moves the transactions into the category if the
+// caller may edit the accounts of all of them. Unknown IDs are left for the
+// transaction service to skip. A batch the service would not accept is
+// rejected before any transaction is looked up.
+func (p *TransactionPolicy) CategorizeTransactions(ctx context.Context, ids []string, categoryID string) (int, error) {
+	invalid := &transactions.ValidationError{}
+	transactions.ValidateBatch(ids, invalid)
+	if err := invalid.OrNil(); err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		err := p.authorizeTransaction(ctx, id, PermissionEdit)
+		if err != nil && !errors.Is(err, transactions.ErrTransactionNotFound) {
+			return 0, err
+		}
+	}
+	return p.transactions.CategorizeTransactions(ctx, ids, categoryID)
+}
+
+// ListTransactions lists the transactions of the accounts the caller may
+// view, or of the filter's account if the caller may view it.
+func (p *TransactionPolicy) ListTransactions(ctx context.Context, filter transactions.TransactionFilter) (*transactions.TransactionPage, error) {
+	filter, err := p.restrict(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return p.transactions.ListTransactions(ctx, filter)
+}
+
+// ExportTransactions exports the transactions of the accounts the caller may
+// view, or of the filter's account if the caller may view it.
+func (p *TransactionPolicy) ExportTransactions(ctx context.Context, filter transactions.TransactionFilter, each func(*transactions.Transaction) error) error {
+	filter, err := p.restrict(ctx, filter)
+	if err != nil {
+		return err
+	}
+	return p.exports.ExportTransactions(ctx, filter, each)
+}
+
+// GetBalance returns the account's balance if the caller may view it.
+func (p *TransactionPolicy) GetBalance(ctx context.Context, accountID string, asOf *time.Time, currency money.Currency) (*transactions.AccountBalance, error) {
+	if err := p.authorize(ctx, accountID, PermissionView); err != nil {
+		return nil, err
+	}
+	return p.transactions.GetBalance(ctx, accountID, asOf, currency)
+}
+
+// CreateTransfer moves the money if the caller may edit both accounts.
+func (p *TransactionPolicy) CreateTransfer(ctx context.Context, fromAccountID, toAccountID string, amount money.Money, description string, date time.Time) (*transactions.Transfer, error) {
+	for _, accountID := range []string{fromAccountID, toAccountID} {
+		if err := p.authorize(ctx, accountID, PermissionEdit); err != nil {
+			return nil, err
+		}
+	}
+	return p.transfers.CreateTransfer(ctx, fromAccountID, toAccountID, amount, description, date)
+}
+
+// authorize checks the caller holds the permission on the account, reporting
+// an account the tenant does not have as transactions.ErrAccountNotFound
+func (p *TransactionPolicy) authorize(ctx context.Context, accountID string, permission Permission) error {
+	err := p.access.Authorize(ctx, accountID, permission)
+	if errors.Is(err, accounts.ErrAccountNotFound) {
+		return transactions.ErrAccountNotFound
+	}
+	return err
+}
+
+// authorizeTransaction checks the caller holds the permission on the account
+// of the transaction with the given ID
+func (p *TransactionPolicy) authorizeTransaction(ctx context.Context, id string, permission Permission) error {
+	transaction, err := p.transactions.GetTransaction(ctx, id)
+	if err != nil {
+		return err
+	}
+	return p.authorize(ctx, transaction.AccountID, permission)
+}
+
+// restrict narrows a listing down to what the caller may view: the filter's
+// account if they may view it, or else the accounts they may view
+func (p *TransactionPolicy) restrict(ctx context.Context, filter transactions.TransactionFilter) (transactions.TransactionFilter, error) {
+	if filter.AccountID != "" {
+		return filter, p.authorize(ctx, filter.AccountID, PermissionView)
+	}
+	ids, err := p.access.AccessibleAccounts(ctx, PermissionView)
+	if err != nil {
+		return filter, err
+	}
+	filter.AccountIDs = ids
+	return filter, nil
+}
@@ -1,23 +1,129 @@
 package accounts
 
 import (
+	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/money"
 	"testing"
 )
 
 // FakeForSavingAccount simulates the persistence layer for testing.
 type FakeForSavingAccount struct {
 	ReturnError bool
+	TenantID    string
 }
 
-func (f *FakeForSavingAccount) SaveAccount(account *Account) error {
+func (f *FakeForSavingAccount) SaveAccount(ctx context.Context, tenantID string, account *Account) error {
 	if f.ReturnError {
 		return errors.New("failed to save account")
 	}
+	f.TenantID = tenantID
 	return nil
 }
 
+// FakeAccountStore simulates loading, modifying and removing accounts for testing. Its accounts
+// belong to tenant 1.
+type FakeAccountStore struct {
+	Accounts    map[string]*Account
+	ReturnError bool
+	Removed     []string
+}
+
+func (f *FakeAccountStore) LoadAccount(ctx context.Context, tenantID, id string) (*Account, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to load account")
+	}
+	account, ok := f.Accounts[id]
+	if !ok || tenantID != "1" {
+		return nil, ErrAccountNotFound
+	}
+	return &Account{ID: account.ID, Name: account.Name, Version: account.Version}, nil
+}
+
+func (f *FakeAccountStore) LoadAccounts(ctx context.Context, tenantID string) ([]*Account, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to load accounts")
+	}
+	var result []*Account
+	if tenantID != "1" {
+		return result, nil
+	}
+	for _, account := range f.Accounts {
+		result = append(result, account)
+	}
+	return result, nil
+}
+
+func (f *FakeAccountStore) ModifyAccount(ctx context.Context, tenantID string, account *Account) error {
+	if f.ReturnError {
+		return errors.New("failed to modify account")
+	}
+	if f.Accounts[account.ID].Version != account.Version {
+		return ErrVersionMismatch
+	}
+	account.Version++
+	f.Accounts[account.ID] = account
+	return nil
+}
+
+func (f *FakeAccountStore) RemoveAccount(ctx context.Context, tenantID, id string, version int) error {
+	if f.ReturnError {
+		return errors.New("failed to remove account")
+	}
+	account, ok := f.Accounts[id]
+	if !ok || tenantID != "1" {
+		return ErrAccountNotFound
+	}
+	if version != 0 && account.Version != version {
+		return ErrVersionMismatch
+	}
+	f.Removed = append(f.Removed, id)
+	return nil
+}
+
+// FakeForRecordingOpeningBalance simulates recording opening balances for testing.
+type FakeForRecordingOpeningBalance struct {
+	ReturnError bool
+	Recorded    map[string]money.Money
+}
+
+func (f *FakeForRecordingOpeningBalance) RecordOpeningBalance(ctx context.Context, tenantID, accountID string, amount money.Money) error {
+	if f.ReturnError {
+		return errors.New("failed to record opening balance")
+	}
+	if f.Recorded == nil {
+		f.Recorded = map[string]money.Money{}
+	}
+	f.Recorded[accountID] = amount
+	return nil
+}
+
+// FakeTransactor simulates a unit of work, recording whether it committed or rolled back.
+type FakeTransactor struct {
+	Committed  bool
+	RolledBack bool
+}
+
+func (f *FakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		f.RolledBack = true
+		return err
+	}
+	f.Committed = true
+	return nil
+}
+
+// tenantContext returns a context acting as a user of the given tenant
+func tenantContext(tenantID string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice", UserID: "5", TenantID: tenantID})
+}
+
+func newTestAccountService(persistence ForSavingAccount, store *FakeAccountStore) *AccountService {
+	return NewAccountService(persistence, store, store, store, &FakeForRecordingOpeningBalance{}, &FakeTransactor{})
+}
+
 // Test for creating a new account
 func TestCreateAccount(t *testing.T) {
 	accountID := "12345"
@@ -31,14 +137,16 @@ func TestCreateAccount(t *testing.T) {
 // Test for saving an account using persistence
 func TestAccountServiceCreateAccount(t *testing.T) {
 	fakePersistence := &FakeForSavingAccount{}
-	accountService := NewAccountService(fakePersistence)
+	accountService := newTestAccountService(fakePersistence, &FakeAccountStore{})
 
 	accountName := "John Doe"
-	newAccount, err := accountService.CreateAccount(accountName)
+	newAccount, err := accountService.CreateAccount(tenantContext("1"), accountName, "GB29NWBK60161331926819", "EUR", money.Zero("EUR"))
 
 	assert.Nil(t, err, "Error should be nil when creating an account")
 	assert.Equal(t, "", newAccount.ID, "Created account ID should be blank")
 	assert.Equal(t, accountName, newAccount.Name, "Created account name should match")
+	assert.Equal(t, money.Currency("EUR"), newAccount.Currency, "Created account currency should match")
+	assert.Equal(t, "GB29NWBK60161331926819", newAccount.Number, "Created account number should match")
 }
 
 // Test account creation failure due to SaveAccount error
@@ -46,11 +154,232 @@ func TestAccountServiceCreateAccount_SaveError(t *testing.T) {
 	fakePersistence := &FakeForSavingAccount{
 		ReturnError: true,
 	}
-	accountService := NewAccountService(fakePersistence)
+	accountService := newTestAccountService(fakePersistence, &FakeAccountStore{})
 
 	accountName := "John Doe"
-	newAccount, err := accountService.CreateAccount(accountName)
+	newAccount, err := accountService.CreateAccount(tenantContext("1"), accountName, "", "EUR", money.Zero("EUR"))
 
 	assert.NotNil(t, err, "Expected an error when saving account")
 	assert.Nil(t, newAccount, "No account should be returned when there's a saving error")
 }
+
+// Test that an account cannot be created with a blank name
+func TestAccountServiceCreateAccount_BlankName(t *testing.T) {
+	fakePersistence := &FakeForSavingAccount{}
+	accountService := newTestAccountService(fakePersistence, &FakeAccountStore{})
+
+	newAccount, err := accountService.CreateAccount(tenantContext("1"), "  ", "", "EUR", money.Zero("EUR"))
+
+	assert.True(t, errors.Is(err, ErrNameRequired), "Expected ErrNameRequired")
+	assert.Nil(t, newAccount, "No account should be returned")
+}
+
+// Test for getting an existing account
+func TestAccountServiceGetAccount(t *testing.T) {
+	store := &FakeAccountStore{Accounts: map[string]*Account{"1": {ID: "1", Name: "Savings"}}}
+	accountService := newTestAccountService(&FakeForSavingAccount{}, store)
+
+	account, err := accountService.GetAccount(tenantContext("1"), "1")
+
+	assert.Nil(t, err, "Error should be nil when getting an account")
+	assert.Equal(t, "Savings", account.Name, "Account name should match")
+}
+
+// Test for getting an account that does not exist
+func TestAccountServiceGetAccount_NotFound(t *testing.T) {
+	store := &FakeAccountStore{Accounts: map[string]*Account{}}
+	accountService := newTestAccountService(&FakeForSavingAccount{}, store)
+
+	account, err := accountService.GetAccount(tenantContext("1"), "1")
+
+	assert.True(t, errors.Is(err, ErrAccountNotFound), "Expected ErrAccountNotFound")
+	assert.Nil(t, account, "No account should be returned")
+}
+
+// Test for listing accounts
+func TestAccountServiceListAccounts(t *testing.T) {
+	store := &FakeAccountStore{Accounts: map[string]*Account{"1": {ID: "1", Name: "Savings"}}}
+	accountService := newTestAccountService(&FakeForSavingAccount{}, store)
+
+	result, err := accountService.ListAccounts(tenantContext("1"))
+
+	assert.Nil(t, err, "Error should be nil when listing accounts")
+	assert.Len(t, result, 1, "One account should be listed")
+}
+
+// Test for renaming an account
+func TestAccountServiceUpdateAccount(t *testing.T) {
+	store := &FakeAccountStore{Accounts: map[string]*Account{"1": {ID: "1", Name: "Savings"}}}
+	accountService := newTestAccountService(&FakeForSavingAccount{}, store)
+
+	account, err := accountService.UpdateAccount(tenantContext("1"), "1", "Holiday fund", 0)
+
+	assert.Nil(t, err, "Error should be nil when updating an account")
+	assert.Equal(t, "Holiday fund", account.Name, "Returned account should be renamed")
+	assert.Equal(t, "Holiday fund", store.Accounts["1"].Name, "Stored account should be renamed")
+}
+
+// Test that an update based on the current version advances it
+func TestAccountServiceUpdateAccount_Version(t *testing.T) {
+	store := &FakeAccountStore{Accounts: map[string]*Account{"1": {ID: "1", Name: "Savings", Version: 3}}}
+	accountService := newTestAccountService(&FakeForSavingAccount{}, store)
+
+	account, err := accountService.UpdateAccount(tenantContext("1"), "1", "Holiday fund", 3)
+
+	assert.Nil(t, err, "Error should be nil when updating the current version")
+	assert.Equal(t, 4, account.Version, "Returned account should be at the next version")
+}
+
+// Test that an update based on a stale version is refused
+func TestAccountServiceUpdateAccount_VersionMismatch(t *testing.T) {
+	store := &FakeAccountStore{Accounts: map[string]*Account{"1": {ID: "1", Name: "Savings", Version: 3}}}
+	accountService := newTestAccountService(&FakeForSavingAccount{}, store)
+
+	account, err := accountService.UpdateAccount(tenantContext("1"), "1", "Holiday fund", 2)
+
+	assert.True(t, errors.Is(err, ErrVersionMismatch), "Expected ErrVersionMismatch")
+	assert.Nil(t, account, "No account should be returned")
+	assert.Equal(t, "Savings", store.Accounts["1"].Name, "Stored account should be unchanged")
+}
+
+// Test that an account cannot be renamed to a blank name
+func TestAccountServiceUpdateAccount_BlankName(t *testing.T) {
+	store := &FakeAccountStore{Accounts: map[string]*Account{"1": {ID: "1", Name: "Savings"}}}
+	accountService := newTestAccountService(&FakeForSavingAccount{}, store)
+
+	account, err := accountService.UpdateAccount(tenantContext("1"), "1", "", 0)
+
+	assert.True(t, errors.Is(err, ErrNameRequired), "Expected ErrNameRequired")
+	assert.Nil(t, account, "No account should be returned")
+	assert.Equal(t, "Savings", store.Accounts["1"].Name, "Stored account should be unchanged")
+}
+
+// Test for updating an account that does not exist
+func TestAccountServiceUpdateAccount_NotFound(t *testing.T) {
+	store := &FakeAccountStore{Accounts: map[string]*Account{}}
+	accountService := newTestAccountService(&FakeForSavingAccount{}, store)
+
+	account, err := accountService.UpdateAccount(tenantContext("1"), "1", "Holiday fund", 0)
+
+	assert.True(t, errors.Is(err, ErrAccountNotFound), "Expected ErrAccountNotFound")
+	assert.Nil(t, account, "No account should be returned")
+}
+
+// Test for deleting an account
+func TestAccountServiceDeleteAccount(t *testing.T) {
+	store := &FakeAccountStore{Accounts: map[string]*Account{"1": {ID: "1", Name: "Savings"}}}
+	accountService := newTestAccountService(&FakeForSavingAccount{}, store)
+
+	err := accountService.DeleteAccount(tenantContext("1"), "1", 0)
+
+	assert.Nil(t, err, "Error should be nil when deleting an account")
+	assert.Equal(t, []string{"1"}, store.Removed, "Account should be removed")
+}
+
+// Test that deleting a stale version of an account is refused
+func TestAccountServiceDeleteAccount_VersionMismatch(t *testing.T) {
+	store := &FakeAccountStore{Accounts: map[string]*Account{"1": {ID: "1", Name: "Savings", Version: 3}}}
+	accountService := newTestAccountService(&FakeForSavingAccount{}, store)
+
+	err := accountService.DeleteAccount(tenantContext("1"), "1", 2)
+
+	assert.True(t, errors.Is(err, ErrVersionMismatch), "Expected ErrVersionMismatch")
+	assert.Empty(t, store.Removed, "Account should not be removed")
+}
+
+// Test that an opening balance is recorded together with the new account
+func TestAccountServiceCreateAccount_WithOpeningBalance(t *testing.T) {
+	openingBalances := &FakeForRecordingOpeningBalance{}
+	transactor := &FakeTransactor{}
+	store := &FakeAccountStore{}
+	accountService := NewAccountService(&FakeForSavingAccount{}, store, store, store, openingBalances, transactor)
+
+	newAccount, err := accountService.CreateAccount(tenantContext("1"), "Savings", "", "EUR", money.MustParse("250.50", "EUR"))
+
+	assert.Nil(t, err, "Error should be nil when creating an account with an opening balance")
+	assert.Equal(t, money.MustParse("250.50", "EUR"), openingBalances.Recorded[newAccount.ID], "Opening balance should be recorded for the new account")
+	assert.True(t, transactor.Committed, "Unit of work should be committed")
+}
+
+// Test that no opening balance transaction is recorded for a zero balance
+func TestAccountServiceCreateAccount_ZeroOpeningBalance(t *testing.T) {
+	openingBalances := &FakeForRecordingOpeningBalance{}
+	store := &FakeAccountStore{}
+	accountService := NewAccountService(&FakeForSavingAccount{}, store, store, store, openingBalances, &FakeTransactor{})
+
+	_, err := accountService.CreateAccount(tenantContext("1"), "Savings", "", "EUR", money.Zero("EUR"))
+
+	assert.Nil(t, err)
+	assert.Empty(t, openingBalances.Recorded, "No opening balance should be recorded")
+}
+
+// Test that a failure recording the opening balance rolls back the account creation
+func TestAccountServiceCreateAccount_OpeningBalanceError(t *testing.T) {
+	transactor := &FakeTransactor{}
+	store := &FakeAccountStore{}
+	accountService := NewAccountService(&FakeForSavingAccount{}, store, store, store, &FakeForRecordingOpeningBalance{ReturnError: true}, transactor)
+
+	newAccount, err := accountService.CreateAccount(tenantContext("1"), "Savings", "", "EUR", money.MustParse("250.50", "EUR"))
+
+	assert.NotNil(t, err, "Expected an error when the opening balance cannot be recorded")
+	assert.Nil(t, newAccount, "No account should be returned")
+	assert.True(t, transactor.RolledBack, "Unit of work should be rolled back")
+}
+
+// Test that an account cannot be created with an unknown currency
+func TestAccountServiceCreateAccount_UnknownCurrency(t *testing.T) {
+	fakePersistence := &FakeForSavingAccount{}
+	accountService := newTestAccountService(fakePersistence, &FakeAccountStore{})
+
+	newAccount, err := accountService.CreateAccount(tenantContext("1"), "Savings", "", "XYZ", money.Money{})
+
+	assert.True(t, errors.Is(err, money.ErrUnknownCurrency), "Expected ErrUnknownCurrency")
+	assert.Nil(t, newAccount, "No account should be returned")
+}
+
+// Test that the opening balance must be in the account's currency
+func TestAccountServiceCreateAccount_OpeningBalanceCurrencyMismatch(t *testing.T) {
+	openingBalances := &FakeForRecordingOpeningBalance{}
+	store := &FakeAccountStore{}
+	accountService := NewAccountService(&FakeForSavingAccount{}, store, store, store, openingBalances, &FakeTransactor{})
+
+	newAccount, err := accountService.CreateAccount(tenantContext("1"), "Savings", "", "GBP", money.MustParse("250.50", "EUR"))
+
+	assert.True(t, errors.Is(err, money.ErrCurrencyMismatch), "Expected ErrCurrencyMismatch")
+	assert.Nil(t, newAccount, "No account should be returned")
+	assert.Empty(t, openingBalances.Recorded, "No opening balance should be recorded")
+}
+
+// Test accounts are created for the tenant of the caller, and a tenant is required
+func TestAccountServiceCreateAccount_Tenant(t *testing.T) {
+	persistence := &FakeForSavingAccount{}
+	accountService := newTestAccountService(persistence, &FakeAccountStore{})
+
+	_, err := accountService.CreateAccount(tenantContext("2"), "Savings", "", "EUR", money.Zero("EUR"))
+	assert.Nil(t, err)
+	assert.Equal(t, "2", persistence.TenantID, "The account should belong to the caller's tenant")
+
+	_, err = accountService.CreateAccount(context.Background(), "Savings", "", "EUR", money.Zero("EUR"))
+	assert.True(t, errors.Is(err, auth.ErrUnauthenticated), "Expected ErrUnauthenticated")
+}
+
+// Test accounts of another tenant cannot be seen, changed or deleted
+func TestAccountService_OtherTenant(t *testing.T) {
+	store := &FakeAccountStore{Accounts: map[string]*Account{"1": {ID: "1", Name: "Savings", Version: 1}}}
+	accountService := newTestAccountService(&FakeForSavingAccount{}, store)
+	ctx := tenantContext("2")
+
+	_, err := accountService.GetAccount(ctx, "1")
+	assert.True(t, errors.Is(err, ErrAccountNotFound), "Expected ErrAccountNotFound")
+
+	result, err := accountService.ListAccounts(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, result)
+
+	_, err = accountService.UpdateAccount(ctx, "1", "Holiday fund", 0)
+	assert.True(t, errors.Is(err, ErrAccountNotFound), "Expected ErrAccountNotFound")
+
+	err = accountService.DeleteAccount(ctx, "1", 0)
+	assert.True(t, errors.Is(err, ErrAccountNotFound), "Expected ErrAccountNotFound")
+	assert.Empty(t, store.Removed)
+}
@@ -0,0 +1,18 @@
+package accounts
+
+import "errors"
+
+// ErrAccountNotFound is returned when no account exists with the requested ID.
+var ErrAccountNotFound = errors.New("account not found")
+
+// ErrAccountHasTransactions is returned when deleting an account that still has transactions.
+var ErrAccountHasTransactions = errors.New("account still has transactions")
+
+// ErrAccountInUse is returned when deleting an account that budgets or categorisation rules still refer to.
+var ErrAccountInUse = errors.New("account still has budgets or rules")
+
+// ErrNameRequired is returned when an account is created or renamed with a blank name.
+var ErrNameRequired = errors.New("account name is required")
+
+// ErrVersionMismatch is returned when an account changed since the version the caller last read.
+var ErrVersionMismatch = errors.New("account version mismatch")
@@ -1,9 +1,17 @@
 package accounts
 
-// Account represents a bank account with an ID and a Name.
+import "spend-api/internal/domain/money"
+
+// Account represents a bank account with an ID, a Name, the optional Number
+// the bank knows it by and the ISO 4217 currency its transactions are held in.
+// Version starts at 1 and goes up with every change, so a client can tell
+// whether the account changed since it last read it.
 type Account struct {
-	ID   string
-	Name string
+	ID       string
+	Number   string
+	Name     string
+	Currency money.Currency
+	Version  int
 }
 
 // NewAccount creates a new account with the given ID and Name.
@@ -1,11 +1,70 @@
 package accounts
 
+import (
+	"context"
+	"spend-api/internal/domain/money"
+)
+
 // ForCreatingAccount defines the port for creating an account.
 type ForCreatingAccount interface {
-	CreateAccount(name string) (*Account, error)
+	CreateAccount(ctx context.Context, name, number string, currency money.Currency, openingBalance money.Money) (*Account, error)
+}
+
+// ForGettingAccount defines the port for retrieving a single account.
+type ForGettingAccount interface {
+	GetAccount(ctx context.Context, id string) (*Account, error)
+}
+
+// ForListingAccounts defines the port for listing all accounts.
+type ForListingAccounts interface {
+	ListAccounts(ctx context.Context) ([]*Account, error)
 }
 
-// ForSavingAccount defines the port for saving an account to persistence
+// ForUpdatingAccount defines the port for updating an account. A non-zero version must match
+// the account's current version.
+type ForUpdatingAccount interface {
+	UpdateAccount(ctx context.Context, id, name string, version int) (*Account, error)
+}
+
+// ForDeletingAccount defines the port for deleting an account. A non-zero version must match
+// the account's current version.
+type ForDeletingAccount interface {
+	DeleteAccount(ctx context.Context, id string, version int) error
+}
+
+// ForSavingAccount defines the port for saving an account of a tenant to persistence
 type ForSavingAccount interface {
-	SaveAccount(account *Account) error
+	SaveAccount(ctx context.Context, tenantID string, account *Account) error
+}
+
+// ForLoadingAccount defines the port for loading accounts of a tenant from persistence. Accounts
+// of other tenants are not found.
+type ForLoadingAccount interface {
+	LoadAccount(ctx context.Context, tenantID, id string) (*Account, error)
+	LoadAccounts(ctx context.Context, tenantID string) ([]*Account, error)
+}
+
+// ForModifyingAccount defines the port for persisting changes to an existing account. The
+// change only applies if the stored account is still at account.Version, so concurrent changes
+// cannot both succeed. Both the stored version and account.Version then go up by one.
+type ForModifyingAccount interface {
+	ModifyAccount(ctx context.Context, tenantID string, account *Account) error
+}
+
+// ForRemovingAccount defines the port for removing an account of a tenant from persistence. A
+// non-zero version must match the stored account's version.
+type ForRemovingAccount interface {
+	RemoveAccount(ctx context.Context, tenantID, id string, version int) error
+}
+
+// ForRecordingOpeningBalance defines the port for recording the opening balance of a tenant's new account
+type ForRecordingOpeningBalance interface {
+	RecordOpeningBalance(ctx context.Context, tenantID, accountID string, amount money.Money) error
+}
+
+// ForRunningInTransaction defines the port for running several persistence
+// operations atomically. Persistence calls made with the context passed to fn
+// either all take effect or none do.
+type ForRunningInTransaction interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
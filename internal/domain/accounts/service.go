@@ -1,28 +1,134 @@
 package accounts
 
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/money"
+	"strings"
+)
+
 // AccountService provides the core logic for managing accounts.
 type AccountService struct {
 	accountPersistence ForSavingAccount
+	accountLoader      ForLoadingAccount
+	accountModifier    ForModifyingAccount
+	accountRemover     ForRemovingAccount
+	openingBalances    ForRecordingOpeningBalance
+	transactor         ForRunningInTransaction
 }
 
 // NewAccountService creates a new AccountService.
-func NewAccountService(persistence ForSavingAccount) *AccountService {
+func NewAccountService(persistence ForSavingAccount, loader ForLoadingAccount, modifier ForModifyingAccount, remover ForRemovingAccount, openingBalances ForRecordingOpeningBalance, transactor ForRunningInTransaction) *AccountService {
 	return &AccountService{
 		accountPersistence: persistence,
+		accountLoader:      loader,
+		accountModifier:    modifier,
+		accountRemover:     remover,
+		openingBalances:    openingBalances,
+		transactor:         transactor,
 	}
 }
 
-// CreateAccount creates a new account and saves it using persistence.
-func (s *AccountService) CreateAccount(name string) (*Account, error) {
+// CreateAccount creates a new account held in the given currency and saves it
+// using persistence, owned by the tenant of the principal in ctx. A non-zero
+// opening balance must be in the account's currency and is recorded in the
+// same unit of work, so either both the account and its opening balance are
+// stored or neither is.
+func (s *AccountService) CreateAccount(ctx context.Context, name, number string, currency money.Currency, openingBalance money.Money) (*Account, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrNameRequired
+	}
+	if !currency.IsValid() {
+		return nil, fmt.Errorf("%w: %q", money.ErrUnknownCurrency, currency)
+	}
+	if !openingBalance.IsZero() && openingBalance.Currency() != currency {
+		return nil, fmt.Errorf("%w: opening balance is in %s but the account is in %s", money.ErrCurrencyMismatch, openingBalance.Currency(), currency)
+	}
+
 	account := &Account{
-		Name: name,
+		Number:   number,
+		Name:     name,
+		Currency: currency,
+		Version:  1,
 	}
 
-	// Save the account using the persistence port
-	err := s.accountPersistence.SaveAccount(account)
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Save the account using the persistence port
+		if err := s.accountPersistence.SaveAccount(ctx, tenantID, account); err != nil {
+			return err
+		}
+		if openingBalance.IsZero() {
+			return nil
+		}
+		return s.openingBalances.RecordOpeningBalance(ctx, tenantID, account.ID, openingBalance)
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return account, nil
 }
+
+// GetAccount retrieves the account with the given ID. Accounts of other
+// tenants are not found.
+func (s *AccountService) GetAccount(ctx context.Context, id string) (*Account, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.accountLoader.LoadAccount(ctx, tenantID, id)
+}
+
+// ListAccounts retrieves all accounts of the tenant.
+func (s *AccountService) ListAccounts(ctx context.Context) ([]*Account, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.accountLoader.LoadAccounts(ctx, tenantID)
+}
+
+// UpdateAccount renames the account with the given ID. The name must not be
+// blank. Unless version is zero, the account must still be at that version,
+// or ErrVersionMismatch is returned and nothing changes.
+func (s *AccountService) UpdateAccount(ctx context.Context, id, name string, version int) (*Account, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrNameRequired
+	}
+	account, err := s.accountLoader.LoadAccount(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && account.Version != version {
+		return nil, fmt.Errorf("%w: account %s is at version %d, not %d", ErrVersionMismatch, id, account.Version, version)
+	}
+
+	account.Name = name
+
+	err = s.accountModifier.ModifyAccount(ctx, tenantID, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// DeleteAccount deletes the account with the given ID. Accounts that still
+// have transactions cannot be deleted. Unless version is zero, the account
+// must still be at that version.
+func (s *AccountService) DeleteAccount(ctx context.Context, id string, version int) error {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+	return s.accountRemover.RemoveAccount(ctx, tenantID, id, version)
+}
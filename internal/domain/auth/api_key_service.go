@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// APIKeyService provides the core logic for issuing, revoking and rotating
+// API keys and for authenticating requests made with them.
+type APIKeyService struct {
+	keyPersistence ForSavingAPIKey
+	keyLoader      ForLoadingAPIKeys
+	keyModifier    ForModifyingAPIKey
+	userLoader     ForLoadingUsers
+	transactor     ForRunningInTransaction
+}
+
+// NewAPIKeyService creates a new APIKeyService.
+func NewAPIKeyService(persistence ForSavingAPIKey, loader ForLoadingAPIKeys, modifier ForModifyingAPIKey, users ForLoadingUsers, transactor ForRunningInTransaction) *APIKeyService {
+	return &APIKeyService{
+		keyPersistence: persistence,
+		keyLoader:      loader,
+		keyModifier:    modifier,
+		userLoader:     users,
+		transactor:     transactor,
+	}
+}
+
+// CreateAPIKey issues a new API key with the given name to the user of the
+// principal in ctx, and saves it. The key itself is only returned here;
+// only its hash is saved. The key records the principal that created it.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, name string) (*IssuedAPIKey, error) {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok || principal.TenantID == "" {
+		return nil, fmt.Errorf("%w: no principal to issue the key to", ErrUnauthenticated)
+	}
+	name, err := validAPIKeyName(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.issue(ctx, principal.TenantID, principal.UserID, name, principal.Subject)
+}
+
+// CreateAPIKeyForSubject issues a new API key with the given name to the
+// user with the given subject. It is how the first key of a tenant is
+// issued, before anyone can authenticate to create one.
+func (s *APIKeyService) CreateAPIKeyForSubject(ctx context.Context, subject, name string) (*IssuedAPIKey, error) {
+	name, err := validAPIKeyName(name)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userLoader.LoadUserBySubject(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	return s.issue(ctx, user.TenantID, user.ID, name, "")
+}
+
+// ListAPIKeys retrieves all API keys of the tenant, including revoked ones.
+func (s *APIKeyService) ListAPIKeys(ctx context.Context) ([]*APIKey, error) {
+	tenantID, err := TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.keyLoader.LoadAPIKeys(ctx, tenantID)
+}
+
+// RevokeAPIKey revokes the API key with the given ID, after which it can no
+// longer be used. Only the user a key was issued to can revoke it; the keys
+// of other users are not found. Revoking a key that is already revoked
+// changes nothing.
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id string) error {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok || principal.TenantID == "" {
+		return fmt.Errorf("%w: no tenant in context", ErrUnauthenticated)
+	}
+	key, err := s.loadOwnKey(ctx, principal, id)
+	if err != nil {
+		return err
+	}
+	if key.Revoked() {
+		return nil
+	}
+	return s.revoke(ctx, principal.TenantID, key)
+}
+
+// RotateAPIKey replaces the API key with the given ID by a new one with the
+// same name and user, revoking the old key in the same unit of work. As with
+// revoking, only the user a key was issued to can rotate it. A key that is
+// already revoked cannot be rotated and fails with ErrAPIKeyRevoked.
+func (s *APIKeyService) RotateAPIKey(ctx context.Context, id string) (*IssuedAPIKey, error) {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok || principal.TenantID == "" {
+		return nil, fmt.Errorf("%w: no tenant in context", ErrUnauthenticated)
+	}
+
+	var issued *IssuedAPIKey
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		key, err := s.loadOwnKey(ctx, principal, id)
+		if err != nil {
+			return err
+		}
+		if key.Revoked() {
+			return fmt.Errorf("%w: %s", ErrAPIKeyRevoked, id)
+		}
+		if err := s.revoke(ctx, principal.TenantID, key); err != nil {
+			return err
+		}
+		issued, err = s.issue(ctx, principal.TenantID, key.UserID, key.Name, principal.Subject)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
+
+// AuthenticateAPIKey returns the principal behind the given API key. Keys
+// that are malformed, unknown or revoked fail with ErrUnauthenticated.
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, presented string) (*Principal, error) {
+	prefix, ok := apiKeyPrefix(presented)
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed API key", ErrUnauthenticated)
+	}
+
+	key, err := s.keyLoader.LoadAPIKeyByPrefix(ctx, prefix)
+	if errors.Is(err, ErrAPIKeyNotFound) {
+		return nil, fmt.Errorf("%w: unknown API key", ErrUnauthenticated)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(presented)), []byte(key.SecretHash)) != 1 {
+		return nil, fmt.Errorf("%w: unknown API key", ErrUnauthenticated)
+	}
+	if key.Revoked() {
+		return nil, fmt.Errorf("%w: API key %s was revoked", ErrUnauthenticated, key.ID)
+	}
+
+	return &Principal{Subject: "api-key:" + key.ID, Method: MethodAPIKey, KeyID: key.ID, UserID: key.UserID, TenantID: key.TenantID}, nil
+}
+
+// loadOwnKey loads the key with the given ID if it was issued to the user of
+// the principal, and fails with ErrAPIKeyNotFound for anyone else's key
+func (s *APIKeyService) loadOwnKey(ctx context.Context, principal *Principal, id string) (*APIKey, error) {
+	key, err := s.keyLoader.LoadAPIKey(ctx, principal.TenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if principal.UserID == "" || key.UserID != principal.UserID {
+		return nil, fmt.Errorf("%w: %s", ErrAPIKeyNotFound, id)
+	}
+	return key, nil
+}
+
+// validAPIKeyName returns the name a key is given, trimmed, or an error if it is not valid
+func validAPIKeyName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MaxAPIKeyNameLength {
+		return "", fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidAPIKey, MaxAPIKeyNameLength)
+	}
+	return name, nil
+}
+
+// issue generates a new key with the given name for a user of the tenant and
+// saves it, recording createdBy as its creator
+func (s *APIKeyService) issue(ctx context.Context, tenantID, userID, name, createdBy string) (*IssuedAPIKey, error) {
+	prefix := make([]byte, 6)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	presented := APIKeyPrefix + hex.EncodeToString(prefix) + "_" + base64.RawURLEncoding.EncodeToString(secret)
+
+	key := &APIKey{
+		TenantID:   tenantID,
+		UserID:     userID,
+		Name:       name,
+		Prefix:     hex.EncodeToString(prefix),
+		SecretHash: hashAPIKey(presented),
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+	}
+	if err := s.keyPersistence.SaveAPIKey(ctx, tenantID, key); err != nil {
+		return nil, err
+	}
+	return &IssuedAPIKey{APIKey: key, Key: presented}, nil
+}
+
+// revoke marks the key revoked as of now and stores the change
+func (s *APIKeyService) revoke(ctx context.Context, tenantID string, key *APIKey) error {
+	revokedAt := time.Now().UTC().Truncate(time.Second)
+	key.RevokedAt = &revokedAt
+	return s.keyModifier.ModifyAPIKey(ctx, tenantID, key)
+}
@@ -0,0 +1,249 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeAPIKeyStore simulates saving, loading and modifying API keys for testing.
+type FakeAPIKeyStore struct {
+	Keys        map[string]*APIKey
+	Modified    []*APIKey
+	ReturnError bool
+}
+
+func newFakeAPIKeyStore(keys ...*APIKey) *FakeAPIKeyStore {
+	store := &FakeAPIKeyStore{Keys: map[string]*APIKey{}}
+	for _, key := range keys {
+		store.Keys[key.ID] = key
+	}
+	return store
+}
+
+func (f *FakeAPIKeyStore) SaveAPIKey(ctx context.Context, tenantID string, key *APIKey) error {
+	if f.ReturnError {
+		return errors.New("failed to save API key")
+	}
+	key.ID = string(rune('0' + len(f.Keys) + 1))
+	key.TenantID = tenantID
+	f.Keys[key.ID] = key
+	return nil
+}
+
+func (f *FakeAPIKeyStore) LoadAPIKey(ctx context.Context, tenantID, id string) (*APIKey, error) {
+	key, ok := f.Keys[id]
+	if !ok || key.TenantID != tenantID {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+func (f *FakeAPIKeyStore) LoadAPIKeyByPrefix(ctx context.Context, prefix string) (*APIKey, error) {
+	for _, key := range f.Keys {
+		if key.Prefix == prefix {
+			return key, nil
+		}
+	}
+	return nil, ErrAPIKeyNotFound
+}
+
+func (f *FakeAPIKeyStore) LoadAPIKeys(ctx context.Context, tenantID string) ([]*APIKey, error) {
+	var keys []*APIKey
+	for _, key := range f.Keys {
+		if key.TenantID == tenantID {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (f *FakeAPIKeyStore) ModifyAPIKey(ctx context.Context, tenantID string, key *APIKey) error {
+	f.Modified = append(f.Modified, key)
+	return nil
+}
+
+// FakeTransactor runs fn directly, counting how often it was asked to.
+type FakeTransactor struct {
+	Calls int
+}
+
+func (f *FakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.Calls++
+	return fn(ctx)
+}
+
+func newAPIKeyService(store *FakeAPIKeyStore) *APIKeyService {
+	return NewAPIKeyService(store, store, store, newFakeUserStore(alice), &FakeTransactor{})
+}
+
+// tenantContext returns a context acting as alice, a user of tenant 1
+func tenantContext() context.Context {
+	return WithPrincipal(context.Background(), &Principal{Subject: "alice", Method: MethodToken, UserID: "5", TenantID: "1"})
+}
+
+// Test an issued key can be used to authenticate, and only its hash is kept
+func TestAPIKeyServiceCreateAPIKey(t *testing.T) {
+	store := newFakeAPIKeyStore()
+	service := newAPIKeyService(store)
+
+	issued, err := service.CreateAPIKey(tenantContext(), "  CI pipeline ")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "CI pipeline", issued.Name)
+	assert.Equal(t, "alice", issued.CreatedBy)
+	assert.Equal(t, "1", issued.TenantID)
+	assert.Equal(t, "5", issued.UserID)
+	assert.True(t, strings.HasPrefix(issued.Key, APIKeyPrefix+issued.Prefix+"_"))
+	assert.Equal(t, hashAPIKey(issued.Key), store.Keys[issued.ID].SecretHash)
+	assert.NotContains(t, store.Keys[issued.ID].SecretHash, issued.Key)
+
+	principal, err := service.AuthenticateAPIKey(context.Background(), issued.Key)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Principal{Subject: "api-key:" + issued.ID, Method: MethodAPIKey, KeyID: issued.ID, UserID: "5", TenantID: "1"}, principal)
+}
+
+// Test keys can only be created on behalf of a principal
+func TestAPIKeyServiceCreateAPIKey_Unauthenticated(t *testing.T) {
+	_, err := newAPIKeyService(newFakeAPIKeyStore()).CreateAPIKey(context.Background(), "CI")
+
+	assert.True(t, errors.Is(err, ErrUnauthenticated), "Expected ErrUnauthenticated")
+}
+
+// Test the first key of a tenant is issued to a user by subject
+func TestAPIKeyServiceCreateAPIKeyForSubject(t *testing.T) {
+	service := newAPIKeyService(newFakeAPIKeyStore())
+
+	issued, err := service.CreateAPIKeyForSubject(context.Background(), "alice", "Admin")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1", issued.TenantID)
+	assert.Equal(t, "5", issued.UserID)
+	assert.Equal(t, "", issued.CreatedBy)
+
+	_, err = service.CreateAPIKeyForSubject(context.Background(), "mallory", "Admin")
+
+	assert.True(t, errors.Is(err, ErrUserNotFound), "Expected ErrUserNotFound")
+}
+
+// Test keys need a name of reasonable length
+func TestAPIKeyServiceCreateAPIKey_InvalidName(t *testing.T) {
+	service := newAPIKeyService(newFakeAPIKeyStore())
+
+	for _, name := range []string{"", "   ", strings.Repeat("a", MaxAPIKeyNameLength+1)} {
+		_, err := service.CreateAPIKey(tenantContext(), name)
+
+		assert.True(t, errors.Is(err, ErrInvalidAPIKey), "Expected ErrInvalidAPIKey for %q", name)
+	}
+}
+
+// Test malformed, unknown, tampered and revoked keys are not accepted
+func TestAPIKeyServiceAuthenticateAPIKey_Rejected(t *testing.T) {
+	store := newFakeAPIKeyStore()
+	service := newAPIKeyService(store)
+	issued, _ := service.CreateAPIKey(tenantContext(), "CI")
+	revoked, _ := service.CreateAPIKey(tenantContext(), "Old")
+	_ = service.RevokeAPIKey(tenantContext(), revoked.ID)
+
+	for name, presented := range map[string]string{
+		"malformed": "not-a-key",
+		"unknown":   APIKeyPrefix + "000000000000_secret",
+		"tampered":  issued.Key + "x",
+		"revoked":   revoked.Key,
+	} {
+		_, err := service.AuthenticateAPIKey(context.Background(), presented)
+
+		assert.True(t, errors.Is(err, ErrUnauthenticated), "Expected ErrUnauthenticated for %s key", name)
+	}
+}
+
+// Test revoking a key stores when it was revoked, once
+func TestAPIKeyServiceRevokeAPIKey(t *testing.T) {
+	store := newFakeAPIKeyStore(&APIKey{ID: "1", TenantID: "1", UserID: "5", Name: "CI"})
+	service := newAPIKeyService(store)
+
+	assert.Nil(t, service.RevokeAPIKey(tenantContext(), "1"))
+	assert.Nil(t, service.RevokeAPIKey(tenantContext(), "1"))
+
+	assert.Len(t, store.Modified, 1)
+	assert.True(t, store.Keys["1"].Revoked())
+	assert.True(t, errors.Is(service.RevokeAPIKey(tenantContext(), "9"), ErrAPIKeyNotFound))
+}
+
+// Test rotating a key revokes it and issues a new one with the same name and user in one unit of work
+func TestAPIKeyServiceRotateAPIKey(t *testing.T) {
+	store := newFakeAPIKeyStore(&APIKey{ID: "1", TenantID: "1", UserID: "5", Name: "CI", Prefix: "aaaaaaaaaaaa"})
+	transactor := &FakeTransactor{}
+	service := NewAPIKeyService(store, store, store, newFakeUserStore(), transactor)
+
+	issued, err := service.RotateAPIKey(tenantContext(), "1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.Calls)
+	assert.Equal(t, "CI", issued.Name)
+	assert.Equal(t, "5", issued.UserID)
+	assert.NotEqual(t, "1", issued.ID)
+	assert.True(t, store.Keys["1"].Revoked())
+
+	_, err = service.RotateAPIKey(tenantContext(), "1")
+
+	assert.True(t, errors.Is(err, ErrAPIKeyRevoked), "Expected ErrAPIKeyRevoked")
+}
+
+// Test a user cannot revoke or rotate the key of another user of the same tenant
+func TestAPIKeyService_OtherUser(t *testing.T) {
+	store := newFakeAPIKeyStore(&APIKey{ID: "1", TenantID: "1", UserID: "6", Name: "Bob's CI", Prefix: "aaaaaaaaaaaa"})
+	service := newAPIKeyService(store)
+
+	assert.True(t, errors.Is(service.RevokeAPIKey(tenantContext(), "1"), ErrAPIKeyNotFound), "Expected ErrAPIKeyNotFound revoking bob's key")
+	issued, err := service.RotateAPIKey(tenantContext(), "1")
+	assert.True(t, errors.Is(err, ErrAPIKeyNotFound), "Expected ErrAPIKeyNotFound rotating bob's key")
+	assert.Nil(t, issued, "No key should be issued in bob's name")
+	assert.False(t, store.Keys["1"].Revoked())
+	assert.Len(t, store.Keys, 1)
+
+	bob := WithPrincipal(context.Background(), &Principal{Subject: "bob", Method: MethodToken, UserID: "6", TenantID: "1"})
+	assert.Nil(t, service.RevokeAPIKey(bob, "1"))
+	assert.True(t, store.Keys["1"].Revoked())
+}
+
+// Test the keys of another tenant can be neither seen, revoked nor rotated
+func TestAPIKeyService_OtherTenant(t *testing.T) {
+	store := newFakeAPIKeyStore(&APIKey{ID: "1", TenantID: "2", Name: "CI"})
+	service := newAPIKeyService(store)
+
+	keys, err := service.ListAPIKeys(tenantContext())
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+
+	assert.True(t, errors.Is(service.RevokeAPIKey(tenantContext(), "1"), ErrAPIKeyNotFound))
+	_, err = service.RotateAPIKey(tenantContext(), "1")
+	assert.True(t, errors.Is(err, ErrAPIKeyNotFound))
+	assert.False(t, store.Keys["1"].Revoked())
+}
+
+// Test the tenant is taken from the principal, and missing without one
+func TestTenantFrom(t *testing.T) {
+	tenantID, err := TenantFrom(tenantContext())
+	assert.Nil(t, err)
+	assert.Equal(t, "1", tenantID)
+
+	_, err = TenantFrom(context.Background())
+	assert.True(t, errors.Is(err, ErrUnauthenticated), "Expected ErrUnauthenticated")
+}
+
+// Test the principal travels with the context
+func TestPrincipalFrom(t *testing.T) {
+	_, ok := PrincipalFrom(context.Background())
+	assert.False(t, ok)
+
+	principal := &Principal{Subject: "alice", Method: MethodToken}
+	got, ok := PrincipalFrom(WithPrincipal(context.Background(), principal))
+
+	assert.True(t, ok)
+	assert.Equal(t, principal, got)
+}
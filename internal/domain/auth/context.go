@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+)
+
+// principalKey is the context key the principal is stored under
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the given principal.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the principal ctx carries, and false if it carries none.
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// TenantFrom returns the ID of the tenant whose data the principal in ctx
+// may see. Without a principal there is no tenant, and ErrUnauthenticated is
+// returned, so data is never read or written on behalf of nobody.
+func TenantFrom(ctx context.Context) (string, error) {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok || principal.TenantID == "" {
+		return "", fmt.Errorf("%w: no tenant in context", ErrUnauthenticated)
+	}
+	return principal.TenantID, nil
+}
@@ -0,0 +1,34 @@
+package auth
+
+import "errors"
+
+// ErrUnauthenticated is returned when a request carries no credentials, or
+// ones that are unknown, revoked, expired or otherwise not valid.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// ErrInvalidAPIKey is returned when an API key is created with an invalid name.
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// ErrAPIKeyNotFound is returned when the requested API key does not exist.
+var ErrAPIKeyNotFound = errors.New("API key not found")
+
+// ErrAPIKeyRevoked is returned when rotating an API key that was already revoked.
+var ErrAPIKeyRevoked = errors.New("API key already revoked")
+
+// ErrDuplicateAPIKey is returned by persistence when an issued key's prefix is already taken.
+var ErrDuplicateAPIKey = errors.New("API key prefix already in use")
+
+// ErrInvalidVerificationKey is returned when a configured token verification key cannot be used.
+var ErrInvalidVerificationKey = errors.New("invalid verification key")
+
+// ErrInvalidUser is returned when a tenant or user is created with an invalid name or subject.
+var ErrInvalidUser = errors.New("invalid user")
+
+// ErrNotAdministrator is returned when a user who is not an administrator of their tenant adds a user to it.
+var ErrNotAdministrator = errors.New("only tenant administrators can add users")
+
+// ErrUserNotFound is returned when the requested user does not exist.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrDuplicateUser is returned when a user is created with a subject that is already taken.
+var ErrDuplicateUser = errors.New("subject already belongs to a user")
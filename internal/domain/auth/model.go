@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const (
+	// APIKeyPrefix starts every API key, telling them apart from bearer tokens.
+	APIKeyPrefix = "sk_"
+	// MaxAPIKeyNameLength caps the length of an API key's name.
+	MaxAPIKeyNameLength = 255
+	// MaxNameLength caps the length of tenant and user names and subjects.
+	MaxNameLength = 255
+)
+
+// Method is how a principal authenticated.
+type Method string
+
+const (
+	MethodAPIKey Method = "api_key"
+	MethodToken  Method = "jwt"
+)
+
+// Principal is who a request is made on behalf of. Subject identifies them:
+// the "sub" claim of a token, or "api-key:<id>" for an API key, whose ID is
+// also kept in KeyID. Either way the principal acts as the user UserID, and
+// sees only the data of that user's tenant, TenantID.
+type Principal struct {
+	Subject  string
+	Method   Method
+	KeyID    string
+	UserID   string
+	TenantID string
+}
+
+// Tenant is a household or team: the unit that owns data. Every account,
+// transaction, category and so on belongs to exactly one tenant, and is
+// invisible to the others.
+type Tenant struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+}
+
+// User is a member of a tenant. Subject is what identifies them to an
+// identity provider, the "sub" claim of the tokens it issues for them. Only
+// the tenant's administrators, Admin, may add users to it; a tenant's first
+// user is one.
+type User struct {
+	ID        string
+	TenantID  string
+	Subject   string
+	Name      string
+	Admin     bool
+	CreatedAt time.Time
+}
+
+// APIKey is a long-lived credential for calling the API as the user UserID.
+// The key itself is only shown when it is issued; what is kept is Prefix,
+// which identifies it, and SecretHash, the SHA-256 of the whole key. A key
+// stops working once RevokedAt is set.
+type APIKey struct {
+	ID         string
+	TenantID   string
+	UserID     string
+	Name       string
+	Prefix     string
+	SecretHash string `json:"-"`
+	CreatedBy  string
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// IssuedAPIKey is a newly created API key together with the key itself.
+type IssuedAPIKey struct {
+	*APIKey
+	Key string
+}
+
+// Revoked reports whether the key can no longer be used.
+func (k *APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// hashAPIKey returns the digest of a key as it is kept in persistence
+func hashAPIKey(key string) string {
+	digest := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(digest[:])
+}
+
+// apiKeyPrefix returns the part of a key of the form sk_<prefix>_<secret>
+// that identifies it, and false if the key is not of that form.
+func apiKeyPrefix(key string) (string, bool) {
+	prefix, secret, found := strings.Cut(strings.TrimPrefix(key, APIKeyPrefix), "_")
+	if !strings.HasPrefix(key, APIKeyPrefix) || !found || prefix == "" || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
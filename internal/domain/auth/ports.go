@@ -0,0 +1,124 @@
+package auth
+
+import "context"
+
+// ForAuthenticatingAPIKey defines the port for identifying the principal behind an API key.
+// Keys that are unknown or revoked fail with ErrUnauthenticated.
+type ForAuthenticatingAPIKey interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*Principal, error)
+}
+
+// ForAuthenticatingToken defines the port for identifying the principal behind a bearer
+// token. Tokens that do not verify, or have expired, fail with ErrUnauthenticated.
+type ForAuthenticatingToken interface {
+	AuthenticateToken(ctx context.Context, token string) (*Principal, error)
+}
+
+// ForCreatingAPIKey defines the port for issuing API keys to the principal's user
+type ForCreatingAPIKey interface {
+	CreateAPIKey(ctx context.Context, name string) (*IssuedAPIKey, error)
+}
+
+// ForCreatingAPIKeyForSubject defines the port for issuing an API key to the user with the
+// given subject, without a principal to issue it on behalf of
+type ForCreatingAPIKeyForSubject interface {
+	CreateAPIKeyForSubject(ctx context.Context, subject, name string) (*IssuedAPIKey, error)
+}
+
+// ForCreatingTenant defines the port for creating a tenant together with its first user
+type ForCreatingTenant interface {
+	CreateTenant(ctx context.Context, name, subject, userName string) (*Tenant, *User, error)
+}
+
+// ForCreatingUser defines the port for adding the user whose subject a bearer token proves to
+// the principal's tenant
+type ForCreatingUser interface {
+	CreateUser(ctx context.Context, token, name string) (*User, error)
+}
+
+// ForAddingUser defines the port for adding a user with the given subject to a tenant, without
+// a principal to add it on behalf of
+type ForAddingUser interface {
+	AddUser(ctx context.Context, tenantID, subject, name string) (*User, error)
+}
+
+// ForVerifyingToken defines the port for proving a subject with a bearer token. It returns
+// the token's subject, whether or not that is a user yet, and fails with ErrUnauthenticated
+// for a token that does not verify or has expired.
+type ForVerifyingToken interface {
+	VerifyToken(ctx context.Context, token string) (string, error)
+}
+
+// ForListingUsers defines the port for listing the users of the principal's tenant
+type ForListingUsers interface {
+	ListUsers(ctx context.Context) ([]*User, error)
+}
+
+// ForListingAPIKeys defines the port for listing API keys
+type ForListingAPIKeys interface {
+	ListAPIKeys(ctx context.Context) ([]*APIKey, error)
+}
+
+// ForRevokingAPIKey defines the port for revoking API keys
+type ForRevokingAPIKey interface {
+	RevokeAPIKey(ctx context.Context, id string) error
+}
+
+// ForRotatingAPIKey defines the port for replacing an API key with a new one
+type ForRotatingAPIKey interface {
+	RotateAPIKey(ctx context.Context, id string) (*IssuedAPIKey, error)
+}
+
+// ForSavingAPIKey defines the port for saving API keys of a tenant to persistence. It fails
+// with ErrDuplicateAPIKey if the key's prefix is already taken.
+type ForSavingAPIKey interface {
+	SaveAPIKey(ctx context.Context, tenantID string, key *APIKey) error
+}
+
+// ForLoadingAPIKeys defines the port for loading API keys from persistence. Keys of other
+// tenants are not found, except by LoadAPIKeyByPrefix, which authenticates a request before
+// its tenant is known.
+type ForLoadingAPIKeys interface {
+	LoadAPIKey(ctx context.Context, tenantID, id string) (*APIKey, error)
+	LoadAPIKeyByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	LoadAPIKeys(ctx context.Context, tenantID string) ([]*APIKey, error)
+}
+
+// ForModifyingAPIKey defines the port for storing changes to an API key of a tenant, which is
+// how it is revoked
+type ForModifyingAPIKey interface {
+	ModifyAPIKey(ctx context.Context, tenantID string, key *APIKey) error
+}
+
+// ForSavingTenant defines the port for saving a new tenant to persistence
+type ForSavingTenant interface {
+	SaveTenant(ctx context.Context, tenant *Tenant) error
+}
+
+// ForSavingUser defines the port for saving a user of a tenant to persistence. It fails with
+// ErrDuplicateUser if the user's subject is already taken.
+type ForSavingUser interface {
+	SaveUser(ctx context.Context, tenantID string, user *User) error
+}
+
+// ForLoadingUsers defines the port for loading users from persistence. Users of other tenants
+// are not found, except by LoadUserBySubject, which authenticates a request before its tenant
+// is known.
+type ForLoadingUsers interface {
+	LoadUser(ctx context.Context, tenantID, id string) (*User, error)
+	LoadUserBySubject(ctx context.Context, subject string) (*User, error)
+	LoadUsers(ctx context.Context, tenantID string) ([]*User, error)
+}
+
+// ForLoadingVerificationKeys defines the port for loading the keys bearer tokens are verified
+// against
+type ForLoadingVerificationKeys interface {
+	LoadVerificationKeys(ctx context.Context) ([]VerificationKey, error)
+}
+
+// ForRunningInTransaction defines the port for running several persistence
+// operations atomically. Persistence calls made with the context passed to fn
+// either all take effect or none do.
+type ForRunningInTransaction interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
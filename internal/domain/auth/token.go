@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Algorithm is a JWS signing algorithm tokens may be signed with.
+type Algorithm string
+
+const (
+	AlgorithmHS256 Algorithm = "HS256"
+	AlgorithmRS256 Algorithm = "RS256"
+	AlgorithmEdDSA Algorithm = "EdDSA"
+)
+
+// VerificationKey is a key bearer tokens signed with Algorithm are verified
+// against: a []byte secret for HS256, an *rsa.PublicKey for RS256 and an
+// ed25519.PublicKey for EdDSA. ID is matched against a token's "kid" header
+// when both are set.
+type VerificationKey struct {
+	ID        string
+	Algorithm Algorithm
+	Key       any
+}
+
+// NewVerificationKey returns a verification key after checking that key is of
+// the kind the algorithm needs.
+func NewVerificationKey(id string, algorithm Algorithm, key any) (VerificationKey, error) {
+	ok := false
+	switch algorithm {
+	case AlgorithmHS256:
+		secret, isSecret := key.([]byte)
+		ok = isSecret && len(secret) >= sha256.Size
+	case AlgorithmRS256:
+		publicKey, isRSA := key.(*rsa.PublicKey)
+		ok = isRSA && publicKey.N.BitLen() >= 2048
+	case AlgorithmEdDSA:
+		publicKey, isEd25519 := key.(ed25519.PublicKey)
+		ok = isEd25519 && len(publicKey) == ed25519.PublicKeySize
+	}
+	if !ok {
+		return VerificationKey{}, fmt.Errorf("%w: %T is not a usable %s key", ErrInvalidVerificationKey, key, algorithm)
+	}
+	return VerificationKey{ID: id, Algorithm: algorithm, Key: key}, nil
+}
+
+// verify reports whether signature is the key's signature of input
+func (k VerificationKey) verify(input, signature []byte) bool {
+	switch key := k.Key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		mac.Write(input)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(input)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, input, signature)
+	}
+	return false
+}
+
+// tokenHeader is the JOSE header of a token
+type tokenHeader struct {
+	Algorithm Algorithm `json:"alg"`
+	KeyID     string    `json:"kid"`
+}
+
+// claims are the registered claims of a token this API looks at
+type claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+}
+
+// audience is the "aud" claim, which may be a single string or an array of them
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// contains reports whether the audience includes the given one
+func (a audience) contains(want string) bool {
+	for _, got := range a {
+		if got == want {
+			return true
+		}
+	}
+	return false
+}
+
+// parseToken verifies the signature of a compact JWS against the keys for
+// its algorithm and returns its claims. Only keys made for the algorithm in
+// the token's header are tried, so a public key can never be used as an
+// HMAC secret.
+func parseToken(token string, keys []VerificationKey) (*claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("token is not a compact JWS")
+	}
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("invalid token header: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid token signature: %w", err)
+	}
+
+	input := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, key := range keys {
+		if key.Algorithm != header.Algorithm || (header.KeyID != "" && key.ID != "" && key.ID != header.KeyID) {
+			continue
+		}
+		if key.verify(input, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("no %q key verifies the token", header.Algorithm)
+	}
+
+	var parsed claims
+	if err := decodeSegment(parts[1], &parsed); err != nil {
+		return nil, fmt.Errorf("invalid token claims: %w", err)
+	}
+	return &parsed, nil
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a token into v
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// validate checks the claims' lifetime, issuer and audience at the given
+// time, allowing for leeway of clock skew. Tokens must carry "exp" and "sub";
+// issuer and audience are only checked when they are configured.
+func (c *claims) validate(now time.Time, leeway time.Duration, issuer, wantAudience string) error {
+	if c.Subject == "" {
+		return errors.New("token has no subject")
+	}
+	if c.ExpiresAt == 0 {
+		return errors.New("token has no expiry")
+	}
+	if !now.Add(-leeway).Before(time.Unix(c.ExpiresAt, 0)) {
+		return errors.New("token has expired")
+	}
+	if c.NotBefore != 0 && now.Add(leeway).Before(time.Unix(c.NotBefore, 0)) {
+		return errors.New("token is not valid yet")
+	}
+	if issuer != "" && c.Issuer != issuer {
+		return fmt.Errorf("token was issued by %q", c.Issuer)
+	}
+	if wantAudience != "" && !c.Audience.contains(wantAudience) {
+		return errors.New("token is not meant for this API")
+	}
+	return nil
+}
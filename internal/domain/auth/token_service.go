@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultLeeway is the clock skew allowed when checking a token's lifetime.
+const DefaultLeeway = time.Minute
+
+// TokenService provides the core logic for authenticating requests made with
+// JWT bearer tokens signed by an identity provider.
+type TokenService struct {
+	keyLoader  ForLoadingVerificationKeys
+	userLoader ForLoadingUsers
+	issuer     string
+	audience   string
+	leeway     time.Duration
+}
+
+// NewTokenService creates a new TokenService. Unless they are empty, tokens
+// must have been issued by issuer for audience.
+func NewTokenService(loader ForLoadingVerificationKeys, users ForLoadingUsers, issuer, audience string, leeway time.Duration) *TokenService {
+	return &TokenService{
+		keyLoader:  loader,
+		userLoader: users,
+		issuer:     issuer,
+		audience:   audience,
+		leeway:     leeway,
+	}
+}
+
+// AuthenticateToken verifies a compact JWS signed with HS256, RS256 or EdDSA
+// and returns the principal named by its "sub" claim, acting as the user
+// with that subject. Tokens that do not verify against a loaded key, have
+// expired or are not valid yet, were issued by or for someone else, or name
+// a subject that is not a user, fail with ErrUnauthenticated.
+func (s *TokenService) AuthenticateToken(ctx context.Context, token string) (*Principal, error) {
+	subject, err := s.VerifyToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userLoader.LoadUserBySubject(ctx, subject)
+	if errors.Is(err, ErrUserNotFound) {
+		return nil, fmt.Errorf("%w: no user has subject %q", ErrUnauthenticated, subject)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Principal{Subject: subject, Method: MethodToken, UserID: user.ID, TenantID: user.TenantID}, nil
+}
+
+// VerifyToken verifies a token the way AuthenticateToken does and returns its
+// subject, which need not be a user yet. This is how a new user proves the
+// subject they are added with.
+func (s *TokenService) VerifyToken(ctx context.Context, token string) (string, error) {
+	keys, err := s.keyLoader.LoadVerificationKeys(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := parseToken(token, keys)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	if err := claims.validate(time.Now(), s.leeway, s.issuer, s.audience); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	return claims.Subject, nil
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeKeyLoader returns a fixed set of verification keys for testing.
+type FakeKeyLoader struct {
+	Keys []VerificationKey
+}
+
+func (f *FakeKeyLoader) LoadVerificationKeys(ctx context.Context) ([]VerificationKey, error) {
+	return f.Keys, nil
+}
+
+func newTokenService(keys []VerificationKey, issuer, audience string) *TokenService {
+	return NewTokenService(&FakeKeyLoader{Keys: keys}, newFakeUserStore(alice), issuer, audience, DefaultLeeway)
+}
+
+var hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// signToken encodes claims as a compact JWS signed with the given algorithm and key
+func signToken(t *testing.T, algorithm Algorithm, kid string, key any, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": string(algorithm), "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+	switch key := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(input))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(input))
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		assert.Nil(t, err)
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(key, []byte(input))
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{"sub": "alice", "iss": "https://id.example.com", "aud": []string{"spend-api"}, "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+// Test tokens signed with each supported algorithm authenticate their subject
+func TestTokenServiceAuthenticateToken(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	edPublic, edPrivate, _ := ed25519.GenerateKey(rand.Reader)
+	hsKey, _ := NewVerificationKey("", AlgorithmHS256, hmacSecret)
+	rsKey, _ := NewVerificationKey("rsa-1", AlgorithmRS256, &rsaKey.PublicKey)
+	edKey, _ := NewVerificationKey("ed-1", AlgorithmEdDSA, edPublic)
+	service := newTokenService([]VerificationKey{hsKey, rsKey, edKey}, "https://id.example.com", "spend-api")
+
+	tests := map[string]string{
+		"HS256": signToken(t, AlgorithmHS256, "", hmacSecret, validClaims()),
+		"RS256": signToken(t, AlgorithmRS256, "rsa-1", rsaKey, validClaims()),
+		"EdDSA": signToken(t, AlgorithmEdDSA, "", edPrivate, validClaims()),
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			principal, err := service.AuthenticateToken(context.Background(), token)
+
+			assert.Nil(t, err)
+			assert.Equal(t, &Principal{Subject: "alice", Method: MethodToken, UserID: "5", TenantID: "1"}, principal)
+		})
+	}
+}
+
+// Test tokens that are tampered with, expired, early, foreign or signed with the wrong key are rejected
+func TestTokenServiceAuthenticateToken_Rejected(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	hsKey, _ := NewVerificationKey("", AlgorithmHS256, hmacSecret)
+	rsKey, _ := NewVerificationKey("rsa-1", AlgorithmRS256, &rsaKey.PublicKey)
+	service := newTokenService([]VerificationKey{hsKey, rsKey}, "https://id.example.com", "spend-api")
+
+	with := func(key string, value any) map[string]any {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+	valid := signToken(t, AlgorithmHS256, "", hmacSecret, validClaims())
+	rsaPublicAsSecret := signToken(t, AlgorithmHS256, "", rsaKey.PublicKey.N.Bytes(), validClaims())
+
+	tests := map[string]string{
+		"not a JWS":       "abc",
+		"tampered":        valid[:len(valid)-2] + "AA",
+		"wrong secret":    signToken(t, AlgorithmHS256, "", []byte("another secret of thirty-two bytes"), validClaims()),
+		"wrong kid":       signToken(t, AlgorithmRS256, "rsa-2", rsaKey, validClaims()),
+		"unsupported alg": signToken(t, Algorithm("none"), "", []byte{}, validClaims()),
+		"key confusion":   rsaPublicAsSecret,
+		"expired":         signToken(t, AlgorithmHS256, "", hmacSecret, with("exp", time.Now().Add(-2*time.Minute).Unix())),
+		"no expiry":       signToken(t, AlgorithmHS256, "", hmacSecret, with("exp", nil)),
+		"not yet valid":   signToken(t, AlgorithmHS256, "", hmacSecret, with("nbf", time.Now().Add(time.Hour).Unix())),
+		"no subject":      signToken(t, AlgorithmHS256, "", hmacSecret, with("sub", nil)),
+		"unknown subject": signToken(t, AlgorithmHS256, "", hmacSecret, with("sub", "mallory")),
+		"other issuer":    signToken(t, AlgorithmHS256, "", hmacSecret, with("iss", "https://evil.example.com")),
+		"other audience":  signToken(t, AlgorithmHS256, "", hmacSecret, with("aud", "other-api")),
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.AuthenticateToken(context.Background(), token)
+
+			assert.True(t, errors.Is(err, ErrUnauthenticated), "Expected ErrUnauthenticated, got %v", err)
+		})
+	}
+}
+
+// Test a token proves its subject even if that is not a user yet
+func TestTokenServiceVerifyToken(t *testing.T) {
+	hsKey, _ := NewVerificationKey("", AlgorithmHS256, hmacSecret)
+	service := newTokenService([]VerificationKey{hsKey}, "https://id.example.com", "spend-api")
+	claims := validClaims()
+	claims["sub"] = "mallory"
+
+	subject, err := service.VerifyToken(context.Background(), signToken(t, AlgorithmHS256, "", hmacSecret, claims))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "mallory", subject)
+
+	_, err = service.VerifyToken(context.Background(), signToken(t, AlgorithmHS256, "", []byte("another secret of thirty-two bytes"), claims))
+
+	assert.True(t, errors.Is(err, ErrUnauthenticated), "Expected ErrUnauthenticated")
+}
+
+// Test clock skew within the leeway is tolerated
+func TestTokenServiceAuthenticateToken_Leeway(t *testing.T) {
+	hsKey, _ := NewVerificationKey("", AlgorithmHS256, hmacSecret)
+	service := newTokenService([]VerificationKey{hsKey}, "", "")
+	claims := map[string]any{"sub": "alice", "aud": "spend-api", "exp": time.Now().Add(-30 * time.Second).Unix()}
+
+	_, err := service.AuthenticateToken(context.Background(), signToken(t, AlgorithmHS256, "", hmacSecret, claims))
+
+	assert.Nil(t, err)
+}
+
+// Test keys must suit the algorithm they are configured for
+func TestNewVerificationKey_Invalid(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 1024)
+	edPublic, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	tests := map[string]struct {
+		algorithm Algorithm
+		key       any
+	}{
+		"short secret":     {AlgorithmHS256, []byte("short")},
+		"short RSA key":    {AlgorithmRS256, &rsaKey.PublicKey},
+		"RSA key as EdDSA": {AlgorithmEdDSA, &rsaKey.PublicKey},
+		"Ed25519 as HS256": {AlgorithmHS256, edPublic},
+		"unknown alg":      {Algorithm("ES256"), edPublic},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewVerificationKey("", tt.algorithm, tt.key)
+
+			assert.True(t, errors.Is(err, ErrInvalidVerificationKey))
+		})
+	}
+}
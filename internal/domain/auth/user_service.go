@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UserService provides the core logic for managing tenants and their users.
+type UserService struct {
+	tenantPersistence ForSavingTenant
+	userPersistence   ForSavingUser
+	userLoader        ForLoadingUsers
+	tokens            ForVerifyingToken
+	transactor        ForRunningInTransaction
+}
+
+// NewUserService creates a new UserService. New users prove their subject
+// with a token that tokens verifies.
+func NewUserService(tenants ForSavingTenant, persistence ForSavingUser, loader ForLoadingUsers, tokens ForVerifyingToken, transactor ForRunningInTransaction) *UserService {
+	return &UserService{
+		tenantPersistence: tenants,
+		userPersistence:   persistence,
+		userLoader:        loader,
+		tokens:            tokens,
+		transactor:        transactor,
+	}
+}
+
+// CreateTenant creates a tenant with the given name together with its first
+// user, its administrator, in the same unit of work, so no tenant is ever left
+// without a user.
+func (s *UserService) CreateTenant(ctx context.Context, name, subject, userName string) (*Tenant, *User, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MaxNameLength {
+		return nil, nil, fmt.Errorf("%w: tenant name must be 1 to %d characters", ErrInvalidUser, MaxNameLength)
+	}
+	user, err := newUser(subject, userName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user.Admin = true
+	tenant := &Tenant{Name: name, CreatedAt: user.CreatedAt}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.tenantPersistence.SaveTenant(ctx, tenant); err != nil {
+			return err
+		}
+		user.TenantID = tenant.ID
+		return s.userPersistence.SaveUser(ctx, tenant.ID, user)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tenant, user, nil
+}
+
+// CreateUser adds a user with the given name to the tenant of the principal
+// in ctx, who must be one of its administrators. The new user's subject is
+// the one their token proves, as a subject can only belong to one user and
+// taking somebody else's would lead their logins into this tenant.
+func (s *UserService) CreateUser(ctx context.Context, token, name string) (*User, error) {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok || principal.TenantID == "" {
+		return nil, fmt.Errorf("%w: no tenant in context", ErrUnauthenticated)
+	}
+	caller, err := s.userLoader.LoadUser(ctx, principal.TenantID, principal.UserID)
+	if errors.Is(err, ErrUserNotFound) {
+		return nil, ErrNotAdministrator
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !caller.Admin {
+		return nil, ErrNotAdministrator
+	}
+
+	subject, err := s.tokens.VerifyToken(ctx, token)
+	if errors.Is(err, ErrUnauthenticated) {
+		return nil, fmt.Errorf("%w: the token does not prove a subject: %v", ErrInvalidUser, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.AddUser(ctx, principal.TenantID, subject, name)
+}
+
+// AddUser adds a user with the given subject and name to the tenant, which is
+// how an operator adds users from the command line. A subject can only belong
+// to one user.
+func (s *UserService) AddUser(ctx context.Context, tenantID, subject, name string) (*User, error) {
+	user, err := newUser(subject, name)
+	if err != nil {
+		return nil, err
+	}
+
+	user.TenantID = tenantID
+	if err := s.userPersistence.SaveUser(ctx, tenantID, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ListUsers retrieves the users of the tenant of the principal in ctx.
+func (s *UserService) ListUsers(ctx context.Context) ([]*User, error) {
+	tenantID, err := TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.userLoader.LoadUsers(ctx, tenantID)
+}
+
+// newUser returns a user with the given subject and name, both trimmed, or
+// an error if either is not valid
+func newUser(subject, name string) (*User, error) {
+	subject, name = strings.TrimSpace(subject), strings.TrimSpace(name)
+	if subject == "" || len(subject) > MaxNameLength {
+		return nil, fmt.Errorf("%w: subject must be 1 to %d characters", ErrInvalidUser, MaxNameLength)
+	}
+	if name == "" || len(name) > MaxNameLength {
+		return nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidUser, MaxNameLength)
+	}
+	return &User{Subject: subject, Name: name, CreatedAt: time.Now().UTC().Truncate(time.Second)}, nil
+}
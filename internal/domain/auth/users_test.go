@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// alice administers tenant 1, and carol is another of its users
+var (
+	alice = &User{ID: "5", TenantID: "1", Subject: "alice", Name: "Alice", Admin: true}
+	carol = &User{ID: "6", TenantID: "1", Subject: "carol", Name: "Carol"}
+)
+
+// FakeUserStore simulates saving and loading tenants and users for testing.
+type FakeUserStore struct {
+	Tenants     []*Tenant
+	Users       []*User
+	ReturnError bool
+}
+
+func newFakeUserStore(users ...*User) *FakeUserStore {
+	return &FakeUserStore{Users: users}
+}
+
+func (f *FakeUserStore) SaveTenant(ctx context.Context, tenant *Tenant) error {
+	tenant.ID = "7"
+	f.Tenants = append(f.Tenants, tenant)
+	return nil
+}
+
+func (f *FakeUserStore) SaveUser(ctx context.Context, tenantID string, user *User) error {
+	if f.ReturnError {
+		return errors.New("failed to save user")
+	}
+	for _, existing := range f.Users {
+		if existing.Subject == user.Subject {
+			return ErrDuplicateUser
+		}
+	}
+	user.ID = "8"
+	user.TenantID = tenantID
+	f.Users = append(f.Users, user)
+	return nil
+}
+
+func (f *FakeUserStore) LoadUser(ctx context.Context, tenantID, id string) (*User, error) {
+	for _, user := range f.Users {
+		if user.ID == id && user.TenantID == tenantID {
+			return user, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (f *FakeUserStore) LoadUserBySubject(ctx context.Context, subject string) (*User, error) {
+	for _, user := range f.Users {
+		if user.Subject == subject {
+			return user, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (f *FakeUserStore) LoadUsers(ctx context.Context, tenantID string) ([]*User, error) {
+	var users []*User
+	for _, user := range f.Users {
+		if user.TenantID == tenantID {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func newUserService(store *FakeUserStore, transactor *FakeTransactor) *UserService {
+	hsKey, _ := NewVerificationKey("", AlgorithmHS256, hmacSecret)
+	tokens := NewTokenService(&FakeKeyLoader{Keys: []VerificationKey{hsKey}}, store, "", "", DefaultLeeway)
+	return NewUserService(store, store, store, tokens, transactor)
+}
+
+// idToken returns a token proving the subject
+func idToken(t *testing.T, subject string) string {
+	return signToken(t, AlgorithmHS256, "", hmacSecret, map[string]any{"sub": subject, "exp": time.Now().Add(time.Hour).Unix()})
+}
+
+// memberContext returns a context acting as carol, who does not administer tenant 1
+func memberContext() context.Context {
+	return WithPrincipal(context.Background(), &Principal{Subject: "carol", Method: MethodToken, UserID: "6", TenantID: "1"})
+}
+
+// Test a tenant is created together with its first user, its administrator, in one unit of work
+func TestUserServiceCreateTenant(t *testing.T) {
+	store := newFakeUserStore()
+	transactor := &FakeTransactor{}
+	service := newUserService(store, transactor)
+
+	tenant, user, err := service.CreateTenant(context.Background(), " Smith household ", "bob", "Bob")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.Calls)
+	assert.Equal(t, "7", tenant.ID)
+	assert.Equal(t, "Smith household", tenant.Name)
+	assert.Equal(t, &User{ID: "8", TenantID: "7", Subject: "bob", Name: "Bob", Admin: true, CreatedAt: user.CreatedAt}, user)
+}
+
+// Test tenants and users need a name and subject of reasonable length
+func TestUserServiceCreateTenant_Invalid(t *testing.T) {
+	service := newUserService(newFakeUserStore(), &FakeTransactor{})
+	long := strings.Repeat("a", MaxNameLength+1)
+
+	for _, args := range [][3]string{{"", "bob", "Bob"}, {long, "bob", "Bob"}, {"Smiths", " ", "Bob"}, {"Smiths", "bob", long}} {
+		_, _, err := service.CreateTenant(context.Background(), args[0], args[1], args[2])
+
+		assert.True(t, errors.Is(err, ErrInvalidUser), "Expected ErrInvalidUser for %q", args)
+	}
+}
+
+// Test users are added to the principal's tenant with the subject their token
+// proves, once per subject
+func TestUserServiceCreateUser(t *testing.T) {
+	store := newFakeUserStore(alice, carol)
+	service := newUserService(store, &FakeTransactor{})
+
+	user, err := service.CreateUser(tenantContext(), idToken(t, "bob"), "Bob")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &User{ID: "8", TenantID: "1", Subject: "bob", Name: "Bob", CreatedAt: user.CreatedAt}, user)
+
+	_, err = service.CreateUser(tenantContext(), idToken(t, "alice"), "Alice again")
+
+	assert.True(t, errors.Is(err, ErrDuplicateUser), "Expected ErrDuplicateUser")
+
+	_, err = service.CreateUser(context.Background(), idToken(t, "dave"), "Dave")
+
+	assert.True(t, errors.Is(err, ErrUnauthenticated), "Expected ErrUnauthenticated")
+}
+
+// Test only the tenant's administrators add users
+func TestUserServiceCreateUser_NotAdministrator(t *testing.T) {
+	store := newFakeUserStore(alice, carol)
+
+	_, err := newUserService(store, &FakeTransactor{}).CreateUser(memberContext(), idToken(t, "bob"), "Bob")
+
+	assert.True(t, errors.Is(err, ErrNotAdministrator), "Expected ErrNotAdministrator")
+	assert.Len(t, store.Users, 2, "No user should be added")
+}
+
+// Test a subject somebody is yet to log in to another tenant with cannot be
+// claimed without a token proving it, so their logins never land in this one
+func TestUserServiceCreateUser_SquattedSubject(t *testing.T) {
+	store := newFakeUserStore(alice, carol)
+	service := newUserService(store, &FakeTransactor{})
+	forged := signToken(t, AlgorithmHS256, "", []byte("not the identity provider's secret"), map[string]any{"sub": "mallory", "exp": time.Now().Add(time.Hour).Unix()})
+
+	for name, token := range map[string]string{"subject only": "mallory", "forged token": forged} {
+		_, err := service.CreateUser(tenantContext(), token, "Mallory")
+
+		assert.True(t, errors.Is(err, ErrInvalidUser), "Expected ErrInvalidUser for %s", name)
+	}
+	_, err := service.CreateUser(memberContext(), idToken(t, "mallory"), "Mallory")
+
+	assert.True(t, errors.Is(err, ErrNotAdministrator), "Expected ErrNotAdministrator")
+	assert.Len(t, store.Users, 2, "No user should be added")
+}
+
+// Test users are added to a given tenant from the command line
+func TestUserServiceAddUser(t *testing.T) {
+	store := newFakeUserStore(alice)
+
+	user, err := newUserService(store, &FakeTransactor{}).AddUser(context.Background(), "2", " mallory ", "Mallory")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &User{ID: "8", TenantID: "2", Subject: "mallory", Name: "Mallory", CreatedAt: user.CreatedAt}, user)
+}
+
+// Test only the users of the principal's tenant are listed
+func TestUserServiceListUsers(t *testing.T) {
+	store := newFakeUserStore(alice, &User{ID: "9", TenantID: "2", Subject: "mallory"})
+
+	users, err := newUserService(store, &FakeTransactor{}).ListUsers(tenantContext())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*User{alice}, users)
+}
@@ -0,0 +1,372 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeBudgetStore simulates saving, loading, modifying and removing budgets for testing. Its
+// budgets belong to tenant 1.
+type FakeBudgetStore struct {
+	Budgets     map[string]*Budget
+	Saved       []*Budget
+	Modified    []*Budget
+	Removed     []string
+	ReturnError bool
+}
+
+func (f *FakeBudgetStore) SaveBudget(ctx context.Context, tenantID string, budget *Budget) error {
+	if f.ReturnError {
+		return errors.New("failed to save budget")
+	}
+	budget.ID = "1"
+	f.Saved = append(f.Saved, budget)
+	return nil
+}
+
+func (f *FakeBudgetStore) LoadBudget(ctx context.Context, tenantID, id string) (*Budget, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to load budget")
+	}
+	budget, ok := f.Budgets[id]
+	if !ok || tenantID != "1" {
+		return nil, ErrBudgetNotFound
+	}
+	return budget, nil
+}
+
+func (f *FakeBudgetStore) LoadBudgets(ctx context.Context, tenantID string) ([]*Budget, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to load budgets")
+	}
+	var result []*Budget
+	for _, budget := range f.Budgets {
+		result = append(result, budget)
+	}
+	return result, nil
+}
+
+func (f *FakeBudgetStore) ModifyBudget(ctx context.Context, tenantID string, budget *Budget) error {
+	if f.ReturnError {
+		return errors.New("failed to modify budget")
+	}
+	f.Modified = append(f.Modified, budget)
+	return nil
+}
+
+func (f *FakeBudgetStore) RemoveBudget(ctx context.Context, tenantID, id string) error {
+	if f.ReturnError {
+		return errors.New("failed to remove budget")
+	}
+	if _, ok := f.Budgets[id]; !ok || tenantID != "1" {
+		return ErrBudgetNotFound
+	}
+	f.Removed = append(f.Removed, id)
+	return nil
+}
+
+// FakeForLoadingAccountCurrency simulates looking up account currencies for testing. Its accounts
+// belong to tenant 1.
+type FakeForLoadingAccountCurrency struct {
+	Currencies map[string]money.Currency
+}
+
+func (f *FakeForLoadingAccountCurrency) LoadAccountCurrency(ctx context.Context, tenantID, accountID string) (money.Currency, error) {
+	currency, ok := f.Currencies[accountID]
+	if !ok || tenantID != "1" {
+		return "", ErrAccountNotFound
+	}
+	return currency, nil
+}
+
+// FakeForLoadingExpenses replays canned expenses that fall within the query's dates for testing.
+type FakeForLoadingExpenses struct {
+	Expenses    []*Expense
+	Query       ExpenseQuery
+	ReturnError bool
+}
+
+func (f *FakeForLoadingExpenses) LoadExpenses(ctx context.Context, tenantID string, query ExpenseQuery, each func(*Expense) error) error {
+	f.Query = query
+	if f.ReturnError {
+		return errors.New("failed to load expenses")
+	}
+	for _, expense := range f.Expenses {
+		if expense.Date.Before(query.From) || expense.Date.After(query.To) {
+			continue
+		}
+		if err := each(expense); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FakeForConvertingMoney simulates converting amounts for testing: amounts are multiplied by the
+// rate of their day, and days without a rate are not found.
+type FakeForConvertingMoney struct {
+	Rates map[string]string
+}
+
+func (f *FakeForConvertingMoney) Convert(ctx context.Context, amount money.Money, to money.Currency, on time.Time) (money.Money, error) {
+	rate, ok := f.Rates[on.Format(time.DateOnly)]
+	if !ok {
+		return money.Money{}, errRateNotFound
+	}
+	factor, _ := new(big.Rat).SetString(rate)
+	return money.FromRat(new(big.Rat).Mul(amount.Decimal().Rat(), factor), to)
+}
+
+var errRateNotFound = errors.New("rate not found")
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func expense(date time.Time, description, amount string) *Expense {
+	return &Expense{Date: date, Description: description, Amount: money.MustParse(amount, "EUR")}
+}
+
+// tenantContext returns a context acting as a user of the given tenant
+func tenantContext(tenantID string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice", UserID: "5", TenantID: tenantID})
+}
+
+func newTestBudgetService(store *FakeBudgetStore, expenses *FakeForLoadingExpenses) *BudgetService {
+	currencies := &FakeForLoadingAccountCurrency{Currencies: map[string]money.Currency{"12345": "EUR", "67890": "GBP"}}
+	return NewBudgetService(store, store, store, store, currencies, expenses, &FakeForConvertingMoney{})
+}
+
+// Test creating a budget defaults it to monthly from the start of the given month
+func TestBudgetServiceCreateBudget(t *testing.T) {
+	store := &FakeBudgetStore{}
+	service := newTestBudgetService(store, &FakeForLoadingExpenses{})
+
+	budget, err := service.CreateBudget(tenantContext("1"), &Budget{
+		Name:      " Groceries ",
+		Amount:    money.MustParse("400.00", "EUR"),
+		StartDate: day(2024, 3, 15),
+		AccountID: "12345",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", budget.ID)
+	assert.Equal(t, "Groceries", budget.Name)
+	assert.Equal(t, PeriodMonthly, budget.Period)
+	assert.Equal(t, day(2024, 3, 1), budget.StartDate)
+	assert.Len(t, store.Saved, 1)
+}
+
+// Test invalid budgets are rejected before anything is saved
+func TestBudgetServiceCreateBudget_Invalid(t *testing.T) {
+	end := day(2024, 1, 31)
+	early := day(2023, 12, 31)
+	tests := map[string]*Budget{
+		"no name":              {Amount: money.MustParse("10.00", "EUR")},
+		"zero amount":          {Name: "Food", Amount: money.Zero("EUR")},
+		"unknown period":       {Name: "Food", Amount: money.MustParse("10.00", "EUR"), Period: "weekly"},
+		"monthly with end":     {Name: "Food", Amount: money.MustParse("10.00", "EUR"), EndDate: &end},
+		"custom without end":   {Name: "Food", Amount: money.MustParse("10.00", "EUR"), Period: PeriodCustom, StartDate: day(2024, 1, 1)},
+		"custom ends early":    {Name: "Food", Amount: money.MustParse("10.00", "EUR"), Period: PeriodCustom, StartDate: day(2024, 1, 1), EndDate: &early},
+		"custom rollover":      {Name: "Food", Amount: money.MustParse("10.00", "EUR"), Period: PeriodCustom, StartDate: day(2024, 1, 1), EndDate: &end, Rollover: true},
+		"bad pattern":          {Name: "Food", Amount: money.MustParse("10.00", "EUR"), DescriptionPattern: "(tesco"},
+		"unknown account":      {Name: "Food", Amount: money.MustParse("10.00", "EUR"), AccountID: "999"},
+		"account in other cur": {Name: "Food", Amount: money.MustParse("10.00", "EUR"), AccountID: "67890"},
+	}
+	for name, budget := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := &FakeBudgetStore{}
+			_, err := newTestBudgetService(store, &FakeForLoadingExpenses{}).CreateBudget(tenantContext("1"), budget)
+
+			assert.ErrorIs(t, err, ErrInvalidBudget)
+			assert.Empty(t, store.Saved)
+		})
+	}
+}
+
+// Test updating a budget that does not exist
+func TestBudgetServiceUpdateBudget_NotFound(t *testing.T) {
+	store := &FakeBudgetStore{Budgets: map[string]*Budget{}}
+	service := newTestBudgetService(store, &FakeForLoadingExpenses{})
+
+	_, err := service.UpdateBudget(tenantContext("1"), "1", &Budget{Name: "Food", Amount: money.MustParse("10.00", "EUR")})
+
+	assert.ErrorIs(t, err, ErrBudgetNotFound)
+	assert.Empty(t, store.Modified)
+}
+
+// Test a monthly budget's status adds up matching spending in the month and projects it to the month's end
+func TestBudgetServiceGetBudgetStatus(t *testing.T) {
+	store := &FakeBudgetStore{Budgets: map[string]*Budget{"1": {
+		ID: "1", Name: "Groceries", Amount: money.MustParse("300.00", "EUR"), Period: PeriodMonthly,
+		StartDate: day(2024, 1, 1), AccountID: "12345", Type: "debit", DescriptionPattern: "(?i)tesco|aldi",
+	}}}
+	expenses := &FakeForLoadingExpenses{Expenses: []*Expense{
+		expense(day(2024, 3, 31), "TESCO STORES", "-500.00"),
+		expense(day(2024, 4, 2), "TESCO STORES", "-60.00"),
+		expense(day(2024, 4, 5), "Aldi", "-40.00"),
+		expense(day(2024, 4, 6), "Aldi refund", "10.00"),
+		expense(day(2024, 4, 8), "Cinema", "-25.00"),
+	}}
+
+	status, err := newTestBudgetService(store, expenses).GetBudgetStatus(tenantContext("1"), "1", StatusQuery{On: day(2024, 4, 10)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, day(2024, 4, 1), status.PeriodStart)
+	assert.Equal(t, day(2024, 4, 30), status.PeriodEnd)
+	assert.Equal(t, money.MustParse("90.00", "EUR"), status.Spent)
+	assert.Equal(t, money.MustParse("210.00", "EUR"), status.Remaining)
+	assert.Equal(t, money.MustParse("270.00", "EUR"), status.Projected)
+	assert.Equal(t, money.Zero("EUR"), status.RolledOver)
+	assert.Equal(t, StateOnTrack, status.State)
+	assert.Equal(t, ExpenseQuery{AccountID: "12345", Type: "debit", Currency: "EUR", From: day(2024, 4, 1), To: day(2024, 4, 10)}, expenses.Query)
+}
+
+// Test spending on course to exceed the budget puts it at risk, and exceeding it overspends it
+func TestBudgetServiceGetBudgetStatus_States(t *testing.T) {
+	store := &FakeBudgetStore{Budgets: map[string]*Budget{"1": {
+		ID: "1", Name: "Eating out", Amount: money.MustParse("100.00", "EUR"), Period: PeriodMonthly, StartDate: day(2024, 1, 1),
+	}}}
+	expenses := &FakeForLoadingExpenses{Expenses: []*Expense{
+		expense(day(2024, 4, 2), "Pizza", "-60.00"),
+		expense(day(2024, 4, 20), "Sushi", "-50.00"),
+	}}
+	service := newTestBudgetService(store, expenses)
+
+	status, err := service.GetBudgetStatus(tenantContext("1"), "1", StatusQuery{On: day(2024, 4, 10)})
+	assert.NoError(t, err)
+	assert.Equal(t, money.MustParse("180.00", "EUR"), status.Projected)
+	assert.Equal(t, StateAtRisk, status.State)
+
+	status, err = service.GetBudgetStatus(tenantContext("1"), "1", StatusQuery{On: day(2024, 4, 30)})
+	assert.NoError(t, err)
+	assert.Equal(t, money.MustParse("-10.00", "EUR"), status.Remaining)
+	assert.Equal(t, money.MustParse("110.00", "EUR"), status.Projected)
+	assert.Equal(t, StateOverspent, status.State)
+}
+
+// Test a rollover budget carries unspent amounts forward but not overspending
+func TestBudgetServiceGetBudgetStatus_Rollover(t *testing.T) {
+	store := &FakeBudgetStore{Budgets: map[string]*Budget{"1": {
+		ID: "1", Name: "Clothes", Amount: money.MustParse("100.00", "EUR"), Period: PeriodMonthly,
+		StartDate: day(2024, 1, 1), Rollover: true,
+	}}}
+	expenses := &FakeForLoadingExpenses{Expenses: []*Expense{
+		expense(day(2024, 1, 10), "Shoes", "-70.00"),
+		expense(day(2024, 2, 10), "Coat", "-200.00"),
+		expense(day(2024, 3, 10), "Shirt", "-20.00"),
+		expense(day(2024, 4, 10), "Jeans", "-50.00"),
+	}}
+
+	status, err := newTestBudgetService(store, expenses).GetBudgetStatus(tenantContext("1"), "1", StatusQuery{On: day(2024, 4, 30)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, day(2024, 1, 1), expenses.Query.From)
+	assert.Equal(t, money.MustParse("80.00", "EUR"), status.RolledOver)
+	assert.Equal(t, money.MustParse("180.00", "EUR"), status.Available)
+	assert.Equal(t, money.MustParse("130.00", "EUR"), status.Remaining)
+}
+
+// Test a custom budget covers its own dates, and nothing is projected before it starts
+func TestBudgetServiceGetBudgetStatus_Custom(t *testing.T) {
+	end := day(2024, 8, 14)
+	store := &FakeBudgetStore{Budgets: map[string]*Budget{"1": {
+		ID: "1", Name: "Holiday", Amount: money.MustParse("1000.00", "EUR"), Period: PeriodCustom,
+		StartDate: day(2024, 8, 1), EndDate: &end,
+	}}}
+	expenses := &FakeForLoadingExpenses{Expenses: []*Expense{expense(day(2024, 7, 20), "Flights", "-400.00")}}
+
+	status, err := newTestBudgetService(store, expenses).GetBudgetStatus(tenantContext("1"), "1", StatusQuery{On: day(2024, 7, 25)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, day(2024, 8, 1), status.PeriodStart)
+	assert.Equal(t, end, status.PeriodEnd)
+	assert.Equal(t, money.Zero("EUR"), status.Spent)
+	assert.Equal(t, money.Zero("EUR"), status.Projected)
+	assert.Equal(t, StateOnTrack, status.State)
+}
+
+// Test a status in another currency converts each day's spending at that day's rate and the
+// budget's own amounts at the rate of the day reported on, keeping the budget's state
+func TestBudgetServiceGetBudgetStatus_Currency(t *testing.T) {
+	store := &FakeBudgetStore{Budgets: map[string]*Budget{"1": {
+		ID: "1", Name: "Groceries", Amount: money.MustParse("300.00", "EUR"), Period: PeriodMonthly, StartDate: day(2024, 1, 1),
+	}}}
+	expenses := &FakeForLoadingExpenses{Expenses: []*Expense{
+		expense(day(2024, 3, 31), "Tesco", "-500.00"),
+		expense(day(2024, 4, 2), "Tesco", "-60.00"),
+		expense(day(2024, 4, 5), "Aldi", "-40.00"),
+		expense(day(2024, 4, 6), "Aldi refund", "10.00"),
+	}}
+	converter := &FakeForConvertingMoney{Rates: map[string]string{"2024-04-02": "1.1", "2024-04-05": "1.2", "2024-04-06": "1.3", "2024-04-10": "1.25"}}
+	service := NewBudgetService(store, store, store, store, &FakeForLoadingAccountCurrency{}, expenses, converter)
+
+	status, err := service.GetBudgetStatus(tenantContext("1"), "1", StatusQuery{On: day(2024, 4, 10), Currency: "USD"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, money.MustParse("375.00", "USD"), status.Budgeted)
+	assert.Equal(t, money.MustParse("375.00", "USD"), status.Available)
+	assert.Equal(t, money.MustParse("101.00", "USD"), status.Spent)
+	assert.Equal(t, money.MustParse("274.00", "USD"), status.Remaining)
+	assert.Equal(t, money.MustParse("303.00", "USD"), status.Projected)
+	assert.Equal(t, StateOnTrack, status.State)
+
+	delete(converter.Rates, "2024-04-05")
+	_, err = service.GetBudgetStatus(tenantContext("1"), "1", StatusQuery{On: day(2024, 4, 10), Currency: "USD"})
+	assert.ErrorIs(t, err, errRateNotFound)
+}
+
+// Test a status restricted to some accounts only loads their expenses
+func TestBudgetServiceGetBudgetStatus_Accounts(t *testing.T) {
+	store := &FakeBudgetStore{Budgets: map[string]*Budget{"1": {
+		ID: "1", Name: "Food", Amount: money.MustParse("100.00", "EUR"), Period: PeriodMonthly, StartDate: day(2024, 1, 1),
+	}}}
+	expenses := &FakeForLoadingExpenses{}
+
+	_, err := newTestBudgetService(store, expenses).GetBudgetStatus(tenantContext("1"), "1", StatusQuery{On: day(2024, 4, 10), AccountIDs: []string{}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, expenses.Query.AccountIDs)
+}
+
+// Test the status of a budget that does not exist, and loading failures
+func TestBudgetServiceGetBudgetStatus_Errors(t *testing.T) {
+	store := &FakeBudgetStore{Budgets: map[string]*Budget{"1": {
+		ID: "1", Name: "Food", Amount: money.MustParse("100.00", "EUR"), Period: PeriodMonthly, StartDate: day(2024, 1, 1),
+	}}}
+
+	_, err := newTestBudgetService(store, &FakeForLoadingExpenses{}).GetBudgetStatus(tenantContext("1"), "2", StatusQuery{})
+	assert.ErrorIs(t, err, ErrBudgetNotFound)
+
+	_, err = newTestBudgetService(store, &FakeForLoadingExpenses{ReturnError: true}).GetBudgetStatus(tenantContext("1"), "1", StatusQuery{})
+	assert.EqualError(t, err, "failed to load expenses")
+}
+
+// Test budgets and accounts of another tenant cannot be used
+func TestBudgetService_OtherTenant(t *testing.T) {
+	store := &FakeBudgetStore{Budgets: map[string]*Budget{"1": {
+		ID: "1", Name: "Food", Amount: money.MustParse("100.00", "EUR"), Period: PeriodMonthly, StartDate: day(2024, 1, 1),
+	}}}
+	service := newTestBudgetService(store, &FakeForLoadingExpenses{})
+	ctx := tenantContext("2")
+
+	_, err := service.GetBudget(ctx, "1")
+	assert.ErrorIs(t, err, ErrBudgetNotFound)
+
+	_, err = service.GetBudgetStatus(ctx, "1", StatusQuery{})
+	assert.ErrorIs(t, err, ErrBudgetNotFound)
+
+	err = service.DeleteBudget(ctx, "1")
+	assert.ErrorIs(t, err, ErrBudgetNotFound)
+
+	_, err = service.CreateBudget(ctx, &Budget{Name: "Food", Amount: money.MustParse("10.00", "EUR"), AccountID: "12345"})
+	assert.ErrorIs(t, err, ErrInvalidBudget, "Another tenant's account cannot be budgeted for")
+	assert.Empty(t, store.Saved)
+}
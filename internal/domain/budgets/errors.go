@@ -0,0 +1,13 @@
+package budgets
+
+import "errors"
+
+// ErrBudgetNotFound is returned when no budget exists with the requested ID.
+var ErrBudgetNotFound = errors.New("budget not found")
+
+// ErrInvalidBudget is returned when a budget has no name, a non-positive amount, an
+// unusable period or description pattern, or an account it cannot apply to.
+var ErrInvalidBudget = errors.New("invalid budget")
+
+// ErrAccountNotFound is returned when a budget refers to an account that does not exist.
+var ErrAccountNotFound = errors.New("account not found")
@@ -0,0 +1,136 @@
+package budgets
+
+import (
+	"regexp"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// Period is how often a budget's amount is made available again.
+type Period string
+
+const (
+	// PeriodMonthly budgets start afresh on the first day of every calendar month.
+	PeriodMonthly Period = "monthly"
+	// PeriodCustom budgets cover a single range of dates.
+	PeriodCustom Period = "custom"
+)
+
+// State summarises how a budget's spending compares with what is available.
+type State string
+
+const (
+	StateOnTrack   State = "on_track"
+	StateAtRisk    State = "at_risk"
+	StateOverspent State = "overspent"
+)
+
+// Budget caps the spending that matches it over each of its periods.
+// Spending matches when every filter the budget sets holds: the account,
+// the transaction type and a description pattern (a Go regular expression);
+// filters left empty match anything. Amount fixes the budget's currency, and
+// transactions in other currencies never count against it.
+//
+// A monthly budget applies from the month StartDate falls in, and with
+// Rollover whatever was left unspent in one month is added to the next. A
+// custom budget covers StartDate to EndDate inclusive.
+type Budget struct {
+	ID                 string
+	Name               string
+	Amount             money.Money
+	Period             Period
+	StartDate          time.Time
+	EndDate            *time.Time
+	AccountID          string
+	Type               string
+	DescriptionPattern string
+	Rollover           bool
+
+	pattern *regexp.Regexp
+}
+
+// Expense is a transaction that may count against a budget. Amount is signed
+// as on the transaction, so money leaving the account is negative and
+// refunds, being positive, reduce what has been spent.
+type Expense struct {
+	Date        time.Time
+	Description string
+	Amount      money.Money
+}
+
+// ExpenseQuery selects the expenses dated From to To inclusive that may count
+// against a budget, in its currency and, when set, its account and
+// transaction type. Without a type only outgoing payments and refunds are
+// selected, never transfers. A non-nil AccountIDs restricts them to those
+// accounts, and to none if it is empty.
+type ExpenseQuery struct {
+	AccountID  string
+	AccountIDs []string
+	Type       string
+	Currency   money.Currency
+	From       time.Time
+	To         time.Time
+}
+
+// StatusQuery picks the day a budget's status is reported on, today if On is
+// zero, and the currency it is reported in, the budget's own if Currency is
+// empty. A non-nil AccountIDs only counts the spending of those accounts, and
+// none at all if it is empty.
+type StatusQuery struct {
+	On         time.Time
+	Currency   money.Currency
+	AccountIDs []string
+}
+
+// Status reports a budget's figures for the period containing a given date.
+// Available is the budgeted amount plus anything RolledOver from earlier
+// periods, and Projected extends the spending so far at the same daily rate
+// to the end of the period.
+type Status struct {
+	BudgetID    string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Budgeted    money.Money
+	RolledOver  money.Money
+	Available   money.Money
+	Spent       money.Money
+	Remaining   money.Money
+	Projected   money.Money
+	State       State
+}
+
+// periodOn returns the first and last day of the budget period containing the given day.
+func (b *Budget) periodOn(day time.Time) (time.Time, time.Time) {
+	if b.Period == PeriodCustom {
+		return b.StartDate, *b.EndDate
+	}
+	start := monthOf(day)
+	return start, start.AddDate(0, 1, -1)
+}
+
+// matches reports whether the expense meets the budget's description pattern.
+// The other filters are applied when expenses are loaded.
+func (b *Budget) matches(expense *Expense) bool {
+	if b.DescriptionPattern == "" {
+		return true
+	}
+	if b.pattern == nil {
+		pattern, err := regexp.Compile(b.DescriptionPattern)
+		if err != nil {
+			return false
+		}
+		b.pattern = pattern
+	}
+	return b.pattern.MatchString(expense.Description)
+}
+
+// dateOf drops the time of day, keeping the calendar date as a UTC midnight.
+func dateOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// monthOf returns the first day of the calendar month containing t.
+func monthOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
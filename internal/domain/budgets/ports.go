@@ -0,0 +1,79 @@
+package budgets
+
+import (
+	"context"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// ForCreatingBudget defines the port for creating a budget.
+type ForCreatingBudget interface {
+	CreateBudget(ctx context.Context, budget *Budget) (*Budget, error)
+}
+
+// ForGettingBudget defines the port for retrieving a single budget.
+type ForGettingBudget interface {
+	GetBudget(ctx context.Context, id string) (*Budget, error)
+}
+
+// ForListingBudgets defines the port for listing all budgets.
+type ForListingBudgets interface {
+	ListBudgets(ctx context.Context) ([]*Budget, error)
+}
+
+// ForUpdatingBudget defines the port for replacing a budget.
+type ForUpdatingBudget interface {
+	UpdateBudget(ctx context.Context, id string, budget *Budget) (*Budget, error)
+}
+
+// ForDeletingBudget defines the port for deleting a budget.
+type ForDeletingBudget interface {
+	DeleteBudget(ctx context.Context, id string) error
+}
+
+// ForGettingBudgetStatus defines the port for reporting a budget's figures for the period containing the
+// query's date, in its currency or, when it is empty, the budget's own.
+type ForGettingBudgetStatus interface {
+	GetBudgetStatus(ctx context.Context, id string, query StatusQuery) (*Status, error)
+}
+
+// ForSavingBudget defines the port for saving a budget of a tenant to persistence
+type ForSavingBudget interface {
+	SaveBudget(ctx context.Context, tenantID string, budget *Budget) error
+}
+
+// ForLoadingBudget defines the port for loading budgets of a tenant from persistence. Budgets of
+// other tenants are not found.
+type ForLoadingBudget interface {
+	LoadBudget(ctx context.Context, tenantID, id string) (*Budget, error)
+	LoadBudgets(ctx context.Context, tenantID string) ([]*Budget, error)
+}
+
+// ForModifyingBudget defines the port for persisting changes to an existing budget of a tenant
+type ForModifyingBudget interface {
+	ModifyBudget(ctx context.Context, tenantID string, budget *Budget) error
+}
+
+// ForRemovingBudget defines the port for removing a budget of a tenant from persistence
+type ForRemovingBudget interface {
+	RemoveBudget(ctx context.Context, tenantID, id string) error
+}
+
+// ForLoadingAccountCurrency defines the port for looking up the currency of the tenant's account a
+// budget applies to. Accounts of other tenants are not found.
+type ForLoadingAccountCurrency interface {
+	LoadAccountCurrency(ctx context.Context, tenantID, accountID string) (money.Currency, error)
+}
+
+// ForLoadingExpenses defines the port for loading the tenant's expenses that may
+// count against a budget. each is called once per expense in date order,
+// without the whole selection being held in memory.
+type ForLoadingExpenses interface {
+	LoadExpenses(ctx context.Context, tenantID string, query ExpenseQuery, each func(*Expense) error) error
+}
+
+// ForConvertingMoney defines the port for converting an amount into another currency at the
+// tenant's rate for the given day.
+type ForConvertingMoney interface {
+	Convert(ctx context.Context, amount money.Money, to money.Currency, on time.Time) (money.Money, error)
+}
@@ -0,0 +1,378 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/money"
+	"strings"
+	"time"
+)
+
+// BudgetService provides the core logic for managing budgets and tracking spending against them.
+type BudgetService struct {
+	budgetPersistence ForSavingBudget
+	budgetLoader      ForLoadingBudget
+	budgetModifier    ForModifyingBudget
+	budgetRemover     ForRemovingBudget
+	accountCurrencies ForLoadingAccountCurrency
+	expenseLoader     ForLoadingExpenses
+	converter         ForConvertingMoney
+}
+
+// NewBudgetService creates a new BudgetService.
+func NewBudgetService(persistence ForSavingBudget, loader ForLoadingBudget, modifier ForModifyingBudget, remover ForRemovingBudget, accountCurrencies ForLoadingAccountCurrency, expenseLoader ForLoadingExpenses, converter ForConvertingMoney) *BudgetService {
+	return &BudgetService{
+		budgetPersistence: persistence,
+		budgetLoader:      loader,
+		budgetModifier:    modifier,
+		budgetRemover:     remover,
+		accountCurrencies: accountCurrencies,
+		expenseLoader:     expenseLoader,
+		converter:         converter,
+	}
+}
+
+// CreateBudget validates the budget and saves it using persistence. A budget
+// without a period is monthly, and a monthly budget without a start date
+// applies from the current month. The budget belongs to the tenant of the
+// principal in ctx.
+func (s *BudgetService) CreateBudget(ctx context.Context, budget *Budget) (*Budget, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	budget.ID = ""
+	if err := s.validate(ctx, tenantID, budget); err != nil {
+		return nil, err
+	}
+
+	// Save the budget using the persistence port
+	if err := s.budgetPersistence.SaveBudget(ctx, tenantID, budget); err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+// GetBudget retrieves the budget with the given ID. Budgets of other tenants
+// are not found.
+func (s *BudgetService) GetBudget(ctx context.Context, id string) (*Budget, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.budgetLoader.LoadBudget(ctx, tenantID, id)
+}
+
+// ListBudgets retrieves all budgets of the tenant.
+func (s *BudgetService) ListBudgets(ctx context.Context) ([]*Budget, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.budgetLoader.LoadBudgets(ctx, tenantID)
+}
+
+// UpdateBudget replaces the budget with the given ID.
+func (s *BudgetService) UpdateBudget(ctx context.Context, id string, budget *Budget) (*Budget, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.budgetLoader.LoadBudget(ctx, tenantID, id); err != nil {
+		return nil, err
+	}
+
+	budget.ID = id
+	if err := s.validate(ctx, tenantID, budget); err != nil {
+		return nil, err
+	}
+	if err := s.budgetModifier.ModifyBudget(ctx, tenantID, budget); err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+// DeleteBudget deletes the budget with the given ID.
+func (s *BudgetService) DeleteBudget(ctx context.Context, id string) error {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+	return s.budgetRemover.RemoveBudget(ctx, tenantID, id)
+}
+
+// GetBudgetStatus reports how much of the budget with the given ID has been
+// spent in the period containing the query's day (today if it is zero) up to
+// and including that day, how much remains, and how much will have been spent
+// by the end of the period if spending carries on at the same rate. The
+// budget is at risk when that projection exceeds what is available, and
+// overspent once spending does. When a currency other than the budget's is
+// asked for, the figures are converted into it: each day's spending at that
+// day's rate, and the budget's own amounts at the rate of the last day
+// counted. Only the spending of the query's accounts counts, if it names any.
+func (s *BudgetService) GetBudgetStatus(ctx context.Context, id string, query StatusQuery) (*Status, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	budget, err := s.budgetLoader.LoadBudget(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	on, currency := query.On, query.Currency
+	if on.IsZero() {
+		on = time.Now()
+	}
+	on = dateOf(on)
+
+	start, end := budget.periodOn(on)
+	from, to := start, end
+	if budget.Rollover && budget.StartDate.Before(start) {
+		from = budget.StartDate
+	}
+	if on.Before(end) {
+		to = on
+	}
+	spent, err := s.spentPerPeriod(ctx, tenantID, budget, query.AccountIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{BudgetID: budget.ID, PeriodStart: start, PeriodEnd: end, Budgeted: budget.Amount}
+	if status.RolledOver, err = rollOver(budget, start, spent); err != nil {
+		return nil, err
+	}
+	if status.Available, err = budget.Amount.Add(status.RolledOver); err != nil {
+		return nil, err
+	}
+	status.Spent = spent.in(start)
+	if status.Remaining, err = status.Available.Sub(status.Spent); err != nil {
+		return nil, err
+	}
+	if status.Projected, err = project(status.Spent, start, end, on); err != nil {
+		return nil, err
+	}
+
+	overrun, err := status.Projected.Sub(status.Available)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case status.Remaining.IsNegative():
+		status.State = StateOverspent
+	case overrun.IsPositive():
+		status.State = StateAtRisk
+	default:
+		status.State = StateOnTrack
+	}
+	if currency == "" || currency == budget.Amount.Currency() {
+		return status, nil
+	}
+	return s.convert(ctx, status, spent, on, to, currency)
+}
+
+// convert expresses the status in another currency. Each day's spending in
+// the status' period is converted at that day's rate and the budget's own
+// amounts at the rate of the given last day; the state is still the one
+// judged in the budget's currency.
+func (s *BudgetService) convert(ctx context.Context, status *Status, spent *periodTotals, on, last time.Time, currency money.Currency) (*Status, error) {
+	converted := *status
+	var err error
+	for _, figure := range []*money.Money{&converted.Budgeted, &converted.RolledOver, &converted.Available} {
+		if *figure, err = s.converter.Convert(ctx, *figure, currency, last); err != nil {
+			return nil, err
+		}
+	}
+
+	converted.Spent = money.Zero(currency)
+	for _, daily := range spent.days {
+		if daily.date.Before(status.PeriodStart) {
+			continue
+		}
+		amount, err := s.converter.Convert(ctx, daily.spent, currency, daily.date)
+		if err != nil {
+			return nil, err
+		}
+		if converted.Spent, err = converted.Spent.Add(amount); err != nil {
+			return nil, err
+		}
+	}
+	if converted.Remaining, err = converted.Available.Sub(converted.Spent); err != nil {
+		return nil, err
+	}
+	if converted.Projected, err = project(converted.Spent, status.PeriodStart, status.PeriodEnd, on); err != nil {
+		return nil, err
+	}
+	return &converted, nil
+}
+
+// periodTotals holds the amount spent in each budget period, keyed by the period's first day,
+// and on each day with spending, in date order.
+type periodTotals struct {
+	currency money.Currency
+	totals   map[string]money.Money
+	days     []*dailyTotal
+}
+
+// dailyTotal is the amount spent on one day
+type dailyTotal struct {
+	date  time.Time
+	spent money.Money
+}
+
+// in returns the amount spent in the period starting on the given day.
+func (p *periodTotals) in(start time.Time) money.Money {
+	total, ok := p.totals[start.Format(time.DateOnly)]
+	if !ok {
+		return money.Zero(p.currency)
+	}
+	return total
+}
+
+// spentPerPeriod adds up the budget's matching expenses of the tenant dated from to to per period,
+// of the given accounts only if accountIDs is not nil.
+func (s *BudgetService) spentPerPeriod(ctx context.Context, tenantID string, budget *Budget, accountIDs []string, from, to time.Time) (*periodTotals, error) {
+	currency := budget.Amount.Currency()
+	spent := &periodTotals{currency: currency, totals: map[string]money.Money{}}
+	query := ExpenseQuery{AccountID: budget.AccountID, AccountIDs: accountIDs, Type: budget.Type, Currency: currency, From: from, To: to}
+	err := s.expenseLoader.LoadExpenses(ctx, tenantID, query, func(expense *Expense) error {
+		if !budget.matches(expense) {
+			return nil
+		}
+		start, _ := budget.periodOn(expense.Date)
+		total, err := spent.in(start).Sub(expense.Amount)
+		if err != nil {
+			return err
+		}
+		spent.totals[start.Format(time.DateOnly)] = total
+
+		date := dateOf(expense.Date)
+		if last := len(spent.days) - 1; last < 0 || !spent.days[last].date.Equal(date) {
+			spent.days = append(spent.days, &dailyTotal{date: date, spent: money.Zero(currency)})
+		}
+		daily := spent.days[len(spent.days)-1]
+		daily.spent, err = daily.spent.Sub(expense.Amount)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return spent, nil
+}
+
+// rollOver works out how much a rollover budget carries into the period
+// starting on the given day: each month from the budget's start, whatever was
+// left of that month's amount and what it carried in moves on to the next.
+// Overspending is not carried forward.
+func rollOver(budget *Budget, start time.Time, spent *periodTotals) (money.Money, error) {
+	carried := money.Zero(budget.Amount.Currency())
+	if !budget.Rollover {
+		return carried, nil
+	}
+	for month := budget.StartDate; month.Before(start); month = month.AddDate(0, 1, 0) {
+		available, err := budget.Amount.Add(carried)
+		if err != nil {
+			return money.Money{}, err
+		}
+		left, err := available.Sub(spent.in(month))
+		if err != nil {
+			return money.Money{}, err
+		}
+		carried = money.Zero(budget.Amount.Currency())
+		if left.IsPositive() {
+			carried = left
+		}
+	}
+	return carried, nil
+}
+
+// project extends the amount spent from start to the given day at the same
+// daily rate to the end of the period. Outside the period there is nothing
+// left to project and the amount spent is returned as it is.
+func project(spent money.Money, start, end, on time.Time) (money.Money, error) {
+	if on.Before(start) || !on.Before(end) {
+		return spent, nil
+	}
+	elapsed := daysBetween(start, on) + 1
+	total := daysBetween(start, end) + 1
+	rate := new(big.Rat).Mul(spent.Decimal().Rat(), big.NewRat(int64(total), int64(elapsed)))
+	return money.FromRat(rate, spent.Currency())
+}
+
+// daysBetween counts the whole days from one date to a later one.
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// validate applies defaults to the budget and checks it: it needs a name, a
+// positive amount, a known period with the dates that period requires, a
+// description pattern that compiles and, when it is limited to an account,
+// an account of the tenant that is held in the budget's currency.
+func (s *BudgetService) validate(ctx context.Context, tenantID string, budget *Budget) error {
+	budget.Name = strings.TrimSpace(budget.Name)
+	if budget.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidBudget)
+	}
+	currency := budget.Amount.Currency()
+	if !currency.IsValid() {
+		return fmt.Errorf("%w: unknown currency %q", ErrInvalidBudget, currency)
+	}
+	if !budget.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidBudget)
+	}
+
+	if budget.Period == "" {
+		budget.Period = PeriodMonthly
+	}
+	switch budget.Period {
+	case PeriodMonthly:
+		if budget.EndDate != nil {
+			return fmt.Errorf("%w: a monthly budget has no end date", ErrInvalidBudget)
+		}
+		if budget.StartDate.IsZero() {
+			budget.StartDate = time.Now()
+		}
+		budget.StartDate = monthOf(budget.StartDate)
+	case PeriodCustom:
+		if budget.StartDate.IsZero() || budget.EndDate == nil {
+			return fmt.Errorf("%w: a custom budget needs a start and an end date", ErrInvalidBudget)
+		}
+		if budget.Rollover {
+			return fmt.Errorf("%w: only monthly budgets can roll over", ErrInvalidBudget)
+		}
+		start, end := dateOf(budget.StartDate), dateOf(*budget.EndDate)
+		if end.Before(start) {
+			return fmt.Errorf("%w: end date must not be before the start date", ErrInvalidBudget)
+		}
+		budget.StartDate, budget.EndDate = start, &end
+	default:
+		return fmt.Errorf("%w: unknown period %q", ErrInvalidBudget, budget.Period)
+	}
+
+	if budget.DescriptionPattern != "" {
+		pattern, err := regexp.Compile(budget.DescriptionPattern)
+		if err != nil {
+			return fmt.Errorf("%w: invalid description pattern: %v", ErrInvalidBudget, err)
+		}
+		budget.pattern = pattern
+	}
+
+	if budget.AccountID == "" {
+		return nil
+	}
+	accountCurrency, err := s.accountCurrencies.LoadAccountCurrency(ctx, tenantID, budget.AccountID)
+	if errors.Is(err, ErrAccountNotFound) {
+		return fmt.Errorf("%w: account %q not found", ErrInvalidBudget, budget.AccountID)
+	}
+	if err != nil {
+		return err
+	}
+	if accountCurrency != currency {
+		return fmt.Errorf("%w: amount is in %s but the account is in %s", ErrInvalidBudget, currency, accountCurrency)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"spend-api/internal/domain/auth"
+	"testing"
+)
+
+// FakeCategoryStore simulates saving, loading, modifying and removing categories for testing.
+// Its categories belong to tenant 1; other tenants have none.
+type FakeCategoryStore struct {
+	Categories  []*Category
+	ReturnError bool
+	Removed     []string
+}
+
+func (f *FakeCategoryStore) SaveCategory(ctx context.Context, tenantID string, category *Category) error {
+	if f.ReturnError {
+		return errors.New("failed to save category")
+	}
+	category.ID = fmt.Sprintf("%d", len(f.Categories)+1)
+	f.Categories = append(f.Categories, category)
+	return nil
+}
+
+func (f *FakeCategoryStore) LoadCategory(ctx context.Context, tenantID, id string) (*Category, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to load category")
+	}
+	if tenantID != "1" {
+		return nil, ErrCategoryNotFound
+	}
+	for _, category := range f.Categories {
+		if category.ID == id {
+			return category, nil
+		}
+	}
+	return nil, ErrCategoryNotFound
+}
+
+func (f *FakeCategoryStore) LoadCategories(ctx context.Context, tenantID string) ([]*Category, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to load categories")
+	}
+	if tenantID != "1" {
+		return []*Category{}, nil
+	}
+	return f.Categories, nil
+}
+
+func (f *FakeCategoryStore) ModifyCategory(ctx context.Context, tenantID string, category *Category) error {
+	for i, existing := range f.Categories {
+		if existing.ID == category.ID {
+			f.Categories[i] = category
+		}
+	}
+	return nil
+}
+
+func (f *FakeCategoryStore) RemoveCategory(ctx context.Context, tenantID, id string) error {
+	f.Removed = append(f.Removed, id)
+	return nil
+}
+
+// newFakeCategoryStore holds Food (1) > Groceries (2) > Organic (3) and Transport (4)
+func newFakeCategoryStore() *FakeCategoryStore {
+	return &FakeCategoryStore{Categories: []*Category{
+		NewCategory("1", "Food", ""),
+		NewCategory("2", "Groceries", "1"),
+		NewCategory("3", "Organic", "2"),
+		NewCategory("4", "Transport", ""),
+	}}
+}
+
+func ptr(value string) *string {
+	return &value
+}
+
+// tenantContext returns a context acting as a user of the given tenant
+func tenantContext(tenantID string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice", UserID: "5", TenantID: tenantID})
+}
+
+func newTestService(store *FakeCategoryStore) *CategoryService {
+	return NewCategoryService(store, store, store, store)
+}
+
+// Test creating top-level and child categories
+func TestCategoryServiceCreateCategory(t *testing.T) {
+	store := newFakeCategoryStore()
+	categoryService := newTestService(store)
+
+	category, err := categoryService.CreateCategory(tenantContext("1"), "  Restaurants ", "1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "5", category.ID, "The ID should be assigned by persistence")
+	assert.Equal(t, "Restaurants", category.Name)
+	assert.Equal(t, "1", category.ParentID)
+}
+
+// Test invalid and duplicate categories are rejected before saving
+func TestCategoryServiceCreateCategory_Invalid(t *testing.T) {
+	cases := map[string]struct {
+		name, parentID string
+		expected       error
+	}{
+		"no name":        {" ", "", ErrInvalidCategory},
+		"unknown parent": {"Taxis", "99", ErrInvalidCategory},
+		"duplicate":      {"groceries", "1", ErrDuplicateCategory},
+		"duplicate top":  {"FOOD", "", ErrDuplicateCategory},
+	}
+
+	for name, c := range cases {
+		store := newFakeCategoryStore()
+		categoryService := newTestService(store)
+
+		_, err := categoryService.CreateCategory(tenantContext("1"), c.name, c.parentID)
+
+		assert.True(t, errors.Is(err, c.expected), name)
+		assert.Len(t, store.Categories, 4, name)
+	}
+}
+
+// Test the same name is allowed under different parents
+func TestCategoryServiceCreateCategory_SameNameElsewhere(t *testing.T) {
+	categoryService := newTestService(newFakeCategoryStore())
+
+	_, err := categoryService.CreateCategory(tenantContext("1"), "Organic", "1")
+
+	assert.Nil(t, err)
+}
+
+// Test moving a category to another parent
+func TestCategoryServiceUpdateCategory(t *testing.T) {
+	store := newFakeCategoryStore()
+	categoryService := newTestService(store)
+
+	category, err := categoryService.UpdateCategory(tenantContext("1"), "2", ptr("Supermarket"), ptr("4"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "4", category.ParentID)
+	assert.Equal(t, "Supermarket", store.Categories[1].Name)
+}
+
+// Test a category cannot be moved under itself or one of its descendants
+func TestCategoryServiceUpdateCategory_Cycle(t *testing.T) {
+	for _, parentID := range []string{"1", "2", "3"} {
+		categoryService := newTestService(newFakeCategoryStore())
+
+		_, err := categoryService.UpdateCategory(tenantContext("1"), "1", nil, ptr(parentID))
+
+		assert.True(t, errors.Is(err, ErrInvalidCategory), parentID)
+	}
+}
+
+// Test fields left out of an update keep their values, and a category is not a duplicate of itself
+func TestCategoryServiceUpdateCategory_Partial(t *testing.T) {
+	categoryService := newTestService(newFakeCategoryStore())
+
+	moved, err := categoryService.UpdateCategory(tenantContext("1"), "2", nil, ptr(""))
+	assert.Nil(t, err)
+	assert.Equal(t, NewCategory("2", "Groceries", ""), moved, "Moving should keep the name")
+
+	renamed, err := categoryService.UpdateCategory(tenantContext("1"), "3", ptr("Bio"), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, NewCategory("3", "Bio", "2"), renamed, "Renaming should keep the parent")
+}
+
+// Test updating a category that does not exist
+func TestCategoryServiceUpdateCategory_NotFound(t *testing.T) {
+	categoryService := newTestService(newFakeCategoryStore())
+
+	_, err := categoryService.UpdateCategory(tenantContext("1"), "99", ptr("Anything"), nil)
+
+	assert.True(t, errors.Is(err, ErrCategoryNotFound), "Expected ErrCategoryNotFound")
+}
+
+// Test deleting a leaf category
+func TestCategoryServiceDeleteCategory(t *testing.T) {
+	store := newFakeCategoryStore()
+	categoryService := newTestService(store)
+
+	err := categoryService.DeleteCategory(tenantContext("1"), "3")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"3"}, store.Removed)
+}
+
+// Test a category with subcategories cannot be deleted
+func TestCategoryServiceDeleteCategory_HasChildren(t *testing.T) {
+	store := newFakeCategoryStore()
+	categoryService := newTestService(store)
+
+	err := categoryService.DeleteCategory(tenantContext("1"), "1")
+
+	assert.True(t, errors.Is(err, ErrCategoryInUse), "Expected ErrCategoryInUse")
+	assert.Empty(t, store.Removed)
+}
+
+// Test persistence failures are passed on
+func TestCategoryService_LoadError(t *testing.T) {
+	categoryService := newTestService(&FakeCategoryStore{ReturnError: true})
+
+	_, err := categoryService.CreateCategory(tenantContext("1"), "Food", "")
+	assert.NotNil(t, err)
+	_, err = categoryService.ListCategories(tenantContext("1"))
+	assert.NotNil(t, err)
+	assert.NotNil(t, categoryService.DeleteCategory(tenantContext("1"), "1"))
+}
+
+// Test categories of another tenant are neither found nor usable as parents or duplicates
+func TestCategoryService_OtherTenant(t *testing.T) {
+	store := newFakeCategoryStore()
+	categoryService := newTestService(store)
+	ctx := tenantContext("2")
+
+	_, err := categoryService.GetCategory(ctx, "1")
+	assert.True(t, errors.Is(err, ErrCategoryNotFound), "Expected ErrCategoryNotFound")
+
+	_, err = categoryService.CreateCategory(ctx, "Restaurants", "1")
+	assert.True(t, errors.Is(err, ErrInvalidCategory), "Another tenant's category cannot be a parent")
+
+	_, err = categoryService.CreateCategory(ctx, "Food", "")
+	assert.Nil(t, err, "Names only clash within a tenant")
+
+	_, err = categoryService.UpdateCategory(ctx, "2", ptr("Supermarket"), nil)
+	assert.True(t, errors.Is(err, ErrCategoryNotFound), "Expected ErrCategoryNotFound")
+
+	err = categoryService.DeleteCategory(ctx, "3")
+	assert.True(t, errors.Is(err, ErrCategoryNotFound), "Expected ErrCategoryNotFound")
+	assert.Empty(t, store.Removed)
+}
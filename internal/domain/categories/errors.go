@@ -0,0 +1,16 @@
+package categories
+
+import "errors"
+
+// ErrCategoryNotFound is returned when no category exists with the requested ID.
+var ErrCategoryNotFound = errors.New("category not found")
+
+// ErrInvalidCategory is returned when a category has no name, an unknown parent, or a parent
+// that would make it its own ancestor.
+var ErrInvalidCategory = errors.New("invalid category")
+
+// ErrDuplicateCategory is returned when a category has the same name as one of its siblings.
+var ErrDuplicateCategory = errors.New("a category with this name already exists under the same parent")
+
+// ErrCategoryInUse is returned when deleting a category that still has subcategories, transactions or rules.
+var ErrCategoryInUse = errors.New("category still has subcategories, transactions or rules")
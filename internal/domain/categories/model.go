@@ -0,0 +1,18 @@
+package categories
+
+// Category is a spending category. Categories form a tree: a category with an
+// empty ParentID is at the top level, any other is a child of its parent.
+type Category struct {
+	ID       string
+	Name     string
+	ParentID string
+}
+
+// NewCategory creates a new category with the given ID, Name and parent.
+func NewCategory(id, name, parentID string) *Category {
+	return &Category{
+		ID:       id,
+		Name:     name,
+		ParentID: parentID,
+	}
+}
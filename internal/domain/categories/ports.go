@@ -0,0 +1,54 @@
+package categories
+
+import "context"
+
+// ForCreatingCategory defines the port for creating a category. An empty parentID creates a
+// top-level category.
+type ForCreatingCategory interface {
+	CreateCategory(ctx context.Context, name, parentID string) (*Category, error)
+}
+
+// ForGettingCategory defines the port for retrieving a single category.
+type ForGettingCategory interface {
+	GetCategory(ctx context.Context, id string) (*Category, error)
+}
+
+// ForListingCategories defines the port for listing all categories.
+type ForListingCategories interface {
+	ListCategories(ctx context.Context) ([]*Category, error)
+}
+
+// ForUpdatingCategory defines the port for renaming a category or moving it to another parent.
+// A nil name or parentID leaves that field unchanged; an empty parentID moves the category to
+// the top level.
+type ForUpdatingCategory interface {
+	UpdateCategory(ctx context.Context, id string, name, parentID *string) (*Category, error)
+}
+
+// ForDeletingCategory defines the port for deleting a category.
+type ForDeletingCategory interface {
+	DeleteCategory(ctx context.Context, id string) error
+}
+
+// ForSavingCategory defines the port for saving a category of a tenant to persistence
+type ForSavingCategory interface {
+	SaveCategory(ctx context.Context, tenantID string, category *Category) error
+}
+
+// ForLoadingCategories defines the port for loading categories of a tenant from persistence.
+// Categories of other tenants are not found.
+type ForLoadingCategories interface {
+	LoadCategory(ctx context.Context, tenantID, id string) (*Category, error)
+	LoadCategories(ctx context.Context, tenantID string) ([]*Category, error)
+}
+
+// ForModifyingCategory defines the port for persisting changes to an existing category of a tenant
+type ForModifyingCategory interface {
+	ModifyCategory(ctx context.Context, tenantID string, category *Category) error
+}
+
+// ForRemovingCategory defines the port for removing a category of a tenant from persistence.
+// Categories that still have transactions must not be removed.
+type ForRemovingCategory interface {
+	RemoveCategory(ctx context.Context, tenantID, id string) error
+}
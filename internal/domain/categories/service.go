@@ -0,0 +1,163 @@
+package categories
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"strings"
+)
+
+// CategoryService provides the core logic for managing categories.
+type CategoryService struct {
+	categoryPersistence ForSavingCategory
+	categoryLoader      ForLoadingCategories
+	categoryModifier    ForModifyingCategory
+	categoryRemover     ForRemovingCategory
+}
+
+// NewCategoryService creates a new CategoryService.
+func NewCategoryService(persistence ForSavingCategory, loader ForLoadingCategories, modifier ForModifyingCategory, remover ForRemovingCategory) *CategoryService {
+	return &CategoryService{
+		categoryPersistence: persistence,
+		categoryLoader:      loader,
+		categoryModifier:    modifier,
+		categoryRemover:     remover,
+	}
+}
+
+// CreateCategory creates a new category under the given parent, or at the top
+// level if parentID is empty, and saves it using persistence. Parents and
+// sibling names are only looked up among the tenant's own categories.
+func (s *CategoryService) CreateCategory(ctx context.Context, name, parentID string) (*Category, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.categoryLoader.LoadCategories(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	category := NewCategory("", strings.TrimSpace(name), parentID)
+	if err := validate(category, existing); err != nil {
+		return nil, err
+	}
+
+	// Save the category using the persistence port
+	if err := s.categoryPersistence.SaveCategory(ctx, tenantID, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// GetCategory retrieves the category with the given ID. Categories of other
+// tenants are not found.
+func (s *CategoryService) GetCategory(ctx context.Context, id string) (*Category, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.categoryLoader.LoadCategory(ctx, tenantID, id)
+}
+
+// ListCategories retrieves all categories of the tenant.
+func (s *CategoryService) ListCategories(ctx context.Context) ([]*Category, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.categoryLoader.LoadCategories(ctx, tenantID)
+}
+
+// UpdateCategory renames the category with the given ID and/or moves it under
+// another parent. A category cannot be moved under itself or one of its own
+// subcategories.
+func (s *CategoryService) UpdateCategory(ctx context.Context, id string, name, parentID *string) (*Category, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.categoryLoader.LoadCategories(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	category := find(existing, id)
+	if category == nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	updated := NewCategory(id, category.Name, category.ParentID)
+	if name != nil {
+		updated.Name = strings.TrimSpace(*name)
+	}
+	if parentID != nil {
+		updated.ParentID = *parentID
+	}
+	if err := validate(updated, existing); err != nil {
+		return nil, err
+	}
+
+	if err := s.categoryModifier.ModifyCategory(ctx, tenantID, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteCategory deletes the category with the given ID. Categories that still
+// have subcategories, transactions or categorisation rules cannot be deleted.
+func (s *CategoryService) DeleteCategory(ctx context.Context, id string) error {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+	existing, err := s.categoryLoader.LoadCategories(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if find(existing, id) == nil {
+		return ErrCategoryNotFound
+	}
+	for _, other := range existing {
+		if other.ParentID == id {
+			return fmt.Errorf("%w: %q has subcategories", ErrCategoryInUse, id)
+		}
+	}
+	return s.categoryRemover.RemoveCategory(ctx, tenantID, id)
+}
+
+// validate checks the category against the existing categories: it needs a
+// name, a parent that exists and is not the category or one of its
+// descendants, and a name no sibling already has.
+func validate(category *Category, existing []*Category) error {
+	if category.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCategory)
+	}
+
+	// Walk up from the new parent; meeting the category itself means a cycle
+	for ancestorID := category.ParentID; ancestorID != ""; {
+		if ancestorID == category.ID {
+			return fmt.Errorf("%w: a category cannot be moved under itself or its subcategories", ErrInvalidCategory)
+		}
+		ancestor := find(existing, ancestorID)
+		if ancestor == nil {
+			return fmt.Errorf("%w: parent category %q not found", ErrInvalidCategory, ancestorID)
+		}
+		ancestorID = ancestor.ParentID
+	}
+
+	for _, sibling := range existing {
+		if sibling.ID != category.ID && sibling.ParentID == category.ParentID && strings.EqualFold(sibling.Name, category.Name) {
+			return fmt.Errorf("%w: %q", ErrDuplicateCategory, category.Name)
+		}
+	}
+	return nil
+}
+
+func find(categories []*Category, id string) *Category {
+	for _, category := range categories {
+		if category.ID == id {
+			return category
+		}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package exchangerates
+
+import "errors"
+
+// ErrRateNotFound is returned when no rate is known for a currency pair on or before the requested date.
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// ErrInvalidRate is returned when importing a rate that is not usable.
+var ErrInvalidRate = errors.New("invalid exchange rate")
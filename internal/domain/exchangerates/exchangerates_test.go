@@ -0,0 +1,202 @@
+package exchangerates
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeRateStore simulates the exchange rate persistence layer for testing.
+// Its rates belong to tenant 1; other tenants have none.
+type FakeRateStore struct {
+	Rates       []*Rate
+	ReturnError bool
+	TenantID    string
+}
+
+func (f *FakeRateStore) SaveRates(ctx context.Context, tenantID string, rates []*Rate) error {
+	if f.ReturnError {
+		return errors.New("failed to save rates")
+	}
+	f.TenantID = tenantID
+	f.Rates = append(f.Rates, rates...)
+	return nil
+}
+
+func (f *FakeRateStore) LoadRate(ctx context.Context, tenantID string, base, quote money.Currency, on time.Time) (*Rate, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to load rate")
+	}
+	if tenantID != "1" {
+		return nil, ErrRateNotFound
+	}
+	var latest *Rate
+	for _, rate := range f.Rates {
+		if rate.Base == base && rate.Quote == quote && !rate.Date.After(on) && (latest == nil || rate.Date.After(latest.Date)) {
+			latest = rate
+		}
+	}
+	if latest == nil {
+		return nil, ErrRateNotFound
+	}
+	return latest, nil
+}
+
+// FakeTransactor simulates a unit of work, recording whether it committed or rolled back.
+type FakeTransactor struct {
+	Committed  bool
+	RolledBack bool
+}
+
+func (f *FakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		f.RolledBack = true
+		return err
+	}
+	f.Committed = true
+	return nil
+}
+
+// tenantContext returns a context acting for a user of the given tenant
+func tenantContext(tenantID string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice", UserID: "5", TenantID: tenantID})
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func rate(base, quote money.Currency, date time.Time, value string) *Rate {
+	decimal, err := money.ParseDecimal(value)
+	if err != nil {
+		panic(err)
+	}
+	return NewRate(base, quote, date, decimal)
+}
+
+// Test importing a batch of rates
+func TestRateServiceImportRates(t *testing.T) {
+	store := &FakeRateStore{}
+	transactor := &FakeTransactor{}
+	rateService := NewRateService(store, store, transactor)
+
+	err := rateService.ImportRates(tenantContext("1"), []*Rate{
+		rate("EUR", "USD", day(2024, 1, 2), "1.0945"),
+		rate("GBP", "EUR", day(2024, 1, 2), "1.1512"),
+	})
+
+	assert.Nil(t, err, "Error should be nil when importing rates")
+	assert.Len(t, store.Rates, 2, "Both rates should be stored")
+	assert.Equal(t, "1", store.TenantID, "Rates should be stored for the caller's tenant")
+	assert.True(t, transactor.Committed, "Import should run in a unit of work")
+}
+
+// Test that an invalid rate rejects the whole batch
+func TestRateServiceImportRates_Invalid(t *testing.T) {
+	invalid := map[string]*Rate{
+		"same currency":    rate("EUR", "EUR", day(2024, 1, 2), "1"),
+		"unknown currency": rate("EUR", "XXX", day(2024, 1, 2), "1.5"),
+		"zero rate":        rate("EUR", "USD", day(2024, 1, 2), "0"),
+		"negative rate":    rate("EUR", "USD", day(2024, 1, 2), "-1.1"),
+		"missing date":     rate("EUR", "USD", time.Time{}, "1.1"),
+	}
+
+	for name, r := range invalid {
+		t.Run(name, func(t *testing.T) {
+			store := &FakeRateStore{}
+			rateService := NewRateService(store, store, &FakeTransactor{})
+
+			err := rateService.ImportRates(tenantContext("1"), []*Rate{rate("EUR", "USD", day(2024, 1, 2), "1.09"), r})
+
+			assert.True(t, errors.Is(err, ErrInvalidRate), "Expected ErrInvalidRate")
+			assert.Empty(t, store.Rates, "No rate should be stored")
+		})
+	}
+}
+
+// Test converting with a direct rate uses the latest rate on or before the day
+func TestRateServiceConvert_Direct(t *testing.T) {
+	store := &FakeRateStore{Rates: []*Rate{
+		rate("EUR", "USD", day(2024, 1, 2), "1.0945"),
+		rate("EUR", "USD", day(2024, 1, 5), "1.0950"),
+		rate("EUR", "USD", day(2024, 1, 9), "2"),
+	}}
+	rateService := NewRateService(store, store, &FakeTransactor{})
+
+	// Saturday, so the Friday rate applies
+	converted, err := rateService.Convert(tenantContext("1"), money.MustParse("100.00", "EUR"), "USD", day(2024, 1, 6))
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.MustParse("109.50", "USD"), converted)
+}
+
+// Test converting with only the inverse rate stored
+func TestRateServiceConvert_Inverse(t *testing.T) {
+	store := &FakeRateStore{Rates: []*Rate{rate("EUR", "GBP", day(2024, 1, 2), "0.8")}}
+	rateService := NewRateService(store, store, &FakeTransactor{})
+
+	converted, err := rateService.Convert(tenantContext("1"), money.MustParse("10.00", "GBP"), "EUR", day(2024, 1, 2))
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.MustParse("12.50", "EUR"), converted)
+}
+
+// Test converting into the same currency needs no rate
+func TestRateServiceConvert_SameCurrency(t *testing.T) {
+	store := &FakeRateStore{}
+	rateService := NewRateService(store, store, &FakeTransactor{})
+
+	converted, err := rateService.Convert(tenantContext("1"), money.MustParse("10.00", "GBP"), "GBP", day(2024, 1, 2))
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.MustParse("10.00", "GBP"), converted)
+}
+
+// Test converting without any rate for the pair
+func TestRateServiceConvert_NotFound(t *testing.T) {
+	store := &FakeRateStore{Rates: []*Rate{rate("EUR", "USD", day(2024, 1, 5), "1.09")}}
+	rateService := NewRateService(store, store, &FakeTransactor{})
+
+	_, err := rateService.Convert(tenantContext("1"), money.MustParse("10.00", "EUR"), "USD", day(2024, 1, 4))
+
+	assert.True(t, errors.Is(err, ErrRateNotFound), "Expected ErrRateNotFound for a day before the first rate")
+}
+
+// Test one tenant's rates are never used to convert another tenant's amounts
+func TestRateServiceConvert_OtherTenant(t *testing.T) {
+	store := &FakeRateStore{Rates: []*Rate{rate("EUR", "USD", day(2024, 1, 2), "1.09")}}
+	rateService := NewRateService(store, store, &FakeTransactor{})
+
+	_, err := rateService.Convert(tenantContext("2"), money.MustParse("10.00", "EUR"), "USD", day(2024, 1, 4))
+
+	assert.True(t, errors.Is(err, ErrRateNotFound), "Expected ErrRateNotFound in another tenant")
+}
+
+// Test rates are neither imported nor used without a tenant
+func TestRateService_Unauthenticated(t *testing.T) {
+	store := &FakeRateStore{}
+	rateService := NewRateService(store, store, &FakeTransactor{})
+
+	err := rateService.ImportRates(context.Background(), []*Rate{rate("EUR", "USD", day(2024, 1, 2), "1.09")})
+	assert.True(t, errors.Is(err, auth.ErrUnauthenticated), "Expected ErrUnauthenticated importing")
+	assert.Empty(t, store.Rates)
+
+	_, err = rateService.Convert(context.Background(), money.MustParse("10.00", "EUR"), "USD", day(2024, 1, 4))
+	assert.True(t, errors.Is(err, auth.ErrUnauthenticated), "Expected ErrUnauthenticated converting")
+}
+
+// Test conversion failure from persistence
+func TestRateServiceConvert_LoadError(t *testing.T) {
+	store := &FakeRateStore{ReturnError: true}
+	rateService := NewRateService(store, store, &FakeTransactor{})
+
+	_, err := rateService.Convert(tenantContext("1"), money.MustParse("10.00", "EUR"), "USD", day(2024, 1, 4))
+
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrRateNotFound))
+}
@@ -0,0 +1,25 @@
+package exchangerates
+
+import (
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// Rate is the price of one unit of the Base currency expressed in the Quote
+// currency, as published for a given day.
+type Rate struct {
+	Base  money.Currency
+	Quote money.Currency
+	Date  time.Time
+	Value money.Decimal
+}
+
+// NewRate creates a new exchange rate.
+func NewRate(base, quote money.Currency, date time.Time, value money.Decimal) *Rate {
+	return &Rate{
+		Base:  base,
+		Quote: quote,
+		Date:  date,
+		Value: value,
+	}
+}
@@ -0,0 +1,35 @@
+package exchangerates
+
+import (
+	"context"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// ForImportingRates defines the port for importing exchange rates.
+type ForImportingRates interface {
+	ImportRates(ctx context.Context, rates []*Rate) error
+}
+
+// ForConvertingMoney defines the port for converting amounts between currencies.
+type ForConvertingMoney interface {
+	Convert(ctx context.Context, amount money.Money, to money.Currency, on time.Time) (money.Money, error)
+}
+
+// ForSavingRates defines the port for storing a tenant's exchange rates,
+// replacing any rate the tenant already stored for the same pair and day.
+type ForSavingRates interface {
+	SaveRates(ctx context.Context, tenantID string, rates []*Rate) error
+}
+
+// ForLoadingRate defines the port for loading the most recent rate of a
+// tenant for a currency pair published on or before the given day.
+type ForLoadingRate interface {
+	LoadRate(ctx context.Context, tenantID string, base, quote money.Currency, on time.Time) (*Rate, error)
+}
+
+// ForRunningInTransaction defines the port for running several persistence
+// operations atomically.
+type ForRunningInTransaction interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,103 @@
+package exchangerates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// RateService provides the core logic for storing exchange rates and converting money with them.
+type RateService struct {
+	ratePersistence ForSavingRates
+	rateLoader      ForLoadingRate
+	transactor      ForRunningInTransaction
+}
+
+// NewRateService creates a new RateService.
+func NewRateService(persistence ForSavingRates, loader ForLoadingRate, transactor ForRunningInTransaction) *RateService {
+	return &RateService{
+		ratePersistence: persistence,
+		rateLoader:      loader,
+		transactor:      transactor,
+	}
+}
+
+// ImportRates validates and stores a batch of rates for the tenant of the
+// principal in ctx. Either the whole batch is stored or, if any rate is
+// invalid or cannot be saved, none of it is.
+func (s *RateService) ImportRates(ctx context.Context, rates []*Rate) error {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+	for i, rate := range rates {
+		if err := validateRate(rate); err != nil {
+			return fmt.Errorf("rate %d: %w", i+1, err)
+		}
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.ratePersistence.SaveRates(ctx, tenantID, rates)
+	})
+}
+
+// Convert converts the amount into the target currency using the tenant's
+// latest rate published on or before the given day. Either direction of the
+// pair may be stored; an inverse rate is used when the direct one is missing.
+func (s *RateService) Convert(ctx context.Context, amount money.Money, to money.Currency, on time.Time) (money.Money, error) {
+	if amount.Currency() == to {
+		return amount, nil
+	}
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	factor, err := s.factor(ctx, tenantID, amount.Currency(), to, on)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	converted := new(big.Rat).Mul(amount.Decimal().Rat(), factor)
+	return money.FromRat(converted, to)
+}
+
+// factor returns the multiplier turning an amount of from into an amount of to
+func (s *RateService) factor(ctx context.Context, tenantID string, from, to money.Currency, on time.Time) (*big.Rat, error) {
+	rate, err := s.rateLoader.LoadRate(ctx, tenantID, from, to, on)
+	if err == nil {
+		return rate.Value.Rat(), nil
+	}
+	if !errors.Is(err, ErrRateNotFound) {
+		return nil, err
+	}
+
+	inverse, err := s.rateLoader.LoadRate(ctx, tenantID, to, from, on)
+	if errors.Is(err, ErrRateNotFound) {
+		return nil, fmt.Errorf("%w: %s/%s on %s", ErrRateNotFound, from, to, on.Format("2006-01-02"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).Inv(inverse.Value.Rat()), nil
+}
+
+func validateRate(rate *Rate) error {
+	if !rate.Base.IsValid() || !rate.Quote.IsValid() {
+		return fmt.Errorf("%w: unknown currency pair %s/%s", ErrInvalidRate, rate.Base, rate.Quote)
+	}
+	if rate.Base == rate.Quote {
+		return fmt.Errorf("%w: base and quote currency are both %s", ErrInvalidRate, rate.Base)
+	}
+	if rate.Value.Sign() <= 0 {
+		return fmt.Errorf("%w: rate must be positive", ErrInvalidRate)
+	}
+	if rate.Date.IsZero() {
+		return fmt.Errorf("%w: missing date", ErrInvalidRate)
+	}
+	return nil
+}
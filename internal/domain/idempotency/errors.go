@@ -0,0 +1,19 @@
+package idempotency
+
+import "errors"
+
+// ErrInvalidKey is returned when an idempotency key is empty or too long.
+var ErrInvalidKey = errors.New("invalid idempotency key")
+
+// ErrKeyReused is returned when an idempotency key is sent again with a different request.
+var ErrKeyReused = errors.New("idempotency key already used for a different request")
+
+// ErrRequestInProgress is returned when an idempotency key is sent again before the first
+// request with it has finished.
+var ErrRequestInProgress = errors.New("a request with this idempotency key is still in progress")
+
+// ErrKeyExists is returned by persistence when a record already exists for the key.
+var ErrKeyExists = errors.New("idempotency key already recorded")
+
+// ErrRecordNotFound is returned when no record exists for the requested key.
+var ErrRecordNotFound = errors.New("idempotency record not found")
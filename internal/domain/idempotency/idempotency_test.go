@@ -0,0 +1,197 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeRecordStore simulates saving, loading, completing and removing records for testing.
+// Records holds the records of tenant 1.
+type FakeRecordStore struct {
+	Records     map[string]*Record
+	Tenants     map[string]map[string]*Record
+	Removed     []string
+	ExpiredAt   time.Time
+	ReturnError bool
+}
+
+func newFakeRecordStore(records ...*Record) *FakeRecordStore {
+	store := &FakeRecordStore{Records: map[string]*Record{}}
+	store.Tenants = map[string]map[string]*Record{"1": store.Records}
+	for _, record := range records {
+		store.Records[record.Key] = record
+	}
+	return store
+}
+
+func (f *FakeRecordStore) records(tenantID string) map[string]*Record {
+	if f.Tenants[tenantID] == nil {
+		f.Tenants[tenantID] = map[string]*Record{}
+	}
+	return f.Tenants[tenantID]
+}
+
+func (f *FakeRecordStore) SaveRecord(ctx context.Context, tenantID string, record *Record) error {
+	if f.ReturnError {
+		return errors.New("failed to save record")
+	}
+	if _, ok := f.records(tenantID)[record.Key]; ok {
+		return ErrKeyExists
+	}
+	f.records(tenantID)[record.Key] = record
+	return nil
+}
+
+func (f *FakeRecordStore) LoadRecord(ctx context.Context, tenantID, key string) (*Record, error) {
+	record, ok := f.records(tenantID)[key]
+	if !ok {
+		return nil, ErrRecordNotFound
+	}
+	return record, nil
+}
+
+func (f *FakeRecordStore) CompleteRecord(ctx context.Context, tenantID, key string, response *Response) error {
+	f.records(tenantID)[key].Response = response
+	return nil
+}
+
+func (f *FakeRecordStore) RemoveRecord(ctx context.Context, tenantID, key string) error {
+	delete(f.records(tenantID), key)
+	f.Removed = append(f.Removed, key)
+	return nil
+}
+
+func (f *FakeRecordStore) RemoveExpired(ctx context.Context, now time.Time) (int, error) {
+	f.ExpiredAt = now
+	return 2, nil
+}
+
+// tenantContext returns a context acting as a user of the given tenant
+func tenantContext(tenantID string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice", UserID: "5", TenantID: tenantID})
+}
+
+func newTestIdempotencyService(store *FakeRecordStore) *IdempotencyService {
+	return NewIdempotencyService(store, store, store, store, DefaultTTL)
+}
+
+// Test a new key is claimed for the request until its TTL runs out
+func TestIdempotencyServiceBegin_NewKey(t *testing.T) {
+	store := newFakeRecordStore()
+	service := newTestIdempotencyService(store)
+
+	response, err := service.Begin(tenantContext("1"), "abc", "fingerprint")
+
+	assert.Nil(t, err)
+	assert.Nil(t, response, "A new key should let the request go ahead")
+	record := store.Records["abc"]
+	assert.Equal(t, "fingerprint", record.Fingerprint)
+	assert.Nil(t, record.Response)
+	assert.Equal(t, DefaultTTL, record.ExpiresAt.Sub(record.CreatedAt))
+}
+
+// Test retrying a finished request replays its response
+func TestIdempotencyServiceBegin_Replay(t *testing.T) {
+	stored := &Response{StatusCode: 201, ContentType: "application/json", Body: []byte(`{"ID":"1"}`)}
+	store := newFakeRecordStore(&Record{Key: "abc", Fingerprint: "fingerprint", Response: stored, ExpiresAt: time.Now().Add(time.Hour)})
+	service := newTestIdempotencyService(store)
+
+	response, err := service.Begin(tenantContext("1"), "abc", "fingerprint")
+
+	assert.Nil(t, err)
+	assert.Equal(t, stored, response)
+}
+
+// Test a key cannot be reused for another request, nor retried while its request is running
+func TestIdempotencyServiceBegin_Conflicts(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	store := newFakeRecordStore(
+		&Record{Key: "done", Fingerprint: "fingerprint", Response: &Response{StatusCode: 201}, ExpiresAt: expiresAt},
+		&Record{Key: "running", Fingerprint: "fingerprint", ExpiresAt: expiresAt},
+	)
+	service := newTestIdempotencyService(store)
+
+	_, err := service.Begin(tenantContext("1"), "done", "other")
+	assert.True(t, errors.Is(err, ErrKeyReused), "Expected ErrKeyReused")
+
+	_, err = service.Begin(tenantContext("1"), "running", "fingerprint")
+	assert.True(t, errors.Is(err, ErrRequestInProgress), "Expected ErrRequestInProgress")
+}
+
+// Test an expired key is forgotten and claimed afresh
+func TestIdempotencyServiceBegin_Expired(t *testing.T) {
+	store := newFakeRecordStore(&Record{Key: "abc", Fingerprint: "old", Response: &Response{StatusCode: 201}, ExpiresAt: time.Now().Add(-time.Minute)})
+	service := newTestIdempotencyService(store)
+
+	response, err := service.Begin(tenantContext("1"), "abc", "new")
+
+	assert.Nil(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, []string{"abc"}, store.Removed)
+	assert.Equal(t, "new", store.Records["abc"].Fingerprint)
+}
+
+// Test keys must be present and not too long
+func TestIdempotencyServiceBegin_InvalidKey(t *testing.T) {
+	service := newTestIdempotencyService(newFakeRecordStore())
+
+	for _, key := range []string{"", string(make([]byte, MaxKeyLength+1))} {
+		_, err := service.Begin(tenantContext("1"), key, "fingerprint")
+		assert.True(t, errors.Is(err, ErrInvalidKey), "Expected ErrInvalidKey")
+	}
+}
+
+// Test persistence failures are passed on
+func TestIdempotencyServiceBegin_SaveError(t *testing.T) {
+	service := newTestIdempotencyService(&FakeRecordStore{ReturnError: true})
+
+	_, err := service.Begin(tenantContext("1"), "abc", "fingerprint")
+
+	assert.NotNil(t, err)
+}
+
+// Test responses are stored, except server errors, which release the key for a retry
+func TestIdempotencyServiceComplete(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	store := newFakeRecordStore(&Record{Key: "created", ExpiresAt: expiresAt}, &Record{Key: "failed", ExpiresAt: expiresAt})
+	service := newTestIdempotencyService(store)
+
+	created := &Response{StatusCode: 201, Body: []byte("{}")}
+	assert.Nil(t, service.Complete(tenantContext("1"), "created", created))
+	assert.Nil(t, service.Complete(tenantContext("1"), "failed", &Response{StatusCode: 500}))
+
+	assert.Equal(t, created, store.Records["created"].Response)
+	assert.Equal(t, []string{"failed"}, store.Removed)
+}
+
+// Test purging removes the records that have expired by now
+func TestIdempotencyServicePurgeExpired(t *testing.T) {
+	store := newFakeRecordStore()
+	service := newTestIdempotencyService(store)
+
+	purged, err := service.PurgeExpired(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, purged)
+	assert.WithinDuration(t, time.Now(), store.ExpiredAt, time.Minute)
+}
+
+// Test tenants can use the same key without seeing each other's responses
+func TestIdempotencyServiceBegin_OtherTenant(t *testing.T) {
+	store := newFakeRecordStore(&Record{Key: "abc", Fingerprint: "fingerprint", ExpiresAt: time.Now().Add(time.Hour), Response: &Response{StatusCode: 201}})
+	service := newTestIdempotencyService(store)
+
+	response, err := service.Begin(tenantContext("2"), "abc", "fingerprint")
+
+	assert.Nil(t, err)
+	assert.Nil(t, response, "Another tenant's response must not be replayed")
+	assert.Contains(t, store.Tenants["2"], "abc")
+
+	_, err = service.Begin(context.Background(), "abc", "fingerprint")
+	assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+}
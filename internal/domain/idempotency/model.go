@@ -0,0 +1,34 @@
+package idempotency
+
+import "time"
+
+const (
+	// DefaultTTL is how long a key's response is kept for retries.
+	DefaultTTL = 24 * time.Hour
+	// MaxKeyLength caps the length of an idempotency key.
+	MaxKeyLength = 255
+)
+
+// Response is what was sent back to the request that first used a key.
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// Record ties an idempotency key to the request that first used it, identified
+// by Fingerprint, a digest of the request's method, path and body. Response is
+// nil until that request has finished. Records are forgotten once ExpiresAt
+// has passed, after which the key can be used afresh.
+type Record struct {
+	Key         string
+	Fingerprint string
+	Response    *Response
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// expired reports whether the record can no longer be replayed at the given time.
+func (r *Record) expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
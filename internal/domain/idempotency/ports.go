@@ -0,0 +1,44 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// ForHandlingIdempotentRequests defines the port for making retried requests safe. Begin
+// returns the stored response when the key was already used for the same request, and nil
+// when the request should go ahead; Complete then stores its response under the key.
+type ForHandlingIdempotentRequests interface {
+	Begin(ctx context.Context, key, fingerprint string) (*Response, error)
+	Complete(ctx context.Context, key string, response *Response) error
+}
+
+// ForPurgingIdempotencyRecords defines the port for forgetting expired keys.
+type ForPurgingIdempotencyRecords interface {
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+// ForSavingRecord defines the port for claiming a tenant's key in persistence. It fails with
+// ErrKeyExists if a record for the key is already stored for the tenant; other tenants may use
+// the same key.
+type ForSavingRecord interface {
+	SaveRecord(ctx context.Context, tenantID string, record *Record) error
+}
+
+// ForLoadingRecord defines the port for loading the record of a tenant's key from persistence
+type ForLoadingRecord interface {
+	LoadRecord(ctx context.Context, tenantID, key string) (*Record, error)
+}
+
+// ForCompletingRecord defines the port for storing the response of a tenant's claimed key
+type ForCompletingRecord interface {
+	CompleteRecord(ctx context.Context, tenantID, key string, response *Response) error
+}
+
+// ForRemovingRecords defines the port for removing records from persistence. RemoveExpired
+// removes those of every tenant expiring at or before the given time and returns how many
+// there were.
+type ForRemovingRecords interface {
+	RemoveRecord(ctx context.Context, tenantID, key string) error
+	RemoveExpired(ctx context.Context, now time.Time) (int, error)
+}
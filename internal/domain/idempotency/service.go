@@ -0,0 +1,107 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"time"
+)
+
+// IdempotencyService provides the core logic for answering retried requests
+// with the response to the first one instead of running them again.
+type IdempotencyService struct {
+	recordPersistence ForSavingRecord
+	recordLoader      ForLoadingRecord
+	recordCompleter   ForCompletingRecord
+	recordRemover     ForRemovingRecords
+	ttl               time.Duration
+}
+
+// NewIdempotencyService creates a new IdempotencyService keeping responses for ttl.
+func NewIdempotencyService(persistence ForSavingRecord, loader ForLoadingRecord, completer ForCompletingRecord, remover ForRemovingRecords, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{
+		recordPersistence: persistence,
+		recordLoader:      loader,
+		recordCompleter:   completer,
+		recordRemover:     remover,
+		ttl:               ttl,
+	}
+}
+
+// Begin claims the key for the request with the given fingerprint. It returns
+// nil if the key is new, or has expired, so the request should be handled.
+// If the key was already used for the same request the stored response is
+// returned to be replayed, unless that request is still being handled, which
+// fails with ErrRequestInProgress. A key used for a different request fails
+// with ErrKeyReused. Keys belong to the tenant of the principal in ctx, so
+// tenants never see each other's responses.
+func (s *IdempotencyService) Begin(ctx context.Context, key, fingerprint string) (*Response, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" || len(key) > MaxKeyLength {
+		return nil, fmt.Errorf("%w: must be 1 to %d characters", ErrInvalidKey, MaxKeyLength)
+	}
+
+	now := time.Now().UTC()
+	record := &Record{Key: key, Fingerprint: fingerprint, CreatedAt: now, ExpiresAt: now.Add(s.ttl)}
+	err = s.recordPersistence.SaveRecord(ctx, tenantID, record)
+	if !errors.Is(err, ErrKeyExists) {
+		return nil, err
+	}
+
+	stored, err := s.recordLoader.LoadRecord(ctx, tenantID, key)
+	if errors.Is(err, ErrRecordNotFound) {
+		// The record expired and was purged in between, so the key is free again
+		return nil, s.reclaim(ctx, tenantID, record)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stored.expired(now) {
+		if err := s.recordRemover.RemoveRecord(ctx, tenantID, key); err != nil {
+			return nil, err
+		}
+		return nil, s.reclaim(ctx, tenantID, record)
+	}
+	if stored.Fingerprint != fingerprint {
+		return nil, ErrKeyReused
+	}
+	if stored.Response == nil {
+		return nil, ErrRequestInProgress
+	}
+	return stored.Response, nil
+}
+
+// reclaim saves the record again once the stored one for its key is gone.
+// Losing that race to another request with the same key means the other
+// request is now in progress.
+func (s *IdempotencyService) reclaim(ctx context.Context, tenantID string, record *Record) error {
+	err := s.recordPersistence.SaveRecord(ctx, tenantID, record)
+	if errors.Is(err, ErrKeyExists) {
+		return ErrRequestInProgress
+	}
+	return err
+}
+
+// Complete stores the response to the request that claimed the key. Server
+// errors are not stored: the key is released instead, so the request can be
+// retried.
+func (s *IdempotencyService) Complete(ctx context.Context, key string, response *Response) error {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode >= 500 {
+		return s.recordRemover.RemoveRecord(ctx, tenantID, key)
+	}
+	return s.recordCompleter.CompleteRecord(ctx, tenantID, key, response)
+}
+
+// PurgeExpired removes the records of every tenant that have expired and
+// returns how many there were.
+func (s *IdempotencyService) PurgeExpired(ctx context.Context) (int, error) {
+	return s.recordRemover.RemoveExpired(ctx, time.Now().UTC())
+}
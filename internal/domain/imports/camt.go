@@ -0,0 +1,257 @@
+package imports
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"spend-api/internal/domain/money"
+	"strings"
+	"time"
+)
+
+// camtBankTypes translates ISO 20022 bank transaction sub-family codes into
+// OFX transaction types
+var camtBankTypes = map[string]string{
+	"CHRG": "FEE",
+	"COMM": "FEE",
+	"FEES": "FEE",
+	"INTR": "INT",
+	"DVDE": "DIV",
+	"BOOK": "XFER",
+}
+
+// camtAmount is an amount with its currency
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+// camtDate is a date given either as a date or as a date and time
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// camtAccount identifies the account a statement is for
+type camtAccount struct {
+	IBAN     string `xml:"Id>IBAN"`
+	Other    string `xml:"Id>Othr>Id"`
+	Currency string `xml:"Ccy"`
+}
+
+// camtBalance is one of a statement's balances, such as its closing balance
+type camtBalance struct {
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Date      camtDate   `xml:"Dt"`
+}
+
+// camtStatus is an entry's status, written as plain text before camt.053.001.08
+// and as a code since
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+// camtDetails holds the details of a transaction booked in an entry
+type camtDetails struct {
+	ServicerReference string   `xml:"Refs>AcctSvcrRef"`
+	Debtor            string   `xml:"RltdPties>Dbtr>Nm"`
+	DebtorParty       string   `xml:"RltdPties>Dbtr>Pty>Nm"`
+	Creditor          string   `xml:"RltdPties>Cdtr>Nm"`
+	CreditorParty     string   `xml:"RltdPties>Cdtr>Pty>Nm"`
+	Remittance        []string `xml:"RmtInf>Ustrd"`
+}
+
+// camtEntry is a single booked entry of a statement
+type camtEntry struct {
+	Reference         string        `xml:"NtryRef"`
+	Amount            camtAmount    `xml:"Amt"`
+	Indicator         string        `xml:"CdtDbtInd"`
+	Reversal          bool          `xml:"RvslInd"`
+	Status            camtStatus    `xml:"Sts"`
+	BookingDate       camtDate      `xml:"BookgDt"`
+	ValueDate         camtDate      `xml:"ValDt"`
+	ServicerReference string        `xml:"AcctSvcrRef"`
+	SubFamily         string        `xml:"BkTxCd>Domn>Fmly>SubFmlyCd"`
+	Details           []camtDetails `xml:"NtryDtls>TxDtls"`
+	Info              string        `xml:"AddtlNtryInf"`
+}
+
+// parseCAMT053 reads the entries and closing balance of every statement in
+// an ISO 20022 camt.053 file. Entries are booked on their booking date and
+// dated on their value date, and take their sign from the credit/debit
+// indicator. Pending and informational entries are skipped, and the
+// account servicer's reference becomes the external ID.
+func parseCAMT053(data io.Reader, currency money.Currency) (*Statement, error) {
+	decoder := xml.NewDecoder(data)
+	statement := &Statement{Currency: currency}
+	ids := syntheticIDs{}
+	var seen accounts
+	statements := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidStatement, err.Error())
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "Stmt":
+			statements++
+		case "Acct":
+			var account camtAccount
+			if err := decoder.DecodeElement(&account, &start); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidStatement, err.Error())
+			}
+			if err := seen.check(account.IBAN + account.Other); err != nil {
+				return nil, err
+			}
+			if account.Currency != "" {
+				if statement.Currency, err = money.ParseCurrency(account.Currency); err != nil {
+					return nil, fmt.Errorf("%w: unknown currency %q", ErrInvalidStatement, account.Currency)
+				}
+			}
+		case "Bal":
+			var balance camtBalance
+			if err := decoder.DecodeElement(&balance, &start); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidStatement, err.Error())
+			}
+			if balance.Code != "CLBD" {
+				continue
+			}
+			amount, date, err := balance.read(statement.Currency)
+			if err != nil {
+				return nil, fmt.Errorf("%w: closing balance: %s", ErrInvalidStatement, err.Error())
+			}
+			statement.closeWith(amount, date)
+		case "Ntry":
+			line, _ := decoder.InputPos()
+			var entry camtEntry
+			if err := decoder.DecodeElement(&entry, &start); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidStatement, err.Error())
+			}
+			statement.addCAMTEntry(line, &entry, ids)
+		}
+	}
+	if statements == 0 {
+		return nil, fmt.Errorf("%w: no camt.053 statement found", ErrInvalidStatement)
+	}
+	return statement, nil
+}
+
+// addCAMTEntry turns a camt.053 entry into a statement entry described by the
+// counterparty and the remittance information of its first transaction
+func (s *Statement) addCAMTEntry(line int, entry *camtEntry, ids syntheticIDs) {
+	status := strings.TrimSpace(entry.Status.Code + entry.Status.Text)
+	if status != "" && status != "BOOK" {
+		s.skip(line, fmt.Sprintf("entry is not booked (%s)", status))
+		return
+	}
+	amount, err := readCAMTAmount(entry.Amount, entry.Indicator, s.Currency)
+	if err != nil {
+		s.fail(line, err)
+		return
+	}
+	if amount.IsZero() {
+		s.skip(line, "zero amount")
+		return
+	}
+	booking, err := entry.BookingDate.read()
+	if err != nil {
+		s.fail(line, err)
+		return
+	}
+	value, err := entry.ValueDate.read()
+	if err != nil && entry.ValueDate != (camtDate{}) {
+		s.fail(line, err)
+		return
+	}
+
+	description := entry.Info
+	externalID := entry.ServicerReference
+	if len(entry.Details) > 0 {
+		details := entry.Details[0]
+		counterparty := describe(details.Creditor, details.CreditorParty)
+		if amount.IsPositive() {
+			counterparty = describe(details.Debtor, details.DebtorParty)
+		}
+		if remittance := strings.Join(details.Remittance, " "); counterparty != "" || remittance != "" {
+			description = describe(counterparty, remittance)
+		}
+		if externalID == "" && len(entry.Details) == 1 {
+			externalID = details.ServicerReference
+		}
+	}
+	if externalID == "" {
+		externalID = ids.next("camt", entry.Reference, entry.BookingDate.Date+entry.BookingDate.DateTime, entry.Indicator, entry.Amount.Value, description)
+	}
+
+	date, posted := entryDates(value, booking)
+	s.Entries = append(s.Entries, &Entry{
+		Line:        line,
+		Date:        date,
+		PostedDate:  posted,
+		Amount:      amount,
+		Description: description,
+		ExternalID:  externalID,
+		BankType:    camtBankTypes[entry.SubFamily],
+		Reversal:    entry.Reversal,
+	})
+}
+
+// read gives the balance as a signed amount and its date
+func (b *camtBalance) read(currency money.Currency) (money.Money, time.Time, error) {
+	amount, err := readCAMTAmount(b.Amount, b.Indicator, currency)
+	if err != nil {
+		return money.Money{}, time.Time{}, err
+	}
+	date, err := b.Date.read()
+	if err != nil {
+		return money.Money{}, time.Time{}, err
+	}
+	return amount, date, nil
+}
+
+// read gives the calendar date, ignoring any time of day
+func (d camtDate) read() (time.Time, error) {
+	value := d.Date
+	if value == "" {
+		value = d.DateTime
+	}
+	if len(value) < 10 {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	date, err := time.Parse(time.DateOnly, value[:10])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return date, nil
+}
+
+// readCAMTAmount reads an unsigned camt amount, negating debits
+func readCAMTAmount(amount camtAmount, indicator string, currency money.Currency) (money.Money, error) {
+	if amount.Currency != "" && money.Currency(amount.Currency) != currency {
+		return money.Money{}, fmt.Errorf("amount is in %s but the statement is in %s", amount.Currency, currency)
+	}
+	value, err := money.Parse(strings.TrimSpace(amount.Value), currency)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("invalid amount %q", amount.Value)
+	}
+	switch indicator {
+	case "CRDT":
+		return value, nil
+	case "DBIT":
+		return value.Neg(), nil
+	}
+	return money.Money{}, errors.New("missing credit/debit indicator")
+}
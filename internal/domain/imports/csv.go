@@ -0,0 +1,163 @@
+package imports
+
+import (
+	"fmt"
+	"spend-api/internal/domain/money"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// dateTokens turns a profile's date format into a Go time layout
+var dateTokens = strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02")
+
+// normalize applies the profile's defaults and validates its mapping.
+func (p *Profile) normalize() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidProfile)
+	}
+	if p.Delimiter == "" {
+		p.Delimiter = ","
+	}
+	if utf8.RuneCountInString(p.Delimiter) != 1 || p.Delimiter == "\"" || p.Delimiter == "\n" || p.Delimiter == "\r" {
+		return fmt.Errorf("%w: delimiter must be a single character other than a quote or newline", ErrInvalidProfile)
+	}
+	if p.DecimalSeparator == "" {
+		p.DecimalSeparator = "."
+	}
+	if p.DecimalSeparator != "." && p.DecimalSeparator != "," {
+		return fmt.Errorf("%w: decimal separator must be \".\" or \",\"", ErrInvalidProfile)
+	}
+	if p.SkipRows < 0 {
+		return fmt.Errorf("%w: skipRows must not be negative", ErrInvalidProfile)
+	}
+	if p.DateFormat == "" {
+		p.DateFormat = "YYYY-MM-DD"
+	}
+	layout := dateTokens.Replace(p.DateFormat)
+	if !strings.Contains(layout, "06") || !strings.Contains(layout, "01") || !strings.Contains(layout, "02") {
+		return fmt.Errorf("%w: date format %q needs a year, month and day", ErrInvalidProfile, p.DateFormat)
+	}
+
+	columns := []struct {
+		name   string
+		column *int
+	}{
+		{"dateColumn", &p.DateColumn},
+		{"descriptionColumn", p.DescriptionColumn},
+		{"amountColumn", p.AmountColumn},
+		{"debitColumn", p.DebitColumn},
+		{"creditColumn", p.CreditColumn},
+	}
+	for _, c := range columns {
+		if c.column != nil && *c.column < 0 {
+			return fmt.Errorf("%w: %s must not be negative", ErrInvalidProfile, c.name)
+		}
+	}
+	split := p.DebitColumn != nil || p.CreditColumn != nil
+	switch {
+	case p.AmountColumn != nil && split:
+		return fmt.Errorf("%w: use either amountColumn or debitColumn and creditColumn", ErrInvalidProfile)
+	case p.AmountColumn == nil && !split:
+		return fmt.Errorf("%w: amountColumn or debitColumn and creditColumn is required", ErrInvalidProfile)
+	case split && (p.DebitColumn == nil || p.CreditColumn == nil):
+		return fmt.Errorf("%w: debitColumn and creditColumn must be given together", ErrInvalidProfile)
+	}
+	return nil
+}
+
+// parseRecord maps a CSV record onto a statement entry. A nil entry with a
+// nil error means the record holds nothing to import and is skipped, with
+// the reason given.
+func (p *Profile) parseRecord(record []string, currency money.Currency) (*Entry, string, error) {
+	blank := true
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			blank = false
+		}
+	}
+	if blank {
+		return nil, "blank line", nil
+	}
+
+	field := func(column int) (string, error) {
+		if column >= len(record) {
+			return "", fmt.Errorf("missing column %d", column)
+		}
+		return strings.TrimSpace(record[column]), nil
+	}
+
+	entry := &Entry{}
+	value, err := field(p.DateColumn)
+	if err != nil {
+		return nil, "", err
+	}
+	entry.Date, err = time.Parse(dateTokens.Replace(p.DateFormat), value)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid date %q, expected %s", value, p.DateFormat)
+	}
+	if p.DescriptionColumn != nil {
+		if entry.Description, err = field(*p.DescriptionColumn); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if p.AmountColumn != nil {
+		value, err := field(*p.AmountColumn)
+		if err != nil {
+			return nil, "", err
+		}
+		if entry.Amount, err = p.parseAmount(value, currency); err != nil {
+			return nil, "", err
+		}
+	} else {
+		debit, err := field(*p.DebitColumn)
+		if err != nil {
+			return nil, "", err
+		}
+		credit, err := field(*p.CreditColumn)
+		if err != nil {
+			return nil, "", err
+		}
+		if debit != "" && credit != "" {
+			return nil, "", fmt.Errorf("both debit %q and credit %q are set", debit, credit)
+		}
+		if debit != "" {
+			amount, err := p.parseAmount(debit, currency)
+			if err != nil {
+				return nil, "", err
+			}
+			entry.Amount = amount.Abs().Neg()
+		} else {
+			amount, err := p.parseAmount(credit, currency)
+			if err != nil {
+				return nil, "", err
+			}
+			entry.Amount = amount.Abs()
+		}
+	}
+
+	if entry.Amount.IsZero() {
+		return nil, "zero amount", nil
+	}
+	return entry, "", nil
+}
+
+// parseAmount reads an amount written with the profile's decimal separator,
+// ignoring spaces and the other separator used to group thousands
+func (p *Profile) parseAmount(value string, currency money.Currency) (money.Money, error) {
+	if value == "" {
+		return money.Money{}, fmt.Errorf("missing amount")
+	}
+	thousands := ","
+	if p.DecimalSeparator == "," {
+		thousands = "."
+	}
+	normalized := strings.NewReplacer(" ", "", "\u00a0", "", thousands, "", p.DecimalSeparator, ".").Replace(value)
+	amount, err := money.Parse(normalized, currency)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
@@ -0,0 +1,21 @@
+package imports
+
+import "errors"
+
+// ErrProfileNotFound is returned when no import profile exists with the requested ID.
+var ErrProfileNotFound = errors.New("import profile not found")
+
+// ErrInvalidProfile is returned when an import profile's mapping is not usable.
+var ErrInvalidProfile = errors.New("invalid import profile")
+
+// ErrAccountNotFound is returned when importing into an account that does not exist.
+var ErrAccountNotFound = errors.New("account not found")
+
+// ErrInvalidEntry is returned when a statement entry is rejected as a transaction.
+var ErrInvalidEntry = errors.New("invalid entry")
+
+// ErrDuplicateEntry is returned when a statement entry has already been imported into the account.
+var ErrDuplicateEntry = errors.New("entry already imported")
+
+// ErrInvalidStatement is returned when a statement file cannot be read at all.
+var ErrInvalidStatement = errors.New("invalid statement")
@@ -0,0 +1,238 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/money"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeProfileStore simulates saving, loading and removing import profiles for testing. Its
+// profiles belong to tenant 1.
+type FakeProfileStore struct {
+	Profiles    []*Profile
+	ReturnError bool
+	Removed     []string
+}
+
+func (f *FakeProfileStore) SaveProfile(ctx context.Context, tenantID string, profile *Profile) error {
+	if f.ReturnError {
+		return errors.New("failed to save profile")
+	}
+	profile.ID = fmt.Sprintf("%d", len(f.Profiles)+1)
+	f.Profiles = append(f.Profiles, profile)
+	return nil
+}
+
+func (f *FakeProfileStore) LoadProfile(ctx context.Context, tenantID, id string) (*Profile, error) {
+	for _, profile := range f.Profiles {
+		if profile.ID == id && tenantID == "1" {
+			return profile, nil
+		}
+	}
+	return nil, ErrProfileNotFound
+}
+
+func (f *FakeProfileStore) LoadProfiles(ctx context.Context, tenantID string) ([]*Profile, error) {
+	return f.Profiles, nil
+}
+
+func (f *FakeProfileStore) RemoveProfile(ctx context.Context, tenantID, id string) error {
+	f.Removed = append(f.Removed, id)
+	return nil
+}
+
+// FakeForLoadingAccountCurrency simulates looking up account currencies for testing. Its only
+// account, 12345, belongs to tenant 1.
+type FakeForLoadingAccountCurrency struct{}
+
+func (f *FakeForLoadingAccountCurrency) LoadAccountCurrency(ctx context.Context, tenantID, accountID string) (money.Currency, error) {
+	if accountID != "12345" || tenantID != "1" {
+		return "", ErrAccountNotFound
+	}
+	return "EUR", nil
+}
+
+// FakeForLoadingBalance simulates loading account balances for testing.
+type FakeForLoadingBalance struct {
+	Balance money.Money
+	AsOf    time.Time
+}
+
+func (f *FakeForLoadingBalance) LoadBalance(ctx context.Context, tenantID, accountID string, asOf time.Time) (money.Money, error) {
+	f.AsOf = asOf
+	return f.Balance, nil
+}
+
+// FakeForRecordingTransaction simulates recording transactions for testing. Entries
+// described as "REJECT" are rejected, as are external IDs recorded before.
+type FakeForRecordingTransaction struct {
+	Entries     []*Entry
+	ReturnError bool
+}
+
+func (f *FakeForRecordingTransaction) RecordTransaction(ctx context.Context, accountID string, entry *Entry) (string, error) {
+	if f.ReturnError {
+		return "", errors.New("failed to record transaction")
+	}
+	if entry.Description == "REJECT" {
+		return "", fmt.Errorf("%w: rejected", ErrInvalidEntry)
+	}
+	for _, recorded := range f.Entries {
+		if entry.ExternalID != "" && recorded.ExternalID == entry.ExternalID {
+			return "", ErrDuplicateEntry
+		}
+	}
+	f.Entries = append(f.Entries, entry)
+	return fmt.Sprintf("%d", len(f.Entries)), nil
+}
+
+func column(index int) *int {
+	return &index
+}
+
+// signedProfile reads "date;description;amount" with a header and decimal commas
+func signedProfile() *Profile {
+	return &Profile{ID: "1", Name: "Sparkasse", Delimiter: ";", SkipRows: 1, DateColumn: 0, DateFormat: "DD.MM.YYYY",
+		DescriptionColumn: column(1), AmountColumn: column(2), DecimalSeparator: ","}
+}
+
+// splitProfile reads "date,description,debit,credit" without a header
+func splitProfile() *Profile {
+	return &Profile{ID: "2", Name: "Split", Delimiter: ",", DateColumn: 0, DateFormat: "YYYY-MM-DD",
+		DescriptionColumn: column(1), DebitColumn: column(2), CreditColumn: column(3), DecimalSeparator: "."}
+}
+
+// tenantContext returns a context acting as a user of the given tenant
+func tenantContext(tenantID string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice", UserID: "5", TenantID: tenantID})
+}
+
+func newTestService(recorder *FakeForRecordingTransaction) *ImportService {
+	store := &FakeProfileStore{Profiles: []*Profile{signedProfile(), splitProfile()}}
+	balances := &FakeForLoadingBalance{Balance: money.MustParse("0.00", "EUR")}
+	return NewImportService(store, store, store, &FakeForLoadingAccountCurrency{}, balances, recorder)
+}
+
+// Test creating a profile fills in defaults
+func TestImportServiceCreateProfile(t *testing.T) {
+	store := &FakeProfileStore{}
+	service := NewImportService(store, store, store, &FakeForLoadingAccountCurrency{}, &FakeForLoadingBalance{}, &FakeForRecordingTransaction{})
+
+	profile, err := service.CreateProfile(tenantContext("1"), &Profile{Name: " Bank ", AmountColumn: column(2)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", profile.ID)
+	assert.Equal(t, "Bank", profile.Name)
+	assert.Equal(t, ",", profile.Delimiter)
+	assert.Equal(t, ".", profile.DecimalSeparator)
+	assert.Equal(t, "YYYY-MM-DD", profile.DateFormat)
+}
+
+// Test unusable profiles are rejected
+func TestImportServiceCreateProfile_Invalid(t *testing.T) {
+	tests := map[string]*Profile{
+		"no name":              {AmountColumn: column(2)},
+		"long delimiter":       {Name: "Bank", Delimiter: ";;", AmountColumn: column(2)},
+		"quote delimiter":      {Name: "Bank", Delimiter: `"`, AmountColumn: column(2)},
+		"decimal separator":    {Name: "Bank", DecimalSeparator: "'", AmountColumn: column(2)},
+		"date without day":     {Name: "Bank", DateFormat: "MM/YYYY", AmountColumn: column(2)},
+		"negative column":      {Name: "Bank", AmountColumn: column(-1)},
+		"no amount":            {Name: "Bank"},
+		"amount and debit":     {Name: "Bank", AmountColumn: column(2), DebitColumn: column(3)},
+		"debit without credit": {Name: "Bank", DebitColumn: column(3)},
+	}
+
+	for name, profile := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := &FakeProfileStore{}
+			service := NewImportService(store, store, store, &FakeForLoadingAccountCurrency{}, &FakeForLoadingBalance{}, &FakeForRecordingTransaction{})
+
+			_, err := service.CreateProfile(tenantContext("1"), profile)
+
+			assert.ErrorIs(t, err, ErrInvalidProfile)
+			assert.Empty(t, store.Profiles)
+		})
+	}
+}
+
+// Test importing a signed-amount statement with a header row and decimal commas
+func TestImportServiceImportCSV_Signed(t *testing.T) {
+	recorder := &FakeForRecordingTransaction{}
+	service := newTestService(recorder)
+
+	data := "Datum;Text;Betrag\n15.01.2024;Lidl;-1.234,56\n16.01.2024;\"Salary; January\";2500,00\n"
+	report, err := service.ImportCSV(tenantContext("1"), "12345", "1", strings.NewReader(data))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, []RowResult{{Line: 2, Status: RowImported, TransactionID: "1"}, {Line: 3, Status: RowImported, TransactionID: "2"}}, report.Rows)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), recorder.Entries[0].Date)
+	assert.Equal(t, money.MustParse("-1234.56", "EUR"), recorder.Entries[0].Amount)
+	assert.Equal(t, "Salary; January", recorder.Entries[1].Description)
+	assert.Equal(t, money.MustParse("2500.00", "EUR"), recorder.Entries[1].Amount)
+}
+
+// Test debit and credit columns give outgoing and incoming amounts, and that
+// bad lines are reported without stopping the import
+func TestImportServiceImportCSV_SplitColumns(t *testing.T) {
+	recorder := &FakeForRecordingTransaction{}
+	service := newTestService(recorder)
+
+	data := strings.Join([]string{
+		"2024-01-15,Coffee,3.50,",
+		"2024-01-16,Refund,,-12.00",
+		",,,",
+		"2024-01-17,Nothing,0.00,",
+		"17/01/2024,Bad date,1.00,",
+		"2024-01-18,Both,1.00,2.00",
+		"2024-01-18,Short",
+		"2024-01-19,REJECT,1.00,",
+		"2024-01-20,Rent,\"1,000.00\",",
+	}, "\n")
+	report, err := service.ImportCSV(tenantContext("1"), "12345", "2", strings.NewReader(data))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Imported)
+	assert.Equal(t, 2, report.Skipped)
+	assert.Equal(t, 4, report.Failed)
+	assert.Equal(t, money.MustParse("-3.50", "EUR"), recorder.Entries[0].Amount)
+	assert.Equal(t, money.MustParse("12.00", "EUR"), recorder.Entries[1].Amount)
+	assert.Equal(t, money.MustParse("-1000.00", "EUR"), recorder.Entries[2].Amount)
+	assert.Equal(t, RowResult{Line: 3, Status: RowSkipped, Message: "blank line"}, report.Rows[2])
+	assert.Equal(t, RowResult{Line: 4, Status: RowSkipped, Message: "zero amount"}, report.Rows[3])
+	assert.Equal(t, RowResult{Line: 5, Status: RowFailed, Message: `invalid date "17/01/2024", expected YYYY-MM-DD`}, report.Rows[4])
+	assert.Equal(t, RowFailed, report.Rows[5].Status)
+	assert.Equal(t, RowResult{Line: 7, Status: RowFailed, Message: "missing column 2"}, report.Rows[6])
+	assert.Equal(t, RowResult{Line: 8, Status: RowFailed, Message: "invalid entry: rejected"}, report.Rows[7])
+}
+
+// Test unknown profiles and accounts stop the import before it starts
+func TestImportServiceImportCSV_NotFound(t *testing.T) {
+	service := newTestService(&FakeForRecordingTransaction{})
+
+	_, err := service.ImportCSV(tenantContext("1"), "12345", "99", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrProfileNotFound)
+
+	_, err = service.ImportCSV(tenantContext("1"), "99", "1", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+
+	_, err = service.ImportCSV(tenantContext("2"), "12345", "1", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrProfileNotFound, "Profiles of another tenant cannot be used")
+}
+
+// Test a failure to record a transaction aborts the import
+func TestImportServiceImportCSV_RecordError(t *testing.T) {
+	service := newTestService(&FakeForRecordingTransaction{ReturnError: true})
+
+	report, err := service.ImportCSV(tenantContext("1"), "12345", "2", strings.NewReader("2024-01-15,Coffee,3.50,\n"))
+
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
@@ -0,0 +1,121 @@
+package imports
+
+import (
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// Profile maps the columns of a bank's CSV statement onto transactions.
+// Column indices count from zero. Amounts come either from a single signed
+// AmountColumn or from separate DebitColumn and CreditColumn, whose values
+// are taken as money going out and coming in whatever their sign.
+// DateFormat is written with the tokens YYYY, YY, MM and DD, such as
+// "DD/MM/YYYY".
+type Profile struct {
+	ID                string
+	Name              string
+	Delimiter         string
+	SkipRows          int
+	DateColumn        int
+	DateFormat        string
+	DescriptionColumn *int
+	AmountColumn      *int
+	DebitColumn       *int
+	CreditColumn      *int
+	DecimalSeparator  string
+}
+
+// Format is the file format of a bank statement. FormatCAMT053 is the ISO
+// 20022 bank to customer statement and FormatMT940 the SWIFT customer
+// statement message.
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatOFX     Format = "ofx"
+	FormatQIF     Format = "qif"
+	FormatCAMT053 Format = "camt053"
+	FormatMT940   Format = "mt940"
+)
+
+// Entry is a single line of a bank statement, ready to be recorded as a
+// transaction dated Date and posted on PostedDate, or on Date if PostedDate
+// is zero. ExternalID identifies the line so that it is only ever imported
+// once; it is empty for CSV statements, which carry no such identifier.
+// BankType is the bank's type for the line in OFX's TRNTYPE terms, such as
+// "INT" or "FEE", into which other formats translate their own codes; it is
+// empty when the format has none. Reversal marks a line that reverses an
+// earlier one, such as a returned direct debit.
+type Entry struct {
+	Line        int
+	Date        time.Time
+	PostedDate  time.Time
+	Amount      money.Money
+	Description string
+	ExternalID  string
+	BankType    string
+	Reversal    bool
+}
+
+// Statement is an OFX, QIF, camt.053 or MT940 file read into entries. A file
+// can hold several statements of the same account, whose entries are
+// gathered together. Results holds the
+// lines that were skipped or could not be read. LedgerBalance is the closing
+// balance the bank reports as of LedgerBalanceDate, when the file has one;
+// for several statements it is the latest of their closing balances.
+type Statement struct {
+	Currency          money.Currency
+	Entries           []*Entry
+	Results           []RowResult
+	LedgerBalance     *money.Money
+	LedgerBalanceDate *time.Time
+}
+
+// RowStatus is the outcome of importing a single line.
+type RowStatus string
+
+const (
+	RowImported RowStatus = "imported"
+	RowSkipped  RowStatus = "skipped"
+	RowFailed   RowStatus = "failed"
+)
+
+// RowResult reports what happened to a single line of an imported file.
+// TransactionID is set for imported lines and Message explains skipped and
+// failed ones.
+type RowResult struct {
+	Line          int
+	Status        RowStatus
+	TransactionID string
+	Message       string
+}
+
+// Report summarises an import line by line. ProfileID is only set for CSV
+// imports. When the statement reports a ledger balance, ComputedBalance is
+// the account's own balance as of the same date once the import is done, so
+// the two can be compared.
+type Report struct {
+	AccountID         string
+	Format            Format
+	ProfileID         string
+	Imported          int
+	Skipped           int
+	Failed            int
+	Rows              []RowResult
+	LedgerBalance     *money.Money
+	LedgerBalanceDate *time.Time
+	ComputedBalance   *money.Money
+}
+
+// add records the outcome of a line and counts it
+func (r *Report) add(result RowResult) {
+	switch result.Status {
+	case RowImported:
+		r.Imported++
+	case RowSkipped:
+		r.Skipped++
+	case RowFailed:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, result)
+}
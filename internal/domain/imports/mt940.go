@@ -0,0 +1,259 @@
+package imports
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"spend-api/internal/domain/money"
+	"strings"
+	"time"
+)
+
+// mt940Line matches the statement line field :61:, which is value date,
+// optional booking date, debit/credit mark, optional funds code, amount,
+// transaction type, customer reference and optional bank reference
+var mt940Line = regexp.MustCompile(`^(\d{6})(\d{4})?(R?[CD])([A-Z])?(\d+,\d*)([NFS][A-Z0-9]{3})([^/\n]{0,16})(?://([^\n]{0,16}))?`)
+
+// mt940Balance matches the balance fields :60F:, :62F: and their
+// intermediate variants, which are debit/credit mark, date, currency and amount
+var mt940Balance = regexp.MustCompile(`^([CD])(\d{6})([A-Z]{3})(\d+,\d*)$`)
+
+// mt940BankTypes translates SWIFT transaction type codes into OFX transaction types
+var mt940BankTypes = map[string]string{
+	"CHG": "FEE",
+	"COM": "FEE",
+	"INT": "INT",
+	"DIV": "DIV",
+}
+
+// mt940Field is a tagged field of an MT940 message with the line it starts on
+type mt940Field struct {
+	line  int
+	tag   string
+	value string
+}
+
+// mt940Pending is a statement line waiting for the :86: field that describes it
+type mt940Pending struct {
+	field mt940Field
+	info  string
+}
+
+// parseMT940 reads the statement lines and final closing balance of every
+// message in a SWIFT MT940 file. Lines are booked on their entry date and
+// dated on their value date, take their sign from the debit/credit mark and
+// are described by the :86: field that follows them. MT940 has no unique
+// line ID, so each line gets a synthetic external ID.
+func parseMT940(data io.Reader, currency money.Currency) (*Statement, error) {
+	fields, err := readMT940Fields(data)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &Statement{Currency: currency}
+	ids := syntheticIDs{}
+	var seen accounts
+	var account string
+	var pending *mt940Pending
+	hasStatement := false
+	flush := func() {
+		if pending != nil {
+			statement.addMT940Line(account, pending, ids)
+			pending = nil
+		}
+	}
+
+	for _, field := range fields {
+		switch field.tag {
+		case "20":
+			flush()
+			hasStatement = true
+		case "25":
+			account = field.value
+			if err := seen.check(account); err != nil {
+				return nil, err
+			}
+		case "60F", "60M":
+			_, balanceCurrency, _, err := readMT940Balance(field.value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: opening balance on line %d: %s", ErrInvalidStatement, field.line, err.Error())
+			}
+			statement.Currency = balanceCurrency
+		case "61":
+			flush()
+			pending = &mt940Pending{field: field}
+		case "86":
+			if pending != nil {
+				pending.info = field.value
+			}
+			flush()
+		case "62F":
+			flush()
+			date, balanceCurrency, amount, err := readMT940Balance(field.value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: closing balance on line %d: %s", ErrInvalidStatement, field.line, err.Error())
+			}
+			balance, err := money.Parse(amount, balanceCurrency)
+			if err != nil {
+				return nil, fmt.Errorf("%w: closing balance on line %d: invalid amount %q", ErrInvalidStatement, field.line, amount)
+			}
+			statement.closeWith(balance, date)
+		default:
+			flush()
+		}
+	}
+	flush()
+	if !hasStatement {
+		return nil, fmt.Errorf("%w: no MT940 statement found", ErrInvalidStatement)
+	}
+	return statement, nil
+}
+
+// readMT940Fields splits an MT940 file into its tagged fields, joining
+// continuation lines and dropping the SWIFT envelope around each message
+func readMT940Fields(data io.Reader) ([]mt940Field, error) {
+	scanner := bufio.NewScanner(data)
+	var fields []mt940Field
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r ")
+		if line == 1 {
+			text = strings.TrimPrefix(text, byteOrderMark)
+		}
+		if _, body, ok := strings.Cut(text, "{4:"); ok {
+			text = body
+		}
+		if text == "" || text == "-" || text == "-}" || strings.HasPrefix(text, "{") {
+			continue
+		}
+		if strings.HasPrefix(text, ":") {
+			if tag, value, ok := strings.Cut(text[1:], ":"); ok && len(tag) <= 3 {
+				fields = append(fields, mt940Field{line: line, tag: tag, value: value})
+				continue
+			}
+		}
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("%w: unexpected text on line %d", ErrInvalidStatement, line)
+		}
+		fields[len(fields)-1].value += "\n" + text
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+	return fields, nil
+}
+
+// addMT940Line turns a :61: statement line and its :86: description into an entry
+func (s *Statement) addMT940Line(account string, pending *mt940Pending, ids syntheticIDs) {
+	line := pending.field.line
+	match := mt940Line.FindStringSubmatch(pending.field.value)
+	if match == nil {
+		s.fail(line, fmt.Errorf("invalid statement line %q", firstLine(pending.field.value)))
+		return
+	}
+	value, err := time.Parse("060102", match[1])
+	if err != nil {
+		s.fail(line, fmt.Errorf("invalid value date %q", match[1]))
+		return
+	}
+	booking := value
+	if match[2] != "" {
+		if booking, err = mt940BookingDate(value, match[2]); err != nil {
+			s.fail(line, err)
+			return
+		}
+	}
+	amount, err := money.Parse(strings.Replace(strings.TrimSuffix(match[5], ","), ",", ".", 1), s.Currency)
+	if err != nil {
+		s.fail(line, fmt.Errorf("invalid amount %q", match[5]))
+		return
+	}
+	// C and RD (a reversed debit) bring money in, D and RC take it out
+	mark := match[3]
+	if mark == "D" || mark == "RC" {
+		amount = amount.Neg()
+	}
+	if amount.IsZero() {
+		s.skip(line, "zero amount")
+		return
+	}
+
+	description := describeMT940(pending.info)
+	date, posted := entryDates(value, booking)
+	s.Entries = append(s.Entries, &Entry{
+		Line:        line,
+		Date:        date,
+		PostedDate:  posted,
+		Amount:      amount,
+		Description: description,
+		ExternalID:  ids.next("mt940", account, pending.field.value, pending.info),
+		BankType:    mt940BankTypes[match[6][1:]],
+		Reversal:    strings.HasPrefix(mark, "R"),
+	})
+}
+
+// mt940BookingDate reads a booking date given as MMDD, taking the year that
+// puts it closest to the value date
+func mt940BookingDate(value time.Time, monthDay string) (time.Time, error) {
+	booking, err := time.Parse("20060102", fmt.Sprintf("%04d%s", value.Year(), monthDay))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid booking date %q", monthDay)
+	}
+	switch {
+	case booking.Sub(value) > 180*24*time.Hour:
+		booking = booking.AddDate(-1, 0, 0)
+	case value.Sub(booking) > 180*24*time.Hour:
+		booking = booking.AddDate(1, 0, 0)
+	}
+	return booking, nil
+}
+
+// readMT940Balance reads a balance field's date, currency and signed amount
+func readMT940Balance(value string) (time.Time, money.Currency, string, error) {
+	match := mt940Balance.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return time.Time{}, "", "", fmt.Errorf("invalid balance %q", value)
+	}
+	date, err := time.Parse("060102", match[2])
+	if err != nil {
+		return time.Time{}, "", "", fmt.Errorf("invalid date %q", match[2])
+	}
+	currency, err := money.ParseCurrency(match[3])
+	if err != nil {
+		return time.Time{}, "", "", fmt.Errorf("unknown currency %q", match[3])
+	}
+	amount := strings.Replace(strings.TrimSuffix(match[4], ","), ",", ".", 1)
+	if match[1] == "D" {
+		amount = "-" + amount
+	}
+	return date, currency, amount, nil
+}
+
+// describeMT940 turns a :86: field into a description. Structured fields,
+// which start with a three digit transaction code followed by ?nn subfields,
+// are described by the counterparty name (?32, ?33) and the remittance
+// information (?20 to ?29); free text is used as it is.
+func describeMT940(info string) string {
+	if len(info) < 4 || info[3] != '?' {
+		return strings.Join(strings.Fields(info), " ")
+	}
+	var name, remittance strings.Builder
+	for _, subfield := range strings.Split(strings.ReplaceAll(info, "\n", ""), "?")[1:] {
+		if len(subfield) < 2 {
+			continue
+		}
+		switch code, text := subfield[:2], subfield[2:]; {
+		case code == "32" || code == "33":
+			name.WriteString(text)
+		case code >= "20" && code <= "29":
+			remittance.WriteString(text)
+		}
+	}
+	return describe(strings.TrimSpace(name.String()), strings.TrimSpace(remittance.String()))
+}
+
+// firstLine returns the first line of a multi-line field
+func firstLine(value string) string {
+	first, _, _ := strings.Cut(value, "\n")
+	return first
+}
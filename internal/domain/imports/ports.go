@@ -0,0 +1,73 @@
+package imports
+
+import (
+	"context"
+	"io"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// ForImportingCSV defines the port for importing a CSV bank statement into an account using a
+// saved profile.
+type ForImportingCSV interface {
+	ImportCSV(ctx context.Context, accountID, profileID string, data io.Reader) (*Report, error)
+}
+
+// ForImportingStatement defines the port for importing an OFX, QFX, QIF, camt.053 or MT940 bank
+// statement into an account. An empty format is detected from the file's contents.
+type ForImportingStatement interface {
+	ImportStatement(ctx context.Context, accountID string, format Format, data io.Reader) (*Report, error)
+}
+
+// ForCreatingProfile defines the port for creating an import profile.
+type ForCreatingProfile interface {
+	CreateProfile(ctx context.Context, profile *Profile) (*Profile, error)
+}
+
+// ForListingProfiles defines the port for listing all import profiles.
+type ForListingProfiles interface {
+	ListProfiles(ctx context.Context) ([]*Profile, error)
+}
+
+// ForDeletingProfile defines the port for deleting an import profile.
+type ForDeletingProfile interface {
+	DeleteProfile(ctx context.Context, id string) error
+}
+
+// ForSavingProfile defines the port for saving an import profile of a tenant to persistence
+type ForSavingProfile interface {
+	SaveProfile(ctx context.Context, tenantID string, profile *Profile) error
+}
+
+// ForLoadingProfiles defines the port for loading import profiles of a tenant from persistence.
+// Profiles of other tenants are not found.
+type ForLoadingProfiles interface {
+	LoadProfile(ctx context.Context, tenantID, id string) (*Profile, error)
+	LoadProfiles(ctx context.Context, tenantID string) ([]*Profile, error)
+}
+
+// ForRemovingProfile defines the port for removing an import profile of a tenant from persistence
+type ForRemovingProfile interface {
+	RemoveProfile(ctx context.Context, tenantID, id string) error
+}
+
+// ForLoadingAccountCurrency defines the port for looking up the currency a tenant's account is
+// held in. Accounts of other tenants are not found.
+type ForLoadingAccountCurrency interface {
+	LoadAccountCurrency(ctx context.Context, tenantID, accountID string) (money.Currency, error)
+}
+
+// ForLoadingBalance defines the port for loading a tenant's account balance as of a date,
+// counting every transaction dated on or before it.
+type ForLoadingBalance interface {
+	LoadBalance(ctx context.Context, tenantID, accountID string, asOf time.Time) (money.Money, error)
+}
+
+// ForRecordingTransaction defines the port for recording a statement entry as a transaction
+// of the account, returning the new transaction's ID. Entries that fail the usual transaction
+// validation are rejected with an error matching ErrInvalidEntry, and entries whose ExternalID
+// was already imported with ErrDuplicateEntry. The transaction is recorded for the tenant of
+// the principal in ctx.
+type ForRecordingTransaction interface {
+	RecordTransaction(ctx context.Context, accountID string, entry *Entry) (string, error)
+}
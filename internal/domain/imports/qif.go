@@ -0,0 +1,137 @@
+package imports
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"spend-api/internal/domain/money"
+	"strings"
+	"time"
+)
+
+// qifAccountTypes are the QIF sections that hold bank style transactions
+var qifAccountTypes = map[string]bool{"bank": true, "cash": true, "ccard": true, "oth a": true, "oth l": true}
+
+// qifDateLayouts are the date layouts tried in turn. Slashed QIF dates are
+// month first, as Quicken writes them; dotted ones are day first.
+var qifDateLayouts = []string{"1/2/2006", "1/2/06", "2006-01-02", "2.1.2006", "2.1.06"}
+
+// qifRecord collects the fields of a single QIF transaction
+type qifRecord struct {
+	line                              int
+	date, amount, payee, memo, number string
+}
+
+// parseQIF reads the transactions of the bank, cash and credit card sections
+// of a QIF file, in the given currency since QIF does not name one. QIF
+// carries neither transaction IDs nor a balance, so each entry gets a
+// synthetic external ID and the statement has no ledger balance.
+func parseQIF(data io.Reader, currency money.Currency) (*Statement, error) {
+	statement := &Statement{Currency: currency}
+	ids := syntheticIDs{}
+	scanner := bufio.NewScanner(data)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	hasTransactions, inSection := false, false
+	var current *qifRecord
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if line == 1 {
+			text = strings.TrimPrefix(text, byteOrderMark)
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if text[0] == '!' {
+			header := strings.ToLower(strings.TrimSpace(text))
+			if strings.HasPrefix(header, "!option") || strings.HasPrefix(header, "!clear") {
+				continue
+			}
+			sectionType, isType := strings.CutPrefix(header, "!type:")
+			inSection = isType && qifAccountTypes[strings.TrimSpace(sectionType)]
+			hasTransactions = hasTransactions || inSection
+			current = nil
+			continue
+		}
+		if !inSection {
+			continue
+		}
+
+		if current == nil {
+			current = &qifRecord{line: line}
+		}
+		value := strings.TrimSpace(text[1:])
+		switch text[0] {
+		case 'D':
+			current.date = value
+		case 'T':
+			current.amount = value
+		case 'U':
+			if current.amount == "" {
+				current.amount = value
+			}
+		case 'P':
+			current.payee = value
+		case 'M':
+			current.memo = value
+		case 'N':
+			current.number = value
+		case '^':
+			statement.addQIFRecord(current, ids)
+			current = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+	if !hasTransactions {
+		return nil, fmt.Errorf("%w: no bank, cash or credit card section found", ErrInvalidStatement)
+	}
+	if current != nil {
+		statement.addQIFRecord(current, ids)
+	}
+	return statement, nil
+}
+
+// addQIFRecord turns a QIF record into an entry described by its payee and memo
+func (s *Statement) addQIFRecord(record *qifRecord, ids syntheticIDs) {
+	date, err := parseQIFDate(record.date)
+	if err != nil {
+		s.fail(record.line, err)
+		return
+	}
+	if record.amount == "" {
+		s.fail(record.line, fmt.Errorf("missing amount"))
+		return
+	}
+	normalized := strings.NewReplacer(",", "", " ", "").Replace(record.amount)
+	amount, err := money.Parse(normalized, s.Currency)
+	if err != nil {
+		s.fail(record.line, fmt.Errorf("invalid amount %q", record.amount))
+		return
+	}
+	if amount.IsZero() {
+		s.skip(record.line, "zero amount")
+		return
+	}
+
+	s.Entries = append(s.Entries, &Entry{
+		Line:        record.line,
+		Date:        date,
+		Amount:      amount,
+		Description: describe(record.payee, record.memo),
+		ExternalID:  ids.next("qif", date.Format(time.DateOnly), amount.String(), record.payee, record.memo, record.number),
+	})
+}
+
+// parseQIFDate reads a QIF date such as "01/15/2024", Quicken's "1/15'24" or
+// "2024-01-15"
+func parseQIFDate(value string) (time.Time, error) {
+	normalized := strings.NewReplacer("'", "/", " ", "").Replace(value)
+	for _, layout := range qifDateLayouts {
+		if date, err := time.Parse(layout, normalized); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected MM/DD/YYYY", value)
+}
@@ -0,0 +1,217 @@
+package imports
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"spend-api/internal/domain/auth"
+	"unicode/utf8"
+)
+
+// ImportService provides the core logic for importing bank statements and
+// managing the profiles that describe them.
+type ImportService struct {
+	profilePersistence ForSavingProfile
+	profileLoader      ForLoadingProfiles
+	profileRemover     ForRemovingProfile
+	accountCurrencies  ForLoadingAccountCurrency
+	balances           ForLoadingBalance
+	transactions       ForRecordingTransaction
+}
+
+// NewImportService creates a new ImportService.
+func NewImportService(persistence ForSavingProfile, loader ForLoadingProfiles, remover ForRemovingProfile, accountCurrencies ForLoadingAccountCurrency, balances ForLoadingBalance, transactions ForRecordingTransaction) *ImportService {
+	return &ImportService{
+		profilePersistence: persistence,
+		profileLoader:      loader,
+		profileRemover:     remover,
+		accountCurrencies:  accountCurrencies,
+		balances:           balances,
+		transactions:       transactions,
+	}
+}
+
+// CreateProfile validates the profile, fills in its defaults and saves it
+// using persistence for the tenant of the principal in ctx.
+func (s *ImportService) CreateProfile(ctx context.Context, profile *Profile) (*Profile, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	profile.ID = ""
+	if err := profile.normalize(); err != nil {
+		return nil, err
+	}
+
+	// Save the profile using the persistence port
+	if err := s.profilePersistence.SaveProfile(ctx, tenantID, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// ListProfiles retrieves all import profiles of the tenant.
+func (s *ImportService) ListProfiles(ctx context.Context) ([]*Profile, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.profileLoader.LoadProfiles(ctx, tenantID)
+}
+
+// DeleteProfile deletes the import profile with the given ID.
+func (s *ImportService) DeleteProfile(ctx context.Context, id string) error {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+	return s.profileRemover.RemoveProfile(ctx, tenantID, id)
+}
+
+// ImportCSV reads a CSV statement with the given profile and records each
+// line as a posted transaction of the account. Lines are imported one by
+// one: a line that cannot be read or is rejected as a transaction is
+// reported as failed without stopping the others, and lines with nothing to
+// import are skipped.
+func (s *ImportService) ImportCSV(ctx context.Context, accountID, profileID string, data io.Reader) (*Report, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.profileLoader.LoadProfile(ctx, tenantID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	currency, err := s.accountCurrencies.LoadAccountCurrency(ctx, tenantID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(data)
+	reader.Comma, _ = utf8.DecodeRuneInString(profile.Delimiter)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	report := &Report{AccountID: accountID, Format: FormatCSV, ProfileID: profileID, Rows: []RowResult{}}
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return report, nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			report.add(RowResult{Line: parseErr.StartLine, Status: RowFailed, Message: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read statement: %w", err)
+		}
+		if row < profile.SkipRows {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		entry, skipped, err := profile.parseRecord(record, currency)
+		if err != nil {
+			report.add(RowResult{Line: line, Status: RowFailed, Message: err.Error()})
+			continue
+		}
+		if entry == nil {
+			report.add(RowResult{Line: line, Status: RowSkipped, Message: skipped})
+			continue
+		}
+
+		entry.Line = line
+		result, err := s.record(ctx, accountID, entry)
+		if err != nil {
+			return nil, err
+		}
+		report.add(result)
+	}
+}
+
+// ImportStatement reads an OFX, QFX, QIF, camt.053 or MT940 statement and
+// records each of its lines as a posted transaction of the account. Like ImportCSV, lines
+// that cannot be read or are rejected are reported without stopping the
+// others. Lines are identified by the bank's transaction ID, so lines that
+// were imported before are skipped and a statement can safely be imported
+// again. When the statement reports a ledger balance, the account's own
+// balance as of the same date is reported next to it.
+func (s *ImportService) ImportStatement(ctx context.Context, accountID string, format Format, data io.Reader) (*Report, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		if format, data, err = detectFormat(data); err != nil {
+			return nil, err
+		}
+	}
+	currency, err := s.accountCurrencies.LoadAccountCurrency(ctx, tenantID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var statement *Statement
+	switch format {
+	case FormatOFX:
+		statement, err = parseOFX(data, currency)
+	case FormatQIF:
+		statement, err = parseQIF(data, currency)
+	case FormatCAMT053:
+		statement, err = parseCAMT053(data, currency)
+	case FormatMT940:
+		statement, err = parseMT940(data, currency)
+	default:
+		err = fmt.Errorf("%w: unsupported format %q", ErrInvalidStatement, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if statement.Currency != currency {
+		return nil, fmt.Errorf("%w: statement is in %s but account %s is held in %s", ErrInvalidStatement, statement.Currency, accountID, currency)
+	}
+
+	results := append([]RowResult{}, statement.Results...)
+	for _, entry := range statement.Entries {
+		result, err := s.record(ctx, accountID, entry)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Line < results[j].Line })
+
+	report := &Report{AccountID: accountID, Format: format, Rows: []RowResult{}}
+	for _, result := range results {
+		report.add(result)
+	}
+	if statement.LedgerBalance != nil {
+		balance, err := s.balances.LoadBalance(ctx, tenantID, accountID, *statement.LedgerBalanceDate)
+		if err != nil {
+			return nil, err
+		}
+		report.LedgerBalance, report.LedgerBalanceDate, report.ComputedBalance = statement.LedgerBalance, statement.LedgerBalanceDate, &balance
+	}
+	return report, nil
+}
+
+// record records a statement entry as a transaction and reports the outcome
+// of its line. Rejected and already imported entries are reported rather than
+// returned as errors.
+func (s *ImportService) record(ctx context.Context, accountID string, entry *Entry) (RowResult, error) {
+	id, err := s.transactions.RecordTransaction(ctx, accountID, entry)
+	if errors.Is(err, ErrDuplicateEntry) {
+		return RowResult{Line: entry.Line, Status: RowSkipped, Message: "already imported"}, nil
+	}
+	if errors.Is(err, ErrInvalidEntry) {
+		return RowResult{Line: entry.Line, Status: RowFailed, Message: err.Error()}, nil
+	}
+	if err != nil {
+		return RowResult{}, err
+	}
+	return RowResult{Line: entry.Line, Status: RowImported, TransactionID: id}, nil
+}
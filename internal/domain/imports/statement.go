@@ -0,0 +1,115 @@
+package imports
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"spend-api/internal/domain/money"
+	"strings"
+	"time"
+)
+
+// byteOrderMark is stripped from the start of statement files
+const byteOrderMark = "\ufeff"
+
+// detectFormat guesses a statement's format from its first bytes: OFX files
+// start with an OFX header or element, camt.053 files are XML with a
+// Document root, QIF files start with a "!Type:" style header and MT940
+// files with a ":20:" field or a SWIFT block. The returned reader still
+// yields the whole file.
+func detectFormat(data io.Reader) (Format, io.Reader, error) {
+	reader := bufio.NewReader(data)
+	head, err := reader.Peek(1024)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return "", nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+	start := strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(string(head), byteOrderMark)))
+	switch {
+	case strings.HasPrefix(start, "OFXHEADER") || strings.HasPrefix(start, "<") && strings.Contains(start, "<OFX"):
+		return FormatOFX, reader, nil
+	case strings.HasPrefix(start, "<") && strings.Contains(start, "DOCUMENT"):
+		return FormatCAMT053, reader, nil
+	case strings.HasPrefix(start, "!"):
+		return FormatQIF, reader, nil
+	case strings.HasPrefix(start, ":20:") || strings.HasPrefix(start, "{1:"):
+		return FormatMT940, reader, nil
+	}
+	return "", nil, fmt.Errorf("%w: not an OFX, QFX, QIF, camt.053 or MT940 file", ErrInvalidStatement)
+}
+
+// entryDates gives the transaction and posted dates of a line with the
+// given value and booking dates. The value date is when the money moved and
+// becomes the transaction date, unless it falls after the booking date, as
+// it can for cheques, in which case the line is dated on its booking.
+func entryDates(value, booking time.Time) (time.Time, time.Time) {
+	if value.IsZero() || value.After(booking) {
+		return booking, booking
+	}
+	return value, booking
+}
+
+// accounts tracks the account a file's statements belong to, since the lines
+// of every statement in a file are imported into the same account
+type accounts struct {
+	id string
+}
+
+// check records the account of the next statement in the file, failing if
+// it differs from the account of those before it
+func (a *accounts) check(id string) error {
+	id = strings.ReplaceAll(id, " ", "")
+	if a.id != "" && id != "" && a.id != id {
+		return fmt.Errorf("%w: file holds statements for accounts %s and %s", ErrInvalidStatement, a.id, id)
+	}
+	if id != "" {
+		a.id = id
+	}
+	return nil
+}
+
+// closeWith takes a closing balance as the statement's ledger balance unless
+// a later one has been seen already
+func (s *Statement) closeWith(balance money.Money, date time.Time) {
+	if s.LedgerBalanceDate != nil && s.LedgerBalanceDate.After(date) {
+		return
+	}
+	s.LedgerBalance, s.LedgerBalanceDate = &balance, &date
+}
+
+// skip records a line that holds nothing to import
+func (s *Statement) skip(line int, reason string) {
+	s.Results = append(s.Results, RowResult{Line: line, Status: RowSkipped, Message: reason})
+}
+
+// fail records a line that could not be read
+func (s *Statement) fail(line int, err error) {
+	s.Results = append(s.Results, RowResult{Line: line, Status: RowFailed, Message: err.Error()})
+}
+
+// describe joins the non-empty parts of a line's description, dropping a
+// part that repeats the one before it
+func describe(parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" && (len(kept) == 0 || kept[len(kept)-1] != part) {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, " - ")
+}
+
+// syntheticIDs hands out stable external IDs for lines the bank did not
+// identify. The ID hashes the line's details, numbered by how often the same
+// details have occurred so far, so re-reading the same file gives the same
+// IDs while two identical lines on one day stay distinct.
+type syntheticIDs map[string]int
+
+// next returns the ID for the next line with the given details
+func (ids syntheticIDs) next(prefix string, details ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(details, "\x1f")))
+	key := hex.EncodeToString(sum[:8])
+	ids[key]++
+	return fmt.Sprintf("%s-%s-%d", prefix, key, ids[key])
+}
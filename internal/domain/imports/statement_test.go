@@ -0,0 +1,366 @@
+package imports
+
+import (
+	"spend-api/internal/domain/money"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sgmlStatement is an OFX 1.x statement, whose leaf elements have no end tags
+const sgmlStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+CHARSET:1252
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20240131</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><TRNUID>1
+<STMTRS>
+<CURDEF>EUR
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>POS
+<DTPOSTED>20240115120000.000[-5:EST]
+<TRNAMT>-4.50
+<FITID>2024011501
+<NAME>Coffee &amp; Cake
+<MEMO>Card 1234
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>INT
+<DTPOSTED>20240131
+<TRNAMT>1,25
+<FITID>2024013101
+<NAME>Interest
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>2024
+<TRNAMT>-1.00
+<FITID>2024013102
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>996.75<DTASOF>20240131</LEDGERBAL>
+<AVAILBAL><BALAMT>900.00<DTASOF>20240131</AVAILBAL>
+</STMTRS>
+</STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+// xmlStatement is an OFX 2.x credit card statement
+const xmlStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <CCSTMTRS>
+        <CURDEF>EUR</CURDEF>
+        <BANKTRANLIST>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20240120</DTPOSTED>
+            <TRNAMT>-30.00</TRNAMT>
+            <FITID>CC-1</FITID>
+            <NAME>Bookshop</NAME>
+          </STMTTRN>
+          <STMTTRN>
+            <TRNTYPE>CREDIT</TRNTYPE>
+            <DTPOSTED>20240121</DTPOSTED>
+            <TRNAMT>0.00</TRNAMT>
+            <FITID>CC-2</FITID>
+          </STMTTRN>
+        </BANKTRANLIST>
+        <LEDGERBAL>
+          <BALAMT>-30.00</BALAMT>
+          <DTASOF>20240121</DTASOF>
+        </LEDGERBAL>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+// qifStatement is a QIF bank export with Quicken style dates
+const qifStatement = "!Type:Bank\r\n" +
+	"D01/15/2024\r\nT-4.50\r\nPCoffee shop\r\nMLatte\r\n^\r\n" +
+	"D1/15'24\r\nT-4.50\r\nPCoffee shop\r\nMLatte\r\n^\r\n" +
+	"D2024-01-16\r\nT1,250.00\r\nPEmployer\r\n^\r\n" +
+	"D15/01/2024\r\nT-1.00\r\n^\r\n"
+
+// Test an SGML statement is imported with the bank's IDs and compared with the ledger balance
+func TestImportServiceImportStatement_SGML(t *testing.T) {
+	recorder := &FakeForRecordingTransaction{}
+	store := &FakeProfileStore{}
+	balances := &FakeForLoadingBalance{Balance: money.MustParse("996.75", "EUR")}
+	service := NewImportService(store, store, store, &FakeForLoadingAccountCurrency{}, balances, recorder)
+
+	report, err := service.ImportStatement(tenantContext("1"), "12345", FormatOFX, strings.NewReader(sgmlStatement))
+
+	assert.NoError(t, err)
+	assert.Equal(t, FormatOFX, report.Format)
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, RowResult{Line: 12, Status: RowImported, TransactionID: "1"}, report.Rows[0])
+	assert.Equal(t, RowResult{Line: 27, Status: RowFailed, Message: `invalid date "2024", expected YYYYMMDD`}, report.Rows[2])
+	assert.Equal(t, &Entry{Line: 12, Date: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), Amount: money.MustParse("-4.50", "EUR"),
+		Description: "Coffee & Cake - Card 1234", ExternalID: "2024011501", BankType: "POS"}, recorder.Entries[0])
+	assert.Equal(t, money.MustParse("1.25", "EUR"), recorder.Entries[1].Amount)
+	assert.Equal(t, "INT", recorder.Entries[1].BankType)
+
+	ledgerDate := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, money.MustParse("996.75", "EUR"), *report.LedgerBalance)
+	assert.Equal(t, ledgerDate, *report.LedgerBalanceDate)
+	assert.Equal(t, money.MustParse("996.75", "EUR"), *report.ComputedBalance)
+	assert.Equal(t, ledgerDate, balances.AsOf)
+}
+
+// Test an XML statement is detected and that re-importing it skips what was imported before
+func TestImportServiceImportStatement_XML(t *testing.T) {
+	recorder := &FakeForRecordingTransaction{}
+	service := newTestService(recorder)
+
+	report, err := service.ImportStatement(tenantContext("1"), "12345", "", strings.NewReader(xmlStatement))
+	assert.NoError(t, err)
+	assert.Equal(t, FormatOFX, report.Format)
+	assert.Equal(t, []RowResult{{Line: 9, Status: RowImported, TransactionID: "1"}, {Line: 16, Status: RowSkipped, Message: "zero amount"}}, report.Rows)
+	assert.Equal(t, "Bookshop", recorder.Entries[0].Description)
+	assert.Equal(t, money.MustParse("-30.00", "EUR"), *report.LedgerBalance)
+
+	report, err = service.ImportStatement(tenantContext("1"), "12345", "", strings.NewReader(xmlStatement))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.Imported)
+	assert.Equal(t, RowResult{Line: 9, Status: RowSkipped, Message: "already imported"}, report.Rows[0])
+	assert.Len(t, recorder.Entries, 1)
+}
+
+// Test a QIF statement gets stable synthetic IDs that keep identical lines apart
+func TestImportServiceImportStatement_QIF(t *testing.T) {
+	recorder := &FakeForRecordingTransaction{}
+	service := newTestService(recorder)
+
+	report, err := service.ImportStatement(tenantContext("1"), "12345", "", strings.NewReader(qifStatement))
+
+	assert.NoError(t, err)
+	assert.Equal(t, FormatQIF, report.Format)
+	assert.Equal(t, 3, report.Imported)
+	assert.Equal(t, RowResult{Line: 16, Status: RowFailed, Message: `invalid date "15/01/2024", expected MM/DD/YYYY`}, report.Rows[3])
+	assert.Nil(t, report.LedgerBalance)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), recorder.Entries[1].Date)
+	assert.Equal(t, "Coffee shop - Latte", recorder.Entries[0].Description)
+	assert.Equal(t, money.MustParse("1250.00", "EUR"), recorder.Entries[2].Amount)
+	assert.NotEqual(t, recorder.Entries[0].ExternalID, recorder.Entries[1].ExternalID)
+
+	report, err = service.ImportStatement(tenantContext("1"), "12345", FormatQIF, strings.NewReader(qifStatement))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Skipped)
+	assert.Len(t, recorder.Entries, 3)
+}
+
+// Test statements that cannot be read, or do not match the account, are rejected whole
+func TestImportServiceImportStatement_Invalid(t *testing.T) {
+	cases := map[string]struct {
+		format Format
+		data   string
+	}{
+		"unknown content":   {"", "Date,Amount\n2024-01-15,1.00\n"},
+		"unknown format":    {"csv", "Date,Amount\n"},
+		"no OFX element":    {FormatOFX, "OFXHEADER:100\n"},
+		"no statement":      {FormatOFX, "<OFX><SIGNONMSGSRSV1></SIGNONMSGSRSV1></OFX>"},
+		"other currency":    {FormatOFX, strings.Replace(sgmlStatement, "<CURDEF>EUR", "<CURDEF>USD", 1)},
+		"unknown currency":  {FormatOFX, strings.Replace(sgmlStatement, "<CURDEF>EUR", "<CURDEF>XYZ", 1)},
+		"investment QIF":    {FormatQIF, "!Type:Invst\nD01/15/2024\nT-4.50\n^\n"},
+		"bad ledger amount": {FormatOFX, strings.Replace(sgmlStatement, "<BALAMT>996.75", "<BALAMT>lots", 1)},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := &FakeForRecordingTransaction{}
+			service := newTestService(recorder)
+
+			_, err := service.ImportStatement(tenantContext("1"), "12345", c.format, strings.NewReader(c.data))
+
+			assert.ErrorIs(t, err, ErrInvalidStatement)
+			assert.Empty(t, recorder.Entries)
+		})
+	}
+
+	_, err := newTestService(&FakeForRecordingTransaction{}).ImportStatement(tenantContext("1"), "99", FormatOFX, strings.NewReader(sgmlStatement))
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+
+	_, err = newTestService(&FakeForRecordingTransaction{}).ImportStatement(tenantContext("2"), "12345", FormatOFX, strings.NewReader(sgmlStatement))
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+}
+
+// camtStatement holds two daily camt.053 statements of the same account
+const camtStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Id>STMT-1</Id>
+      <Acct><Id><IBAN>DE89 3704 0044 0532 0130 00</IBAN></Id><Ccy>EUR</Ccy></Acct>
+      <Bal><Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp><Amt Ccy="EUR">1000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2024-01-15</Dt></Dt></Bal>
+      <Bal><Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp><Amt Ccy="EUR">950.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2024-01-15</Dt></Dt></Bal>
+      <Ntry>
+        <Amt Ccy="EUR">50.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2024-01-15</Dt></BookgDt>
+        <ValDt><Dt>2024-01-14</Dt></ValDt>
+        <AcctSvcrRef>REF-1</AcctSvcrRef>
+        <BkTxCd><Domn><Cd>PMNT</Cd><Fmly><Cd>ICDT</Cd><SubFmlyCd>ESCT</SubFmlyCd></Fmly></Domn></BkTxCd>
+        <NtryDtls><TxDtls>
+          <RltdPties><Cdtr><Nm>Electricity Ltd</Nm></Cdtr></RltdPties>
+          <RmtInf><Ustrd>Invoice 42</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+    </Stmt>
+    <Stmt>
+      <Id>STMT-2</Id>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id><Ccy>EUR</Ccy></Acct>
+      <Bal><Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp><Amt Ccy="EUR">969.50</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2024-01-16</Dt></Dt></Bal>
+      <Ntry>
+        <Amt Ccy="EUR">2.50</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2024-01-16T09:00:00+01:00</DtTm></BookgDt>
+        <AcctSvcrRef>REF-2</AcctSvcrRef>
+        <BkTxCd><Domn><Cd>ACMT</Cd><Fmly><Cd>MDOP</Cd><SubFmlyCd>CHRG</SubFmlyCd></Fmly></Domn></BkTxCd>
+        <AddtlNtryInf>Account fee</AddtlNtryInf>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">22.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <RvslInd>true</RvslInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2024-01-16</Dt></BookgDt>
+        <ValDt><Dt>2024-01-17</Dt></ValDt>
+        <NtryDtls><TxDtls>
+          <Refs><AcctSvcrRef>REF-3</AcctSvcrRef></Refs>
+          <RltdPties><Dbtr><Pty><Nm>Gym</Nm></Pty></Dbtr></RltdPties>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">9.99</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>PDNG</Cd></Sts>
+        <BookgDt><Dt>2024-01-16</Dt></BookgDt>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="USD">5.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2024-01-16</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+// mt940Statement holds two MT940 messages of the same account, the second in a SWIFT envelope
+const mt940Statement = ":20:STARTUMS\r\n" +
+	":25:10020030/1234567\r\n" +
+	":28C:00001/001\r\n" +
+	":60F:C240114EUR1000,00\r\n" +
+	":61:2401150115DR50,00NTRFNONREF//B-1\r\n" +
+	":86:166?00SEPA-UEBERWEISUNG?20Invoice 42?21 January?32Electricity\r\n" +
+	"?33 Ltd\r\n" +
+	":62F:C240115EUR950,00\r\n" +
+	"-\r\n" +
+	"{1:F01BANKDEFFAXXX0000000000}{2:O940}{4:\r\n" +
+	":20:STARTUMS\r\n" +
+	":25:10020030/1234567\r\n" +
+	":60F:C240115EUR950,00\r\n" +
+	":61:2401160116D2,50NCHGNONREF\r\n" +
+	":86:Account fee\r\n" +
+	":61:2401170116RD22,NDDTNONREF\r\n" +
+	":86:Returned direct debit\r\n" +
+	"gym membership\r\n" +
+	":61:240116CX1,00\r\n" +
+	":62F:C240116EUR969,50\r\n" +
+	"-}\r\n"
+
+// Test a camt.053 file with several statements is imported with booking and value dates
+func TestImportServiceImportStatement_CAMT053(t *testing.T) {
+	recorder := &FakeForRecordingTransaction{}
+	service := newTestService(recorder)
+
+	report, err := service.ImportStatement(tenantContext("1"), "12345", "", strings.NewReader(camtStatement))
+
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCAMT053, report.Format)
+	assert.Equal(t, 3, report.Imported)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, &Entry{Line: 9, Date: time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC), PostedDate: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		Amount: money.MustParse("-50.00", "EUR"), Description: "Electricity Ltd - Invoice 42", ExternalID: "REF-1"}, recorder.Entries[0])
+	assert.Equal(t, "Account fee", recorder.Entries[1].Description)
+	assert.Equal(t, "FEE", recorder.Entries[1].BankType)
+	assert.Equal(t, time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC), recorder.Entries[1].Date)
+	assert.Equal(t, "Gym", recorder.Entries[2].Description)
+	assert.Equal(t, "REF-3", recorder.Entries[2].ExternalID)
+	assert.True(t, recorder.Entries[2].Reversal)
+	assert.Equal(t, time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC), recorder.Entries[2].Date, "A value date after booking should not date the line")
+	assert.Equal(t, RowResult{Line: 48, Status: RowSkipped, Message: "entry is not booked (PDNG)"}, report.Rows[3])
+	assert.Equal(t, RowResult{Line: 54, Status: RowFailed, Message: "amount is in USD but the statement is in EUR"}, report.Rows[4])
+	assert.Equal(t, money.MustParse("969.50", "EUR"), *report.LedgerBalance)
+	assert.Equal(t, time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC), *report.LedgerBalanceDate)
+}
+
+// Test an MT940 file with several messages is imported with its debit/credit marks
+func TestImportServiceImportStatement_MT940(t *testing.T) {
+	recorder := &FakeForRecordingTransaction{}
+	service := newTestService(recorder)
+
+	report, err := service.ImportStatement(tenantContext("1"), "12345", "", strings.NewReader(mt940Statement))
+
+	assert.NoError(t, err)
+	assert.Equal(t, FormatMT940, report.Format)
+	assert.Equal(t, 3, report.Imported)
+	assert.Equal(t, RowResult{Line: 19, Status: RowFailed, Message: `invalid statement line "240116CX1,00"`}, report.Rows[3])
+	assert.Equal(t, Entry{Line: 5, Date: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), PostedDate: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		Amount: money.MustParse("-50.00", "EUR"), Description: "Electricity Ltd - Invoice 42 January", ExternalID: recorder.Entries[0].ExternalID}, *recorder.Entries[0])
+	assert.Equal(t, "FEE", recorder.Entries[1].BankType)
+	assert.Equal(t, money.MustParse("22.00", "EUR"), recorder.Entries[2].Amount)
+	assert.True(t, recorder.Entries[2].Reversal)
+	assert.Equal(t, "Returned direct debit gym membership", recorder.Entries[2].Description)
+	assert.Equal(t, time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC), recorder.Entries[2].Date)
+	assert.Equal(t, money.MustParse("969.50", "EUR"), *report.LedgerBalance)
+
+	report, err = service.ImportStatement(tenantContext("1"), "12345", FormatMT940, strings.NewReader(mt940Statement))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Skipped)
+}
+
+// Test files holding statements of different accounts are rejected
+func TestImportServiceImportStatement_MixedAccounts(t *testing.T) {
+	service := newTestService(&FakeForRecordingTransaction{})
+	files := map[Format]string{
+		FormatCAMT053: strings.Replace(camtStatement, "<IBAN>DE89370400440532013000", "<IBAN>GB29NWBK60161331926819", 1),
+		FormatMT940:   strings.Replace(mt940Statement, ":25:10020030/1234567\r\n:60F:", ":25:10020030/7654321\r\n:60F:", 1),
+	}
+
+	for format, data := range files {
+		_, err := service.ImportStatement(tenantContext("1"), "12345", format, strings.NewReader(data))
+
+		assert.ErrorIs(t, err, ErrInvalidStatement, string(format))
+	}
+}
+
+// Test MT940 booking dates take the year closest to the value date
+func TestMT940BookingDate(t *testing.T) {
+	value := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	booking, err := mt940BookingDate(value, "0102")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), booking)
+
+	booking, err = mt940BookingDate(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "1229")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC), booking)
+
+	_, err = mt940BookingDate(value, "1340")
+	assert.Error(t, err)
+}
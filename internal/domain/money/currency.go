@@ -0,0 +1,40 @@
+package money
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Currency is an ISO 4217 alphabetic currency code.
+type Currency string
+
+// currencyExponents lists the supported currencies with the number of digits
+// after the decimal separator their minor unit allows.
+var currencyExponents = map[Currency]int{
+	"AUD": 2, "BGN": 2, "BHD": 3, "BRL": 2, "CAD": 2, "CHF": 2, "CLP": 0,
+	"CNY": 2, "CZK": 2, "DKK": 2, "EUR": 2, "GBP": 2, "HKD": 2, "HUF": 2,
+	"IDR": 2, "ILS": 2, "INR": 2, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0,
+	"KWD": 3, "MXN": 2, "MYR": 2, "NOK": 2, "NZD": 2, "OMR": 3, "PHP": 2,
+	"PLN": 2, "RON": 2, "SEK": 2, "SGD": 2, "THB": 2, "TND": 3, "TRY": 2,
+	"USD": 2, "VND": 0, "ZAR": 2,
+}
+
+// ParseCurrency validates an ISO 4217 code, accepting any letter case.
+func ParseCurrency(code string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if _, ok := currencyExponents[currency]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+	return currency, nil
+}
+
+// Exponent returns the number of minor unit digits of the currency.
+func (c Currency) Exponent() int {
+	return currencyExponents[c]
+}
+
+// IsValid reports whether the currency is a supported ISO 4217 code.
+func (c Currency) IsValid() bool {
+	_, ok := currencyExponents[c]
+	return ok
+}
@@ -0,0 +1,146 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// maxScale bounds the number of decimal places a Decimal may carry.
+const maxScale = 18
+
+// Decimal is an exact decimal number, stored as an unscaled integer and the
+// number of digits after the decimal point. It is used for amounts that are
+// not tied to a currency, such as listing filter bounds.
+type Decimal struct {
+	unscaled int64
+	scale    int
+}
+
+// ParseDecimal parses a plain decimal string such as "-12.345".
+func ParseDecimal(s string) (Decimal, error) {
+	text := strings.TrimSpace(s)
+	negative := false
+	switch {
+	case strings.HasPrefix(text, "-"):
+		negative = true
+		text = text[1:]
+	case strings.HasPrefix(text, "+"):
+		text = text[1:]
+	}
+
+	whole, fraction, hasPoint := strings.Cut(text, ".")
+	if whole == "" && fraction == "" || hasPoint && fraction == "" || !isDigits(whole) || !isDigits(fraction) {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if len(fraction) > maxScale {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	unscaled, ok := new(big.Int).SetString("0"+whole+fraction, 10)
+	if !ok || !unscaled.IsInt64() {
+		return Decimal{}, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+	}
+	value := unscaled.Int64()
+	if negative {
+		value = -value
+	}
+	return Decimal{unscaled: value, scale: len(fraction)}, nil
+}
+
+// String formats the decimal without exponent notation.
+func (d Decimal) String() string {
+	return formatUnscaled(d.unscaled, d.scale)
+}
+
+// MarshalJSON encodes the decimal as a JSON string, like Money amounts.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a decimal sent as a JSON string or number.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidAmount, data)
+		}
+	}
+	parsed, err := ParseDecimal(text)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Cmp compares two decimals, returning -1, 0 or +1.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.rat().Cmp(other.rat())
+}
+
+// rescale returns the unscaled value expressed with the given number of
+// decimal places, failing if digits would be lost or the value overflows.
+func (d Decimal) rescale(scale int) (int64, error) {
+	value := big.NewInt(d.unscaled)
+	if d.scale > scale {
+		divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale-scale)), nil)
+		quotient, remainder := new(big.Int).QuoRem(value, divisor, new(big.Int))
+		if remainder.Sign() != 0 {
+			return 0, ErrTooPrecise
+		}
+		value = quotient
+	} else if d.scale < scale {
+		value.Mul(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-d.scale)), nil))
+	}
+	if !value.IsInt64() {
+		return 0, fmt.Errorf("%w: %s is out of range", ErrInvalidAmount, d)
+	}
+	return value.Int64(), nil
+}
+
+// Rat returns the decimal as an exact rational number.
+func (d Decimal) Rat() *big.Rat {
+	return d.rat()
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of the decimal.
+func (d Decimal) Sign() int {
+	switch {
+	case d.unscaled < 0:
+		return -1
+	case d.unscaled > 0:
+		return 1
+	}
+	return 0
+}
+
+func (d Decimal) rat() *big.Rat {
+	denominator := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale)), nil)
+	return new(big.Rat).SetFrac(big.NewInt(d.unscaled), denominator)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatUnscaled renders an unscaled integer with scale digits after the point.
+func formatUnscaled(unscaled int64, scale int) string {
+	digits := new(big.Int).Abs(big.NewInt(unscaled)).String()
+	if scale > 0 {
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if unscaled < 0 {
+		return "-" + digits
+	}
+	return digits
+}
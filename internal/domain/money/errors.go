@@ -0,0 +1,19 @@
+package money
+
+import "errors"
+
+// ErrUnknownCurrency is returned for currency codes that are not supported ISO 4217 codes.
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// ErrInvalidAmount is returned when an amount is not a valid decimal number.
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// ErrTooPrecise is returned when an amount has more decimal places than its currency allows.
+var ErrTooPrecise = errors.New("amount has more decimal places than the currency allows")
+
+// ErrCurrencyMismatch is returned when combining amounts of different currencies.
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// ErrOverflow is returned when the result of a calculation does not fit in the
+// range of minor units an amount can hold.
+var ErrOverflow = errors.New("amount out of range")
@@ -0,0 +1,175 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// Money is an exact amount of a currency, held as an integer number of the
+// currency's minor units (cents, pence, ...) so arithmetic never rounds.
+type Money struct {
+	minorUnits int64
+	currency   Currency
+}
+
+// New creates an amount from a number of minor units.
+func New(minorUnits int64, currency Currency) Money {
+	return Money{minorUnits: minorUnits, currency: currency}
+}
+
+// Zero returns a zero amount of the currency.
+func Zero(currency Currency) Money {
+	return Money{currency: currency}
+}
+
+// Parse parses a decimal amount such as "12.34" in the given currency.
+// Trailing zeros are accepted, but amounts with more significant decimal
+// places than the currency allows are rejected with ErrTooPrecise.
+func Parse(amount string, currency Currency) (Money, error) {
+	if !currency.IsValid() {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	decimal, err := ParseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	minorUnits, err := decimal.rescale(currency.Exponent())
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %s %s", err, amount, currency)
+	}
+	return Money{minorUnits: minorUnits, currency: currency}, nil
+}
+
+// MustParse is like Parse but panics on invalid input. It is intended for
+// constants and tests.
+func MustParse(amount string, currency Currency) Money {
+	m, err := Parse(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// FromRat converts an exact rational amount into the currency, rounding half
+// to even ("banker's rounding") to the currency's minor unit. Amounts too
+// large to hold fail with ErrOverflow.
+func FromRat(amount *big.Rat, currency Currency) (Money, error) {
+	scaled := new(big.Rat).Mul(amount, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(currency.Exponent())), nil)))
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+
+	// Compare twice the remainder with the denominator to decide rounding
+	twice := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+	switch cmp := twice.Cmp(scaled.Denom()); {
+	case cmp > 0, cmp == 0 && quotient.Bit(0) == 1:
+		quotient.Add(quotient, big.NewInt(int64(scaled.Sign())))
+	}
+	if !quotient.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s %s", ErrOverflow, amount.FloatString(currency.Exponent()), currency)
+	}
+	return Money{minorUnits: quotient.Int64(), currency: currency}, nil
+}
+
+// MinorUnits returns the amount as an integer number of minor units.
+func (m Money) MinorUnits() int64 {
+	return m.minorUnits
+}
+
+// Currency returns the currency of the amount.
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// Decimal returns the amount as a currency-less decimal.
+func (m Money) Decimal() Decimal {
+	return Decimal{unscaled: m.minorUnits, scale: m.currency.Exponent()}
+}
+
+// String formats the amount with exactly as many decimals as the currency uses, e.g. "12.30".
+func (m Money) String() string {
+	return formatUnscaled(m.minorUnits, m.currency.Exponent())
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.minorUnits == 0
+}
+
+// IsNegative reports whether the amount is below zero.
+func (m Money) IsNegative() bool {
+	return m.minorUnits < 0
+}
+
+// IsPositive reports whether the amount is above zero.
+func (m Money) IsPositive() bool {
+	return m.minorUnits > 0
+}
+
+// Neg returns the amount with its sign flipped.
+func (m Money) Neg() Money {
+	return Money{minorUnits: -m.minorUnits, currency: m.currency}
+}
+
+// Abs returns the amount without its sign.
+func (m Money) Abs() Money {
+	if m.minorUnits < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Add returns the sum of two amounts of the same currency. A sum too large
+// to hold fails with ErrOverflow rather than wrapping around.
+func (m Money) Add(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+	sum := m.minorUnits + other.minorUnits
+	if other.minorUnits > 0 && sum < m.minorUnits || other.minorUnits < 0 && sum > m.minorUnits {
+		return Money{}, fmt.Errorf("%w: %s + %s %s", ErrOverflow, m, other, m.currency)
+	}
+	return Money{minorUnits: sum, currency: m.currency}, nil
+}
+
+// Sub returns the difference of two amounts of the same currency. A
+// difference too large to hold fails with ErrOverflow.
+func (m Money) Sub(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+	difference := m.minorUnits - other.minorUnits
+	if other.minorUnits > 0 && difference > m.minorUnits || other.minorUnits < 0 && difference < m.minorUnits {
+		return Money{}, fmt.Errorf("%w: %s - %s %s", ErrOverflow, m, other, m.currency)
+	}
+	return Money{minorUnits: difference, currency: m.currency}, nil
+}
+
+// moneyJSON is the wire representation of Money; the amount is a string so
+// that clients never round-trip it through a binary float.
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes the amount as {"amount":"12.34","currency":"EUR"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.String(), Currency: string(m.currency)})
+}
+
+// UnmarshalJSON decodes the representation produced by MarshalJSON.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	currency, err := ParseCurrency(raw.Currency)
+	if err != nil {
+		return err
+	}
+	parsed, err := Parse(raw.Amount, currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
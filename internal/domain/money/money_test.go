@@ -0,0 +1,194 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test parsing amounts into minor units
+func TestParse(t *testing.T) {
+	tests := []struct {
+		amount     string
+		currency   Currency
+		minorUnits int64
+		formatted  string
+	}{
+		{"12.34", "EUR", 1234, "12.34"},
+		{"12.3", "EUR", 1230, "12.30"},
+		{"12", "USD", 1200, "12.00"},
+		{"-0.05", "GBP", -5, "-0.05"},
+		{".5", "GBP", 50, "0.50"},
+		{"+7.10", "USD", 710, "7.10"},
+		{"100.0000", "EUR", 10000, "100.00"},
+		{"1500", "JPY", 1500, "1500"},
+		{"1.234", "KWD", 1234, "1.234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount+" "+string(tt.currency), func(t *testing.T) {
+			m, err := Parse(tt.amount, tt.currency)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.minorUnits, m.MinorUnits())
+			assert.Equal(t, tt.currency, m.Currency())
+			assert.Equal(t, tt.formatted, m.String())
+		})
+	}
+}
+
+// Test that amounts with more precision than the currency allows are rejected
+func TestParse_TooPrecise(t *testing.T) {
+	for _, tt := range []struct {
+		amount   string
+		currency Currency
+	}{{"12.345", "EUR"}, {"0.001", "USD"}, {"10.5", "JPY"}, {"1.2345", "KWD"}} {
+		_, err := Parse(tt.amount, tt.currency)
+		assert.True(t, errors.Is(err, ErrTooPrecise), "Expected ErrTooPrecise for %s %s", tt.amount, tt.currency)
+	}
+}
+
+// Test that malformed amounts are rejected
+func TestParse_Invalid(t *testing.T) {
+	for _, amount := range []string{"", ".", "-", "12.", "1,50", "1e3", "abc", "12.3.4", "99999999999999999999"} {
+		_, err := Parse(amount, "EUR")
+		assert.True(t, errors.Is(err, ErrInvalidAmount), "Expected ErrInvalidAmount for %q", amount)
+	}
+}
+
+// Test that unknown currencies are rejected
+func TestParse_UnknownCurrency(t *testing.T) {
+	_, err := Parse("1.00", "XXX")
+	assert.True(t, errors.Is(err, ErrUnknownCurrency))
+
+	_, err = ParseCurrency("euro")
+	assert.True(t, errors.Is(err, ErrUnknownCurrency))
+
+	currency, err := ParseCurrency(" gbp ")
+	assert.NoError(t, err)
+	assert.Equal(t, Currency("GBP"), currency)
+}
+
+// Test arithmetic is exact and refuses to mix currencies
+func TestMoney_Arithmetic(t *testing.T) {
+	// 0.1 + 0.2 is the classic float rounding trap
+	sum, err := MustParse("0.10", "EUR").Add(MustParse("0.20", "EUR"))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.30", sum.String())
+
+	difference, err := MustParse("5.00", "EUR").Sub(MustParse("7.25", "EUR"))
+	assert.NoError(t, err)
+	assert.Equal(t, "-2.25", difference.String())
+	assert.True(t, difference.IsNegative())
+	assert.Equal(t, "2.25", difference.Abs().String())
+	assert.Equal(t, "2.25", difference.Neg().String())
+
+	_, err = MustParse("1.00", "EUR").Add(MustParse("1.00", "USD"))
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+	_, err = MustParse("1.00", "EUR").Sub(MustParse("1.00", "USD"))
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+}
+
+// Test arithmetic refuses to wrap around at the limits of the minor units
+func TestMoney_Overflow(t *testing.T) {
+	largest := New(math.MaxInt64, "EUR")
+	smallest := New(math.MinInt64, "EUR")
+
+	_, err := largest.Add(New(1, "EUR"))
+	assert.True(t, errors.Is(err, ErrOverflow), "Expected ErrOverflow adding past the maximum")
+	_, err = smallest.Add(New(-1, "EUR"))
+	assert.True(t, errors.Is(err, ErrOverflow), "Expected ErrOverflow adding past the minimum")
+	_, err = smallest.Sub(New(1, "EUR"))
+	assert.True(t, errors.Is(err, ErrOverflow), "Expected ErrOverflow subtracting past the minimum")
+	_, err = Zero("EUR").Sub(smallest)
+	assert.True(t, errors.Is(err, ErrOverflow), "Expected ErrOverflow negating the minimum")
+
+	difference, err := smallest.Sub(New(-1, "EUR"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(math.MinInt64+1), difference.MinorUnits())
+}
+
+// Test JSON encoding uses a string amount
+func TestMoney_JSON(t *testing.T) {
+	encoded, err := json.Marshal(MustParse("1234.5", "EUR"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"1234.50","currency":"EUR"}`, string(encoded))
+
+	var decoded Money
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, MustParse("1234.50", "EUR"), decoded)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":"1.001","currency":"EUR"}`), &decoded))
+}
+
+// Test decimal parsing and comparison
+func TestDecimal(t *testing.T) {
+	a, err := ParseDecimal("10.5")
+	assert.NoError(t, err)
+	b, err := ParseDecimal("10.50")
+	assert.NoError(t, err)
+	c, err := ParseDecimal("-3")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, a.Cmp(b))
+	assert.Equal(t, 1, a.Cmp(c))
+	assert.Equal(t, -1, c.Cmp(a))
+	assert.Equal(t, "10.50", b.String())
+	assert.Equal(t, "0.05", MustParse("0.05", "EUR").Decimal().String())
+}
+
+// Test decimals are encoded as strings and decoded from strings or numbers
+func TestDecimal_JSON(t *testing.T) {
+	value, _ := ParseDecimal("-12.50")
+	encoded, err := json.Marshal(value)
+	assert.NoError(t, err)
+	assert.Equal(t, `"-12.50"`, string(encoded))
+
+	var decoded Decimal
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, value, decoded)
+	assert.NoError(t, json.Unmarshal([]byte(`7.25`), &decoded))
+	assert.Equal(t, "7.25", decoded.String())
+	assert.Error(t, json.Unmarshal([]byte(`"ten"`), &decoded))
+
+	// Called directly, the quotes must still pair up exactly
+	for _, malformed := range []string{`"12.5`, `12.5"`, `""12.5""`} {
+		assert.True(t, errors.Is(decoded.UnmarshalJSON([]byte(malformed)), ErrInvalidAmount), malformed)
+	}
+}
+
+// Test converting rational amounts rounds half to even
+func TestFromRat(t *testing.T) {
+	tests := []struct {
+		rat      string
+		currency Currency
+		expected string
+	}{
+		{"1/3", "EUR", "0.33"},
+		{"2/3", "EUR", "0.67"},
+		{"0.125", "EUR", "0.12"},
+		{"0.135", "EUR", "0.14"},
+		{"-0.125", "EUR", "-0.12"},
+		{"-0.135", "EUR", "-0.14"},
+		{"1234.5", "JPY", "1234"},
+		{"1235.5", "JPY", "1236"},
+	}
+
+	for _, tt := range tests {
+		rat, _ := new(big.Rat).SetString(tt.rat)
+		amount, err := FromRat(rat, tt.currency)
+		assert.NoError(t, err, tt.rat)
+		assert.Equal(t, tt.expected, amount.String(), tt.rat)
+	}
+}
+
+// Test converting a rational amount too large for the currency fails
+func TestFromRat_Overflow(t *testing.T) {
+	rat, _ := new(big.Rat).SetString("100000000000000000000")
+
+	_, err := FromRat(rat, "EUR")
+	assert.True(t, errors.Is(err, ErrOverflow), "Expected ErrOverflow")
+}
@@ -0,0 +1,10 @@
+package reports
+
+import "errors"
+
+// ErrInvalidReport is returned when a report is asked for with an unknown period or an invalid
+// date range.
+var ErrInvalidReport = errors.New("invalid report")
+
+// ErrAccountNotFound is returned when a report is asked for of an account that does not exist.
+var ErrAccountNotFound = errors.New("account not found")
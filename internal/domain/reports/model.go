@@ -0,0 +1,97 @@
+package reports
+
+import (
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// Period is the length of time a report's totals are grouped by.
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+	PeriodYear  Period = "year"
+)
+
+// periodDays is the approximate length of each period, used to cap the number of periods a
+// report can span
+var periodDays = map[Period]int{
+	PeriodDay:   1,
+	PeriodWeek:  7,
+	PeriodMonth: 30,
+	PeriodYear:  365,
+}
+
+// start returns the first day of the period containing the given day.
+func (p Period) start(date time.Time) time.Time {
+	switch p {
+	case PeriodWeek:
+		return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+	case PeriodMonth:
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case PeriodYear:
+		return time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return date
+}
+
+// MaxPeriods caps the number of periods a single report can span.
+const MaxPeriods = 1000
+
+// SpendingQuery describes a spending report. From and To are inclusive days; a zero To means
+// today and a zero From the year up to To. AccountID and CategoryID narrow the report down,
+// CategoryID including its subcategories, and ByAccount and ByCategory split each period's
+// totals further. A non-nil AccountIDs restricts the report to those accounts, and to none if
+// it is empty. Transfers between accounts are left out unless IncludeTransfers is set, in
+// which case money transferred in counts as income and money transferred out as expenses.
+// When Currency is set every amount is converted into it at the rate of its transaction's day,
+// so the report has a single total.
+type SpendingQuery struct {
+	From             time.Time
+	To               time.Time
+	Period           Period
+	AccountID        string
+	AccountIDs       []string
+	CategoryID       string
+	ByAccount        bool
+	ByCategory       bool
+	IncludeTransfers bool
+	Currency         money.Currency
+}
+
+// SpendingRow holds the totals of one period, and of one account and category when the report
+// is split by them. Weeks start on Monday. Income is the money that came in and Expenses the
+// money that went out, both positive, and NetCashFlow is the difference. Transfers between
+// accounts are neither unless the query includes them, and voided transactions are left out. AccountID and CategoryID are
+// only set when the report is split by them; uncategorised transactions have an empty
+// CategoryID.
+type SpendingRow struct {
+	PeriodStart  time.Time
+	AccountID    string
+	CategoryID   string
+	Income       money.Money
+	Expenses     money.Money
+	NetCashFlow  money.Money
+	Transactions int
+}
+
+// SpendingTotal adds up the rows of a report in one currency.
+type SpendingTotal struct {
+	Income       money.Money
+	Expenses     money.Money
+	NetCashFlow  money.Money
+	Transactions int
+}
+
+// SpendingReport is a spending report: a row per period with transactions, and the totals
+// over the whole report, one per currency since amounts in different currencies are never
+// added together. The first and last periods may be cut short by From and To.
+type SpendingReport struct {
+	From   time.Time
+	To     time.Time
+	Period Period
+	Rows   []*SpendingRow
+	Totals []*SpendingTotal
+}
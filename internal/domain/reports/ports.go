@@ -0,0 +1,26 @@
+package reports
+
+import (
+	"context"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// ForReportingSpending defines the port for reporting income, expenses and net cash flow over time.
+type ForReportingSpending interface {
+	ReportSpending(ctx context.Context, query SpendingQuery) (*SpendingReport, error)
+}
+
+// ForLoadingSpending defines the port for adding up transactions in the persistence layer. It
+// returns a row per period, account, category and currency, as far as the query splits them,
+// ordered by period start and then by account, category and currency; periods without
+// transactions are left out. Only the tenant's transactions are added up.
+type ForLoadingSpending interface {
+	LoadSpending(ctx context.Context, tenantID string, query SpendingQuery) ([]*SpendingRow, error)
+}
+
+// ForConvertingMoney defines the port for converting an amount into another currency at the
+// tenant's rate for the given day.
+type ForConvertingMoney interface {
+	Convert(ctx context.Context, amount money.Money, to money.Currency, on time.Time) (money.Money, error)
+}
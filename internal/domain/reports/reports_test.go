@@ -0,0 +1,202 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForLoadingSpending simulates adding up transactions in the persistence layer for testing.
+type FakeForLoadingSpending struct {
+	Rows        []*SpendingRow
+	Query       SpendingQuery
+	TenantID    string
+	Calls       int
+	ReturnError bool
+}
+
+func (f *FakeForLoadingSpending) LoadSpending(ctx context.Context, tenantID string, query SpendingQuery) ([]*SpendingRow, error) {
+	f.Query, f.TenantID = query, tenantID
+	f.Calls++
+	if f.ReturnError {
+		return nil, errors.New("failed to load spending")
+	}
+	return f.Rows, nil
+}
+
+// FakeForConvertingMoney simulates converting amounts for testing: each day's rate into a
+// currency is looked up by "from/to date", and an unknown rate is not found.
+type FakeForConvertingMoney struct {
+	Rates map[string]string
+	Calls int
+}
+
+func (f *FakeForConvertingMoney) Convert(ctx context.Context, amount money.Money, to money.Currency, on time.Time) (money.Money, error) {
+	f.Calls++
+	if amount.Currency() == to {
+		return amount, nil
+	}
+	rate, ok := f.Rates[fmt.Sprintf("%s/%s %s", amount.Currency(), to, on.Format(time.DateOnly))]
+	if !ok {
+		return money.Money{}, errRateNotFound
+	}
+	factor, _ := new(big.Rat).SetString(rate)
+	return money.FromRat(new(big.Rat).Mul(amount.Decimal().Rat(), factor), to)
+}
+
+var errRateNotFound = errors.New("rate not found")
+
+// tenantContext returns a context acting as a user of tenant 1
+func tenantContext() context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice", UserID: "5", TenantID: "1"})
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func spendingRow(start time.Time, income, expenses string, currency money.Currency, count int) *SpendingRow {
+	in, out := money.MustParse(income, currency), money.MustParse(expenses, currency)
+	net, _ := in.Sub(out)
+	return &SpendingRow{PeriodStart: start, Income: in, Expenses: out, NetCashFlow: net, Transactions: count}
+}
+
+// Test a report passes the query on and totals its rows per currency
+func TestReportServiceReportSpending(t *testing.T) {
+	loader := &FakeForLoadingSpending{Rows: []*SpendingRow{
+		spendingRow(day(2024, 1, 1), "2500.00", "1200.50", "EUR", 30),
+		spendingRow(day(2024, 1, 1), "0.00", "40.00", "GBP", 2),
+		spendingRow(day(2024, 2, 1), "2500.00", "2700.00", "EUR", 28),
+	}}
+	service := NewReportService(loader, &FakeForConvertingMoney{})
+
+	query := SpendingQuery{From: day(2024, 1, 1), To: day(2024, 2, 29), AccountID: "12345", ByCategory: true}
+	report, err := service.ReportSpending(tenantContext(), query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, PeriodMonth, report.Period)
+	assert.Equal(t, day(2024, 1, 1), report.From)
+	assert.Len(t, report.Rows, 3)
+	assert.Equal(t, []*SpendingTotal{
+		{Income: money.MustParse("5000.00", "EUR"), Expenses: money.MustParse("3900.50", "EUR"), NetCashFlow: money.MustParse("1099.50", "EUR"), Transactions: 58},
+		{Income: money.MustParse("0.00", "GBP"), Expenses: money.MustParse("40.00", "GBP"), NetCashFlow: money.MustParse("-40.00", "GBP"), Transactions: 2},
+	}, report.Totals)
+	assert.Equal(t, "12345", loader.Query.AccountID)
+	assert.True(t, loader.Query.ByCategory)
+}
+
+// Test a report without dates covers the year up to today
+func TestReportServiceReportSpending_Defaults(t *testing.T) {
+	loader := &FakeForLoadingSpending{}
+
+	report, err := NewReportService(loader, &FakeForConvertingMoney{}).ReportSpending(tenantContext(), SpendingQuery{})
+
+	assert.NoError(t, err)
+	now := time.Now()
+	today := day(now.Year(), now.Month(), now.Day())
+	assert.Equal(t, today, loader.Query.To)
+	assert.Equal(t, today.AddDate(-1, 0, 1), loader.Query.From)
+	assert.Equal(t, PeriodMonth, loader.Query.Period)
+	assert.Empty(t, report.Rows)
+	assert.Empty(t, report.Totals)
+}
+
+// Test invalid reports are rejected before anything is loaded
+func TestReportServiceReportSpending_Invalid(t *testing.T) {
+	queries := []SpendingQuery{
+		{Period: "fortnight"},
+		{From: day(2024, 2, 1), To: day(2024, 1, 1)},
+		{From: day(2020, 1, 1), To: day(2024, 1, 1), Period: PeriodDay},
+	}
+
+	for _, query := range queries {
+		loader := &FakeForLoadingSpending{}
+
+		_, err := NewReportService(loader, &FakeForConvertingMoney{}).ReportSpending(tenantContext(), query)
+
+		assert.ErrorIs(t, err, ErrInvalidReport)
+		assert.Zero(t, loader.Calls)
+	}
+
+	_, err := NewReportService(&FakeForLoadingSpending{}, &FakeForConvertingMoney{}).ReportSpending(tenantContext(), SpendingQuery{From: day(2021, 4, 7), To: day(2024, 1, 1), Period: PeriodDay})
+	assert.NoError(t, err, "A thousand days is allowed")
+}
+
+// Test loading failure
+func TestReportServiceReportSpending_LoadError(t *testing.T) {
+	_, err := NewReportService(&FakeForLoadingSpending{ReturnError: true}, &FakeForConvertingMoney{}).ReportSpending(tenantContext(), SpendingQuery{})
+
+	assert.Error(t, err)
+}
+
+// Test only the caller's tenant is reported on, and a tenant is required
+func TestReportServiceReportSpending_Tenant(t *testing.T) {
+	loader := &FakeForLoadingSpending{}
+
+	_, err := NewReportService(loader, &FakeForConvertingMoney{}).ReportSpending(tenantContext(), SpendingQuery{})
+	assert.Nil(t, err)
+	assert.Equal(t, "1", loader.TenantID)
+
+	_, err = NewReportService(loader, &FakeForConvertingMoney{}).ReportSpending(context.Background(), SpendingQuery{})
+	assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+	assert.Equal(t, 1, loader.Calls)
+}
+
+// Test a report in a reporting currency converts each day at its own rate and adds the days
+// up into the query's periods
+func TestReportServiceReportSpending_Currency(t *testing.T) {
+	loader := &FakeForLoadingSpending{Rows: []*SpendingRow{
+		spendingRow(day(2024, 1, 2), "100.00", "10.00", "USD", 2),
+		spendingRow(day(2024, 1, 2), "0.00", "20.00", "EUR", 1),
+		spendingRow(day(2024, 1, 20), "0.00", "10.00", "USD", 1),
+		spendingRow(day(2024, 2, 5), "50.00", "0.00", "EUR", 1),
+	}}
+	converter := &FakeForConvertingMoney{Rates: map[string]string{
+		"USD/EUR 2024-01-02": "0.9",
+		"USD/EUR 2024-01-20": "0.8",
+	}}
+
+	query := SpendingQuery{From: day(2024, 1, 1), To: day(2024, 2, 29), Currency: "EUR"}
+	report, err := NewReportService(loader, converter).ReportSpending(tenantContext(), query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, PeriodDay, loader.Query.Period, "Totals are loaded per day to convert them")
+	assert.Equal(t, PeriodMonth, report.Period)
+	assert.Equal(t, []*SpendingRow{
+		spendingRow(day(2024, 1, 1), "90.00", "37.00", "EUR", 4),
+		spendingRow(day(2024, 2, 1), "50.00", "0.00", "EUR", 1),
+	}, report.Rows)
+	assert.Equal(t, []*SpendingTotal{
+		{Income: money.MustParse("140.00", "EUR"), Expenses: money.MustParse("37.00", "EUR"), NetCashFlow: money.MustParse("103.00", "EUR"), Transactions: 5},
+	}, report.Totals)
+}
+
+// Test a report in a reporting currency fails when a day's rate is missing, and an unknown
+// currency is rejected before anything is loaded
+func TestReportServiceReportSpending_CurrencyErrors(t *testing.T) {
+	loader := &FakeForLoadingSpending{Rows: []*SpendingRow{spendingRow(day(2024, 1, 2), "0.00", "10.00", "USD", 1)}}
+
+	_, err := NewReportService(loader, &FakeForConvertingMoney{}).ReportSpending(tenantContext(), SpendingQuery{Currency: "EUR"})
+	assert.ErrorIs(t, err, errRateNotFound)
+
+	loader = &FakeForLoadingSpending{}
+	_, err = NewReportService(loader, &FakeForConvertingMoney{}).ReportSpending(tenantContext(), SpendingQuery{Currency: "XYZ"})
+	assert.ErrorIs(t, err, ErrInvalidReport)
+	assert.Zero(t, loader.Calls)
+}
+
+// Test weeks start on Monday and months and years on their first day
+func TestPeriodStart(t *testing.T) {
+	assert.Equal(t, day(2024, 1, 1), PeriodWeek.start(day(2024, 1, 7)))
+	assert.Equal(t, day(2024, 1, 8), PeriodWeek.start(day(2024, 1, 8)))
+	assert.Equal(t, day(2024, 2, 1), PeriodMonth.start(day(2024, 2, 29)))
+	assert.Equal(t, day(2024, 1, 1), PeriodYear.start(day(2024, 12, 31)))
+	assert.Equal(t, day(2024, 3, 3), PeriodDay.start(day(2024, 3, 3)))
+}
@@ -0,0 +1,170 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// ReportService provides the core logic for reporting on spending.
+type ReportService struct {
+	spendingLoader ForLoadingSpending
+	converter      ForConvertingMoney
+}
+
+// NewReportService creates a new ReportService.
+func NewReportService(loader ForLoadingSpending, converter ForConvertingMoney) *ReportService {
+	return &ReportService{
+		spendingLoader: loader,
+		converter:      converter,
+	}
+}
+
+// ReportSpending adds up income, expenses and net cash flow per period, and
+// per account and category when asked to, over the transactions of the
+// tenant of the principal in ctx. The adding up is left to the persistence
+// layer, so only the totals are ever loaded. A report in a reporting currency
+// loads the totals per day instead, converts each day's at that day's rate and
+// adds them up into the query's periods.
+func (s *ReportService) ReportSpending(ctx context.Context, query SpendingQuery) (*SpendingReport, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := query.normalize(time.Now()); err != nil {
+		return nil, err
+	}
+
+	var rows []*SpendingRow
+	if query.Currency == "" {
+		rows, err = s.spendingLoader.LoadSpending(ctx, tenantID, query)
+	} else {
+		rows, err = s.loadConverted(ctx, tenantID, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	totals, err := addUp(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &SpendingReport{From: query.From, To: query.To, Period: query.Period, Rows: rows, Totals: totals}, nil
+}
+
+// normalize applies defaults to the query and validates it.
+func (q *SpendingQuery) normalize(now time.Time) error {
+	if q.Period == "" {
+		q.Period = PeriodMonth
+	}
+	days, ok := periodDays[q.Period]
+	if !ok {
+		return fmt.Errorf("%w: unknown period %q", ErrInvalidReport, q.Period)
+	}
+	if q.To.IsZero() {
+		q.To = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	if q.From.IsZero() {
+		q.From = q.To.AddDate(-1, 0, 1)
+	}
+	if q.Currency != "" && !q.Currency.IsValid() {
+		return fmt.Errorf("%w: unknown currency %q", ErrInvalidReport, q.Currency)
+	}
+	if q.From.After(q.To) {
+		return fmt.Errorf("%w: from must not be after to", ErrInvalidReport)
+	}
+	if span := int(q.To.Sub(q.From).Hours()/24) + 1; span > MaxPeriods*days {
+		return fmt.Errorf("%w: a report by %s can span at most %d periods", ErrInvalidReport, q.Period, MaxPeriods)
+	}
+	return nil
+}
+
+// loadConverted loads the tenant's daily totals for the query, converts them
+// into the query's currency at the rate of their day and adds them up per
+// period, account and category.
+func (s *ReportService) loadConverted(ctx context.Context, tenantID string, query SpendingQuery) ([]*SpendingRow, error) {
+	daily := query
+	daily.Period = PeriodDay
+	days, err := s.spendingLoader.LoadSpending(ctx, tenantID, daily)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		start               time.Time
+		accountID, category string
+	}
+	rows := []*SpendingRow{}
+	byKey := map[key]*SpendingRow{}
+	for _, total := range days {
+		income, err := s.converter.Convert(ctx, total.Income, query.Currency, total.PeriodStart)
+		if err != nil {
+			return nil, err
+		}
+		expenses, err := s.converter.Convert(ctx, total.Expenses, query.Currency, total.PeriodStart)
+		if err != nil {
+			return nil, err
+		}
+
+		k := key{start: query.Period.start(total.PeriodStart), accountID: total.AccountID, category: total.CategoryID}
+		row, ok := byKey[k]
+		if !ok {
+			zero := money.Zero(query.Currency)
+			row = &SpendingRow{PeriodStart: k.start, AccountID: k.accountID, CategoryID: k.category, Income: zero, Expenses: zero, NetCashFlow: zero}
+			byKey[k] = row
+			rows = append(rows, row)
+		}
+		if row.Income, err = row.Income.Add(income); err != nil {
+			return nil, err
+		}
+		if row.Expenses, err = row.Expenses.Add(expenses); err != nil {
+			return nil, err
+		}
+		if row.NetCashFlow, err = row.Income.Sub(row.Expenses); err != nil {
+			return nil, err
+		}
+		row.Transactions += total.Transactions
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if !a.PeriodStart.Equal(b.PeriodStart) {
+			return a.PeriodStart.Before(b.PeriodStart)
+		}
+		if a.AccountID != b.AccountID {
+			return a.AccountID < b.AccountID
+		}
+		return a.CategoryID < b.CategoryID
+	})
+	return rows, nil
+}
+
+// addUp totals the rows per currency, in the order the currencies first appear
+func addUp(rows []*SpendingRow) ([]*SpendingTotal, error) {
+	totals := []*SpendingTotal{}
+	byCurrency := map[money.Currency]*SpendingTotal{}
+	for _, row := range rows {
+		currency := row.NetCashFlow.Currency()
+		total, ok := byCurrency[currency]
+		if !ok {
+			total = &SpendingTotal{Income: money.Zero(currency), Expenses: money.Zero(currency), NetCashFlow: money.Zero(currency)}
+			byCurrency[currency] = total
+			totals = append(totals, total)
+		}
+
+		var err error
+		if total.Income, err = total.Income.Add(row.Income); err != nil {
+			return nil, err
+		}
+		if total.Expenses, err = total.Expenses.Add(row.Expenses); err != nil {
+			return nil, err
+		}
+		if total.NetCashFlow, err = total.NetCashFlow.Add(row.NetCashFlow); err != nil {
+			return nil, err
+		}
+		total.Transactions += row.Transactions
+	}
+	return totals, nil
+}
@@ -0,0 +1,39 @@
+package transactions
+
+import (
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// AccountBalance is the balance of an account at the end of today and, when
+// asked for, at the end of another day. Balances count pending and posted
+// transactions; voided transactions never count. Balances asked for in a
+// reporting currency add up each day's change converted at that day's rate.
+type AccountBalance struct {
+	AccountID   string
+	Current     money.Money
+	AsOf        *time.Time
+	AsOfBalance *money.Money
+}
+
+// DailyChange is what an account's transactions of one day add to its balance.
+type DailyChange struct {
+	Date   time.Time
+	Amount money.Money
+}
+
+// tracksRunningBalance reports whether a listing shows running balances. That
+// needs a single account's transactions in date order with nothing skipped,
+// so each row's balance follows from its neighbour's.
+func (f *TransactionFilter) tracksRunningBalance() bool {
+	return f.AccountID != "" && f.SortBy == SortByDate && f.CategoryID == "" && f.Type == "" && f.Status == "" &&
+		f.MinAmount == nil && f.MaxAmount == nil && f.Description == "" && f.TransferID == ""
+}
+
+// balanceChange is what the transaction adds to its account's balance
+func (t *Transaction) balanceChange() money.Money {
+	if t.Status == StatusVoided {
+		return money.Zero(t.Amount.Currency())
+	}
+	return t.Amount
+}
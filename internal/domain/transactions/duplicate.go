@@ -0,0 +1,141 @@
+package transactions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// DefaultDuplicateWindow is how many days apart two transactions can be dated and still be
+	// flagged as duplicates, when a search does not ask for a specific window.
+	DefaultDuplicateWindow = 3
+	// MaxDuplicateWindow caps the window of a duplicate search.
+	MaxDuplicateWindow = 31
+	// DefaultMinSimilarity is the description similarity two transactions need to be flagged as
+	// duplicates, when a search does not ask for a specific one.
+	DefaultMinSimilarity = 0.5
+	// maxDuplicateCandidates caps the number of candidate pairs a single search scores.
+	maxDuplicateCandidates = 5000
+)
+
+// DuplicateFilter narrows down a search for duplicate transactions. An empty
+// AccountID searches every account, and a non-nil AccountIDs restricts the
+// search to those accounts, and to nothing if it is empty. WindowDays is the
+// most days apart two transactions can be dated, and MinSimilarity the
+// description similarity, from 0 to 1, they need to be flagged.
+type DuplicateFilter struct {
+	AccountID     string
+	AccountIDs    []string
+	WindowDays    int
+	MinSimilarity float64
+	Limit         int
+}
+
+// DuplicatePair is two transactions of the same account for the same amount,
+// dated close together, that are likely the same payment recorded twice.
+// Transaction is the one recorded first. Similarity is how alike their
+// descriptions are, from 0 to 1.
+type DuplicatePair struct {
+	Transaction *Transaction
+	Duplicate   *Transaction
+	Similarity  float64
+}
+
+// ResolutionAction is what was done about a flagged pair of transactions.
+type ResolutionAction string
+
+const (
+	// ResolutionMerged keeps one transaction and voids the other.
+	ResolutionMerged ResolutionAction = "merged"
+	// ResolutionDismissed keeps both, and stops the pair being flagged again.
+	ResolutionDismissed ResolutionAction = "dismissed"
+)
+
+// DuplicateResolution is the audit record of a resolved pair. KeptID is the
+// transaction that was retained; DuplicateID was voided by a merge, or left
+// alone when the pair was dismissed.
+type DuplicateResolution struct {
+	ID          string
+	Action      ResolutionAction
+	KeptID      string
+	DuplicateID string
+	ResolvedAt  time.Time
+}
+
+// ResolutionFilter narrows down the audit trail of resolved pairs. A non-nil
+// AccountIDs restricts it to the pairs whose transactions both belong to those
+// accounts, and to nothing if it is empty.
+type ResolutionFilter struct {
+	AccountIDs []string
+}
+
+// normalize applies defaults to the filter and validates it.
+func (f *DuplicateFilter) normalize() error {
+	if f.WindowDays == 0 {
+		f.WindowDays = DefaultDuplicateWindow
+	}
+	if f.WindowDays < 0 || f.WindowDays > MaxDuplicateWindow {
+		return fmt.Errorf("%w: windowDays must be between 1 and %d", ErrInvalidFilter, MaxDuplicateWindow)
+	}
+	if f.MinSimilarity == 0 {
+		f.MinSimilarity = DefaultMinSimilarity
+	}
+	if f.MinSimilarity < 0 || f.MinSimilarity > 1 {
+		return fmt.Errorf("%w: minSimilarity must be between 0 and 1", ErrInvalidFilter)
+	}
+	if f.Limit == 0 {
+		f.Limit = DefaultPageSize
+	}
+	if f.Limit < 0 || f.Limit > MaxPageSize {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidFilter, MaxPageSize)
+	}
+	return nil
+}
+
+// similarity scores how alike two transactions' descriptions are, as the
+// Sørensen–Dice coefficient of their letter pairs, so that "AMAZON MKTP" and
+// "Amazon Marketplace" score well. Transactions given the same payee by a
+// rule are always alike, and so are two without any description.
+func similarity(a, b *Transaction) float64 {
+	if a.Payee != "" && strings.EqualFold(a.Payee, b.Payee) {
+		return 1
+	}
+	first, second := letterPairs(a.Description), letterPairs(b.Description)
+	if len(first) == 0 && len(second) == 0 {
+		return 1
+	}
+	if len(first) == 0 || len(second) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for pair, count := range first {
+		shared += min(count, second[pair])
+	}
+	total := 0
+	for _, count := range first {
+		total += count
+	}
+	for _, count := range second {
+		total += count
+	}
+	return 2 * float64(shared) / float64(total)
+}
+
+// letterPairs counts the adjacent letter and digit pairs within each word of
+// a description, ignoring case and punctuation
+func letterPairs(description string) map[string]int {
+	pairs := map[string]int{}
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		runes := []rune(word)
+		for i := 0; i+1 < len(runes); i++ {
+			pairs[string(runes[i:i+2])]++
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,170 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"time"
+)
+
+// DuplicateService provides the core logic for finding transactions that were
+// recorded twice, such as by overlapping imports, and resolving them.
+type DuplicateService struct {
+	candidateLoader       ForLoadingDuplicateCandidates
+	transactionLoader     ForLoadingTransactions
+	transactionLabeler    ForModifyingTransactionLabels
+	statusModifier        ForModifyingTransactionStatus
+	resolutionPersistence ForSavingDuplicateResolution
+	resolutionLoader      ForLoadingDuplicateResolutions
+	transactor            ForRunningInTransaction
+}
+
+// NewDuplicateService creates a new DuplicateService.
+func NewDuplicateService(candidates ForLoadingDuplicateCandidates, transactionLoader ForLoadingTransactions, transactionLabeler ForModifyingTransactionLabels, statusModifier ForModifyingTransactionStatus, persistence ForSavingDuplicateResolution, loader ForLoadingDuplicateResolutions, transactor ForRunningInTransaction) *DuplicateService {
+	return &DuplicateService{
+		candidateLoader:       candidates,
+		transactionLoader:     transactionLoader,
+		transactionLabeler:    transactionLabeler,
+		statusModifier:        statusModifier,
+		resolutionPersistence: persistence,
+		resolutionLoader:      loader,
+		transactor:            transactor,
+	}
+}
+
+// FindDuplicates returns the pairs of transactions that are likely
+// duplicates, most recent first: those of the same account for the same
+// amount, dated within the filter's window of each other, whose descriptions
+// are similar enough. Voided transactions and dismissed pairs are left out.
+func (s *DuplicateService) FindDuplicates(ctx context.Context, filter DuplicateFilter) ([]*DuplicatePair, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := filter.normalize(); err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.candidateLoader.LoadDuplicateCandidates(ctx, tenantID, filter, maxDuplicateCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := []*DuplicatePair{}
+	for _, candidate := range candidates {
+		candidate.Similarity = similarity(candidate.Transaction, candidate.Duplicate)
+		if candidate.Similarity < filter.MinSimilarity {
+			continue
+		}
+		pairs = append(pairs, candidate)
+		if len(pairs) == filter.Limit {
+			break
+		}
+	}
+	return pairs, nil
+}
+
+// ResolveDuplicate records what was done about a pair of transactions. A
+// merge keeps the transaction with keptID, copies over the category and payee
+// it lacks from the other one, and voids the other one, which keeps its
+// external ID so a re-import does not bring it back. A dismissal leaves both
+// alone. Either way the pair is not flagged again, and the resolution is kept
+// as an audit record. Invalid resolutions are reported as a *ValidationError
+// matching ErrInvalidResolution. Both transactions must belong to the tenant
+// of the principal in ctx.
+func (s *DuplicateService) ResolveDuplicate(ctx context.Context, action ResolutionAction, keptID, duplicateID string) (*DuplicateResolution, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invalid := &ValidationError{Err: ErrInvalidResolution}
+	if action != ResolutionMerged && action != ResolutionDismissed {
+		invalid.Add("action", fmt.Sprintf("unknown action %q", action))
+	}
+	if keptID == "" {
+		invalid.Add("keptID", "is required")
+	}
+	if duplicateID == "" {
+		invalid.Add("duplicateID", "is required")
+	} else if duplicateID == keptID {
+		invalid.Add("duplicateID", "must differ from keptID")
+	}
+	if err := invalid.OrNil(); err != nil {
+		return nil, err
+	}
+
+	kept, err := s.transactionLoader.LoadTransaction(ctx, tenantID, keptID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.transactionLoader.LoadTransaction(ctx, tenantID, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	resolution := &DuplicateResolution{Action: action, KeptID: keptID, DuplicateID: duplicateID, ResolvedAt: time.Now().UTC()}
+	if action == ResolutionDismissed {
+		if err := s.resolutionPersistence.SaveDuplicateResolution(ctx, tenantID, resolution); err != nil {
+			return nil, err
+		}
+		return resolution, nil
+	}
+
+	if duplicate.AccountID != kept.AccountID {
+		invalid.Add("duplicateID", "belongs to a different account")
+	}
+	if duplicate.Amount != kept.Amount {
+		invalid.Add("duplicateID", "has a different amount")
+	}
+	if kept.Status == StatusVoided {
+		invalid.Add("keptID", "is voided")
+	}
+	if duplicate.Status == StatusVoided {
+		invalid.Add("duplicateID", "is already voided")
+	}
+	if duplicate.TransferID != "" {
+		invalid.Add("duplicateID", "is part of a transfer, which must be voided as a whole")
+	}
+	if err := invalid.OrNil(); err != nil {
+		return nil, err
+	}
+
+	labelled := false
+	if kept.CategoryID == "" && duplicate.CategoryID != "" {
+		kept.CategoryID, labelled = duplicate.CategoryID, true
+	}
+	if kept.Payee == "" && duplicate.Payee != "" {
+		kept.Payee, labelled = duplicate.Payee, true
+	}
+	from := duplicate.Status
+	if err := duplicate.Void(); err != nil {
+		return nil, err
+	}
+
+	// Void the duplicate and record the merge as one unit of work, so the
+	// audit trail always matches what happened to the transactions
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if labelled {
+			if err := s.transactionLabeler.ModifyTransactionLabels(ctx, tenantID, kept); err != nil {
+				return err
+			}
+		}
+		if err := s.statusModifier.ModifyTransactionStatus(ctx, tenantID, duplicate, from); err != nil {
+			return err
+		}
+		return s.resolutionPersistence.SaveDuplicateResolution(ctx, tenantID, resolution)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resolution, nil
+}
+
+// ListDuplicateResolutions returns the tenant's audit trail of resolved pairs
+// the filter selects, most recent first.
+func (s *DuplicateService) ListDuplicateResolutions(ctx context.Context, filter ResolutionFilter) ([]*DuplicateResolution, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.resolutionLoader.LoadDuplicateResolutions(ctx, tenantID, filter)
+}
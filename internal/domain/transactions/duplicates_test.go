@@ -0,0 +1,205 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeDuplicateStore simulates loading duplicate candidates and saving and loading resolutions for testing.
+type FakeDuplicateStore struct {
+	Candidates  []*DuplicatePair
+	Resolutions []*DuplicateResolution
+	Filter      DuplicateFilter
+	ReturnError bool
+}
+
+func (f *FakeDuplicateStore) LoadDuplicateCandidates(ctx context.Context, tenantID string, filter DuplicateFilter, limit int) ([]*DuplicatePair, error) {
+	f.Filter = filter
+	return f.Candidates, nil
+}
+
+func (f *FakeDuplicateStore) SaveDuplicateResolution(ctx context.Context, tenantID string, resolution *DuplicateResolution) error {
+	if f.ReturnError {
+		return errors.New("failed to save resolution")
+	}
+	resolution.ID = "1"
+	f.Resolutions = append(f.Resolutions, resolution)
+	return nil
+}
+
+func (f *FakeDuplicateStore) LoadDuplicateResolutions(ctx context.Context, tenantID string, filter ResolutionFilter) ([]*DuplicateResolution, error) {
+	return f.Resolutions, nil
+}
+
+func duplicateTransaction(id, amount, description string) *Transaction {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	transaction := NewTransaction(id, "12345", money.MustParse(amount, "EUR"), KindDebit, date, description)
+	transaction.Status = StatusPosted
+	return transaction
+}
+
+func newTestDuplicateService(store *FakeDuplicateStore, loader *FakeForLoadingTransactions, labeler *FakeForModifyingTransactionLabels, statusModifier *FakeForModifyingTransactionStatus) *DuplicateService {
+	return NewDuplicateService(store, loader, labeler, statusModifier, store, store, &FakeTransactor{})
+}
+
+// Test description similarity tolerates case, punctuation and abbreviation
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, similarity(duplicateTransaction("1", "-1.00", "TESCO STORES 2041"), duplicateTransaction("2", "-1.00", "Tesco Stores, 2041")))
+	assert.Greater(t, similarity(duplicateTransaction("1", "-1.00", "AMAZON MKTP"), duplicateTransaction("2", "-1.00", "Amazon Marketplace")), 0.5)
+	assert.Less(t, similarity(duplicateTransaction("1", "-1.00", "Coffee"), duplicateTransaction("2", "-1.00", "Rent")), 0.1)
+	assert.Equal(t, 1.0, similarity(duplicateTransaction("1", "-1.00", ""), duplicateTransaction("2", "-1.00", "")))
+	assert.Equal(t, 0.0, similarity(duplicateTransaction("1", "-1.00", "Coffee"), duplicateTransaction("2", "-1.00", "")))
+
+	first, second := duplicateTransaction("1", "-1.00", "CARD 1234"), duplicateTransaction("2", "-1.00", "Lidl Berlin")
+	first.Payee, second.Payee = "Lidl", "lidl"
+	assert.Equal(t, 1.0, similarity(first, second), "A shared payee makes transactions alike")
+}
+
+// Test only candidates with similar descriptions are flagged, up to the limit
+func TestDuplicateServiceFindDuplicates(t *testing.T) {
+	store := &FakeDuplicateStore{Candidates: []*DuplicatePair{
+		{Transaction: duplicateTransaction("1", "-4.50", "COFFEE SHOP"), Duplicate: duplicateTransaction("2", "-4.50", "Coffee Shop Berlin")},
+		{Transaction: duplicateTransaction("3", "-4.50", "Coffee"), Duplicate: duplicateTransaction("4", "-4.50", "Bus ticket")},
+		{Transaction: duplicateTransaction("5", "-9.00", "Cinema"), Duplicate: duplicateTransaction("6", "-9.00", "CINEMA")},
+	}}
+	service := newTestDuplicateService(store, &FakeForLoadingTransactions{}, &FakeForModifyingTransactionLabels{}, &FakeForModifyingTransactionStatus{})
+
+	pairs, err := service.FindDuplicates(tenantContext(), DuplicateFilter{AccountID: "12345"})
+	assert.NoError(t, err)
+	assert.Len(t, pairs, 2)
+	assert.Equal(t, "2", pairs[0].Duplicate.ID)
+	assert.Equal(t, 1.0, pairs[1].Similarity)
+	assert.Equal(t, "12345", store.Filter.AccountID)
+	assert.Equal(t, DefaultDuplicateWindow, store.Filter.WindowDays)
+
+	pairs, err = service.FindDuplicates(tenantContext(), DuplicateFilter{MinSimilarity: 1, Limit: 5})
+	assert.NoError(t, err)
+	assert.Len(t, pairs, 1)
+
+	pairs, err = service.FindDuplicates(tenantContext(), DuplicateFilter{Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, pairs, 1)
+}
+
+// Test invalid searches are rejected
+func TestDuplicateServiceFindDuplicates_InvalidFilter(t *testing.T) {
+	service := newTestDuplicateService(&FakeDuplicateStore{}, &FakeForLoadingTransactions{}, &FakeForModifyingTransactionLabels{}, &FakeForModifyingTransactionStatus{})
+
+	for _, filter := range []DuplicateFilter{{WindowDays: 32}, {WindowDays: -1}, {MinSimilarity: 1.5}, {Limit: MaxPageSize + 1}} {
+		_, err := service.FindDuplicates(tenantContext(), filter)
+
+		assert.ErrorIs(t, err, ErrInvalidFilter)
+	}
+}
+
+// Test merging keeps one transaction, fills in its labels and voids the other
+func TestDuplicateServiceResolveDuplicate_Merge(t *testing.T) {
+	kept := duplicateTransaction("1", "-4.50", "COFFEE SHOP")
+	duplicate := duplicateTransaction("2", "-4.50", "Coffee Shop")
+	duplicate.CategoryID, duplicate.Payee = "7", "Coffee Shop"
+	store := &FakeDuplicateStore{}
+	labeler := &FakeForModifyingTransactionLabels{}
+	statusModifier := &FakeForModifyingTransactionStatus{}
+	service := newTestDuplicateService(store, &FakeForLoadingTransactions{Transactions: []*Transaction{kept, duplicate}}, labeler, statusModifier)
+
+	resolution, err := service.ResolveDuplicate(tenantContext(), ResolutionMerged, "1", "2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", resolution.ID)
+	assert.Equal(t, "1", resolution.KeptID)
+	assert.Equal(t, "2", resolution.DuplicateID)
+	assert.False(t, resolution.ResolvedAt.IsZero())
+	assert.Equal(t, []*Transaction{kept}, labeler.Modified)
+	assert.Equal(t, "7", kept.CategoryID)
+	assert.Equal(t, "Coffee Shop", kept.Payee)
+	assert.Equal(t, []*Transaction{duplicate}, statusModifier.Modified)
+	assert.Equal(t, []Status{StatusPosted}, statusModifier.From)
+	assert.Equal(t, StatusVoided, duplicate.Status)
+	assert.Len(t, store.Resolutions, 1)
+}
+
+// Test dismissing a pair only records the decision
+func TestDuplicateServiceResolveDuplicate_Dismiss(t *testing.T) {
+	store := &FakeDuplicateStore{}
+	labeler := &FakeForModifyingTransactionLabels{}
+	statusModifier := &FakeForModifyingTransactionStatus{}
+	loader := &FakeForLoadingTransactions{Transactions: []*Transaction{duplicateTransaction("1", "-4.50", "Coffee"), duplicateTransaction("2", "-5.00", "Coffee")}}
+	service := newTestDuplicateService(store, loader, labeler, statusModifier)
+
+	resolution, err := service.ResolveDuplicate(tenantContext(), ResolutionDismissed, "1", "2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, ResolutionDismissed, resolution.Action)
+	assert.Empty(t, labeler.Modified)
+	assert.Empty(t, statusModifier.Modified)
+
+	resolutions, err := service.ListDuplicateResolutions(tenantContext(), ResolutionFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*DuplicateResolution{resolution}, resolutions)
+}
+
+// Test pairs that cannot be merged are rejected before anything changes
+func TestDuplicateServiceResolveDuplicate_Invalid(t *testing.T) {
+	voided := duplicateTransaction("3", "-4.50", "Coffee")
+	voided.Status = StatusVoided
+	otherAccount := duplicateTransaction("4", "-4.50", "Coffee")
+	otherAccount.AccountID = "67890"
+	loader := &FakeForLoadingTransactions{Transactions: []*Transaction{
+		duplicateTransaction("1", "-4.50", "Coffee"), duplicateTransaction("2", "-5.00", "Coffee"), voided, otherAccount,
+	}}
+	cases := map[string]struct {
+		action      ResolutionAction
+		keptID      string
+		duplicateID string
+		fields      []FieldError
+	}{
+		"unknown action":    {"ignore", "1", "2", []FieldError{{"action", `unknown action "ignore"`}}},
+		"missing IDs":       {ResolutionMerged, "", "", []FieldError{{"keptID", "is required"}, {"duplicateID", "is required"}}},
+		"same transaction":  {ResolutionMerged, "1", "1", []FieldError{{"duplicateID", "must differ from keptID"}}},
+		"different amount":  {ResolutionMerged, "1", "2", []FieldError{{"duplicateID", "has a different amount"}}},
+		"already voided":    {ResolutionMerged, "1", "3", []FieldError{{"duplicateID", "is already voided"}}},
+		"different account": {ResolutionMerged, "1", "4", []FieldError{{"duplicateID", "belongs to a different account"}}},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			store := &FakeDuplicateStore{}
+			statusModifier := &FakeForModifyingTransactionStatus{}
+			service := newTestDuplicateService(store, loader, &FakeForModifyingTransactionLabels{}, statusModifier)
+
+			_, err := service.ResolveDuplicate(tenantContext(), c.action, c.keptID, c.duplicateID)
+
+			var invalid *ValidationError
+			assert.ErrorAs(t, err, &invalid)
+			assert.ErrorIs(t, err, ErrInvalidResolution)
+			assert.Equal(t, c.fields, invalid.Fields)
+			assert.Empty(t, statusModifier.Modified)
+			assert.Empty(t, store.Resolutions)
+		})
+	}
+
+	service := newTestDuplicateService(&FakeDuplicateStore{}, loader, &FakeForModifyingTransactionLabels{}, &FakeForModifyingTransactionStatus{})
+	_, err := service.ResolveDuplicate(tenantContext(), ResolutionMerged, "1", "99")
+	assert.ErrorIs(t, err, ErrTransactionNotFound)
+}
+
+// Test merging a transfer leg away as a duplicate is refused, as it would void only one side of the transfer
+func TestDuplicateServiceResolveDuplicate_TransferLeg(t *testing.T) {
+	duplicate := duplicateTransaction("2", "-250.00", "Savings")
+	duplicate.Type, duplicate.TransferID = KindTransferOut, "4"
+	store := &FakeDuplicateStore{}
+	loader := &FakeForLoadingTransactions{Transactions: []*Transaction{duplicateTransaction("1", "-250.00", "Savings"), duplicate}}
+	modifier := &FakeForModifyingTransactionStatus{}
+	duplicateService := newTestDuplicateService(store, loader, &FakeForModifyingTransactionLabels{}, modifier)
+
+	_, err := duplicateService.ResolveDuplicate(tenantContext(), ResolutionMerged, "1", "2")
+
+	assert.True(t, errors.Is(err, ErrInvalidResolution), "Expected ErrInvalidResolution")
+	assert.Empty(t, modifier.Modified)
+	assert.Empty(t, store.Resolutions)
+}
@@ -0,0 +1,56 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/auth"
+)
+
+// EditService provides the core logic for correcting recorded transactions.
+type EditService struct {
+	transactionLoader   ForLoadingTransactions
+	transactionModifier ForModifyingTransaction
+	transactor          ForRunningInTransaction
+}
+
+// NewEditService creates a new EditService.
+func NewEditService(loader ForLoadingTransactions, modifier ForModifyingTransaction, transactor ForRunningInTransaction) *EditService {
+	return &EditService{
+		transactionLoader:   loader,
+		transactionModifier: modifier,
+		transactor:          transactor,
+	}
+}
+
+// UpdateTransaction changes the description and/or amount of the transaction
+// with the given ID. Invalid changes are reported together as a
+// *ValidationError. Unless version is zero, the transaction must still be at
+// that version, or ErrVersionMismatch is returned and nothing changes.
+func (s *EditService) UpdateTransaction(ctx context.Context, id string, changes TransactionChanges, version int) (*Transaction, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := s.transactionLoader.LoadTransaction(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && transaction.Version != version {
+		return nil, fmt.Errorf("%w: transaction %s is at version %d, not %d", ErrVersionMismatch, id, transaction.Version, version)
+	}
+
+	previous := transaction.Amount
+	if err := transaction.Edit(changes); err != nil {
+		return nil, err
+	}
+
+	// Persistence corrects the balance data derived from the amount as it
+	// goes, so the edit runs as one unit of work
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.transactionModifier.ModifyTransaction(ctx, tenantID, transaction, previous)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
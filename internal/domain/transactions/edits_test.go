@@ -0,0 +1,131 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+)
+
+// FakeForModifyingTransaction simulates persisting transaction edits for testing.
+type FakeForModifyingTransaction struct {
+	ReturnError bool
+	Modified    []*Transaction
+	Previous    []money.Money
+}
+
+func (f *FakeForModifyingTransaction) ModifyTransaction(ctx context.Context, tenantID string, transaction *Transaction, previous money.Money) error {
+	if f.ReturnError {
+		return errors.New("failed to modify transaction")
+	}
+	f.Modified = append(f.Modified, transaction)
+	f.Previous = append(f.Previous, previous)
+	transaction.Version++
+	return nil
+}
+
+func newEditableTransaction() *Transaction {
+	transaction := NewTransaction("7", "12345", money.MustParse("-4.50", "EUR"), KindDebit, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), "Coffe")
+	transaction.Version = 3
+	return transaction
+}
+
+// Test editing a transaction's description and amount persists both and remembers the old amount
+func TestEditServiceUpdateTransaction(t *testing.T) {
+	modifier := &FakeForModifyingTransaction{}
+	transactor := &FakeTransactor{}
+	editService := NewEditService(&FakeForLoadingTransactions{Transactions: []*Transaction{newEditableTransaction()}}, modifier, transactor)
+	description, amount := "Coffee", money.MustParse("-5.40", "EUR")
+
+	transaction, err := editService.UpdateTransaction(tenantContext(), "7", TransactionChanges{Description: &description, Amount: &amount}, 3)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Coffee", transaction.Description)
+	assert.Equal(t, amount, transaction.Amount)
+	assert.Equal(t, 4, transaction.Version)
+	assert.Equal(t, []money.Money{money.MustParse("-4.50", "EUR")}, modifier.Previous)
+	assert.Equal(t, 1, transactor.Calls)
+}
+
+// Test fields left out of the changes keep their values, and a zero version skips the check
+func TestEditServiceUpdateTransaction_Partial(t *testing.T) {
+	modifier := &FakeForModifyingTransaction{}
+	editService := NewEditService(&FakeForLoadingTransactions{Transactions: []*Transaction{newEditableTransaction()}}, modifier, &FakeTransactor{})
+	description := "Coffee"
+
+	transaction, err := editService.UpdateTransaction(tenantContext(), "7", TransactionChanges{Description: &description}, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Coffee", transaction.Description)
+	assert.Equal(t, money.MustParse("-4.50", "EUR"), transaction.Amount)
+}
+
+// Test editing a transaction that changed since the given version is refused
+func TestEditServiceUpdateTransaction_VersionMismatch(t *testing.T) {
+	modifier := &FakeForModifyingTransaction{}
+	editService := NewEditService(&FakeForLoadingTransactions{Transactions: []*Transaction{newEditableTransaction()}}, modifier, &FakeTransactor{})
+	description := "Coffee"
+
+	transaction, err := editService.UpdateTransaction(tenantContext(), "7", TransactionChanges{Description: &description}, 2)
+
+	assert.True(t, errors.Is(err, ErrVersionMismatch), "Expected ErrVersionMismatch")
+	assert.Nil(t, transaction)
+	assert.Empty(t, modifier.Modified)
+}
+
+// Test invalid edits are reported together and nothing is persisted
+func TestEditServiceUpdateTransaction_Invalid(t *testing.T) {
+	voided := newEditableTransaction()
+	voided.Status = StatusVoided
+	leg := newEditableTransaction()
+	leg.ID, leg.TransferID = "8", "4"
+	tests := map[string]struct {
+		transaction *Transaction
+		amount      money.Money
+		fields      []string
+	}{
+		"wrong sign":     {newEditableTransaction(), money.MustParse("4.50", "EUR"), []string{"amount"}},
+		"wrong currency": {newEditableTransaction(), money.MustParse("-4.50", "USD"), []string{"currency"}},
+		"voided":         {voided, money.MustParse("4.50", "EUR"), []string{"status", "amount"}},
+		"transfer leg":   {leg, money.MustParse("-5.00", "EUR"), []string{"amount"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			modifier := &FakeForModifyingTransaction{}
+			editService := NewEditService(&FakeForLoadingTransactions{Transactions: []*Transaction{tt.transaction}}, modifier, &FakeTransactor{})
+
+			_, err := editService.UpdateTransaction(tenantContext(), tt.transaction.ID, TransactionChanges{Amount: &tt.amount}, 0)
+
+			var invalid *ValidationError
+			assert.True(t, errors.As(err, &invalid), "Expected a ValidationError")
+			var fields []string
+			for _, field := range invalid.Fields {
+				fields = append(fields, field.Field)
+			}
+			assert.Equal(t, tt.fields, fields)
+			assert.Empty(t, modifier.Modified)
+		})
+	}
+}
+
+// Test editing a transaction that does not exist
+func TestEditServiceUpdateTransaction_NotFound(t *testing.T) {
+	editService := NewEditService(&FakeForLoadingTransactions{}, &FakeForModifyingTransaction{}, &FakeTransactor{})
+	description := "Coffee"
+
+	_, err := editService.UpdateTransaction(tenantContext(), "7", TransactionChanges{Description: &description}, 0)
+
+	assert.True(t, errors.Is(err, ErrTransactionNotFound), "Expected ErrTransactionNotFound")
+}
+
+// Test persistence failures are passed on
+func TestEditServiceUpdateTransaction_ModifyError(t *testing.T) {
+	editService := NewEditService(&FakeForLoadingTransactions{Transactions: []*Transaction{newEditableTransaction()}}, &FakeForModifyingTransaction{ReturnError: true}, &FakeTransactor{})
+	description := "Coffee"
+
+	_, err := editService.UpdateTransaction(tenantContext(), "7", TransactionChanges{Description: &description}, 0)
+
+	assert.NotNil(t, err)
+}
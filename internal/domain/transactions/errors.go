@@ -0,0 +1,82 @@
+package transactions
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidFilter is returned when a transaction listing is requested with an invalid filter or cursor.
+var ErrInvalidFilter = errors.New("invalid transaction filter")
+
+// ErrAccountNotFound is returned when a transaction refers to an account that does not exist.
+var ErrAccountNotFound = errors.New("account not found")
+
+// ErrTransactionNotFound is returned when no transaction exists with the requested ID.
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// ErrInvalidTransaction is returned when a transaction's details are inconsistent.
+var ErrInvalidTransaction = errors.New("invalid transaction")
+
+// ErrDuplicateTransaction is returned when a transaction with the same external ID has
+// already been imported into the account.
+var ErrDuplicateTransaction = errors.New("transaction already imported")
+
+// ErrVersionMismatch is returned when a transaction changed since the version the caller last read.
+var ErrVersionMismatch = errors.New("transaction version mismatch")
+
+// ErrInvalidStatusTransition is returned when a transaction cannot move to the requested status.
+var ErrInvalidStatusTransition = errors.New("invalid transaction status transition")
+
+// ErrInvalidResolution is returned when a pair of transactions cannot be resolved as asked.
+var ErrInvalidResolution = errors.New("invalid duplicate resolution")
+
+// ErrInvalidTransfer is returned when a transfer between accounts fails validation.
+var ErrInvalidTransfer = errors.New("invalid transfer")
+
+// ErrRuleNotFound is returned when no categorisation rule exists with the requested ID.
+var ErrRuleNotFound = errors.New("rule not found")
+
+// ErrInvalidRule is returned when a categorisation rule's details fail validation.
+var ErrInvalidRule = errors.New("invalid rule")
+
+// FieldError describes a problem with a single input field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError is returned when a transaction's, rule's or transfer's
+// details fail validation. It lists every offending field and matches Err, or
+// ErrInvalidTransaction if Err is nil, with errors.Is.
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+// Add records a problem with the named field.
+func (e *ValidationError) Add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// OrNil returns the error if any field was recorded, and nil otherwise.
+func (e *ValidationError) OrNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+	return e.Unwrap().Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	if e.Err == nil {
+		return ErrInvalidTransaction
+	}
+	return e.Err
+}
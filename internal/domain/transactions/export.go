@@ -0,0 +1,41 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/auth"
+)
+
+// ExportService provides the core logic for exporting transactions in bulk.
+type ExportService struct {
+	transactionStreamer ForStreamingTransactions
+}
+
+// NewExportService creates a new ExportService.
+func NewExportService(streamer ForStreamingTransactions) *ExportService {
+	return &ExportService{
+		transactionStreamer: streamer,
+	}
+}
+
+// ExportTransactions hands every transaction matching the filter to each, in
+// the filter's order, without holding the whole set in memory. Exports are
+// oldest first unless the filter asks otherwise, and are not paginated, so
+// the filter cannot set a limit or cursor. It stops at the first error each
+// returns. Only the tenant's transactions are exported.
+func (s *ExportService) ExportTransactions(ctx context.Context, filter TransactionFilter, each func(*Transaction) error) error {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+	if filter.Limit != 0 || filter.Cursor != "" {
+		return fmt.Errorf("%w: exports are not paginated", ErrInvalidFilter)
+	}
+	if filter.SortOrder == "" {
+		filter.SortOrder = SortAscending
+	}
+	if err := filter.normalize(); err != nil {
+		return err
+	}
+	return s.transactionStreamer.StreamTransactions(ctx, tenantID, filter, each)
+}
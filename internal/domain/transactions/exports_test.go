@@ -0,0 +1,86 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeForStreamingTransactions simulates streaming transactions from the persistence layer for testing.
+type FakeForStreamingTransactions struct {
+	Transactions []*Transaction
+	Filter       TransactionFilter
+	Calls        int
+}
+
+func (f *FakeForStreamingTransactions) StreamTransactions(ctx context.Context, tenantID string, filter TransactionFilter, each func(*Transaction) error) error {
+	f.Filter = filter
+	f.Calls++
+	for _, transaction := range f.Transactions {
+		if err := each(transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Test exporting streams every transaction, oldest first by default
+func TestExportServiceExportTransactions(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	streamer := &FakeForStreamingTransactions{Transactions: []*Transaction{
+		NewTransaction("1", "12345", money.MustParse("-4.50", "EUR"), KindDebit, date, "Coffee"),
+		NewTransaction("2", "12345", money.MustParse("100.00", "EUR"), KindCredit, date, "Salary"),
+	}}
+	service := NewExportService(streamer)
+
+	var exported []string
+	err := service.ExportTransactions(tenantContext(), TransactionFilter{AccountID: "12345"}, func(transaction *Transaction) error {
+		exported = append(exported, transaction.ID)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, exported)
+	assert.Equal(t, "12345", streamer.Filter.AccountID)
+	assert.Equal(t, SortByDate, streamer.Filter.SortBy)
+	assert.Equal(t, SortAscending, streamer.Filter.SortOrder)
+}
+
+// Test an error from the consumer stops the export
+func TestExportServiceExportTransactions_ConsumerError(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	streamer := &FakeForStreamingTransactions{Transactions: []*Transaction{
+		NewTransaction("1", "12345", money.MustParse("-4.50", "EUR"), KindDebit, date, "Coffee"),
+		NewTransaction("2", "12345", money.MustParse("-5.00", "EUR"), KindDebit, date, "Lunch"),
+	}}
+	stop := errors.New("client went away")
+	calls := 0
+
+	err := NewExportService(streamer).ExportTransactions(tenantContext(), TransactionFilter{}, func(*Transaction) error {
+		calls++
+		return stop
+	})
+
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, calls)
+}
+
+// Test invalid and paginated exports are rejected before anything is read
+func TestExportServiceExportTransactions_InvalidFilter(t *testing.T) {
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filters := []TransactionFilter{{Limit: 10}, {Cursor: "abc"}, {Status: "lost"}, {From: &from, To: &to}}
+
+	for _, filter := range filters {
+		streamer := &FakeForStreamingTransactions{}
+
+		err := NewExportService(streamer).ExportTransactions(tenantContext(), filter, func(*Transaction) error { return nil })
+
+		assert.ErrorIs(t, err, ErrInvalidFilter)
+		assert.Zero(t, streamer.Calls)
+	}
+}
@@ -0,0 +1,139 @@
+package transactions
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// SortField identifies the value transactions are ordered by.
+type SortField string
+
+const (
+	SortByDate   SortField = "date"
+	SortByAmount SortField = "amount"
+)
+
+// SortOrder identifies the direction transactions are ordered in.
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+const (
+	// DefaultPageSize is used when a listing does not ask for a specific page size.
+	DefaultPageSize = 50
+	// MaxPageSize caps the number of transactions returned in a single page.
+	MaxPageSize = 500
+)
+
+// TransactionFilter narrows down and orders a transaction listing. Zero
+// values mean "no restriction"; date bounds are inclusive. CategoryID matches
+// the category and all of its subcategories, and TransferID selects the legs
+// of a single transfer. A non-nil AccountIDs restricts the listing to those
+// accounts, and to nothing if it is empty.
+type TransactionFilter struct {
+	AccountID   string
+	AccountIDs  []string
+	CategoryID  string
+	From        *time.Time
+	To          *time.Time
+	Type        Kind
+	Status      Status
+	MinAmount   *money.Decimal
+	MaxAmount   *money.Decimal
+	Description string
+	TransferID  string
+	SortBy      SortField
+	SortOrder   SortOrder
+	Limit       int
+	Cursor      string
+}
+
+// TransactionPage is a single page of a transaction listing. NextCursor is
+// empty when there are no further pages.
+type TransactionPage struct {
+	Transactions []*Transaction
+	NextCursor   string
+}
+
+// PageCursor marks the position of the last transaction of a page in the
+// listing's sort order. Clients only ever see it in its encoded, opaque form.
+type PageCursor struct {
+	SortBy SortField `json:"s"`
+	Date   time.Time `json:"d,omitempty"`
+	Amount string    `json:"a,omitempty"`
+	ID     string    `json:"i"`
+}
+
+// normalize applies defaults to the filter and validates it.
+func (f *TransactionFilter) normalize() error {
+	if f.SortBy == "" {
+		f.SortBy = SortByDate
+	}
+	if f.SortBy != SortByDate && f.SortBy != SortByAmount {
+		return fmt.Errorf("%w: unknown sort field %q", ErrInvalidFilter, f.SortBy)
+	}
+	if f.SortOrder == "" {
+		f.SortOrder = SortDescending
+	}
+	if f.SortOrder != SortAscending && f.SortOrder != SortDescending {
+		return fmt.Errorf("%w: unknown sort order %q", ErrInvalidFilter, f.SortOrder)
+	}
+	if f.Type != "" && !f.Type.IsValid() {
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidFilter, f.Type)
+	}
+	if f.Status != "" && f.Status != StatusPending && f.Status != StatusPosted && f.Status != StatusVoided {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidFilter, f.Status)
+	}
+	if f.Limit == 0 {
+		f.Limit = DefaultPageSize
+	}
+	if f.Limit < 0 || f.Limit > MaxPageSize {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidFilter, MaxPageSize)
+	}
+	if f.From != nil && f.To != nil && f.From.After(*f.To) {
+		return fmt.Errorf("%w: from must not be after to", ErrInvalidFilter)
+	}
+	if f.MinAmount != nil && f.MaxAmount != nil && f.MinAmount.Cmp(*f.MaxAmount) > 0 {
+		return fmt.Errorf("%w: minAmount must not be greater than maxAmount", ErrInvalidFilter)
+	}
+	return nil
+}
+
+// cursorAfter builds the cursor pointing just past the given transaction.
+func cursorAfter(transaction *Transaction, sortBy SortField) *PageCursor {
+	return &PageCursor{
+		SortBy: sortBy,
+		Date:   transaction.Timestamp,
+		Amount: transaction.Amount.Decimal().String(),
+		ID:     transaction.ID,
+	}
+}
+
+// EncodeCursor turns a cursor into the opaque token handed to clients.
+func EncodeCursor(cursor *PageCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses an opaque cursor token produced by EncodeCursor,
+// checking that it belongs to a listing with the given sort field.
+func DecodeCursor(token string, sortBy SortField) (*PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidFilter)
+	}
+	cursor := &PageCursor{}
+	if err := json.Unmarshal(raw, cursor); err != nil || cursor.ID == "" {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidFilter)
+	}
+	if cursor.SortBy != sortBy {
+		return nil, fmt.Errorf("%w: cursor does not match sort field", ErrInvalidFilter)
+	}
+	return cursor, nil
+}
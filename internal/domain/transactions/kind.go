@@ -0,0 +1,61 @@
+package transactions
+
+import (
+	"fmt"
+	"spend-api/internal/domain/money"
+)
+
+// Kind classifies a transaction. Amounts are signed from the account's point
+// of view: money leaving the account is negative, money arriving is positive.
+type Kind string
+
+const (
+	KindDebit       Kind = "debit"
+	KindCredit      Kind = "credit"
+	KindFee         Kind = "fee"
+	KindInterest    Kind = "interest"
+	KindRefund      Kind = "refund"
+	KindTransferIn  Kind = "transfer_in"
+	KindTransferOut Kind = "transfer_out"
+	KindAdjustment  Kind = "adjustment"
+)
+
+// kindSigns gives the sign each kind's amount must have; 0 allows either sign
+var kindSigns = map[Kind]int{
+	KindDebit:       -1,
+	KindFee:         -1,
+	KindTransferOut: -1,
+	KindCredit:      1,
+	KindInterest:    1,
+	KindRefund:      1,
+	KindTransferIn:  1,
+	KindAdjustment:  0,
+}
+
+// Kinds lists every transaction kind.
+var Kinds = []Kind{KindDebit, KindCredit, KindFee, KindInterest, KindRefund, KindTransferIn, KindTransferOut, KindAdjustment}
+
+// IsValid reports whether the kind is one of the known kinds.
+func (k Kind) IsValid() bool {
+	_, ok := kindSigns[k]
+	return ok
+}
+
+// CheckAmount reports whether the amount follows the kind's sign convention.
+// Zero amounts are never valid.
+func (k Kind) CheckAmount(amount money.Money) error {
+	if amount.IsZero() {
+		return fmt.Errorf("amount must not be zero")
+	}
+	switch kindSigns[k] {
+	case -1:
+		if !amount.IsNegative() {
+			return fmt.Errorf("a %s must have a negative amount", k)
+		}
+	case 1:
+		if !amount.IsPositive() {
+			return fmt.Errorf("a %s must have a positive amount", k)
+		}
+	}
+	return nil
+}
@@ -1,25 +1,129 @@
 package transactions
 
-import "time"
+import (
+	"fmt"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// Status is the point a transaction has reached in its lifecycle. A
+// transaction starts out pending or posted, a pending transaction can be
+// posted, and either can be voided. Voided is final.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusPosted  Status = "posted"
+	StatusVoided  Status = "voided"
+)
 
 // Transaction represents a financial transaction associated with an account.
+// Timestamp is the date the transaction took place; PostedDate is the date the
+// bank booked it and is only set once the transaction has been posted.
+// CategoryID is empty for uncategorised transactions and Payee is the
+// normalised counterparty set by categorisation rules. ExternalID is the
+// bank's identifier for transactions imported from a statement and is empty
+// for those entered by hand. TransferID links the two legs of a transfer
+// between accounts and is empty for other transactions. Version starts at 1
+// and goes up with every change, so a client can tell whether the transaction
+// changed since it last read it. RunningBalance is the account's balance just
+// after the transaction and is only filled in by listings that track it.
 type Transaction struct {
-	ID          string
-	AccountID   string
-	Amount      float64
-	Type        string
-	Timestamp   time.Time
-	Description string
+	ID             string
+	AccountID      string
+	Amount         money.Money
+	Type           Kind
+	Timestamp      time.Time
+	PostedDate     *time.Time
+	Status         Status
+	Description    string
+	CategoryID     string
+	Payee          string
+	ExternalID     string
+	TransferID     string
+	Version        int
+	RunningBalance *money.Money
+}
+
+// TransactionChanges lists the edits to make to a transaction. Nil fields are
+// left as they are.
+type TransactionChanges struct {
+	Description *string
+	Amount      *money.Money
 }
 
-// NewTransaction creates a new transaction.
-func NewTransaction(id, accountID string, amount float64, txnType string, timestamp time.Time, description string) *Transaction {
+// NewTransaction creates a new pending transaction.
+func NewTransaction(id, accountID string, amount money.Money, kind Kind, timestamp time.Time, description string) *Transaction {
 	return &Transaction{
 		ID:          id,
 		AccountID:   accountID,
 		Amount:      amount,
-		Type:        txnType,
+		Type:        kind,
 		Timestamp:   timestamp,
+		Status:      StatusPending,
 		Description: description,
+		Version:     1,
+	}
+}
+
+// Edit applies the changes, reporting every invalid one together as a
+// *ValidationError. A voided transaction cannot be edited, a new amount must
+// stay in the transaction's currency and follow its kind's sign convention,
+// and the amount of a transfer leg cannot change on its own.
+func (t *Transaction) Edit(changes TransactionChanges) error {
+	invalid := &ValidationError{}
+	if t.Status == StatusVoided {
+		invalid.Add("status", "a voided transaction cannot be edited")
+	}
+	if amount := changes.Amount; amount != nil {
+		if t.TransferID != "" {
+			invalid.Add("amount", "is part of a transfer, which must be voided and made again")
+		} else if amount.Currency() != t.Amount.Currency() {
+			invalid.Add("currency", fmt.Sprintf("transaction is held in %s", t.Amount.Currency()))
+		} else if err := t.Type.CheckAmount(*amount); err != nil {
+			invalid.Add("amount", err.Error())
+		}
+	}
+	if err := invalid.OrNil(); err != nil {
+		return err
+	}
+
+	if changes.Description != nil {
+		t.Description = *changes.Description
 	}
+	if changes.Amount != nil {
+		t.Amount = *changes.Amount
+	}
+	return nil
+}
+
+// Post marks a pending transaction as booked by the bank on the given date,
+// which cannot be before the transaction date.
+func (t *Transaction) Post(on time.Time) error {
+	if t.Status != StatusPending {
+		return fmt.Errorf("%w: cannot post a %s transaction", ErrInvalidStatusTransition, t.Status)
+	}
+	on = DateOf(on)
+	if on.Before(DateOf(t.Timestamp)) {
+		return fmt.Errorf("%w: posted date %s is before the transaction date %s", ErrInvalidTransaction, on.Format(time.DateOnly), t.Timestamp.Format(time.DateOnly))
+	}
+	t.Status = StatusPosted
+	t.PostedDate = &on
+	return nil
+}
+
+// Void cancels a pending or posted transaction.
+func (t *Transaction) Void() error {
+	if t.Status == StatusVoided {
+		return fmt.Errorf("%w: transaction is already voided", ErrInvalidStatusTransition)
+	}
+	t.Status = StatusVoided
+	return nil
+}
+
+// DateOf drops the time of day, keeping the calendar date as a UTC midnight.
+// Transaction and posted dates are whole days.
+func DateOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
 }
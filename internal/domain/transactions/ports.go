@@ -1,11 +1,245 @@
 package transactions
 
-// ForCreatingTransaction defines the port for creating a transaction.
+import (
+	"context"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// ForCreatingTransaction defines the port for creating a transaction. A nil
+// postedDate creates a pending transaction and an empty categoryID leaves it
+// uncategorised.
 type ForCreatingTransaction interface {
-	CreateTransaction(accountID string, amount float64, txnType, description string) (*Transaction, error)
+	CreateTransaction(ctx context.Context, accountID string, amount money.Money, kind Kind, description, categoryID string, date time.Time, postedDate *time.Time) (*Transaction, error)
+}
+
+// ForImportingTransaction defines the port for recording a posted transaction taken from
+// a bank statement. A non-empty externalID identifies it so it is only imported once.
+type ForImportingTransaction interface {
+	ImportTransaction(ctx context.Context, accountID string, amount money.Money, kind Kind, description, externalID string, date, postedDate time.Time) (*Transaction, error)
+}
+
+// ForGettingTransaction defines the port for retrieving a single transaction.
+type ForGettingTransaction interface {
+	GetTransaction(ctx context.Context, id string) (*Transaction, error)
+}
+
+// ForUpdatingTransaction defines the port for editing a transaction's description and amount.
+// A non-zero version must match the transaction's current version.
+type ForUpdatingTransaction interface {
+	UpdateTransaction(ctx context.Context, id string, changes TransactionChanges, version int) (*Transaction, error)
+}
+
+// ForPostingTransaction defines the port for marking a pending transaction as posted.
+type ForPostingTransaction interface {
+	PostTransaction(ctx context.Context, id string, postedDate time.Time) (*Transaction, error)
+}
+
+// ForVoidingTransaction defines the port for voiding a transaction.
+type ForVoidingTransaction interface {
+	VoidTransaction(ctx context.Context, id string) (*Transaction, error)
+}
+
+// ForCreatingTransfer defines the port for moving money between two accounts. A zero date
+// means today.
+type ForCreatingTransfer interface {
+	CreateTransfer(ctx context.Context, fromAccountID, toAccountID string, amount money.Money, description string, date time.Time) (*Transfer, error)
+}
+
+// ForCategorizingTransactions defines the port for moving existing transactions into a
+// category in bulk. An empty categoryID makes them uncategorised.
+type ForCategorizingTransactions interface {
+	CategorizeTransactions(ctx context.Context, ids []string, categoryID string) (int, error)
+}
+
+// ForListingTransactions defines the port for listing transactions page by page.
+type ForListingTransactions interface {
+	ListTransactions(ctx context.Context, filter TransactionFilter) (*TransactionPage, error)
+}
+
+// ForExportingTransactions defines the port for exporting every transaction matching a filter,
+// handing them to each one at a time.
+type ForExportingTransactions interface {
+	ExportTransactions(ctx context.Context, filter TransactionFilter, each func(*Transaction) error) error
+}
+
+// ForGettingBalance defines the port for getting an account's balance today and, optionally,
+// at the end of the asOf day. An empty currency keeps the account's own.
+type ForGettingBalance interface {
+	GetBalance(ctx context.Context, accountID string, asOf *time.Time, currency money.Currency) (*AccountBalance, error)
+}
+
+// ForCreatingRule defines the port for creating a categorisation rule.
+type ForCreatingRule interface {
+	CreateRule(ctx context.Context, rule *Rule) (*Rule, error)
+}
+
+// ForGettingRule defines the port for retrieving a single categorisation rule.
+type ForGettingRule interface {
+	GetRule(ctx context.Context, id string) (*Rule, error)
+}
+
+// ForListingRules defines the port for listing categorisation rules in the order they are tried.
+type ForListingRules interface {
+	ListRules(ctx context.Context) ([]*Rule, error)
+}
+
+// ForUpdatingRule defines the port for replacing the conditions and actions of a categorisation rule.
+type ForUpdatingRule interface {
+	UpdateRule(ctx context.Context, id string, rule *Rule) (*Rule, error)
+}
+
+// ForDeletingRule defines the port for deleting a categorisation rule.
+type ForDeletingRule interface {
+	DeleteRule(ctx context.Context, id string) error
+}
+
+// ForApplyingRules defines the port for applying categorisation rules to existing transactions.
+type ForApplyingRules interface {
+	ApplyRules(ctx context.Context, application RuleApplication) (*RuleRun, error)
+}
+
+// ForFindingDuplicates defines the port for finding transactions that are likely duplicates.
+type ForFindingDuplicates interface {
+	FindDuplicates(ctx context.Context, filter DuplicateFilter) ([]*DuplicatePair, error)
+}
+
+// ForResolvingDuplicate defines the port for merging or dismissing a pair of likely duplicates.
+type ForResolvingDuplicate interface {
+	ResolveDuplicate(ctx context.Context, action ResolutionAction, keptID, duplicateID string) (*DuplicateResolution, error)
+}
+
+// ForListingDuplicateResolutions defines the port for listing the audit trail of resolved duplicates.
+type ForListingDuplicateResolutions interface {
+	ListDuplicateResolutions(ctx context.Context, filter ResolutionFilter) ([]*DuplicateResolution, error)
 }
 
 // ForSavingTransaction defines the port for saving a transaction in the persistence layer.
 type ForSavingTransaction interface {
-	SaveTransaction(transaction *Transaction) error
+	SaveTransaction(ctx context.Context, tenantID string, transaction *Transaction) error
+}
+
+// ForSavingTransfer defines the port for saving a transfer in the persistence layer. It assigns
+// the transfer's ID; its legs are saved separately.
+type ForSavingTransfer interface {
+	SaveTransfer(ctx context.Context, tenantID string, transfer *Transfer) error
+}
+
+// ForLoadingTransactions defines the port for loading a tenant's transactions from the persistence
+// layer; transactions of other tenants are not found.
+// LoadTransactions returns filtered, ordered transactions; only those positioned strictly after
+// the cursor are returned, if one is given.
+type ForLoadingTransactions interface {
+	LoadTransaction(ctx context.Context, tenantID, id string) (*Transaction, error)
+	LoadTransactions(ctx context.Context, tenantID string, filter TransactionFilter, after *PageCursor, limit int) ([]*Transaction, error)
+}
+
+// ForModifyingTransaction defines the port for persisting edits to a transaction's description
+// and amount. The edit only applies if the stored transaction is still at transaction.Version, so
+// concurrent edits cannot both succeed; both versions then go up by one. previous is the amount
+// before the edit, so balance data derived from it can be corrected.
+type ForModifyingTransaction interface {
+	ModifyTransaction(ctx context.Context, tenantID string, transaction *Transaction, previous money.Money) error
+}
+
+// ForModifyingTransactionStatus defines the port for persisting a status change. The change
+// only applies if the stored transaction is still in the from status, so concurrent changes
+// cannot both succeed. It advances the transaction's version.
+type ForModifyingTransactionStatus interface {
+	ModifyTransactionStatus(ctx context.Context, tenantID string, transaction *Transaction, from Status) error
+}
+
+// ForModifyingTransactionCategory defines the port for persisting the category of several
+// transactions at once. It returns how many transactions changed.
+type ForModifyingTransactionCategory interface {
+	ModifyTransactionCategory(ctx context.Context, tenantID string, ids []string, categoryID string) (int, error)
+}
+
+// ForModifyingTransactionLabels defines the port for persisting the category and payee of a
+// single transaction.
+type ForModifyingTransactionLabels interface {
+	ModifyTransactionLabels(ctx context.Context, tenantID string, transaction *Transaction) error
+}
+
+// ForSavingRule defines the port for saving a categorisation rule in the persistence layer.
+type ForSavingRule interface {
+	SaveRule(ctx context.Context, tenantID string, rule *Rule) error
+}
+
+// ForLoadingRules defines the port for loading categorisation rules from the persistence layer.
+// LoadRules returns them in ascending priority, then ID.
+type ForLoadingRules interface {
+	LoadRule(ctx context.Context, tenantID, id string) (*Rule, error)
+	LoadRules(ctx context.Context, tenantID string) ([]*Rule, error)
+}
+
+// ForModifyingRule defines the port for persisting changes to a categorisation rule.
+type ForModifyingRule interface {
+	ModifyRule(ctx context.Context, tenantID string, rule *Rule) error
+}
+
+// ForRemovingRule defines the port for removing a categorisation rule from the persistence layer.
+type ForRemovingRule interface {
+	RemoveRule(ctx context.Context, tenantID, id string) error
+}
+
+// ForLoadingDuplicateCandidates defines the port for loading pairs of transactions that could be
+// duplicates: those of the same account for the same amount, dated at most windowDays apart,
+// neither of them voided, and not already dismissed. Pairs come most recent first, with the
+// transaction recorded first as Transaction, of the accounts the filter selects.
+type ForLoadingDuplicateCandidates interface {
+	LoadDuplicateCandidates(ctx context.Context, tenantID string, filter DuplicateFilter, limit int) ([]*DuplicatePair, error)
+}
+
+// ForSavingDuplicateResolution defines the port for saving the audit record of a resolved pair.
+type ForSavingDuplicateResolution interface {
+	SaveDuplicateResolution(ctx context.Context, tenantID string, resolution *DuplicateResolution) error
+}
+
+// ForLoadingDuplicateResolutions defines the port for loading the audit trail of resolved pairs,
+// most recent first, of the accounts the filter selects.
+type ForLoadingDuplicateResolutions interface {
+	LoadDuplicateResolutions(ctx context.Context, tenantID string, filter ResolutionFilter) ([]*DuplicateResolution, error)
+}
+
+// ForCheckingCategory defines the port for checking that a category exists for the tenant.
+type ForCheckingCategory interface {
+	CategoryExists(ctx context.Context, tenantID, id string) (bool, error)
+}
+
+// ForLoadingAccountCurrency defines the port for looking up the currency a tenant's account is held
+// in. Accounts of other tenants are not found.
+type ForLoadingAccountCurrency interface {
+	LoadAccountCurrency(ctx context.Context, tenantID, accountID string) (money.Currency, error)
+}
+
+// ForStreamingTransactions defines the port for reading every transaction matching a filter, in
+// its order, from the persistence layer, handing them to each as they are read. The filter's
+// Limit and Cursor are ignored. It stops at the first error each returns.
+type ForStreamingTransactions interface {
+	StreamTransactions(ctx context.Context, tenantID string, filter TransactionFilter, each func(*Transaction) error) error
+}
+
+// ForLoadingBalance defines the port for loading account balances from the persistence layer.
+// LoadBalance sums the account's transactions dated on or before asOf; LoadBalanceThrough sums
+// those up to and including the given transaction in (date, id) order. LoadDailyChanges sums
+// the transactions dated on or before asOf per day, in date order. Voided transactions are
+// left out of all three.
+type ForLoadingBalance interface {
+	LoadBalance(ctx context.Context, tenantID, accountID string, asOf time.Time) (money.Money, error)
+	LoadBalanceThrough(ctx context.Context, tenantID string, transaction *Transaction) (money.Money, error)
+	LoadDailyChanges(ctx context.Context, tenantID, accountID string, asOf time.Time) ([]*DailyChange, error)
+}
+
+// ForConvertingMoney defines the port for converting an amount into another currency at the
+// tenant's rate for the given day.
+type ForConvertingMoney interface {
+	Convert(ctx context.Context, amount money.Money, to money.Currency, on time.Time) (money.Money, error)
+}
+
+// ForRunningInTransaction defines the port for running several persistence
+// operations atomically. Persistence calls made with the context passed to fn
+// either all take effect or none do.
+type ForRunningInTransaction interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
@@ -0,0 +1,170 @@
+package transactions
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"spend-api/internal/domain/money"
+	"strings"
+	"time"
+)
+
+// Rule categorises transactions automatically. A rule matches a transaction
+// when every condition it sets holds; conditions left empty match anything.
+// A matching rule sets the transaction's category and/or normalised payee.
+// Rules are tried in ascending Priority, and for each of the two fields the
+// first matching rule that sets it wins.
+type Rule struct {
+	ID       string
+	Name     string
+	Priority int
+
+	AccountID           string
+	Type                Kind
+	DescriptionContains string
+	DescriptionPattern  string
+	MinAmount           *money.Decimal
+	MaxAmount           *money.Decimal
+
+	CategoryID string
+	Payee      string
+
+	pattern *regexp.Regexp
+}
+
+// RuleChange describes how applying rules changes, or would change, a transaction.
+type RuleChange struct {
+	TransactionID  string
+	RuleIDs        []string
+	FromCategoryID string
+	ToCategoryID   string
+	FromPayee      string
+	ToPayee        string
+}
+
+// RuleApplication selects the transactions rules are applied to after the
+// fact: those dated From to To inclusive, optionally in a single account. A
+// non-nil AccountIDs restricts them to those accounts, and to none if it is
+// empty. Categories and payees that are already set are only replaced when
+// Overwrite is set, and with DryRun the changes are reported without being
+// saved.
+type RuleApplication struct {
+	AccountID  string
+	AccountIDs []string
+	From       time.Time
+	To         time.Time
+	Overwrite  bool
+	DryRun     bool
+}
+
+// RuleRun reports the outcome of applying rules to existing transactions.
+// Nothing is saved when DryRun is set.
+type RuleRun struct {
+	DryRun   bool
+	Examined int
+	Changes  []RuleChange
+}
+
+// validate checks the rule's own fields, recording problems in invalid, and
+// compiles its description pattern.
+func (r *Rule) validate(invalid *ValidationError) {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		invalid.Add("name", "is required")
+	}
+	if r.AccountID == "" && r.Type == "" && r.DescriptionContains == "" && r.DescriptionPattern == "" && r.MinAmount == nil && r.MaxAmount == nil {
+		invalid.Add("conditions", "at least one condition is required")
+	}
+	if r.Type != "" && !r.Type.IsValid() {
+		invalid.Add("type", fmt.Sprintf("unknown type %q", r.Type))
+	}
+	if r.DescriptionPattern != "" {
+		pattern, err := regexp.Compile(r.DescriptionPattern)
+		if err != nil {
+			invalid.Add("descriptionPattern", err.Error())
+		}
+		r.pattern = pattern
+	}
+	if r.MinAmount != nil && r.MaxAmount != nil && r.MinAmount.Cmp(*r.MaxAmount) > 0 {
+		invalid.Add("maxAmount", "must not be less than minAmount")
+	}
+	if r.CategoryID == "" && strings.TrimSpace(r.Payee) == "" {
+		invalid.Add("actions", "a rule must set a categoryID or a payee")
+	}
+	r.Payee = strings.TrimSpace(r.Payee)
+}
+
+// Matches reports whether the transaction meets all of the rule's conditions.
+func (r *Rule) Matches(t *Transaction) bool {
+	if r.AccountID != "" && r.AccountID != t.AccountID {
+		return false
+	}
+	if r.Type != "" && r.Type != t.Type {
+		return false
+	}
+	if r.DescriptionContains != "" && !strings.Contains(strings.ToLower(t.Description), strings.ToLower(r.DescriptionContains)) {
+		return false
+	}
+	if r.DescriptionPattern != "" {
+		if r.pattern == nil {
+			pattern, err := regexp.Compile(r.DescriptionPattern)
+			if err != nil {
+				return false
+			}
+			r.pattern = pattern
+		}
+		if !r.pattern.MatchString(t.Description) {
+			return false
+		}
+	}
+	amount := t.Amount.Decimal()
+	if r.MinAmount != nil && amount.Cmp(*r.MinAmount) < 0 {
+		return false
+	}
+	if r.MaxAmount != nil && amount.Cmp(*r.MaxAmount) > 0 {
+		return false
+	}
+	return true
+}
+
+// applyRules sets the transaction's category and payee from the first
+// matching rules. Fields that already have a value are only replaced when
+// overwrite is set. It returns the change made, or nil if nothing changed.
+func applyRules(t *Transaction, rules []*Rule, overwrite bool) *RuleChange {
+	sorted := make([]*Rule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Priority < sorted[j].Priority })
+
+	change := &RuleChange{
+		TransactionID:  t.ID,
+		FromCategoryID: t.CategoryID,
+		ToCategoryID:   t.CategoryID,
+		FromPayee:      t.Payee,
+		ToPayee:        t.Payee,
+	}
+	categorySet, payeeSet := !overwrite && t.CategoryID != "", !overwrite && t.Payee != ""
+	for _, rule := range sorted {
+		if (categorySet || rule.CategoryID == "") && (payeeSet || rule.Payee == "") {
+			continue
+		}
+		if !rule.Matches(t) {
+			continue
+		}
+		used := false
+		if !categorySet && rule.CategoryID != "" {
+			change.ToCategoryID, categorySet, used = rule.CategoryID, true, true
+		}
+		if !payeeSet && rule.Payee != "" {
+			change.ToPayee, payeeSet, used = rule.Payee, true, true
+		}
+		if used {
+			change.RuleIDs = append(change.RuleIDs, rule.ID)
+		}
+	}
+
+	if change.ToCategoryID == change.FromCategoryID && change.ToPayee == change.FromPayee {
+		return nil
+	}
+	t.CategoryID, t.Payee = change.ToCategoryID, change.ToPayee
+	return change
+}
@@ -0,0 +1,201 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/auth"
+)
+
+// RuleService provides the core logic for managing categorisation rules and
+// applying them to transactions that already exist.
+type RuleService struct {
+	rulePersistence    ForSavingRule
+	ruleLoader         ForLoadingRules
+	ruleModifier       ForModifyingRule
+	ruleRemover        ForRemovingRule
+	categories         ForCheckingCategory
+	transactionLoader  ForLoadingTransactions
+	transactionLabeler ForModifyingTransactionLabels
+	transactor         ForRunningInTransaction
+}
+
+// NewRuleService creates a new RuleService.
+func NewRuleService(persistence ForSavingRule, loader ForLoadingRules, modifier ForModifyingRule, remover ForRemovingRule, categories ForCheckingCategory, transactionLoader ForLoadingTransactions, transactionLabeler ForModifyingTransactionLabels, transactor ForRunningInTransaction) *RuleService {
+	return &RuleService{
+		rulePersistence:    persistence,
+		ruleLoader:         loader,
+		ruleModifier:       modifier,
+		ruleRemover:        remover,
+		categories:         categories,
+		transactionLoader:  transactionLoader,
+		transactionLabeler: transactionLabeler,
+		transactor:         transactor,
+	}
+}
+
+// CreateRule validates the rule and saves it using persistence. A rule needs
+// a name, at least one condition and at least one action; failures are
+// reported together as a *ValidationError matching ErrInvalidRule. The rule
+// belongs to the tenant of the principal in ctx.
+func (s *RuleService) CreateRule(ctx context.Context, rule *Rule) (*Rule, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = ""
+	if err := s.validate(ctx, tenantID, rule); err != nil {
+		return nil, err
+	}
+
+	// Save the rule using the persistence port
+	if err := s.rulePersistence.SaveRule(ctx, tenantID, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// GetRule retrieves the rule with the given ID. Rules of other tenants are
+// not found.
+func (s *RuleService) GetRule(ctx context.Context, id string) (*Rule, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.ruleLoader.LoadRule(ctx, tenantID, id)
+}
+
+// ListRules retrieves all of the tenant's rules in the order they are tried.
+func (s *RuleService) ListRules(ctx context.Context) ([]*Rule, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.ruleLoader.LoadRules(ctx, tenantID)
+}
+
+// UpdateRule replaces the rule with the given ID.
+func (s *RuleService) UpdateRule(ctx context.Context, id string, rule *Rule) (*Rule, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.ruleLoader.LoadRule(ctx, tenantID, id); err != nil {
+		return nil, err
+	}
+
+	rule.ID = id
+	if err := s.validate(ctx, tenantID, rule); err != nil {
+		return nil, err
+	}
+	if err := s.ruleModifier.ModifyRule(ctx, tenantID, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// DeleteRule deletes the rule with the given ID. Transactions it already
+// categorised keep their category and payee.
+func (s *RuleService) DeleteRule(ctx context.Context, id string) error {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return err
+	}
+	return s.ruleRemover.RemoveRule(ctx, tenantID, id)
+}
+
+// ApplyRules runs the rules over the transactions selected by the application
+// and saves the resulting changes, unless it is a dry run. Voided
+// transactions are left alone. The changes are returned either way. Only the
+// tenant's rules and transactions take part.
+func (s *RuleService) ApplyRules(ctx context.Context, application RuleApplication) (*RuleRun, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invalid := &ValidationError{Err: ErrInvalidRule}
+	if application.From.IsZero() {
+		invalid.Add("from", "is required")
+	}
+	if application.To.IsZero() {
+		invalid.Add("to", "is required")
+	}
+	if !application.From.IsZero() && application.To.Before(application.From) {
+		invalid.Add("to", "must not be before from")
+	}
+	if err := invalid.OrNil(); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ruleLoader.LoadRules(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := DateOf(application.From), DateOf(application.To)
+	filter := TransactionFilter{
+		AccountID:  application.AccountID,
+		AccountIDs: application.AccountIDs,
+		From:       &from,
+		To:         &to,
+		SortBy:     SortByDate,
+		SortOrder:  SortAscending,
+	}
+	run := &RuleRun{DryRun: application.DryRun, Changes: []RuleChange{}}
+
+	// Work through the range a page at a time, saving each page's changes
+	// together so an interrupted run leaves whole pages applied
+	var after *PageCursor
+	for {
+		page, err := s.transactionLoader.LoadTransactions(ctx, tenantID, filter, after, MaxPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		var changed []*Transaction
+		for _, transaction := range page {
+			if transaction.Status == StatusVoided {
+				continue
+			}
+			run.Examined++
+			if change := applyRules(transaction, rules, application.Overwrite); change != nil {
+				run.Changes = append(run.Changes, *change)
+				changed = append(changed, transaction)
+			}
+		}
+
+		if len(changed) > 0 && !application.DryRun {
+			err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				for _, transaction := range changed {
+					if err := s.transactionLabeler.ModifyTransactionLabels(ctx, tenantID, transaction); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(page) < MaxPageSize {
+			return run, nil
+		}
+		after = cursorAfter(page[len(page)-1], SortByDate)
+	}
+}
+
+// validate checks the rule's fields and that the category it sets exists for the tenant.
+func (s *RuleService) validate(ctx context.Context, tenantID string, rule *Rule) error {
+	invalid := &ValidationError{Err: ErrInvalidRule}
+	rule.validate(invalid)
+	if rule.CategoryID != "" {
+		exists, err := s.categories.CategoryExists(ctx, tenantID, rule.CategoryID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			invalid.Add("categoryID", fmt.Sprintf("unknown category %q", rule.CategoryID))
+		}
+	}
+	return invalid.OrNil()
+}
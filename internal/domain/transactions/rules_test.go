@@ -0,0 +1,254 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+)
+
+// FakeRuleStore simulates saving, loading, modifying and removing rules for testing.
+type FakeRuleStore struct {
+	Rules       []*Rule
+	ReturnError bool
+	Removed     []string
+}
+
+func (f *FakeRuleStore) SaveRule(ctx context.Context, tenantID string, rule *Rule) error {
+	if f.ReturnError {
+		return errors.New("failed to save rule")
+	}
+	rule.ID = fmt.Sprintf("%d", len(f.Rules)+1)
+	f.Rules = append(f.Rules, rule)
+	return nil
+}
+
+func (f *FakeRuleStore) LoadRule(ctx context.Context, tenantID, id string) (*Rule, error) {
+	for _, rule := range f.Rules {
+		if rule.ID == id {
+			return rule, nil
+		}
+	}
+	return nil, ErrRuleNotFound
+}
+
+func (f *FakeRuleStore) LoadRules(ctx context.Context, tenantID string) ([]*Rule, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to load rules")
+	}
+	return f.Rules, nil
+}
+
+func (f *FakeRuleStore) ModifyRule(ctx context.Context, tenantID string, rule *Rule) error {
+	for i, existing := range f.Rules {
+		if existing.ID == rule.ID {
+			f.Rules[i] = rule
+		}
+	}
+	return nil
+}
+
+func (f *FakeRuleStore) RemoveRule(ctx context.Context, tenantID, id string) error {
+	f.Removed = append(f.Removed, id)
+	return nil
+}
+
+// FakeForModifyingTransactionLabels simulates persisting transaction categories and payees for testing.
+type FakeForModifyingTransactionLabels struct {
+	Modified []*Transaction
+}
+
+func (f *FakeForModifyingTransactionLabels) ModifyTransactionLabels(ctx context.Context, tenantID string, transaction *Transaction) error {
+	f.Modified = append(f.Modified, transaction)
+	return nil
+}
+
+func decimal(value string) *money.Decimal {
+	amount := money.MustParse(value, "EUR").Decimal()
+	return &amount
+}
+
+// newFakeRuleStore holds a catch-all supermarket rule and a more specific one
+// for large supermarket spends that takes precedence
+func newFakeRuleStore() *FakeRuleStore {
+	return &FakeRuleStore{Rules: []*Rule{
+		{ID: "1", Name: "Supermarkets", Priority: 20, DescriptionPattern: `(?i)^(tesco|lidl)\b`, CategoryID: "7", Payee: "Supermarket"},
+		{ID: "2", Name: "Big shops", Priority: 10, DescriptionContains: "tesco", MaxAmount: decimal("-100.00"), Payee: "Tesco"},
+	}}
+}
+
+// Test rules match on every condition they set
+func TestRuleMatches(t *testing.T) {
+	transaction := NewTransaction("1", "12345", money.MustParse("-25.00", "EUR"), KindDebit, time.Now(), "TESCO STORES 2041")
+
+	tests := map[string]struct {
+		rule    Rule
+		matches bool
+	}{
+		"contains ignores case":    {Rule{DescriptionContains: "tesco stores"}, true},
+		"contains":                 {Rule{DescriptionContains: "lidl"}, false},
+		"pattern":                  {Rule{DescriptionPattern: `STORES \d+$`}, true},
+		"pattern is exact case":    {Rule{DescriptionPattern: `stores`}, false},
+		"account":                  {Rule{AccountID: "12345"}, true},
+		"other account":            {Rule{AccountID: "999"}, false},
+		"type":                     {Rule{Type: KindDebit}, true},
+		"other type":               {Rule{Type: KindFee}, false},
+		"amount in range":          {Rule{MinAmount: decimal("-50.00"), MaxAmount: decimal("-10.00")}, true},
+		"amount below minimum":     {Rule{MinAmount: decimal("-20.00")}, false},
+		"amount above maximum":     {Rule{MaxAmount: decimal("-30.00")}, false},
+		"all conditions must hold": {Rule{DescriptionContains: "tesco", Type: KindCredit}, false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.matches, test.rule.Matches(transaction))
+		})
+	}
+}
+
+// Test creating a transaction fills in the payee and category from the rules
+// in priority order, keeping a category that was given explicitly
+func TestTransactionServiceCreateTransaction_AppliesRules(t *testing.T) {
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, newFakeRuleStore(), &FakeTransactor{}, nil)
+
+	small, err := transactionService.CreateTransaction(tenantContext(), "12345", money.MustParse("-12.00", "EUR"), KindDebit, "Tesco Metro", "", time.Time{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", small.CategoryID)
+	assert.Equal(t, "Supermarket", small.Payee)
+
+	large, err := transactionService.CreateTransaction(tenantContext(), "12345", money.MustParse("-150.00", "EUR"), KindDebit, "Tesco Extra", "", time.Time{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", large.CategoryID, "Category should come from the first rule that sets one")
+	assert.Equal(t, "Tesco", large.Payee, "Payee should come from the higher priority rule")
+
+	categories := newFakeCategories()
+	categories.Categories["8"] = true
+	transactionService = NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, categories, &FakeForModifyingTransactionCategory{}, newFakeRuleStore(), &FakeTransactor{}, nil)
+	explicit, err := transactionService.CreateTransaction(tenantContext(), "12345", money.MustParse("-12.00", "EUR"), KindDebit, "Lidl", "8", time.Time{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "8", explicit.CategoryID, "An explicit category should not be replaced")
+	assert.Equal(t, "Supermarket", explicit.Payee)
+}
+
+func newTestRuleService(store *FakeRuleStore, loader *FakeForLoadingTransactions, labeler *FakeForModifyingTransactionLabels) *RuleService {
+	return NewRuleService(store, store, store, store, newFakeCategories(), loader, labeler, &FakeTransactor{})
+}
+
+// Test creating a rule validates its conditions, actions and category
+func TestRuleServiceCreateRule(t *testing.T) {
+	store := &FakeRuleStore{}
+	ruleService := newTestRuleService(store, &FakeForLoadingTransactions{}, &FakeForModifyingTransactionLabels{})
+
+	rule, err := ruleService.CreateRule(tenantContext(), &Rule{Name: " Coffee ", DescriptionContains: "starbucks", CategoryID: "7"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", rule.ID)
+	assert.Equal(t, "Coffee", rule.Name)
+
+	_, err = ruleService.CreateRule(tenantContext(), &Rule{
+		DescriptionPattern: "(",
+		Type:               "gift",
+		MinAmount:          decimal("10.00"),
+		MaxAmount:          decimal("5.00"),
+		CategoryID:         "99",
+	})
+	assert.ErrorIs(t, err, ErrInvalidRule)
+	var invalid *ValidationError
+	assert.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []string{"name", "type", "descriptionPattern", "maxAmount", "categoryID"}, fieldNames(invalid))
+
+	_, err = ruleService.CreateRule(tenantContext(), &Rule{Name: "Nothing"})
+	assert.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []string{"conditions", "actions"}, fieldNames(invalid))
+	assert.Len(t, store.Rules, 1, "Invalid rules should not be saved")
+}
+
+// Test updating a rule replaces it and reports unknown rules
+func TestRuleServiceUpdateRule(t *testing.T) {
+	store := newFakeRuleStore()
+	ruleService := newTestRuleService(store, &FakeForLoadingTransactions{}, &FakeForModifyingTransactionLabels{})
+
+	rule, err := ruleService.UpdateRule(tenantContext(), "2", &Rule{Name: "Big shops", DescriptionContains: "tesco", Payee: "Tesco Extra"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", rule.ID)
+	assert.Equal(t, "Tesco Extra", store.Rules[1].Payee)
+
+	_, err = ruleService.UpdateRule(tenantContext(), "99", &Rule{Name: "Other", DescriptionContains: "x", Payee: "X"})
+	assert.ErrorIs(t, err, ErrRuleNotFound)
+}
+
+// Test a rule is retrieved by its ID
+func TestRuleServiceGetRule(t *testing.T) {
+	ruleService := newTestRuleService(newFakeRuleStore(), &FakeForLoadingTransactions{}, &FakeForModifyingTransactionLabels{})
+
+	rule, err := ruleService.GetRule(tenantContext(), "2")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", rule.ID)
+
+	_, err = ruleService.GetRule(tenantContext(), "99")
+	assert.ErrorIs(t, err, ErrRuleNotFound)
+}
+
+// Test applying rules retroactively with a dry run reports changes without saving them
+func TestRuleServiceApplyRules_DryRun(t *testing.T) {
+	transactions := []*Transaction{
+		NewTransaction("1", "12345", money.MustParse("-12.00", "EUR"), KindDebit, time.Now(), "LIDL 42"),
+		NewTransaction("2", "12345", money.MustParse("-8.00", "EUR"), KindDebit, time.Now(), "Cinema"),
+		NewTransaction("3", "12345", money.MustParse("-9.00", "EUR"), KindDebit, time.Now(), "Lidl"),
+	}
+	_ = transactions[2].Void()
+	loader := &FakeForLoadingTransactions{Transactions: transactions}
+	labeler := &FakeForModifyingTransactionLabels{}
+	ruleService := newTestRuleService(newFakeRuleStore(), loader, labeler)
+
+	from, to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	run, err := ruleService.ApplyRules(tenantContext(), RuleApplication{AccountID: "12345", AccountIDs: []string{"12345"}, From: from, To: to, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.True(t, run.DryRun)
+	assert.Equal(t, 2, run.Examined, "Voided transactions should be skipped")
+	assert.Equal(t, []RuleChange{{TransactionID: "1", RuleIDs: []string{"1"}, ToCategoryID: "7", ToPayee: "Supermarket"}}, run.Changes)
+	assert.Empty(t, labeler.Modified, "A dry run should not save anything")
+	assert.Equal(t, "12345", loader.Filter.AccountID)
+	assert.Equal(t, []string{"12345"}, loader.Filter.AccountIDs)
+	assert.Equal(t, from, *loader.Filter.From)
+	assert.Equal(t, SortAscending, loader.Filter.SortOrder)
+}
+
+// Test applying rules saves the changes and only replaces existing values when asked to
+func TestRuleServiceApplyRules_Overwrite(t *testing.T) {
+	transaction := NewTransaction("1", "12345", money.MustParse("-12.00", "EUR"), KindDebit, time.Now(), "Lidl")
+	transaction.CategoryID = "3"
+	loader := &FakeForLoadingTransactions{Transactions: []*Transaction{transaction}}
+	labeler := &FakeForModifyingTransactionLabels{}
+	ruleService := newTestRuleService(newFakeRuleStore(), loader, labeler)
+	application := RuleApplication{From: time.Now(), To: time.Now()}
+
+	run, err := ruleService.ApplyRules(tenantContext(), application)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", run.Changes[0].ToCategoryID, "Existing categories should be kept")
+	assert.Equal(t, "Supermarket", run.Changes[0].ToPayee)
+
+	transaction.Payee = ""
+	application.Overwrite = true
+	run, err = ruleService.ApplyRules(tenantContext(), application)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", run.Changes[0].FromCategoryID)
+	assert.Equal(t, "7", run.Changes[0].ToCategoryID)
+	assert.Len(t, labeler.Modified, 2)
+	assert.Equal(t, "7", labeler.Modified[1].CategoryID)
+}
+
+// Test applying rules needs a valid date range
+func TestRuleServiceApplyRules_InvalidRange(t *testing.T) {
+	ruleService := newTestRuleService(newFakeRuleStore(), &FakeForLoadingTransactions{}, &FakeForModifyingTransactionLabels{})
+
+	_, err := ruleService.ApplyRules(tenantContext(), RuleApplication{From: time.Now(), To: time.Now().AddDate(0, 0, -1)})
+
+	assert.ErrorIs(t, err, ErrInvalidRule)
+	var invalid *ValidationError
+	assert.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []string{"to"}, fieldNames(invalid))
+}
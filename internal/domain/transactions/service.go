@@ -1,28 +1,385 @@
 package transactions
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/money"
+	"time"
+)
 
 // TransactionService provides the core logic for managing transactions.
 type TransactionService struct {
 	transactionPersistence ForSavingTransaction
+	transactionLoader      ForLoadingTransactions
+	accountCurrencies      ForLoadingAccountCurrency
+	statusModifier         ForModifyingTransactionStatus
+	balanceLoader          ForLoadingBalance
+	categories             ForCheckingCategory
+	categoryModifier       ForModifyingTransactionCategory
+	rules                  ForLoadingRules
+	transactor             ForRunningInTransaction
+	converter              ForConvertingMoney
 }
 
 // NewTransactionService creates a new TransactionService.
-func NewTransactionService(persistence ForSavingTransaction) *TransactionService {
+func NewTransactionService(persistence ForSavingTransaction, loader ForLoadingTransactions, accountCurrencies ForLoadingAccountCurrency, statusModifier ForModifyingTransactionStatus, balanceLoader ForLoadingBalance, categories ForCheckingCategory, categoryModifier ForModifyingTransactionCategory, rules ForLoadingRules, transactor ForRunningInTransaction, converter ForConvertingMoney) *TransactionService {
 	return &TransactionService{
 		transactionPersistence: persistence,
+		transactionLoader:      loader,
+		accountCurrencies:      accountCurrencies,
+		statusModifier:         statusModifier,
+		balanceLoader:          balanceLoader,
+		categories:             categories,
+		categoryModifier:       categoryModifier,
+		rules:                  rules,
+		transactor:             transactor,
+		converter:              converter,
 	}
 }
 
 // CreateTransaction creates a new transaction and saves it using persistence.
-func (s *TransactionService) CreateTransaction(accountID string, amount float64, txnType, description string) (*Transaction, error) {
-	transaction := NewTransaction("", accountID, amount, txnType, time.Now(), description)
+// The amount's sign must follow the kind's convention and its currency must
+// be the currency of the account it is booked against, and its category must
+// exist; failures are reported together as a *ValidationError. A zero date
+// means today; the transaction is posted when a posted date is given and
+// pending otherwise. Categorisation rules fill in the payee and, unless one
+// was given, the category. The account and category must belong to the
+// tenant of the principal in ctx.
+func (s *TransactionService) CreateTransaction(ctx context.Context, accountID string, amount money.Money, kind Kind, description, categoryID string, date time.Time, postedDate *time.Time) (*Transaction, error) {
+	if date.IsZero() {
+		date = time.Now()
+	}
+	transaction := NewTransaction("", accountID, amount, kind, DateOf(date), description)
+	transaction.CategoryID = categoryID
+	return s.record(ctx, transaction, postedDate)
+}
+
+// ImportTransaction records a posted transaction taken from a bank statement.
+// It is validated and categorised like one created by CreateTransaction. A
+// non-empty externalID is the bank's own identifier for the line; importing
+// the same externalID into the account again fails with
+// ErrDuplicateTransaction, so statements can safely be imported twice.
+func (s *TransactionService) ImportTransaction(ctx context.Context, accountID string, amount money.Money, kind Kind, description, externalID string, date, postedDate time.Time) (*Transaction, error) {
+	transaction := NewTransaction("", accountID, amount, kind, DateOf(date), description)
+	transaction.ExternalID = externalID
+	return s.record(ctx, transaction, &postedDate)
+}
+
+// record validates a new transaction, posts it if postedDate is set, applies
+// the tenant's categorisation rules and saves it for the tenant
+func (s *TransactionService) record(ctx context.Context, transaction *Transaction, postedDate *time.Time) (*Transaction, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	accountID, amount, kind := transaction.AccountID, transaction.Amount, transaction.Type
+
+	invalid := &ValidationError{}
+	if accountID == "" {
+		invalid.Add("accountID", "is required")
+	}
+	if !kind.IsValid() {
+		invalid.Add("type", fmt.Sprintf("unknown type %q", kind))
+	} else if err := kind.CheckAmount(amount); err != nil {
+		invalid.Add("amount", err.Error())
+	}
+	if postedDate != nil && DateOf(*postedDate).Before(transaction.Timestamp) {
+		invalid.Add("postedDate", "must not be before the transaction date")
+	}
+	if err := invalid.OrNil(); err != nil {
+		return nil, err
+	}
+	if postedDate != nil {
+		if err := transaction.Post(*postedDate); err != nil {
+			return nil, err
+		}
+	}
+
+	currency, err := s.accountCurrencies.LoadAccountCurrency(ctx, tenantID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if amount.Currency() != currency {
+		invalid.Add("currency", fmt.Sprintf("account %s is held in %s", accountID, currency))
+	}
+	if err := s.checkCategory(ctx, tenantID, transaction.CategoryID, invalid); err != nil {
+		return nil, err
+	}
+	if err := invalid.OrNil(); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.rules.LoadRules(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	applyRules(transaction, rules, false)
+
+	// Save the transaction using the persistence port. Persistence keeps
+	// derived balance data alongside it, so the save runs as one unit of work
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.transactionPersistence.SaveTransaction(ctx, tenantID, transaction)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// CategorizeTransactions moves the transactions with the given IDs into the
+// category, or out of any category if categoryID is empty, and returns how many
+// of them changed. Unknown IDs, and those of other tenants, are skipped.
+func (s *TransactionService) CategorizeTransactions(ctx context.Context, ids []string, categoryID string) (int, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return 0, err
+	}
+	invalid := &ValidationError{}
+	ValidateBatch(ids, invalid)
+	if err := s.checkCategory(ctx, tenantID, categoryID, invalid); err != nil {
+		return 0, err
+	}
+	if err := invalid.OrNil(); err != nil {
+		return 0, err
+	}
+	return s.categoryModifier.ModifyTransactionCategory(ctx, tenantID, ids, categoryID)
+}
+
+// ValidateBatch records a field error unless ids names between one and
+// MaxPageSize transactions, the batch categorised at once.
+func ValidateBatch(ids []string, invalid *ValidationError) {
+	if len(ids) == 0 {
+		invalid.Add("transactionIDs", "is required")
+	}
+	if len(ids) > MaxPageSize {
+		invalid.Add("transactionIDs", fmt.Sprintf("at most %d transactions can be categorised at once", MaxPageSize))
+	}
+}
+
+// checkCategory records a field error if categoryID is set but unknown to the tenant
+func (s *TransactionService) checkCategory(ctx context.Context, tenantID, categoryID string, invalid *ValidationError) error {
+	if categoryID == "" {
+		return nil
+	}
+	exists, err := s.categories.CategoryExists(ctx, tenantID, categoryID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		invalid.Add("categoryID", fmt.Sprintf("unknown category %q", categoryID))
+	}
+	return nil
+}
+
+// GetTransaction retrieves the transaction with the given ID. Transactions
+// of other tenants are not found.
+func (s *TransactionService) GetTransaction(ctx context.Context, id string) (*Transaction, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.transactionLoader.LoadTransaction(ctx, tenantID, id)
+}
+
+// PostTransaction marks the pending transaction with the given ID as posted on the given date.
+func (s *TransactionService) PostTransaction(ctx context.Context, id string, postedDate time.Time) (*Transaction, error) {
+	return s.changeStatus(ctx, id, func(transaction *Transaction) error {
+		return transaction.Post(postedDate)
+	})
+}
+
+// VoidTransaction voids the transaction with the given ID. Voiding either leg
+// of a transfer voids the other with it, so money never leaves one account
+// without arriving in the other.
+func (s *TransactionService) VoidTransaction(ctx context.Context, id string) (*Transaction, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := s.transactionLoader.LoadTransaction(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	affected := []*Transaction{transaction}
+	if transaction.TransferID != "" {
+		filter := TransactionFilter{TransferID: transaction.TransferID, SortBy: SortByDate, SortOrder: SortAscending}
+		legs, err := s.transactionLoader.LoadTransactions(ctx, tenantID, filter, nil, MaxPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, leg := range legs {
+			if leg.ID != transaction.ID && leg.Status != StatusVoided {
+				affected = append(affected, leg)
+			}
+		}
+	}
+
+	err = s.changeStatuses(ctx, tenantID, affected, func(transaction *Transaction) error {
+		return transaction.Void()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
 
-	// Save the transaction using the persistence port
-	err := s.transactionPersistence.SaveTransaction(transaction)
+// changeStatus loads the transaction, applies the transition and persists it
+// only if nobody changed the status in between.
+func (s *TransactionService) changeStatus(ctx context.Context, id string, transition func(transaction *Transaction) error) (*Transaction, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := s.transactionLoader.LoadTransaction(ctx, tenantID, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.changeStatuses(ctx, tenantID, []*Transaction{transaction}, transition); err != nil {
+		return nil, err
+	}
 	return transaction, nil
 }
+
+// changeStatuses applies the transition to every transaction and persists
+// them as one unit of work, failing unless none of their statuses changed in
+// between.
+func (s *TransactionService) changeStatuses(ctx context.Context, tenantID string, transactions []*Transaction, transition func(transaction *Transaction) error) error {
+	from := make([]Status, len(transactions))
+	for i, transaction := range transactions {
+		from[i] = transaction.Status
+		if err := transition(transaction); err != nil {
+			return err
+		}
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i, transaction := range transactions {
+			if err := s.statusModifier.ModifyTransactionStatus(ctx, tenantID, transaction, from[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListTransactions returns a single page of the tenant's transactions matching the filter.
+func (s *TransactionService) ListTransactions(ctx context.Context, filter TransactionFilter) (*TransactionPage, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := filter.normalize(); err != nil {
+		return nil, err
+	}
+
+	var after *PageCursor
+	if filter.Cursor != "" {
+		cursor, err := DecodeCursor(filter.Cursor, filter.SortBy)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	// Ask for one extra row to find out whether another page follows
+	loaded, err := s.transactionLoader.LoadTransactions(ctx, tenantID, filter, after, filter.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &TransactionPage{Transactions: loaded}
+	if len(loaded) > filter.Limit {
+		page.Transactions = loaded[:filter.Limit]
+		page.NextCursor = EncodeCursor(cursorAfter(page.Transactions[filter.Limit-1], filter.SortBy))
+	}
+	if filter.tracksRunningBalance() {
+		if err := s.fillRunningBalances(ctx, tenantID, page.Transactions, filter.SortOrder); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// fillRunningBalances sets the running balance of a page of consecutive
+// transactions from a single account. Only the balance through the first
+// transaction is loaded; the rest are worked out from the amounts on the page.
+func (s *TransactionService) fillRunningBalances(ctx context.Context, tenantID string, page []*Transaction, order SortOrder) error {
+	if len(page) == 0 {
+		return nil
+	}
+	balance, err := s.balanceLoader.LoadBalanceThrough(ctx, tenantID, page[0])
+	if err != nil {
+		return err
+	}
+
+	for i, transaction := range page {
+		if i > 0 {
+			if order == SortAscending {
+				balance, err = balance.Add(transaction.balanceChange())
+			} else {
+				balance, err = balance.Sub(page[i-1].balanceChange())
+			}
+			if err != nil {
+				return err
+			}
+		}
+		running := balance
+		transaction.RunningBalance = &running
+	}
+	return nil
+}
+
+// GetBalance returns the account's balance at the end of today and, when asOf
+// is given, at the end of that day. When a currency is given the balances are
+// converted into it, each day's change at that day's rate. Accounts of other
+// tenants are not found.
+func (s *TransactionService) GetBalance(ctx context.Context, accountID string, asOf *time.Time, currency money.Currency) (*AccountBalance, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.loadBalance(ctx, tenantID, accountID, DateOf(time.Now()), currency)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := &AccountBalance{AccountID: accountID, Current: current}
+	if asOf != nil {
+		date := DateOf(*asOf)
+		asOfBalance, err := s.loadBalance(ctx, tenantID, accountID, date, currency)
+		if err != nil {
+			return nil, err
+		}
+		balance.AsOf, balance.AsOfBalance = &date, &asOfBalance
+	}
+	return balance, nil
+}
+
+// loadBalance loads the balance of the tenant's account at the end of the
+// given day, converted into currency unless that is empty or the account's own
+func (s *TransactionService) loadBalance(ctx context.Context, tenantID, accountID string, date time.Time, currency money.Currency) (money.Money, error) {
+	balance, err := s.balanceLoader.LoadBalance(ctx, tenantID, accountID, date)
+	if err != nil || currency == "" || currency == balance.Currency() {
+		return balance, err
+	}
+
+	changes, err := s.balanceLoader.LoadDailyChanges(ctx, tenantID, accountID, date)
+	if err != nil {
+		return money.Money{}, err
+	}
+	converted := money.Zero(currency)
+	for _, change := range changes {
+		amount, err := s.converter.Convert(ctx, change.Amount, currency, change.Date)
+		if err != nil {
+			return money.Money{}, err
+		}
+		if converted, err = converted.Add(amount); err != nil {
+			return money.Money{}, err
+		}
+	}
+	return converted, nil
+}
@@ -1,8 +1,13 @@
 package transactions
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"math/big"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/money"
 	"testing"
 	"time"
 )
@@ -10,21 +15,223 @@ import (
 // FakeForSavingTransaction simulates the persistence layer for testing.
 type FakeForSavingTransaction struct {
 	ReturnError bool
+	Saved       []*Transaction
 }
 
-func (f *FakeForSavingTransaction) SaveTransaction(transaction *Transaction) error {
+func (f *FakeForSavingTransaction) SaveTransaction(ctx context.Context, tenantID string, transaction *Transaction) error {
 	if f.ReturnError {
 		return errors.New("failed to save transaction")
 	}
+	f.Saved = append(f.Saved, transaction)
 	return nil
 }
 
+// FakeForLoadingTransactions simulates loading transactions from the persistence layer for testing.
+// Its transactions belong to tenant 1.
+type FakeForLoadingTransactions struct {
+	Transactions []*Transaction
+	ReturnError  bool
+	Filter       TransactionFilter
+	After        *PageCursor
+	Limit        int
+}
+
+func (f *FakeForLoadingTransactions) LoadTransaction(ctx context.Context, tenantID, id string) (*Transaction, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to load transaction")
+	}
+	if tenantID != "1" {
+		return nil, ErrTransactionNotFound
+	}
+	for _, transaction := range f.Transactions {
+		if transaction.ID == id {
+			return transaction, nil
+		}
+	}
+	return nil, ErrTransactionNotFound
+}
+
+func (f *FakeForLoadingTransactions) LoadTransactions(ctx context.Context, tenantID string, filter TransactionFilter, after *PageCursor, limit int) ([]*Transaction, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to load transactions")
+	}
+	f.Filter, f.After, f.Limit = filter, after, limit
+	if len(f.Transactions) > limit {
+		return f.Transactions[:limit], nil
+	}
+	return f.Transactions, nil
+}
+
+// FakeForModifyingTransactionStatus simulates persisting status changes for testing.
+type FakeForModifyingTransactionStatus struct {
+	ReturnError bool
+	Modified    []*Transaction
+	From        []Status
+}
+
+func (f *FakeForModifyingTransactionStatus) ModifyTransactionStatus(ctx context.Context, tenantID string, transaction *Transaction, from Status) error {
+	if f.ReturnError {
+		return errors.New("failed to modify transaction status")
+	}
+	f.Modified = append(f.Modified, transaction)
+	f.From = append(f.From, from)
+	return nil
+}
+
+// FakeForLoadingAccountCurrency simulates looking up account currencies for testing.
+type FakeForLoadingAccountCurrency struct {
+	Currencies  map[string]money.Currency
+	ReturnError bool
+}
+
+func (f *FakeForLoadingAccountCurrency) LoadAccountCurrency(ctx context.Context, tenantID, accountID string) (money.Currency, error) {
+	if f.ReturnError {
+		return "", errors.New("failed to load account currency")
+	}
+	currency, ok := f.Currencies[accountID]
+	if !ok {
+		return "", ErrAccountNotFound
+	}
+	return currency, nil
+}
+
+// FakeForLoadingBalance simulates loading balances for testing. Balances are
+// made up from the fake's transactions.
+type FakeForLoadingBalance struct {
+	Transactions []*Transaction
+	ReturnError  bool
+	Through      []*Transaction
+}
+
+func (f *FakeForLoadingBalance) LoadBalance(ctx context.Context, tenantID, accountID string, asOf time.Time) (money.Money, error) {
+	if f.ReturnError {
+		return money.Money{}, errors.New("failed to load balance")
+	}
+	if accountID != "12345" {
+		return money.Money{}, ErrAccountNotFound
+	}
+	balance := money.Zero("EUR")
+	for _, transaction := range f.Transactions {
+		if !transaction.Timestamp.After(asOf) {
+			balance, _ = balance.Add(transaction.balanceChange())
+		}
+	}
+	return balance, nil
+}
+
+func (f *FakeForLoadingBalance) LoadBalanceThrough(ctx context.Context, tenantID string, through *Transaction) (money.Money, error) {
+	if f.ReturnError {
+		return money.Money{}, errors.New("failed to load balance")
+	}
+	f.Through = append(f.Through, through)
+	balance := money.Zero("EUR")
+	for _, transaction := range f.Transactions {
+		balance, _ = balance.Add(transaction.balanceChange())
+		if transaction.ID == through.ID {
+			break
+		}
+	}
+	return balance, nil
+}
+
+func (f *FakeForLoadingBalance) LoadDailyChanges(ctx context.Context, tenantID, accountID string, asOf time.Time) ([]*DailyChange, error) {
+	if f.ReturnError {
+		return nil, errors.New("failed to load balance")
+	}
+	changes := []*DailyChange{}
+	for _, transaction := range f.Transactions {
+		date := DateOf(transaction.Timestamp)
+		if date.After(asOf) {
+			continue
+		}
+		if last := len(changes) - 1; last >= 0 && changes[last].Date.Equal(date) {
+			changes[last].Amount, _ = changes[last].Amount.Add(transaction.balanceChange())
+			continue
+		}
+		changes = append(changes, &DailyChange{Date: date, Amount: transaction.balanceChange()})
+	}
+	return changes, nil
+}
+
+// FakeForConvertingMoney simulates converting amounts for testing: amounts are
+// multiplied by the rate of their day, and days without a rate are not found.
+type FakeForConvertingMoney struct {
+	Rates map[string]string
+}
+
+func (f *FakeForConvertingMoney) Convert(ctx context.Context, amount money.Money, to money.Currency, on time.Time) (money.Money, error) {
+	rate, ok := f.Rates[on.Format(time.DateOnly)]
+	if !ok {
+		return money.Money{}, errRateNotFound
+	}
+	factor, _ := new(big.Rat).SetString(rate)
+	return money.FromRat(new(big.Rat).Mul(amount.Decimal().Rat(), factor), to)
+}
+
+var errRateNotFound = errors.New("rate not found")
+
+// FakeForCheckingCategory simulates checking categories for testing.
+type FakeForCheckingCategory struct {
+	Categories  map[string]bool
+	ReturnError bool
+}
+
+func (f *FakeForCheckingCategory) CategoryExists(ctx context.Context, tenantID, id string) (bool, error) {
+	if f.ReturnError {
+		return false, errors.New("failed to check category")
+	}
+	return f.Categories[id], nil
+}
+
+func newFakeCategories() *FakeForCheckingCategory {
+	return &FakeForCheckingCategory{Categories: map[string]bool{"7": true}}
+}
+
+// FakeForModifyingTransactionCategory simulates persisting transaction categories for testing.
+type FakeForModifyingTransactionCategory struct {
+	IDs        []string
+	CategoryID string
+}
+
+func (f *FakeForModifyingTransactionCategory) ModifyTransactionCategory(ctx context.Context, tenantID string, ids []string, categoryID string) (int, error) {
+	f.IDs, f.CategoryID = ids, categoryID
+	return len(ids), nil
+}
+
+// FakeTransactor runs the unit of work directly and counts how often it was used.
+type FakeTransactor struct {
+	Calls int
+}
+
+func (f *FakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.Calls++
+	return fn(ctx)
+}
+
+// tenantContext returns a context acting as a user of tenant 1
+func tenantContext() context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice", UserID: "5", TenantID: "1"})
+}
+
+func newFakeAccountCurrencies() *FakeForLoadingAccountCurrency {
+	return &FakeForLoadingAccountCurrency{Currencies: map[string]money.Currency{"12345": "EUR"}}
+}
+
+func makeTransactions(count int) []*Transaction {
+	var result []*Transaction
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < count; i++ {
+		result = append(result, NewTransaction(fmt.Sprintf("%d", i+1), "12345", money.New(int64(i+1)*100, "EUR"), "debit", start.AddDate(0, 0, i), "Groceries"))
+	}
+	return result
+}
+
 // Test for creating a new transaction with AccountID and Description
 func TestCreateTransaction(t *testing.T) {
 	transactionID := "txn123"
 	accountID := "12345"
-	amount := 100.0
-	txnType := "credit"
+	amount := money.MustParse("100.00", "EUR")
+	txnType := KindCredit
 	timestamp := time.Now()
 	description := "Payment for groceries"
 
@@ -41,14 +248,14 @@ func TestCreateTransaction(t *testing.T) {
 // Test for creating and saving a transaction using FakeTransactionPersistence
 func TestTransactionServiceCreateTransaction(t *testing.T) {
 	fakePersistence := &FakeForSavingTransaction{}
-	transactionService := NewTransactionService(fakePersistence)
+	transactionService := NewTransactionService(fakePersistence, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
 
 	accountID := "12345"
-	amount := 100.0
-	txnType := "credit"
+	amount := money.MustParse("100.00", "EUR")
+	txnType := KindCredit
 	description := "Payment for groceries"
 
-	newTransaction, err := transactionService.CreateTransaction(accountID, amount, txnType, description)
+	newTransaction, err := transactionService.CreateTransaction(tenantContext(), accountID, amount, txnType, description, "", time.Time{}, nil)
 
 	assert.Nil(t, err, "Error should be nil when creating a transaction")
 	assert.Equal(t, "", newTransaction.ID, "Transaction ID should be blank")
@@ -56,7 +263,8 @@ func TestTransactionServiceCreateTransaction(t *testing.T) {
 	assert.Equal(t, amount, newTransaction.Amount, "Transaction amount should be correctly set")
 	assert.Equal(t, txnType, newTransaction.Type, "Transaction type should be correctly set")
 	assert.Equal(t, description, newTransaction.Description, "Transaction description should be correctly set")
-	assert.WithinDuration(t, time.Now(), newTransaction.Timestamp, time.Second, "Transaction timestamp should be close to the current time")
+	assert.Equal(t, DateOf(time.Now()), newTransaction.Timestamp, "Transaction date should default to today")
+	assert.Equal(t, StatusPending, newTransaction.Status, "Transaction without a posted date should be pending")
 }
 
 // Test transaction creation failure due to SaveTransaction error
@@ -64,14 +272,470 @@ func TestTransactionServiceCreateTransaction_SaveError(t *testing.T) {
 	fakePersistence := &FakeForSavingTransaction{
 		ReturnError: true,
 	}
-	transactionService := NewTransactionService(fakePersistence)
+	transactionService := NewTransactionService(fakePersistence, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
 
 	accountID := "12345"
-	amount := 100.0
-	txnType := "credit"
+	amount := money.MustParse("100.00", "EUR")
+	txnType := KindCredit
 	description := "Payment"
-	newTransaction, err := transactionService.CreateTransaction(accountID, amount, txnType, description)
+	newTransaction, err := transactionService.CreateTransaction(tenantContext(), accountID, amount, txnType, description, "", time.Time{}, nil)
 
 	assert.NotNil(t, err, "Expected an error when saving transaction")
 	assert.Nil(t, newTransaction, "No transaction should be returned when there's a saving error")
 }
+
+// Test listing transactions applies defaults and reports when no further page exists
+func TestTransactionServiceListTransactions_Defaults(t *testing.T) {
+	loader := &FakeForLoadingTransactions{Transactions: makeTransactions(3)}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, loader, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	page, err := transactionService.ListTransactions(tenantContext(), TransactionFilter{AccountID: "12345"})
+
+	assert.Nil(t, err, "Error should be nil when listing transactions")
+	assert.Len(t, page.Transactions, 3)
+	assert.Empty(t, page.NextCursor, "There should be no next page")
+	assert.Equal(t, SortByDate, loader.Filter.SortBy, "Listing should default to sorting by date")
+	assert.Equal(t, SortDescending, loader.Filter.SortOrder, "Listing should default to newest first")
+	assert.Equal(t, DefaultPageSize+1, loader.Limit, "One extra row should be requested to detect further pages")
+	assert.Nil(t, loader.After)
+}
+
+// Test listing transactions returns a cursor that resumes after the last row
+func TestTransactionServiceListTransactions_Paging(t *testing.T) {
+	loader := &FakeForLoadingTransactions{Transactions: makeTransactions(5)}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, loader, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	page, err := transactionService.ListTransactions(tenantContext(), TransactionFilter{SortBy: SortByAmount, Limit: 2})
+
+	assert.Nil(t, err)
+	assert.Len(t, page.Transactions, 2)
+	assert.NotEmpty(t, page.NextCursor, "A next cursor should be returned")
+
+	_, err = transactionService.ListTransactions(tenantContext(), TransactionFilter{SortBy: SortByAmount, Limit: 2, Cursor: page.NextCursor})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "2", loader.After.ID, "Next page should start after the last transaction")
+	assert.Equal(t, "2.00", loader.After.Amount)
+}
+
+// Test invalid filters are rejected before reaching persistence
+func TestTransactionServiceListTransactions_InvalidFilter(t *testing.T) {
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dateCursor := EncodeCursor(&PageCursor{SortBy: SortByDate, ID: "1"})
+
+	filters := map[string]TransactionFilter{
+		"unknown sort field":   {SortBy: "payee"},
+		"unknown sort order":   {SortOrder: "sideways"},
+		"unknown status":       {Status: "cleared"},
+		"unknown type":         {Type: "banana"},
+		"limit too large":      {Limit: MaxPageSize + 1},
+		"from after to":        {From: &from, To: &to},
+		"malformed cursor":     {Cursor: "not-a-cursor"},
+		"cursor sort mismatch": {SortBy: SortByAmount, Cursor: dateCursor},
+	}
+
+	for name, filter := range filters {
+		t.Run(name, func(t *testing.T) {
+			loader := &FakeForLoadingTransactions{}
+			transactionService := NewTransactionService(&FakeForSavingTransaction{}, loader, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+			page, err := transactionService.ListTransactions(tenantContext(), filter)
+
+			assert.True(t, errors.Is(err, ErrInvalidFilter), "Expected ErrInvalidFilter")
+			assert.Nil(t, page)
+			assert.Zero(t, loader.Limit, "Persistence should not be queried")
+		})
+	}
+}
+
+// Test listing failure from persistence
+func TestTransactionServiceListTransactions_LoadError(t *testing.T) {
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{ReturnError: true}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	page, err := transactionService.ListTransactions(tenantContext(), TransactionFilter{})
+
+	assert.NotNil(t, err, "Expected an error when loading transactions fails")
+	assert.Nil(t, page)
+}
+
+// Test that the amount must be in the account's currency
+func TestTransactionServiceCreateTransaction_CurrencyMismatch(t *testing.T) {
+	fakePersistence := &FakeForSavingTransaction{}
+	transactionService := NewTransactionService(fakePersistence, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	newTransaction, err := transactionService.CreateTransaction(tenantContext(), "12345", money.MustParse("100.00", "USD"), "credit", "Payment", "", time.Time{}, nil)
+
+	var invalid *ValidationError
+	assert.True(t, errors.As(err, &invalid), "Expected a ValidationError")
+	assert.Equal(t, "currency", invalid.Fields[0].Field)
+	assert.Nil(t, newTransaction, "No transaction should be returned")
+}
+
+// Test creating a transaction for an account that does not exist
+func TestTransactionServiceCreateTransaction_AccountNotFound(t *testing.T) {
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	newTransaction, err := transactionService.CreateTransaction(tenantContext(), "999", money.MustParse("100.00", "EUR"), "credit", "Payment", "", time.Time{}, nil)
+
+	assert.True(t, errors.Is(err, ErrAccountNotFound), "Expected ErrAccountNotFound")
+	assert.Nil(t, newTransaction, "No transaction should be returned")
+}
+
+// Test creating a transaction with a client-supplied date and posted date
+func TestTransactionServiceCreateTransaction_Dates(t *testing.T) {
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	date := time.Date(2023, 12, 30, 15, 4, 5, 0, time.UTC)
+	posted := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	newTransaction, err := transactionService.CreateTransaction(tenantContext(), "12345", money.MustParse("-12.00", "EUR"), "debit", "Taxi", "", date, &posted)
+
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC), newTransaction.Timestamp, "Transaction date should keep only the day")
+	assert.Equal(t, posted, *newTransaction.PostedDate)
+	assert.Equal(t, StatusPosted, newTransaction.Status, "Transaction with a posted date should be posted")
+}
+
+// Test a posted date before the transaction date is rejected
+func TestTransactionServiceCreateTransaction_PostedBeforeDate(t *testing.T) {
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	posted := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newTransaction, err := transactionService.CreateTransaction(tenantContext(), "12345", money.MustParse("-12.00", "EUR"), "debit", "Taxi", "", date, &posted)
+
+	assert.True(t, errors.Is(err, ErrInvalidTransaction), "Expected ErrInvalidTransaction")
+	assert.Nil(t, newTransaction)
+}
+
+// Test each kind's sign convention is enforced
+func TestTransactionServiceCreateTransaction_SignRules(t *testing.T) {
+	cases := []struct {
+		kind   Kind
+		amount string
+		valid  bool
+	}{
+		{KindDebit, "-4.50", true},
+		{KindDebit, "4.50", false},
+		{KindFee, "-1.00", true},
+		{KindFee, "1.00", false},
+		{KindTransferOut, "-100.00", true},
+		{KindTransferOut, "100.00", false},
+		{KindCredit, "2500.00", true},
+		{KindCredit, "-2500.00", false},
+		{KindInterest, "0.12", true},
+		{KindInterest, "-0.12", false},
+		{KindRefund, "19.99", true},
+		{KindRefund, "-19.99", false},
+		{KindTransferIn, "100.00", true},
+		{KindTransferIn, "-100.00", false},
+		{KindAdjustment, "-3.00", true},
+		{KindAdjustment, "3.00", true},
+		{KindAdjustment, "0.00", false},
+		{KindCredit, "0.00", false},
+	}
+
+	for _, c := range cases {
+		fakePersistence := &FakeForSavingTransaction{}
+		transactionService := NewTransactionService(fakePersistence, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+		_, err := transactionService.CreateTransaction(tenantContext(), "12345", money.MustParse(c.amount, "EUR"), c.kind, "", "", time.Time{}, nil)
+
+		if c.valid {
+			assert.Nil(t, err, "%s %s should be accepted", c.kind, c.amount)
+			continue
+		}
+		var invalid *ValidationError
+		assert.True(t, errors.As(err, &invalid), "%s %s should be rejected", c.kind, c.amount)
+		assert.Equal(t, []string{"amount"}, fieldNames(invalid), "%s %s", c.kind, c.amount)
+	}
+}
+
+// Test every invalid field is reported at once
+func TestTransactionServiceCreateTransaction_ValidationErrors(t *testing.T) {
+	fakePersistence := &FakeForSavingTransaction{}
+	transactionService := NewTransactionService(fakePersistence, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	posted := date.AddDate(0, 0, -1)
+	_, err := transactionService.CreateTransaction(tenantContext(), "", money.MustParse("1.00", "EUR"), "banana", "", "", date, &posted)
+
+	var invalid *ValidationError
+	assert.True(t, errors.As(err, &invalid), "Expected a ValidationError")
+	assert.True(t, errors.Is(err, ErrInvalidTransaction), "A ValidationError should match ErrInvalidTransaction")
+	assert.Equal(t, []string{"accountID", "type", "postedDate"}, fieldNames(invalid))
+	assert.Contains(t, err.Error(), `type: unknown type "banana"`)
+}
+
+func fieldNames(err *ValidationError) []string {
+	var names []string
+	for _, field := range err.Fields {
+		names = append(names, field.Field)
+	}
+	return names
+}
+
+// Test the allowed and forbidden status transitions
+func TestTransactionStatusTransitions(t *testing.T) {
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	pending := NewTransaction("1", "12345", money.MustParse("1.00", "EUR"), "debit", date, "Coffee")
+	assert.Nil(t, pending.Post(date.AddDate(0, 0, 1)), "Pending transactions can be posted")
+	assert.True(t, errors.Is(pending.Post(date), ErrInvalidStatusTransition), "Posted transactions cannot be posted again")
+	assert.Nil(t, pending.Void(), "Posted transactions can be voided")
+	assert.True(t, errors.Is(pending.Void(), ErrInvalidStatusTransition), "Voided transactions cannot be voided again")
+	assert.True(t, errors.Is(pending.Post(date), ErrInvalidStatusTransition), "Voided transactions cannot be posted")
+
+	unposted := NewTransaction("2", "12345", money.MustParse("1.00", "EUR"), "debit", date, "Coffee")
+	assert.Nil(t, unposted.Void(), "Pending transactions can be voided")
+	assert.Nil(t, unposted.PostedDate)
+}
+
+// Test getting a single transaction
+func TestTransactionServiceGetTransaction(t *testing.T) {
+	loader := &FakeForLoadingTransactions{Transactions: makeTransactions(2)}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, loader, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	transaction, err := transactionService.GetTransaction(tenantContext(), "2")
+	assert.Nil(t, err)
+	assert.Equal(t, "2", transaction.ID)
+	assert.Equal(t, 1, transaction.Version, "New transactions start at version 1")
+
+	_, err = transactionService.GetTransaction(tenantContext(), "9")
+	assert.True(t, errors.Is(err, ErrTransactionNotFound), "Expected ErrTransactionNotFound")
+}
+
+// Test transactions of another tenant are not found, and a tenant is required
+func TestTransactionServiceGetTransaction_OtherTenant(t *testing.T) {
+	loader := &FakeForLoadingTransactions{Transactions: makeTransactions(2)}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, loader, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	other := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "bob", UserID: "6", TenantID: "2"})
+	_, err := transactionService.GetTransaction(other, "2")
+	assert.True(t, errors.Is(err, ErrTransactionNotFound), "Expected ErrTransactionNotFound")
+
+	_, err = transactionService.GetTransaction(context.Background(), "2")
+	assert.True(t, errors.Is(err, auth.ErrUnauthenticated), "Expected ErrUnauthenticated")
+}
+
+// Test posting a pending transaction
+func TestTransactionServicePostTransaction(t *testing.T) {
+	loader := &FakeForLoadingTransactions{Transactions: makeTransactions(1)}
+	modifier := &FakeForModifyingTransactionStatus{}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, loader, newFakeAccountCurrencies(), modifier, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	posted := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	transaction, err := transactionService.PostTransaction(tenantContext(), "1", posted)
+
+	assert.Nil(t, err)
+	assert.Equal(t, StatusPosted, transaction.Status)
+	assert.Equal(t, posted, *transaction.PostedDate)
+	assert.Equal(t, []Status{StatusPending}, modifier.From, "The change should only apply to a still pending transaction")
+}
+
+// Test voiding a transaction
+func TestTransactionServiceVoidTransaction(t *testing.T) {
+	loader := &FakeForLoadingTransactions{Transactions: makeTransactions(1)}
+	modifier := &FakeForModifyingTransactionStatus{}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, loader, newFakeAccountCurrencies(), modifier, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	transaction, err := transactionService.VoidTransaction(tenantContext(), "1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, StatusVoided, transaction.Status)
+	assert.Len(t, modifier.Modified, 1)
+}
+
+// Test status changes that are not allowed are not persisted
+func TestTransactionServiceVoidTransaction_AlreadyVoided(t *testing.T) {
+	transactions := makeTransactions(1)
+	transactions[0].Status = StatusVoided
+	modifier := &FakeForModifyingTransactionStatus{}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{Transactions: transactions}, newFakeAccountCurrencies(), modifier, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	_, err := transactionService.VoidTransaction(tenantContext(), "1")
+
+	assert.True(t, errors.Is(err, ErrInvalidStatusTransition), "Expected ErrInvalidStatusTransition")
+	assert.Empty(t, modifier.Modified)
+}
+
+// Test changing the status of a transaction that does not exist
+func TestTransactionServicePostTransaction_NotFound(t *testing.T) {
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	_, err := transactionService.PostTransaction(tenantContext(), "1", time.Now())
+
+	assert.True(t, errors.Is(err, ErrTransactionNotFound), "Expected ErrTransactionNotFound")
+}
+
+// Test status change failure from persistence
+func TestTransactionServiceVoidTransaction_ModifyError(t *testing.T) {
+	loader := &FakeForLoadingTransactions{Transactions: makeTransactions(1)}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, loader, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{ReturnError: true}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	transaction, err := transactionService.VoidTransaction(tenantContext(), "1")
+
+	assert.NotNil(t, err)
+	assert.Nil(t, transaction)
+}
+
+// Test the current balance and the balance at the end of an earlier day
+func TestTransactionServiceGetBalance(t *testing.T) {
+	balances := &FakeForLoadingBalance{Transactions: makeTransactions(3)}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, balances, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+	asOf := time.Date(2024, 1, 2, 15, 30, 0, 0, time.UTC)
+
+	balance, err := transactionService.GetBalance(tenantContext(), "12345", &asOf, "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.MustParse("6.00", "EUR"), balance.Current)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), *balance.AsOf, "As-of balances are for whole days")
+	assert.Equal(t, money.MustParse("3.00", "EUR"), *balance.AsOfBalance)
+}
+
+// Test the as-of balance is left out unless asked for, and unknown accounts are reported
+func TestTransactionServiceGetBalance_CurrentOnly(t *testing.T) {
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	balance, err := transactionService.GetBalance(tenantContext(), "12345", nil, "")
+	assert.Nil(t, err)
+	assert.True(t, balance.Current.IsZero())
+	assert.Nil(t, balance.AsOfBalance)
+
+	_, err = transactionService.GetBalance(tenantContext(), "999", nil, "")
+	assert.True(t, errors.Is(err, ErrAccountNotFound), "Expected ErrAccountNotFound")
+}
+
+// Test balances in a reporting currency convert each day's change at that day's rate, and a
+// missing rate fails the balance
+func TestTransactionServiceGetBalance_Currency(t *testing.T) {
+	balances := &FakeForLoadingBalance{Transactions: makeTransactions(3)}
+	converter := &FakeForConvertingMoney{Rates: map[string]string{"2024-01-01": "1.1", "2024-01-02": "1.2", "2024-01-03": "1.3"}}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, balances, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, converter)
+	asOf := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	balance, err := transactionService.GetBalance(tenantContext(), "12345", &asOf, "USD")
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.MustParse("7.40", "USD"), balance.Current)
+	assert.Equal(t, money.MustParse("3.50", "USD"), *balance.AsOfBalance)
+
+	balance, err = transactionService.GetBalance(tenantContext(), "12345", nil, "EUR")
+	assert.Nil(t, err)
+	assert.Equal(t, money.MustParse("6.00", "EUR"), balance.Current, "Nothing is converted into the account's own currency")
+
+	delete(converter.Rates, "2024-01-03")
+	_, err = transactionService.GetBalance(tenantContext(), "12345", nil, "USD")
+	assert.ErrorIs(t, err, errRateNotFound)
+}
+
+// Test running balances are worked out from a single lookup in either sort order, skipping voided transactions
+func TestTransactionServiceListTransactions_RunningBalance(t *testing.T) {
+	for _, order := range []SortOrder{SortAscending, SortDescending} {
+		t.Run(string(order), func(t *testing.T) {
+			stored := makeTransactions(4)
+			stored[2].Status = StatusVoided
+			balances := &FakeForLoadingBalance{Transactions: stored}
+			page := []*Transaction{stored[1], stored[2], stored[3]}
+			if order == SortDescending {
+				page = []*Transaction{stored[3], stored[2], stored[1]}
+			}
+			transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{Transactions: page}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, balances, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+			result, err := transactionService.ListTransactions(tenantContext(), TransactionFilter{AccountID: "12345", SortOrder: order})
+
+			assert.Nil(t, err)
+			assert.Len(t, balances.Through, 1, "Only one balance lookup per page")
+			running := map[string]string{}
+			for _, transaction := range result.Transactions {
+				running[transaction.ID] = transaction.RunningBalance.String()
+			}
+			assert.Equal(t, map[string]string{"2": "3.00", "3": "3.00", "4": "7.00"}, running)
+		})
+	}
+}
+
+// Test running balances are left out when the listing skips some of the account's transactions
+func TestTransactionServiceListTransactions_NoRunningBalance(t *testing.T) {
+	for name, filter := range map[string]TransactionFilter{
+		"all accounts":   {},
+		"sorted by size": {AccountID: "12345", SortBy: SortByAmount},
+		"filtered":       {AccountID: "12345", Description: "Groceries"},
+		"in a category":  {AccountID: "12345", CategoryID: "7"},
+	} {
+		balances := &FakeForLoadingBalance{}
+		transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{Transactions: makeTransactions(2)}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, balances, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+		result, err := transactionService.ListTransactions(tenantContext(), filter)
+
+		assert.Nil(t, err, name)
+		assert.Empty(t, balances.Through, name)
+		assert.Nil(t, result.Transactions[0].RunningBalance, name)
+	}
+}
+
+// Test writes go through a unit of work so derived balances stay in step
+func TestTransactionService_WritesInTransaction(t *testing.T) {
+	transactor := &FakeTransactor{}
+	loader := &FakeForLoadingTransactions{Transactions: makeTransactions(1)}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, loader, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, transactor, nil)
+
+	_, err := transactionService.CreateTransaction(tenantContext(), "12345", money.MustParse("-1.00", "EUR"), KindDebit, "Coffee", "", time.Time{}, nil)
+	assert.Nil(t, err)
+	_, err = transactionService.VoidTransaction(tenantContext(), "1")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, transactor.Calls)
+}
+
+// Test a new transaction can be filed under an existing category, and unknown categories are rejected
+func TestTransactionServiceCreateTransaction_Category(t *testing.T) {
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	transaction, err := transactionService.CreateTransaction(tenantContext(), "12345", money.MustParse("-3.20", "EUR"), KindDebit, "Bakery", "7", time.Time{}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "7", transaction.CategoryID)
+
+	_, err = transactionService.CreateTransaction(tenantContext(), "12345", money.MustParse("-3.20", "EUR"), KindDebit, "Bakery", "99", time.Time{}, nil)
+	var invalid *ValidationError
+	assert.True(t, errors.As(err, &invalid), "Expected a ValidationError")
+	assert.Equal(t, []string{"categoryID"}, fieldNames(invalid))
+}
+
+// Test re-categorising transactions in bulk
+func TestTransactionServiceCategorizeTransactions(t *testing.T) {
+	modifier := &FakeForModifyingTransactionCategory{}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), modifier, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+	changed, err := transactionService.CategorizeTransactions(tenantContext(), []string{"1", "2"}, "7")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, changed)
+	assert.Equal(t, []string{"1", "2"}, modifier.IDs)
+	assert.Equal(t, "7", modifier.CategoryID)
+}
+
+// Test bulk re-categorising validates the request before changing anything
+func TestTransactionServiceCategorizeTransactions_Invalid(t *testing.T) {
+	cases := map[string]struct {
+		ids        []string
+		categoryID string
+		fields     []string
+	}{
+		"no transactions":  {nil, "", []string{"transactionIDs"}},
+		"unknown category": {[]string{"1"}, "99", []string{"categoryID"}},
+		"too many":         {make([]string, MaxPageSize+1), "7", []string{"transactionIDs"}},
+	}
+
+	for name, c := range cases {
+		modifier := &FakeForModifyingTransactionCategory{}
+		transactionService := NewTransactionService(&FakeForSavingTransaction{}, &FakeForLoadingTransactions{}, newFakeAccountCurrencies(), &FakeForModifyingTransactionStatus{}, &FakeForLoadingBalance{}, newFakeCategories(), modifier, &FakeRuleStore{}, &FakeTransactor{}, nil)
+
+		_, err := transactionService.CategorizeTransactions(tenantContext(), c.ids, c.categoryID)
+
+		var invalid *ValidationError
+		assert.True(t, errors.As(err, &invalid), name)
+		assert.Equal(t, c.fields, fieldNames(invalid), name)
+		assert.Nil(t, modifier.IDs, name)
+	}
+}
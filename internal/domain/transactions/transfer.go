@@ -0,0 +1,22 @@
+package transactions
+
+import (
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// Transfer moves money from one account to another. It is recorded as two
+// linked transactions: Outgoing, a transfer_out of the negated amount against
+// the source account, and Incoming, a transfer_in of the amount against the
+// destination. Both legs carry the transfer's ID as their TransferID and are
+// voided together.
+type Transfer struct {
+	ID            string
+	FromAccountID string
+	ToAccountID   string
+	Amount        money.Money
+	Date          time.Time
+	Description   string
+	Outgoing      *Transaction
+	Incoming      *Transaction
+}
@@ -0,0 +1,98 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"spend-api/internal/domain/auth"
+	"spend-api/internal/domain/money"
+	"time"
+)
+
+// TransferService provides the core logic for moving money between accounts.
+type TransferService struct {
+	transferPersistence    ForSavingTransfer
+	transactionPersistence ForSavingTransaction
+	accountCurrencies      ForLoadingAccountCurrency
+	transactor             ForRunningInTransaction
+}
+
+// NewTransferService creates a new TransferService.
+func NewTransferService(persistence ForSavingTransfer, transactionPersistence ForSavingTransaction, accountCurrencies ForLoadingAccountCurrency, transactor ForRunningInTransaction) *TransferService {
+	return &TransferService{
+		transferPersistence:    persistence,
+		transactionPersistence: transactionPersistence,
+		accountCurrencies:      accountCurrencies,
+		transactor:             transactor,
+	}
+}
+
+// CreateTransfer moves a positive amount from one account to a different one
+// held in the same currency, recording the outgoing and incoming transactions
+// as a single unit of work so neither exists without the other. Failures are
+// reported together as a *ValidationError matching ErrInvalidTransfer. A zero
+// date means today; both legs are pending until posted. Both accounts must
+// belong to the tenant of the principal in ctx.
+func (s *TransferService) CreateTransfer(ctx context.Context, fromAccountID, toAccountID string, amount money.Money, description string, date time.Time) (*Transfer, error) {
+	tenantID, err := auth.TenantFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invalid := &ValidationError{Err: ErrInvalidTransfer}
+	if fromAccountID == "" {
+		invalid.Add("fromAccountID", "is required")
+	}
+	if toAccountID == "" {
+		invalid.Add("toAccountID", "is required")
+	} else if toAccountID == fromAccountID {
+		invalid.Add("toAccountID", "must differ from fromAccountID")
+	}
+	if !amount.IsPositive() {
+		invalid.Add("amount", "must be positive")
+	}
+	if err := invalid.OrNil(); err != nil {
+		return nil, err
+	}
+
+	for _, account := range []struct{ field, id string }{{"fromAccountID", fromAccountID}, {"toAccountID", toAccountID}} {
+		currency, err := s.accountCurrencies.LoadAccountCurrency(ctx, tenantID, account.id)
+		if err != nil {
+			return nil, err
+		}
+		if amount.Currency() != currency {
+			invalid.Add(account.field, fmt.Sprintf("account %s is held in %s", account.id, currency))
+		}
+	}
+	if err := invalid.OrNil(); err != nil {
+		return nil, err
+	}
+
+	if date.IsZero() {
+		date = time.Now()
+	}
+	transfer := &Transfer{
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        amount,
+		Date:          DateOf(date),
+		Description:   description,
+	}
+	transfer.Outgoing = NewTransaction("", fromAccountID, amount.Neg(), KindTransferOut, transfer.Date, description)
+	transfer.Incoming = NewTransaction("", toAccountID, amount, KindTransferIn, transfer.Date, description)
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.transferPersistence.SaveTransfer(ctx, tenantID, transfer); err != nil {
+			return err
+		}
+		for _, leg := range []*Transaction{transfer.Outgoing, transfer.Incoming} {
+			leg.TransferID = transfer.ID
+			if err := s.transactionPersistence.SaveTransaction(ctx, tenantID, leg); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
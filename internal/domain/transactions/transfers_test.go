@@ -0,0 +1,137 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"spend-api/internal/domain/money"
+	"testing"
+	"time"
+)
+
+// FakeForSavingTransfer simulates saving transfers for testing.
+type FakeForSavingTransfer struct {
+	ReturnError bool
+	Saved       []*Transfer
+}
+
+func (f *FakeForSavingTransfer) SaveTransfer(ctx context.Context, tenantID string, transfer *Transfer) error {
+	if f.ReturnError {
+		return errors.New("failed to save transfer")
+	}
+	transfer.ID = "4"
+	f.Saved = append(f.Saved, transfer)
+	return nil
+}
+
+func newFakeTransferAccounts() *FakeForLoadingAccountCurrency {
+	return &FakeForLoadingAccountCurrency{Currencies: map[string]money.Currency{"12345": "EUR", "67890": "EUR", "555": "USD"}}
+}
+
+// Test a transfer records both legs, linked to it, in one unit of work
+func TestTransferServiceCreateTransfer(t *testing.T) {
+	transfers := &FakeForSavingTransfer{}
+	saver := &FakeForSavingTransaction{}
+	transactor := &FakeTransactor{}
+	transferService := NewTransferService(transfers, saver, newFakeTransferAccounts(), transactor)
+	date := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+
+	transfer, err := transferService.CreateTransfer(tenantContext(), "12345", "67890", money.MustParse("250.00", "EUR"), "Savings", date)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "4", transfer.ID)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), transfer.Date)
+	assert.Equal(t, 1, transactor.Calls)
+	assert.Len(t, transfers.Saved, 1)
+	assert.Equal(t, []*Transaction{transfer.Outgoing, transfer.Incoming}, saver.Saved)
+
+	assert.Equal(t, "12345", transfer.Outgoing.AccountID)
+	assert.Equal(t, KindTransferOut, transfer.Outgoing.Type)
+	assert.Equal(t, money.MustParse("-250.00", "EUR"), transfer.Outgoing.Amount)
+	assert.Equal(t, "67890", transfer.Incoming.AccountID)
+	assert.Equal(t, KindTransferIn, transfer.Incoming.Type)
+	assert.Equal(t, money.MustParse("250.00", "EUR"), transfer.Incoming.Amount)
+	for _, leg := range saver.Saved {
+		assert.Equal(t, "4", leg.TransferID)
+		assert.Equal(t, StatusPending, leg.Status)
+		assert.Equal(t, "Savings", leg.Description)
+	}
+}
+
+// Test invalid transfers are rejected with every problem listed and nothing saved
+func TestTransferServiceCreateTransfer_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   string
+		to     string
+		amount money.Money
+		fields []string
+	}{
+		{"missing accounts", "", "", money.MustParse("10.00", "EUR"), []string{"fromAccountID", "toAccountID"}},
+		{"same account", "12345", "12345", money.MustParse("10.00", "EUR"), []string{"toAccountID"}},
+		{"not positive", "12345", "67890", money.MustParse("-10.00", "EUR"), []string{"amount"}},
+		{"zero", "12345", "67890", money.Zero("EUR"), []string{"amount"}},
+		{"other currency", "12345", "555", money.MustParse("10.00", "EUR"), []string{"toAccountID"}},
+		{"both in other currency", "12345", "67890", money.MustParse("10.00", "USD"), []string{"fromAccountID", "toAccountID"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saver := &FakeForSavingTransaction{}
+			transferService := NewTransferService(&FakeForSavingTransfer{}, saver, newFakeTransferAccounts(), &FakeTransactor{})
+
+			_, err := transferService.CreateTransfer(tenantContext(), tt.from, tt.to, tt.amount, "", time.Time{})
+
+			assert.True(t, errors.Is(err, ErrInvalidTransfer), "Expected ErrInvalidTransfer")
+			var invalid *ValidationError
+			assert.True(t, errors.As(err, &invalid))
+			var fields []string
+			for _, field := range invalid.Fields {
+				fields = append(fields, field.Field)
+			}
+			assert.Equal(t, tt.fields, fields)
+			assert.Empty(t, saver.Saved)
+		})
+	}
+}
+
+// Test transferring to an account that does not exist
+func TestTransferServiceCreateTransfer_AccountNotFound(t *testing.T) {
+	transferService := NewTransferService(&FakeForSavingTransfer{}, &FakeForSavingTransaction{}, newFakeTransferAccounts(), &FakeTransactor{})
+
+	_, err := transferService.CreateTransfer(tenantContext(), "12345", "999", money.MustParse("10.00", "EUR"), "", time.Time{})
+
+	assert.True(t, errors.Is(err, ErrAccountNotFound), "Expected ErrAccountNotFound")
+}
+
+// Test a failure saving either leg fails the whole transfer
+func TestTransferServiceCreateTransfer_SaveError(t *testing.T) {
+	transferService := NewTransferService(&FakeForSavingTransfer{}, &FakeForSavingTransaction{ReturnError: true}, newFakeTransferAccounts(), &FakeTransactor{})
+
+	transfer, err := transferService.CreateTransfer(tenantContext(), "12345", "67890", money.MustParse("10.00", "EUR"), "", time.Time{})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, transfer)
+}
+
+// Test voiding one leg of a transfer voids the other in the same unit of work
+func TestTransactionServiceVoidTransaction_Transfer(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	outgoing := NewTransaction("1", "12345", money.MustParse("-250.00", "EUR"), KindTransferOut, date, "Savings")
+	incoming := NewTransaction("2", "67890", money.MustParse("250.00", "EUR"), KindTransferIn, date, "Savings")
+	_ = incoming.Post(date)
+	outgoing.TransferID, incoming.TransferID = "4", "4"
+	loader := &FakeForLoadingTransactions{Transactions: []*Transaction{outgoing, incoming}}
+	modifier := &FakeForModifyingTransactionStatus{}
+	transactor := &FakeTransactor{}
+	transactionService := NewTransactionService(&FakeForSavingTransaction{}, loader, newFakeAccountCurrencies(), modifier, &FakeForLoadingBalance{}, newFakeCategories(), &FakeForModifyingTransactionCategory{}, &FakeRuleStore{}, transactor, nil)
+
+	transaction, err := transactionService.VoidTransaction(tenantContext(), "2")
+
+	assert.Nil(t, err)
+	assert.Equal(t, incoming, transaction)
+	assert.Equal(t, "4", loader.Filter.TransferID, "The other leg should be looked up by its transfer")
+	assert.Equal(t, []*Transaction{incoming, outgoing}, modifier.Modified)
+	assert.Equal(t, []Status{StatusPosted, StatusPending}, modifier.From)
+	assert.Equal(t, StatusVoided, outgoing.Status)
+	assert.Equal(t, 1, transactor.Calls)
+}
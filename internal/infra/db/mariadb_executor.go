@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
@@ -10,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"spend-api/internal/config"
+	"strings"
 )
 
 var readFile = os.ReadFile
@@ -29,6 +31,7 @@ func NewMariaDbExecutor(cfg *config.Config) (*MariaDbExecutor, error) {
 
 	dsn := createDSN(cfg)
 	dsn = setupTLSConfig(dsn, cfg)
+	dsn = appendDSNParam(dsn, "parseTime", "true")
 
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
@@ -45,6 +48,15 @@ func createDSN(cfg *config.Config) string {
 	return dsn
 }
 
+// appendDSNParam adds a query parameter to the DSN, taking care of the separator
+func appendDSNParam(dsn, key, value string) string {
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	return dsn + separator + key + "=" + value
+}
+
 // setupTLSConfig configures TLS using the specified certificates and registers it with the MySQL driver
 func setupTLSConfig(dsn string, cfg *config.Config) string {
 
@@ -88,14 +100,69 @@ func setupTLSConfig(dsn string, cfg *config.Config) string {
 
 // Exec executes a query with the given arguments
 func (e *MariaDbExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
-	result, err := e.db.Exec(query, args...)
+	return e.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext executes a query with the given arguments, honouring ctx cancellation.
+// If ctx carries a transaction started by WithinTransaction the query runs inside it.
+func (e *MariaDbExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return execContext(ctx, e.conn(ctx), query, args...)
+}
+
+// Query executes a query that returns rows
+func (e *MariaDbExecutor) Query(query string, args ...interface{}) (Rows, error) {
+	return e.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext executes a query that returns rows, honouring ctx cancellation.
+// If ctx carries a transaction started by WithinTransaction the query runs inside it.
+func (e *MariaDbExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (Rows, error) {
+	return queryContext(ctx, e.conn(ctx), query, args...)
+}
+
+// QueryRow executes a query that is expected to return at most one row
+func (e *MariaDbExecutor) QueryRow(query string, args ...interface{}) Row {
+	return e.QueryRowContext(context.Background(), query, args...)
+}
+
+// QueryRowContext executes a query that is expected to return at most one row, honouring ctx cancellation.
+// If ctx carries a transaction started by WithinTransaction the query runs inside it.
+func (e *MariaDbExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) Row {
+	return e.conn(ctx).QueryRowContext(ctx, query, args...)
+}
+
+// Close closes the database connection
+func (e *MariaDbExecutor) Close() error {
+	return e.db.Close()
+}
+
+// conn returns the transaction carried by ctx, or the connection pool if there is none
+func (e *MariaDbExecutor) conn(ctx context.Context) sqlConn {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.tx
+	}
+	return e.db
+}
+
+// sqlConn is the subset of *sql.DB and *sql.Tx used to run queries
+type sqlConn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func execContext(ctx context.Context, conn sqlConn, query string, args ...interface{}) (sql.Result, error) {
+	result, err := conn.ExecContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 	return result, nil
 }
 
-// Close closes the database connection
-func (e *MariaDbExecutor) Close() error {
-	return e.db.Close()
+func queryContext(ctx context.Context, conn sqlConn, query string, args ...interface{}) (Rows, error) {
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	return rows, nil
 }
@@ -64,6 +64,11 @@ func TestCreateDSN(t *testing.T) {
 	assert.Equal(t, expectedDSN, dsn)
 }
 
+func TestAppendDSNParam(t *testing.T) {
+	assert.Equal(t, "u:p@tcp(h:1)/db?parseTime=true", appendDSNParam("u:p@tcp(h:1)/db", "parseTime", "true"))
+	assert.Equal(t, "u:p@tcp(h:1)/db?tls=dbTLS&parseTime=true", appendDSNParam("u:p@tcp(h:1)/db?tls=dbTLS", "parseTime", "true"))
+}
+
 func TestSetupTLSConfig(t *testing.T) {
 	// Define table of test cases
 	tests := []struct {
@@ -195,6 +200,65 @@ func TestMariaDbExecutor_Exec_Failure(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestMariaDbExecutor_Query_Success(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Account1").AddRow(2, "Account2"))
+
+	executor := &MariaDbExecutor{mockDB}
+
+	rows, err := executor.Query("SELECT id, name FROM accounts")
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var id, name string
+		assert.NoError(t, rows.Scan(&id, &name))
+		names = append(names, name)
+	}
+	assert.NoError(t, rows.Err())
+	assert.Equal(t, []string{"Account1", "Account2"}, names)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMariaDbExecutor_Query_Failure(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM accounts").
+		WillReturnError(sql.ErrConnDone)
+
+	executor := &MariaDbExecutor{mockDB}
+
+	_, err = executor.Query("SELECT id, name FROM accounts")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, sql.ErrConnDone), "Expected error to be sql.ErrConnDone")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMariaDbExecutor_QueryRow(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("SELECT name FROM accounts WHERE id").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Account1"))
+
+	executor := &MariaDbExecutor{mockDB}
+
+	var name string
+	err = executor.QueryRow("SELECT name FROM accounts WHERE id = ?", "1").Scan(&name)
+	assert.NoError(t, err)
+	assert.Equal(t, "Account1", name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMariaDbExecutor_Close_Success(t *testing.T) {
 	mockDB, mock, err := sqlmock.New()
 	assert.NoError(t, err)
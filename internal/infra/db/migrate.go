@@ -0,0 +1,323 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// Migrations holds the versioned schema migrations shipped with the binary
+var Migrations, _ = fs.Sub(embeddedMigrations, "migrations")
+
+// ErrMigrationLocked is returned when another instance is already running migrations.
+var ErrMigrationLocked = errors.New("migrations are locked by another instance")
+
+// ErrNoMigrationToRevert is returned by Down when no migration has been applied.
+var ErrNoMigrationToRevert = errors.New("no applied migration to revert")
+
+// migrationFile matches names such as 0001_initial_schema.up.sql
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+const createMigrationsTable = "CREATE TABLE IF NOT EXISTS schema_migrations (" +
+	"version BIGINT UNSIGNED NOT NULL PRIMARY KEY, " +
+	"name VARCHAR(255) NOT NULL, " +
+	"applied_at DATETIME NOT NULL)"
+
+const createLockTable = "CREATE TABLE IF NOT EXISTS schema_migrations_lock (" +
+	"id TINYINT UNSIGNED NOT NULL PRIMARY KEY, " +
+	"owner VARCHAR(255) NOT NULL, " +
+	"locked_at DATETIME NOT NULL)"
+
+// Migration is a single versioned schema change
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports whether a migration has been applied, and when
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrator applies and reverts schema migrations through an Executor. MariaDB
+// commits DDL implicitly, so each migration is recorded only after all of its
+// statements succeed; a failed migration must be fixed up by hand.
+type Migrator struct {
+	db         Executor
+	migrations []Migration
+	owner      string
+}
+
+// NewMigrator creates a Migrator for the migrations found in source
+func NewMigrator(executor Executor, source fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(source)
+	if err != nil {
+		return nil, err
+	}
+	hostname, _ := os.Hostname()
+	return &Migrator{
+		db:         executor,
+		migrations: migrations,
+		owner:      fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+	}, nil
+}
+
+// LoadMigrations reads the *.up.sql and *.down.sql files at the root of source,
+// ordered by version. Every version needs an up file; down files are optional.
+func LoadMigrations(source fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up applies every pending migration in version order and returns those applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func() error {
+		versions, err := m.appliedVersions(ctx)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+			if err := m.execScript(ctx, migration.Up); err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			_, err := m.db.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+				migration.Version, migration.Name, time.Now().UTC())
+			if err != nil {
+				return fmt.Errorf("failed to record migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the most recently applied migration and returns it
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var reverted *Migration
+	err := m.withLock(ctx, func() error {
+		versions, err := m.appliedVersions(ctx)
+		if err != nil {
+			return err
+		}
+		latest := -1
+		for version := range versions {
+			latest = max(latest, version)
+		}
+		if latest < 0 {
+			return ErrNoMigrationToRevert
+		}
+
+		migration := m.find(latest)
+		if migration == nil || migration.Down == "" {
+			return fmt.Errorf("migration %d has no down file", latest)
+		}
+		if err := m.execScript(ctx, migration.Down); err != nil {
+			return fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := m.db.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", migration.Version); err != nil {
+			return fmt.Errorf("failed to record migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		reverted = migration
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every known migration, plus any applied migration whose files are missing
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.ensureTables(ctx); err != nil {
+		return nil, err
+	}
+	versions, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := versions[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+			delete(versions, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for version, appliedAt := range versions {
+		statuses = append(statuses, MigrationStatus{Version: version, AppliedAt: &appliedAt})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Unlock releases a lock left behind by an instance that died while migrating
+func (m *Migrator) Unlock(ctx context.Context) error {
+	if err := m.ensureTables(ctx); err != nil {
+		return err
+	}
+	if _, err := m.db.ExecContext(ctx, "DELETE FROM schema_migrations_lock WHERE id = 1"); err != nil {
+		return fmt.Errorf("failed to release migration lock: %w", err)
+	}
+	return nil
+}
+
+// withLock runs fn while holding the single row of the lock table. Inserting
+// the row is atomic, so only one instance at a time can hold it.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) (err error) {
+	if err := m.ensureTables(ctx); err != nil {
+		return err
+	}
+
+	result, err := m.db.ExecContext(ctx, "INSERT IGNORE INTO schema_migrations_lock (id, owner, locked_at) VALUES (1, ?, ?)",
+		m.owner, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	if affected == 0 {
+		return ErrMigrationLocked
+	}
+
+	defer func() {
+		_, unlockErr := m.db.ExecContext(ctx, "DELETE FROM schema_migrations_lock WHERE id = 1 AND owner = ?", m.owner)
+		if unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to release migration lock: %w", unlockErr))
+		}
+	}()
+	return fn()
+}
+
+func (m *Migrator) ensureTables(ctx context.Context) error {
+	for _, statement := range []string{createMigrationsTable, createLockTable} {
+		if _, err := m.db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create migration tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// appliedVersions maps each applied version onto the time it was applied
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int]time.Time, error) {
+	type appliedMigration struct {
+		version   int
+		appliedAt time.Time
+	}
+	rows, err := QueryAll(ctx, m.db, func(row Row) (appliedMigration, error) {
+		var applied appliedMigration
+		err := row.Scan(&applied.version, &applied.appliedAt)
+		return applied, err
+	}, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	versions := make(map[int]time.Time, len(rows))
+	for _, row := range rows {
+		versions[row.version] = row.appliedAt
+	}
+	return versions, nil
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// execScript runs each statement of a migration file in turn
+func (m *Migrator) execScript(ctx context.Context, script string) error {
+	for _, statement := range splitStatements(script) {
+		if _, err := m.db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitStatements splits a migration file into statements ending in a
+// semicolon at the end of a line, dropping "--" comment lines. The driver
+// runs one statement per call unless multiStatements is enabled on the DSN.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSuffix(strings.TrimSpace(current.String()), ";"))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMigrations = fstest.MapFS{
+	"0001_initial.up.sql":     {Data: []byte("-- first\nCREATE TABLE a (id INT);\nCREATE TABLE b (\n  id INT\n);\n")},
+	"0001_initial.down.sql":   {Data: []byte("DROP TABLE b;\nDROP TABLE a;\n")},
+	"0002_add_c.up.sql":       {Data: []byte("CREATE TABLE c (id INT);\n")},
+	"0002_add_c.down.sql":     {Data: []byte("DROP TABLE c;\n")},
+	"readme.md":               {Data: []byte("not a migration")},
+	"0003_no_down_yet.up.sql": {Data: []byte("CREATE TABLE d (id INT)")},
+}
+
+func newTestMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = mockDB.Close() })
+
+	migrator, err := NewMigrator(&MariaDbExecutor{mockDB}, testMigrations)
+	assert.NoError(t, err)
+	migrator.owner = "test"
+	return migrator, mock
+}
+
+func expectTables(mock sqlmock.Sqlmock) {
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations ").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	expectTables(mock)
+	mock.ExpectExec("INSERT IGNORE INTO schema_migrations_lock").WithArgs("test", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec("DELETE FROM schema_migrations_lock WHERE id = 1 AND owner = ?").WithArgs("test").WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := LoadMigrations(testMigrations)
+
+	assert.NoError(t, err)
+	assert.Len(t, migrations, 3)
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Equal(t, "initial", migrations[0].Name)
+	assert.Contains(t, migrations[0].Down, "DROP TABLE b")
+	assert.Equal(t, "add_c", migrations[1].Name)
+	assert.Empty(t, migrations[2].Down)
+}
+
+func TestLoadMigrations_MissingUp(t *testing.T) {
+	_, err := LoadMigrations(fstest.MapFS{"0001_initial.down.sql": {Data: []byte("DROP TABLE a;")}})
+
+	assert.EqualError(t, err, "migration 1_initial has no up file")
+}
+
+func TestLoadMigrations_ConflictingNames(t *testing.T) {
+	_, err := LoadMigrations(fstest.MapFS{
+		"0001_initial.up.sql": {Data: []byte("CREATE TABLE a (id INT);")},
+		"0001_other.up.sql":   {Data: []byte("CREATE TABLE b (id INT);")},
+	})
+
+	assert.ErrorContains(t, err, "conflicting names")
+}
+
+// Test the embedded migrations can be loaded and start with the initial schema
+func TestLoadMigrations_Embedded(t *testing.T) {
+	migrations, err := LoadMigrations(Migrations)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Contains(t, migrations[0].Up, "CREATE TABLE accounts")
+	assert.NotEmpty(t, migrations[0].Down)
+}
+
+func TestSplitStatements(t *testing.T) {
+	statements := splitStatements("-- comment\nCREATE TABLE a (\n  id INT\n);\n\nDROP TABLE b;\nSELECT 1")
+
+	assert.Equal(t, []string{"CREATE TABLE a (\n  id INT\n)", "DROP TABLE b", "SELECT 1"}, statements)
+}
+
+func TestMigrator_Up(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+
+	expectLock(mock)
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()))
+	mock.ExpectExec("CREATE TABLE c").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(2, "add_c", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("CREATE TABLE d").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(3, "no_down_yet", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectUnlock(mock)
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, applied, 2)
+	assert.Equal(t, 2, applied[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Up_StopsAtFailure(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+
+	expectLock(mock)
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}))
+	mock.ExpectExec("CREATE TABLE a").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE b").WillReturnError(errors.New("syntax error"))
+	expectUnlock(mock)
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.ErrorContains(t, err, "failed to apply migration 1_initial")
+	assert.Empty(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet(), "The failed migration must not be recorded and the lock must be released")
+}
+
+func TestMigrator_Up_Locked(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+
+	expectTables(mock)
+	mock.ExpectExec("INSERT IGNORE INTO schema_migrations_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	_, err := migrator.Up(context.Background())
+
+	assert.True(t, errors.Is(err, ErrMigrationLocked), "Expected ErrMigrationLocked")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Nothing should run without the lock")
+}
+
+func TestMigrator_Down(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+
+	expectLock(mock)
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()).AddRow(2, time.Now()))
+	mock.ExpectExec("DROP TABLE c").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM schema_migrations WHERE version = ?").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectUnlock(mock)
+
+	reverted, err := migrator.Down(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, reverted.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Down_NothingApplied(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+
+	expectLock(mock)
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}))
+	expectUnlock(mock)
+
+	_, err := migrator.Down(context.Background())
+
+	assert.True(t, errors.Is(err, ErrNoMigrationToRevert), "Expected ErrNoMigrationToRevert")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Down_NoDownFile(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+
+	expectLock(mock)
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(3, time.Now()))
+	expectUnlock(mock)
+
+	_, err := migrator.Down(context.Background())
+
+	assert.EqualError(t, err, "migration 3 has no down file")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Status(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+	appliedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	expectTables(mock)
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, appliedAt).AddRow(9, appliedAt))
+
+	statuses, err := migrator.Status(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 4)
+	assert.Equal(t, appliedAt, *statuses[0].AppliedAt)
+	assert.Nil(t, statuses[1].AppliedAt, "Migration 2 should be pending")
+	assert.Equal(t, 9, statuses[3].Version, "Applied migrations without files should be reported")
+	assert.Empty(t, statuses[3].Name)
+}
+
+func TestMigrator_Unlock(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+
+	expectTables(mock)
+	mock.ExpectExec("DELETE FROM schema_migrations_lock WHERE id = 1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := migrator.Unlock(context.Background())
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,32 @@
+-- The schema as it stood before versioned migrations were introduced.
+
+CREATE TABLE accounts (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    number VARCHAR(34) NULL,
+    name VARCHAR(255) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    PRIMARY KEY (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE transactions (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    account_id BIGINT UNSIGNED NOT NULL,
+    amount DECIMAL(19, 4) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    transaction_type VARCHAR(32) NOT NULL,
+    transaction_date DATE NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (id),
+    KEY idx_transactions_account_date (account_id, transaction_date, id),
+    KEY idx_transactions_date (transaction_date, id),
+    KEY idx_transactions_amount (amount, id),
+    CONSTRAINT fk_transactions_account FOREIGN KEY (account_id) REFERENCES accounts (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE exchange_rates (
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate_date DATE NOT NULL,
+    rate DECIMAL(19, 8) NOT NULL,
+    PRIMARY KEY (base_currency, quote_currency, rate_date)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,3 @@
+ALTER TABLE transactions
+    DROP COLUMN status,
+    DROP COLUMN posted_date;
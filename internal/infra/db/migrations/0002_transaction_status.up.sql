@@ -0,0 +1,7 @@
+-- Transactions recorded before statuses existed were all booked already.
+
+ALTER TABLE transactions
+    ADD COLUMN posted_date DATE NULL AFTER transaction_date,
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'pending' AFTER posted_date;
+
+UPDATE transactions SET status = 'posted', posted_date = transaction_date;
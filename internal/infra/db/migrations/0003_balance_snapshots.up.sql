@@ -0,0 +1,16 @@
+-- Net change of each account's balance per calendar month, kept up to date
+-- as transactions are written so balances never need a full history scan.
+
+CREATE TABLE balance_snapshots (
+    account_id BIGINT UNSIGNED NOT NULL,
+    period_start DATE NOT NULL,
+    net_change DECIMAL(19, 4) NOT NULL,
+    PRIMARY KEY (account_id, period_start),
+    CONSTRAINT fk_balance_snapshots_account FOREIGN KEY (account_id) REFERENCES accounts (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+INSERT INTO balance_snapshots (account_id, period_start, net_change)
+SELECT account_id, DATE_FORMAT(transaction_date, '%Y-%m-01'), SUM(amount)
+FROM transactions
+WHERE status <> 'voided'
+GROUP BY account_id, DATE_FORMAT(transaction_date, '%Y-%m-01');
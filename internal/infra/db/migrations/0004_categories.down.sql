@@ -0,0 +1,6 @@
+ALTER TABLE transactions
+    DROP FOREIGN KEY fk_transactions_category,
+    DROP KEY idx_transactions_category,
+    DROP COLUMN category_id;
+
+DROP TABLE categories;
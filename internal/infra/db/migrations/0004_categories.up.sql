@@ -0,0 +1,15 @@
+-- Hierarchical spending categories, and the category of each transaction.
+
+CREATE TABLE categories (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    parent_id BIGINT UNSIGNED NULL,
+    PRIMARY KEY (id),
+    KEY idx_categories_parent (parent_id),
+    CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+ALTER TABLE transactions
+    ADD COLUMN category_id BIGINT UNSIGNED NULL AFTER description,
+    ADD KEY idx_transactions_category (category_id, transaction_date),
+    ADD CONSTRAINT fk_transactions_category FOREIGN KEY (category_id) REFERENCES categories (id);
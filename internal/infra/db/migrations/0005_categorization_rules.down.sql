@@ -0,0 +1,4 @@
+ALTER TABLE transactions
+    DROP COLUMN payee;
+
+DROP TABLE categorization_rules;
@@ -0,0 +1,23 @@
+-- Rules that categorise transactions automatically, and the normalised payee
+-- they can set on a transaction.
+
+CREATE TABLE categorization_rules (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    account_id BIGINT UNSIGNED NULL,
+    transaction_type VARCHAR(32) NULL,
+    description_contains VARCHAR(255) NULL,
+    description_pattern VARCHAR(255) NULL,
+    min_amount DECIMAL(19, 4) NULL,
+    max_amount DECIMAL(19, 4) NULL,
+    category_id BIGINT UNSIGNED NULL,
+    payee VARCHAR(255) NULL,
+    PRIMARY KEY (id),
+    KEY idx_categorization_rules_priority (priority, id),
+    CONSTRAINT fk_categorization_rules_account FOREIGN KEY (account_id) REFERENCES accounts (id),
+    CONSTRAINT fk_categorization_rules_category FOREIGN KEY (category_id) REFERENCES categories (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+ALTER TABLE transactions
+    ADD COLUMN payee VARCHAR(255) NULL AFTER category_id;
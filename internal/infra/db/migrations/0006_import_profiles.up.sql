@@ -0,0 +1,16 @@
+-- Column mappings for importing CSV bank statements.
+
+CREATE TABLE import_profiles (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    delimiter CHAR(1) NOT NULL DEFAULT ',',
+    skip_rows INT NOT NULL DEFAULT 0,
+    date_column INT NOT NULL,
+    date_format VARCHAR(32) NOT NULL,
+    description_column INT NULL,
+    amount_column INT NULL,
+    debit_column INT NULL,
+    credit_column INT NULL,
+    decimal_separator CHAR(1) NOT NULL DEFAULT '.',
+    PRIMARY KEY (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,3 @@
+ALTER TABLE transactions
+    DROP KEY uq_transactions_external_id,
+    DROP COLUMN external_id;
@@ -0,0 +1,7 @@
+-- The bank's identifier for transactions imported from OFX and QIF
+-- statements. It is unique per account so re-importing a statement cannot
+-- record the same line twice; hand-entered transactions leave it NULL.
+
+ALTER TABLE transactions
+    ADD COLUMN external_id VARCHAR(255) NULL AFTER payee,
+    ADD UNIQUE KEY uq_transactions_external_id (account_id, external_id);
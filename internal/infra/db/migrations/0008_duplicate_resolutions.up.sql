@@ -0,0 +1,16 @@
+-- The audit trail of flagged duplicate transactions: which one was kept, and
+-- whether the other was voided by a merge or the pair was dismissed. A
+-- resolved pair is not flagged again.
+
+CREATE TABLE duplicate_resolutions (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    action VARCHAR(32) NOT NULL,
+    kept_transaction_id BIGINT UNSIGNED NOT NULL,
+    duplicate_transaction_id BIGINT UNSIGNED NOT NULL,
+    resolved_at DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    KEY idx_duplicate_resolutions_pair (kept_transaction_id, duplicate_transaction_id),
+    KEY idx_duplicate_resolutions_resolved_at (resolved_at, id),
+    CONSTRAINT fk_duplicate_resolutions_kept FOREIGN KEY (kept_transaction_id) REFERENCES transactions (id),
+    CONSTRAINT fk_duplicate_resolutions_duplicate FOREIGN KEY (duplicate_transaction_id) REFERENCES transactions (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,18 @@
+-- Budgets cap the spending that matches them over each monthly or custom
+-- period. Unset filters are NULL and match any transaction.
+
+CREATE TABLE budgets (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    amount DECIMAL(19, 4) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    period VARCHAR(16) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NULL,
+    account_id BIGINT UNSIGNED NULL,
+    transaction_type VARCHAR(32) NULL,
+    description_pattern VARCHAR(255) NULL,
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_budgets_account FOREIGN KEY (account_id) REFERENCES accounts (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+    DROP FOREIGN KEY fk_transactions_transfer,
+    DROP COLUMN transfer_id;
+
+DROP TABLE transfers;
@@ -0,0 +1,17 @@
+-- A transfer moves money between two accounts as a pair of transactions, one
+-- leaving the source account and one arriving in the destination. Both legs
+-- point at their transfer so voiding one can void the other.
+
+CREATE TABLE transfers (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    from_account_id BIGINT UNSIGNED NOT NULL,
+    to_account_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_transfers_from_account FOREIGN KEY (from_account_id) REFERENCES accounts (id),
+    CONSTRAINT fk_transfers_to_account FOREIGN KEY (to_account_id) REFERENCES accounts (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+ALTER TABLE transactions
+    ADD COLUMN transfer_id BIGINT UNSIGNED NULL AFTER external_id,
+    ADD CONSTRAINT fk_transactions_transfer FOREIGN KEY (transfer_id) REFERENCES transfers (id);
@@ -0,0 +1,16 @@
+-- Idempotency keys let clients retry create requests safely. The first
+-- request with a key claims it; its response is stored once it finishes and
+-- replayed to retries until the key expires. response_status is NULL while
+-- the first request is still being handled.
+
+CREATE TABLE idempotency_keys (
+    idempotency_key VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    response_status SMALLINT NULL,
+    response_content_type VARCHAR(255) NULL,
+    response_body MEDIUMBLOB NULL,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    PRIMARY KEY (idempotency_key),
+    KEY idx_idempotency_keys_expires_at (expires_at)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
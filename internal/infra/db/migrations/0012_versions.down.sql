@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+    DROP COLUMN version;
+
+ALTER TABLE accounts
+    DROP COLUMN version;
@@ -0,0 +1,9 @@
+-- Accounts and transactions carry a version that goes up with every change,
+-- so an update can be made conditional on nobody else having changed the row
+-- since it was read.
+
+ALTER TABLE accounts
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
+
+ALTER TABLE transactions
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
@@ -0,0 +1,17 @@
+-- API keys authenticate callers of the API. Only the SHA-256 of a key is
+-- stored; prefix is the random part of the key that is not secret, used to
+-- find it. A key stops working once revoked_at is set. created_by is the
+-- subject of whoever created the key, NULL if it was created from the
+-- command line.
+
+CREATE TABLE api_keys (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    prefix CHAR(12) NOT NULL,
+    secret_hash CHAR(64) NOT NULL,
+    created_by VARCHAR(255) NULL,
+    created_at DATETIME NOT NULL,
+    revoked_at DATETIME NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_api_keys_prefix (prefix)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,54 @@
+DELETE FROM idempotency_keys;
+
+ALTER TABLE idempotency_keys
+    DROP FOREIGN KEY fk_idempotency_keys_tenant,
+    DROP PRIMARY KEY,
+    DROP COLUMN tenant_id,
+    ADD PRIMARY KEY (idempotency_key);
+
+ALTER TABLE api_keys
+    DROP FOREIGN KEY fk_api_keys_user,
+    DROP FOREIGN KEY fk_api_keys_tenant,
+    DROP COLUMN user_id,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE budgets
+    DROP FOREIGN KEY fk_budgets_tenant,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE duplicate_resolutions
+    DROP FOREIGN KEY fk_duplicate_resolutions_tenant,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE import_profiles
+    DROP FOREIGN KEY fk_import_profiles_tenant,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE categorization_rules
+    DROP FOREIGN KEY fk_categorization_rules_tenant,
+    DROP KEY idx_categorization_rules_tenant_priority,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE categories
+    DROP FOREIGN KEY fk_categories_tenant,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE transfers
+    DROP FOREIGN KEY fk_transfers_tenant,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE balance_snapshots
+    DROP FOREIGN KEY fk_balance_snapshots_tenant,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE transactions
+    DROP FOREIGN KEY fk_transactions_tenant,
+    DROP KEY idx_transactions_tenant_date,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE accounts
+    DROP FOREIGN KEY fk_accounts_tenant,
+    DROP COLUMN tenant_id;
+
+DROP TABLE users;
+DROP TABLE tenants;
@@ -0,0 +1,101 @@
+-- Tenants are the households or teams that own data, and users are their
+-- members. Every other table gains the tenant_id of the tenant its rows
+-- belong to. Rows written before tenants existed are given to a "Default"
+-- tenant, which is only created if there are any, and API keys to its user
+-- "migrated". Stored idempotency keys are short-lived and simply dropped.
+
+CREATE TABLE tenants (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE users (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    tenant_id BIGINT UNSIGNED NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_users_subject (subject),
+    CONSTRAINT fk_users_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+INSERT INTO tenants (id, name, created_at)
+SELECT 1, 'Default', UTC_TIMESTAMP() FROM DUAL
+WHERE EXISTS (SELECT 1 FROM accounts) OR EXISTS (SELECT 1 FROM categories)
+    OR EXISTS (SELECT 1 FROM categorization_rules) OR EXISTS (SELECT 1 FROM import_profiles)
+    OR EXISTS (SELECT 1 FROM budgets) OR EXISTS (SELECT 1 FROM api_keys);
+
+INSERT INTO users (tenant_id, subject, name, created_at)
+SELECT 1, 'migrated', 'Migrated API keys', UTC_TIMESTAMP() FROM DUAL
+WHERE EXISTS (SELECT 1 FROM api_keys);
+
+ALTER TABLE accounts
+    ADD COLUMN tenant_id BIGINT UNSIGNED NOT NULL DEFAULT 1 AFTER id,
+    ADD CONSTRAINT fk_accounts_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id);
+
+ALTER TABLE transactions
+    ADD COLUMN tenant_id BIGINT UNSIGNED NOT NULL DEFAULT 1 AFTER id,
+    ADD KEY idx_transactions_tenant_date (tenant_id, transaction_date, id),
+    ADD CONSTRAINT fk_transactions_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id);
+
+ALTER TABLE balance_snapshots
+    ADD COLUMN tenant_id BIGINT UNSIGNED NOT NULL DEFAULT 1 FIRST,
+    ADD CONSTRAINT fk_balance_snapshots_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id);
+
+ALTER TABLE transfers
+    ADD COLUMN tenant_id BIGINT UNSIGNED NOT NULL DEFAULT 1 AFTER id,
+    ADD CONSTRAINT fk_transfers_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id);
+
+ALTER TABLE categories
+    ADD COLUMN tenant_id BIGINT UNSIGNED NOT NULL DEFAULT 1 AFTER id,
+    ADD CONSTRAINT fk_categories_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id);
+
+ALTER TABLE categorization_rules
+    ADD COLUMN tenant_id BIGINT UNSIGNED NOT NULL DEFAULT 1 AFTER id,
+    ADD KEY idx_categorization_rules_tenant_priority (tenant_id, priority, id),
+    ADD CONSTRAINT fk_categorization_rules_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id);
+
+ALTER TABLE import_profiles
+    ADD COLUMN tenant_id BIGINT UNSIGNED NOT NULL DEFAULT 1 AFTER id,
+    ADD CONSTRAINT fk_import_profiles_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id);
+
+ALTER TABLE duplicate_resolutions
+    ADD COLUMN tenant_id BIGINT UNSIGNED NOT NULL DEFAULT 1 AFTER id,
+    ADD CONSTRAINT fk_duplicate_resolutions_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id);
+
+ALTER TABLE budgets
+    ADD COLUMN tenant_id BIGINT UNSIGNED NOT NULL DEFAULT 1 AFTER id,
+    ADD CONSTRAINT fk_budgets_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id);
+
+ALTER TABLE api_keys
+    ADD COLUMN tenant_id BIGINT UNSIGNED NOT NULL DEFAULT 1 AFTER id,
+    ADD COLUMN user_id BIGINT UNSIGNED NULL AFTER tenant_id;
+
+UPDATE api_keys SET user_id = (SELECT id FROM users WHERE subject = 'migrated');
+
+ALTER TABLE api_keys
+    MODIFY COLUMN user_id BIGINT UNSIGNED NOT NULL,
+    ADD CONSTRAINT fk_api_keys_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id),
+    ADD CONSTRAINT fk_api_keys_user FOREIGN KEY (user_id) REFERENCES users (id);
+
+DELETE FROM idempotency_keys;
+
+ALTER TABLE idempotency_keys
+    ADD COLUMN tenant_id BIGINT UNSIGNED NOT NULL FIRST,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (tenant_id, idempotency_key),
+    ADD CONSTRAINT fk_idempotency_keys_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id);
+
+ALTER TABLE accounts ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE transactions ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE balance_snapshots ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE transfers ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE categories ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE categorization_rules ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE import_profiles ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE duplicate_resolutions ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE budgets ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE api_keys ALTER COLUMN tenant_id DROP DEFAULT;
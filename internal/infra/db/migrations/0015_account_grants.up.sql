@@ -0,0 +1,23 @@
+-- Account grants give users of a tenant a role on one of its accounts:
+-- owner, editor or viewer. Before roles existed every user could do
+-- anything with every account of their tenant, so every existing user is
+-- made an owner of each of their tenant's accounts.
+
+CREATE TABLE account_grants (
+    tenant_id BIGINT UNSIGNED NOT NULL,
+    account_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    granted_by VARCHAR(255) NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (account_id, user_id),
+    KEY idx_account_grants_tenant_user (tenant_id, user_id),
+    CONSTRAINT fk_account_grants_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id),
+    CONSTRAINT fk_account_grants_account FOREIGN KEY (account_id) REFERENCES accounts (id),
+    CONSTRAINT fk_account_grants_user FOREIGN KEY (user_id) REFERENCES users (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+INSERT INTO account_grants (tenant_id, account_id, user_id, role, granted_by, created_at)
+SELECT a.tenant_id, a.id, u.id, 'owner', NULL, UTC_TIMESTAMP()
+FROM accounts a
+JOIN users u ON u.tenant_id = a.tenant_id;
@@ -0,0 +1,19 @@
+-- Rates of different tenants for the same pair and day collapse into one;
+-- the rate of the tenant with the lowest ID is kept.
+
+RENAME TABLE exchange_rates TO tenant_exchange_rates;
+
+CREATE TABLE exchange_rates (
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate_date DATE NOT NULL,
+    rate DECIMAL(19, 8) NOT NULL,
+    PRIMARY KEY (base_currency, quote_currency, rate_date)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+INSERT IGNORE INTO exchange_rates (base_currency, quote_currency, rate_date, rate)
+SELECT base_currency, quote_currency, rate_date, rate
+FROM tenant_exchange_rates
+ORDER BY tenant_id;
+
+DROP TABLE tenant_exchange_rates;
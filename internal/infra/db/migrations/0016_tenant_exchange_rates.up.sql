@@ -0,0 +1,22 @@
+-- Exchange rates belong to a tenant, so one tenant's imports never replace
+-- the rates another tenant converts with. Every tenant starts with a copy of
+-- the rates that were shared until now.
+
+RENAME TABLE exchange_rates TO shared_exchange_rates;
+
+CREATE TABLE exchange_rates (
+    tenant_id BIGINT UNSIGNED NOT NULL,
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate_date DATE NOT NULL,
+    rate DECIMAL(19, 8) NOT NULL,
+    PRIMARY KEY (tenant_id, base_currency, quote_currency, rate_date),
+    CONSTRAINT fk_exchange_rates_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+INSERT INTO exchange_rates (tenant_id, base_currency, quote_currency, rate_date, rate)
+SELECT t.id, r.base_currency, r.quote_currency, r.rate_date, r.rate
+FROM shared_exchange_rates r
+CROSS JOIN tenants t;
+
+DROP TABLE shared_exchange_rates;
@@ -0,0 +1,9 @@
+-- Only a tenant's administrators may add users to it. The first user of
+-- each tenant, the one it was created with, becomes its administrator.
+
+ALTER TABLE users
+    ADD COLUMN admin BOOLEAN NOT NULL DEFAULT FALSE AFTER name;
+
+UPDATE users u
+JOIN (SELECT MIN(id) AS id FROM users GROUP BY tenant_id) first_users ON first_users.id = u.id
+SET u.admin = TRUE;
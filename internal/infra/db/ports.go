@@ -1,9 +1,46 @@
 package db
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+)
 
-// Executor abstracts the database operations needed for saving accounts
+// Executor abstracts the database operations needed by the persistence adapters
 type Executor interface {
 	Exec(query string, args ...interface{}) (sql.Result, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (Rows, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (Rows, error)
+	QueryRow(query string, args ...interface{}) Row
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) Row
 	Close() error
 }
+
+// Row is the result of a query that is expected to return at most one row
+type Row interface {
+	Scan(dest ...interface{}) error
+}
+
+// Rows is the result of a query returning any number of rows
+type Rows interface {
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+	Close() error
+}
+
+// Tx is an Executor bound to a single SQL transaction
+type Tx interface {
+	Executor
+	Commit() error
+	Rollback() error
+}
+
+// UnitOfWork runs several database operations atomically
+type UnitOfWork interface {
+	// Begin starts a new SQL transaction and returns an Executor bound to it
+	Begin(ctx context.Context) (Tx, error)
+	// WithinTransaction runs fn inside a SQL transaction carried by the context
+	// passed to fn, committing if fn succeeds and rolling back otherwise
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+)
+
+// ScanFunc scans the current row of a result into a value of type T
+type ScanFunc[T any] func(row Row) (T, error)
+
+// QueryAll runs the query and scans every returned row with scan
+func QueryAll[T any](ctx context.Context, e Executor, scan ScanFunc[T], query string, args ...interface{}) ([]T, error) {
+	rows, err := e.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []T{}
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		result = append(result, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return result, nil
+}
+
+// QueryEach runs the query and hands each returned row, scanned with scan, to
+// each as soon as it is read, so results too large to hold in memory can be
+// streamed. It stops at the first error each returns.
+func QueryEach[T any](ctx context.Context, e Executor, scan ScanFunc[T], each func(T) error, query string, args ...interface{}) error {
+	rows, err := e.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := each(item); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return nil
+}
+
+// QueryOne runs a query expected to return a single row and scans it with scan.
+// sql.ErrNoRows is passed through so callers can map it to a domain error.
+func QueryOne[T any](ctx context.Context, e Executor, scan ScanFunc[T], query string, args ...interface{}) (T, error) {
+	return scan(e.QueryRowContext(ctx, query, args...))
+}
+
+// duplicateEntry is the MariaDB error number for a unique key violation
+const duplicateEntry = 1062
+
+// IsDuplicateKey reports whether err is a unique key violation
+func IsDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntry
+}
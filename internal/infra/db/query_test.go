@@ -0,0 +1,192 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAccount struct {
+	ID   string
+	Name string
+}
+
+func scanTestAccount(row Row) (testAccount, error) {
+	var account testAccount
+	err := row.Scan(&account.ID, &account.Name)
+	return account, err
+}
+
+func TestQueryAll_Success(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Account1").AddRow(2, "Account2"))
+
+	executor := &MariaDbExecutor{mockDB}
+
+	result, err := QueryAll(context.Background(), executor, scanTestAccount, "SELECT id, name FROM accounts")
+	assert.NoError(t, err)
+	assert.Equal(t, []testAccount{{"1", "Account1"}, {"2", "Account2"}}, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryAll_Empty(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	executor := &MariaDbExecutor{mockDB}
+
+	result, err := QueryAll(context.Background(), executor, scanTestAccount, "SELECT id, name FROM accounts")
+	assert.NoError(t, err)
+	assert.NotNil(t, result, "Expected an empty, non-nil slice")
+	assert.Empty(t, result)
+}
+
+func TestQueryAll_QueryFailure(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM accounts").WillReturnError(sql.ErrConnDone)
+
+	executor := &MariaDbExecutor{mockDB}
+
+	_, err = QueryAll(context.Background(), executor, scanTestAccount, "SELECT id, name FROM accounts")
+	assert.True(t, errors.Is(err, sql.ErrConnDone), "Expected error to be sql.ErrConnDone")
+}
+
+func TestQueryAll_RowError(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Account1").RowError(0, sql.ErrConnDone))
+
+	executor := &MariaDbExecutor{mockDB}
+
+	_, err = QueryAll(context.Background(), executor, scanTestAccount, "SELECT id, name FROM accounts")
+	assert.True(t, errors.Is(err, sql.ErrConnDone), "Expected error to be sql.ErrConnDone")
+}
+
+func TestQueryEach_Success(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Account1").AddRow(2, "Account2"))
+
+	executor := &MariaDbExecutor{mockDB}
+
+	var seen []testAccount
+	err = QueryEach(context.Background(), executor, scanTestAccount, func(account testAccount) error {
+		seen = append(seen, account)
+		return nil
+	}, "SELECT id, name FROM accounts")
+	assert.NoError(t, err)
+	assert.Equal(t, []testAccount{{"1", "Account1"}, {"2", "Account2"}}, seen)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryEach_StopsAtCallbackError(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Account1").AddRow(2, "Account2"))
+
+	executor := &MariaDbExecutor{mockDB}
+
+	calls := 0
+	stop := errors.New("client went away")
+	err = QueryEach(context.Background(), executor, scanTestAccount, func(account testAccount) error {
+		calls++
+		return stop
+	}, "SELECT id, name FROM accounts")
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestQueryEach_RowError(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Account1").RowError(0, sql.ErrConnDone))
+
+	executor := &MariaDbExecutor{mockDB}
+
+	err = QueryEach(context.Background(), executor, scanTestAccount, func(testAccount) error { return nil }, "SELECT id, name FROM accounts")
+	assert.True(t, errors.Is(err, sql.ErrConnDone), "Expected error to be sql.ErrConnDone")
+}
+
+func TestQueryOne_Success(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM accounts WHERE id").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Account1"))
+
+	executor := &MariaDbExecutor{mockDB}
+
+	account, err := QueryOne(context.Background(), executor, scanTestAccount, "SELECT id, name FROM accounts WHERE id = ?", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, testAccount{"1", "Account1"}, account)
+}
+
+func TestQueryOne_NoRows(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM accounts WHERE id").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	executor := &MariaDbExecutor{mockDB}
+
+	_, err = QueryOne(context.Background(), executor, scanTestAccount, "SELECT id, name FROM accounts WHERE id = ?", "1")
+	assert.True(t, errors.Is(err, sql.ErrNoRows), "Expected error to be sql.ErrNoRows")
+}
+
+func TestMariaDbExecutor_ExecContext_Cancelled(t *testing.T) {
+	mockDB, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	executor := &MariaDbExecutor{mockDB}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = executor.ExecContext(ctx, "INSERT INTO accounts (name) VALUES (?)", "Account1")
+	assert.True(t, errors.Is(err, context.Canceled), "Expected error to be context.Canceled")
+}
+
+// Test unique key violations are recognised, however they are wrapped
+func TestIsDuplicateKey(t *testing.T) {
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+
+	assert.True(t, IsDuplicateKey(duplicate))
+	assert.True(t, IsDuplicateKey(fmt.Errorf("failed: %w", duplicate)))
+	assert.False(t, IsDuplicateKey(&mysql.MySQLError{Number: 1452}))
+	assert.False(t, IsDuplicateKey(errors.New("database down")))
+}
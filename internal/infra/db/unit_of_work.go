@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// txKey is the context key under which WithinTransaction stores the active transaction
+type txKey struct{}
+
+// MariaDbTx is an Executor bound to a single MariaDB transaction
+type MariaDbTx struct {
+	tx *sql.Tx
+}
+
+// Begin starts a new transaction
+func (e *MariaDbExecutor) Begin(ctx context.Context) (Tx, error) {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	return &MariaDbTx{tx: tx}, nil
+}
+
+// WithinTransaction runs fn inside a transaction. The transaction travels in
+// the context handed to fn, so every Executor call made with that context
+// joins it. Nested calls reuse the enclosing transaction.
+func (e *MariaDbExecutor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := e.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// txFromContext returns the transaction carried by ctx, if any
+func txFromContext(ctx context.Context) *MariaDbTx {
+	tx, _ := ctx.Value(txKey{}).(*MariaDbTx)
+	return tx
+}
+
+// Exec executes a query inside the transaction
+func (t *MariaDbTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return t.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext executes a query inside the transaction, honouring ctx cancellation
+func (t *MariaDbTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return execContext(ctx, t.tx, query, args...)
+}
+
+// Query executes a query that returns rows inside the transaction
+func (t *MariaDbTx) Query(query string, args ...interface{}) (Rows, error) {
+	return t.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext executes a query that returns rows inside the transaction, honouring ctx cancellation
+func (t *MariaDbTx) QueryContext(ctx context.Context, query string, args ...interface{}) (Rows, error) {
+	return queryContext(ctx, t.tx, query, args...)
+}
+
+// QueryRow executes a query expected to return at most one row inside the transaction
+func (t *MariaDbTx) QueryRow(query string, args ...interface{}) Row {
+	return t.QueryRowContext(context.Background(), query, args...)
+}
+
+// QueryRowContext executes a query expected to return at most one row inside the transaction, honouring ctx cancellation
+func (t *MariaDbTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) Row {
+	return t.tx.QueryRowContext(ctx, query, args...)
+}
+
+// Commit commits the transaction
+func (t *MariaDbTx) Commit() error {
+	if err := t.tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Rollback aborts the transaction
+func (t *MariaDbTx) Rollback() error {
+	if err := t.tx.Rollback(); err != nil {
+		return fmt.Errorf("failed to roll back transaction: %w", err)
+	}
+	return nil
+}
+
+// Close rolls the transaction back unless it has already been committed or rolled back
+func (t *MariaDbTx) Close() error {
+	err := t.tx.Rollback()
+	if err != nil && !errors.Is(err, sql.ErrTxDone) {
+		return fmt.Errorf("failed to roll back transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMariaDbExecutor_WithinTransaction_Commit(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO accounts").WithArgs("Account1").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO transactions").WithArgs("1").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	executor := &MariaDbExecutor{mockDB}
+
+	err = executor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if _, err := executor.ExecContext(ctx, "INSERT INTO accounts (name) VALUES (?)", "Account1"); err != nil {
+			return err
+		}
+		_, err := executor.ExecContext(ctx, "INSERT INTO transactions (account_id) VALUES (?)", "1")
+		return err
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMariaDbExecutor_WithinTransaction_RollbackOnError(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO accounts").WithArgs("Account1").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectRollback()
+
+	executor := &MariaDbExecutor{mockDB}
+	failure := errors.New("opening balance rejected")
+
+	err = executor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if _, err := executor.ExecContext(ctx, "INSERT INTO accounts (name) VALUES (?)", "Account1"); err != nil {
+			return err
+		}
+		return failure
+	})
+
+	assert.True(t, errors.Is(err, failure), "Expected the error returned by fn")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMariaDbExecutor_WithinTransaction_RollbackOnPanic(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	executor := &MariaDbExecutor{mockDB}
+
+	assert.Panics(t, func() {
+		_ = executor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			panic("boom")
+		})
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMariaDbExecutor_WithinTransaction_Nested(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO accounts").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	executor := &MariaDbExecutor{mockDB}
+
+	err = executor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		return executor.WithinTransaction(ctx, func(ctx context.Context) error {
+			_, err := executor.ExecContext(ctx, "INSERT INTO accounts (name) VALUES (?)", "Account1")
+			return err
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMariaDbExecutor_WithinTransaction_BeginFailure(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectBegin().WillReturnError(errors.New("too many connections"))
+
+	executor := &MariaDbExecutor{mockDB}
+	called := false
+
+	err = executor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	assert.EqualError(t, err, "failed to begin transaction: too many connections")
+	assert.False(t, called, "fn should not run when the transaction cannot be started")
+}
+
+func TestMariaDbExecutor_Begin(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE accounts").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT name FROM accounts").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Account1"))
+	mock.ExpectRollback()
+
+	executor := &MariaDbExecutor{mockDB}
+
+	tx, err := executor.Begin(context.Background())
+	assert.NoError(t, err)
+
+	_, err = tx.Exec("UPDATE accounts SET name = ? WHERE id = ?", "Account1", "1")
+	assert.NoError(t, err)
+
+	var name string
+	assert.NoError(t, tx.QueryRow("SELECT name FROM accounts WHERE id = ?", "1").Scan(&name))
+	assert.Equal(t, "Account1", name)
+
+	assert.NoError(t, tx.Rollback())
+	assert.NoError(t, tx.Close(), "Closing a finished transaction should be a no-op")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}